@@ -0,0 +1,40 @@
+package agent
+
+import (
+	"fmt"
+	"path"
+)
+
+// ContainerWorkDir is where an agent's worktree is mounted in its container.
+// Relative container paths given to CopyTo and CopyFrom resolve against it.
+const ContainerWorkDir = "/workspace"
+
+// CopyTo copies srcHost, a file or directory, into the agent's container at
+// dstContainer. The container may be running or stopped.
+func (m *Manager) CopyTo(name, srcHost, dstContainer string) error {
+	agent, err := m.state.GetAgent(name)
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrAgentNotFound, name)
+	}
+	return m.docker.CopyToContainer(agent.ContainerID, srcHost, containerPath(dstContainer))
+}
+
+// CopyFrom copies srcContainer, a file or directory in the agent's
+// container, to dstHost.
+func (m *Manager) CopyFrom(name, srcContainer, dstHost string) error {
+	agent, err := m.state.GetAgent(name)
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrAgentNotFound, name)
+	}
+	return m.docker.CopyFromContainer(agent.ContainerID, containerPath(srcContainer), dstHost)
+}
+
+// containerPath resolves a container path against ContainerWorkDir. The
+// path isn't cleaned, since docker cp gives a trailing "/." meaning: copy a
+// directory's contents rather than the directory itself.
+func containerPath(p string) string {
+	if path.IsAbs(p) {
+		return p
+	}
+	return ContainerWorkDir + "/" + p
+}
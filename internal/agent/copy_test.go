@@ -0,0 +1,49 @@
+package agent
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestManager_CopyToAndFrom(t *testing.T) {
+	var gotContainer, gotSrc, gotDst string
+	docker := &mockDockerManager{
+		copyToContainerFn: func(containerID, src, dst string) error {
+			gotContainer, gotSrc, gotDst = containerID, src, dst
+			return nil
+		},
+		copyFromContainerFn: func(containerID, src, dst string) error {
+			gotContainer, gotSrc, gotDst = containerID, src, dst
+			return nil
+		},
+	}
+	state := newMockStateManager()
+	state.agents["auth-1"] = &Agent{Name: "auth-1", ContainerID: "abc123def456789"}
+	manager, _ := NewManager(testConfig(), &mockGitManager{}, docker, state, &mockExecutor{})
+
+	tests := []struct {
+		name    string
+		copy    func() error
+		wantSrc string
+		wantDst string
+	}{
+		{"to, relative", func() error { return manager.CopyTo("auth-1", "gen/config.json", "config/app.json") }, "gen/config.json", "/workspace/config/app.json"},
+		{"to, absolute", func() error { return manager.CopyTo("auth-1", "tools", "/opt/tools") }, "tools", "/opt/tools"},
+		{"from, directory contents", func() error { return manager.CopyFrom("auth-1", "coverage/.", "out") }, "/workspace/coverage/.", "out"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.copy(); err != nil {
+				t.Fatalf("copy error = %v", err)
+			}
+			if gotContainer != "abc123def456789" || gotSrc != tt.wantSrc || gotDst != tt.wantDst {
+				t.Errorf("copied %s: %q -> %q, want %q -> %q", gotContainer, gotSrc, gotDst, tt.wantSrc, tt.wantDst)
+			}
+		})
+	}
+
+	if err := manager.CopyTo("missing", "a", "b"); !errors.Is(err, ErrAgentNotFound) {
+		t.Errorf("CopyTo() error = %v, want ErrAgentNotFound", err)
+	}
+}
@@ -0,0 +1,65 @@
+package agent
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/bkonkle/tanuki/internal/docker"
+	"github.com/bkonkle/tanuki/internal/task"
+)
+
+// missingFilesScript prints each glob argument that matches nothing in the
+// worktree. IFS is cleared so a pattern is globbed but never split on spaces.
+const missingFilesScript = `cd "` + ContainerWorkDir + `" || exit 1
+IFS=
+for p do
+	ls -d -- $p >/dev/null 2>&1 || printf '%s\n' "$p"
+done`
+
+// MissingFiles returns the globs in patterns that match no files in the
+// agent's worktree, in the order given. Globs use shell syntax and are
+// relative to the worktree.
+func (m *Manager) MissingFiles(name string, patterns []string) ([]string, error) {
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+
+	agent, err := m.state.GetAgent(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrAgentNotFound, name)
+	}
+
+	var out bytes.Buffer
+	opts := docker.ExecOptions{Stdout: &out, Stderr: &out}
+	cmd := append([]string{"sh", "-c", missingFilesScript, "sh"}, patterns...)
+	code, err := m.docker.ExecCommand(agent.ContainerID, cmd, opts)
+	if err != nil {
+		return nil, fmt.Errorf("check expected files: %w", err)
+	}
+	if code != 0 {
+		return nil, fmt.Errorf("check expected files: exit status %d: %s", code, strings.TrimSpace(out.String()))
+	}
+
+	var missing []string
+	for _, line := range strings.Split(out.String(), "\n") {
+		if line != "" {
+			missing = append(missing, line)
+		}
+	}
+	return missing, nil
+}
+
+// CheckExpects checks that a run on the agent produced the files in t's
+// expects list. The error wraps task.ErrExpectedFilesMissing and lists the
+// globs that matched nothing.
+func (m *Manager) CheckExpects(name string, t *task.Task) error {
+	missing, err := m.MissingFiles(name, t.Expects)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", task.ErrExpectedFilesMissing, strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/bkonkle/tanuki/internal/task"
+)
+
+// errGroupCancelled is returned by executeTask when the run was stopped
+// because another member of the task's group failed.
+var errGroupCancelled = errors.New("another task in its group failed")
+
+// failGroup stops the rest of a failed task's group, as the project
+// orchestrator does: members running on other runners are cancelled, and
+// members not yet started are set aside under the GroupFailure policy.
+// Members already running to completion are left to finish.
+func (r *WorkstreamRunner) failGroup(t *task.Task) {
+	if t.Group == "" {
+		return
+	}
+
+	log.Printf("Task %s failed, stopping the rest of group %s", t.ID, t.Group)
+
+	for _, member := range r.taskMgr.GetByGroup(t.Group) {
+		if member.ID == t.ID {
+			continue
+		}
+
+		switch member.Status {
+		case task.StatusComplete:
+			log.Printf("Warning: task %s in group %s already completed; its changes are not reverted", member.ID, t.Group)
+
+		case task.StatusFailed:
+			// Already failed on its own
+
+		case task.StatusPending, task.StatusBlocked:
+			r.setAsideGroupMember(member.ID, t)
+
+		default:
+			if r.orch == nil || !r.orch.cancelGroupMember(member.ID, t) {
+				log.Printf("Warning: task %s in group %s is still running and will finish", member.ID, t.Group)
+			}
+		}
+	}
+}
+
+// setAsideGroupMember leaves a member of a failed group unassigned in the
+// status the GroupFailure policy calls for. Blocked members keep a reason,
+// so runners skip them until `tanuki task unblock`.
+func (r *WorkstreamRunner) setAsideGroupMember(taskID string, failed *task.Task) {
+	if err := r.taskMgr.Unassign(taskID); err != nil {
+		log.Printf("Warning: failed to unassign task %s: %v", taskID, err)
+	}
+
+	if r.config.GroupFailure == "fail" {
+		if err := r.taskMgr.UpdateStatus(taskID, task.StatusFailed); err != nil {
+			log.Printf("Warning: failed to mark task %s failed: %v", taskID, err)
+		}
+		return
+	}
+
+	reason := fmt.Sprintf("group %s failed: %s failed", failed.Group, failed.ID)
+	if err := r.taskMgr.Block(taskID, reason); err != nil {
+		log.Printf("Warning: failed to mark task %s blocked: %v", taskID, err)
+	}
+}
+
+// cancelForGroup stops the runner's agent if it is running taskID, so the
+// run ends and the runner sets the task aside because failed broke its
+// group. Returns false if the runner isn't running it.
+func (r *WorkstreamRunner) cancelForGroup(taskID string, failed *task.Task) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.current != taskID {
+		return false
+	}
+	if r.groupFailedBy != nil {
+		return true
+	}
+	r.groupFailedBy = failed
+
+	log.Printf("Cancelling task %s on %s", taskID, r.agentName)
+	// Stop while holding the lock, so the runner can't restart the agent
+	// before the stop has finished
+	if err := r.agentMgr.Stop(r.agentName); err != nil {
+		log.Printf("Warning: failed to stop agent %s: %v", r.agentName, err)
+	}
+	return true
+}
+
+// startTask records taskID as the runner's running task.
+func (r *WorkstreamRunner) startTask(taskID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.current = taskID
+	r.groupFailedBy = nil
+}
+
+// finishTask clears the running task. If it was cancelled because its group
+// failed, it returns the task that failed.
+func (r *WorkstreamRunner) finishTask() (groupFailedBy *task.Task) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.current = ""
+	return r.groupFailedBy
+}
+
+// cancelledForGroup brings back the agent stopped by cancelForGroup and
+// sets the task aside.
+func (r *WorkstreamRunner) cancelledForGroup(taskID string, failed *task.Task) error {
+	if err := r.agentMgr.Start(r.agentName); err != nil {
+		log.Printf("Warning: failed to restart agent %s: %v", r.agentName, err)
+	}
+	r.setAsideGroupMember(taskID, failed)
+	return errGroupCancelled
+}
+
+// cancelGroupMember cancels taskID on the runner running it, because failed
+// broke its group. Returns false if no runner is running it.
+func (o *WorkstreamOrchestrator) cancelGroupMember(taskID string, failed *task.Task) bool {
+	o.mu.Lock()
+	runners := append([]*WorkstreamRunner(nil), o.runners...)
+	o.mu.Unlock()
+
+	for _, r := range runners {
+		if r.cancelForGroup(taskID, failed) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bkonkle/tanuki/internal/state"
+)
+
+// ErrAgentNotHeld indicates Release was called on an agent that isn't held.
+var ErrAgentNotHeld = errors.New("agent is not held")
+
+// Hold stops an agent's container without removing it and marks the agent
+// StatusHeld, recording the task that failed on it. A held agent isn't idle,
+// so the orchestrator won't give it more work, and its container keeps the
+// failed run's files and logs until Release.
+func (m *Manager) Hold(name, taskID string) error {
+	agent, err := m.state.GetAgent(name)
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrAgentNotFound, name)
+	}
+
+	if err := m.docker.StopContainer(agent.ContainerID); err != nil {
+		return fmt.Errorf("failed to stop container: %w", err)
+	}
+
+	return m.state.UpdateAgent(name, func(a *Agent) error {
+		a.Status = state.StatusHeld
+		a.HeldFor = taskID
+		return nil
+	})
+}
+
+// Release restarts a held agent's container and returns the agent to the
+// pool as idle.
+func (m *Manager) Release(name string) error {
+	agent, err := m.state.GetAgent(name)
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrAgentNotFound, name)
+	}
+	if agent.Status != state.StatusHeld {
+		return fmt.Errorf("%w: %q is %s", ErrAgentNotHeld, name, agent.Status)
+	}
+	return m.Start(name)
+}
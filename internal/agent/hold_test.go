@@ -0,0 +1,59 @@
+package agent
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestManager_HoldAndRelease(t *testing.T) {
+	var stopped, started string
+	docker := &mockDockerManager{
+		stopContainerFn: func(id string) error {
+			stopped = id
+			return nil
+		},
+		startContainerFn: func(id string) error {
+			started = id
+			return nil
+		},
+	}
+	state := newMockStateManager()
+	state.agents["auth-1"] = &Agent{Name: "auth-1", ContainerID: "abc123def456789", Status: "working"}
+	manager, _ := NewManager(testConfig(), &mockGitManager{}, docker, state, &mockExecutor{})
+
+	if err := manager.Release("auth-1"); !errors.Is(err, ErrAgentNotHeld) {
+		t.Errorf("Release() of a working agent error = %v, want ErrAgentNotHeld", err)
+	}
+
+	if err := manager.Hold("auth-1", "TASK-001"); err != nil {
+		t.Fatalf("Hold() error = %v", err)
+	}
+	if stopped != "abc123def456789" {
+		t.Errorf("stopped container = %q, want the agent's container stopped", stopped)
+	}
+	if ag := state.agents["auth-1"]; ag.Status != "failed-held" || ag.HeldFor != "TASK-001" {
+		t.Errorf("agent = %s held for %q, want failed-held for TASK-001", ag.Status, ag.HeldFor)
+	}
+
+	status, err := manager.Status("auth-1")
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if status.HeldFor != "TASK-001" {
+		t.Errorf("Status().HeldFor = %q, want TASK-001", status.HeldFor)
+	}
+
+	if err := manager.Release("auth-1"); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	if started != "abc123def456789" {
+		t.Errorf("started container = %q, want the agent's container restarted", started)
+	}
+	if ag := state.agents["auth-1"]; ag.Status != "idle" || ag.HeldFor != "" {
+		t.Errorf("agent = %s held for %q, want idle and no longer held", ag.Status, ag.HeldFor)
+	}
+
+	if err := manager.Hold("missing", "TASK-001"); !errors.Is(err, ErrAgentNotFound) {
+		t.Errorf("Hold() error = %v, want ErrAgentNotFound", err)
+	}
+}
@@ -0,0 +1,147 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidLabel indicates a label isn't a valid key=value pair.
+var ErrInvalidLabel = errors.New("invalid label")
+
+// labelKeyPattern allows keys like "feature", "team.name", or "org/area".
+var labelKeyPattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._/-]*[A-Za-z0-9])?$`)
+
+// labelValuePattern allows empty values and the same characters as keys.
+var labelValuePattern = regexp.MustCompile(`^[A-Za-z0-9._/-]*$`)
+
+// ParseLabels parses key=value pairs, as given to --label, into a map.
+// A key given more than once keeps its last value.
+func ParseLabels(pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+
+	labels := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w %q: want key=value", ErrInvalidLabel, pair)
+		}
+		if err := validateLabel(key, value); err != nil {
+			return nil, err
+		}
+		labels[key] = value
+	}
+	return labels, nil
+}
+
+// validateLabel checks a label key and value.
+func validateLabel(key, value string) error {
+	if !labelKeyPattern.MatchString(key) {
+		return fmt.Errorf("%w key %q: use letters, numbers, '.', '_', '-' or '/'", ErrInvalidLabel, key)
+	}
+	if !labelValuePattern.MatchString(value) {
+		return fmt.Errorf("%w value %q: use letters, numbers, '.', '_', '-' or '/'", ErrInvalidLabel, value)
+	}
+	return nil
+}
+
+// MatchesLabels reports whether the agent has every label in selector.
+// An empty selector matches every agent.
+func MatchesLabels(ag *Agent, selector map[string]string) bool {
+	for key, want := range selector {
+		got, ok := ag.Labels[key]
+		if !ok || got != want {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterByLabels returns the agents that have every label in selector.
+func FilterByLabels(agents []*Agent, selector map[string]string) []*Agent {
+	matched := make([]*Agent, 0, len(agents))
+	for _, ag := range agents {
+		if MatchesLabels(ag, selector) {
+			matched = append(matched, ag)
+		}
+	}
+	return matched
+}
+
+// FormatLabels renders labels as sorted key=value pairs separated by commas.
+func FormatLabels(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = key + "=" + labels[key]
+	}
+	return strings.Join(pairs, ",")
+}
+
+// SetNotes replaces the agent's notes, then saves the agent. Empty notes
+// clear them.
+func (m *Manager) SetNotes(name, notes string) (*Agent, error) {
+	return m.updateAgent(name, func(agent *Agent) error {
+		agent.Notes = strings.TrimSpace(notes)
+		return nil
+	})
+}
+
+// SetLabels adds or updates the labels in set and deletes the keys in remove,
+// then saves the agent.
+func (m *Manager) SetLabels(name string, set map[string]string, remove []string) (*Agent, error) {
+	for key, value := range set {
+		if err := validateLabel(key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return m.updateAgent(name, func(agent *Agent) error {
+		for key, value := range set {
+			if agent.Labels == nil {
+				agent.Labels = make(map[string]string)
+			}
+			agent.Labels[key] = value
+		}
+		for _, key := range remove {
+			delete(agent.Labels, key)
+		}
+		if len(agent.Labels) == 0 {
+			agent.Labels = nil
+		}
+		return nil
+	})
+}
+
+// updateAgent applies fn to the agent's current state and saves it, returning
+// the updated agent.
+func (m *Manager) updateAgent(name string, fn func(*Agent) error) (*Agent, error) {
+	if _, err := m.state.GetAgent(name); err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrAgentNotFound, name)
+	}
+
+	var updated *Agent
+	err := m.state.UpdateAgent(name, func(agent *Agent) error {
+		if err := fn(agent); err != nil {
+			return err
+		}
+		updated = agent
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save state: %w", err)
+	}
+
+	// A copy, so later updates to the state don't change it
+	agent := *updated
+	return &agent, nil
+}
@@ -0,0 +1,166 @@
+package agent
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		pairs   []string
+		want    map[string]string
+		wantErr bool
+	}{
+		{"none", nil, nil, false},
+		{"single", []string{"feature=auth"}, map[string]string{"feature": "auth"}, false},
+		{"several", []string{"feature=auth", "team/area=id"}, map[string]string{"feature": "auth", "team/area": "id"}, false},
+		{"empty value", []string{"draft="}, map[string]string{"draft": ""}, false},
+		{"last wins", []string{"feature=auth", "feature=billing"}, map[string]string{"feature": "billing"}, false},
+		{"missing equals", []string{"feature"}, nil, true},
+		{"empty key", []string{"=auth"}, nil, true},
+		{"space in value", []string{"feature=user auth"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLabels(tt.pairs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLabels() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidLabel) {
+				t.Errorf("ParseLabels() error = %v, want ErrInvalidLabel", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseLabels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterByLabels(t *testing.T) {
+	agents := []*Agent{
+		{Name: "auth-1", Labels: map[string]string{"feature": "auth", "team": "id"}},
+		{Name: "auth-2", Labels: map[string]string{"feature": "auth"}},
+		{Name: "billing", Labels: map[string]string{"feature": "billing"}},
+		{Name: "plain"},
+	}
+
+	tests := []struct {
+		name     string
+		selector map[string]string
+		want     []string
+	}{
+		{"empty selector", nil, []string{"auth-1", "auth-2", "billing", "plain"}},
+		{"one label", map[string]string{"feature": "auth"}, []string{"auth-1", "auth-2"}},
+		{"all labels must match", map[string]string{"feature": "auth", "team": "id"}, []string{"auth-1"}},
+		{"no match", map[string]string{"feature": "search"}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, ag := range FilterByLabels(agents, tt.selector) {
+				got = append(got, ag.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FilterByLabels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatLabels(t *testing.T) {
+	got := FormatLabels(map[string]string{"team": "id", "feature": "auth"})
+	if got != "feature=auth,team=id" {
+		t.Errorf("FormatLabels() = %q, want sorted key=value pairs", got)
+	}
+	if got := FormatLabels(nil); got != "" {
+		t.Errorf("FormatLabels(nil) = %q, want empty", got)
+	}
+}
+
+func TestManager_SetLabels(t *testing.T) {
+	state := newMockStateManager()
+	state.agents["auth-1"] = &Agent{Name: "auth-1", Labels: map[string]string{"feature": "auth", "team": "id"}}
+	manager, _ := NewManager(testConfig(), &mockGitManager{}, &mockDockerManager{}, state, &mockExecutor{})
+
+	ag, err := manager.SetLabels("auth-1", map[string]string{"feature": "login", "area": "web"}, []string{"team"})
+	if err != nil {
+		t.Fatalf("SetLabels() error = %v", err)
+	}
+	want := map[string]string{"feature": "login", "area": "web"}
+	if !reflect.DeepEqual(ag.Labels, want) {
+		t.Errorf("Labels = %v, want %v", ag.Labels, want)
+	}
+
+	ag, err = manager.SetLabels("auth-1", nil, []string{"feature", "area"})
+	if err != nil {
+		t.Fatalf("SetLabels() error = %v", err)
+	}
+	if ag.Labels != nil {
+		t.Errorf("Labels = %v, want nil once every label is removed", ag.Labels)
+	}
+
+	if _, err := manager.SetLabels("auth-1", map[string]string{"bad key": "x"}, nil); !errors.Is(err, ErrInvalidLabel) {
+		t.Errorf("SetLabels() error = %v, want ErrInvalidLabel", err)
+	}
+	if _, err := manager.SetLabels("missing", nil, nil); !errors.Is(err, ErrAgentNotFound) {
+		t.Errorf("SetLabels() error = %v, want ErrAgentNotFound", err)
+	}
+}
+
+func TestManager_SetNotes(t *testing.T) {
+	state := newMockStateManager()
+	state.agents["auth-1"] = &Agent{Name: "auth-1", ContainerID: "abc123def456789"}
+	manager, _ := NewManager(testConfig(), &mockGitManager{}, &mockDockerManager{}, state, &mockExecutor{})
+
+	ag, err := manager.SetNotes("auth-1", "  working on OAuth refactor\n")
+	if err != nil {
+		t.Fatalf("SetNotes() error = %v", err)
+	}
+	if ag.Notes != "working on OAuth refactor" || state.agents["auth-1"].Notes != ag.Notes {
+		t.Errorf("Notes = %q, want the trimmed notes saved", ag.Notes)
+	}
+
+	status, err := manager.Status("auth-1")
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if status.Notes != "working on OAuth refactor" {
+		t.Errorf("Status().Notes = %q, want the saved notes", status.Notes)
+	}
+
+	if ag, _ := manager.SetNotes("auth-1", ""); ag.Notes != "" {
+		t.Errorf("Notes = %q, want cleared", ag.Notes)
+	}
+	if _, err := manager.SetNotes("missing", "x"); !errors.Is(err, ErrAgentNotFound) {
+		t.Errorf("SetNotes() error = %v, want ErrAgentNotFound", err)
+	}
+}
+
+func TestSpawn_Labels(t *testing.T) {
+	manager, _ := NewManager(testConfig(), &mockGitManager{}, &mockDockerManager{}, newMockStateManager(), &mockExecutor{})
+
+	labels := map[string]string{"feature": "auth"}
+	ag, err := manager.Spawn("auth-1", SpawnOptions{Labels: labels})
+	if err != nil {
+		t.Fatalf("Spawn failed: %v", err)
+	}
+	if !reflect.DeepEqual(ag.Labels, labels) {
+		t.Errorf("Labels = %v, want %v", ag.Labels, labels)
+	}
+
+	clone, err := manager.Clone("auth-1", "auth-2")
+	if err != nil {
+		t.Fatalf("Clone failed: %v", err)
+	}
+	if !reflect.DeepEqual(clone.Labels, labels) {
+		t.Errorf("clone Labels = %v, want %v", clone.Labels, labels)
+	}
+
+	if _, err := manager.Spawn("auth-3", SpawnOptions{Labels: map[string]string{"feature": "a b"}}); !errors.Is(err, ErrInvalidLabel) {
+		t.Errorf("Spawn() error = %v, want ErrInvalidLabel", err)
+	}
+}
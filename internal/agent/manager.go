@@ -8,7 +8,9 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/bkonkle/tanuki/internal/config"
@@ -16,6 +18,7 @@ import (
 	"github.com/bkonkle/tanuki/internal/docker"
 	"github.com/bkonkle/tanuki/internal/executor"
 	"github.com/bkonkle/tanuki/internal/state"
+	"github.com/bkonkle/tanuki/internal/task"
 )
 
 var (
@@ -25,11 +28,33 @@ var (
 	// ErrAgentNotFound indicates no agent with the given name exists.
 	ErrAgentNotFound = errors.New("agent not found")
 
-	// ErrAgentWorking indicates the agent is currently working and cannot be removed.
+	// ErrAgentWorking indicates the agent is currently working and cannot be removed or restarted.
 	ErrAgentWorking = errors.New("agent is currently working")
 
+	// ErrAgentBusy indicates the agent is already running a task and cannot accept another.
+	ErrAgentBusy = errors.New("agent is already working on a task")
+
 	// ErrInvalidName indicates the agent name doesn't meet requirements.
 	ErrInvalidName = errors.New("invalid agent name")
+
+	// ErrInvalidResources indicates a spawn resource override isn't a valid Docker limit.
+	ErrInvalidResources = errors.New("invalid resource limit")
+
+	// ErrAgentNotReady indicates Claude Code never became usable in the agent's container.
+	ErrAgentNotReady = errors.New("agent container did not become ready")
+
+	// ErrBranchCheckedOut indicates the branch an agent would use is already
+	// checked out in another worktree or the main working tree.
+	ErrBranchCheckedOut = errors.New("branch is already checked out")
+)
+
+const (
+	// DefaultReadyTimeout bounds how long Spawn and Start wait for Claude Code
+	// to become usable in the container.
+	DefaultReadyTimeout = 60 * time.Second
+
+	// DefaultReadyInterval is how often the readiness check is retried.
+	DefaultReadyInterval = 500 * time.Millisecond
 )
 
 // validNamePattern enforces agent naming rules: start with lowercase letter,
@@ -44,13 +69,17 @@ type TaskInfo = state.TaskInfo
 
 // Status provides detailed status information about an agent.
 type Status struct {
-	Name      string
-	Status    string
-	Branch    string
-	Container ContainerStatus
-	Git       GitStatus
-	LastTask  *TaskInfo
-	Uptime    time.Duration
+	Name       string
+	Status     string
+	Branch     string
+	Container  ContainerStatus
+	Git        GitStatus
+	LastTask   *TaskInfo
+	Uptime     time.Duration
+	Labels     map[string]string
+	Notes      string
+	HeldFor    string
+	PinnedTask string
 }
 
 // ContainerStatus contains Docker container status information.
@@ -67,14 +96,30 @@ type GitStatus struct {
 	CommitsBehind int
 	CommitsAhead  int
 	HasChanges    bool
+	DiskUsage     int64 // Worktree size in bytes, excluding .git
 }
 
 // SpawnOptions configures agent creation.
 type SpawnOptions struct {
-	// Branch specifies an existing branch to use (optional)
+	// Branch is an existing branch to check out in the agent's worktree
+	// instead of creating a new <prefix><name> branch (optional)
 	Branch string
 	// Workstream specifies the workstream to assign to the agent (optional)
 	Workstream string
+	// Memory overrides the configured container memory limit, in Docker
+	// syntax such as "8g" (optional)
+	Memory string
+	// CPUs overrides the configured container CPU limit, such as "4" (optional)
+	CPUs string
+	// Labels are key=value tags stored on the agent for grouping and
+	// selection (optional)
+	Labels map[string]string
+	// Network overrides the Docker network, including the workstream's
+	// network setting; docker.NoNetwork disables networking (optional)
+	Network string
+	// PinnedTask reserves the agent for one task, which the orchestrator
+	// assigns only to it (optional)
+	PinnedTask string
 }
 
 // RemoveOptions configures agent removal.
@@ -97,24 +142,47 @@ type RunOptions struct {
 	MaxTurns int
 	// Model overrides the default Claude model
 	Model string
-	// SystemPrompt adds additional system instructions
+	// SystemPrompt adds run-specific system instructions, after the global
+	// and workstream system prompts
 	SystemPrompt string
 	// Output writer for streaming (defaults to os.Stdout)
 	Output io.Writer
+	// LogFilePath is the file Output writes to, if any, recorded in the
+	// agent's LastTask so the full log can be found later
+	LogFilePath string
+	// OnCheckpoint is called for each checkpoint the agent reports
+	OnCheckpoint func(checkpoint string)
+	// OnProgress is called when the agent reports a new progress
+	// percentage, matched by the configured progress_pattern
+	OnProgress func(percent int)
+	// CompletionSignal is the task's completion signal, checked in the
+	// run's output once it finishes. See ApplyTaskSignal.
+	CompletionSignal string
+	// TaskID is the task being run, recorded in the agent's LastTask
+	TaskID string
+	// ResumeSessionID continues an earlier Claude session instead of
+	// starting a new one. See RetrySession.
+	ResumeSessionID string
 }
 
 // GitManager defines the interface for Git worktree operations.
 type GitManager interface {
 	CreateWorktree(name string) (string, error)
+	CreateWorktreeForBranch(name string, branch string) (string, error)
 	RemoveWorktree(name string, deleteBranch bool) error
 	GetDiff(name string, baseBranch string) (string, error)
+	CommitsAhead(name string, baseBranch string) (int, error)
+	Push(name string) error
 	GetStatus(name string) (string, error)
 	GetCurrentBranch() (string, error)
 	GetMainBranch() (string, error)
 	WorktreeExists(name string) bool
 	BranchExists(name string) bool
+	BranchCheckedOut(branch string) (string, error)
 	GetWorktreePath(name string) string
 	GetBranchName(name string) string
+	WorktreeDiskUsage(name string) (int64, error)
+	WorktreeDir() string
 }
 
 // DockerManager defines the interface for Docker container operations.
@@ -130,6 +198,9 @@ type DockerManager interface {
 	ContainerRunning(containerID string) bool
 	InspectContainer(containerID string) (*ContainerInfo, error)
 	ExecWithOutput(containerID string, cmd []string) (string, error)
+	ExecCommand(containerID string, command []string, opts docker.ExecOptions) (int, error)
+	CopyToContainer(containerID, srcHost, dstContainer string) error
+	CopyFromContainer(containerID, srcContainer, dstHost string) error
 	GetResourceUsage(containerID string) (*ResourceUsage, error)
 }
 
@@ -159,6 +230,7 @@ type StateManager interface {
 	Save(state *State) error
 	GetAgent(name string) (*Agent, error)
 	SetAgent(agent *Agent) error
+	UpdateAgent(name string, fn func(*Agent) error) error
 	RemoveAgent(name string) error
 	ListAgents() ([]*Agent, error)
 }
@@ -190,6 +262,14 @@ type Manager struct {
 	executor          ClaudeExecutor
 	workstreamManager WorkstreamManager
 	serviceInjector   ServiceInjector
+
+	// runMu makes the busy check and the transition to working atomic
+	runMu sync.Mutex
+
+	// readyTimeout and readyInterval bound the readiness check run before an
+	// agent is marked idle
+	readyTimeout  time.Duration
+	readyInterval time.Duration
 }
 
 // NewManager creates a new agent manager.
@@ -223,9 +303,42 @@ func NewManager(cfg *config.Config, git GitManager, docker DockerManager, state
 		executor:          executor,
 		workstreamManager: nil, // Will be set via SetWorkstreamManager
 		serviceInjector:   nil, // Will be set via SetServiceInjector
+		readyTimeout:      DefaultReadyTimeout,
+		readyInterval:     DefaultReadyInterval,
 	}, nil
 }
 
+// SetReadinessProbe sets how long Spawn and Start wait for Claude Code to
+// become usable in a container, and how often they check. Non-positive
+// values keep the current setting.
+func (m *Manager) SetReadinessProbe(timeout, interval time.Duration) {
+	if timeout > 0 {
+		m.readyTimeout = timeout
+	}
+	if interval > 0 {
+		m.readyInterval = interval
+	}
+}
+
+// waitUntilReady polls the executor's container check until it passes or
+// the readiness timeout elapses. A freshly set up container can take a
+// moment before the claude binary is usable.
+func (m *Manager) waitUntilReady(containerID string) error {
+	deadline := time.Now().Add(m.readyTimeout)
+	for {
+		err := m.executor.CheckContainer(containerID)
+		if err == nil {
+			return nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return fmt.Errorf("%w after %s: %v", ErrAgentNotReady, m.readyTimeout, err)
+		}
+		time.Sleep(min(m.readyInterval, remaining))
+	}
+}
+
 // SetWorkstreamManager sets the workstream manager for this agent manager.
 // This is optional and allows workstream-based agent spawning.
 func (m *Manager) SetWorkstreamManager(workstreamManager WorkstreamManager) {
@@ -241,18 +354,50 @@ func (m *Manager) SetServiceInjector(injector ServiceInjector) {
 // Spawn creates a new agent with an isolated worktree and container.
 // This operation is atomic - if any step fails, all created resources are cleaned up.
 func (m *Manager) Spawn(name string, opts SpawnOptions) (*Agent, error) {
-	// 1. Validate name
+	// 1. Validate name and resource overrides
 	if err := validateAgentName(name); err != nil {
 		return nil, fmt.Errorf("%w: %v", ErrInvalidName, err)
 	}
 
+	if err := opts.ValidateResources(); err != nil {
+		return nil, err
+	}
+	for key, value := range opts.Labels {
+		if err := validateLabel(key, value); err != nil {
+			return nil, err
+		}
+	}
+
 	// 2. Check if agent already exists
 	if _, err := m.state.GetAgent(name); err == nil {
 		return nil, fmt.Errorf("%w: %q", ErrAgentExists, name)
 	}
 
-	// 3. Create worktree
-	worktreePath, err := m.git.CreateWorktree(name)
+	network := m.spawnNetwork(opts)
+	if network != "" && network != docker.NoNetwork {
+		if err := m.docker.EnsureNetwork(network); err != nil {
+			return nil, fmt.Errorf("failed to ensure Docker network %q: %w", network, err)
+		}
+	}
+
+	// 3. Create worktree, on a new branch or the requested existing one
+	branch := opts.Branch
+	if branch == "" {
+		branch = m.git.GetBranchName(name)
+	}
+	if location, err := m.git.BranchCheckedOut(branch); err != nil {
+		return nil, fmt.Errorf("failed to check branch %q: %w", branch, err)
+	} else if location != "" {
+		return nil, fmt.Errorf("%w: %q is checked out at %s; switch that worktree to another branch or remove it first", ErrBranchCheckedOut, branch, location)
+	}
+
+	var worktreePath string
+	var err error
+	if opts.Branch != "" {
+		worktreePath, err = m.git.CreateWorktreeForBranch(name, opts.Branch)
+	} else {
+		worktreePath, err = m.git.CreateWorktree(name)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to create worktree: %w", err)
 	}
@@ -309,7 +454,12 @@ func (m *Manager) Spawn(name string, opts SpawnOptions) (*Agent, error) {
 
 	// 6. Create container with service injection (rollback worktree on failure)
 	containerOpts := docker.AgentContainerOptions{
-		ServiceEnv: serviceEnv,
+		ServiceEnv:      serviceEnv,
+		SSHKeyPath:      m.config.Git.SSHKeyPath,
+		ForwardSSHAgent: m.config.Git.ForwardSSHAgent,
+		Memory:          opts.Memory,
+		CPUs:            opts.CPUs,
+		Network:         network,
 	}
 	containerID, err := m.docker.CreateAgentContainerWithOptions(name, worktreePath, containerOpts)
 	if err != nil {
@@ -332,7 +482,15 @@ func (m *Manager) Spawn(name string, opts SpawnOptions) (*Agent, error) {
 		return nil, fmt.Errorf("failed to setup container: %w", err)
 	}
 
-	// 9. Create state entry
+	// 9. Wait for Claude Code to be usable before the agent can take work
+	if err := m.waitUntilReady(containerID); err != nil {
+		_ = m.docker.StopContainer(containerID)   // Rollback
+		_ = m.docker.RemoveContainer(containerID) // Rollback
+		_ = m.git.RemoveWorktree(name, true)      // Rollback
+		return nil, err
+	}
+
+	// 10. Create state entry
 	agent := &Agent{
 		Name:          name,
 		ContainerID:   containerID,
@@ -340,9 +498,16 @@ func (m *Manager) Spawn(name string, opts SpawnOptions) (*Agent, error) {
 		Branch:        m.git.GetBranchName(name),
 		WorktreePath:  worktreePath,
 		Status:        state.StatusIdle,
+		PinnedTask:    opts.PinnedTask,
 		CreatedAt:     time.Now(),
 		UpdatedAt:     time.Now(),
 	}
+	if len(opts.Labels) > 0 {
+		agent.Labels = make(map[string]string, len(opts.Labels))
+		for key, value := range opts.Labels {
+			agent.Labels[key] = value
+		}
+	}
 
 	// Store workstream information if workstream was assigned
 	if wsInfo != nil {
@@ -362,6 +527,56 @@ func (m *Manager) Spawn(name string, opts SpawnOptions) (*Agent, error) {
 	return agent, nil
 }
 
+// spawnNetwork returns the Docker network for a new agent: the one in opts,
+// else its workstream's, else "" for the configured default.
+func (m *Manager) spawnNetwork(opts SpawnOptions) string {
+	if opts.Network != "" {
+		return opts.Network
+	}
+	if ws := m.config.GetWorkstreamConfig(opts.Workstream); ws != nil {
+		return ws.Network
+	}
+	return ""
+}
+
+// Clone creates a new agent with the same workstream, tool configuration, and
+// labels as an existing one. The clone gets a fresh worktree and container;
+// the source agent's worktree contents are not copied.
+func (m *Manager) Clone(srcName, newName string) (*Agent, error) {
+	if err := validateAgentName(newName); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidName, err)
+	}
+
+	src, err := m.state.GetAgent(srcName)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrAgentNotFound, srcName)
+	}
+
+	// Only route through the workstream manager when one is configured, so
+	// workstream agents can still be cloned from a plain CLI session.
+	opts := SpawnOptions{Labels: src.Labels}
+	if src.Workstream != "" && m.workstreamManager != nil {
+		opts.Workstream = src.Workstream
+	}
+
+	agent, err := m.Spawn(newName, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	agent.Workstream = src.Workstream
+	agent.AllowedTools = append([]string(nil), src.AllowedTools...)
+	agent.DisallowedTools = append([]string(nil), src.DisallowedTools...)
+	agent.UpdatedAt = time.Now()
+
+	if err := m.state.SetAgent(agent); err != nil {
+		_ = m.Remove(newName, RemoveOptions{Force: true}) // Rollback
+		return nil, fmt.Errorf("failed to save state: %w", err)
+	}
+
+	return agent, nil
+}
+
 // Remove deletes an agent and cleans up all associated resources.
 // By default, this fails if the agent is currently working unless Force is true.
 func (m *Manager) Remove(name string, opts RemoveOptions) error {
@@ -402,12 +617,13 @@ func (m *Manager) Stop(name string) error {
 	}
 
 	// Update state
-	agent.Status = state.StatusStopped
-	agent.UpdatedAt = time.Now()
-	return m.state.SetAgent(agent)
+	return m.state.UpdateAgent(name, func(a *Agent) error {
+		a.Status = state.StatusStopped
+		return nil
+	})
 }
 
-// Start starts a stopped agent's container.
+// Start starts a stopped agent's container and waits for it to be ready.
 func (m *Manager) Start(name string) error {
 	agent, err := m.state.GetAgent(name)
 	if err != nil {
@@ -418,10 +634,38 @@ func (m *Manager) Start(name string) error {
 		return fmt.Errorf("failed to start container: %w", err)
 	}
 
+	if err := m.waitUntilReady(agent.ContainerID); err != nil {
+		_ = m.docker.StopContainer(agent.ContainerID) // Leave it stopped rather than half started
+		return err
+	}
+
 	// Update state
-	agent.Status = state.StatusIdle
-	agent.UpdatedAt = time.Now()
-	return m.state.SetAgent(agent)
+	return m.state.UpdateAgent(name, func(a *Agent) error {
+		a.Status = state.StatusIdle
+		a.HeldFor = ""
+		return nil
+	})
+}
+
+// Restart stops and starts an agent's container, keeping its worktree and
+// state. It refuses an agent that is working, since stopping the container
+// would kill its task. Like Start, it leaves the agent idle.
+func (m *Manager) Restart(name string) error {
+	agent, err := m.state.GetAgent(name)
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrAgentNotFound, name)
+	}
+
+	if agent.Status == state.StatusWorking {
+		return fmt.Errorf("%w: wait for its task to finish before restarting", ErrAgentWorking)
+	}
+
+	if agent.Status != state.StatusStopped {
+		if err := m.Stop(name); err != nil {
+			return err
+		}
+	}
+	return m.Start(name)
 }
 
 // Get returns information about a specific agent.
@@ -451,11 +695,15 @@ func (m *Manager) Status(name string) (*Status, error) {
 	}
 
 	status := &Status{
-		Name:     agent.Name,
-		Status:   string(agent.Status),
-		Branch:   agent.Branch,
-		LastTask: agent.LastTask,
-		Uptime:   time.Since(agent.CreatedAt),
+		Name:       agent.Name,
+		Status:     string(agent.Status),
+		Branch:     agent.Branch,
+		LastTask:   agent.LastTask,
+		Uptime:     time.Since(agent.CreatedAt),
+		Labels:     agent.Labels,
+		Notes:      agent.Notes,
+		HeldFor:    agent.HeldFor,
+		PinnedTask: agent.PinnedTask,
 	}
 
 	// Get container status
@@ -478,18 +726,28 @@ func (m *Manager) Status(name string) (*Status, error) {
 		}
 	}
 
-	// Get git status
+	// Get git status, diffing against the repo's current branch if no
+	// default branch can be detected
 	mainBranch, err := m.git.GetMainBranch()
 	if err != nil {
-		mainBranch = "main" // Fallback
+		mainBranch, _ = m.git.GetCurrentBranch()
 	}
 
-	diff, _ := m.git.GetDiff(name, mainBranch)
+	var diff string
+	var ahead int
+	if mainBranch != "" {
+		diff, _ = m.git.GetDiff(name, mainBranch)
+		ahead, _ = m.git.CommitsAhead(name, mainBranch)
+	}
 	gitStatus, _ := m.git.GetStatus(name)
 
+	diskUsage, _ := m.git.WorktreeDiskUsage(name)
+
 	status.Git = GitStatus{
-		Branch:     agent.Branch,
-		HasChanges: len(diff) > 0 || len(gitStatus) > 0,
+		Branch:       agent.Branch,
+		HasChanges:   len(diff) > 0 || len(gitStatus) > 0,
+		CommitsAhead: ahead,
+		DiskUsage:    diskUsage,
 	}
 
 	return status, nil
@@ -505,7 +763,7 @@ func (m *Manager) Run(name string, prompt string, opts RunOptions) error {
 
 	// Check if agent is already working
 	if agent.Status == state.StatusWorking {
-		return errors.New("agent is already working on a task")
+		return fmt.Errorf("%w: %q", ErrAgentBusy, name)
 	}
 
 	// Check container is ready
@@ -513,36 +771,48 @@ func (m *Manager) Run(name string, prompt string, opts RunOptions) error {
 		return fmt.Errorf("container not ready: %w", err)
 	}
 
+	systemPrompt, err := m.systemPrompt(opts, agent)
+	if err != nil {
+		return err
+	}
+
 	// Build execute options
 	execOpts := executor.ExecuteOptions{
-		AllowedTools:    opts.AllowedTools,
-		DisallowedTools: opts.DisallowedTools,
 		MaxTurns:        opts.MaxTurns,
 		Model:           opts.Model,
-		SystemPrompt:    opts.SystemPrompt,
+		SystemPrompt:    systemPrompt,
 		WorkDir:         "/workspace",
+		OnCheckpoint:    opts.OnCheckpoint,
+		OnProgress:      opts.OnProgress,
+		ResumeSessionID: opts.ResumeSessionID,
 	}
 
-	// Apply defaults from config if not specified
-	if len(execOpts.AllowedTools) == 0 {
-		execOpts.AllowedTools = m.config.Defaults.AllowedTools
+	if opts.OnProgress != nil {
+		// Validated with the config, so an error only falls back to the default
+		execOpts.ProgressPattern, _ = executor.CompileProgressPattern(m.config.ProgressPattern)
 	}
+
+	execOpts.AllowedTools, execOpts.DisallowedTools = resolveTools(opts, agent, m.config.Defaults.AllowedTools)
+
+	// Apply defaults from config if not specified
 	if execOpts.MaxTurns == 0 {
 		execOpts.MaxTurns = m.config.Defaults.MaxTurns
 	}
 	if execOpts.Model == "" {
+		// Fallbacks only apply to the configured model, not an explicit choice
 		execOpts.Model = m.config.Defaults.Model
+		execOpts.ModelFallbacks = m.config.Defaults.ModelFallbacks
+	}
+	execOpts.MaxContinuations = m.config.Defaults.GetMaxContinuations()
+	execOpts.MaxOutputBytes = m.config.Defaults.GetMaxOutputBytes()
+	if opts.CompletionSignal != "" {
+		execOpts.Signals = []string{opts.CompletionSignal}
 	}
 
 	// Update state to working
-	agent.Status = state.StatusWorking
-	agent.UpdatedAt = time.Now()
-	agent.LastTask = &TaskInfo{
-		Prompt:    prompt,
-		StartedAt: time.Now(),
-	}
-	if err := m.state.SetAgent(agent); err != nil {
-		return fmt.Errorf("failed to update state: %w", err)
+	agent, err = m.claim(name, prompt, opts)
+	if err != nil {
+		return err
 	}
 
 	// Execute based on mode
@@ -562,23 +832,187 @@ func (m *Manager) Run(name string, prompt string, opts RunOptions) error {
 		result, execErr = m.executor.Run(agent.ContainerID, prompt, execOpts)
 	}
 
-	// Update state back to idle
-	agent.Status = state.StatusIdle
-	agent.UpdatedAt = time.Now()
+	// Update state back to idle. Reload rather than writing back the agent
+	// claimed above, so notes, labels, or a stop recorded during the run
+	// aren't lost.
+	err = m.state.UpdateAgent(name, func(a *Agent) error {
+		if a.Status == state.StatusWorking {
+			a.Status = state.StatusIdle
+		}
+		if result != nil && a.LastTask != nil {
+			completedAt := result.CompletedAt
+			a.LastTask.CompletedAt = &completedAt
+			a.LastTask.SessionID = result.SessionID
+			a.LastTask.Model = result.Model
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update state after execution: %w", err)
+	}
 
-	if result != nil {
-		completedAt := result.CompletedAt
-		agent.LastTask.CompletedAt = &completedAt
-		agent.LastTask.SessionID = result.SessionID
+	if execErr == nil && opts.CompletionSignal != "" {
+		execErr = m.checkSignal(result, opts.CompletionSignal, output)
 	}
 
-	if err := m.state.SetAgent(agent); err != nil {
-		return fmt.Errorf("failed to update state after execution: %w", err)
+	if execErr == nil {
+		m.autoPush(name, output)
 	}
 
 	return execErr
 }
 
+// RetrySession returns the Claude session a retry of t can resume on the
+// named agent: the session of the failed attempt, if that attempt ran on
+// this agent and was its last run. It returns "" otherwise, and the retry
+// starts a new session.
+func (m *Manager) RetrySession(name string, t *task.Task) string {
+	if t.RetryCount == 0 || t.FailedAgent != name {
+		return ""
+	}
+
+	agent, err := m.state.GetAgent(name)
+	if err != nil || agent.LastTask == nil || agent.LastTask.TaskID != t.ID {
+		return ""
+	}
+	return agent.LastTask.SessionID
+}
+
+// checkSignal reports a run that finished without printing signal. With
+// require_signal set it returns an error wrapping ErrNoCompletionSignal, so
+// callers can tell "ran but didn't signal done" from a failed run; otherwise
+// it only writes a warning.
+func (m *Manager) checkSignal(result *executor.ExecutionResult, signal string, output io.Writer) error {
+	if result != nil && (slices.Contains(result.SignalsSeen, signal) || strings.Contains(result.Output, signal)) {
+		return nil
+	}
+	if m.config.RequireSignal {
+		return fmt.Errorf("%w: %q", executor.ErrNoCompletionSignal, signal)
+	}
+	_, _ = fmt.Fprintf(output, "\nWarning: completion signal %q not found in output\n", signal)
+	return nil
+}
+
+// systemPrompt assembles the system prompt appended for a run. Segments go
+// from broadest to most specific, so later instructions can refine earlier
+// ones: the global system prompt, then the agent's workstream prompt, then
+// the run options' SystemPrompt (set by the caller for the task or CLI).
+func (m *Manager) systemPrompt(opts RunOptions, agent *Agent) (string, error) {
+	global, err := m.config.GetGlobalSystemPrompt()
+	if err != nil {
+		return "", err
+	}
+
+	var workstream string
+	if agent.Workstream != "" {
+		if workstream, err = m.config.GetWorkstreamConfig(agent.Workstream).GetSystemPrompt(); err != nil {
+			return "", fmt.Errorf("workstream %s: %w", agent.Workstream, err)
+		}
+	}
+
+	var segments []string
+	for _, s := range []string{global, workstream, opts.SystemPrompt} {
+		if s = strings.TrimSpace(s); s != "" {
+			segments = append(segments, s)
+		}
+	}
+	return strings.Join(segments, "\n\n"), nil
+}
+
+// resolveTools picks the tool lists for a run. Each list comes from the most
+// specific level that sets it: the run options (CLI flags, then task front
+// matter via ApplyTaskTools), then the agent's workstream, then the config
+// defaults. Tools allowed by the run options are dropped from disallowed
+// tools inherited from the workstream, so a task can grant a tool its
+// workstream denies.
+func resolveTools(opts RunOptions, agent *Agent, defaults []string) (allowed, disallowed []string) {
+	allowed = opts.AllowedTools
+	if len(allowed) == 0 {
+		allowed = agent.AllowedTools
+	}
+	if len(allowed) == 0 {
+		allowed = defaults
+	}
+
+	disallowed = opts.DisallowedTools
+	if len(disallowed) == 0 && len(agent.DisallowedTools) > 0 {
+		granted := make(map[string]bool, len(opts.AllowedTools))
+		for _, tool := range opts.AllowedTools {
+			granted[tool] = true
+		}
+		for _, tool := range agent.DisallowedTools {
+			if !granted[tool] {
+				disallowed = append(disallowed, tool)
+			}
+		}
+	}
+
+	return allowed, disallowed
+}
+
+// autoPush pushes the agent's branch when git.auto_push is enabled and the
+// branch has commits ahead of the base branch. Failures are logged rather
+// than returned, since the run itself succeeded.
+func (m *Manager) autoPush(name string, log io.Writer) {
+	if !m.config.Git.AutoPush {
+		return
+	}
+
+	baseBranch, err := m.git.GetMainBranch()
+	if err != nil {
+		_, _ = fmt.Fprintf(log, "Warning: skipping auto-push for %s: %v\n", name, err)
+		return
+	}
+
+	ahead, err := m.git.CommitsAhead(name, baseBranch)
+	if err != nil {
+		_, _ = fmt.Fprintf(log, "Warning: skipping auto-push for %s: %v\n", name, err)
+		return
+	}
+	if ahead == 0 {
+		return
+	}
+
+	if err := m.git.Push(name); err != nil {
+		_, _ = fmt.Fprintf(log, "Warning: auto-push failed for %s: %v\n", name, err)
+		return
+	}
+	_, _ = fmt.Fprintf(log, "Pushed %s (%d commits ahead of %s)\n", m.git.GetBranchName(name), ahead, baseBranch)
+}
+
+// claim atomically re-checks that the agent is not busy and marks it as working.
+// Concurrent Run calls for the same agent are serialized here so only one wins.
+func (m *Manager) claim(name string, prompt string, opts RunOptions) (*Agent, error) {
+	m.runMu.Lock()
+	defer m.runMu.Unlock()
+
+	var agent *Agent
+	err := m.state.UpdateAgent(name, func(a *Agent) error {
+		if a.Status == state.StatusWorking {
+			return fmt.Errorf("%w: %q", ErrAgentBusy, name)
+		}
+
+		a.Status = state.StatusWorking
+		a.LastTask = &TaskInfo{
+			Prompt:      prompt,
+			TaskID:      opts.TaskID,
+			StartedAt:   time.Now(),
+			LogFilePath: opts.LogFilePath,
+		}
+		agentCopy := *a
+		agent = &agentCopy
+		return nil
+	})
+	if errors.Is(err, ErrAgentBusy) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update state: %w", err)
+	}
+
+	return agent, nil
+}
+
 // IsWorking checks if an agent is currently executing a task.
 func (m *Manager) IsWorking(name string) (bool, error) {
 	agent, err := m.state.GetAgent(name)
@@ -625,6 +1059,26 @@ func (m *Manager) Reconcile() error {
 	return nil
 }
 
+// MovedWorktrees returns the agents whose recorded worktree isn't in the
+// configured worktree directory, as happens when git.worktree_dir changes
+// after they were spawned. They keep using the worktree they were created
+// with; only new agents get worktrees in the new directory.
+func (m *Manager) MovedWorktrees() ([]*Agent, error) {
+	agents, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Clean(m.git.WorktreeDir())
+	var moved []*Agent
+	for _, ag := range agents {
+		if ag.WorktreePath != "" && filepath.Dir(filepath.Clean(ag.WorktreePath)) != dir {
+			moved = append(moved, ag)
+		}
+	}
+	return moved, nil
+}
+
 // validateAgentName checks if an agent name meets requirements:
 // - At least 2 characters
 // - At most 63 characters (DNS label limit)
@@ -644,6 +1098,19 @@ func validateAgentName(name string) error {
 	return nil
 }
 
+// ValidateResources checks the memory and CPU overrides with the rules config
+// validation uses, so bad limits are caught before any worktree or container
+// is created.
+func (opts SpawnOptions) ValidateResources() error {
+	if opts.Memory != "" && !config.ValidMemoryLimit(opts.Memory) {
+		return fmt.Errorf("%w: memory must be a Docker memory limit like 512m or 4g (got %q)", ErrInvalidResources, opts.Memory)
+	}
+	if opts.CPUs != "" && !config.ValidCPULimit(opts.CPUs) {
+		return fmt.Errorf("%w: cpus must be a positive number like 2 or 0.5 (got %q)", ErrInvalidResources, opts.CPUs)
+	}
+	return nil
+}
+
 // generateClaudeMD creates a CLAUDE.md file in the worktree with workstream-specific instructions.
 func (m *Manager) generateClaudeMD(worktreePath string, wsInfo *WorkstreamInfo) error {
 	claudeMDPath := filepath.Join(worktreePath, "CLAUDE.md")
@@ -664,6 +1131,18 @@ func (m *Manager) generateClaudeMD(worktreePath string, wsInfo *WorkstreamInfo)
 		}
 	}
 
+	// Warn that paths excluded by .tanukiignore were withheld on purpose
+	if projectRoot, err := os.Getwd(); err == nil {
+		if ignore, err := context.LoadIgnoreFile(projectRoot); err == nil && len(ignore.Patterns()) > 0 {
+			content.WriteString("\n## Excluded Paths\n\n")
+			content.WriteString(fmt.Sprintf("The project's %s excludes these paths from your context. ", context.IgnoreFileName))
+			content.WriteString("Treat matching files as off-limits; do not read, copy, or print them:\n\n")
+			for _, pattern := range ignore.Patterns() {
+				content.WriteString(fmt.Sprintf("- `%s`\n", pattern))
+			}
+		}
+	}
+
 	// Add service documentation if available
 	if m.serviceInjector != nil {
 		serviceDocs := m.serviceInjector.GenerateDocumentation()
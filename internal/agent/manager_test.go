@@ -1,29 +1,49 @@
 package agent
 
 import (
+	"bytes"
 	"errors"
 	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/bkonkle/tanuki/internal/config"
 	"github.com/bkonkle/tanuki/internal/docker"
 	"github.com/bkonkle/tanuki/internal/executor"
+	"github.com/bkonkle/tanuki/internal/task"
 )
 
 // Mock implementations for testing
 
 type mockGitManager struct {
 	createWorktreeFn   func(name string) (string, error)
+	createForBranchFn  func(name string, branch string) (string, error)
 	removeWorktreeFn   func(name string, deleteBranch bool) error
 	getDiffFn          func(name string, baseBranch string) (string, error)
+	commitsAheadFn     func(name string, baseBranch string) (int, error)
+	pushFn             func(name string) error
 	getStatusFn        func(name string) (string, error)
 	getCurrentBranchFn func() (string, error)
 	getMainBranchFn    func() (string, error)
 	worktreeExistsFn   func(name string) bool
 	branchExistsFn     func(name string) bool
+	checkedOutFn       func(branch string) (string, error)
 	getWorktreePathFn  func(name string) string
 	getBranchNameFn    func(name string) string
+	diskUsageFn        func(name string) (int64, error)
+	worktreeDir        string
+}
+
+func (m *mockGitManager) WorktreeDir() string {
+	if m.worktreeDir != "" {
+		return m.worktreeDir
+	}
+	return "/test/worktree"
 }
 
 func (m *mockGitManager) CreateWorktree(name string) (string, error) {
@@ -33,6 +53,13 @@ func (m *mockGitManager) CreateWorktree(name string) (string, error) {
 	return "/test/worktree/" + name, nil
 }
 
+func (m *mockGitManager) CreateWorktreeForBranch(name string, branch string) (string, error) {
+	if m.createForBranchFn != nil {
+		return m.createForBranchFn(name, branch)
+	}
+	return "/test/worktree/" + name, nil
+}
+
 func (m *mockGitManager) RemoveWorktree(name string, deleteBranch bool) error {
 	if m.removeWorktreeFn != nil {
 		return m.removeWorktreeFn(name, deleteBranch)
@@ -47,6 +74,20 @@ func (m *mockGitManager) GetDiff(name string, baseBranch string) (string, error)
 	return "", nil
 }
 
+func (m *mockGitManager) CommitsAhead(name string, baseBranch string) (int, error) {
+	if m.commitsAheadFn != nil {
+		return m.commitsAheadFn(name, baseBranch)
+	}
+	return 0, nil
+}
+
+func (m *mockGitManager) Push(name string) error {
+	if m.pushFn != nil {
+		return m.pushFn(name)
+	}
+	return nil
+}
+
 func (m *mockGitManager) GetStatus(name string) (string, error) {
 	if m.getStatusFn != nil {
 		return m.getStatusFn(name)
@@ -82,6 +123,13 @@ func (m *mockGitManager) BranchExists(name string) bool {
 	return false
 }
 
+func (m *mockGitManager) BranchCheckedOut(branch string) (string, error) {
+	if m.checkedOutFn != nil {
+		return m.checkedOutFn(branch)
+	}
+	return "", nil
+}
+
 func (m *mockGitManager) GetWorktreePath(name string) string {
 	if m.getWorktreePathFn != nil {
 		return m.getWorktreePathFn(name)
@@ -96,6 +144,13 @@ func (m *mockGitManager) GetBranchName(name string) string {
 	return "tanuki/" + name
 }
 
+func (m *mockGitManager) WorktreeDiskUsage(name string) (int64, error) {
+	if m.diskUsageFn != nil {
+		return m.diskUsageFn(name)
+	}
+	return 0, nil
+}
+
 type mockDockerManager struct {
 	ensureNetworkFn                   func(name string) error
 	createAgentContainerFn            func(name string, worktreePath string) (string, error)
@@ -109,6 +164,9 @@ type mockDockerManager struct {
 	inspectContainerFn                func(containerID string) (*ContainerInfo, error)
 	execWithOutputFn                  func(containerID string, cmd []string) (string, error)
 	getResourceUsageFn                func(containerID string) (*ResourceUsage, error)
+	execCommandFn                     func(containerID string, command []string, opts docker.ExecOptions) (int, error)
+	copyToContainerFn                 func(containerID, srcHost, dstContainer string) error
+	copyFromContainerFn               func(containerID, srcContainer, dstHost string) error
 }
 
 func (m *mockDockerManager) EnsureNetwork(name string) error {
@@ -132,6 +190,27 @@ func (m *mockDockerManager) CreateAgentContainerWithOptions(name string, worktre
 	return "container-" + name, nil
 }
 
+func (m *mockDockerManager) ExecCommand(containerID string, command []string, opts docker.ExecOptions) (int, error) {
+	if m.execCommandFn != nil {
+		return m.execCommandFn(containerID, command, opts)
+	}
+	return 0, nil
+}
+
+func (m *mockDockerManager) CopyToContainer(containerID, srcHost, dstContainer string) error {
+	if m.copyToContainerFn != nil {
+		return m.copyToContainerFn(containerID, srcHost, dstContainer)
+	}
+	return nil
+}
+
+func (m *mockDockerManager) CopyFromContainer(containerID, srcContainer, dstHost string) error {
+	if m.copyFromContainerFn != nil {
+		return m.copyFromContainerFn(containerID, srcContainer, dstHost)
+	}
+	return nil
+}
+
 func (m *mockDockerManager) StartContainer(containerID string) error {
 	if m.startContainerFn != nil {
 		return m.startContainerFn(containerID)
@@ -240,7 +319,10 @@ func (m *mockExecutor) CheckContainer(containerID string) error {
 	return nil
 }
 
+// mockStateManager hands out copies of its agents, like the file-backed
+// state manager, so concurrent updates don't share an Agent.
 type mockStateManager struct {
+	mu            sync.Mutex
 	agents        map[string]*Agent
 	loadFn        func() (*State, error)
 	saveFn        func(state *State) error
@@ -278,18 +360,52 @@ func (m *mockStateManager) GetAgent(name string) (*Agent, error) {
 	if m.getAgentFn != nil {
 		return m.getAgentFn(name)
 	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	agent, exists := m.agents[name]
 	if !exists {
 		return nil, errors.New("agent not found")
 	}
-	return agent, nil
+	agentCopy := *agent
+	return &agentCopy, nil
 }
 
 func (m *mockStateManager) SetAgent(agent *Agent) error {
 	if m.setAgentFn != nil {
 		return m.setAgentFn(agent)
 	}
-	m.agents[agent.Name] = agent
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	agentCopy := *agent
+	m.agents[agent.Name] = &agentCopy
+	return nil
+}
+
+func (m *mockStateManager) UpdateAgent(name string, fn func(*Agent) error) error {
+	if m.getAgentFn != nil || m.setAgentFn != nil {
+		agent, err := m.GetAgent(name)
+		if err != nil {
+			return err
+		}
+		if err := fn(agent); err != nil {
+			return err
+		}
+		agent.UpdatedAt = time.Now()
+		return m.SetAgent(agent)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	agent, exists := m.agents[name]
+	if !exists {
+		return errors.New("agent not found")
+	}
+	agentCopy := *agent
+	if err := fn(&agentCopy); err != nil {
+		return err
+	}
+	agentCopy.UpdatedAt = time.Now()
+	m.agents[name] = &agentCopy
 	return nil
 }
 
@@ -297,6 +413,8 @@ func (m *mockStateManager) RemoveAgent(name string) error {
 	if m.removeAgentFn != nil {
 		return m.removeAgentFn(name)
 	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	delete(m.agents, name)
 	return nil
 }
@@ -305,9 +423,12 @@ func (m *mockStateManager) ListAgents() ([]*Agent, error) {
 	if m.listAgentsFn != nil {
 		return m.listAgentsFn()
 	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	agents := make([]*Agent, 0, len(m.agents))
 	for _, agent := range m.agents {
-		agents = append(agents, agent)
+		agentCopy := *agent
+		agents = append(agents, &agentCopy)
 	}
 	return agents, nil
 }
@@ -389,6 +510,219 @@ func TestSpawn(t *testing.T) {
 	}
 }
 
+func TestSpawn_ResourceOverrides(t *testing.T) {
+	var gotOpts docker.AgentContainerOptions
+	dockerMgr := &mockDockerManager{
+		createAgentContainerWithOptionsFn: func(name, _ string, opts docker.AgentContainerOptions) (string, error) {
+			gotOpts = opts
+			return "container-" + name, nil
+		},
+	}
+	manager, _ := NewManager(testConfig(), &mockGitManager{}, dockerMgr, newMockStateManager(), &mockExecutor{})
+
+	if _, err := manager.Spawn("big-agent", SpawnOptions{Memory: "16g", CPUs: "8"}); err != nil {
+		t.Fatalf("Spawn failed: %v", err)
+	}
+	if gotOpts.Memory != "16g" || gotOpts.CPUs != "8" {
+		t.Errorf("container resources = %q/%q, want 16g/8", gotOpts.Memory, gotOpts.CPUs)
+	}
+}
+
+func TestSpawn_PinnedTask(t *testing.T) {
+	state := newMockStateManager()
+	manager, _ := NewManager(testConfig(), &mockGitManager{}, &mockDockerManager{}, state, &mockExecutor{})
+
+	ag, err := manager.Spawn("migrate", SpawnOptions{PinnedTask: "DB-007"})
+	if err != nil {
+		t.Fatalf("Spawn failed: %v", err)
+	}
+	if ag.PinnedTask != "DB-007" || state.agents["migrate"].PinnedTask != "DB-007" {
+		t.Errorf("PinnedTask = %q, want DB-007 saved with the agent", ag.PinnedTask)
+	}
+}
+
+func TestSpawn_Network(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        SpawnOptions
+		wantNetwork string
+		wantEnsured []string
+	}{
+		{"default", SpawnOptions{}, "", nil},
+		{"override", SpawnOptions{Network: "sandbox-net"}, "sandbox-net", []string{"sandbox-net"}},
+		{"no network", SpawnOptions{Network: docker.NoNetwork}, docker.NoNetwork, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotOpts docker.AgentContainerOptions
+			var ensured []string
+			dockerMgr := &mockDockerManager{
+				createAgentContainerWithOptionsFn: func(name, _ string, opts docker.AgentContainerOptions) (string, error) {
+					gotOpts = opts
+					return "container-" + name, nil
+				},
+			}
+			manager, _ := NewManager(testConfig(), &mockGitManager{}, dockerMgr, newMockStateManager(), &mockExecutor{})
+			dockerMgr.ensureNetworkFn = func(name string) error {
+				ensured = append(ensured, name)
+				return nil
+			}
+
+			if _, err := manager.Spawn("agent-1", tt.opts); err != nil {
+				t.Fatalf("Spawn failed: %v", err)
+			}
+			if gotOpts.Network != tt.wantNetwork {
+				t.Errorf("container network = %q, want %q", gotOpts.Network, tt.wantNetwork)
+			}
+			if !reflect.DeepEqual(ensured, tt.wantEnsured) {
+				t.Errorf("ensured networks = %v, want %v", ensured, tt.wantEnsured)
+			}
+		})
+	}
+}
+
+func TestSpawnNetwork_Workstream(t *testing.T) {
+	cfg := testConfig()
+	cfg.Workstreams = map[string]*config.WorkstreamConfig{
+		"experiments": {Network: "isolated-net"},
+	}
+	manager, _ := NewManager(cfg, &mockGitManager{}, &mockDockerManager{}, newMockStateManager(), &mockExecutor{})
+
+	if got := manager.spawnNetwork(SpawnOptions{Workstream: "experiments"}); got != "isolated-net" {
+		t.Errorf("spawnNetwork(experiments) = %q, want isolated-net", got)
+	}
+	if got := manager.spawnNetwork(SpawnOptions{Workstream: "experiments", Network: docker.NoNetwork}); got != docker.NoNetwork {
+		t.Errorf("spawnNetwork() = %q, want the explicit network to win", got)
+	}
+	if got := manager.spawnNetwork(SpawnOptions{Workstream: "backend"}); got != "" {
+		t.Errorf("spawnNetwork(backend) = %q, want the default", got)
+	}
+}
+
+func TestSpawn_InvalidResources(t *testing.T) {
+	tests := []struct {
+		name string
+		opts SpawnOptions
+	}{
+		{"memory", SpawnOptions{Memory: "4 GB"}},
+		{"cpus", SpawnOptions{CPUs: "0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created := false
+			git := &mockGitManager{
+				createWorktreeFn: func(name string) (string, error) {
+					created = true
+					return "/tmp/worktrees/" + name, nil
+				},
+			}
+			manager, _ := NewManager(testConfig(), git, &mockDockerManager{}, newMockStateManager(), &mockExecutor{})
+
+			_, err := manager.Spawn("test-agent", tt.opts)
+			if !errors.Is(err, ErrInvalidResources) {
+				t.Errorf("Spawn() error = %v, want ErrInvalidResources", err)
+			}
+			if created {
+				t.Error("worktree created before resource limits were validated")
+			}
+		})
+	}
+}
+
+func TestSpawn_ExistingBranch(t *testing.T) {
+	var gotBranch string
+	git := &mockGitManager{
+		createWorktreeFn: func(name string) (string, error) {
+			t.Error("CreateWorktree should not be called when a branch is given")
+			return "", nil
+		},
+		createForBranchFn: func(name, branch string) (string, error) {
+			gotBranch = branch
+			return "/test/worktree/" + name, nil
+		},
+		getBranchNameFn: func(name string) string {
+			return gotBranch
+		},
+	}
+	state := newMockStateManager()
+	manager, _ := NewManager(testConfig(), git, &mockDockerManager{}, state, &mockExecutor{})
+
+	agent, err := manager.Spawn("test-agent", SpawnOptions{Branch: "feature/login"})
+	if err != nil {
+		t.Fatalf("Spawn failed: %v", err)
+	}
+
+	if gotBranch != "feature/login" {
+		t.Errorf("CreateWorktreeForBranch branch = %q, want feature/login", gotBranch)
+	}
+	if agent.Branch != "feature/login" {
+		t.Errorf("agent.Branch = %q, want feature/login", agent.Branch)
+	}
+	saved, _ := state.GetAgent("test-agent")
+	if saved.Branch != "feature/login" {
+		t.Errorf("persisted Branch = %q, want feature/login", saved.Branch)
+	}
+}
+
+func TestSpawn_BranchCheckedOut(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       SpawnOptions
+		wantBranch string
+	}{
+		{"new branch", SpawnOptions{}, "tanuki/test-agent"},
+		{"existing branch", SpawnOptions{Branch: "feature/login"}, "feature/login"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var checked string
+			git := &mockGitManager{
+				checkedOutFn: func(branch string) (string, error) {
+					checked = branch
+					return "/repo/main", nil
+				},
+				createWorktreeFn: func(name string) (string, error) {
+					t.Error("CreateWorktree should not be called when the branch is checked out")
+					return "", nil
+				},
+				createForBranchFn: func(name, branch string) (string, error) {
+					t.Error("CreateWorktreeForBranch should not be called when the branch is checked out")
+					return "", nil
+				},
+			}
+			manager, _ := NewManager(testConfig(), git, &mockDockerManager{}, newMockStateManager(), &mockExecutor{})
+
+			_, err := manager.Spawn("test-agent", tt.opts)
+			if !errors.Is(err, ErrBranchCheckedOut) {
+				t.Fatalf("Spawn() error = %v, want ErrBranchCheckedOut", err)
+			}
+			if !strings.Contains(err.Error(), "/repo/main") {
+				t.Errorf("Spawn() error = %v, want it to name the worktree", err)
+			}
+			if checked != tt.wantBranch {
+				t.Errorf("BranchCheckedOut branch = %q, want %q", checked, tt.wantBranch)
+			}
+		})
+	}
+}
+
+func TestSpawn_ExistingBranch_NotFound(t *testing.T) {
+	git := &mockGitManager{
+		createForBranchFn: func(name, branch string) (string, error) {
+			return "", errors.New("branch not found: " + branch)
+		},
+	}
+	manager, _ := NewManager(testConfig(), git, &mockDockerManager{}, newMockStateManager(), &mockExecutor{})
+
+	_, err := manager.Spawn("test-agent", SpawnOptions{Branch: "missing"})
+	if err == nil || !strings.Contains(err.Error(), "branch not found: missing") {
+		t.Errorf("Spawn() error = %v, want branch not found", err)
+	}
+}
+
 func TestSpawn_InvalidName(t *testing.T) {
 	cfg := testConfig()
 	git := &mockGitManager{}
@@ -509,6 +843,132 @@ func TestSpawn_ContainerFailure_Rollback(t *testing.T) {
 	}
 }
 
+func TestClone(t *testing.T) {
+	cfg := testConfig()
+	git := &mockGitManager{}
+	docker := &mockDockerManager{}
+	state := newMockStateManager()
+
+	executor := &mockExecutor{}
+	manager, _ := NewManager(cfg, git, docker, state, executor)
+
+	src, err := manager.Spawn("backend-1", SpawnOptions{})
+	if err != nil {
+		t.Fatalf("Spawn failed: %v", err)
+	}
+	src.Workstream = "backend"
+	src.AllowedTools = []string{"Read", "Bash"}
+	src.DisallowedTools = []string{"WebFetch"}
+	src.Status = "working"
+	_ = state.SetAgent(src)
+
+	clone, err := manager.Clone("backend-1", "backend-2")
+	if err != nil {
+		t.Fatalf("Clone failed: %v", err)
+	}
+
+	if clone.Workstream != "backend" {
+		t.Errorf("expected workstream 'backend', got %q", clone.Workstream)
+	}
+	if strings.Join(clone.AllowedTools, ",") != "Read,Bash" {
+		t.Errorf("expected allowed tools [Read Bash], got %v", clone.AllowedTools)
+	}
+	if strings.Join(clone.DisallowedTools, ",") != "WebFetch" {
+		t.Errorf("expected disallowed tools [WebFetch], got %v", clone.DisallowedTools)
+	}
+	if clone.Status != "idle" {
+		t.Errorf("expected status 'idle', got %q", clone.Status)
+	}
+	if clone.ContainerID != "container-backend-2" {
+		t.Errorf("expected fresh container 'container-backend-2', got %q", clone.ContainerID)
+	}
+	if clone.WorktreePath == src.WorktreePath {
+		t.Errorf("expected a fresh worktree, got source path %q", clone.WorktreePath)
+	}
+
+	// Mutating the clone's tools must not affect the source
+	clone.AllowedTools[0] = "Write"
+	if src.AllowedTools[0] != "Read" {
+		t.Error("clone shares tool slice with source")
+	}
+
+	saved, err := state.GetAgent("backend-2")
+	if err != nil {
+		t.Fatalf("clone not saved to state: %v", err)
+	}
+	if saved.Workstream != "backend" {
+		t.Errorf("expected saved workstream 'backend', got %q", saved.Workstream)
+	}
+}
+
+func TestClone_Errors(t *testing.T) {
+	cfg := testConfig()
+	git := &mockGitManager{}
+	docker := &mockDockerManager{}
+	state := newMockStateManager()
+
+	executor := &mockExecutor{}
+	manager, _ := NewManager(cfg, git, docker, state, executor)
+
+	if _, err := manager.Spawn("src", SpawnOptions{}); err != nil {
+		t.Fatalf("Spawn failed: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		src     string
+		dst     string
+		wantErr error
+	}{
+		{"missing source", "nope", "dst", ErrAgentNotFound},
+		{"invalid name", "src", "Bad_Name", ErrInvalidName},
+		{"existing name", "src", "src", ErrAgentExists},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := manager.Clone(tt.src, tt.dst)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Clone(%q, %q) error = %v, want %v", tt.src, tt.dst, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGenerateClaudeMD_ExcludedPaths(t *testing.T) {
+	projectRoot := t.TempDir()
+	worktree := t.TempDir()
+	if err := os.WriteFile(filepath.Join(projectRoot, ".tanukiignore"), []byte("# secrets\n.env\nbuild/\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	origDir, _ := os.Getwd()
+	if err := os.Chdir(projectRoot); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(origDir) }()
+
+	manager, _ := NewManager(testConfig(), &mockGitManager{}, &mockDockerManager{}, newMockStateManager(), &mockExecutor{})
+	if err := manager.generateClaudeMD(worktree, &WorkstreamInfo{Name: "backend", SystemPrompt: "Build it."}); err != nil {
+		t.Fatalf("generateClaudeMD failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(worktree, "CLAUDE.md"))
+	if err != nil {
+		t.Fatalf("read CLAUDE.md: %v", err)
+	}
+	content := string(data)
+
+	for _, want := range []string{"## Excluded Paths", "- `.env`", "- `build/`"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("CLAUDE.md missing %q:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "# secrets") {
+		t.Error("CLAUDE.md should not include ignore file comments")
+	}
+}
+
 func TestRemove(t *testing.T) {
 	cfg := testConfig()
 	git := &mockGitManager{}
@@ -663,7 +1123,11 @@ func TestList(t *testing.T) {
 
 func TestStatus(t *testing.T) {
 	cfg := testConfig()
-	git := &mockGitManager{}
+	git := &mockGitManager{
+		diskUsageFn: func(_ string) (int64, error) {
+			return 4096, nil
+		},
+	}
 	docker := &mockDockerManager{}
 	state := newMockStateManager()
 
@@ -691,6 +1155,33 @@ func TestStatus(t *testing.T) {
 	if !status.Container.Running {
 		t.Error("expected container to be running")
 	}
+	if status.Git.DiskUsage != 4096 {
+		t.Errorf("expected disk usage 4096, got %d", status.Git.DiskUsage)
+	}
+}
+
+func TestStatus_MainBranchFallback(t *testing.T) {
+	var diffBase string
+	git := &mockGitManager{
+		getMainBranchFn:    func() (string, error) { return "", errors.New("could not determine main branch") },
+		getCurrentBranchFn: func() (string, error) { return "develop", nil },
+		getDiffFn: func(_ string, baseBranch string) (string, error) {
+			diffBase = baseBranch
+			return "", nil
+		},
+	}
+	manager, _ := NewManager(testConfig(), git, &mockDockerManager{}, newMockStateManager(), &mockExecutor{})
+
+	if _, err := manager.Spawn("test-agent", SpawnOptions{}); err != nil {
+		t.Fatalf("Spawn failed: %v", err)
+	}
+	if _, err := manager.Status("test-agent"); err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+
+	if diffBase != "develop" {
+		t.Errorf("diffed against %q, want current branch develop", diffBase)
+	}
 }
 
 func TestReconcile(t *testing.T) {
@@ -845,33 +1336,781 @@ func TestRun(t *testing.T) {
 	if agent.LastTask.SessionID != "test-session-123" {
 		t.Errorf("expected session ID 'test-session-123', got %q", agent.LastTask.SessionID)
 	}
+	if agent.LastTask.LogFilePath != "" {
+		t.Errorf("expected no log file for output to stdout, got %q", agent.LastTask.LogFilePath)
+	}
 }
 
-func TestAgent_UpdatedAt(t *testing.T) {
+func TestRun_SystemPromptOrder(t *testing.T) {
 	cfg := testConfig()
-	git := &mockGitManager{}
-	docker := &mockDockerManager{}
+	cfg.GlobalSystemPromptFile = filepath.Join(t.TempDir(), "guardrails.md")
+	if err := os.WriteFile(cfg.GlobalSystemPromptFile, []byte("Never touch migrations.\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfg.Workstreams = map[string]*config.WorkstreamConfig{
+		"backend": {SystemPrompt: "You own the API."},
+	}
+
+	var got string
+	exec := &mockExecutor{
+		runFn: func(_ string, _ string, opts executor.ExecuteOptions) (*executor.ExecutionResult, error) {
+			got = opts.SystemPrompt
+			return &executor.ExecutionResult{CompletedAt: time.Now()}, nil
+		},
+	}
 	state := newMockStateManager()
+	manager, _ := NewManager(cfg, &mockGitManager{}, &mockDockerManager{}, state, exec)
+	if _, err := manager.Spawn("test-agent", SpawnOptions{}); err != nil {
+		t.Fatalf("Spawn failed: %v", err)
+	}
+	ag, _ := state.GetAgent("test-agent")
+	ag.Workstream = "backend"
+	_ = state.SetAgent(ag)
 
-	executor := &mockExecutor{}
-	manager, _ := NewManager(cfg, git, docker, state, executor)
+	if err := manager.Run("test-agent", "prompt", RunOptions{SystemPrompt: "Focus on the failing test."}); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
 
-	// Create agent
-	agent, _ := manager.Spawn("test-agent", SpawnOptions{})
-	createdAt := agent.CreatedAt
-	updatedAt := agent.UpdatedAt
+	want := "Never touch migrations.\n\nYou own the API.\n\nFocus on the failing test."
+	if got != want {
+		t.Errorf("SystemPrompt = %q, want %q", got, want)
+	}
+}
 
-	// Wait a moment
-	time.Sleep(10 * time.Millisecond)
+func TestRun_MissingGlobalSystemPromptFile(t *testing.T) {
+	cfg := testConfig()
+	cfg.GlobalSystemPromptFile = filepath.Join(t.TempDir(), "missing.md")
 
-	// Stop agent (should update UpdatedAt)
-	_ = manager.Stop("test-agent")
-	agent, _ = state.GetAgent("test-agent")
+	state := newMockStateManager()
+	manager, _ := NewManager(cfg, &mockGitManager{}, &mockDockerManager{}, state, &mockExecutor{})
+	if _, err := manager.Spawn("test-agent", SpawnOptions{}); err != nil {
+		t.Fatalf("Spawn failed: %v", err)
+	}
 
-	if !agent.CreatedAt.Equal(createdAt) {
-		t.Error("CreatedAt should not change")
+	err := manager.Run("test-agent", "prompt", RunOptions{})
+	if err == nil || !strings.Contains(err.Error(), "global_system_prompt_file") {
+		t.Fatalf("Run() error = %v, want one naming global_system_prompt_file", err)
 	}
-	if agent.UpdatedAt.Equal(updatedAt) {
-		t.Error("UpdatedAt should be updated")
+	if ag, _ := state.GetAgent("test-agent"); ag.Status == "working" {
+		t.Error("agent left working after a run that couldn't start")
+	}
+}
+
+func TestRun_RecordsLogFilePath(t *testing.T) {
+	state := newMockStateManager()
+	manager, _ := NewManager(testConfig(), &mockGitManager{}, &mockDockerManager{}, state, &mockExecutor{})
+	if _, err := manager.Spawn("test-agent", SpawnOptions{}); err != nil {
+		t.Fatalf("Spawn failed: %v", err)
+	}
+
+	opts := RunOptions{Follow: true, Output: io.Discard, LogFilePath: ".tanuki/logs/task-T1.log"}
+	if err := manager.Run("test-agent", "test prompt", opts); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	agent, _ := state.GetAgent("test-agent")
+	if agent.LastTask == nil || agent.LastTask.LogFilePath != ".tanuki/logs/task-T1.log" {
+		t.Errorf("LastTask = %+v, want LogFilePath .tanuki/logs/task-T1.log", agent.LastTask)
+	}
+}
+
+func TestRun_KeepsChangesMadeDuringRun(t *testing.T) {
+	state := newMockStateManager()
+	var manager *Manager
+	exec := &mockExecutor{
+		runFollowFn: func(string, string, executor.ExecuteOptions, io.Writer) (*executor.ExecutionResult, error) {
+			if _, err := manager.SetNotes("test-agent", "investigating flaky test"); err != nil {
+				return nil, err
+			}
+			if _, err := manager.SetLabels("test-agent", map[string]string{"team": "core"}, nil); err != nil {
+				return nil, err
+			}
+			return &executor.ExecutionResult{SessionID: "s-1", CompletedAt: time.Now()}, nil
+		},
+	}
+	manager, _ = NewManager(testConfig(), &mockGitManager{}, &mockDockerManager{}, state, exec)
+	if _, err := manager.Spawn("test-agent", SpawnOptions{}); err != nil {
+		t.Fatalf("Spawn failed: %v", err)
+	}
+
+	if err := manager.Run("test-agent", "test prompt", RunOptions{Follow: true, Output: io.Discard}); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	agent, _ := state.GetAgent("test-agent")
+	if agent.Notes != "investigating flaky test" {
+		t.Errorf("Notes = %q, want the notes set during the run", agent.Notes)
+	}
+	if agent.Labels["team"] != "core" {
+		t.Errorf("Labels = %v, want the labels set during the run", agent.Labels)
+	}
+	if agent.Status != "idle" {
+		t.Errorf("Status = %s, want idle", agent.Status)
+	}
+	if agent.LastTask == nil || agent.LastTask.SessionID != "s-1" || agent.LastTask.CompletedAt == nil {
+		t.Errorf("LastTask = %+v, want the run's session and completion time", agent.LastTask)
+	}
+}
+
+func TestRetrySession(t *testing.T) {
+	var resumed string
+	exec := &mockExecutor{
+		runFn: func(_ string, _ string, opts executor.ExecuteOptions) (*executor.ExecutionResult, error) {
+			resumed = opts.ResumeSessionID
+			return &executor.ExecutionResult{SessionID: "s-1"}, errors.New("tests failed")
+		},
+	}
+	manager, _ := NewManager(testConfig(), &mockGitManager{}, &mockDockerManager{}, newMockStateManager(), exec)
+	if _, err := manager.Spawn("test-agent", SpawnOptions{}); err != nil {
+		t.Fatalf("Spawn failed: %v", err)
+	}
+	_ = manager.Run("test-agent", "prompt", RunOptions{TaskID: "T1"})
+
+	tests := []struct {
+		name  string
+		agent string
+		task  *task.Task
+		want  string
+	}{
+		{"retry on the failed agent", "test-agent", &task.Task{ID: "T1", RetryCount: 1, FailedAgent: "test-agent"}, "s-1"},
+		{"first attempt", "test-agent", &task.Task{ID: "T1", FailedAgent: "test-agent"}, ""},
+		{"retry on another agent", "other", &task.Task{ID: "T1", RetryCount: 1, FailedAgent: "test-agent"}, ""},
+		{"agent ran another task since", "test-agent", &task.Task{ID: "T2", RetryCount: 1, FailedAgent: "test-agent"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := manager.RetrySession(tt.agent, tt.task); got != tt.want {
+				t.Errorf("RetrySession() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	_ = manager.Run("test-agent", "prompt", RunOptions{TaskID: "T1", ResumeSessionID: "s-1"})
+	if resumed != "s-1" {
+		t.Errorf("ResumeSessionID = %q, want s-1 passed to the executor", resumed)
+	}
+}
+
+func TestRun_CompletionSignal(t *testing.T) {
+	tests := []struct {
+		name        string
+		require     bool
+		output      string
+		wantErr     bool
+		wantWarning bool
+	}{
+		{"signal printed", true, "all done\nTASK_DONE\n", false, false},
+		{"required signal missing", true, "gave up\n", true, false},
+		{"optional signal missing", false, "gave up\n", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig()
+			cfg.RequireSignal = tt.require
+			exec := &mockExecutor{
+				runFollowFn: func(_ string, _ string, _ executor.ExecuteOptions, _ io.Writer) (*executor.ExecutionResult, error) {
+					return &executor.ExecutionResult{Output: tt.output, CompletedAt: time.Now()}, nil
+				},
+			}
+			manager, _ := NewManager(cfg, &mockGitManager{}, &mockDockerManager{}, newMockStateManager(), exec)
+			if _, err := manager.Spawn("test-agent", SpawnOptions{}); err != nil {
+				t.Fatalf("Spawn failed: %v", err)
+			}
+
+			var out bytes.Buffer
+			err := manager.Run("test-agent", "prompt", RunOptions{Follow: true, Output: &out, CompletionSignal: "TASK_DONE"})
+			if tt.wantErr != errors.Is(err, executor.ErrNoCompletionSignal) {
+				t.Errorf("Run() error = %v, want ErrNoCompletionSignal: %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Run() error = %v", err)
+			}
+			if got := strings.Contains(out.String(), "completion signal"); got != tt.wantWarning {
+				t.Errorf("warning written = %v, want %v (output %q)", got, tt.wantWarning, out.String())
+			}
+		})
+	}
+}
+
+func TestRun_ModelFallbacks(t *testing.T) {
+	tests := []struct {
+		name          string
+		model         string
+		wantFallbacks []string
+	}{
+		{name: "configured model", model: "", wantFallbacks: []string{"backup"}},
+		{name: "explicit model", model: "chosen", wantFallbacks: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig()
+			cfg.Defaults.ModelFallbacks = []string{"backup"}
+
+			var gotOpts executor.ExecuteOptions
+			exec := &mockExecutor{
+				runFn: func(_ string, _ string, opts executor.ExecuteOptions) (*executor.ExecutionResult, error) {
+					gotOpts = opts
+					return &executor.ExecutionResult{CompletedAt: time.Now(), Model: "backup"}, nil
+				},
+			}
+			state := newMockStateManager()
+			manager, _ := NewManager(cfg, &mockGitManager{}, &mockDockerManager{}, state, exec)
+			if _, err := manager.Spawn("test-agent", SpawnOptions{}); err != nil {
+				t.Fatalf("Spawn failed: %v", err)
+			}
+
+			if err := manager.Run("test-agent", "prompt", RunOptions{Model: tt.model}); err != nil {
+				t.Fatalf("Run failed: %v", err)
+			}
+
+			if !reflect.DeepEqual(gotOpts.ModelFallbacks, tt.wantFallbacks) {
+				t.Errorf("ModelFallbacks = %v, want %v", gotOpts.ModelFallbacks, tt.wantFallbacks)
+			}
+			agent, _ := state.GetAgent("test-agent")
+			if agent.LastTask.Model != "backup" {
+				t.Errorf("LastTask.Model = %q, want the model that ran", agent.LastTask.Model)
+			}
+		})
+	}
+}
+
+func TestSpawn_WaitsUntilReady(t *testing.T) {
+	checks := 0
+	exec := &mockExecutor{
+		checkContainerFn: func(_ string) error {
+			checks++
+			if checks < 3 {
+				return executor.ErrClaudeNotFound
+			}
+			return nil
+		},
+	}
+	state := newMockStateManager()
+	manager, _ := NewManager(testConfig(), &mockGitManager{}, &mockDockerManager{}, state, exec)
+	manager.SetReadinessProbe(time.Second, time.Millisecond)
+
+	agent, err := manager.Spawn("test-agent", SpawnOptions{})
+	if err != nil {
+		t.Fatalf("Spawn failed: %v", err)
+	}
+	if checks != 3 {
+		t.Errorf("CheckContainer called %d times, want 3", checks)
+	}
+	if agent.Status != "idle" {
+		t.Errorf("Status = %q, want idle", agent.Status)
+	}
+}
+
+func TestSpawn_NotReadyRollsBack(t *testing.T) {
+	var removedContainer, removedWorktree bool
+	git := &mockGitManager{
+		removeWorktreeFn: func(_ string, _ bool) error {
+			removedWorktree = true
+			return nil
+		},
+	}
+	docker := &mockDockerManager{
+		removeContainerFn: func(_ string) error {
+			removedContainer = true
+			return nil
+		},
+	}
+	exec := &mockExecutor{
+		checkContainerFn: func(_ string) error { return executor.ErrClaudeNotFound },
+	}
+	state := newMockStateManager()
+	manager, _ := NewManager(testConfig(), git, docker, state, exec)
+	manager.SetReadinessProbe(20*time.Millisecond, time.Millisecond)
+
+	_, err := manager.Spawn("test-agent", SpawnOptions{})
+	if !errors.Is(err, ErrAgentNotReady) {
+		t.Fatalf("Spawn() error = %v, want ErrAgentNotReady", err)
+	}
+	if !strings.Contains(err.Error(), executor.ErrClaudeNotFound.Error()) {
+		t.Errorf("Spawn() error = %q, want it to include the last check failure", err)
+	}
+	if !removedContainer || !removedWorktree {
+		t.Errorf("rollback: container removed = %v, worktree removed = %v; want both", removedContainer, removedWorktree)
+	}
+	if _, err := state.GetAgent("test-agent"); err == nil {
+		t.Error("agent was saved despite never becoming ready")
+	}
+}
+
+func TestStart_NotReadyStopsContainer(t *testing.T) {
+	var stopped bool
+	docker := &mockDockerManager{
+		stopContainerFn: func(_ string) error {
+			stopped = true
+			return nil
+		},
+	}
+	exec := &mockExecutor{}
+	state := newMockStateManager()
+	manager, _ := NewManager(testConfig(), &mockGitManager{}, docker, state, exec)
+	manager.SetReadinessProbe(20*time.Millisecond, time.Millisecond)
+
+	if _, err := manager.Spawn("test-agent", SpawnOptions{}); err != nil {
+		t.Fatalf("Spawn failed: %v", err)
+	}
+	exec.checkContainerFn = func(_ string) error { return executor.ErrClaudeNotFound }
+
+	if err := manager.Start("test-agent"); !errors.Is(err, ErrAgentNotReady) {
+		t.Fatalf("Start() error = %v, want ErrAgentNotReady", err)
+	}
+	if !stopped {
+		t.Error("container was left running after the readiness check failed")
+	}
+}
+
+func TestResolveTools(t *testing.T) {
+	defaults := []string{"Read", "Edit", "Bash"}
+
+	tests := []struct {
+		name           string
+		opts           RunOptions
+		agent          *Agent
+		wantAllowed    []string
+		wantDisallowed []string
+	}{
+		{
+			name:        "config defaults",
+			agent:       &Agent{},
+			wantAllowed: defaults,
+		},
+		{
+			name:           "workstream overrides defaults",
+			agent:          &Agent{AllowedTools: []string{"Read"}, DisallowedTools: []string{"Bash"}},
+			wantAllowed:    []string{"Read"},
+			wantDisallowed: []string{"Bash"},
+		},
+		{
+			name:           "run options override workstream",
+			opts:           RunOptions{AllowedTools: []string{"Read", "WebFetch"}},
+			agent:          &Agent{AllowedTools: []string{"Read"}, DisallowedTools: []string{"Bash"}},
+			wantAllowed:    []string{"Read", "WebFetch"},
+			wantDisallowed: []string{"Bash"},
+		},
+		{
+			name:           "run options grant a workstream-denied tool",
+			opts:           RunOptions{AllowedTools: []string{"Read", "Bash"}},
+			agent:          &Agent{DisallowedTools: []string{"Bash", "Write"}},
+			wantAllowed:    []string{"Read", "Bash"},
+			wantDisallowed: []string{"Write"},
+		},
+		{
+			name:           "explicit disallowed tools are kept",
+			opts:           RunOptions{AllowedTools: []string{"Bash"}, DisallowedTools: []string{"Bash"}},
+			agent:          &Agent{DisallowedTools: []string{"Write"}},
+			wantAllowed:    []string{"Bash"},
+			wantDisallowed: []string{"Bash"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, disallowed := resolveTools(tt.opts, tt.agent, defaults)
+			if !reflect.DeepEqual(allowed, tt.wantAllowed) {
+				t.Errorf("allowed = %v, want %v", allowed, tt.wantAllowed)
+			}
+			if !reflect.DeepEqual(disallowed, tt.wantDisallowed) {
+				t.Errorf("disallowed = %v, want %v", disallowed, tt.wantDisallowed)
+			}
+		})
+	}
+}
+
+func TestRun_TaskGrantsTool(t *testing.T) {
+	cfg := testConfig()
+	cfg.Defaults.AllowedTools = []string{"Read", "Edit"}
+
+	var gotOpts executor.ExecuteOptions
+	exec := &mockExecutor{
+		runFn: func(_ string, _ string, opts executor.ExecuteOptions) (*executor.ExecutionResult, error) {
+			gotOpts = opts
+			return &executor.ExecutionResult{CompletedAt: time.Now()}, nil
+		},
+	}
+	manager, _ := NewManager(cfg, &mockGitManager{}, &mockDockerManager{}, newMockStateManager(), exec)
+	if _, err := manager.Spawn("test-agent", SpawnOptions{}); err != nil {
+		t.Fatalf("Spawn failed: %v", err)
+	}
+
+	tk := &task.Task{ID: "T1", AllowedTools: []string{"Read", "Edit", "WebFetch"}}
+	opts := RunOptions{}
+	ApplyTaskTools(&opts, tk)
+
+	if err := manager.Run("test-agent", "prompt", opts); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	want := []string{"Read", "Edit", "WebFetch"}
+	if !reflect.DeepEqual(gotOpts.AllowedTools, want) {
+		t.Errorf("AllowedTools = %v, want %v", gotOpts.AllowedTools, want)
+	}
+}
+
+func TestApplyTaskTools_KeepsExplicitOptions(t *testing.T) {
+	tk := &task.Task{AllowedTools: []string{"WebFetch"}, DisallowedTools: []string{"Bash"}}
+	opts := RunOptions{AllowedTools: []string{"Read"}}
+
+	ApplyTaskTools(&opts, tk)
+
+	if !reflect.DeepEqual(opts.AllowedTools, []string{"Read"}) {
+		t.Errorf("AllowedTools = %v, want [Read]", opts.AllowedTools)
+	}
+	if !reflect.DeepEqual(opts.DisallowedTools, []string{"Bash"}) {
+		t.Errorf("DisallowedTools = %v, want [Bash]", opts.DisallowedTools)
+	}
+}
+
+func TestApplyTaskSignal(t *testing.T) {
+	var opts RunOptions
+	ApplyTaskSignal(&opts, &task.Task{})
+	if opts.CompletionSignal != "" {
+		t.Errorf("CompletionSignal = %q, want none for a task without completion", opts.CompletionSignal)
+	}
+
+	ApplyTaskSignal(&opts, &task.Task{Completion: &task.CompletionConfig{Signal: "DONE"}})
+	if opts.CompletionSignal != "DONE" {
+		t.Errorf("CompletionSignal = %q, want DONE", opts.CompletionSignal)
+	}
+}
+
+func TestRun_AutoPush(t *testing.T) {
+	tests := []struct {
+		name       string
+		autoPush   bool
+		ahead      int
+		runErr     error
+		pushErr    error
+		wantPushed bool
+		wantOutput string
+	}{
+		{name: "pushes when enabled and ahead", autoPush: true, ahead: 2, wantPushed: true, wantOutput: "Pushed tanuki/test-agent"},
+		{name: "disabled", autoPush: false, ahead: 2, wantPushed: false},
+		{name: "no commits ahead", autoPush: true, ahead: 0, wantPushed: false},
+		{name: "run failed", autoPush: true, ahead: 2, runErr: errors.New("boom"), wantPushed: false},
+		{name: "push failure is only logged", autoPush: true, ahead: 1, pushErr: errors.New("denied"), wantPushed: true, wantOutput: "auto-push failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig()
+			cfg.Git.AutoPush = tt.autoPush
+
+			pushed := false
+			git := &mockGitManager{
+				commitsAheadFn: func(name, baseBranch string) (int, error) {
+					if baseBranch != "main" {
+						t.Errorf("CommitsAhead base = %q, want main", baseBranch)
+					}
+					return tt.ahead, nil
+				},
+				pushFn: func(name string) error {
+					pushed = true
+					if name != "test-agent" {
+						t.Errorf("Push(%q), want test-agent", name)
+					}
+					return tt.pushErr
+				},
+			}
+			exec := &mockExecutor{
+				runFn: func(containerID string, prompt string, opts executor.ExecuteOptions) (*executor.ExecutionResult, error) {
+					return &executor.ExecutionResult{CompletedAt: time.Now()}, tt.runErr
+				},
+			}
+			manager, _ := NewManager(cfg, git, &mockDockerManager{}, newMockStateManager(), exec)
+			if _, err := manager.Spawn("test-agent", SpawnOptions{}); err != nil {
+				t.Fatalf("Spawn failed: %v", err)
+			}
+
+			var out bytes.Buffer
+			err := manager.Run("test-agent", "test prompt", RunOptions{Output: &out})
+			if !errors.Is(err, tt.runErr) {
+				t.Errorf("Run() error = %v, want %v", err, tt.runErr)
+			}
+			if pushed != tt.wantPushed {
+				t.Errorf("Push called = %v, want %v", pushed, tt.wantPushed)
+			}
+			if tt.wantOutput != "" && !strings.Contains(out.String(), tt.wantOutput) {
+				t.Errorf("output = %q, want it to contain %q", out.String(), tt.wantOutput)
+			}
+		})
+	}
+}
+
+func TestRun_AlreadyWorking(t *testing.T) {
+	cfg := testConfig()
+	git := &mockGitManager{}
+	docker := &mockDockerManager{}
+	state := newMockStateManager()
+
+	executor := &mockExecutor{}
+	manager, _ := NewManager(cfg, git, docker, state, executor)
+
+	agent, _ := manager.Spawn("test-agent", SpawnOptions{})
+	agent.Status = "working"
+	_ = state.SetAgent(agent)
+
+	err := manager.Run("test-agent", "test prompt", RunOptions{})
+	if !errors.Is(err, ErrAgentBusy) {
+		t.Errorf("expected ErrAgentBusy, got %v", err)
+	}
+}
+
+func TestRun_ConcurrentCallsRejected(t *testing.T) {
+	cfg := testConfig()
+	git := &mockGitManager{}
+	docker := &mockDockerManager{}
+	state := newMockStateManager()
+
+	release := make(chan struct{})
+	executor := &mockExecutor{
+		runFn: func(_ string, _ string, _ executor.ExecuteOptions) (*executor.ExecutionResult, error) {
+			<-release
+			return &executor.ExecutionResult{CompletedAt: time.Now()}, nil
+		},
+	}
+	manager, _ := NewManager(cfg, git, docker, state, executor)
+
+	if _, err := manager.Spawn("test-agent", SpawnOptions{}); err != nil {
+		t.Fatalf("Spawn failed: %v", err)
+	}
+
+	// Both calls pass the initial busy check before either claims the agent
+	var arrived sync.WaitGroup
+	arrived.Add(2)
+	executor.checkContainerFn = func(_ string) error {
+		arrived.Done()
+		arrived.Wait()
+		return nil
+	}
+
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			errs <- manager.Run("test-agent", "test prompt", RunOptions{})
+		}()
+	}
+
+	// The loser returns immediately while the winner is still running
+	if err := <-errs; !errors.Is(err, ErrAgentBusy) {
+		t.Errorf("expected ErrAgentBusy for concurrent run, got %v", err)
+	}
+
+	close(release)
+	if err := <-errs; err != nil {
+		t.Errorf("winning run failed: %v", err)
+	}
+}
+
+func TestAgent_UpdatedAt(t *testing.T) {
+	cfg := testConfig()
+	git := &mockGitManager{}
+	docker := &mockDockerManager{}
+	state := newMockStateManager()
+
+	executor := &mockExecutor{}
+	manager, _ := NewManager(cfg, git, docker, state, executor)
+
+	// Create agent
+	agent, _ := manager.Spawn("test-agent", SpawnOptions{})
+	createdAt := agent.CreatedAt
+	updatedAt := agent.UpdatedAt
+
+	// Wait a moment
+	time.Sleep(10 * time.Millisecond)
+
+	// Stop agent (should update UpdatedAt)
+	_ = manager.Stop("test-agent")
+	agent, _ = state.GetAgent("test-agent")
+
+	if !agent.CreatedAt.Equal(createdAt) {
+		t.Error("CreatedAt should not change")
+	}
+	if agent.UpdatedAt.Equal(updatedAt) {
+		t.Error("UpdatedAt should be updated")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	state := newMockStateManager()
+	state.agents["test-agent"] = &Agent{Name: "test-agent", ContainerID: "c1"}
+
+	var gotContainer string
+	var gotCommand []string
+	dockerMgr := &mockDockerManager{
+		execCommandFn: func(containerID string, command []string, opts docker.ExecOptions) (int, error) {
+			gotContainer, gotCommand = containerID, command
+			_, _ = opts.Stdout.Write([]byte("2 tests failed\n"))
+			return 1, nil
+		},
+	}
+	manager, _ := NewManager(testConfig(), &mockGitManager{}, dockerMgr, state, &mockExecutor{})
+
+	passed, output, err := manager.Verify("test-agent", "make test && make lint")
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if passed {
+		t.Error("Verify() passed = true, want false for a non-zero exit")
+	}
+	if output != "2 tests failed\n" {
+		t.Errorf("Verify() output = %q, want the command's output", output)
+	}
+	if gotContainer != "c1" || len(gotCommand) != 3 || gotCommand[0] != "sh" || gotCommand[2] != "make test && make lint" {
+		t.Errorf("ran %q in %q, want the command through sh in the agent's container", gotCommand, gotContainer)
+	}
+
+	if _, _, err := manager.Verify("missing", "true"); !errors.Is(err, ErrAgentNotFound) {
+		t.Errorf("Verify() error = %v, want ErrAgentNotFound", err)
+	}
+}
+
+func TestCheckExpects(t *testing.T) {
+	state := newMockStateManager()
+	state.agents["test-agent"] = &Agent{Name: "test-agent", ContainerID: "c1"}
+
+	var gotCommand []string
+	dockerMgr := &mockDockerManager{
+		execCommandFn: func(containerID string, command []string, opts docker.ExecOptions) (int, error) {
+			gotCommand = command
+			_, _ = opts.Stdout.Write([]byte("docs/*.md\ncoverage.out\n"))
+			return 0, nil
+		},
+	}
+	manager, _ := NewManager(testConfig(), &mockGitManager{}, dockerMgr, state, &mockExecutor{})
+
+	tsk := &task.Task{ID: "T1", Expects: []string{"dist/*.js", "docs/*.md", "coverage.out"}}
+	err := manager.CheckExpects("test-agent", tsk)
+	if !errors.Is(err, task.ErrExpectedFilesMissing) {
+		t.Fatalf("CheckExpects() error = %v, want ErrExpectedFilesMissing", err)
+	}
+	if !strings.Contains(err.Error(), "docs/*.md, coverage.out") {
+		t.Errorf("CheckExpects() error = %q, want the missing globs listed", err)
+	}
+	// Globs are passed as arguments, not spliced into the script
+	if len(gotCommand) != 7 || gotCommand[0] != "sh" || gotCommand[4] != "dist/*.js" {
+		t.Errorf("ran %q, want the globs passed to sh as arguments", gotCommand)
+	}
+
+	gotCommand = nil
+	if err := manager.CheckExpects("test-agent", &task.Task{ID: "T2"}); err != nil || gotCommand != nil {
+		t.Errorf("CheckExpects() without expects = %v after running %q, want nil without running anything", err, gotCommand)
+	}
+}
+
+func TestTotalResourceUsage(t *testing.T) {
+	state := newMockStateManager()
+	state.agents["a1"] = &Agent{Name: "a1", ContainerID: "c1", Status: "working"}
+	state.agents["a2"] = &Agent{Name: "a2", ContainerID: "c2", Status: "idle"}
+	state.agents["a3"] = &Agent{Name: "a3", ContainerID: "c3", Status: "stopped"}
+	state.agents["a4"] = &Agent{Name: "a4", ContainerID: "c4", Status: "idle"}
+
+	dockerMgr := &mockDockerManager{
+		getResourceUsageFn: func(containerID string) (*ResourceUsage, error) {
+			switch containerID {
+			case "c1":
+				return &ResourceUsage{Memory: "1GiB / 4GiB", CPU: "150.5%"}, nil
+			case "c2":
+				return &ResourceUsage{Memory: "512MiB / 4GiB", CPU: "--"}, nil
+			case "c3":
+				t.Error("GetResourceUsage() called for a stopped agent")
+			}
+			return nil, nil
+		},
+	}
+	manager, _ := NewManager(testConfig(), &mockGitManager{}, dockerMgr, state, &mockExecutor{})
+
+	total, err := manager.TotalResourceUsage()
+	if err != nil {
+		t.Fatalf("TotalResourceUsage() error = %v", err)
+	}
+	if total.Agents != 2 {
+		t.Errorf("Agents = %d, want 2", total.Agents)
+	}
+	if want := int64(1<<30 + 512<<20); total.MemoryBytes != want {
+		t.Errorf("MemoryBytes = %d, want %d", total.MemoryBytes, want)
+	}
+	if total.CPUPercent != 150.5 {
+		t.Errorf("CPUPercent = %v, want 150.5", total.CPUPercent)
+	}
+	if len(total.PerAgent) != 2 || total.PerAgent["a1"].CPU != "150.5%" || total.PerAgent["a2"] == nil {
+		t.Errorf("PerAgent = %v, want entries for a1 and a2", total.PerAgent)
+	}
+}
+
+func TestManager_Restart(t *testing.T) {
+	var calls []string
+	docker := &mockDockerManager{
+		stopContainerFn: func(id string) error {
+			calls = append(calls, "stop "+id)
+			return nil
+		},
+		startContainerFn: func(id string) error {
+			calls = append(calls, "start "+id)
+			return nil
+		},
+	}
+	state := newMockStateManager()
+	state.agents["auth-1"] = &Agent{Name: "auth-1", ContainerID: "abc123def456789", Status: "idle", Branch: "tanuki/auth-1", WorktreePath: "/repo/.tanuki/worktrees/auth-1"}
+	state.agents["busy-1"] = &Agent{Name: "busy-1", ContainerID: "fed987cba654321", Status: "working"}
+	state.agents["down-1"] = &Agent{Name: "down-1", ContainerID: "0123456789abcdef", Status: "stopped"}
+	manager, _ := NewManager(testConfig(), &mockGitManager{}, docker, state, &mockExecutor{})
+
+	if err := manager.Restart("auth-1"); err != nil {
+		t.Fatalf("Restart() error = %v", err)
+	}
+	if len(calls) != 2 || calls[0] != "stop abc123def456789" || calls[1] != "start abc123def456789" {
+		t.Errorf("container calls = %v, want stop then start", calls)
+	}
+	ag := state.agents["auth-1"]
+	if ag.Status != "idle" {
+		t.Errorf("Status = %s, want idle", ag.Status)
+	}
+	if ag.Branch != "tanuki/auth-1" || ag.WorktreePath != "/repo/.tanuki/worktrees/auth-1" {
+		t.Errorf("agent = %+v, want branch and worktree kept", ag)
+	}
+
+	// A stopped agent is only started
+	calls = nil
+	if err := manager.Restart("down-1"); err != nil {
+		t.Fatalf("Restart() of a stopped agent error = %v", err)
+	}
+	if len(calls) != 1 || calls[0] != "start 0123456789abcdef" {
+		t.Errorf("container calls = %v, want only a start", calls)
+	}
+	if ag := state.agents["down-1"]; ag.Status != "idle" {
+		t.Errorf("Status = %s, want idle", ag.Status)
+	}
+
+	calls = nil
+	if err := manager.Restart("busy-1"); !errors.Is(err, ErrAgentWorking) {
+		t.Errorf("Restart() of a working agent error = %v, want ErrAgentWorking", err)
+	}
+	if len(calls) != 0 {
+		t.Errorf("container calls = %v, want a working agent left alone", calls)
+	}
+
+	if err := manager.Restart("missing"); !errors.Is(err, ErrAgentNotFound) {
+		t.Errorf("Restart() error = %v, want ErrAgentNotFound", err)
+	}
+}
+
+func TestManager_MovedWorktrees(t *testing.T) {
+	state := newMockStateManager()
+	state.agents["auth-1"] = &Agent{Name: "auth-1", WorktreePath: "/test/worktree/auth-1"}
+	state.agents["api-1"] = &Agent{Name: "api-1", WorktreePath: "/old/worktrees/api-1"}
+	manager, _ := NewManager(testConfig(), &mockGitManager{}, &mockDockerManager{}, state, &mockExecutor{})
+
+	moved, err := manager.MovedWorktrees()
+	if err != nil {
+		t.Fatalf("MovedWorktrees() error = %v", err)
+	}
+	if len(moved) != 1 || moved[0].Name != "api-1" {
+		t.Errorf("MovedWorktrees() = %v, want only api-1", moved)
 	}
 }
@@ -0,0 +1,47 @@
+package agent
+
+import (
+	"fmt"
+
+	"github.com/bkonkle/tanuki/internal/docker"
+	"github.com/bkonkle/tanuki/internal/state"
+)
+
+// TotalUsage is the combined resource usage of all running agents.
+type TotalUsage struct {
+	Agents      int                       `json:"agents"`
+	MemoryBytes int64                     `json:"memory_bytes"`
+	CPUPercent  float64                   `json:"cpu_percent"`
+	PerAgent    map[string]*ResourceUsage `json:"per_agent"`
+}
+
+// TotalResourceUsage sums memory and CPU usage across every running agent's
+// container. Agents whose containers are stopped or report no stats are left
+// out; a value Docker reports in an unexpected format counts as zero.
+func (m *Manager) TotalResourceUsage() (*TotalUsage, error) {
+	agents, err := m.state.ListAgents()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agents: %w", err)
+	}
+
+	total := &TotalUsage{PerAgent: make(map[string]*ResourceUsage)}
+	for _, ag := range agents {
+		if ag.ContainerID == "" || ag.Status == state.StatusStopped {
+			continue
+		}
+		usage, err := m.docker.GetResourceUsage(ag.ContainerID)
+		if err != nil || usage == nil {
+			continue
+		}
+
+		total.Agents++
+		total.PerAgent[ag.Name] = usage
+		if mem, err := docker.ParseMemoryUsage(usage.Memory); err == nil {
+			total.MemoryBytes += mem
+		}
+		if cpu, err := docker.ParseCPUPercent(usage.CPU); err == nil {
+			total.CPUPercent += cpu
+		}
+	}
+	return total, nil
+}
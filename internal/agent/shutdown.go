@@ -0,0 +1,97 @@
+package agent
+
+import (
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// Signal handling hooks, replaced in tests.
+var (
+	notifySignals = func(c chan<- os.Signal) { signal.Notify(c, os.Interrupt, syscall.SIGTERM) }
+	stopSignals   = signal.Stop
+	exitProcess   = os.Exit
+)
+
+// ShuttingDown reports whether Shutdown has been called.
+func (o *WorkstreamOrchestrator) ShuttingDown() bool {
+	select {
+	case <-o.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// Shutdown stops the workstream runners gracefully. Runners take no new
+// tasks, running tasks get up to grace to finish, and then the runners'
+// agents are stopped. Tasks cut short return to pending so the next run
+// picks them up. Calls after the first wait for it to finish.
+func (o *WorkstreamOrchestrator) Shutdown(grace time.Duration) {
+	o.shutdownOnce.Do(func() {
+		close(o.stop)
+
+		o.mu.Lock()
+		runners := append([]*WorkstreamRunner(nil), o.runners...)
+		o.mu.Unlock()
+
+		deadline := time.After(grace)
+	wait:
+		for _, r := range runners {
+			select {
+			case <-r.done:
+			case <-deadline:
+				log.Printf("Shutdown grace period (%s) elapsed with tasks still running", grace)
+				break wait
+			}
+		}
+
+		// Stopping the container ends any run still in flight
+		stopped := make(map[string]bool)
+		for _, r := range runners {
+			if stopped[r.agentName] {
+				continue
+			}
+			stopped[r.agentName] = true
+			if err := o.agentMgr.Stop(r.agentName); err != nil {
+				log.Printf("Warning: failed to stop agent %s: %v", r.agentName, err)
+			}
+		}
+	})
+}
+
+// HandleSignals shuts the orchestrator down on SIGINT or SIGTERM, giving
+// running tasks up to grace to finish, and forces the process to exit on a
+// second signal. Call the returned function to remove the handler.
+func (o *WorkstreamOrchestrator) HandleSignals(grace time.Duration) (stop func()) {
+	sigCh := make(chan os.Signal, 2)
+	done := make(chan struct{})
+	notifySignals(sigCh)
+	go o.watchSignals(sigCh, grace, done)
+
+	return func() {
+		stopSignals(sigCh)
+		close(done)
+	}
+}
+
+// watchSignals starts a shutdown on the first signal and forces the process
+// to exit on the second.
+func (o *WorkstreamOrchestrator) watchSignals(sigCh <-chan os.Signal, grace time.Duration, done <-chan struct{}) {
+	select {
+	case sig := <-sigCh:
+		log.Printf("Received %s, shutting down (repeat to force exit)", sig)
+		go o.Shutdown(grace)
+	case <-done:
+		return
+	}
+
+	select {
+	case sig := <-sigCh:
+		log.Printf("Received %s again, forcing exit", sig)
+		exitProcess(1)
+	case <-done:
+	}
+}
@@ -0,0 +1,148 @@
+package agent
+
+import (
+	"errors"
+	"io"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/bkonkle/tanuki/internal/executor"
+	"github.com/bkonkle/tanuki/internal/task"
+)
+
+// newShutdownTestManagers returns managers for a "backend" workstream with
+// one pending task and an existing, idle backend agent.
+func newShutdownTestManagers(t *testing.T, docker *mockDockerManager, exec *mockExecutor) (*Manager, *task.Manager) {
+	t.Helper()
+
+	taskMgr := task.NewManager(&task.Config{ProjectRoot: writeWorkstreamTask(t, "")})
+	if _, err := taskMgr.Scan(); err != nil {
+		t.Fatal(err)
+	}
+
+	state := newMockStateManager()
+	state.agents["backend"] = &Agent{Name: "backend", ContainerID: "container-backend", Status: "idle"}
+	agentMgr, err := NewManager(testConfig(), &mockGitManager{}, docker, state, exec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return agentMgr, taskMgr
+}
+
+func TestWorkstreamOrchestrator_Shutdown_RequeuesInterruptedTask(t *testing.T) {
+	started := make(chan struct{})
+	killed := make(chan struct{})
+	var runner *WorkstreamRunner
+
+	docker := &mockDockerManager{
+		stopContainerFn: func(string) error {
+			// Stopping the container ends the run; let the runner finish
+			// with it before Stop records the agent as stopped
+			close(killed)
+			<-runner.done
+			return nil
+		},
+	}
+	exec := &mockExecutor{
+		runFollowFn: func(string, string, executor.ExecuteOptions, io.Writer) (*executor.ExecutionResult, error) {
+			close(started)
+			<-killed
+			return nil, errors.New("container stopped")
+		},
+	}
+	agentMgr, taskMgr := newShutdownTestManagers(t, docker, exec)
+
+	orch := NewWorkstreamOrchestrator(agentMgr, taskMgr, DefaultWorkstreamConfig())
+	var err error
+	runner, err = orch.StartWorkstream("", "backend")
+	if err != nil {
+		t.Fatalf("StartWorkstream() error: %v", err)
+	}
+	runner.SetOutput(io.Discard)
+
+	runErr := make(chan error, 1)
+	go func() { runErr <- runner.Run() }()
+	<-started
+
+	orch.Shutdown(10 * time.Millisecond)
+
+	if err := <-runErr; err != nil {
+		t.Errorf("Run() error = %v, want nil after shutdown", err)
+	}
+	tsk, _ := taskMgr.Get("TASK-001")
+	if tsk.Status != task.StatusPending || tsk.AssignedTo != "" {
+		t.Errorf("task = %s/%q, want pending and unassigned", tsk.Status, tsk.AssignedTo)
+	}
+	if ag, _ := agentMgr.Get("backend"); ag.Status != "stopped" {
+		t.Errorf("agent status = %q, want stopped", ag.Status)
+	}
+
+	if _, err := orch.StartWorkstream("", "frontend"); err == nil {
+		t.Error("StartWorkstream() after Shutdown should fail")
+	}
+}
+
+func TestWorkstreamOrchestrator_Shutdown_StopsIdleRunners(t *testing.T) {
+	agentMgr, taskMgr := newShutdownTestManagers(t, &mockDockerManager{}, &mockExecutor{})
+
+	orch := NewWorkstreamOrchestrator(agentMgr, taskMgr, DefaultWorkstreamConfig())
+	runner, err := orch.StartWorkstream("", "backend")
+	if err != nil {
+		t.Fatalf("StartWorkstream() error: %v", err)
+	}
+
+	orch.Shutdown(time.Millisecond)
+	if !orch.ShuttingDown() {
+		t.Error("ShuttingDown() = false after Shutdown")
+	}
+
+	// A runner started late takes no tasks
+	if err := runner.Run(); err != nil {
+		t.Errorf("Run() error = %v", err)
+	}
+	if tsk, _ := taskMgr.Get("TASK-001"); tsk.Status != task.StatusPending {
+		t.Errorf("task status = %s, want pending", tsk.Status)
+	}
+}
+
+func TestWorkstreamOrchestrator_WatchSignals(t *testing.T) {
+	origExit := exitProcess
+	t.Cleanup(func() { exitProcess = origExit })
+
+	exited := make(chan int, 1)
+	exitProcess = func(code int) { exited <- code }
+
+	agentMgr, taskMgr := newShutdownTestManagers(t, &mockDockerManager{}, &mockExecutor{})
+	orch := NewWorkstreamOrchestrator(agentMgr, taskMgr, DefaultWorkstreamConfig())
+
+	sigCh := make(chan os.Signal, 2)
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		orch.watchSignals(sigCh, time.Millisecond, done)
+		close(finished)
+	}()
+
+	sigCh <- syscall.SIGTERM
+	deadline := time.After(time.Second)
+	for !orch.ShuttingDown() {
+		select {
+		case <-deadline:
+			t.Fatal("first signal should start a shutdown")
+		case <-time.After(time.Millisecond):
+		}
+	}
+
+	sigCh <- os.Interrupt
+	select {
+	case code := <-exited:
+		if code != 1 {
+			t.Errorf("exit code = %d, want 1", code)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("second signal should force exit")
+	}
+	<-finished
+}
@@ -0,0 +1,54 @@
+package agent
+
+import (
+	"sync"
+	"time"
+)
+
+// SpawnLimiter throttles agent creation. Creating an agent means a worktree
+// checkout and a container start, and a burst of them at once can overwhelm
+// the Docker daemon and disk, so the limiter caps how many run at the same
+// time and staggers their starts. A nil SpawnLimiter doesn't limit anything.
+type SpawnLimiter struct {
+	slots    chan struct{}
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time // Earliest start for the next spawn
+}
+
+// NewSpawnLimiter returns a limiter allowing maxConcurrent spawns at once,
+// each starting at least interval after the previous one. maxConcurrent
+// below 1 is treated as 1.
+func NewSpawnLimiter(maxConcurrent int, interval time.Duration) *SpawnLimiter {
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+	return &SpawnLimiter{
+		slots:    make(chan struct{}, maxConcurrent),
+		interval: interval,
+	}
+}
+
+// Acquire blocks until a spawn may start, and returns a function to call
+// once the spawn has finished.
+func (l *SpawnLimiter) Acquire() (release func()) {
+	if l == nil {
+		return func() {}
+	}
+
+	l.slots <- struct{}{}
+
+	l.mu.Lock()
+	now := time.Now()
+	start := l.next
+	if start.Before(now) {
+		start = now
+	}
+	l.next = start.Add(l.interval)
+	l.mu.Unlock()
+
+	time.Sleep(time.Until(start))
+
+	return func() { <-l.slots }
+}
@@ -0,0 +1,82 @@
+package agent
+
+import (
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSpawnLimiter_MaxConcurrent(t *testing.T) {
+	limiter := NewSpawnLimiter(2, 0)
+
+	var mu sync.Mutex
+	active, peak := 0, 0
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release := limiter.Acquire()
+			defer release()
+
+			mu.Lock()
+			active++
+			if active > peak {
+				peak = active
+			}
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			active--
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if peak > 2 {
+		t.Errorf("peak concurrent spawns = %d, want at most 2", peak)
+	}
+	if peak < 2 {
+		t.Errorf("peak concurrent spawns = %d, want the limiter to allow 2", peak)
+	}
+}
+
+func TestSpawnLimiter_Interval(t *testing.T) {
+	limiter := NewSpawnLimiter(10, 20*time.Millisecond)
+
+	var mu sync.Mutex
+	var starts []time.Time
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release := limiter.Acquire()
+			defer release()
+
+			mu.Lock()
+			starts = append(starts, time.Now())
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+	for i := 1; i < len(starts); i++ {
+		// Allow for timer granularity
+		if gap := starts[i].Sub(starts[i-1]); gap < 15*time.Millisecond {
+			t.Errorf("spawn %d started %v after the previous one, want about 20ms", i, gap)
+		}
+	}
+}
+
+func TestSpawnLimiter_Nil(t *testing.T) {
+	var limiter *SpawnLimiter
+	release := limiter.Acquire()
+	release()
+}
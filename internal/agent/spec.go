@@ -0,0 +1,105 @@
+package agent
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/bkonkle/tanuki/internal/docker"
+	"gopkg.in/yaml.v3"
+)
+
+// SpawnSpec is a saved spawn, read from a YAML file so a complex setup can
+// be version-controlled and shared instead of retyped as flags. Its fields
+// map to SpawnOptions, plus the agent names to spawn.
+type SpawnSpec struct {
+	// Name is the agent to spawn (optional; the command line's name wins)
+	Name string `yaml:"name"`
+	// Count spawns agent-1 through agent-<count> when no name is given
+	Count int `yaml:"count"`
+
+	Branch     string            `yaml:"branch"`
+	Workstream string            `yaml:"workstream"`
+	Memory     string            `yaml:"memory"`
+	CPUs       string            `yaml:"cpus"`
+	Labels     map[string]string `yaml:"labels"`
+	Network    string            `yaml:"network"`
+	PinnedTask string            `yaml:"pinned_task"`
+
+	// NoNetwork disables the container's networking, like --no-network
+	NoNetwork bool `yaml:"no_network"`
+}
+
+// LoadSpawnSpec reads and validates a spawn spec file. Fields that don't
+// belong to SpawnSpec are reported as errors rather than ignored, so a typo
+// can't silently drop part of the setup.
+func LoadSpawnSpec(path string) (*SpawnSpec, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - path is given by the user
+	if err != nil {
+		return nil, fmt.Errorf("read spawn spec: %w", err)
+	}
+
+	spec, err := ParseSpawnSpec(data)
+	if err != nil {
+		return nil, fmt.Errorf("spawn spec %s: %w", path, err)
+	}
+	return spec, nil
+}
+
+// ParseSpawnSpec decodes and validates a spawn spec, rejecting unknown fields.
+func ParseSpawnSpec(data []byte) (*SpawnSpec, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var spec SpawnSpec
+	if err := decoder.Decode(&spec); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// Validate checks the spec's values with the same rules as the spawn flags.
+func (s *SpawnSpec) Validate() error {
+	if s.Name != "" {
+		if err := validateAgentName(s.Name); err != nil {
+			return fmt.Errorf("name %q: %w", s.Name, err)
+		}
+	}
+	if s.Count < 0 {
+		return fmt.Errorf("count can't be negative (got %d)", s.Count)
+	}
+	if s.Name != "" && s.Count > 1 {
+		return errors.New("cannot set both name and a count above 1")
+	}
+	if s.NoNetwork && s.Network != "" && s.Network != docker.NoNetwork {
+		return fmt.Errorf("cannot set both no_network and network %q", s.Network)
+	}
+	for key, value := range s.Labels {
+		if err := validateLabel(key, value); err != nil {
+			return err
+		}
+	}
+	return s.Options().ValidateResources()
+}
+
+// Options returns the SpawnOptions the spec describes.
+func (s *SpawnSpec) Options() SpawnOptions {
+	opts := SpawnOptions{
+		Branch:     s.Branch,
+		Workstream: s.Workstream,
+		Memory:     s.Memory,
+		CPUs:       s.CPUs,
+		Labels:     s.Labels,
+		Network:    s.Network,
+		PinnedTask: s.PinnedTask,
+	}
+	if s.NoNetwork {
+		opts.Network = docker.NoNetwork
+	}
+	return opts
+}
@@ -0,0 +1,100 @@
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bkonkle/tanuki/internal/docker"
+)
+
+func TestParseSpawnSpec(t *testing.T) {
+	data := `
+name: backend
+branch: feature/api
+workstream: api
+memory: 8g
+cpus: "4"
+labels:
+  team: platform
+pinned_task: API-001
+`
+	spec, err := ParseSpawnSpec([]byte(data))
+	if err != nil {
+		t.Fatalf("ParseSpawnSpec() error = %v", err)
+	}
+
+	want := SpawnOptions{
+		Branch:     "feature/api",
+		Workstream: "api",
+		Memory:     "8g",
+		CPUs:       "4",
+		Labels:     map[string]string{"team": "platform"},
+		PinnedTask: "API-001",
+	}
+	if spec.Name != "backend" {
+		t.Errorf("Name = %q, want backend", spec.Name)
+	}
+	if got := spec.Options(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Options() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseSpawnSpecNoNetwork(t *testing.T) {
+	spec, err := ParseSpawnSpec([]byte("no_network: true\n"))
+	if err != nil {
+		t.Fatalf("ParseSpawnSpec() error = %v", err)
+	}
+	if got := spec.Options().Network; got != docker.NoNetwork {
+		t.Errorf("Options().Network = %q, want %q", got, docker.NoNetwork)
+	}
+}
+
+func TestParseSpawnSpecEmpty(t *testing.T) {
+	spec, err := ParseSpawnSpec(nil)
+	if err != nil {
+		t.Fatalf("ParseSpawnSpec() error = %v", err)
+	}
+	if !reflect.DeepEqual(spec.Options(), SpawnOptions{}) {
+		t.Errorf("Options() = %+v, want zero value", spec.Options())
+	}
+}
+
+func TestParseSpawnSpecInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{"unknown field", "name: backend\nrole: reviewer\n", "field role not found"},
+		{"bad name", "name: Backend\n", "name \"Backend\""},
+		{"negative count", "count: -1\n", "count"},
+		{"name and count", "name: backend\ncount: 2\n", "count"},
+		{"bad memory", "memory: lots\n", "memory"},
+		{"bad label", "labels:\n  team: a b\n", "invalid label"},
+		{"network conflict", "no_network: true\nnetwork: tanuki-net\n", "no_network"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSpawnSpec([]byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseSpawnSpec() error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadSpawnSpec(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "agent.yaml")
+	if err := os.WriteFile(path, []byte("workstream: api\nextra: true\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := LoadSpawnSpec(path)
+	if err == nil || !strings.Contains(err.Error(), path) || !strings.Contains(err.Error(), "extra") {
+		t.Errorf("LoadSpawnSpec() error = %v, want one naming the file and the unknown field", err)
+	}
+}
@@ -0,0 +1,57 @@
+package agent
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bkonkle/tanuki/internal/docker"
+	"github.com/bkonkle/tanuki/internal/task"
+)
+
+// Verify runs a task's verify command through sh in the agent's container,
+// from the worktree, and returns its combined output. passed reports whether
+// the command exited zero; err is set only if it could not be run at all.
+func (m *Manager) Verify(name, command string) (passed bool, output string, err error) {
+	agent, err := m.state.GetAgent(name)
+	if err != nil {
+		return false, "", fmt.Errorf("%w: %q", ErrAgentNotFound, name)
+	}
+
+	var out bytes.Buffer
+	opts := docker.ExecOptions{Stdout: &out, Stderr: &out}
+	code, err := m.docker.ExecCommand(agent.ContainerID, []string{"sh", "-c", command}, opts)
+	if err != nil {
+		return false, out.String(), fmt.Errorf("run verify command: %w", err)
+	}
+	return code == 0, out.String(), nil
+}
+
+// verifyOutputLines is how much of a failed verify command's output is kept
+// in the task failure.
+const verifyOutputLines = 20
+
+// VerifyTask runs t's verify command with verify, copying its output to w.
+// When the command fails, the error wraps task.ErrVerifyFailed and ends with
+// the last lines of its output, so the failure says why.
+func VerifyTask(t *task.Task, w io.Writer, verify func(command string) (bool, string, error)) error {
+	command := t.Completion.Verify
+	_, _ = fmt.Fprintf(w, "\n--- Running verify command: %s ---\n", command)
+
+	passed, output, err := verify(command)
+	_, _ = io.WriteString(w, output)
+	if err != nil {
+		return fmt.Errorf("verify %s: %w", t.ID, err)
+	}
+	if passed {
+		_, _ = fmt.Fprintf(w, "\n=== Verify command passed ===\n")
+		return nil
+	}
+
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	if len(lines) > verifyOutputLines {
+		lines = lines[len(lines)-verifyOutputLines:]
+	}
+	return fmt.Errorf("%w: %s exited non-zero:\n%s", task.ErrVerifyFailed, command, strings.Join(lines, "\n"))
+}
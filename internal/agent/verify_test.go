@@ -0,0 +1,77 @@
+package agent
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/bkonkle/tanuki/internal/task"
+)
+
+func TestVerifyTask(t *testing.T) {
+	tsk := &task.Task{ID: "TASK-001", Completion: &task.CompletionConfig{Verify: "go test ./..."}}
+
+	var manyLines strings.Builder
+	for i := 1; i <= 30; i++ {
+		fmt.Fprintf(&manyLines, "line %d\n", i)
+	}
+
+	tests := []struct {
+		name       string
+		passed     bool
+		output     string
+		runErr     error
+		wantErr    bool
+		wantVerify bool     // error wraps task.ErrVerifyFailed
+		wantIn     []string // substrings of the error
+		wantOut    []string // substrings of the error it must not contain
+	}{
+		{name: "passes", passed: true, output: "ok\n"},
+		{
+			name: "fails", output: "FAIL: TestLogin\n", wantErr: true, wantVerify: true,
+			wantIn: []string{"go test ./...", "FAIL: TestLogin"},
+		},
+		{
+			name: "keeps the tail of long output", output: manyLines.String(), wantErr: true, wantVerify: true,
+			wantIn: []string{"line 11", "line 30"}, wantOut: []string{"line 10\n"},
+		},
+		{name: "cannot run", runErr: errors.New("container gone"), wantErr: true, wantIn: []string{"container gone"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotCommand string
+			verify := func(command string) (bool, string, error) {
+				gotCommand = command
+				return tt.passed, tt.output, tt.runErr
+			}
+
+			var log bytes.Buffer
+			err := VerifyTask(tsk, &log, verify)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("VerifyTask() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if gotCommand != "go test ./..." {
+				t.Errorf("ran %q, want the task's verify command", gotCommand)
+			}
+			if errors.Is(err, task.ErrVerifyFailed) != tt.wantVerify {
+				t.Errorf("errors.Is(err, ErrVerifyFailed) = %v, want %v", !tt.wantVerify, tt.wantVerify)
+			}
+			for _, want := range tt.wantIn {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error = %q, want it to contain %q", err, want)
+				}
+			}
+			for _, unwanted := range tt.wantOut {
+				if strings.Contains(err.Error(), unwanted) {
+					t.Errorf("error = %q, want it to leave out %q", err, unwanted)
+				}
+			}
+			if !strings.Contains(log.String(), tt.output) {
+				t.Errorf("log = %q, want the verify output copied", log.String())
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"log"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/bkonkle/tanuki/internal/task"
@@ -43,11 +44,26 @@ type WorkstreamRunner struct {
 
 	// Output writer for task execution
 	output io.Writer
+
+	// stop is closed when the orchestrator shuts down; a nil channel never
+	// closes. done is closed when Run returns.
+	stop <-chan struct{}
+	done chan struct{}
+
+	// orch is the orchestrator that started the runner, used to cancel
+	// group members on other runners; nil for a standalone runner
+	orch *WorkstreamOrchestrator
+
+	// The running task, and the task whose failure cancelled it, if any
+	mu            sync.Mutex
+	current       string
+	groupFailedBy *task.Task
 }
 
 // WorkstreamConfig configures workstream execution behavior.
 type WorkstreamConfig struct {
-	// PollInterval is how often to check for dependency resolution
+	// PollInterval is how often to check for dependency resolution, and for
+	// edits to the file of the task being run
 	PollInterval time.Duration
 
 	// MaxWaitTime is the maximum time to wait for dependencies
@@ -61,6 +77,33 @@ type WorkstreamConfig struct {
 
 	// Follow enables streaming output
 	Follow bool
+
+	// MaxRetries is how many times a failed task with a retry_prompt is
+	// retried (0 uses a default of 1)
+	MaxRetries int
+
+	// ResumeRetries resumes the failed attempt's Claude session when a task
+	// is retried. See Manager.RetrySession.
+	ResumeRetries bool
+
+	// SkipVerify completes tasks without running their completion.verify
+	// command after a successful run
+	SkipVerify bool
+
+	// RestartOnTaskChange stops a run whose task file body was edited since
+	// it started and reruns the task with the new content. When false the
+	// change is only logged.
+	RestartOnTaskChange bool
+
+	// GroupFailure decides what happens to the rest of a task group when
+	// one of its members fails for good: "block" (the default) blocks them
+	// and "fail" fails them. See project.GroupFailure.
+	GroupFailure string
+
+	// KeepContainerOnFailure holds the agent, with its container stopped
+	// but not removed, when a task fails for good, and stops the runner.
+	// See Manager.Hold.
+	KeepContainerOnFailure bool
 }
 
 // DefaultWorkstreamConfig returns default configuration.
@@ -91,6 +134,7 @@ func NewWorkstreamRunner(
 		agentName:  agentName,
 		config:     config,
 		output:     os.Stdout,
+		done:       make(chan struct{}),
 	}
 }
 
@@ -106,12 +150,6 @@ func buildWorkstreamAgentName(projectName, workstream string) string {
 	return fmt.Sprintf("%s-%s", project, ws)
 }
 
-// buildWorktreeBranch creates the standardized branch name.
-// Format: tanuki/{project}-{workstream}
-func buildWorktreeBranch(projectName, workstream string) string {
-	return fmt.Sprintf("tanuki/%s", buildWorkstreamAgentName(projectName, workstream))
-}
-
 // SetOutput sets the output writer for task execution.
 func (r *WorkstreamRunner) SetOutput(w io.Writer) {
 	r.output = w
@@ -143,12 +181,20 @@ func (r *WorkstreamRunner) SetOnWorkstreamComplete(fn func(workstream string)) {
 }
 
 // Run executes all tasks in the workstream sequentially.
-// Returns when all tasks are complete or an unrecoverable error occurs.
+// Returns when all tasks are complete, the orchestrator shuts down, or an
+// unrecoverable error occurs.
 func (r *WorkstreamRunner) Run() error {
+	defer close(r.done)
+
 	log.Printf("Starting workstream runner: %s (project=%s, workstream=%s)",
 		r.agentName, r.project, r.workstream)
 
 	for {
+		if r.stopping() {
+			log.Printf("Workstream runner %s stopped by shutdown", r.agentName)
+			return nil
+		}
+
 		// Get next pending task for this workstream
 		nextTask, err := r.getNextTask()
 		if err != nil {
@@ -165,24 +211,78 @@ func (r *WorkstreamRunner) Run() error {
 		// Wait for dependencies if blocked
 		if blocked, _ := r.taskMgr.IsBlocked(nextTask.ID); blocked {
 			if err := r.waitForDependencies(nextTask); err != nil {
+				if r.stopping() {
+					continue
+				}
 				return fmt.Errorf("wait for dependencies: %w", err)
 			}
 		}
 
 		// Execute the task
 		if err := r.executeTask(nextTask); err != nil {
+			// Another orchestrator got to it first; move on to the next task
+			if errors.Is(err, errTaskClaimed) {
+				log.Printf("Task %s already claimed, skipping", nextTask.ID)
+				continue
+			}
+
+			// Edited mid-run with RestartOnTaskChange; it's pending again
+			if errors.Is(err, errTaskChanged) {
+				continue
+			}
+
+			// Another member of its group failed; it's been set aside
+			if errors.Is(err, errGroupCancelled) {
+				log.Printf("Task %s cancelled: %v", nextTask.ID, err)
+				continue
+			}
+
+			// A run cut short by shutdown goes back to pending for the next run
+			if r.stopping() {
+				r.requeueInterrupted(nextTask.ID)
+				continue
+			}
+
+			// Agent busy is transient - put the task back and retry later
+			if errors.Is(err, ErrAgentBusy) {
+				log.Printf("Agent %s busy, requeueing task %s", r.agentName, nextTask.ID)
+				if unassignErr := r.taskMgr.Unassign(nextTask.ID); unassignErr != nil {
+					log.Printf("Warning: failed to unassign task: %v", unassignErr)
+				}
+				r.sleep(r.config.PollInterval)
+				continue
+			}
+
 			// Mark task as failed and save error message
 			// Log path will be empty for now - needs to be integrated at agent manager level
 			if updateErr := r.taskMgr.UpdateFailure(nextTask.ID, err, ""); updateErr != nil {
 				log.Printf("Warning: failed to update task failure: %v", updateErr)
 			}
 
+			// Retry with the task's retry_prompt guidance while attempts remain
+			if r.retryTask(nextTask) {
+				continue
+			}
+
+			log.Printf("Task %s failed: %v", nextTask.ID, err)
+			r.failGroup(nextTask)
+
+			// Hold before reporting, so the failed run's container is kept
+			// for inspection. The runner's only agent is then out of the
+			// pool, so the rest of the workstream waits for the next run.
+			if r.config.KeepContainerOnFailure {
+				r.holdAgent(nextTask.ID)
+				if r.onTaskFailed != nil {
+					r.onTaskFailed(nextTask.ID, err)
+				}
+				return fmt.Errorf("task %s failed, holding agent %s: %w", nextTask.ID, r.agentName, err)
+			}
+
 			if r.onTaskFailed != nil {
 				r.onTaskFailed(nextTask.ID, err)
 			}
 
 			// Continue to next task instead of stopping the workstream
-			log.Printf("Task %s failed: %v", nextTask.ID, err)
 			continue
 		}
 	}
@@ -190,6 +290,80 @@ func (r *WorkstreamRunner) Run() error {
 
 var errNoMoreTasks = errors.New("no more tasks in workstream")
 
+// errTaskClaimed is returned by executeTask when another orchestrator has
+// already claimed the task.
+var errTaskClaimed = errors.New("task already claimed")
+
+// errTaskChanged is returned by executeTask when the task file was edited
+// mid-run and the run was stopped to rerun it with the new content.
+var errTaskChanged = errors.New("task changed during run")
+
+// errStopping is returned by waits cut short by shutdown.
+var errStopping = errors.New("workstream runner stopping")
+
+// stopping reports whether the orchestrator has begun shutting down.
+func (r *WorkstreamRunner) stopping() bool {
+	select {
+	case <-r.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// sleep waits for d, returning false early if the runner is stopped.
+func (r *WorkstreamRunner) sleep(d time.Duration) bool {
+	select {
+	case <-r.stop:
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
+
+// requeueInterrupted returns a task cut short by shutdown to pending so the
+// next run picks it up again.
+func (r *WorkstreamRunner) requeueInterrupted(taskID string) {
+	log.Printf("Task %s interrupted by shutdown, returning to pending", taskID)
+	if err := r.taskMgr.Unassign(taskID); err != nil {
+		log.Printf("Warning: failed to reset task %s: %v", taskID, err)
+	}
+}
+
+// holdAgent keeps the runner's agent out of the pool after taskID failed on
+// it, with its container stopped for inspection.
+func (r *WorkstreamRunner) holdAgent(taskID string) {
+	if err := r.agentMgr.Hold(r.agentName, taskID); err != nil {
+		log.Printf("Warning: failed to hold agent %s after %s failed: %v", r.agentName, taskID, err)
+		return
+	}
+	log.Printf("Holding agent %s for inspection after %s failed; release it with: tanuki agent release %s", r.agentName, taskID, r.agentName)
+}
+
+// retryTask requeues a failed task if it has a retry_prompt and retries left.
+// Returns true if the task was requeued.
+func (r *WorkstreamRunner) retryTask(t *task.Task) bool {
+	maxRetries := r.config.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = 1
+	}
+
+	if !t.CanRetry(maxRetries) {
+		if t.RetryPrompt != "" {
+			log.Printf("Task %s exhausted %d retries, leaving it failed", t.ID, maxRetries)
+		}
+		return false
+	}
+
+	if err := r.taskMgr.Retry(t.ID); err != nil {
+		log.Printf("Warning: failed to retry task %s: %v", t.ID, err)
+		return false
+	}
+
+	log.Printf("Retrying task %s (retry %d/%d)", t.ID, t.RetryCount, maxRetries)
+	return true
+}
+
 // getNextTask returns the next pending task for this workstream.
 func (r *WorkstreamRunner) getNextTask() (*task.Task, error) {
 	var tasks []*task.Task
@@ -200,8 +374,11 @@ func (r *WorkstreamRunner) getNextTask() (*task.Task, error) {
 		tasks = r.taskMgr.GetByWorkstream(r.workstream)
 	}
 
-	// Find first pending task
+	// Find first pending task no one else has claimed
 	for _, t := range tasks {
+		if t.AssignedTo != "" || t.BlockedReason != "" {
+			continue
+		}
 		if t.Status == task.StatusPending || t.Status == task.StatusBlocked {
 			return t, nil
 		}
@@ -239,7 +416,9 @@ func (r *WorkstreamRunner) waitForDependencies(t *task.Task) error {
 		}
 
 		// Wait before next check
-		time.Sleep(r.config.PollInterval)
+		if !r.sleep(r.config.PollInterval) {
+			return errStopping
+		}
 
 		// Re-scan tasks to pick up status changes from disk
 		if _, err := r.taskMgr.Scan(); err != nil {
@@ -250,6 +429,16 @@ func (r *WorkstreamRunner) waitForDependencies(t *task.Task) error {
 
 // executeTask runs a single task through the agent.
 func (r *WorkstreamRunner) executeTask(t *task.Task) error {
+	// Claim the task so another orchestrator sharing the tasks directory
+	// can't run it too
+	claimed, err := r.taskMgr.Claim(t.ID, r.agentName)
+	if err != nil {
+		return fmt.Errorf("claim task: %w", err)
+	}
+	if !claimed {
+		return errTaskClaimed
+	}
+
 	log.Printf("Executing task %s: %s", t.ID, t.Title)
 
 	// Notify task start
@@ -257,18 +446,13 @@ func (r *WorkstreamRunner) executeTask(t *task.Task) error {
 		r.onTaskStart(t.ID)
 	}
 
-	// Assign task to agent
-	if err := r.taskMgr.Assign(t.ID, r.agentName); err != nil {
-		return fmt.Errorf("assign task: %w", err)
-	}
-
 	// Mark as in progress
 	if err := r.taskMgr.UpdateStatus(t.ID, task.StatusInProgress); err != nil {
 		return fmt.Errorf("update status to in_progress: %w", err)
 	}
 
 	// Build prompt
-	prompt := buildTaskPrompt(t)
+	prompt := BuildTaskPrompt(t)
 
 	// Execute via agent manager
 	runOpts := RunOptions{
@@ -276,11 +460,55 @@ func (r *WorkstreamRunner) executeTask(t *task.Task) error {
 		MaxTurns: r.config.MaxTurns,
 		Model:    r.config.Model,
 		Output:   r.output,
+		TaskID:   t.ID,
+		OnCheckpoint: func(checkpoint string) {
+			log.Printf("Task %s checkpoint: %s", t.ID, checkpoint)
+			if err := r.taskMgr.AddCheckpoint(t.ID, checkpoint); err != nil {
+				log.Printf("Warning: failed to record checkpoint: %v", err)
+			}
+		},
+		OnProgress: func(percent int) {
+			if err := r.taskMgr.SetProgress(t.ID, percent); err != nil {
+				log.Printf("Warning: failed to record progress: %v", err)
+			}
+		},
+	}
+	ApplyTaskTools(&runOpts, t)
+	ApplyTaskSignal(&runOpts, t)
+	if r.config.ResumeRetries {
+		runOpts.ResumeSessionID = r.agentMgr.RetrySession(r.agentName, t)
 	}
 
-	err := r.agentMgr.Run(r.agentName, prompt, runOpts)
-	if err != nil {
-		return fmt.Errorf("agent run: %w", err)
+	// Watch the task file for edits while the agent works on it
+	watchDone := make(chan struct{})
+	restartCh := make(chan bool, 1)
+	go func() { restartCh <- r.watchTaskChanges(t, watchDone) }()
+
+	r.startTask(t.ID)
+	runErr := r.agentMgr.Run(r.agentName, prompt, runOpts)
+	close(watchDone)
+	restart := <-restartCh
+	if failed := r.finishTask(); failed != nil {
+		return r.cancelledForGroup(t.ID, failed)
+	}
+	if restart {
+		return r.restartChangedTask(t.ID)
+	}
+	if runErr != nil {
+		return fmt.Errorf("agent run: %w", runErr)
+	}
+
+	if !r.config.SkipVerify && t.Completion != nil && t.Completion.Verify != "" {
+		verify := func(command string) (bool, string, error) {
+			return r.agentMgr.Verify(r.agentName, command)
+		}
+		if err := VerifyTask(t, r.output, verify); err != nil {
+			return err
+		}
+	}
+
+	if err := r.agentMgr.CheckExpects(r.agentName, t); err != nil {
+		return err
 	}
 
 	// Mark task as complete
@@ -297,21 +525,113 @@ func (r *WorkstreamRunner) executeTask(t *task.Task) error {
 	return nil
 }
 
-// buildTaskPrompt creates the prompt for Claude from a task.
-func buildTaskPrompt(t *task.Task) string {
+// watchTaskChanges polls t's file until done is closed. An edit to its body
+// is logged once and, with RestartOnTaskChange, stops the agent so the run
+// ends and the task can rerun with the new content. Returns true if it
+// stopped the agent.
+func (r *WorkstreamRunner) watchTaskChanges(t *task.Task, done <-chan struct{}) bool {
+	hash := t.ContentHash
+	for {
+		select {
+		case <-done:
+			return false
+		case <-r.stop:
+			return false
+		case <-time.After(r.config.PollInterval):
+		}
+
+		current, err := task.ParseFile(t.FilePath)
+		if err != nil || current.ContentHash == "" || current.ContentHash == hash {
+			continue
+		}
+		hash = current.ContentHash
+
+		if !r.config.RestartOnTaskChange {
+			log.Printf("Warning: task %s changed while %s is working on it; the agent is using the old content", t.ID, r.agentName)
+			continue
+		}
+
+		log.Printf("Task %s changed while %s is working on it, restarting with the new content", t.ID, r.agentName)
+		// Runs don't watch for cancellation, so stop the container to end this one
+		if err := r.agentMgr.Stop(r.agentName); err != nil {
+			log.Printf("Warning: failed to stop agent %s: %v", r.agentName, err)
+		}
+		return true
+	}
+}
+
+// restartChangedTask brings back the agent stopped by watchTaskChanges and
+// returns the task to pending with its current content.
+func (r *WorkstreamRunner) restartChangedTask(taskID string) error {
+	if err := r.agentMgr.Start(r.agentName); err != nil {
+		log.Printf("Warning: failed to restart agent %s: %v", r.agentName, err)
+	}
+
+	// Load the edit before resetting the task, so writing the reset doesn't
+	// put the old content back
+	if _, err := r.taskMgr.Scan(); err != nil {
+		log.Printf("Warning: failed to re-scan tasks: %v", err)
+	}
+	if err := r.taskMgr.Unassign(taskID); err != nil {
+		log.Printf("Warning: failed to reset task %s: %v", taskID, err)
+	}
+	return errTaskChanged
+}
+
+// ApplyTaskTools copies a task's allowed_tools and disallowed_tools into opts,
+// keeping any list opts already sets (such as from CLI flags). Run then falls
+// back to the workstream and config defaults for lists that are still empty.
+func ApplyTaskTools(opts *RunOptions, t *task.Task) {
+	if len(opts.AllowedTools) == 0 {
+		opts.AllowedTools = t.AllowedTools
+	}
+	if len(opts.DisallowedTools) == 0 {
+		opts.DisallowedTools = t.DisallowedTools
+	}
+}
+
+// ApplyTaskSignal sets opts.CompletionSignal from a task's completion.signal,
+// so a single-shot run is checked for it. Ralph mode checks the signal
+// itself on every iteration and shouldn't use this.
+func ApplyTaskSignal(opts *RunOptions, t *task.Task) {
+	if t.Completion != nil {
+		opts.CompletionSignal = t.Completion.Signal
+	}
+}
+
+// BuildTaskPrompt creates the prompt for Claude from a task. The completion
+// criteria list the expected files and the verify command and signal the
+// task is checked with; a ralph block's verify and signal take precedence,
+// since they are what a Ralph loop checks.
+func BuildTaskPrompt(t *task.Task) string {
 	prompt := fmt.Sprintf("# Task: %s\n\n", t.Title)
 	prompt += t.Content
 
-	if t.Completion != nil {
+	if t.Completion != nil || t.Ralph != nil || len(t.Expects) > 0 {
 		prompt += "\n\n## Completion Criteria\n\n"
-		if t.Completion.Verify != "" {
-			prompt += fmt.Sprintf("Run this command to verify: `%s`\n", t.Completion.Verify)
+	}
+	if len(t.Expects) > 0 {
+		prompt += "These files must exist in the repository when you finish:\n"
+		for _, pattern := range t.Expects {
+			prompt += fmt.Sprintf("- `%s`\n", pattern)
+		}
+	}
+	if t.Completion != nil || t.Ralph != nil {
+		loop := t.RalphLoop()
+		if loop.Verify != "" {
+			prompt += fmt.Sprintf("Run this command to verify: `%s`\n", loop.Verify)
+		}
+		if loop.Signal != "" {
+			prompt += fmt.Sprintf("Say **%s** when complete.\n", loop.Signal)
 		}
-		if t.Completion.Signal != "" {
-			prompt += fmt.Sprintf("Say **%s** when complete.\n", t.Completion.Signal)
+		if t.Completion != nil && t.Completion.HTTP != nil && t.Completion.HTTP.URL != "" {
+			prompt += fmt.Sprintf("Completion is checked by requesting %s.\n", t.Completion.HTTP.URL)
 		}
 	}
 
+	prompt += task.CheckpointPrompt(t)
+	prompt += task.RetryGuidance(t)
+
 	return prompt
 }
 
@@ -323,9 +643,23 @@ type WorkstreamOrchestrator struct {
 	// Concurrency limits per workstream
 	workstreamConcurrency map[string]int
 
+	// Retry limits per workstream
+	workstreamMaxRetries map[string]int
+
 	// Active runners by workstream
 	activeRunners map[string]int
 
+	// Throttles agent creation; nil doesn't throttle
+	spawnLimiter *SpawnLimiter
+
+	// Runners started so far, stopped together by Shutdown
+	mu      sync.Mutex
+	runners []*WorkstreamRunner
+
+	// stop is closed when Shutdown begins
+	stop         chan struct{}
+	shutdownOnce sync.Once
+
 	// Configuration
 	config WorkstreamConfig
 }
@@ -336,12 +670,14 @@ func NewWorkstreamOrchestrator(agentMgr *Manager, taskMgr *task.Manager, config
 		agentMgr:              agentMgr,
 		taskMgr:               taskMgr,
 		workstreamConcurrency: make(map[string]int),
+		workstreamMaxRetries:  make(map[string]int),
 		activeRunners:         make(map[string]int),
+		stop:                  make(chan struct{}),
 		config:                config,
 	}
 }
 
-// SetWorkstreamConcurrency sets the concurrency limit for a workstream.
+// SetWorkstreamConcurrency sets the agent concurrency limit (runners) for a workstream.
 func (o *WorkstreamOrchestrator) SetWorkstreamConcurrency(workstream string, limit int) {
 	if limit <= 0 {
 		limit = 1
@@ -349,6 +685,16 @@ func (o *WorkstreamOrchestrator) SetWorkstreamConcurrency(workstream string, lim
 	o.workstreamConcurrency[workstream] = limit
 }
 
+// SetWorkstreamMaxRetries sets the retry limit for failed tasks in a workstream.
+func (o *WorkstreamOrchestrator) SetWorkstreamMaxRetries(workstream string, limit int) {
+	o.workstreamMaxRetries[workstream] = limit
+}
+
+// SetSpawnLimiter throttles the agents spawned for new workstreams.
+func (o *WorkstreamOrchestrator) SetSpawnLimiter(limiter *SpawnLimiter) {
+	o.spawnLimiter = limiter
+}
+
 // CanStartWorkstream checks if a new workstream can be started.
 func (o *WorkstreamOrchestrator) CanStartWorkstream(workstream string) bool {
 	limit := o.workstreamConcurrency[workstream]
@@ -361,33 +707,45 @@ func (o *WorkstreamOrchestrator) CanStartWorkstream(workstream string) bool {
 // StartWorkstream spawns an agent and starts running the workstream.
 // Returns the runner for monitoring, or an error if the workstream cannot be started.
 func (o *WorkstreamOrchestrator) StartWorkstream(projectName, workstream string) (*WorkstreamRunner, error) {
+	if o.ShuttingDown() {
+		return nil, fmt.Errorf("orchestrator is shutting down")
+	}
 	if !o.CanStartWorkstream(workstream) {
 		return nil, fmt.Errorf("concurrency limit reached for workstream %s", workstream)
 	}
 
 	agentName := buildWorkstreamAgentName(projectName, workstream)
-	branchName := buildWorktreeBranch(projectName, workstream)
 
 	// Check if agent already exists
 	_, err := o.agentMgr.Get(agentName)
 	if err != nil {
-		// Agent doesn't exist, spawn it
-		log.Printf("Spawning agent %s for workstream (branch: %s)", agentName, branchName)
+		// Agent doesn't exist, spawn it on its own new branch
+		release := o.spawnLimiter.Acquire()
+		log.Printf("Spawning agent %s for workstream (branch: %s)", agentName, o.agentMgr.git.GetBranchName(agentName))
 
 		_, err = o.agentMgr.Spawn(agentName, SpawnOptions{
-			Branch:     branchName,
 			Workstream: workstream,
 		})
+		release()
 		if err != nil {
 			return nil, fmt.Errorf("spawn agent: %w", err)
 		}
 	}
 
 	// Create runner
-	runner := NewWorkstreamRunner(o.agentMgr, o.taskMgr, projectName, workstream, o.config)
+	runnerConfig := o.config
+	if limit, ok := o.workstreamMaxRetries[workstream]; ok {
+		runnerConfig.MaxRetries = limit
+	}
+	runner := NewWorkstreamRunner(o.agentMgr, o.taskMgr, projectName, workstream, runnerConfig)
+	runner.stop = o.stop
+	runner.orch = o
 
 	// Track active runner
 	o.activeRunners[workstream]++
+	o.mu.Lock()
+	o.runners = append(o.runners, runner)
+	o.mu.Unlock()
 
 	// Set up completion callback to decrement counter
 	originalOnComplete := runner.onTaskComplete
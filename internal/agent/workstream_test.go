@@ -1,8 +1,20 @@
 package agent
 
 import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/bkonkle/tanuki/internal/docker"
+	"github.com/bkonkle/tanuki/internal/executor"
+	"github.com/bkonkle/tanuki/internal/state"
+	"github.com/bkonkle/tanuki/internal/task"
 )
 
 func TestBuildWorkstreamAgentName(t *testing.T) {
@@ -45,17 +57,49 @@ func TestDefaultWorkstreamConfig(t *testing.T) {
 	}
 }
 
-func TestBuildWorktreeBranch(t *testing.T) {
-	// Test that buildWorktreeBranch produces expected format
-	branch := buildWorktreeBranch("auth-feature", "oauth")
-	if branch != "tanuki/auth-feature-oauth" {
-		t.Errorf("buildWorktreeBranch = %q, want tanuki/auth-feature-oauth", branch)
+// mockWorkstreamManager returns bare info for any workstream.
+type mockWorkstreamManager struct{}
+
+func (mockWorkstreamManager) GetWorkstreamInfo(name string) (*WorkstreamInfo, error) {
+	return &WorkstreamInfo{Name: name}, nil
+}
+
+func TestWorkstreamOrchestrator_StartWorkstream_FreshRepo(t *testing.T) {
+	// A fresh repo has no tanuki branches; only CreateWorktree makes one
+	branches := map[string]bool{"main": true}
+	git := &mockGitManager{
+		createWorktreeFn: func(name string) (string, error) {
+			branches["tanuki/"+name] = true
+			return t.TempDir(), nil
+		},
+		createForBranchFn: func(_ string, branch string) (string, error) {
+			if !branches[branch] {
+				return "", fmt.Errorf("branch not found: %s", branch)
+			}
+			return t.TempDir(), nil
+		},
+	}
+	state := newMockStateManager()
+	agentMgr, err := NewManager(testConfig(), git, &mockDockerManager{}, state, &mockExecutor{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	agentMgr.SetWorkstreamManager(mockWorkstreamManager{})
+
+	orch := NewWorkstreamOrchestrator(agentMgr, nil, DefaultWorkstreamConfig())
+	if _, err := orch.StartWorkstream("auth-feature", "oauth"); err != nil {
+		t.Fatalf("StartWorkstream() error: %v", err)
 	}
 
-	// Test with spaces (should be normalized)
-	branch = buildWorktreeBranch("My Project", "Feature A")
-	if branch != "tanuki/my-project-feature-a" {
-		t.Errorf("buildWorktreeBranch = %q, want tanuki/my-project-feature-a", branch)
+	ag, err := agentMgr.Get("auth-feature-oauth")
+	if err != nil {
+		t.Fatalf("agent not spawned: %v", err)
+	}
+	if ag.Branch != "tanuki/auth-feature-oauth" || !branches[ag.Branch] {
+		t.Errorf("agent branch = %q, want a new tanuki/auth-feature-oauth branch", ag.Branch)
+	}
+	if ag.Workstream != "oauth" {
+		t.Errorf("agent workstream = %q, want oauth", ag.Workstream)
 	}
 }
 
@@ -137,3 +181,451 @@ func TestWorkstreamOrchestrator_GetActiveCount(t *testing.T) {
 		t.Errorf("active count = %d, want 3", orch.GetActiveCount("auth"))
 	}
 }
+
+// writeWorkstreamTask writes a pending TASK-001 in the backend workstream,
+// with extra frontmatter lines, and returns the project root.
+func writeWorkstreamTask(t *testing.T, extra string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	tasksDir := filepath.Join(dir, "tasks")
+	if err := os.MkdirAll(tasksDir, 0750); err != nil {
+		t.Fatal(err)
+	}
+	content := "---\nid: TASK-001\ntitle: Test\nworkstream: backend\nstatus: pending\n" + extra + "---\n\nContent\n"
+	if err := os.WriteFile(filepath.Join(tasksDir, "TASK-001.md"), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// newTestRunner returns a backend workstream runner over the tasks in dir,
+// with an existing, idle backend agent.
+func newTestRunner(t *testing.T, dir string, docker *mockDockerManager, exec *mockExecutor, config WorkstreamConfig) (*WorkstreamRunner, *task.Manager) {
+	t.Helper()
+
+	taskMgr := task.NewManager(&task.Config{ProjectRoot: dir})
+	if _, err := taskMgr.Scan(); err != nil {
+		t.Fatal(err)
+	}
+	stateMgr := newMockStateManager()
+	stateMgr.agents["backend"] = &Agent{Name: "backend", ContainerID: "container-backend", Status: "idle"}
+	agentMgr, err := NewManager(testConfig(), &mockGitManager{}, docker, stateMgr, exec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runner := NewWorkstreamRunner(agentMgr, taskMgr, "", "backend", config)
+	runner.SetOutput(io.Discard)
+	return runner, taskMgr
+}
+
+func TestWorkstreamRunner_ClaimRace(t *testing.T) {
+	dir := writeWorkstreamTask(t, "")
+
+	// Two orchestrators share the tasks directory, each with its own agent
+	var runs atomic.Int32
+	exec := &mockExecutor{
+		runFollowFn: func(string, string, executor.ExecuteOptions, io.Writer) (*executor.ExecutionResult, error) {
+			runs.Add(1)
+			return &executor.ExecutionResult{StartedAt: time.Now(), CompletedAt: time.Now()}, nil
+		},
+	}
+	runners := make([]*WorkstreamRunner, 2)
+	for i := range runners {
+		runners[i], _ = newTestRunner(t, dir, &mockDockerManager{}, exec, DefaultWorkstreamConfig())
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(runners))
+	for i, runner := range runners {
+		wg.Add(1)
+		go func(i int, runner *WorkstreamRunner) {
+			defer wg.Done()
+			errs[i] = runner.Run()
+		}(i, runner)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("runner %d Run() error: %v", i, err)
+		}
+	}
+	if got := runs.Load(); got != 1 {
+		t.Errorf("task ran %d times, want 1", got)
+	}
+}
+
+func TestWorkstreamRunner_Verify(t *testing.T) {
+	tests := []struct {
+		name       string
+		skipVerify bool
+		exitCode   int
+		wantStatus task.Status
+		wantRan    bool
+	}{
+		{name: "passes", exitCode: 0, wantStatus: task.StatusComplete, wantRan: true},
+		{name: "fails", exitCode: 1, wantStatus: task.StatusFailed, wantRan: true},
+		{name: "skipped", skipVerify: true, exitCode: 1, wantStatus: task.StatusComplete},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeWorkstreamTask(t, "completion:\n  verify: \"make test\"\n")
+
+			var ran []string
+			dockerMgr := &mockDockerManager{
+				execCommandFn: func(_ string, command []string, _ docker.ExecOptions) (int, error) {
+					ran = command
+					return tt.exitCode, nil
+				},
+			}
+			config := DefaultWorkstreamConfig()
+			config.SkipVerify = tt.skipVerify
+			runner, taskMgr := newTestRunner(t, dir, dockerMgr, &mockExecutor{}, config)
+
+			if err := runner.Run(); err != nil {
+				t.Fatalf("Run() error: %v", err)
+			}
+
+			got, err := taskMgr.Get("TASK-001")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", got.Status, tt.wantStatus)
+			}
+			if tt.wantRan != (len(ran) > 0 && ran[len(ran)-1] == "make test") {
+				t.Errorf("ran %v, want verify run = %v", ran, tt.wantRan)
+			}
+			if tt.wantStatus == task.StatusFailed && got.FailureCategory != task.FailureVerify {
+				t.Errorf("failure category = %q, want %q", got.FailureCategory, task.FailureVerify)
+			}
+		})
+	}
+}
+
+func TestWorkstreamRunner_KeepContainerOnFailure(t *testing.T) {
+	tests := []struct {
+		name       string
+		keep       bool
+		wantErr    bool
+		wantStatus state.Status
+		wantNext   task.Status // status of the workstream's next task
+	}{
+		{name: "keeps container", keep: true, wantErr: true, wantStatus: state.StatusHeld, wantNext: task.StatusPending},
+		{name: "moves on", wantStatus: state.StatusIdle, wantNext: task.StatusFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeWorkstreamTask(t, "")
+			next := "---\nid: TASK-002\ntitle: Next\nworkstream: backend\nstatus: pending\n---\n\nContent\n"
+			if err := os.WriteFile(filepath.Join(dir, "tasks", "TASK-002.md"), []byte(next), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			exec := &mockExecutor{
+				runFollowFn: func(string, string, executor.ExecuteOptions, io.Writer) (*executor.ExecutionResult, error) {
+					return nil, fmt.Errorf("run failed")
+				},
+			}
+			config := DefaultWorkstreamConfig()
+			config.KeepContainerOnFailure = tt.keep
+			runner, taskMgr := newTestRunner(t, dir, &mockDockerManager{}, exec, config)
+
+			if err := runner.Run(); (err != nil) != tt.wantErr {
+				t.Fatalf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			agent, err := runner.agentMgr.state.GetAgent("backend")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if agent.Status != tt.wantStatus {
+				t.Errorf("agent status = %s, want %s", agent.Status, tt.wantStatus)
+			}
+			if tt.keep && agent.HeldFor != "TASK-001" {
+				t.Errorf("HeldFor = %q, want TASK-001", agent.HeldFor)
+			}
+			got, err := taskMgr.Get("TASK-002")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Status != tt.wantNext {
+				t.Errorf("next task status = %s, want %s", got.Status, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestWorkstreamRunner_RestartOnTaskChange(t *testing.T) {
+	tests := []struct {
+		name      string
+		restart   bool
+		wantRuns  int
+		wantFinal string // content of the last prompt
+	}{
+		{name: "restarts", restart: true, wantRuns: 2, wantFinal: "New content"},
+		{name: "only warns", wantRuns: 1, wantFinal: "Content"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeWorkstreamTask(t, "")
+			path := filepath.Join(dir, "tasks", "TASK-001.md")
+
+			stopped := make(chan struct{}, 1)
+			dockerMgr := &mockDockerManager{
+				stopContainerFn: func(string) error {
+					stopped <- struct{}{}
+					return nil
+				},
+			}
+			var prompts []string
+			exec := &mockExecutor{
+				runFollowFn: func(_ string, prompt string, _ executor.ExecuteOptions, _ io.Writer) (*executor.ExecutionResult, error) {
+					prompts = append(prompts, prompt)
+					if len(prompts) > 1 {
+						return &executor.ExecutionResult{}, nil
+					}
+
+					// Edit the body mid-run
+					data, err := os.ReadFile(path)
+					if err != nil {
+						return nil, err
+					}
+					edited := strings.Replace(string(data), "Content", "New content", 1)
+					if err := os.WriteFile(path, []byte(edited), 0600); err != nil {
+						return nil, err
+					}
+
+					select {
+					case <-stopped:
+						return nil, fmt.Errorf("container stopped")
+					case <-time.After(100 * time.Millisecond):
+						return &executor.ExecutionResult{}, nil
+					}
+				},
+			}
+			config := DefaultWorkstreamConfig()
+			config.PollInterval = 5 * time.Millisecond
+			config.RestartOnTaskChange = tt.restart
+			runner, taskMgr := newTestRunner(t, dir, dockerMgr, exec, config)
+
+			if err := runner.Run(); err != nil {
+				t.Fatalf("Run() error: %v", err)
+			}
+
+			if len(prompts) != tt.wantRuns {
+				t.Fatalf("ran %d times, want %d", len(prompts), tt.wantRuns)
+			}
+			if last := prompts[len(prompts)-1]; !strings.Contains(last, tt.wantFinal) {
+				t.Errorf("last prompt = %q, want it to contain %q", last, tt.wantFinal)
+			}
+			got, err := taskMgr.Get("TASK-001")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Status != task.StatusComplete {
+				t.Errorf("status = %s, want complete", got.Status)
+			}
+			if !tt.restart {
+				return
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(data), "New content") {
+				t.Errorf("task file lost the edit:\n%s", data)
+			}
+		})
+	}
+}
+
+func TestWorkstreamRunner_GroupFailure(t *testing.T) {
+	tests := []struct {
+		policy     string
+		wantStatus task.Status
+		wantReason bool
+	}{
+		{policy: "block", wantStatus: task.StatusBlocked, wantReason: true},
+		{policy: "fail", wantStatus: task.StatusFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			// TASK-001 fails on the backend while TASK-002 runs on the
+			// frontend; TASK-003 hasn't started
+			dir := writeWorkstreamTask(t, "group: orders\n")
+			others := map[string]string{
+				"TASK-002": "---\nid: TASK-002\ntitle: Running\nworkstream: frontend\nstatus: pending\ngroup: orders\n---\n\nContent\n",
+				"TASK-003": "---\nid: TASK-003\ntitle: Later\nworkstream: backend\nstatus: pending\ngroup: orders\n---\n\nContent\n",
+			}
+			for id, content := range others {
+				if err := os.WriteFile(filepath.Join(dir, "tasks", id+".md"), []byte(content), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			taskMgr := task.NewManager(&task.Config{ProjectRoot: dir})
+			if _, err := taskMgr.Scan(); err != nil {
+				t.Fatal(err)
+			}
+
+			frontendStarted := make(chan struct{})
+			frontendStopped := make(chan struct{})
+			var stopOnce sync.Once
+			dockerMgr := &mockDockerManager{
+				stopContainerFn: func(id string) error {
+					if id == "container-frontend" {
+						stopOnce.Do(func() { close(frontendStopped) })
+					}
+					return nil
+				},
+			}
+			var laterRan atomic.Bool
+			exec := &mockExecutor{
+				runFollowFn: func(id string, prompt string, _ executor.ExecuteOptions, _ io.Writer) (*executor.ExecutionResult, error) {
+					switch {
+					case id == "container-frontend":
+						close(frontendStarted)
+						<-frontendStopped
+						return nil, fmt.Errorf("container stopped")
+					case strings.Contains(prompt, "# Task: Later"):
+						laterRan.Store(true)
+						return &executor.ExecutionResult{}, nil
+					default:
+						<-frontendStarted
+						return nil, fmt.Errorf("tests failed")
+					}
+				},
+			}
+			stateMgr := newMockStateManager()
+			for _, name := range []string{"backend", "frontend"} {
+				stateMgr.agents[name] = &Agent{Name: name, ContainerID: "container-" + name, Status: "idle"}
+			}
+			agentMgr, err := NewManager(testConfig(), &mockGitManager{}, dockerMgr, stateMgr, exec)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			config := DefaultWorkstreamConfig()
+			config.GroupFailure = tt.policy
+			orch := NewWorkstreamOrchestrator(agentMgr, taskMgr, config)
+			var wg sync.WaitGroup
+			for _, ws := range []string{"backend", "frontend"} {
+				runner := NewWorkstreamRunner(agentMgr, taskMgr, "", ws, config)
+				runner.SetOutput(io.Discard)
+				runner.orch = orch
+				orch.runners = append(orch.runners, runner)
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if err := runner.Run(); err != nil {
+						t.Errorf("Run() error: %v", err)
+					}
+				}()
+			}
+			wg.Wait()
+
+			if laterRan.Load() {
+				t.Error("TASK-003 ran after its group failed")
+			}
+			want := map[string]task.Status{
+				"TASK-001": task.StatusFailed,
+				"TASK-002": tt.wantStatus,
+				"TASK-003": tt.wantStatus,
+			}
+			for id, status := range want {
+				got, err := taskMgr.Get(id)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got.Status != status {
+					t.Errorf("%s status = %s, want %s", id, got.Status, status)
+				}
+				if id != "TASK-001" && (got.BlockedReason != "") != tt.wantReason {
+					t.Errorf("%s BlockedReason = %q, want one: %v", id, got.BlockedReason, tt.wantReason)
+				}
+				if got.AssignedTo != "" && id != "TASK-001" {
+					t.Errorf("%s AssignedTo = %q, want unassigned", id, got.AssignedTo)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildTaskPrompt(t *testing.T) {
+	tests := []struct {
+		name    string
+		task    *task.Task
+		want    []string
+		wantOut []string
+	}{
+		{
+			name: "completion",
+			task: &task.Task{Completion: &task.CompletionConfig{
+				Verify: "npm test",
+				Signal: "DONE",
+				HTTP:   &task.HTTPCompletion{URL: "http://localhost:8080/health"},
+			}},
+			want: []string{
+				"## Completion Criteria\n\n",
+				"Run this command to verify: `npm test`\n",
+				"Say **DONE** when complete.\n",
+				"Completion is checked by requesting http://localhost:8080/health.\n",
+			},
+		},
+		{
+			name: "ralph block overrides completion",
+			task: &task.Task{
+				Completion: &task.CompletionConfig{Verify: "npm test", Signal: "DONE"},
+				Ralph:      &task.RalphConfig{Verify: "go test ./...", Signal: "SHIPPED"},
+			},
+			want:    []string{"Run this command to verify: `go test ./...`\n", "Say **SHIPPED** when complete.\n"},
+			wantOut: []string{"npm test", "**DONE**"},
+		},
+		{
+			name: "expects",
+			task: &task.Task{Expects: []string{"dist/*.js", "docs/api.md"}},
+			want: []string{
+				"## Completion Criteria\n\n",
+				"These files must exist in the repository when you finish:\n- `dist/*.js`\n- `docs/api.md`\n",
+			},
+		},
+		{
+			name:    "no criteria",
+			task:    &task.Task{},
+			wantOut: []string{"## Completion Criteria"},
+		},
+		{
+			name: "checkpoints",
+			task: &task.Task{Checkpoints: []string{"schema migrated"}},
+			want: []string{"Previously completed:\n- schema migrated\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.task.Title = "Test Task"
+			tt.task.Content = "Do the thing."
+
+			prompt := BuildTaskPrompt(tt.task)
+
+			if !strings.HasPrefix(prompt, "# Task: Test Task\n\nDo the thing.") {
+				t.Errorf("prompt should start with the title and content:\n%s", prompt)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(prompt, want) {
+					t.Errorf("prompt missing %q:\n%s", want, prompt)
+				}
+			}
+			for _, unwanted := range tt.wantOut {
+				if strings.Contains(prompt, unwanted) {
+					t.Errorf("prompt should not contain %q:\n%s", unwanted, prompt)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var agentCmd = &cobra.Command{
+	Use:   "agent",
+	Short: "Inspect and manage individual agents",
+	Long: `Agent commands operate on agents and their worktrees.
+
+Commands:
+  clone   - Create a new agent with the same setup as an existing one
+  du      - Show worktree disk usage per agent
+  exec    - Run a one-off command in an agent's container
+  label   - Show, add, or remove an agent's labels`,
+}
+
+func init() {
+	rootCmd.AddCommand(agentCmd)
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bkonkle/tanuki/internal/agent"
+	"github.com/bkonkle/tanuki/internal/docker"
+	"github.com/bkonkle/tanuki/internal/git"
+	"github.com/bkonkle/tanuki/internal/state"
+	"github.com/spf13/cobra"
+)
+
+var agentCloneCmd = &cobra.Command{
+	Use:   "clone <src> <dst>",
+	Short: "Create a new agent with the same setup as an existing one",
+	Long: `Create a new agent that copies an existing agent's workstream, tool
+configuration, and labels. The new agent gets its own fresh worktree and container; the
+source agent's worktree contents are not copied.
+
+Examples:
+  tanuki agent clone backend-1 backend-2`,
+	Args: cobra.ExactArgs(2),
+	RunE: runAgentClone,
+}
+
+func init() {
+	agentCmd.AddCommand(agentCloneCmd)
+}
+
+func runAgentClone(_ *cobra.Command, args []string) error {
+	srcName, dstName := args[0], args[1]
+
+	if err := validateAgentName(dstName); err != nil {
+		return err
+	}
+
+	// Load config
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Create dependencies
+	gitMgr, err := git.NewManager(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create git manager: %w", err)
+	}
+
+	dockerMgr, err := docker.NewManager(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create docker manager: %w", err)
+	}
+
+	stateMgr, err := state.NewFileStateManager(state.DefaultStatePath(), dockerMgr)
+	if err != nil {
+		return fmt.Errorf("failed to create state manager: %w", err)
+	}
+
+	// Create executor
+	exec, err := newExecutor(cfg, dockerMgr)
+	if err != nil {
+		return fmt.Errorf("failed to create executor: %w", err)
+	}
+
+	// Create agent manager
+	agentMgr, err := agent.NewManager(cfg, gitMgr, dockerMgr, stateMgr, exec)
+	if err != nil {
+		return fmt.Errorf("failed to create agent manager: %w", err)
+	}
+
+	fmt.Printf("Cloning agent %s to %s...\n", srcName, dstName)
+
+	start := time.Now()
+	ag, err := agentMgr.Clone(srcName, dstName)
+	if err != nil {
+		return err
+	}
+	elapsed := time.Since(start)
+
+	fmt.Printf("  Created agent %s (%.1fs)\n", ag.Name, elapsed.Seconds())
+	fmt.Printf("    Branch:    %s\n", ag.Branch)
+	fmt.Printf("    Container: %s\n", ag.ContainerName)
+	if ag.Workstream != "" {
+		fmt.Printf("    Workstream: %s\n", ag.Workstream)
+	}
+	fmt.Printf("    Worktree:  %s\n", ag.WorktreePath)
+	if len(ag.Labels) > 0 {
+		fmt.Printf("    Labels:    %s\n", agent.FormatLabels(ag.Labels))
+	}
+
+	return nil
+}
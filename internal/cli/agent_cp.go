@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bkonkle/tanuki/internal/agent"
+	"github.com/bkonkle/tanuki/internal/docker"
+	"github.com/bkonkle/tanuki/internal/git"
+	"github.com/bkonkle/tanuki/internal/state"
+	"github.com/spf13/cobra"
+)
+
+var agentCpCmd = &cobra.Command{
+	Use:   "cp <src> <dst>",
+	Short: "Copy files between the host and an agent's container",
+	Long: `Copy a file or directory into or out of an agent's container, like
+docker cp. Name the container side as <agent>:<path>; the other side is a
+host path. Relative container paths are resolved against the agent's
+worktree at /workspace.
+
+Directories are copied recursively, and file modes are kept. End a source
+directory with "/." to copy its contents instead of the directory itself.
+The agent's container doesn't have to be running.
+
+Examples:
+  tanuki agent cp ./gen/config.json auth-1:config/app.json
+  tanuki agent cp auth-1:/tmp/coverage ./coverage
+  tanuki agent cp ./fixtures/. auth-1:test/fixtures`,
+	Args: cobra.ExactArgs(2),
+	RunE: runAgentCp,
+}
+
+func init() {
+	agentCmd.AddCommand(agentCpCmd)
+}
+
+func runAgentCp(_ *cobra.Command, args []string) error {
+	src, dst := args[0], args[1]
+	srcAgent, srcPath, srcInAgent := splitAgentPath(src)
+	dstAgent, dstPath, dstInAgent := splitAgentPath(dst)
+	if srcInAgent == dstInAgent {
+		return fmt.Errorf("exactly one of <src> and <dst> must be an <agent>:<path>")
+	}
+
+	// Load config
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Create dependencies
+	gitMgr, err := git.NewManager(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create git manager: %w", err)
+	}
+
+	dockerMgr, err := docker.NewManager(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create docker manager: %w", err)
+	}
+
+	stateMgr, err := state.NewFileStateManager(state.DefaultStatePath(), dockerMgr)
+	if err != nil {
+		return fmt.Errorf("failed to create state manager: %w", err)
+	}
+
+	// Create executor
+	exec, err := newExecutor(cfg, dockerMgr)
+	if err != nil {
+		return fmt.Errorf("failed to create executor: %w", err)
+	}
+
+	// Create agent manager
+	agentMgr, err := agent.NewManager(cfg, gitMgr, dockerMgr, stateMgr, exec)
+	if err != nil {
+		return fmt.Errorf("failed to create agent manager: %w", err)
+	}
+
+	if srcInAgent {
+		return agentMgr.CopyFrom(srcAgent, srcPath, dst)
+	}
+	return agentMgr.CopyTo(dstAgent, src, dstPath)
+}
+
+// splitAgentPath splits an <agent>:<path> argument. ok is false for host
+// paths, which have no colon or don't start with a valid agent name.
+func splitAgentPath(arg string) (name, path string, ok bool) {
+	name, path, found := strings.Cut(arg, ":")
+	if !found || path == "" || !validNamePattern.MatchString(name) {
+		return "", "", false
+	}
+	return name, path, true
+}
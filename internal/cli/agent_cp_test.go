@@ -0,0 +1,28 @@
+package cli
+
+import "testing"
+
+func TestSplitAgentPath(t *testing.T) {
+	tests := []struct {
+		arg      string
+		wantName string
+		wantPath string
+		wantOK   bool
+	}{
+		{"auth-1:config/app.json", "auth-1", "config/app.json", true},
+		{"auth-1:/tmp/coverage", "auth-1", "/tmp/coverage", true},
+		{"./gen/config.json", "", "", false},
+		{"auth-1:", "", "", false},
+		{"Not_An_Agent:file", "", "", false},
+		{"/abs/with:colon", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			name, path, ok := splitAgentPath(tt.arg)
+			if name != tt.wantName || path != tt.wantPath || ok != tt.wantOK {
+				t.Errorf("splitAgentPath(%q) = %q, %q, %v; want %q, %q, %v", tt.arg, name, path, ok, tt.wantName, tt.wantPath, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/bkonkle/tanuki/internal/agent"
+	"github.com/bkonkle/tanuki/internal/docker"
+	"github.com/bkonkle/tanuki/internal/git"
+	"github.com/bkonkle/tanuki/internal/state"
+	"github.com/bkonkle/tanuki/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	agentDuOutput string
+)
+
+var agentDuCmd = &cobra.Command{
+	Use:   "du [agent...]",
+	Short: "Show worktree disk usage",
+	Long: `Show the disk usage of each agent's worktree, plus a total.
+
+Sizes exclude Git metadata (.git). With no arguments, all agents are shown.
+
+Examples:
+  tanuki agent du
+  tanuki agent du auth-feature
+  tanuki agent du -o json`,
+	RunE: runAgentDu,
+}
+
+func init() {
+	agentDuCmd.Flags().StringVarP(&agentDuOutput, "output", "o", "table", "Output format (table, json)")
+	agentCmd.AddCommand(agentDuCmd)
+}
+
+// agentDiskUsage is the disk usage of a single agent's worktree.
+type agentDiskUsage struct {
+	Name  string `json:"name"`
+	Bytes int64  `json:"bytes"`
+	Error string `json:"error,omitempty"`
+}
+
+func runAgentDu(_ *cobra.Command, args []string) error {
+	// Load config
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Create dependencies
+	gitMgr, err := git.NewManager(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create git manager: %w", err)
+	}
+
+	dockerMgr, err := docker.NewManager(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create docker manager: %w", err)
+	}
+
+	stateMgr, err := state.NewFileStateManager(state.DefaultStatePath(), dockerMgr)
+	if err != nil {
+		return fmt.Errorf("failed to create state manager: %w", err)
+	}
+
+	// Create executor
+	exec, err := newExecutor(cfg, dockerMgr)
+	if err != nil {
+		return fmt.Errorf("failed to create executor: %w", err)
+	}
+
+	// Create agent manager
+	agentMgr, err := agent.NewManager(cfg, gitMgr, dockerMgr, stateMgr, exec)
+	if err != nil {
+		return fmt.Errorf("failed to create agent manager: %w", err)
+	}
+
+	names := args
+	if len(names) == 0 {
+		agents, listErr := agentMgr.List()
+		if listErr != nil {
+			return listErr
+		}
+		for _, ag := range agents {
+			names = append(names, ag.Name)
+		}
+	} else {
+		for _, name := range names {
+			if _, getErr := agentMgr.Get(name); getErr != nil {
+				return fmt.Errorf("agent %q not found\n\nUse 'tanuki list' to see available agents", name)
+			}
+		}
+	}
+
+	if len(names) == 0 {
+		fmt.Println("No agents found.")
+		return nil
+	}
+
+	usages := make([]agentDiskUsage, 0, len(names))
+	var total int64
+	for _, name := range names {
+		u := agentDiskUsage{Name: name}
+		bytes, duErr := gitMgr.WorktreeDiskUsage(name)
+		if duErr != nil {
+			u.Error = duErr.Error()
+		} else {
+			u.Bytes = bytes
+			total += bytes
+		}
+		usages = append(usages, u)
+	}
+
+	switch agentDuOutput {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(map[string]interface{}{
+			"agents":      usages,
+			"total_bytes": total,
+		})
+	default:
+		return printAgentDuTable(usages, total)
+	}
+}
+
+func printAgentDuTable(usages []agentDiskUsage, total int64) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NAME\tSIZE")
+	_, _ = fmt.Fprintln(w, "----\t----")
+
+	for _, u := range usages {
+		size := tui.FormatBytes(u.Bytes)
+		if u.Error != "" {
+			size = "-"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\n", u.Name, size)
+	}
+
+	_, _ = fmt.Fprintln(w, "\t")
+	_, _ = fmt.Fprintf(w, "TOTAL\t%s\n", tui.FormatBytes(total))
+
+	return w.Flush()
+}
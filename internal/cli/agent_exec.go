@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/bkonkle/tanuki/internal/agent"
+	"github.com/bkonkle/tanuki/internal/docker"
+	"github.com/bkonkle/tanuki/internal/git"
+	"github.com/bkonkle/tanuki/internal/state"
+	"github.com/spf13/cobra"
+)
+
+var (
+	agentExecFollow bool
+)
+
+var agentExecCmd = &cobra.Command{
+	Use:   "exec <agent> -- <command> [args...]",
+	Short: "Run a one-off command in an agent's container",
+	Long: `Run a single command in an agent's container and print its output.
+
+The command runs in the agent's worktree as the node user, without a shell,
+so pass "sh -c '...'" for pipes or globs. tanuki exits with the command's
+exit code. Output is printed when the command finishes; use --follow to
+stream it as it runs.
+
+Examples:
+  tanuki agent exec auth-feature -- git status
+  tanuki agent exec auth-feature --follow -- npm test
+  tanuki agent exec auth-feature -- sh -c 'ls src | wc -l'`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runAgentExec,
+}
+
+func init() {
+	agentExecCmd.Flags().BoolVarP(&agentExecFollow, "follow", "f", false, "Stream output while the command runs")
+	agentCmd.AddCommand(agentExecCmd)
+}
+
+func runAgentExec(cmd *cobra.Command, args []string) error {
+	// Everything after "--" is the command, even if it looks like a flag
+	if dash := cmd.ArgsLenAtDash(); dash > 1 {
+		return fmt.Errorf("expected a single agent name before --, got %d arguments", dash)
+	}
+	agentName, command := args[0], args[1:]
+
+	// Load config
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Create dependencies
+	gitMgr, err := git.NewManager(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create git manager: %w", err)
+	}
+
+	dockerMgr, err := docker.NewManager(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create docker manager: %w", err)
+	}
+
+	stateMgr, err := state.NewFileStateManager(state.DefaultStatePath(), dockerMgr)
+	if err != nil {
+		return fmt.Errorf("failed to create state manager: %w", err)
+	}
+
+	// Create executor
+	exec, err := newExecutor(cfg, dockerMgr)
+	if err != nil {
+		return fmt.Errorf("failed to create executor: %w", err)
+	}
+
+	// Create agent manager
+	agentMgr, err := agent.NewManager(cfg, gitMgr, dockerMgr, stateMgr, exec)
+	if err != nil {
+		return fmt.Errorf("failed to create agent manager: %w", err)
+	}
+
+	ag, err := agentMgr.Get(agentName)
+	if err != nil {
+		return fmt.Errorf("agent %q not found\n\nUse 'tanuki list' to see available agents", agentName)
+	}
+
+	if !dockerMgr.ContainerRunning(ag.ContainerID) {
+		return fmt.Errorf("agent %q is not running\nUse 'tanuki start %s' first", agentName, agentName)
+	}
+
+	code, err := execInAgent(dockerMgr, ag.ContainerID, command, agentExecFollow)
+	if err != nil {
+		return err
+	}
+	if code != 0 {
+		// The command already reported its own failure
+		cmd.SilenceErrors = true
+		return &ExitCodeError{Code: code}
+	}
+	return nil
+}
+
+// execInAgent runs command in the container, streaming its output or
+// printing it once the command exits, and returns the exit code.
+func execInAgent(dockerMgr *docker.Manager, containerID string, command []string, follow bool) (int, error) {
+	if follow {
+		return dockerMgr.ExecCommand(containerID, command, docker.ExecOptions{
+			Stdout: os.Stdout,
+			Stderr: os.Stderr,
+		})
+	}
+
+	var stdout, stderr bytes.Buffer
+	code, err := dockerMgr.ExecCommand(containerID, command, docker.ExecOptions{
+		Stdout: &stdout,
+		Stderr: &stderr,
+	})
+	_, _ = os.Stdout.Write(stdout.Bytes())
+	_, _ = os.Stderr.Write(stderr.Bytes())
+	return code, err
+}
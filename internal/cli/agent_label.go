@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/bkonkle/tanuki/internal/agent"
+	"github.com/bkonkle/tanuki/internal/docker"
+	"github.com/bkonkle/tanuki/internal/git"
+	"github.com/bkonkle/tanuki/internal/state"
+	"github.com/spf13/cobra"
+)
+
+var agentLabelCmd = &cobra.Command{
+	Use:   "label <agent> [key=value...] [key-...]",
+	Short: "Show, add, or remove an agent's labels",
+	Long: `Show, add, or remove labels on an agent. Labels are key=value tags for
+grouping agents in the dashboard and selecting them with --label in spawn,
+list, stop, start, and remove.
+
+A key=value argument adds or updates a label; a key- argument removes it.
+With no label arguments, the agent's current labels are printed.
+
+Examples:
+  tanuki agent label auth-1                       # Show labels
+  tanuki agent label auth-1 feature=auth team=id  # Add or update labels
+  tanuki agent label auth-1 team-                 # Remove the team label`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runAgentLabel,
+}
+
+func init() {
+	agentCmd.AddCommand(agentLabelCmd)
+}
+
+func runAgentLabel(_ *cobra.Command, args []string) error {
+	name := args[0]
+
+	set, remove, err := parseLabelEdits(args[1:])
+	if err != nil {
+		return err
+	}
+
+	// Load config
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Create dependencies
+	gitMgr, err := git.NewManager(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create git manager: %w", err)
+	}
+
+	dockerMgr, err := docker.NewManager(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create docker manager: %w", err)
+	}
+
+	stateMgr, err := state.NewFileStateManager(state.DefaultStatePath(), dockerMgr)
+	if err != nil {
+		return fmt.Errorf("failed to create state manager: %w", err)
+	}
+
+	// Create executor
+	exec, err := newExecutor(cfg, dockerMgr)
+	if err != nil {
+		return fmt.Errorf("failed to create executor: %w", err)
+	}
+
+	// Create agent manager
+	agentMgr, err := agent.NewManager(cfg, gitMgr, dockerMgr, stateMgr, exec)
+	if err != nil {
+		return fmt.Errorf("failed to create agent manager: %w", err)
+	}
+
+	var ag *agent.Agent
+	if len(set) == 0 && len(remove) == 0 {
+		ag, err = agentMgr.Get(name)
+	} else {
+		ag, err = agentMgr.SetLabels(name, set, remove)
+	}
+	if err != nil {
+		return err
+	}
+
+	printAgentLabels(os.Stdout, ag)
+	return nil
+}
+
+// parseLabelEdits splits label arguments into labels to set (key=value) and
+// keys to remove (key-).
+func parseLabelEdits(args []string) (map[string]string, []string, error) {
+	var pairs, remove []string
+	for _, arg := range args {
+		if key, ok := strings.CutSuffix(arg, "-"); ok && !strings.Contains(arg, "=") {
+			remove = append(remove, key)
+			continue
+		}
+		pairs = append(pairs, arg)
+	}
+
+	set, err := agent.ParseLabels(pairs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return set, remove, nil
+}
+
+// printAgentLabels writes an agent's labels one per line.
+func printAgentLabels(w io.Writer, ag *agent.Agent) {
+	if len(ag.Labels) == 0 {
+		_, _ = fmt.Fprintf(w, "%s has no labels\n", ag.Name)
+		return
+	}
+	for _, pair := range strings.Split(agent.FormatLabels(ag.Labels), ",") {
+		_, _ = fmt.Fprintln(w, pair)
+	}
+}
+
+// selectAgentsByLabel returns the agents matching every --label selector.
+func selectAgentsByLabel(agentMgr *agent.Manager, pairs []string) ([]*agent.Agent, error) {
+	selector, err := agent.ParseLabels(pairs)
+	if err != nil {
+		return nil, err
+	}
+
+	agents, err := agentMgr.List()
+	if err != nil {
+		return nil, err
+	}
+	return agent.FilterByLabels(agents, selector), nil
+}
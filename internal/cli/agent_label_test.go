@@ -0,0 +1,24 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLabelEdits(t *testing.T) {
+	set, remove, err := parseLabelEdits([]string{"feature=auth", "team-", "stage=pre-release"})
+	if err != nil {
+		t.Fatalf("parseLabelEdits() error = %v", err)
+	}
+	wantSet := map[string]string{"feature": "auth", "stage": "pre-release"}
+	if !reflect.DeepEqual(set, wantSet) {
+		t.Errorf("set = %v, want %v", set, wantSet)
+	}
+	if !reflect.DeepEqual(remove, []string{"team"}) {
+		t.Errorf("remove = %v, want [team]", remove)
+	}
+
+	if _, _, err := parseLabelEdits([]string{"feature"}); err == nil {
+		t.Error("parseLabelEdits() error = nil, want error for a bare key")
+	}
+}
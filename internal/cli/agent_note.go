@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/bkonkle/tanuki/internal/agent"
+	"github.com/bkonkle/tanuki/internal/docker"
+	"github.com/bkonkle/tanuki/internal/git"
+	"github.com/bkonkle/tanuki/internal/state"
+	"github.com/spf13/cobra"
+)
+
+var agentNoteClear bool
+
+var agentNoteCmd = &cobra.Command{
+	Use:   "note <agent> [text...]",
+	Short: "Show or set an agent's notes",
+	Long: `Show or set free-form notes on an agent, such as what it's working on.
+Notes are only for you: they are saved with the agent's state and shown in
+"tanuki status <agent>" and the dashboard, but never sent to the agent.
+
+Setting notes replaces any existing notes. With no text, the agent's
+current notes are printed.
+
+Examples:
+  tanuki agent note auth-1 "working on OAuth refactor"
+  tanuki agent note auth-1           # Show notes
+  tanuki agent note auth-1 --clear   # Remove notes`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runAgentNote,
+}
+
+func init() {
+	agentNoteCmd.Flags().BoolVar(&agentNoteClear, "clear", false, "Remove the agent's notes")
+	agentCmd.AddCommand(agentNoteCmd)
+}
+
+func runAgentNote(_ *cobra.Command, args []string) error {
+	name := args[0]
+	text := strings.Join(args[1:], " ")
+	if agentNoteClear && text != "" {
+		return fmt.Errorf("cannot use note text with --clear")
+	}
+
+	// Load config
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Create dependencies
+	gitMgr, err := git.NewManager(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create git manager: %w", err)
+	}
+
+	dockerMgr, err := docker.NewManager(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create docker manager: %w", err)
+	}
+
+	stateMgr, err := state.NewFileStateManager(state.DefaultStatePath(), dockerMgr)
+	if err != nil {
+		return fmt.Errorf("failed to create state manager: %w", err)
+	}
+
+	// Create executor
+	exec, err := newExecutor(cfg, dockerMgr)
+	if err != nil {
+		return fmt.Errorf("failed to create executor: %w", err)
+	}
+
+	// Create agent manager
+	agentMgr, err := agent.NewManager(cfg, gitMgr, dockerMgr, stateMgr, exec)
+	if err != nil {
+		return fmt.Errorf("failed to create agent manager: %w", err)
+	}
+
+	var ag *agent.Agent
+	if text == "" && !agentNoteClear {
+		ag, err = agentMgr.Get(name)
+	} else {
+		ag, err = agentMgr.SetNotes(name, text)
+	}
+	if err != nil {
+		return err
+	}
+
+	printAgentNotes(os.Stdout, ag)
+	return nil
+}
+
+// printAgentNotes writes an agent's notes.
+func printAgentNotes(w io.Writer, ag *agent.Agent) {
+	if ag.Notes == "" {
+		_, _ = fmt.Fprintf(w, "%s has no notes\n", ag.Name)
+		return
+	}
+	_, _ = fmt.Fprintln(w, ag.Notes)
+}
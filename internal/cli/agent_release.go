@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/bkonkle/tanuki/internal/agent"
+	"github.com/bkonkle/tanuki/internal/docker"
+	"github.com/bkonkle/tanuki/internal/git"
+	"github.com/bkonkle/tanuki/internal/state"
+	"github.com/spf13/cobra"
+)
+
+var agentReleaseCmd = &cobra.Command{
+	Use:   "release <agent>",
+	Short: "Return a held agent to the pool",
+	Long: `Release an agent held after a task failed on it. With
+keep_container_on_failure set in tanuki.yaml, the orchestrator stops the
+agent's container instead of reusing it, and marks the agent failed-held so
+no more tasks are assigned to it. Inspect the container and worktree, then
+release the agent to restart its container and make it idle again.
+
+Examples:
+  tanuki agent release auth-1`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAgentRelease,
+}
+
+func init() {
+	agentCmd.AddCommand(agentReleaseCmd)
+}
+
+func runAgentRelease(_ *cobra.Command, args []string) error {
+	name := args[0]
+
+	// Load config
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Create dependencies
+	gitMgr, err := git.NewManager(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create git manager: %w", err)
+	}
+
+	dockerMgr, err := docker.NewManager(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create docker manager: %w", err)
+	}
+
+	stateMgr, err := state.NewFileStateManager(state.DefaultStatePath(), dockerMgr)
+	if err != nil {
+		return fmt.Errorf("failed to create state manager: %w", err)
+	}
+
+	// Create executor
+	exec, err := newExecutor(cfg, dockerMgr)
+	if err != nil {
+		return fmt.Errorf("failed to create executor: %w", err)
+	}
+
+	// Create agent manager
+	agentMgr, err := agent.NewManager(cfg, gitMgr, dockerMgr, stateMgr, exec)
+	if err != nil {
+		return fmt.Errorf("failed to create agent manager: %w", err)
+	}
+
+	if err := agentMgr.Release(name); err != nil {
+		return err
+	}
+
+	fmt.Printf("Released %s\n", name)
+	return nil
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/bkonkle/tanuki/internal/agent"
+	"github.com/bkonkle/tanuki/internal/docker"
+	"github.com/bkonkle/tanuki/internal/git"
+	"github.com/bkonkle/tanuki/internal/state"
+	"github.com/spf13/cobra"
+)
+
+var agentRestartCmd = &cobra.Command{
+	Use:   "restart <agent>",
+	Short: "Restart an agent's container",
+	Long: `Stop and start an agent's container, keeping its worktree, branch and
+state. Use it to clear a wedged process or pick up a changed environment
+without removing and re-creating the agent. The agent is idle once its
+container is ready again. A working agent can't be restarted.
+
+Examples:
+  tanuki agent restart auth-1`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAgentRestart,
+}
+
+func init() {
+	agentCmd.AddCommand(agentRestartCmd)
+}
+
+func runAgentRestart(_ *cobra.Command, args []string) error {
+	name := args[0]
+
+	// Load config
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Create dependencies
+	gitMgr, err := git.NewManager(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create git manager: %w", err)
+	}
+
+	dockerMgr, err := docker.NewManager(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create docker manager: %w", err)
+	}
+
+	stateMgr, err := state.NewFileStateManager(state.DefaultStatePath(), dockerMgr)
+	if err != nil {
+		return fmt.Errorf("failed to create state manager: %w", err)
+	}
+
+	// Create executor
+	exec, err := newExecutor(cfg, dockerMgr)
+	if err != nil {
+		return fmt.Errorf("failed to create executor: %w", err)
+	}
+
+	// Create agent manager
+	agentMgr, err := agent.NewManager(cfg, gitMgr, dockerMgr, stateMgr, exec)
+	if err != nil {
+		return fmt.Errorf("failed to create agent manager: %w", err)
+	}
+
+	if err := agentMgr.Restart(name); err != nil {
+		return err
+	}
+
+	fmt.Printf("Restarted %s\n", name)
+	return nil
+}
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/bkonkle/tanuki/internal/agent"
+)
+
+// isInteractive reports whether both stdin and stdout are terminals, so a
+// command can prompt instead of failing. Scripts and pipes get the error.
+func isInteractive() bool {
+	for _, f := range []*os.File{os.Stdin, os.Stdout} {
+		fileInfo, err := f.Stat()
+		if err != nil || fileInfo.Mode()&os.ModeCharDevice == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// promptAgentName lets the user pick which agent to act on when no name was
+// given. Outside a terminal it returns missing, the command's usual error.
+// It returns "" if the user cancels or no agent matches keep.
+func promptAgentName(agentMgr *agent.Manager, action string, keep func(*agent.Agent) bool, missing error) (string, error) {
+	if !isInteractive() {
+		return "", missing
+	}
+
+	agents, err := agentMgr.List()
+	if err != nil {
+		return "", err
+	}
+
+	var choices []*agent.Agent
+	for _, ag := range agents {
+		if keep == nil || keep(ag) {
+			choices = append(choices, ag)
+		}
+	}
+	if len(choices) == 0 {
+		fmt.Printf("No agents to %s\n", action)
+		return "", nil
+	}
+
+	ag, err := selectAgent(os.Stdin, os.Stdout, choices, action)
+	if err != nil || ag == nil {
+		return "", err
+	}
+	return ag.Name, nil
+}
+
+// selectAgent lists agents with numbers and reads a choice from in, either a
+// number or an agent name. An empty answer cancels and returns nil.
+func selectAgent(in io.Reader, out io.Writer, agents []*agent.Agent, action string) (*agent.Agent, error) {
+	for i, ag := range agents {
+		_, _ = fmt.Fprintf(out, "  %d) %s (%s)\n", i+1, ag.Name, ag.Status)
+	}
+	_, _ = fmt.Fprintf(out, "Agent to %s [1-%d, empty to cancel]: ", action, len(agents))
+
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && answer == "" {
+		return nil, nil
+	}
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return nil, nil
+	}
+
+	if n, err := strconv.Atoi(answer); err == nil {
+		if n < 1 || n > len(agents) {
+			return nil, fmt.Errorf("no agent numbered %d", n)
+		}
+		return agents[n-1], nil
+	}
+	for _, ag := range agents {
+		if ag.Name == answer {
+			return ag, nil
+		}
+	}
+	return nil, fmt.Errorf("agent %q not found", answer)
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/bkonkle/tanuki/internal/agent"
+	"github.com/bkonkle/tanuki/internal/state"
+)
+
+func TestSelectAgent(t *testing.T) {
+	agents := []*agent.Agent{
+		{Name: "auth", Status: state.StatusIdle},
+		{Name: "api", Status: state.StatusWorking},
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"by number", "2\n", "api", false},
+		{"by name", "auth\n", "auth", false},
+		{"empty cancels", "\n", "", false},
+		{"end of input cancels", "", "", false},
+		{"number out of range", "3\n", "", true},
+		{"unknown name", "web\n", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			got, err := selectAgent(strings.NewReader(tt.input), &out, agents, "remove")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("selectAgent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			name := ""
+			if got != nil {
+				name = got.Name
+			}
+			if name != tt.want {
+				t.Errorf("selectAgent() = %q, want %q", name, tt.want)
+			}
+			if !strings.Contains(out.String(), "  2) api (working)") {
+				t.Errorf("output = %q, want the numbered agent list", out.String())
+			}
+		})
+	}
+}
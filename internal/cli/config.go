@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/bkonkle/tanuki/internal/config"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var configShowEffective bool
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Check and inspect tanuki configuration",
+	Long: `Config commands check tanuki.yaml and show what tanuki resolves from it.
+
+Commands:
+  validate - Check a config file and report any problems
+  show     - Print the merged configuration as YAML`,
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate [path]",
+	Short: "Check a config file and report any problems",
+	Long: `Load and validate tanuki configuration, reporting every problem found.
+
+Without a path, the config tanuki would use is checked: the file given by
+--config, or the project and global config files. The selected profile is
+applied first, so its overrides are validated too.
+
+Examples:
+  tanuki config validate
+  tanuki config validate ./configs/staging.yaml
+  tanuki config validate --profile ci`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConfigValidate,
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the merged configuration as YAML",
+	Long: `Print the configuration merged from the global and project config files,
+the selected profile, and CLI overrides.
+
+By default only the settings those sources set are shown. With --effective,
+the full configuration tanuki resolves is shown, including defaults for
+everything left unset.
+
+Examples:
+  tanuki config show
+  tanuki config show --effective
+  tanuki config show --effective --profile ci`,
+	Args: cobra.NoArgs,
+	RunE: runConfigShow,
+}
+
+func init() {
+	configShowCmd.Flags().BoolVar(&configShowEffective, "effective", false, "Include defaults for settings the config files leave unset")
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configShowCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+func runConfigValidate(_ *cobra.Command, args []string) error {
+	path := configPath
+	if len(args) > 0 {
+		path = args[0]
+	}
+	return validateConfig(os.Stdout, newConfigLoader(), path)
+}
+
+func runConfigShow(_ *cobra.Command, _ []string) error {
+	return showConfig(os.Stdout, newConfigLoader(), configPath, configShowEffective)
+}
+
+// newConfigLoader returns a loader for the profile selected with --profile.
+func newConfigLoader() *config.Loader {
+	loader := config.NewLoader()
+	loader.SetProfile(configProfile)
+	return loader
+}
+
+// validateConfig loads the config at path, or the searched config files if
+// path is empty, and lists any validation problems one per line.
+func validateConfig(w io.Writer, loader *config.Loader, path string) error {
+	source := path
+	if source == "" {
+		source = describeConfigSources()
+	}
+
+	var err error
+	if path != "" {
+		_, err = loader.LoadFromPath(path)
+	} else {
+		_, err = loader.Load()
+	}
+
+	var errs config.ValidationErrors
+	if errors.As(err, &errs) {
+		_, _ = fmt.Fprintf(w, "%s has %d problem(s):\n", source, len(errs))
+		for _, e := range errs {
+			_, _ = fmt.Fprintf(w, "  - %s\n", e.Message)
+		}
+		return fmt.Errorf("invalid config: %s", source)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(w, "%s is valid.\n", source)
+	return nil
+}
+
+// describeConfigSources names the config files Load would read.
+func describeConfigSources() string {
+	project := config.FindProjectConfig()
+	global := config.GlobalConfigPath()
+	if global != "" && !config.Exists(global) {
+		global = ""
+	}
+
+	switch {
+	case project != "" && global != "":
+		return fmt.Sprintf("Config (%s, %s)", project, global)
+	case project != "":
+		return project
+	case global != "":
+		return global
+	default:
+		return "Default config (no config files found)"
+	}
+}
+
+// showConfig writes the config as YAML: only the settings configured at
+// path (or the searched config files), or with effective, the full
+// resolved config including defaults.
+func showConfig(w io.Writer, loader *config.Loader, path string, effective bool) error {
+	var value interface{}
+	if effective {
+		var cfg *config.Config
+		var err error
+		if path != "" {
+			cfg, err = loader.LoadFromPath(path)
+		} else {
+			cfg, err = loader.Load()
+		}
+		if err != nil {
+			return err
+		}
+		value = cfg
+	} else {
+		settings, err := loader.Settings(path)
+		if err != nil {
+			return err
+		}
+		if len(settings) == 0 {
+			_, _ = fmt.Fprintln(w, "# No settings configured; run with --effective to see the defaults")
+			return nil
+		}
+		value = settings
+	}
+
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("encode config: %w", err)
+	}
+	_, err = w.Write(data)
+	return err
+}
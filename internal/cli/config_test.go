@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bkonkle/tanuki/internal/config"
+)
+
+// writeConfigFile writes a tanuki.yaml into a temp dir and returns its path.
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tanuki.yaml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestValidateConfig(t *testing.T) {
+	valid := writeConfigFile(t, "version: \"1\"\ngroup_failure: fail\n")
+	invalid := writeConfigFile(t, "version: \"1\"\ngroup_failure: cancel\ndashboard:\n  refresh_interval: fast\n")
+
+	var out bytes.Buffer
+	if err := validateConfig(&out, config.NewLoader(), valid); err != nil {
+		t.Fatalf("validateConfig() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "is valid") {
+		t.Errorf("output = %q, want it to report the config valid", out.String())
+	}
+
+	out.Reset()
+	if err := validateConfig(&out, config.NewLoader(), invalid); err == nil {
+		t.Fatal("validateConfig() error = nil, want error for an invalid config")
+	}
+	for _, want := range []string{"2 problem(s)", "'group_failure' must be block or fail", "'dashboard.refresh_interval'"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output = %q, want it to contain %q", out.String(), want)
+		}
+	}
+}
+
+func TestShowConfig(t *testing.T) {
+	path := writeConfigFile(t, "version: \"1\"\ngroup_failure: fail\n")
+
+	var out bytes.Buffer
+	if err := showConfig(&out, config.NewLoader(), path, false); err != nil {
+		t.Fatalf("showConfig() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "group_failure: fail") {
+		t.Errorf("output = %q, want the configured setting", out.String())
+	}
+	if strings.Contains(out.String(), "tasks_dir") {
+		t.Errorf("output = %q, want defaults left out without --effective", out.String())
+	}
+
+	out.Reset()
+	if err := showConfig(&out, config.NewLoader(), path, true); err != nil {
+		t.Fatalf("showConfig(effective) error = %v", err)
+	}
+	for _, want := range []string{"group_failure: fail", "tasks_dir: tasks"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("effective output = %q, want it to contain %q", out.String(), want)
+		}
+	}
+}
@@ -1,8 +1,14 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
@@ -10,8 +16,8 @@ import (
 	"github.com/bkonkle/tanuki/internal/agent"
 	"github.com/bkonkle/tanuki/internal/config"
 	"github.com/bkonkle/tanuki/internal/docker"
-	"github.com/bkonkle/tanuki/internal/executor"
 	"github.com/bkonkle/tanuki/internal/git"
+	"github.com/bkonkle/tanuki/internal/project"
 	"github.com/bkonkle/tanuki/internal/state"
 	"github.com/bkonkle/tanuki/internal/task"
 	"github.com/bkonkle/tanuki/internal/tui"
@@ -28,6 +34,7 @@ The dashboard provides:
   - Task list with filtering
   - Log streaming for selected agent
   - Quick actions (start, stop, attach)
+  - In-process project orchestrator (press o to start/stop)
 
 Navigation:
   Tab/Shift+Tab - Switch between panes
@@ -43,7 +50,7 @@ func init() {
 
 func runDashboard(_ *cobra.Command, _ []string) error {
 	// Load config
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
@@ -54,13 +61,30 @@ func runDashboard(_ *cobra.Command, _ []string) error {
 		return fmt.Errorf("create agent provider: %w", err)
 	}
 
-	taskProvider, err := createTaskProvider()
+	taskProvider, err := createTaskProvider(cfg)
 	if err != nil {
 		return fmt.Errorf("create task provider: %w", err)
 	}
 
+	// Create the in-process orchestrator, sharing the providers' managers
+	orchProvider := newOrchestratorProviderAdapter(cfg, agentProvider.manager, taskProvider.manager)
+	defer orchProvider.shutdown()
+
+	// Keep orchestrator logging from drawing over the TUI
+	if logFile, logErr := openOrchestratorLog(); logErr == nil {
+		log.SetOutput(logFile)
+		defer func() {
+			log.SetOutput(os.Stderr)
+			_ = logFile.Close()
+		}()
+	}
+
 	// Create dashboard model
 	model := tui.NewModel(agentProvider, taskProvider)
+	model.SetOrchestratorProvider(orchProvider)
+	model.SetResourceProvider(agentProvider)
+	model.SetMaxLogs(cfg.GetDashboardMaxLogs())
+	model.SetRefreshInterval(cfg.GetDashboardRefreshInterval())
 
 	// Create and run the BubbleTea program
 	p := tea.NewProgram(model, tea.WithAltScreen())
@@ -72,9 +96,38 @@ func runDashboard(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
+// newTaskLogWriter returns a writer for task run logs under the current
+// directory, or nil if the log directory can't be created.
+func newTaskLogWriter() *task.LogWriter {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+	logs, err := task.NewLogWriter(cwd)
+	if err != nil {
+		log.Printf("Warning: task output won't be saved: %v", err)
+		return nil
+	}
+	return logs
+}
+
+// openOrchestratorLog opens the log file used by the dashboard's orchestrator.
+func openOrchestratorLog() (*os.File, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	logDir := filepath.Join(cwd, ".tanuki", task.LogsDir)
+	if err := os.MkdirAll(logDir, 0750); err != nil {
+		return nil, err
+	}
+	return os.OpenFile(filepath.Join(logDir, "orchestrator.log"), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600) //nolint:gosec // Path is constructed from trusted internal config
+}
+
 // agentProviderAdapter adapts the agent.Manager to the tui.AgentProvider interface.
 type agentProviderAdapter struct {
 	manager *agent.Manager
+	git     *git.Manager
 }
 
 func (a *agentProviderAdapter) ListAgents() ([]*tui.AgentInfo, error) {
@@ -85,12 +138,13 @@ func (a *agentProviderAdapter) ListAgents() ([]*tui.AgentInfo, error) {
 
 	result := make([]*tui.AgentInfo, len(agents))
 	for i, ag := range agents {
-		currentTask := ""
+		currentTask, logFilePath := "", ""
 		if ag.LastTask != nil {
 			currentTask = ag.LastTask.Prompt
 			if len(currentTask) > 30 {
 				currentTask = currentTask[:30] + "..."
 			}
+			logFilePath = ag.LastTask.LogFilePath
 		}
 
 		result[i] = &tui.AgentInfo{
@@ -99,12 +153,28 @@ func (a *agentProviderAdapter) ListAgents() ([]*tui.AgentInfo, error) {
 			Workstream:  ag.Workstream,
 			CurrentTask: currentTask,
 			Branch:      ag.Branch,
+			LogFilePath: logFilePath,
+			Labels:      ag.Labels,
+			Notes:       ag.Notes,
+		}
+		if a.git != nil {
+			if usage, err := a.git.WorktreeDiskUsage(ag.Name); err == nil {
+				result[i].DiskUsage = usage
+			}
 		}
 	}
 
 	return result, nil
 }
 
+func (a *agentProviderAdapter) ResourceUsage() (*tui.ResourceInfo, error) {
+	total, err := a.manager.TotalResourceUsage()
+	if err != nil {
+		return nil, err
+	}
+	return resourceInfo(total), nil
+}
+
 func (a *agentProviderAdapter) StopAgent(name string) error {
 	return a.manager.Stop(name)
 }
@@ -128,19 +198,192 @@ func (t *taskProviderAdapter) ListTasks() ([]*tui.TaskInfo, error) {
 	result := make([]*tui.TaskInfo, len(tasks))
 	for i, tk := range tasks {
 		result[i] = &tui.TaskInfo{
-			ID:         tk.ID,
-			Title:      tk.Title,
-			Status:     string(tk.Status),
-			Workstream: tk.GetWorkstream(),
-			AssignedTo: tk.AssignedTo,
-			Priority:   string(tk.Priority),
+			ID:              tk.ID,
+			Title:           tk.Title,
+			Status:          string(tk.Status),
+			Workstream:      tk.GetWorkstream(),
+			AssignedTo:      tk.AssignedTo,
+			Priority:        string(tk.Priority),
+			FailureMessage:  tk.FailureMessage,
+			FailureCategory: string(tk.FailureCategory),
+			LogFilePath:     tk.LogFilePath,
+			ValidationLog:   tk.ValidationLog,
+			DependsOn:       tk.DependsOn,
+			BlockedReason:   tk.BlockedReason,
+			ProgressPercent: tk.ProgressPercent,
+			StartedAt:       tk.StartedAt,
+			CompletedAt:     tk.CompletedAt,
+		}
+		if tk.FailureMessage != "" {
+			firstLine, _, _ := strings.Cut(tk.FailureMessage, "\n")
+			result[i].ErrorPreview = truncate(firstLine, 60)
 		}
 	}
 
 	return result, nil
 }
 
-func createAgentProvider(cfg *config.Config) (tui.AgentProvider, error) {
+// orchestratorProviderAdapter runs a project.Orchestrator in the background and
+// adapts it to the tui.OrchestratorProvider interface.
+type orchestratorProviderAdapter struct {
+	orch     *project.Orchestrator
+	taskMgr  *task.Manager
+	resolver project.DependencyResolver
+
+	mu       sync.Mutex
+	cancel   context.CancelFunc
+	stopping bool
+	lastErr  error
+}
+
+func newOrchestratorProviderAdapter(cfg *config.Config, agentMgr *agent.Manager, taskMgr *task.Manager) *orchestratorProviderAdapter {
+	orchCfg := project.DefaultOrchestratorConfig()
+	// Bubble Tea owns the terminal; a forced exit would leave it in raw mode
+	orchCfg.HandleSignals = false
+	orchCfg.ApplyConfig(cfg)
+
+	orch := project.NewOrchestrator(
+		project.NewTaskManagerAdapter(taskMgr),
+		agentMgr,
+		task.NewQueue(),
+		orchCfg,
+	)
+	// Without a resolver every pending task is queued, dependencies or not
+	resolver := project.NewDependencyResolver(taskMgr)
+	orch.SetResolver(resolver)
+	orch.SetRunner(project.NewAgentTaskRunnerWithOptions(taskMgr, agentMgr, project.AgentTaskRunnerOptions{
+		Logs:          newTaskLogWriter(),
+		SkipVerify:    orchCfg.SkipVerify,
+		ResumeRetries: orchCfg.ResumeRetries,
+	}))
+
+	return &orchestratorProviderAdapter{orch: orch, taskMgr: taskMgr, resolver: resolver}
+}
+
+func (o *orchestratorProviderAdapter) Start() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.cancel != nil {
+		if o.stopping {
+			return fmt.Errorf("orchestrator still %s", project.StatusStopping)
+		}
+		return fmt.Errorf("orchestrator already %s", o.orch.GetStatus().Status)
+	}
+
+	// The run loop checks too, but only reports its error once it has exited
+	if _, err := o.taskMgr.Scan(); err != nil {
+		return fmt.Errorf("scan tasks: %w", err)
+	}
+	if cycle := o.resolver.DetectCycle(); cycle != nil {
+		return fmt.Errorf("dependency cycle detected: %s", task.FormatCycle(cycle))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	o.cancel = cancel
+	o.lastErr = nil
+
+	go func() {
+		err := o.orch.Start(ctx)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("Orchestrator exited: %v", err)
+		} else {
+			err = nil
+		}
+		cancel()
+
+		o.mu.Lock()
+		o.cancel = nil
+		o.stopping = false
+		o.lastErr = err
+		o.mu.Unlock()
+	}()
+
+	return nil
+}
+
+func (o *orchestratorProviderAdapter) Stop() error {
+	o.mu.Lock()
+	cancel := o.cancel
+	if cancel == nil || o.stopping {
+		o.mu.Unlock()
+		return fmt.Errorf("orchestrator not running")
+	}
+	o.stopping = true
+	o.mu.Unlock()
+
+	if err := o.orch.Stop(); err != nil {
+		o.mu.Lock()
+		o.stopping = false
+		o.mu.Unlock()
+		return err
+	}
+	cancel()
+
+	return nil
+}
+
+func (o *orchestratorProviderAdapter) GetProgress() (*tui.OrchestratorInfo, error) {
+	status := o.orch.GetStatus()
+	// While stopped nothing refreshes the snapshot, so rescan to show edits
+	progress := o.orch.GetProgress()
+	if status.Status == project.StatusStopped {
+		progress = o.orch.ForceRefresh()
+	}
+
+	o.mu.Lock()
+	lastErr := o.lastErr
+	o.mu.Unlock()
+
+	info := &tui.OrchestratorInfo{
+		Status:     string(o.displayStatus(status.Status)),
+		Total:      progress.Total,
+		Complete:   progress.Complete,
+		InProgress: progress.InProgress,
+		Pending:    progress.Pending,
+		Percentage: progress.Percentage,
+	}
+	if status.Status == project.StatusRunning {
+		info.Uptime = status.Uptime
+	}
+	if lastErr != nil {
+		info.LastError = lastErr.Error()
+	}
+
+	return info, nil
+}
+
+// displayStatus returns the status to show for an orchestrator reporting
+// status. Start launches the run loop asynchronously, so it reports
+// "starting" until the orchestrator has picked it up, and "stopping" after
+// Stop until the run loop has exited and Start can be called again.
+func (o *orchestratorProviderAdapter) displayStatus(status project.OrchestratorStatus) project.OrchestratorStatus {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	switch {
+	case o.stopping:
+		return project.StatusStopping
+	case o.cancel != nil && status == project.StatusStopped:
+		return project.StatusStarting
+	}
+	return status
+}
+
+// shutdown stops the orchestrator if it is still running when the dashboard exits.
+func (o *orchestratorProviderAdapter) shutdown() {
+	o.mu.Lock()
+	cancel := o.cancel
+	o.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	_ = o.orch.Stop()
+	cancel()
+}
+
+func createAgentProvider(cfg *config.Config) (*agentProviderAdapter, error) {
 	// Get current working directory
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -164,7 +407,10 @@ func createAgentProvider(cfg *config.Config) (tui.AgentProvider, error) {
 		return nil, fmt.Errorf("create state manager: %w", err)
 	}
 
-	exec := executor.NewExecutor(dockerMgr)
+	exec, err := newExecutor(cfg, dockerMgr)
+	if err != nil {
+		return nil, fmt.Errorf("create executor: %w", err)
+	}
 
 	// Create agent manager
 	agentMgr, err := agent.NewManager(cfg, gitMgr, dockerMgr, stateMgr, exec)
@@ -172,10 +418,10 @@ func createAgentProvider(cfg *config.Config) (tui.AgentProvider, error) {
 		return nil, fmt.Errorf("create agent manager: %w", err)
 	}
 
-	return &agentProviderAdapter{manager: agentMgr}, nil
+	return &agentProviderAdapter{manager: agentMgr, git: gitMgr}, nil
 }
 
-func createTaskProvider() (tui.TaskProvider, error) {
+func createTaskProvider(cfg *config.Config) (*taskProviderAdapter, error) {
 	// Get current working directory
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -183,9 +429,7 @@ func createTaskProvider() (tui.TaskProvider, error) {
 	}
 
 	// Create task manager
-	taskMgr := task.NewManager(&task.Config{
-		ProjectRoot: cwd,
-	})
+	taskMgr := newTaskManagerFromConfig(cwd, cfg)
 
 	return &taskProviderAdapter{manager: taskMgr}, nil
 }
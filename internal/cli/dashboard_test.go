@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bkonkle/tanuki/internal/config"
+	"github.com/bkonkle/tanuki/internal/project"
+	"github.com/bkonkle/tanuki/internal/task"
+)
+
+// newTestOrchestratorAdapter returns a dashboard orchestrator adapter over
+// task files with the given dependencies, keyed by task ID.
+func newTestOrchestratorAdapter(t *testing.T, deps map[string]string) *orchestratorProviderAdapter {
+	t.Helper()
+
+	dir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(oldWd) })
+	_ = os.Chdir(dir)
+
+	tasksDir := filepath.Join(dir, "tasks")
+	if err := os.MkdirAll(tasksDir, 0750); err != nil {
+		t.Fatal(err)
+	}
+	for id, dep := range deps {
+		content := "---\nid: " + id + "\ntitle: Test\nstatus: pending\ndepends_on: [" + dep + "]\n---\n"
+		if err := os.WriteFile(filepath.Join(tasksDir, id+".md"), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	taskMgr := task.NewManager(&task.Config{ProjectRoot: dir})
+	return newOrchestratorProviderAdapter(config.DefaultConfig(), nil, taskMgr)
+}
+
+func TestOrchestratorProviderAdapter_StartRejectsCycle(t *testing.T) {
+	adapter := newTestOrchestratorAdapter(t, map[string]string{"TASK-001": "TASK-002", "TASK-002": "TASK-001"})
+
+	err := adapter.Start()
+	if err == nil || !strings.Contains(err.Error(), "dependency cycle") {
+		t.Fatalf("Start() error = %v, want a dependency cycle error", err)
+	}
+	if adapter.cancel != nil {
+		t.Error("Start() launched the run loop despite the cycle")
+	}
+}
+
+func TestOrchestratorProviderAdapter_DisplayStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		launched bool
+		stopping bool
+		status   project.OrchestratorStatus
+		want     project.OrchestratorStatus
+	}{
+		{"stopped", false, false, project.StatusStopped, project.StatusStopped},
+		{"launched", true, false, project.StatusStopped, project.StatusStarting},
+		{"running", true, false, project.StatusRunning, project.StatusRunning},
+		{"run loop still exiting", true, true, project.StatusStopped, project.StatusStopping},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter := &orchestratorProviderAdapter{stopping: tt.stopping}
+			if tt.launched {
+				adapter.cancel = func() {}
+			}
+			if got := adapter.displayStatus(tt.status); got != tt.want {
+				t.Errorf("displayStatus(%s) = %s, want %s", tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrchestratorProviderAdapter_StartWhileStopping(t *testing.T) {
+	adapter := &orchestratorProviderAdapter{cancel: func() {}, stopping: true}
+
+	err := adapter.Start()
+	if err == nil || !strings.Contains(err.Error(), "stopping") {
+		t.Errorf("Start() error = %v, want it to wait for the stop to finish", err)
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"os"
 	"os/exec"
 
-	"github.com/bkonkle/tanuki/internal/config"
 	"github.com/bkonkle/tanuki/internal/docker"
 	"github.com/bkonkle/tanuki/internal/git"
 	"github.com/bkonkle/tanuki/internal/state"
@@ -43,7 +42,7 @@ func runDiff(_ *cobra.Command, args []string) error {
 	agentName := args[0]
 
 	// Load config
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/bkonkle/tanuki/internal/agent"
+	"github.com/bkonkle/tanuki/internal/config"
+	"github.com/bkonkle/tanuki/internal/docker"
+	"github.com/bkonkle/tanuki/internal/executor"
+	"github.com/bkonkle/tanuki/internal/git"
+	"github.com/bkonkle/tanuki/internal/state"
+	"github.com/bkonkle/tanuki/internal/task"
+	"github.com/spf13/cobra"
+)
+
+// jsonErrors reports command failures as JSON on stderr, set via --json-errors
+var jsonErrors bool
+
+// errorCodeUnknown is the code for errors that don't match a known error.
+const errorCodeUnknown = "unknown"
+
+// sentinelCodes maps sentinel errors to the stable codes reported by
+// --json-errors. Codes are part of the CLI's interface: add new ones, but
+// don't rename them.
+var sentinelCodes = []struct {
+	err  error
+	code string
+}{
+	{agent.ErrAgentNotFound, "agent_not_found"},
+	{agent.ErrAgentExists, "agent_exists"},
+	{agent.ErrAgentWorking, "agent_working"},
+	{agent.ErrAgentBusy, "agent_busy"},
+	{agent.ErrAgentNotReady, "agent_not_ready"},
+	{agent.ErrAgentNotHeld, "agent_not_held"},
+	{agent.ErrInvalidName, "invalid_agent_name"},
+	{agent.ErrInvalidResources, "invalid_resources"},
+	{agent.ErrInvalidLabel, "invalid_label"},
+	{agent.ErrBranchCheckedOut, "branch_checked_out"},
+	{docker.ErrDockerNotInstalled, "docker_not_installed"},
+	{docker.ErrDockerNotRunning, "docker_not_running"},
+	{docker.ErrContainerNotFound, "container_not_found"},
+	{docker.ErrImageNotFound, "image_not_found"},
+	{docker.ErrPermissionDenied, "docker_permission_denied"},
+	{executor.ErrAlreadyRunning, "already_running"},
+	{executor.ErrClaudeNotFound, "claude_not_found"},
+	{executor.ErrMaxIterations, "max_iterations"},
+	{executor.ErrMaxTurns, "max_turns"},
+	{executor.ErrNoCompletionSignal, "no_completion_signal"},
+	{git.ErrNotGitRepo, "not_git_repo"},
+	{git.ErrBranchExists, "branch_exists"},
+	{git.ErrBranchNotFound, "branch_not_found"},
+	{git.ErrWorktreeExists, "worktree_exists"},
+	{state.ErrLockTimeout, "state_lock_timeout"},
+	{task.ErrVerifyFailed, "verify_failed"},
+	{task.ErrExpectedFilesMissing, "expected_files_missing"},
+}
+
+// errorCode returns the stable code for err, or errorCodeUnknown.
+func errorCode(err error) string {
+	var daemonErr *docker.DaemonUnreachableError
+	var cycleErr *task.CycleError
+	var validationErrs config.ValidationErrors
+	var exitErr *ExitCodeError
+	switch {
+	case errors.As(err, &daemonErr):
+		return "docker_unreachable"
+	case errors.As(err, &cycleErr):
+		return "dependency_cycle"
+	case errors.As(err, &validationErrs):
+		return "invalid_config"
+	case errors.As(err, &exitErr):
+		return "exit_status"
+	}
+
+	for _, sentinel := range sentinelCodes {
+		if errors.Is(err, sentinel.err) {
+			return sentinel.code
+		}
+	}
+	return errorCodeUnknown
+}
+
+// jsonError is the shape of an error printed by --json-errors.
+type jsonError struct {
+	Error string `json:"error"`
+	Code  string `json:"code"`
+}
+
+// reportError prints a failed command's error to w: as JSON with
+// --json-errors or --output json, otherwise as "Error: ..." unless the
+// command already reported it. The root command is always silenced so that
+// Execute can report for it, meaning its own errors (such as an unknown
+// command) are always printed.
+func reportError(w io.Writer, cmd *cobra.Command, err error) {
+	if jsonErrors || outputsJSON(cmd) {
+		data, _ := json.Marshal(jsonError{Error: err.Error(), Code: errorCode(err)})
+		_, _ = fmt.Fprintln(w, string(data))
+		return
+	}
+	if cmd == nil || !cmd.HasParent() || !cmd.SilenceErrors {
+		_, _ = fmt.Fprintln(w, "Error:", err)
+	}
+}
+
+// outputsJSON reports whether cmd was run with --output json.
+func outputsJSON(cmd *cobra.Command) bool {
+	if cmd == nil {
+		return false
+	}
+	flag := cmd.Flags().Lookup("output")
+	return flag != nil && flag.Value.String() == "json"
+}
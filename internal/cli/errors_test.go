@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/bkonkle/tanuki/internal/agent"
+	"github.com/bkonkle/tanuki/internal/config"
+	"github.com/bkonkle/tanuki/internal/docker"
+	"github.com/bkonkle/tanuki/internal/task"
+	"github.com/spf13/cobra"
+)
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"wrapped sentinel", fmt.Errorf("%w: %q", agent.ErrAgentNotFound, "auth-1"), "agent_not_found"},
+		{"docker sentinel", docker.ErrDockerNotInstalled, "docker_not_installed"},
+		{"daemon unreachable", &docker.DaemonUnreachableError{Host: "unix:///var/run/docker.sock"}, "docker_unreachable"},
+		{"dependency cycle", fmt.Errorf("sort: %w", &task.CycleError{Cycle: []string{"A", "B", "A"}}), "dependency_cycle"},
+		{"invalid config", fmt.Errorf("failed to load config: %w", config.ValidationErrors{{Message: "bad"}}), "invalid_config"},
+		{"exit status", &ExitCodeError{Code: 3}, "exit_status"},
+		{"unknown", errors.New("boom"), errorCodeUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorCode(tt.err); got != tt.want {
+				t.Errorf("errorCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReportError(t *testing.T) {
+	origJSON := jsonErrors
+	t.Cleanup(func() { jsonErrors = origJSON })
+
+	root := &cobra.Command{Use: "tanuki"}
+	status := &cobra.Command{Use: "status"}
+	status.Flags().StringP("output", "o", "text", "")
+	root.AddCommand(status)
+	err := fmt.Errorf("%w: %q", agent.ErrAgentNotFound, "auth-1")
+
+	var out bytes.Buffer
+	jsonErrors = false
+	reportError(&out, status, err)
+	if got := out.String(); got != "Error: agent not found: \"auth-1\"\n" {
+		t.Errorf("plain output = %q", got)
+	}
+
+	want := `{"error":"agent not found: \"auth-1\"","code":"agent_not_found"}` + "\n"
+	out.Reset()
+	jsonErrors = true
+	reportError(&out, status, err)
+	if got := out.String(); got != want {
+		t.Errorf("--json-errors output = %q, want %q", got, want)
+	}
+
+	out.Reset()
+	jsonErrors = false
+	_ = status.Flags().Set("output", "json")
+	reportError(&out, status, err)
+	if got := out.String(); got != want {
+		t.Errorf("--output json output = %q, want %q", got, want)
+	}
+
+	// A command that reported its own error stays quiet in plain mode
+	out.Reset()
+	_ = status.Flags().Set("output", "text")
+	status.SilenceErrors = true
+	reportError(&out, status, err)
+	if out.Len() != 0 {
+		t.Errorf("silenced output = %q, want nothing", out.String())
+	}
+}
@@ -77,7 +77,7 @@ func runInit(_ *cobra.Command, _ []string) error {
 	}
 
 	// Load config (which may have been just created or already existed)
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		// If validation fails, report but continue with defaults
 		fmt.Printf("Warning: config validation issue: %v\n", err)
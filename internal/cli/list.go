@@ -3,14 +3,14 @@ package cli
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 	"text/tabwriter"
 	"time"
 
 	"github.com/bkonkle/tanuki/internal/agent"
-	"github.com/bkonkle/tanuki/internal/config"
 	"github.com/bkonkle/tanuki/internal/docker"
-	"github.com/bkonkle/tanuki/internal/executor"
 	"github.com/bkonkle/tanuki/internal/git"
 	"github.com/bkonkle/tanuki/internal/state"
 	"github.com/fatih/color"
@@ -19,24 +19,31 @@ import (
 
 var (
 	listOutput string
+	listLabels []string
 )
 
 var listCmd = &cobra.Command{
 	Use:     "list",
 	Aliases: []string{"ls"},
 	Short:   "List all agents",
-	Long:    `List all agents and their current status.`,
-	RunE:    runList,
+	Long: `List all agents and their current status.
+
+Examples:
+  tanuki list
+  tanuki list --label feature=auth
+  tanuki list -o json`,
+	RunE: runList,
 }
 
 func init() {
 	listCmd.Flags().StringVarP(&listOutput, "output", "o", "table", "Output format (table, json)")
+	listCmd.Flags().StringArrayVar(&listLabels, "label", nil, "Only list agents with this key=value label (repeatable)")
 	rootCmd.AddCommand(listCmd)
 }
 
 func runList(_ *cobra.Command, _ []string) error {
 	// Load config
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
@@ -58,7 +65,10 @@ func runList(_ *cobra.Command, _ []string) error {
 	}
 
 	// Create executor
-	exec := executor.NewExecutor(dockerMgr)
+	exec, err := newExecutor(cfg, dockerMgr)
+	if err != nil {
+		return fmt.Errorf("failed to create executor: %w", err)
+	}
 
 	// Create agent manager
 	agentMgr, err := agent.NewManager(cfg, gitMgr, dockerMgr, stateMgr, exec)
@@ -71,12 +81,17 @@ func runList(_ *cobra.Command, _ []string) error {
 		// Log warning but continue
 		fmt.Fprintf(os.Stderr, "Warning: failed to reconcile state: %v\n", reconcileErr)
 	}
+	warnMovedWorktrees(os.Stderr, agentMgr)
 
-	// Get all agents
-	agents, err := agentMgr.List()
+	// Get all agents, narrowed by any --label selectors
+	agents, err := selectAgentsByLabel(agentMgr, listLabels)
 	if err != nil {
 		return err
 	}
+	if len(agents) == 0 && len(listLabels) > 0 {
+		fmt.Printf("No agents match %s\n", strings.Join(listLabels, ", "))
+		return nil
+	}
 
 	// Handle empty list
 	if len(agents) == 0 {
@@ -97,16 +112,17 @@ func runList(_ *cobra.Command, _ []string) error {
 
 func printTable(agents []*agent.Agent) error {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	_, _ = fmt.Fprintln(w, "NAME\tSTATUS\tBRANCH\tUPTIME")
-	_, _ = fmt.Fprintln(w, "----\t------\t------\t------")
+	_, _ = fmt.Fprintln(w, "NAME\tSTATUS\tBRANCH\tUPTIME\tLABELS")
+	_, _ = fmt.Fprintln(w, "----\t------\t------\t------\t------")
 
 	for _, ag := range agents {
 		uptime := formatDuration(time.Since(ag.CreatedAt))
-		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
 			ag.Name,
 			colorStatus(string(ag.Status)),
 			ag.Branch,
 			uptime,
+			agent.FormatLabels(ag.Labels),
 		)
 	}
 
@@ -126,7 +142,7 @@ func colorStatus(status string) string {
 		return color.YellowString(status)
 	case "stopped":
 		return color.RedString(status)
-	case "error":
+	case "error", "failed-held":
 		return color.RedString(status)
 	default:
 		return status
@@ -161,3 +177,16 @@ func isTerminal() bool {
 	}
 	return (fileInfo.Mode() & os.ModeCharDevice) != 0
 }
+
+// warnMovedWorktrees warns about agents whose worktrees aren't in the
+// configured git.worktree_dir, usually because it changed after they were
+// spawned. Those agents keep working in their old worktrees.
+func warnMovedWorktrees(w io.Writer, agentMgr *agent.Manager) {
+	moved, err := agentMgr.MovedWorktrees()
+	if err != nil {
+		return
+	}
+	for _, ag := range moved {
+		_, _ = fmt.Fprintf(w, "Warning: %s's worktree %s is outside git.worktree_dir; it will keep being used\n", ag.Name, ag.WorktreePath)
+	}
+}
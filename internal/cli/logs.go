@@ -1,17 +1,13 @@
 package cli
 
 import (
-	"bufio"
 	"fmt"
 	"os"
 	"os/exec"
 	"strconv"
-	"sync"
 
 	"github.com/bkonkle/tanuki/internal/agent"
-	"github.com/bkonkle/tanuki/internal/config"
 	"github.com/bkonkle/tanuki/internal/docker"
-	"github.com/bkonkle/tanuki/internal/executor"
 	"github.com/bkonkle/tanuki/internal/git"
 	"github.com/bkonkle/tanuki/internal/state"
 	"github.com/spf13/cobra"
@@ -32,7 +28,8 @@ Examples:
   tanuki logs auth-feature
   tanuki logs auth-feature --follow
   tanuki logs auth-feature --tail 100
-  tanuki logs --all`,
+  tanuki logs --all
+  tanuki logs --all --follow`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runLogs,
 }
@@ -40,13 +37,13 @@ Examples:
 func init() {
 	logsCmd.Flags().BoolVarP(&logsFollow, "follow", "f", false, "Follow log output")
 	logsCmd.Flags().IntVarP(&logsTail, "tail", "n", 0, "Number of lines to show from end (0 = all)")
-	logsCmd.Flags().BoolVar(&logsAll, "all", false, "Show logs from all agents")
+	logsCmd.Flags().BoolVar(&logsAll, "all", false, "Show logs from all agents (with --follow, interleaved by timestamp)")
 	rootCmd.AddCommand(logsCmd)
 }
 
 func runLogs(_ *cobra.Command, args []string) error {
 	// Load config
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
@@ -68,7 +65,10 @@ func runLogs(_ *cobra.Command, args []string) error {
 	}
 
 	// Create executor
-	exec := executor.NewExecutor(dockerMgr)
+	exec, err := newExecutor(cfg, dockerMgr)
+	if err != nil {
+		return fmt.Errorf("failed to create executor: %w", err)
+	}
 
 	// Create agent manager
 	agentMgr, err := agent.NewManager(cfg, gitMgr, dockerMgr, stateMgr, exec)
@@ -138,42 +138,6 @@ func showAllLogs(agentMgr *agent.Manager, follow bool, tail int) error {
 		return nil
 	}
 
-	// For --all with --follow, use goroutines with prefixed output
-	var wg sync.WaitGroup
-	for _, ag := range agents {
-		wg.Add(1)
-		go func(ag *agent.Agent) {
-			defer wg.Done()
-			streamLogsWithPrefix(ag, ag.Name)
-		}(ag)
-	}
-	wg.Wait()
-
-	return nil
-}
-
-func streamLogsWithPrefix(ag *agent.Agent, prefix string) {
-	cmd := exec.Command("docker", "logs", "-f", ag.ContainerID) //nolint:gosec // G204: Container ID is from internal state, not user input
-	stdout, err := cmd.StdoutPipe()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "[%s] Error: %v\n", prefix, err)
-		return
-	}
-
-	if err := cmd.Start(); err != nil {
-		fmt.Fprintf(os.Stderr, "[%s] Error starting: %v\n", prefix, err)
-		return
-	}
-
-	scanner := bufio.NewScanner(stdout)
-	for scanner.Scan() {
-		fmt.Printf("[%s] %s\n", prefix, scanner.Text())
-	}
-
-	if err := cmd.Wait(); err != nil {
-		// Don't print error for normal termination
-		if _, ok := err.(*exec.ExitError); !ok {
-			fmt.Fprintf(os.Stderr, "[%s] Error: %v\n", prefix, err)
-		}
-	}
+	// For --all with --follow, interleave every running agent's output
+	return followAllLogs(agents, tail)
 }
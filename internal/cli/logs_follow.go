@@ -0,0 +1,224 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/bkonkle/tanuki/internal/agent"
+	"github.com/bkonkle/tanuki/internal/state"
+	"github.com/bkonkle/tanuki/internal/tui"
+	"github.com/fatih/color"
+)
+
+// logMergeWindow is how long merged lines are held so a line that arrives
+// slightly late from one agent can still print ahead of newer lines from
+// another.
+const logMergeWindow = 250 * time.Millisecond
+
+// agentLogColors cycles through prefix colors so each agent is easy to pick out.
+var agentLogColors = []color.Attribute{
+	color.FgCyan,
+	color.FgGreen,
+	color.FgYellow,
+	color.FgMagenta,
+	color.FgBlue,
+	color.FgRed,
+}
+
+// pendingLogLine is a merged line waiting out the merge window.
+type pendingLogLine struct {
+	line     tui.LogLine
+	received time.Time
+}
+
+// logMerger orders lines from several agents by timestamp. Ordering is
+// best-effort: a line held longer than the window is emitted as-is, even if
+// an older line turns up afterwards.
+type logMerger struct {
+	window  time.Duration
+	pending []pendingLogLine
+}
+
+// add holds a line that was received at now.
+func (m *logMerger) add(line tui.LogLine, now time.Time) {
+	m.pending = append(m.pending, pendingLogLine{line: line, received: now})
+}
+
+// flush returns, in timestamp order, the lines received at least one window
+// before now.
+func (m *logMerger) flush(now time.Time) []tui.LogLine {
+	cutoff := now.Add(-m.window)
+
+	var ready []tui.LogLine
+	kept := m.pending[:0]
+	for _, p := range m.pending {
+		if p.received.After(cutoff) {
+			kept = append(kept, p)
+			continue
+		}
+		ready = append(ready, p.line)
+	}
+	m.pending = kept
+
+	sortLogLines(ready)
+	return ready
+}
+
+// drain returns every held line in timestamp order.
+func (m *logMerger) drain() []tui.LogLine {
+	lines := make([]tui.LogLine, 0, len(m.pending))
+	for _, p := range m.pending {
+		lines = append(lines, p.line)
+	}
+	m.pending = nil
+
+	sortLogLines(lines)
+	return lines
+}
+
+// sortLogLines orders lines by timestamp, keeping arrival order for ties.
+func sortLogLines(lines []tui.LogLine) {
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].Timestamp.Before(lines[j].Timestamp)
+	})
+}
+
+// agentLogPrefixes builds a colored, width-aligned "[name]" prefix per agent.
+func agentLogPrefixes(names []string) map[string]string {
+	width := 0
+	for _, name := range names {
+		if len(name) > width {
+			width = len(name)
+		}
+	}
+
+	prefixes := make(map[string]string, len(names))
+	for i, name := range names {
+		c := color.New(agentLogColors[i%len(agentLogColors)])
+		prefixes[name] = c.Sprintf("%-*s", width+2, "["+name+"]")
+	}
+	return prefixes
+}
+
+// followAllLogs streams every running agent's output as one interleaved,
+// timestamp-ordered feed until all streams end or the user interrupts.
+func followAllLogs(agents []*agent.Agent, tail int) error {
+	var names []string
+	for _, ag := range agents {
+		if ag.Status != state.StatusStopped {
+			names = append(names, ag.Name)
+		}
+	}
+	if len(names) == 0 {
+		fmt.Println("No running agents.")
+		return nil
+	}
+	sort.Strings(names)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	merged := make(chan tui.LogLine, 100)
+	done := make(chan struct{}, len(names))
+	started := 0
+	for _, name := range names {
+		reader := tui.NewLogReader(name)
+		reader.SetTail(tail)
+		if err := reader.Start(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to follow logs for %s: %v\n", name, err)
+			continue
+		}
+		defer reader.Stop()
+		started++
+
+		go forwardLogLines(ctx, reader, merged, done)
+	}
+	if started == 0 {
+		return fmt.Errorf("no agent logs could be followed")
+	}
+
+	printMergedLogs(ctx, os.Stdout, agentLogPrefixes(names), merged, done, started)
+	return nil
+}
+
+// forwardLogLines copies a reader's lines onto merged and signals done once
+// its stream has ended and been drained.
+func forwardLogLines(ctx context.Context, reader *tui.LogReader, merged chan<- tui.LogLine, done chan<- struct{}) {
+	defer func() { done <- struct{}{} }()
+
+	send := func(line tui.LogLine) bool {
+		select {
+		case merged <- line:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case line := <-reader.OutputCh():
+			if !send(line) {
+				return
+			}
+		case <-reader.Done():
+			for {
+				select {
+				case line := <-reader.OutputCh():
+					if !send(line) {
+						return
+					}
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// printMergedLogs writes merged lines in timestamp order until every stream
+// has finished or ctx is cancelled, then prints whatever is still held.
+func printMergedLogs(ctx context.Context, out io.Writer, prefixes map[string]string, merged <-chan tui.LogLine, done <-chan struct{}, streams int) {
+	merger := &logMerger{window: logMergeWindow}
+	ticker := time.NewTicker(logMergeWindow / 2)
+	defer ticker.Stop()
+
+	emit := func(lines []tui.LogLine) {
+		for _, line := range lines {
+			_, _ = fmt.Fprintf(out, "%s %s\n", prefixes[line.Agent], line.Content)
+		}
+	}
+
+	for streams > 0 {
+		select {
+		case <-ctx.Done():
+			emit(merger.drain())
+			return
+		case line := <-merged:
+			merger.add(line, time.Now())
+		case <-done:
+			streams--
+		case now := <-ticker.C:
+			emit(merger.flush(now))
+		}
+	}
+
+	// Pick up lines forwarded just before their stream finished
+	for {
+		select {
+		case line := <-merged:
+			merger.add(line, time.Now())
+		default:
+			emit(merger.drain())
+			return
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bkonkle/tanuki/internal/tui"
+	"github.com/fatih/color"
+)
+
+func TestLogMerger_Flush(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	merger := &logMerger{window: time.Second}
+
+	// The backlog from two agents arrives out of order
+	merger.add(tui.LogLine{Agent: "api", Content: "api 2", Timestamp: start.Add(2 * time.Second)}, start)
+	merger.add(tui.LogLine{Agent: "ui", Content: "ui 1", Timestamp: start.Add(time.Second)}, start)
+	merger.add(tui.LogLine{Agent: "api", Content: "api 3", Timestamp: start.Add(3 * time.Second)}, start)
+	merger.add(tui.LogLine{Agent: "ui", Content: "ui 4", Timestamp: start.Add(4 * time.Second)}, start.Add(900*time.Millisecond))
+
+	got := contents(merger.flush(start.Add(500 * time.Millisecond)))
+	if len(got) != 0 {
+		t.Errorf("flush() inside the window = %v, want nothing", got)
+	}
+
+	got = contents(merger.flush(start.Add(time.Second)))
+	if want := "ui 1,api 2,api 3"; strings.Join(got, ",") != want {
+		t.Errorf("flush() = %v, want %s", got, want)
+	}
+
+	got = contents(merger.drain())
+	if want := "ui 4"; strings.Join(got, ",") != want {
+		t.Errorf("drain() = %v, want %s", got, want)
+	}
+	if len(merger.pending) != 0 {
+		t.Errorf("pending = %d lines after drain, want 0", len(merger.pending))
+	}
+}
+
+func TestAgentLogPrefixes(t *testing.T) {
+	orig := color.NoColor
+	color.NoColor = true
+	t.Cleanup(func() { color.NoColor = orig })
+
+	prefixes := agentLogPrefixes([]string{"api", "frontend"})
+	if got, want := prefixes["api"], "[api]     "; got != want {
+		t.Errorf("prefix for api = %q, want %q", got, want)
+	}
+	if got, want := prefixes["frontend"], "[frontend]"; got != want {
+		t.Errorf("prefix for frontend = %q, want %q", got, want)
+	}
+}
+
+func TestPrintMergedLogs(t *testing.T) {
+	now := time.Now()
+	merged := make(chan tui.LogLine, 4)
+	done := make(chan struct{}, 2)
+
+	merged <- tui.LogLine{Agent: "b", Content: "second", Timestamp: now.Add(time.Second)}
+	merged <- tui.LogLine{Agent: "a", Content: "first", Timestamp: now}
+	done <- struct{}{}
+	done <- struct{}{}
+
+	var out bytes.Buffer
+	prefixes := map[string]string{"a": "[a]", "b": "[b]"}
+	printMergedLogs(context.Background(), &out, prefixes, merged, done, 2)
+
+	if got, want := out.String(), "[a] first\n[b] second\n"; got != want {
+		t.Errorf("printMergedLogs() output = %q, want %q", got, want)
+	}
+}
+
+func contents(lines []tui.LogLine) []string {
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		out = append(out, line.Content)
+	}
+	return out
+}
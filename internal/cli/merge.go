@@ -8,9 +8,7 @@ import (
 	"strings"
 
 	"github.com/bkonkle/tanuki/internal/agent"
-	"github.com/bkonkle/tanuki/internal/config"
 	"github.com/bkonkle/tanuki/internal/docker"
-	"github.com/bkonkle/tanuki/internal/executor"
 	"github.com/bkonkle/tanuki/internal/git"
 	"github.com/bkonkle/tanuki/internal/state"
 	"github.com/spf13/cobra"
@@ -54,7 +52,7 @@ func runMerge(_ *cobra.Command, args []string) error {
 	agentName := args[0]
 
 	// Load config
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
@@ -76,7 +74,10 @@ func runMerge(_ *cobra.Command, args []string) error {
 	}
 
 	// Create executor
-	exec := executor.NewExecutor(dockerMgr)
+	exec, err := newExecutor(cfg, dockerMgr)
+	if err != nil {
+		return fmt.Errorf("failed to create executor: %w", err)
+	}
 
 	// Create agent manager
 	agentMgr, err := agent.NewManager(cfg, gitMgr, dockerMgr, stateMgr, exec)
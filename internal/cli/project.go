@@ -4,6 +4,7 @@ import (
 	"path/filepath"
 
 	"github.com/bkonkle/tanuki/internal/config"
+	"github.com/bkonkle/tanuki/internal/task"
 	"github.com/spf13/cobra"
 )
 
@@ -19,9 +20,13 @@ dependencies, and completion criteria.
 Commands:
   init    - Initialize project task structure
   status  - Show all tasks, agents, and progress
+  workstreams - Show workstream scheduling state and blockers
   start   - Spawn agents by workstream and assign tasks
   stop    - Stop all project agents gracefully
-  resume  - Resume a stopped project`,
+  resume  - Resume a stopped project
+  promote - Move a ready workstream to the front of the queue
+  demote  - Move a ready workstream to the back of the queue
+  report  - Export a run report as Markdown or HTML`,
 }
 
 func init() {
@@ -31,10 +36,35 @@ func init() {
 // getTasksDir returns the absolute path to the tasks directory.
 // It loads config to get the tasks_dir setting (defaults to "tasks").
 func getTasksDir(projectRoot string) string {
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		// Fall back to default if config can't be loaded
 		return filepath.Join(projectRoot, "tasks")
 	}
 	return filepath.Join(projectRoot, cfg.TasksDir)
 }
+
+// newTaskManager returns a task manager for projectRoot set up from
+// tanuki.yaml: tasks_dir, extra_task_dirs, task_id_format, and
+// max_assignment_history. It falls back to the defaults if config can't be
+// loaded.
+func newTaskManager(projectRoot string) *task.Manager {
+	cfg, err := loadConfig()
+	if err != nil {
+		cfg = nil
+	}
+	return newTaskManagerFromConfig(projectRoot, cfg)
+}
+
+// newTaskManagerFromConfig is newTaskManager for callers that have already
+// loaded the config. A nil cfg uses the defaults.
+func newTaskManagerFromConfig(projectRoot string, cfg *config.Config) *task.Manager {
+	taskCfg := &task.Config{ProjectRoot: projectRoot}
+	if cfg != nil {
+		taskCfg.TasksDir = cfg.TasksDir
+		taskCfg.ExtraTaskDirs = cfg.ExtraTaskDirs
+		taskCfg.IDFormat = cfg.TaskIDFormat
+		taskCfg.MaxAssignmentHistory = cfg.MaxAssignmentHistory
+	}
+	return task.NewManager(taskCfg)
+}
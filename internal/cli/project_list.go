@@ -40,7 +40,7 @@ func runProjectList(_ *cobra.Command, _ []string) error {
 	}
 
 	// Create task manager and project manager
-	taskMgr := task.NewManager(&task.Config{ProjectRoot: projectRoot})
+	taskMgr := newTaskManager(projectRoot)
 	projMgr := project.NewManager(taskDir, taskMgr)
 
 	// Scan for projects
@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bkonkle/tanuki/internal/project"
+)
+
+const (
+	// progressBarWidth is the number of cells in each progress bar.
+	progressBarWidth = 24
+
+	// progressTTYInterval is how often bars are redrawn on a terminal.
+	progressTTYInterval = time.Second
+
+	// progressLogInterval is how often a summary line is logged when stdout
+	// isn't a terminal.
+	progressLogInterval = 30 * time.Second
+)
+
+// progressReporter periodically renders project progress without the TUI.
+// On a terminal it redraws per-workstream bars in place; otherwise it writes
+// one summary line per interval so logs stay readable.
+type progressReporter struct {
+	out      io.Writer
+	tty      bool
+	interval time.Duration
+	source   func() *project.Progress
+
+	// lines is how many lines the last in-place render drew
+	lines int
+}
+
+// newProgressReporter creates a reporter that reads progress from source.
+func newProgressReporter(out io.Writer, tty bool, source func() *project.Progress) *progressReporter {
+	interval := progressLogInterval
+	if tty {
+		interval = progressTTYInterval
+	}
+	return &progressReporter{
+		out:      out,
+		tty:      tty,
+		interval: interval,
+		source:   source,
+	}
+}
+
+// Run renders progress until stop is closed, then renders a final update.
+func (r *progressReporter) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	r.render()
+	for {
+		select {
+		case <-stop:
+			r.render()
+			return
+		case <-ticker.C:
+			r.render()
+		}
+	}
+}
+
+// render writes a single progress update.
+func (r *progressReporter) render() {
+	progress := r.source()
+	if progress == nil {
+		return
+	}
+
+	if !r.tty {
+		_, _ = fmt.Fprintln(r.out, formatProgressLine(progress))
+		return
+	}
+
+	lines := formatProgressBars(progress)
+
+	var sb strings.Builder
+	if r.lines > 0 {
+		// Move back to the top of the previous render
+		fmt.Fprintf(&sb, "\x1b[%dA", r.lines)
+	}
+	for _, line := range lines {
+		sb.WriteString("\x1b[2K")
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+	_, _ = io.WriteString(r.out, sb.String())
+	r.lines = len(lines)
+}
+
+// sortedWorkstreams returns the workstream progress entries ordered by name.
+func sortedWorkstreams(progress *project.Progress) []*project.WorkstreamProgress {
+	workstreams := make([]*project.WorkstreamProgress, 0, len(progress.ByWorkstream))
+	for _, wp := range progress.ByWorkstream {
+		workstreams = append(workstreams, wp)
+	}
+	sort.Slice(workstreams, func(i, j int) bool {
+		return workstreams[i].Workstream < workstreams[j].Workstream
+	})
+	return workstreams
+}
+
+// formatProgressBars renders one bar per workstream plus an overall total.
+func formatProgressBars(progress *project.Progress) []string {
+	workstreams := sortedWorkstreams(progress)
+
+	nameWidth := len("Total")
+	for _, wp := range workstreams {
+		if len(wp.Workstream) > nameWidth {
+			nameWidth = len(wp.Workstream)
+		}
+	}
+
+	lines := make([]string, 0, len(workstreams)+1)
+	for _, wp := range workstreams {
+		line := fmt.Sprintf("  %-*s %s %d/%d", nameWidth, wp.Workstream,
+			renderProgressBar(wp.Complete, wp.Total, progressBarWidth), wp.Complete, wp.Total)
+		if wp.InProgress > 0 {
+			line += fmt.Sprintf(" (%d running)", wp.InProgress)
+		}
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, fmt.Sprintf("  %-*s %s %d/%d %3.0f%%", nameWidth, "Total",
+		renderProgressBar(progress.Complete, progress.Total, progressBarWidth),
+		progress.Complete, progress.Total, progress.Percentage))
+
+	return lines
+}
+
+// formatProgressLine renders progress as a single log-friendly line.
+func formatProgressLine(progress *project.Progress) string {
+	parts := make([]string, 0, len(progress.ByWorkstream))
+	for _, wp := range sortedWorkstreams(progress) {
+		parts = append(parts, fmt.Sprintf("%s %d/%d", wp.Workstream, wp.Complete, wp.Total))
+	}
+
+	line := fmt.Sprintf("[%s] Progress: %d/%d complete (%.0f%%), %d running, %d pending",
+		time.Now().Format("15:04:05"), progress.Complete, progress.Total,
+		progress.Percentage, progress.InProgress, progress.Pending)
+	if len(parts) > 0 {
+		line += " | " + strings.Join(parts, ", ")
+	}
+	return line
+}
+
+// renderProgressBar draws a fixed-width bar such as "[██████░░░░]".
+func renderProgressBar(done, total, width int) string {
+	filled := 0
+	if total > 0 {
+		filled = done * width / total
+	}
+	if filled > width {
+		filled = width
+	}
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", width-filled) + "]"
+}
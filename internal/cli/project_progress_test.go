@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/bkonkle/tanuki/internal/project"
+)
+
+func testProgress() *project.Progress {
+	return &project.Progress{
+		Total:      4,
+		Complete:   1,
+		InProgress: 1,
+		Pending:    2,
+		Percentage: 25,
+		ByWorkstream: map[string]*project.WorkstreamProgress{
+			"ui":  {Workstream: "ui", Total: 2, Complete: 0},
+			"api": {Workstream: "api", Total: 2, Complete: 1, InProgress: 1},
+		},
+	}
+}
+
+func TestRenderProgressBar(t *testing.T) {
+	tests := []struct {
+		done, total int
+		want        string
+	}{
+		{0, 4, "[░░░░]"},
+		{2, 4, "[██░░]"},
+		{4, 4, "[████]"},
+		{0, 0, "[░░░░]"},
+		{5, 4, "[████]"},
+	}
+
+	for _, tt := range tests {
+		if got := renderProgressBar(tt.done, tt.total, 4); got != tt.want {
+			t.Errorf("renderProgressBar(%d, %d, 4) = %q, want %q", tt.done, tt.total, got, tt.want)
+		}
+	}
+}
+
+func TestFormatProgressBars(t *testing.T) {
+	lines := formatProgressBars(testProgress())
+
+	if len(lines) != 3 {
+		t.Fatalf("formatProgressBars() returned %d lines, want 3", len(lines))
+	}
+	if !strings.Contains(lines[0], "api") || !strings.Contains(lines[0], "1/2 (1 running)") {
+		t.Errorf("lines[0] = %q, want api 1/2 with 1 running", lines[0])
+	}
+	if !strings.Contains(lines[1], "ui") || strings.Contains(lines[1], "running") {
+		t.Errorf("lines[1] = %q, want ui without running count", lines[1])
+	}
+	if !strings.Contains(lines[2], "Total") || !strings.Contains(lines[2], "1/4  25%") {
+		t.Errorf("lines[2] = %q, want total 1/4 25%%", lines[2])
+	}
+}
+
+func TestProgressReporter_Render(t *testing.T) {
+	t.Run("tty redraws in place", func(t *testing.T) {
+		var buf bytes.Buffer
+		r := newProgressReporter(&buf, true, testProgress)
+
+		r.render()
+		if strings.Contains(buf.String(), "\x1b[3A") {
+			t.Error("first render should not move the cursor up")
+		}
+
+		buf.Reset()
+		r.render()
+		if !strings.HasPrefix(buf.String(), "\x1b[3A") {
+			t.Errorf("second render = %q, want it to move up 3 lines first", buf.String())
+		}
+	})
+
+	t.Run("plain output logs one line", func(t *testing.T) {
+		var buf bytes.Buffer
+		r := newProgressReporter(&buf, false, testProgress)
+
+		r.render()
+		out := buf.String()
+		if strings.Contains(out, "\x1b[") {
+			t.Errorf("plain render contains escape codes: %q", out)
+		}
+		if strings.Count(out, "\n") != 1 {
+			t.Errorf("plain render = %q, want a single line", out)
+		}
+		if !strings.Contains(out, "1/4 complete (25%)") || !strings.Contains(out, "api 1/2, ui 0/2") {
+			t.Errorf("plain render = %q, want totals and per-workstream counts", out)
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/bkonkle/tanuki/internal/project"
+	"github.com/spf13/cobra"
+)
+
+var projectPromoteCmd = &cobra.Command{
+	Use:   "promote <workstream>",
+	Short: "Move a ready workstream to the front of the queue",
+	Long: `Move a ready workstream ahead of the others, overriding the scheduler's
+readiness scoring.
+
+The running project applies the change before it starts its next
+workstream, and the manual order lasts until the queue is next re-sorted
+(when another workstream becomes ready). If no project is running, the
+change is applied when "tanuki project start" runs.
+
+Examples:
+  tanuki project promote api
+  tanuki project demote docs`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		return queueWorkstreamOverride(args[0], false)
+	},
+}
+
+var projectDemoteCmd = &cobra.Command{
+	Use:   "demote <workstream>",
+	Short: "Move a ready workstream to the back of the queue",
+	Long: `Move a ready workstream behind the others, overriding the scheduler's
+readiness scoring. See "tanuki project promote" for when it takes effect.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		return queueWorkstreamOverride(args[0], true)
+	},
+}
+
+func init() {
+	projectCmd.AddCommand(projectPromoteCmd)
+	projectCmd.AddCommand(projectDemoteCmd)
+}
+
+// queueWorkstreamOverride records a promotion or demotion for the running
+// project to pick up.
+func queueWorkstreamOverride(workstream string, demote bool) error {
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory: %w", err)
+	}
+
+	taskMgr := newTaskManager(projectRoot)
+	if _, err := taskMgr.Scan(); err != nil {
+		return fmt.Errorf("scan tasks: %w", err)
+	}
+	if len(taskMgr.GetByWorkstream(workstream)) == 0 {
+		return fmt.Errorf("no tasks in workstream %q", workstream)
+	}
+
+	override := project.QueueOverride{Workstream: workstream, Demote: demote}
+	if err := project.AddQueueOverride(projectRoot, override); err != nil {
+		return err
+	}
+
+	if demote {
+		fmt.Printf("Queued demotion of %s; it moves to the back of the ready queue.\n", workstream)
+	} else {
+		fmt.Printf("Queued promotion of %s; it moves to the front of the ready queue.\n", workstream)
+	}
+	return nil
+}
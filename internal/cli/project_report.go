@@ -0,0 +1,396 @@
+package cli
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bkonkle/tanuki/internal/task"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reportFormat string
+	reportOutput string
+)
+
+var projectReportCmd = &cobra.Command{
+	Use:   "report [name]",
+	Short: "Export a run report as Markdown or HTML",
+	Long: `Compiles a shareable summary of a project run from the task files: total
+duration, tasks by status, a per-workstream breakdown, failures with their
+messages and log paths, and per-agent contributions.
+
+The Markdown report is ready to paste into a PR description; the HTML report
+is a standalone page.
+
+Examples:
+  tanuki project report auth-feature
+  tanuki project report auth-feature --format html -o report.html`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runProjectReport,
+}
+
+func init() {
+	projectReportCmd.Flags().StringVarP(&reportFormat, "format", "f", "md", "Report format: md or html")
+	projectReportCmd.Flags().StringVarP(&reportOutput, "output", "o", "", "Write the report to a file instead of stdout")
+	projectCmd.AddCommand(projectReportCmd)
+}
+
+func runProjectReport(_ *cobra.Command, args []string) error {
+	var render func(io.Writer, *projectReport) error
+	switch reportFormat {
+	case "md", "markdown":
+		render = renderReportMarkdown
+	case "html":
+		render = renderReportHTML
+	default:
+		return fmt.Errorf("unknown report format %q (use md or html)", reportFormat)
+	}
+
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory: %w", err)
+	}
+
+	taskMgr := newTaskManager(projectRoot)
+	tasks, err := taskMgr.Scan()
+	if err != nil {
+		return fmt.Errorf("scan tasks: %w", err)
+	}
+
+	name := filepath.Base(projectRoot)
+	if len(args) > 0 {
+		name = args[0]
+		tasks = taskMgr.GetByProject(name)
+	}
+	if len(tasks) == 0 {
+		return fmt.Errorf("no tasks found for project %q", name)
+	}
+
+	report := buildProjectReport(name, tasks, time.Now())
+
+	if reportOutput == "" {
+		return render(os.Stdout, report)
+	}
+
+	f, err := os.Create(reportOutput) //nolint:gosec // G304: output path is chosen by the user
+	if err != nil {
+		return fmt.Errorf("create report file: %w", err)
+	}
+	if err := render(f, report); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("write report file: %w", err)
+	}
+	fmt.Printf("Wrote report to %s\n", reportOutput)
+	return nil
+}
+
+// projectReport is the data behind a run report.
+type projectReport struct {
+	Name        string
+	GeneratedAt time.Time
+
+	// Duration spans the earliest task start to the latest finish (or
+	// GeneratedAt if tasks are still running). Zero if no task has started.
+	Duration time.Duration
+
+	Total       int
+	ByStatus    []reportStatusCount
+	Workstreams []*reportGroup
+	Agents      []*reportGroup
+	Failures    []reportFailure
+}
+
+// reportStatusCount is the number of tasks in a status.
+type reportStatusCount struct {
+	Status task.Status
+	Count  int
+}
+
+// reportGroup summarizes the tasks in a workstream or run by an agent.
+type reportGroup struct {
+	Name       string
+	Total      int
+	Complete   int
+	Failed     int
+	InProgress int
+	Pending    int
+
+	// Time is the sum of the durations of tasks that have finished
+	Time time.Duration
+}
+
+// reportFailure describes a failed task.
+type reportFailure struct {
+	ID       string
+	Title    string
+	Category task.FailureCategory
+	Message  string
+	LogPath  string
+}
+
+// buildProjectReport compiles a report from the tasks as of now.
+func buildProjectReport(name string, tasks []*task.Task, now time.Time) *projectReport {
+	r := &projectReport{
+		Name:        name,
+		GeneratedAt: now,
+		Total:       len(tasks),
+	}
+
+	sorted := append([]*task.Task(nil), tasks...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	counts := make(map[task.Status]int)
+	workstreams := make(map[string]*reportGroup)
+	agents := make(map[string]*reportGroup)
+	var start, end time.Time
+	running := false
+
+	for _, t := range sorted {
+		counts[t.Status]++
+
+		addToReportGroup(workstreams, t.GetWorkstream(), t)
+		if t.LastAgent != "" {
+			addToReportGroup(agents, t.LastAgent, t)
+		}
+
+		if t.StartedAt != nil && (start.IsZero() || t.StartedAt.Before(start)) {
+			start = *t.StartedAt
+		}
+		switch {
+		case t.Status == task.StatusInProgress || t.Status == task.StatusAssigned:
+			running = true
+		case t.CompletedAt != nil && t.CompletedAt.After(end):
+			end = *t.CompletedAt
+		}
+
+		if t.Status == task.StatusFailed {
+			category := t.FailureCategory
+			if category == "" {
+				category = task.FailureOther
+			}
+			r.Failures = append(r.Failures, reportFailure{
+				ID:       t.ID,
+				Title:    t.Title,
+				Category: category,
+				Message:  t.FailureMessage,
+				LogPath:  t.LogFilePath,
+			})
+		}
+	}
+
+	if running {
+		end = now
+	}
+	if !start.IsZero() && end.After(start) {
+		r.Duration = end.Sub(start)
+	}
+
+	for status, count := range counts {
+		r.ByStatus = append(r.ByStatus, reportStatusCount{Status: status, Count: count})
+	}
+	sort.Slice(r.ByStatus, func(i, j int) bool {
+		return statusOrder(r.ByStatus[i].Status) < statusOrder(r.ByStatus[j].Status)
+	})
+
+	r.Workstreams = sortedReportGroups(workstreams)
+	r.Agents = sortedReportGroups(agents)
+	return r
+}
+
+// addToReportGroup counts t towards the named group.
+func addToReportGroup(groups map[string]*reportGroup, name string, t *task.Task) {
+	g, ok := groups[name]
+	if !ok {
+		g = &reportGroup{Name: name}
+		groups[name] = g
+	}
+
+	g.Total++
+	switch t.Status {
+	case task.StatusComplete:
+		g.Complete++
+	case task.StatusFailed:
+		g.Failed++
+	case task.StatusInProgress, task.StatusAssigned:
+		g.InProgress++
+	case task.StatusPending, task.StatusBlocked:
+		g.Pending++
+	}
+
+	g.Time += t.Duration()
+}
+
+// sortedReportGroups returns the groups ordered by name.
+func sortedReportGroups(groups map[string]*reportGroup) []*reportGroup {
+	result := make([]*reportGroup, 0, len(groups))
+	for _, g := range groups {
+		result = append(result, g)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// formatReportDuration renders a duration to the minute, e.g. "1h20m", or
+// "-" if it is unknown.
+func formatReportDuration(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	if d < time.Minute {
+		return d.Round(time.Second).String()
+	}
+
+	d = d.Round(time.Minute)
+	hours, minutes := int(d.Hours()), int(d.Minutes())%60
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	}
+}
+
+// markdownCell escapes text for use in a Markdown table cell.
+func markdownCell(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// renderReportMarkdown writes the report as GitHub-flavored Markdown.
+func renderReportMarkdown(w io.Writer, r *projectReport) error {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "## Tanuki report: %s\n\n", r.Name)
+	fmt.Fprintf(&sb, "Generated %s · %d tasks · duration %s\n\n",
+		r.GeneratedAt.Format("2006-01-02 15:04"), r.Total, formatReportDuration(r.Duration))
+
+	sb.WriteString("### Tasks by status\n\n")
+	sb.WriteString("| Status | Count |\n| --- | ---: |\n")
+	for _, sc := range r.ByStatus {
+		fmt.Fprintf(&sb, "| %s | %d |\n", sc.Status, sc.Count)
+	}
+
+	sb.WriteString("\n### Workstreams\n\n")
+	writeMarkdownGroups(&sb, "Workstream", r.Workstreams)
+
+	sb.WriteString("\n### Failures\n\n")
+	if len(r.Failures) == 0 {
+		sb.WriteString("None.\n")
+	}
+	for _, f := range r.Failures {
+		fmt.Fprintf(&sb, "- **%s** %s (`%s`)", f.ID, markdownCell(f.Title), f.Category)
+		if f.Message != "" {
+			fmt.Fprintf(&sb, ": %s", markdownCell(f.Message))
+		}
+		sb.WriteString("\n")
+		if f.LogPath != "" {
+			fmt.Fprintf(&sb, "  - Log: `%s`\n", f.LogPath)
+		}
+	}
+
+	sb.WriteString("\n### Agents\n\n")
+	if len(r.Agents) == 0 {
+		sb.WriteString("No tasks have been assigned to agents.\n")
+	} else {
+		writeMarkdownGroups(&sb, "Agent", r.Agents)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// writeMarkdownGroups writes a breakdown table for workstreams or agents.
+func writeMarkdownGroups(sb *strings.Builder, label string, groups []*reportGroup) {
+	fmt.Fprintf(sb, "| %s | Tasks | Complete | Failed | In progress | Pending | Time |\n", label)
+	sb.WriteString("| --- | ---: | ---: | ---: | ---: | ---: | ---: |\n")
+	for _, g := range groups {
+		fmt.Fprintf(sb, "| %s | %d | %d | %d | %d | %d | %s |\n",
+			markdownCell(g.Name), g.Total, g.Complete, g.Failed, g.InProgress, g.Pending, formatReportDuration(g.Time))
+	}
+}
+
+// reportHTMLTemplate renders a standalone HTML report.
+var reportHTMLTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"duration": formatReportDuration,
+	"time":     func(t time.Time) string { return t.Format("2006-01-02 15:04") },
+	"groupTable": func(label string, groups []*reportGroup) any {
+		return struct {
+			Label  string
+			Groups []*reportGroup
+		}{label, groups}
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Tanuki report: {{.Name}}</title>
+<style>
+  body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; margin: 2rem auto; max-width: 60rem; color: #1f2328; }
+  table { border-collapse: collapse; margin-bottom: 1.5rem; }
+  th, td { border: 1px solid #d0d7de; padding: 0.3rem 0.7rem; text-align: left; }
+  td.num, th.num { text-align: right; }
+  th { background: #f6f8fa; }
+  .muted { color: #656d76; }
+  .failed { color: #cf222e; }
+  code { background: #f6f8fa; padding: 0.1rem 0.3rem; border-radius: 4px; }
+</style>
+</head>
+<body>
+<h1>Tanuki report: {{.Name}}</h1>
+<p class="muted">Generated {{time .GeneratedAt}} · {{.Total}} tasks · duration {{duration .Duration}}</p>
+
+<h2>Tasks by status</h2>
+<table>
+<tr><th>Status</th><th class="num">Count</th></tr>
+{{- range .ByStatus}}
+<tr><td>{{.Status}}</td><td class="num">{{.Count}}</td></tr>
+{{- end}}
+</table>
+
+<h2>Workstreams</h2>
+{{template "groups" (groupTable "Workstream" .Workstreams)}}
+
+<h2>Failures</h2>
+{{- if not .Failures}}
+<p>None.</p>
+{{- else}}
+<ul>
+{{- range .Failures}}
+<li><strong class="failed">{{.ID}}</strong> {{.Title}} (<code>{{.Category}}</code>){{if .Message}}: {{.Message}}{{end}}
+{{- if .LogPath}}<br><span class="muted">Log: <code>{{.LogPath}}</code></span>{{end}}</li>
+{{- end}}
+</ul>
+{{- end}}
+
+<h2>Agents</h2>
+{{- if not .Agents}}
+<p>No tasks have been assigned to agents.</p>
+{{- else}}
+{{template "groups" (groupTable "Agent" .Agents)}}
+{{- end}}
+</body>
+</html>
+{{define "groups"}}<table>
+<tr><th>{{.Label}}</th><th class="num">Tasks</th><th class="num">Complete</th><th class="num">Failed</th><th class="num">In progress</th><th class="num">Pending</th><th class="num">Time</th></tr>
+{{- range .Groups}}
+<tr><td>{{.Name}}</td><td class="num">{{.Total}}</td><td class="num">{{.Complete}}</td><td class="num">{{.Failed}}</td><td class="num">{{.InProgress}}</td><td class="num">{{.Pending}}</td><td class="num">{{duration .Time}}</td></tr>
+{{- end}}
+</table>{{end}}
+`))
+
+// renderReportHTML writes the report as a standalone HTML page.
+func renderReportHTML(w io.Writer, r *projectReport) error {
+	return reportHTMLTemplate.Execute(w, r)
+}
@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bkonkle/tanuki/internal/task"
+)
+
+func reportTestTasks(base time.Time) []*task.Task {
+	at := func(d time.Duration) *time.Time {
+		t := base.Add(d)
+		return &t
+	}
+	return []*task.Task{
+		{ID: "auth-001", Title: "Schema", Workstream: "db", Status: task.StatusComplete,
+			LastAgent: "db-agent", StartedAt: at(0), CompletedAt: at(30 * time.Minute)},
+		{ID: "auth-002", Title: "API | handlers", Workstream: "api", Status: task.StatusFailed,
+			LastAgent: "api-agent", StartedAt: at(30 * time.Minute), CompletedAt: at(80 * time.Minute),
+			FailureMessage: "verify failed:\n2 tests", FailureCategory: task.FailureVerify, LogFilePath: ".tanuki/logs/auth-002.log"},
+		{ID: "auth-003", Title: "UI", Workstream: "api", Status: task.StatusPending},
+	}
+}
+
+func TestBuildProjectReport(t *testing.T) {
+	base := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	r := buildProjectReport("auth", reportTestTasks(base), base.Add(2*time.Hour))
+
+	if r.Total != 3 {
+		t.Errorf("Total = %d, want 3", r.Total)
+	}
+	if r.Duration != 80*time.Minute {
+		t.Errorf("Duration = %v, want 1h20m", r.Duration)
+	}
+
+	wantStatus := []reportStatusCount{
+		{Status: task.StatusPending, Count: 1},
+		{Status: task.StatusComplete, Count: 1},
+		{Status: task.StatusFailed, Count: 1},
+	}
+	if len(r.ByStatus) != len(wantStatus) {
+		t.Fatalf("ByStatus = %v, want %v", r.ByStatus, wantStatus)
+	}
+	for i, want := range wantStatus {
+		if r.ByStatus[i] != want {
+			t.Errorf("ByStatus[%d] = %v, want %v", i, r.ByStatus[i], want)
+		}
+	}
+
+	if len(r.Workstreams) != 2 || r.Workstreams[0].Name != "api" {
+		t.Fatalf("Workstreams = %v, want api and db", r.Workstreams)
+	}
+	api := r.Workstreams[0]
+	if api.Total != 2 || api.Failed != 1 || api.Pending != 1 || api.Time != 50*time.Minute {
+		t.Errorf("api workstream = %+v", api)
+	}
+
+	if len(r.Agents) != 2 || r.Agents[1].Name != "db-agent" || r.Agents[1].Complete != 1 {
+		t.Errorf("Agents = %+v", r.Agents)
+	}
+
+	if len(r.Failures) != 1 || r.Failures[0].Category != task.FailureVerify {
+		t.Errorf("Failures = %+v", r.Failures)
+	}
+}
+
+func TestBuildProjectReport_Running(t *testing.T) {
+	base := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	tasks := []*task.Task{{ID: "T1", Status: task.StatusInProgress, StartedAt: &base}}
+
+	r := buildProjectReport("p", tasks, base.Add(15*time.Minute))
+	if r.Duration != 15*time.Minute {
+		t.Errorf("Duration = %v, want 15m while tasks are running", r.Duration)
+	}
+}
+
+func TestFormatReportDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "-"},
+		{42 * time.Second, "42s"},
+		{5 * time.Minute, "5m"},
+		{2 * time.Hour, "2h"},
+		{80*time.Minute + 20*time.Second, "1h20m"},
+	}
+	for _, tt := range tests {
+		if got := formatReportDuration(tt.d); got != tt.want {
+			t.Errorf("formatReportDuration(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestRenderReportMarkdown(t *testing.T) {
+	base := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	r := buildProjectReport("auth", reportTestTasks(base), base.Add(2*time.Hour))
+
+	var out bytes.Buffer
+	if err := renderReportMarkdown(&out, r); err != nil {
+		t.Fatalf("renderReportMarkdown() error: %v", err)
+	}
+
+	for _, want := range []string{
+		"## Tanuki report: auth",
+		"duration 1h20m",
+		"| failed | 1 |",
+		"| api | 2 | 0 | 1 | 0 | 1 | 50m |",
+		"- **auth-002** API \\| handlers (`verify_failed`): verify failed: 2 tests",
+		"  - Log: `.tanuki/logs/auth-002.log`",
+		"| db-agent | 1 | 1 | 0 | 0 | 0 | 30m |",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("markdown report missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestRenderReportHTML(t *testing.T) {
+	base := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	tasks := reportTestTasks(base)
+	tasks[1].Title = "<script>alert(1)</script>"
+	r := buildProjectReport("auth", tasks, base.Add(2*time.Hour))
+
+	var out bytes.Buffer
+	if err := renderReportHTML(&out, r); err != nil {
+		t.Fatalf("renderReportHTML() error: %v", err)
+	}
+
+	html := out.String()
+	if !strings.HasPrefix(html, "<!DOCTYPE html>") {
+		t.Error("expected a standalone HTML document")
+	}
+	if strings.Contains(html, "<script>") {
+		t.Error("expected task text to be escaped")
+	}
+	for _, want := range []string{"<td>db-agent</td>", "&lt;script&gt;", ".tanuki/logs/auth-002.log"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("html report missing %q", want)
+		}
+	}
+}
@@ -46,7 +46,7 @@ func runProjectResume(_ *cobra.Command, args []string) error {
 	}
 
 	// Use the real task manager
-	taskMgr := task.NewManager(&task.Config{ProjectRoot: projectRoot})
+	taskMgr := newTaskManager(projectRoot)
 	allTasks, err := taskMgr.Scan()
 	if err != nil {
 		return fmt.Errorf("scan tasks: %w", err)
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -11,7 +12,6 @@ import (
 	"github.com/bkonkle/tanuki/internal/agent"
 	"github.com/bkonkle/tanuki/internal/config"
 	"github.com/bkonkle/tanuki/internal/docker"
-	"github.com/bkonkle/tanuki/internal/executor"
 	"github.com/bkonkle/tanuki/internal/git"
 	"github.com/bkonkle/tanuki/internal/project"
 	"github.com/bkonkle/tanuki/internal/state"
@@ -37,15 +37,83 @@ This command:
   4. Assigns pending tasks to idle agents
   5. Starts task execution
 
+Concurrency comes from the workstreams section of tanuki.yaml: concurrency
+limits the agents working a workstream, and max_concurrent_tasks limits its
+tasks in flight across those agents. Override agent concurrency for a single
+run with repeatable --concurrency workstream=N flags, and cap the total number
+of agents running at once with --max-total.
+
+Examples:
+  tanuki project start
+  tanuki project start auth-feature --concurrency api=2 --concurrency ui=1
+  tanuki project start --max-total 3
+  tanuki project start --progress
+
+Use --progress for per-workstream progress bars that update in place. When
+stdout isn't a terminal, a progress summary line is logged periodically instead.
+
 Use --dry-run to see what would happen without making changes.`,
 	RunE: runProjectStart,
 }
 
 func init() {
 	projectStartCmd.Flags().Bool("dry-run", false, "Show what would happen without doing it")
+	projectStartCmd.Flags().StringArray("concurrency", nil, "Override workstream concurrency as workstream=N (repeatable)")
+	projectStartCmd.Flags().Int("max-total", 0, "Maximum agents running at once across all workstreams (0 = no cap)")
+	projectStartCmd.Flags().Bool("progress", false, "Show per-workstream progress bars while the project runs")
 	projectCmd.AddCommand(projectStartCmd)
 }
 
+const (
+	// maxWorkstreamConcurrency matches the limit enforced on workstream
+	// concurrency in tanuki.yaml.
+	maxWorkstreamConcurrency = 10
+	// maxTotalAgentsLimit is the largest accepted --max-total value.
+	maxTotalAgentsLimit = 50
+)
+
+// parseConcurrencyOverrides parses repeatable --concurrency workstream=N values.
+func parseConcurrencyOverrides(values []string) (map[string]int, error) {
+	overrides := make(map[string]int, len(values))
+	for _, v := range values {
+		workstream, n, ok := strings.Cut(v, "=")
+		workstream = strings.TrimSpace(workstream)
+		if !ok || workstream == "" {
+			return nil, fmt.Errorf("invalid --concurrency %q: expected workstream=N", v)
+		}
+		limit, err := strconv.Atoi(strings.TrimSpace(n))
+		if err != nil {
+			return nil, fmt.Errorf("invalid --concurrency %q: %q is not a number", v, n)
+		}
+		if limit < 1 || limit > maxWorkstreamConcurrency {
+			return nil, fmt.Errorf("invalid --concurrency %q: must be between 1 and %d", v, maxWorkstreamConcurrency)
+		}
+		overrides[workstream] = limit
+	}
+	return overrides, nil
+}
+
+// validateMaxTotal checks a --max-total value. Zero disables the cap.
+func validateMaxTotal(n int) error {
+	if n < 0 || n > maxTotalAgentsLimit {
+		return fmt.Errorf("invalid --max-total %d: must be between 1 and %d (or 0 for no cap)", n, maxTotalAgentsLimit)
+	}
+	return nil
+}
+
+// buildOrchestratorConfig resolves per-workstream agent concurrency, task
+// concurrency, and retry limits for a run. Command-line concurrency overrides
+// take precedence over tanuki.yaml.
+func buildOrchestratorConfig(cfg *config.Config, workstreams []string, overrides map[string]int, maxTotal int) project.OrchestratorConfig {
+	orchCfg := project.DefaultOrchestratorConfig()
+	orchCfg.MaxTotalAgents = maxTotal
+	orchCfg.ApplyConfig(cfg, workstreams...)
+	for ws, limit := range overrides {
+		orchCfg.WorkstreamConcurrency[ws] = limit
+	}
+	return orchCfg
+}
+
 func runProjectStart(cmd *cobra.Command, args []string) error {
 	projectRoot, err := os.Getwd()
 	if err != nil {
@@ -53,6 +121,23 @@ func runProjectStart(cmd *cobra.Command, args []string) error {
 	}
 
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	showProgress, _ := cmd.Flags().GetBool("progress")
+
+	concurrencyFlags, _ := cmd.Flags().GetStringArray("concurrency")
+	overrides, err := parseConcurrencyOverrides(concurrencyFlags)
+	if err != nil {
+		return err
+	}
+
+	maxTotal, _ := cmd.Flags().GetInt("max-total")
+	if err := validateMaxTotal(maxTotal); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
 
 	taskDir := getTasksDir(projectRoot)
 
@@ -63,7 +148,7 @@ func runProjectStart(cmd *cobra.Command, args []string) error {
 	}
 
 	// Use the real task manager
-	taskMgr := task.NewManager(&task.Config{ProjectRoot: projectRoot})
+	taskMgr := newTaskManagerFromConfig(projectRoot, cfg)
 	allTasks, err := taskMgr.Scan()
 	if err != nil {
 		return fmt.Errorf("scan tasks: %w", err)
@@ -131,6 +216,12 @@ func runProjectStart(cmd *cobra.Command, args []string) error {
 		len(tasks), len(workstreams))
 	fmt.Println()
 
+	workstreamNames := make([]string, 0, len(workstreams))
+	for key := range workstreams {
+		workstreamNames = append(workstreamNames, key.workstream)
+	}
+	orchCfg := buildOrchestratorConfig(cfg, workstreamNames, overrides, maxTotal)
+
 	if dryRun {
 		fmt.Println("[DRY RUN] Would spawn agents:")
 		for key, count := range workstreams {
@@ -138,6 +229,13 @@ func runProjectStart(cmd *cobra.Command, args []string) error {
 			branchName := project.WorktreeBranch(key.project, key.workstream)
 			fmt.Printf("  %s (workstream: %s) - %d tasks\n", agentName, key.workstream, count)
 			fmt.Printf("    Branch: %s\n", branchName)
+			fmt.Printf("    Concurrency: %d\n", orchCfg.GetWorkstreamConcurrency(key.workstream))
+			if limit := orchCfg.GetWorkstreamTaskConcurrency(key.workstream); limit > 0 {
+				fmt.Printf("    Max concurrent tasks: %d\n", limit)
+			}
+		}
+		if orchCfg.MaxTotalAgents > 0 {
+			fmt.Printf("  Max total agents: %d\n", orchCfg.MaxTotalAgents)
 		}
 		return nil
 	}
@@ -152,6 +250,7 @@ func runProjectStart(cmd *cobra.Command, args []string) error {
 	if reconcileErr := agentMgr.Reconcile(); reconcileErr != nil {
 		return fmt.Errorf("reconcile agents: %w", reconcileErr)
 	}
+	warnMovedWorktrees(os.Stderr, agentMgr)
 
 	// Remove agents that are in error state (stale containers)
 	agents, err := agentMgr.List()
@@ -175,9 +274,10 @@ func runProjectStart(cmd *cobra.Command, args []string) error {
 	// Create readiness-aware scheduler (prevents deadlocks)
 	scheduler := project.NewReadinessAwareScheduler(taskMgr)
 
-	// Set workstream concurrency limits (default to 1 per workstream)
+	// Set workstream concurrency limits
 	for key := range workstreams {
-		scheduler.SetWorkstreamConcurrency(key.workstream, 1)
+		scheduler.SetWorkstreamConcurrency(key.workstream, orchCfg.GetWorkstreamConcurrency(key.workstream))
+		scheduler.SetWorkstreamTaskConcurrency(key.workstream, orchCfg.GetWorkstreamTaskConcurrency(key.workstream))
 	}
 
 	// Initialize scheduler - analyzes dependencies and builds readiness graph
@@ -185,6 +285,9 @@ func runProjectStart(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("initialize scheduler: %w", err)
 	}
 
+	// Honor promotions and demotions queued before the project started
+	applyQueueOverrides(projectRoot, scheduler)
+
 	// Check for potential deadlocks before starting
 	if deadlock := scheduler.DetectPotentialDeadlock(); deadlock != nil {
 		fmt.Println("Warning: Potential deadlock detected:")
@@ -197,7 +300,17 @@ func runProjectStart(cmd *cobra.Command, args []string) error {
 
 	// Create workstream orchestrator for agent spawning
 	wsConfig := agent.DefaultWorkstreamConfig()
+	wsConfig.ResumeRetries = orchCfg.ResumeRetries
+	wsConfig.SkipVerify = orchCfg.SkipVerify
+	wsConfig.RestartOnTaskChange = orchCfg.RestartOnTaskChange
+	wsConfig.KeepContainerOnFailure = orchCfg.KeepContainerOnFailure
+	wsConfig.GroupFailure = string(orchCfg.GroupFailure)
 	orchestrator := agent.NewWorkstreamOrchestrator(agentMgr, taskMgr, wsConfig)
+	for key := range workstreams {
+		orchestrator.SetWorkstreamConcurrency(key.workstream, orchCfg.GetWorkstreamConcurrency(key.workstream))
+		orchestrator.SetWorkstreamMaxRetries(key.workstream, orchCfg.GetWorkstreamMaxRetries(key.workstream))
+	}
+	orchestrator.SetSpawnLimiter(agent.NewSpawnLimiter(cfg.GetSpawnMaxConcurrent(), cfg.GetSpawnInterval()))
 
 	// Spawn agents only for ready workstreams (those with unblocked tasks)
 	fmt.Println("Spawning agents for ready workstreams...")
@@ -207,6 +320,11 @@ func runProjectStart(cmd *cobra.Command, args []string) error {
 	// Get all ready workstreams
 	readyWorkstreams := scheduler.GetReadyWorkstreams()
 	for _, ws := range readyWorkstreams {
+		if orchCfg.MaxTotalAgents > 0 && len(runners) >= orchCfg.MaxTotalAgents {
+			fmt.Printf("  Max total agents (%d) reached; remaining workstreams start as others finish\n", orchCfg.MaxTotalAgents)
+			break
+		}
+
 		agentName := buildAgentName(ws.Project, ws.Workstream)
 		key := workstreamKey{project: ws.Project, workstream: ws.Workstream}
 
@@ -236,6 +354,7 @@ func runProjectStart(cmd *cobra.Command, args []string) error {
 			orchestrator.ReleaseWorkstream(completedWS)
 
 			// Check if another workstream is now ready
+			applyQueueOverrides(projectRoot, scheduler)
 			nextWS := scheduler.GetNextReadyWorkstream()
 			if nextWS != nil {
 				// Spawn a new runner for the next ready workstream
@@ -279,6 +398,10 @@ func runProjectStart(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Starting %d workstream runner(s)...\n", len(runners))
 		fmt.Println()
 
+		// Ctrl+C stops the runners, returning interrupted tasks to pending
+		stopSignals := orchestrator.HandleSignals(project.DefaultShutdownGracePeriod)
+		defer stopSignals()
+
 		var wg sync.WaitGroup
 		for key, runner := range runners {
 			wg.Add(1)
@@ -290,7 +413,7 @@ func runProjectStart(cmd *cobra.Command, args []string) error {
 			}(key, runner)
 		}
 
-		fmt.Println("Project running. Press Ctrl+C to stop.")
+		fmt.Println("Project running. Press Ctrl+C to stop (twice to force).")
 		if projectName != "" {
 			fmt.Printf("Monitor in another terminal with: tanuki project status %s\n", projectName)
 		} else {
@@ -298,8 +421,31 @@ func runProjectStart(cmd *cobra.Command, args []string) error {
 		}
 		fmt.Println()
 
+		// Render progress until all workstream runners complete
+		stopProgress := make(chan struct{})
+		progressDone := make(chan struct{})
+		if showProgress {
+			reporter := newProgressReporter(os.Stdout, isTerminal(), func() *project.Progress {
+				return project.ProgressFromStats(taskMgr.Stats())
+			})
+			go func() {
+				defer close(progressDone)
+				reporter.Run(stopProgress)
+			}()
+		} else {
+			close(progressDone)
+		}
+
 		// Wait for all workstream runners to complete
 		wg.Wait()
+		close(stopProgress)
+		<-progressDone
+		if orchestrator.ShuttingDown() {
+			// Wait for the shutdown to finish stopping agents
+			orchestrator.Shutdown(project.DefaultShutdownGracePeriod)
+			fmt.Println("Project stopped. Interrupted tasks were returned to pending.")
+			return nil
+		}
 		fmt.Println("All workstreams complete!")
 	} else {
 		fmt.Println("No workstream runners started.")
@@ -308,6 +454,24 @@ func runProjectStart(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// applyQueueOverrides applies promotions and demotions queued with
+// `tanuki project promote` and `tanuki project demote`.
+func applyQueueOverrides(projectRoot string, scheduler *project.ReadinessAwareScheduler) {
+	overrides, err := project.TakeQueueOverrides(projectRoot)
+	if err != nil {
+		log.Printf("Warning: failed to read queue overrides: %v", err)
+		return
+	}
+	for _, o := range overrides {
+		verb := "Promoting"
+		if o.Demote {
+			verb = "Demoting"
+		}
+		log.Printf("%s workstream %s", verb, o.Workstream)
+	}
+	scheduler.ApplyQueueOverrides(overrides)
+}
+
 // buildAgentName creates the agent name from project and workstream.
 // Uses project.AgentName for standardization.
 func buildAgentName(projectName, workstream string) string {
@@ -318,30 +482,10 @@ func buildAgentName(projectName, workstream string) string {
 	return project.AgentName(projectName, workstream)
 }
 
-// buildTaskPrompt creates the prompt to send to an agent for a task.
-func buildTaskPrompt(t *task.Task) string {
-	var prompt strings.Builder
-
-	prompt.WriteString(fmt.Sprintf("# Task: %s\n\n", t.Title))
-	prompt.WriteString(t.Content)
-
-	if t.Completion != nil {
-		prompt.WriteString("\n\n## Completion Criteria\n\n")
-		if t.Completion.Verify != "" {
-			prompt.WriteString(fmt.Sprintf("Run this command to verify: `%s`\n", t.Completion.Verify))
-		}
-		if t.Completion.Signal != "" {
-			prompt.WriteString(fmt.Sprintf("Say **%s** when complete.\n", t.Completion.Signal))
-		}
-	}
-
-	return prompt.String()
-}
-
 // createAgentManager creates an agent.Manager with all dependencies.
 func createAgentManager(_ string) (*agent.Manager, error) {
 	// Load config
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return nil, fmt.Errorf("load config: %w", err)
 	}
@@ -365,7 +509,10 @@ func createAgentManager(_ string) (*agent.Manager, error) {
 	}
 
 	// Create executor
-	exec := executor.NewExecutor(dockerMgr)
+	exec, err := newExecutor(cfg, dockerMgr)
+	if err != nil {
+		return nil, fmt.Errorf("create executor: %w", err)
+	}
 
 	// Create agent manager
 	agentMgr, err := agent.NewManager(cfg, gitMgr, dockerMgr, stateMgr, exec)
@@ -5,8 +5,11 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"text/tabwriter"
+	"time"
 
+	"github.com/bkonkle/tanuki/internal/config"
 	"github.com/bkonkle/tanuki/internal/project"
 	"github.com/bkonkle/tanuki/internal/task"
 	"github.com/spf13/cobra"
@@ -48,7 +51,7 @@ func runProjectStatus(_ *cobra.Command, args []string) error {
 	}
 
 	// Use the real task manager
-	taskMgr := task.NewManager(&task.Config{ProjectRoot: projectRoot})
+	taskMgr := newTaskManager(projectRoot)
 	tasks, err := taskMgr.Scan()
 	if err != nil {
 		return fmt.Errorf("scan tasks: %w", err)
@@ -100,7 +103,17 @@ func runProjectStatus(_ *cobra.Command, args []string) error {
 		counts[task.StatusInProgress]+counts[task.StatusAssigned],
 		counts[task.StatusComplete],
 	)
+	if counts[task.StatusFailed] > 0 {
+		fmt.Printf("Failures: %d (%s)\n", counts[task.StatusFailed], formatFailureBreakdown(tasks))
+	}
 	fmt.Printf("Workstreams: %d\n", len(workstreams))
+	rate := task.CompletionRate(tasks, velocityWindow, time.Now())
+	if rate > 0 {
+		fmt.Printf("Velocity: %.1f tasks/hr (last %s)\n", rate, formatDuration(velocityWindow))
+	}
+	if eta, basis := estimateProjectETA(tasks, rate); eta > 0 {
+		fmt.Printf("ETA: ~%s (%s)\n", formatDuration(eta), basis)
+	}
 	fmt.Println()
 
 	// Print agents (placeholder for now - will integrate with agent manager later)
@@ -165,6 +178,69 @@ func runProjectStatus(_ *cobra.Command, args []string) error {
 	return w.Flush()
 }
 
+// Velocity is measured over velocityWindow, and replaces task estimates in
+// the ETA once at least minVelocitySamples tasks were completed in it.
+const (
+	velocityWindow     = 24 * time.Hour
+	minVelocitySamples = 3
+)
+
+// estimateProjectETA returns a ballpark estimate of the remaining work and
+// what it is based on. With enough recent completions it projects the
+// completion rate forward; otherwise it uses task estimates and the
+// configured workstream concurrency.
+func estimateProjectETA(tasks []*task.Task, rate float64) (time.Duration, string) {
+	if rate > 0 && completedSince(tasks, time.Now().Add(-velocityWindow)) >= minVelocitySamples {
+		return project.EstimateFromRate(tasks, rate), fmt.Sprintf("at %.1f tasks/hr", rate)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		cfg = config.DefaultConfig()
+	}
+
+	parallelism := project.EffectiveParallelism(tasks, cfg.GetWorkstreamConcurrency)
+	return project.EstimateRemaining(tasks, parallelism, cfg.GetDefaultEstimate()), "estimated remaining"
+}
+
+// completedSince counts the complete tasks that finished after since.
+func completedSince(tasks []*task.Task, since time.Time) int {
+	count := 0
+	for _, t := range tasks {
+		if t.Status == task.StatusComplete && t.CompletedAt != nil && t.CompletedAt.After(since) {
+			count++
+		}
+	}
+	return count
+}
+
+// formatFailureBreakdown summarizes failed tasks by category, e.g. "timeout: 2, other: 1".
+func formatFailureBreakdown(tasks []*task.Task) string {
+	byCategory := make(map[task.FailureCategory]int)
+	for _, t := range tasks {
+		if t.Status != task.StatusFailed {
+			continue
+		}
+		category := t.FailureCategory
+		if category == "" {
+			category = task.FailureOther
+		}
+		byCategory[category]++
+	}
+
+	categories := make([]string, 0, len(byCategory))
+	for category := range byCategory {
+		categories = append(categories, string(category))
+	}
+	sort.Strings(categories)
+
+	parts := make([]string, len(categories))
+	for i, category := range categories {
+		parts[i] = fmt.Sprintf("%s: %d", category, byCategory[task.FailureCategory(category)])
+	}
+	return strings.Join(parts, ", ")
+}
+
 // workstreamInfo holds summary info for a workstream.
 type workstreamInfo struct {
 	Name       string
@@ -5,7 +5,9 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/bkonkle/tanuki/internal/config"
 	"github.com/bkonkle/tanuki/internal/task"
 )
 
@@ -187,6 +189,39 @@ func TestProjectInitMultipleProjects(t *testing.T) {
 	}
 }
 
+func TestNewTaskManager_UsesConfig(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []struct{ dir, id string }{
+		{"work", "TASK-001"},
+		{"shared", "TASK-002"},
+	} {
+		if err := os.MkdirAll(filepath.Join(dir, f.dir), 0750); err != nil {
+			t.Fatal(err)
+		}
+		content := "---\nid: " + f.id + "\ntitle: Test\nstatus: pending\n---\n"
+		if err := os.WriteFile(filepath.Join(dir, f.dir, f.id+".md"), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	oldPath := configPath
+	defer func() { configPath = oldPath }()
+	configPath = writeConfigFile(t, "version: \"1\"\ntasks_dir: work\nextra_task_dirs: [shared]\n")
+
+	taskMgr := newTaskManager(dir)
+	if got, want := taskMgr.TasksDir(), filepath.Join(dir, "work"); got != want {
+		t.Errorf("TasksDir() = %q, want %q", got, want)
+	}
+	if _, err := taskMgr.Scan(); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"TASK-001", "TASK-002"} {
+		if _, err := taskMgr.Get(id); err != nil {
+			t.Errorf("Get(%s) error = %v, want tasks from tasks_dir and extra_task_dirs", id, err)
+		}
+	}
+}
+
 func TestMockTaskManagerScan(t *testing.T) {
 	// Create temp directory with task files
 	tempDir := t.TempDir()
@@ -520,6 +555,108 @@ func TestSortTasksByStatus(t *testing.T) {
 	}
 }
 
+func TestFormatFailureBreakdown(t *testing.T) {
+	tasks := []*task.Task{
+		{ID: "A", Status: task.StatusFailed, FailureCategory: task.FailureTimeout},
+		{ID: "B", Status: task.StatusFailed, FailureCategory: task.FailureTimeout},
+		{ID: "C", Status: task.StatusFailed},
+		{ID: "D", Status: task.StatusComplete, FailureCategory: task.FailureVerify},
+	}
+
+	got := formatFailureBreakdown(tasks)
+	want := "other: 1, timeout: 2"
+	if got != want {
+		t.Errorf("formatFailureBreakdown() = %q, want %q", got, want)
+	}
+}
+
+func TestParseConcurrencyOverrides(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    map[string]int
+		wantErr bool
+	}{
+		{"empty", nil, map[string]int{}, false},
+		{"single", []string{"api=2"}, map[string]int{"api": 2}, false},
+		{"repeated", []string{"api=2", "ui=1", "api=3"}, map[string]int{"api": 3, "ui": 1}, false},
+		{"spaces", []string{" api = 4 "}, map[string]int{"api": 4}, false},
+		{"missing equals", []string{"api"}, nil, true},
+		{"missing workstream", []string{"=2"}, nil, true},
+		{"not a number", []string{"api=two"}, nil, true},
+		{"zero", []string{"api=0"}, nil, true},
+		{"too large", []string{"api=11"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseConcurrencyOverrides(tt.values)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseConcurrencyOverrides() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseConcurrencyOverrides() = %v, want %v", got, tt.want)
+			}
+			for ws, n := range tt.want {
+				if got[ws] != n {
+					t.Errorf("parseConcurrencyOverrides()[%q] = %d, want %d", ws, got[ws], n)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateMaxTotal(t *testing.T) {
+	for _, n := range []int{0, 1, 50} {
+		if err := validateMaxTotal(n); err != nil {
+			t.Errorf("validateMaxTotal(%d) unexpected error: %v", n, err)
+		}
+	}
+	for _, n := range []int{-1, 51} {
+		if err := validateMaxTotal(n); err == nil {
+			t.Errorf("validateMaxTotal(%d) expected error", n)
+		}
+	}
+}
+
+func TestBuildOrchestratorConfig_OverridesWinOverConfig(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Workstreams = map[string]*config.WorkstreamConfig{
+		"api": {Concurrency: 3, MaxRetries: 4, MaxConcurrentTasks: 2},
+		"ui":  {Concurrency: 2},
+	}
+
+	orchCfg := buildOrchestratorConfig(cfg, []string{"api", "ui", "docs"}, map[string]int{"ui": 5}, 4)
+
+	if got := orchCfg.GetWorkstreamConcurrency("api"); got != 3 {
+		t.Errorf("api concurrency = %d, want 3 from config", got)
+	}
+	if got := orchCfg.GetWorkstreamConcurrency("ui"); got != 5 {
+		t.Errorf("ui concurrency = %d, want 5 from override", got)
+	}
+	if got := orchCfg.GetWorkstreamConcurrency("docs"); got != 1 {
+		t.Errorf("docs concurrency = %d, want default 1", got)
+	}
+	if orchCfg.MaxTotalAgents != 4 {
+		t.Errorf("MaxTotalAgents = %d, want 4", orchCfg.MaxTotalAgents)
+	}
+	if got := orchCfg.GetWorkstreamTaskConcurrency("api"); got != 2 {
+		t.Errorf("api task concurrency = %d, want 2 from config", got)
+	}
+	if got := orchCfg.GetWorkstreamTaskConcurrency("ui"); got != 0 {
+		t.Errorf("ui task concurrency = %d, want 0 (limited by agents)", got)
+	}
+	if got := orchCfg.GetWorkstreamMaxRetries("api"); got != 4 {
+		t.Errorf("api max retries = %d, want 4 from config", got)
+	}
+	if got := orchCfg.GetWorkstreamMaxRetries("ui"); got != 1 {
+		t.Errorf("ui max retries = %d, want default 1", got)
+	}
+}
+
 func TestTruncate(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -540,28 +677,21 @@ func TestTruncate(t *testing.T) {
 	}
 }
 
-func TestBuildTaskPrompt(t *testing.T) {
-	tsk := &task.Task{
-		Title:   "Test Task",
-		Content: "Do the thing.",
-		Completion: &task.CompletionConfig{
-			Verify: "npm test",
-			Signal: "DONE",
-		},
+func TestEstimateProjectETA_Velocity(t *testing.T) {
+	done := time.Now().Add(-time.Hour)
+	tasks := []*task.Task{
+		{ID: "T1", Status: task.StatusComplete, CompletedAt: &done},
+		{ID: "T2", Status: task.StatusComplete, CompletedAt: &done},
+		{ID: "T3", Status: task.StatusComplete, CompletedAt: &done},
+		{ID: "T4", Status: task.StatusPending, Estimate: "8h"},
+		{ID: "T5", Status: task.StatusPending, Estimate: "8h"},
 	}
 
-	prompt := buildTaskPrompt(tsk)
-
-	if !strings.Contains(prompt, "# Task: Test Task") {
-		t.Error("prompt missing title")
-	}
-	if !strings.Contains(prompt, "Do the thing.") {
-		t.Error("prompt missing content")
-	}
-	if !strings.Contains(prompt, "npm test") {
-		t.Error("prompt missing verify command")
+	eta, basis := estimateProjectETA(tasks, 4)
+	if eta != 30*time.Minute {
+		t.Errorf("estimateProjectETA() = %v, want 30m from 4 tasks/hr", eta)
 	}
-	if !strings.Contains(prompt, "DONE") {
-		t.Error("prompt missing signal")
+	if basis != "at 4.0 tasks/hr" {
+		t.Errorf("basis = %q, want %q", basis, "at 4.0 tasks/hr")
 	}
 }
@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/bkonkle/tanuki/internal/config"
+	"github.com/bkonkle/tanuki/internal/project"
+	"github.com/spf13/cobra"
+)
+
+var workstreamsJSON bool
+
+var projectWorkstreamsCmd = &cobra.Command{
+	Use:   "workstreams [name]",
+	Short: "Show workstream scheduling state",
+	Long: `Shows how the scheduler sees each workstream right now, computed from the
+task files: its status (ready, blocked, active, or complete), how many tasks
+are ready or blocked, its concurrency limit, and which workstreams a blocked
+workstream is waiting on.
+
+Use this to understand why nothing is progressing.
+
+Examples:
+  tanuki project workstreams
+  tanuki project workstreams auth-feature --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runProjectWorkstreams,
+}
+
+func init() {
+	projectWorkstreamsCmd.Flags().BoolVar(&workstreamsJSON, "json", false, "Output as JSON")
+	projectCmd.AddCommand(projectWorkstreamsCmd)
+}
+
+func runProjectWorkstreams(_ *cobra.Command, args []string) error {
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory: %w", err)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		cfg = config.DefaultConfig()
+	}
+
+	taskMgr := newTaskManager(projectRoot)
+	scheduler := project.NewReadinessAwareScheduler(taskMgr)
+	if err := scheduler.Initialize(); err != nil {
+		return fmt.Errorf("initialize scheduler: %w", err)
+	}
+	for _, ws := range scheduler.GetAllWorkstreams() {
+		scheduler.SetWorkstreamConcurrency(ws, cfg.GetWorkstreamConcurrency(ws))
+		scheduler.SetWorkstreamTaskConcurrency(ws, cfg.GetWorkstreamTaskConcurrency(ws))
+	}
+
+	breakdown := scheduler.Breakdown()
+	if len(args) > 0 {
+		filtered := breakdown[:0]
+		for _, b := range breakdown {
+			if b.Project == args[0] {
+				filtered = append(filtered, b)
+			}
+		}
+		breakdown = filtered
+	}
+
+	if workstreamsJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(breakdown)
+	}
+
+	if len(breakdown) == 0 {
+		fmt.Println("No workstreams found.")
+		return nil
+	}
+
+	if err := printWorkstreamBreakdown(os.Stdout, breakdown); err != nil {
+		return err
+	}
+
+	if deadlock := scheduler.DetectPotentialDeadlock(); deadlock != nil {
+		fmt.Printf("\nWarning: %s\n  %s\n", deadlock.Message, deadlock.Suggestion)
+	}
+	return nil
+}
+
+// printWorkstreamBreakdown writes the breakdown as a table.
+func printWorkstreamBreakdown(out io.Writer, breakdown []*project.WorkstreamBreakdown) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "WORKSTREAM\tSTATUS\tREADY\tBLOCKED\tACTIVE\tCONCURRENCY\tWAITING ON")
+	_, _ = fmt.Fprintln(w, "----------\t------\t-----\t-------\t------\t-----------\t----------")
+
+	for _, b := range breakdown {
+		name := b.Workstream
+		if b.Project != "" {
+			name = b.Project + "/" + b.Workstream
+		}
+
+		waitingOn := "-"
+		if len(b.BlockingWorkstreams) > 0 {
+			waitingOn = strings.Join(b.BlockingWorkstreams, ", ")
+		}
+
+		concurrency := fmt.Sprintf("%d", b.Concurrency)
+		if b.TaskConcurrency > 0 {
+			concurrency += fmt.Sprintf(" (max %d tasks)", b.TaskConcurrency)
+		}
+
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%s\t%s\n",
+			name, b.Status, b.ReadyTasks, b.BlockedTasks, b.ActiveTasks, concurrency, waitingOn)
+	}
+
+	return w.Flush()
+}
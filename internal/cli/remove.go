@@ -7,9 +7,7 @@ import (
 	"strings"
 
 	"github.com/bkonkle/tanuki/internal/agent"
-	"github.com/bkonkle/tanuki/internal/config"
 	"github.com/bkonkle/tanuki/internal/docker"
-	"github.com/bkonkle/tanuki/internal/executor"
 	"github.com/bkonkle/tanuki/internal/git"
 	"github.com/bkonkle/tanuki/internal/state"
 	"github.com/spf13/cobra"
@@ -19,6 +17,7 @@ var (
 	removeForce      bool
 	removeKeepBranch bool
 	removeAll        bool
+	removeLabels     []string
 )
 
 var removeCmd = &cobra.Command{
@@ -29,10 +28,14 @@ var removeCmd = &cobra.Command{
 
 This action is destructive. Use --keep-branch to preserve the git branch.
 
+Without an agent name in a terminal, you're asked to pick the agent from a
+list. Scripts and pipes still get an error.
+
 Examples:
   tanuki remove auth-feature
   tanuki remove auth-feature --keep-branch
-  tanuki remove --all --force`,
+  tanuki remove --all --force
+  tanuki remove --label feature=auth`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runRemove,
 }
@@ -41,12 +44,13 @@ func init() {
 	removeCmd.Flags().BoolVar(&removeForce, "force", false, "Skip confirmation")
 	removeCmd.Flags().BoolVar(&removeKeepBranch, "keep-branch", false, "Keep the git branch")
 	removeCmd.Flags().BoolVar(&removeAll, "all", false, "Remove all agents")
+	removeCmd.Flags().StringArrayVar(&removeLabels, "label", nil, "Remove agents with this key=value label (repeatable)")
 	rootCmd.AddCommand(removeCmd)
 }
 
 func runRemove(_ *cobra.Command, args []string) error {
 	// Load config
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
@@ -68,7 +72,10 @@ func runRemove(_ *cobra.Command, args []string) error {
 	}
 
 	// Create executor
-	exec := executor.NewExecutor(dockerMgr)
+	exec, err := newExecutor(cfg, dockerMgr)
+	if err != nil {
+		return fmt.Errorf("failed to create executor: %w", err)
+	}
 
 	// Create agent manager
 	agentMgr, err := agent.NewManager(cfg, gitMgr, dockerMgr, stateMgr, exec)
@@ -76,6 +83,13 @@ func runRemove(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create agent manager: %w", err)
 	}
 
+	if len(removeLabels) > 0 {
+		if len(args) > 0 || removeAll {
+			return fmt.Errorf("cannot use --label with an agent name or --all")
+		}
+		return removeByLabel(agentMgr)
+	}
+
 	if removeAll {
 		if !removeForce {
 			if !confirm("Remove ALL agents? This cannot be undone.") {
@@ -104,7 +118,11 @@ func runRemove(_ *cobra.Command, args []string) error {
 	}
 
 	if len(args) == 0 {
-		return fmt.Errorf("agent name required (or use --all)")
+		name, err := promptAgentName(agentMgr, "remove", nil, fmt.Errorf("agent name required (or use --all or --label)"))
+		if err != nil || name == "" {
+			return err
+		}
+		args = []string{name}
 	}
 
 	agentName := args[0]
@@ -130,20 +148,61 @@ func runRemove(_ *cobra.Command, args []string) error {
 		KeepBranch: removeKeepBranch,
 	}
 
+	// Look up the branch first; it can't be resolved once the worktree is gone
+	var branchName string
+	if ag, err := agentMgr.Get(agentName); err == nil {
+		branchName = ag.Branch
+	}
+
 	if err := agentMgr.Remove(agentName, opts); err != nil {
 		return err
 	}
 
 	fmt.Printf("Removed agent %s\n", agentName)
-	if removeKeepBranch {
-		gitMgr, _ := git.NewManager(cfg)
-		branchName := gitMgr.GetBranchName(agentName)
+	if removeKeepBranch && branchName != "" {
 		fmt.Printf("  Branch preserved: %s\n", branchName)
 	}
 
 	return nil
 }
 
+// removeByLabel removes every agent matching the --label selectors, after
+// listing them and asking for confirmation unless --force is set.
+func removeByLabel(agentMgr *agent.Manager) error {
+	agents, err := selectAgentsByLabel(agentMgr, removeLabels)
+	if err != nil {
+		return err
+	}
+	if len(agents) == 0 {
+		fmt.Printf("No agents match %s\n", strings.Join(removeLabels, ", "))
+		return nil
+	}
+
+	if !removeForce {
+		fmt.Printf("Agents matching %s:\n", strings.Join(removeLabels, ", "))
+		for _, ag := range agents {
+			fmt.Printf("  %s (%s)\n", ag.Name, ag.Status)
+		}
+		if !confirm(fmt.Sprintf("Remove %d agent(s)? This cannot be undone.", len(agents))) {
+			return nil
+		}
+	}
+
+	for _, ag := range agents {
+		fmt.Printf("Removing %s...\n", ag.Name)
+		opts := agent.RemoveOptions{
+			Force:      true,
+			KeepBranch: removeKeepBranch,
+		}
+		if err := agentMgr.Remove(ag.Name, opts); err != nil {
+			fmt.Printf("  Error: %v\n", err)
+		} else {
+			fmt.Printf("  Removed\n")
+		}
+	}
+	return nil
+}
+
 // confirm prompts the user for yes/no confirmation.
 func confirm(message string) bool {
 	reader := bufio.NewReader(os.Stdin)
@@ -1,8 +1,16 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
+	"os"
 
+	"github.com/bkonkle/tanuki/internal/config"
+	"github.com/bkonkle/tanuki/internal/docker"
+	"github.com/bkonkle/tanuki/internal/executor"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fatih/color"
+	"github.com/muesli/termenv"
 	"github.com/spf13/cobra"
 )
 
@@ -13,6 +21,15 @@ var (
 	date    = "unknown"
 )
 
+// configProfile is the config profile selected via --profile
+var configProfile string
+
+// configPath is an explicit config file selected via --config
+var configPath string
+
+// noColor disables colored output, set via --no-color
+var noColor bool
+
 var rootCmd = &cobra.Command{
 	Use:   "tanuki",
 	Short: "Multi-agent orchestration for Claude Code",
@@ -23,26 +40,91 @@ Each agent operates in its own Git worktree with a dedicated branch,
 allowing multiple AI agents to work on different features simultaneously
 without stepping on each other's changes.`,
 	SilenceUsage: true,
-}
-
-var versionCmd = &cobra.Command{
-	Use:   "version",
-	Short: "Print version information",
-	Run: func(_ *cobra.Command, _ []string) {
-		fmt.Printf("tanuki %s\n", version)
-		fmt.Printf("  commit: %s\n", commit)
-		fmt.Printf("  built:  %s\n", date)
-	},
+	// Execute reports errors itself, so they can be printed as JSON
+	SilenceErrors: true,
 }
 
 func init() {
-	rootCmd.AddCommand(versionCmd)
+	cobra.OnInitialize(configureColor)
+
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose output")
+	rootCmd.PersistentFlags().StringVar(&configPath, "config", "", "Load config from this file instead of searching for tanuki.yaml")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output (also honors $NO_COLOR)")
+	rootCmd.PersistentFlags().StringVar(&configProfile, "profile", "", "Config profile to apply (default: $TANUKI_PROFILE)")
+	rootCmd.PersistentFlags().BoolVar(&jsonErrors, "json-errors", false, `Print failures to stderr as JSON: {"error": "...", "code": "..."}`)
+}
+
+// configureColor disables ANSI styling when --no-color is passed or the
+// NO_COLOR environment variable is set to any non-empty value.
+func configureColor() {
+	if !noColor && os.Getenv("NO_COLOR") == "" {
+		return
+	}
+	color.NoColor = true
+	lipgloss.SetColorProfile(termenv.Ascii)
+}
+
+// loadConfig loads the configuration, applying any global CLI selections
+// such as the config file path and profile.
+func loadConfig() (*config.Config, error) {
+	loader := config.NewLoader()
+	loader.SetProfile(configProfile)
+	if configPath != "" {
+		return loader.LoadFromPath(configPath)
+	}
+	return loader.Load()
+}
+
+// newExecutor creates an executor for the agent CLI selected by the
+// executor config.
+func newExecutor(cfg *config.Config, dockerMgr *docker.Manager) (*executor.Executor, error) {
+	backend, err := executor.NewBackend(cfg.Executor.Backend, cfg.Executor.Command)
+	if err != nil {
+		return nil, err
+	}
+	return executor.NewBackendExecutor(dockerMgr, backend), nil
+}
+
+// ExitCodeError makes tanuki exit with a specific status, such as the exit
+// code of a command run inside an agent's container.
+type ExitCodeError struct {
+	Code int
 }
 
-// Execute runs the root command
+func (e *ExitCodeError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+// Execute runs the root command, reporting any error on stderr
 func Execute() error {
-	return rootCmd.Execute()
+	surfaceDockerErrors(rootCmd)
+	cmd, err := rootCmd.ExecuteC()
+	if err != nil {
+		reportError(os.Stderr, cmd, err)
+	}
+	return err
+}
+
+// surfaceDockerErrors wraps the RunE of cmd and its subcommands so that a
+// missing docker CLI or unreachable daemon is reported on its own, rather
+// than under the "failed to create ..." context added on the way up.
+func surfaceDockerErrors(cmd *cobra.Command) {
+	if run := cmd.RunE; run != nil {
+		cmd.RunE = func(c *cobra.Command, args []string) error {
+			err := run(c, args)
+			var daemonErr *docker.DaemonUnreachableError
+			if errors.As(err, &daemonErr) {
+				return daemonErr
+			}
+			if errors.Is(err, docker.ErrDockerNotInstalled) {
+				return docker.ErrDockerNotInstalled
+			}
+			return err
+		}
+	}
+	for _, sub := range cmd.Commands() {
+		surfaceDockerErrors(sub)
+	}
 }
 
 // SetVersionInfo sets version information from build flags
@@ -50,6 +132,7 @@ func SetVersionInfo(v, c, d string) {
 	version = v
 	commit = c
 	date = d
+	setVersionFlag()
 }
 
 // GetRootCmd returns the root command for testing and subcommand registration
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/bkonkle/tanuki/internal/docker"
+	"github.com/bkonkle/tanuki/internal/tui"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fatih/color"
+	"github.com/muesli/termenv"
+	"github.com/spf13/cobra"
+)
+
+func TestConfigureColor(t *testing.T) {
+	origProfile := lipgloss.ColorProfile()
+	origNoColor := color.NoColor
+	origFlag := noColor
+	t.Cleanup(func() {
+		lipgloss.SetColorProfile(origProfile)
+		color.NoColor = origNoColor
+		noColor = origFlag
+	})
+
+	tests := []struct {
+		name    string
+		flag    bool
+		env     string
+		wantRaw bool
+	}{
+		{"colored by default", false, "", false},
+		{"--no-color flag", true, "", true},
+		{"NO_COLOR env", false, "1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.env)
+			lipgloss.SetColorProfile(termenv.TrueColor)
+			color.NoColor = false
+			noColor = tt.flag
+
+			configureColor()
+
+			rendered := tui.ErrorStyle.Render("boom")
+			if got := rendered == "boom"; got != tt.wantRaw {
+				t.Errorf("ErrorStyle.Render() = %q, plain = %v, want plain = %v", rendered, got, tt.wantRaw)
+			}
+			if color.NoColor != tt.wantRaw {
+				t.Errorf("color.NoColor = %v, want %v", color.NoColor, tt.wantRaw)
+			}
+		})
+	}
+}
+
+func TestSurfaceDockerErrors(t *testing.T) {
+	daemonErr := &docker.DaemonUnreachableError{Host: "unix:///var/run/docker.sock"}
+	otherErr := errors.New("boom")
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"daemon error is unwrapped", fmt.Errorf("failed to create agent manager: %w", fmt.Errorf("wrapped: %w", daemonErr)), daemonErr},
+		{"missing docker CLI is unwrapped", fmt.Errorf("failed to create docker manager: %w", docker.ErrDockerNotInstalled), docker.ErrDockerNotInstalled},
+		{"other errors kept", fmt.Errorf("failed: %w", otherErr), nil},
+		{"nil", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent := &cobra.Command{Use: "parent"}
+			child := &cobra.Command{Use: "child", RunE: func(*cobra.Command, []string) error { return tt.err }}
+			parent.AddCommand(child)
+
+			surfaceDockerErrors(parent)
+			got := child.RunE(child, nil)
+
+			want := tt.want
+			if want == nil {
+				want = tt.err
+			}
+			if got != want {
+				t.Errorf("RunE() = %v, want %v", got, want)
+			}
+		})
+	}
+}
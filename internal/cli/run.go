@@ -2,7 +2,9 @@ package cli
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"strings"
@@ -11,9 +13,9 @@ import (
 	"github.com/bkonkle/tanuki/internal/agent"
 	"github.com/bkonkle/tanuki/internal/config"
 	"github.com/bkonkle/tanuki/internal/docker"
-	"github.com/bkonkle/tanuki/internal/executor"
 	"github.com/bkonkle/tanuki/internal/git"
 	"github.com/bkonkle/tanuki/internal/state"
+	"github.com/bkonkle/tanuki/internal/task"
 	"github.com/spf13/cobra"
 )
 
@@ -24,10 +26,14 @@ var (
 	runMaxTurns int
 	runAllow    []string
 	runDeny     []string
+	runAssign   bool
+	runCleanup  bool
+	runRalph    bool
+	runPrompt   string
 )
 
 var runCmd = &cobra.Command{
-	Use:   "run <agent> <prompt>",
+	Use:   "run <agent> <prompt> | run <agent> --prompt-file <path> | run --assign <task-id>",
 	Short: "Send a task to an agent",
 	Long: `Send a task to an agent using Ralph mode (autonomous loop until complete).
 
@@ -36,12 +42,44 @@ The agent will iterate until completion criteria are met:
 - Verify command exits with code 0 (if specified)
 - Max iterations reached
 
+With --assign, the argument is a task ID instead. Tanuki spawns an agent named
+after the task (or reuses it if it already exists), runs the task using its
+completion criteria, and updates the task status with the result. Add
+--cleanup to remove the agent afterward; its branch is kept.
+
+A task loops in Ralph mode when its front matter has completion criteria or a
+ralph block, which sets its own max_iterations, signal, verify, and cooldown.
+Other tasks run once; add --ralph to loop them too. Settings a task doesn't
+set come from the flags, then the ralph section of tanuki.yaml.
+
+With --prompt-file, the prompt is read from a file instead of the command
+line, which avoids shell quoting for long prompts. Use "-" to read it from
+stdin.
+
 Examples:
   tanuki run auth "Implement OAuth2 login"
   tanuki run auth "Fix all lint errors. Say DONE when clean."
   tanuki run auth "Increase coverage to 80%" --verify "npm test -- --coverage"
-  tanuki run auth "Add feature" --signal "COMPLETE" --max-iter 50`,
-	Args: cobra.ExactArgs(2),
+  tanuki run auth "Add feature" --signal "COMPLETE" --max-iter 50
+  tanuki run auth --prompt-file prompts/oauth.md
+  git show HEAD:prompts/review.md | tanuki run auth --prompt-file -
+  tanuki run --assign TASK-001 --cleanup
+  tanuki run --assign TASK-002 --ralph`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if runAssign {
+			if runPrompt != "" {
+				return fmt.Errorf("--prompt-file can't be used with --assign; the task file is the prompt")
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		}
+		if runPrompt != "" {
+			if len(args) == 2 {
+				return fmt.Errorf("give the prompt inline or with --prompt-file, not both")
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		}
+		return cobra.ExactArgs(2)(cmd, args)
+	},
 	RunE: runRun,
 }
 
@@ -50,25 +88,52 @@ func init() {
 	runCmd.Flags().IntVar(&runMaxIter, "max-iter", 30, "Max iterations before stopping")
 	runCmd.Flags().StringVar(&runSignal, "signal", "DONE", "Completion signal to detect in output")
 	runCmd.Flags().StringVar(&runVerify, "verify", "", "Command to verify completion (e.g., 'npm test')")
+	runCmd.Flags().StringVar(&runPrompt, "prompt-file", "", "Read the prompt from a file (- for stdin)")
 
 	// Execution options
 	runCmd.Flags().IntVarP(&runMaxTurns, "max-turns", "t", 0, "Max conversation turns per iteration")
 	runCmd.Flags().StringSliceVarP(&runAllow, "allow", "a", nil, "Additional allowed tools")
 	runCmd.Flags().StringSliceVarP(&runDeny, "deny", "d", nil, "Disallowed tools")
 
+	// One-off task options
+	runCmd.Flags().BoolVar(&runAssign, "assign", false, "Treat the argument as a task ID and run it on a dedicated agent")
+	runCmd.Flags().BoolVar(&runCleanup, "cleanup", false, "Remove the agent after an --assign run (keeps its branch)")
+	runCmd.Flags().BoolVar(&runRalph, "ralph", false, "Loop an --assign task in Ralph mode even without completion criteria or a ralph block")
+
 	rootCmd.AddCommand(runCmd)
 }
 
-func runRun(_ *cobra.Command, args []string) error {
-	agentName := args[0]
-	prompt := args[1]
+func runRun(cmd *cobra.Command, args []string) error {
+	if !runAssign {
+		if runCleanup {
+			return fmt.Errorf("--cleanup requires --assign")
+		}
+		if runRalph {
+			return fmt.Errorf("--ralph requires --assign; other runs always use Ralph mode")
+		}
+	}
 
 	// Load config
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	criteria := defaultRalphCriteria(cmd, cfg)
+	if runAssign {
+		return runAssignTask(args[0], cfg, criteria)
+	}
+
+	agentName := args[0]
+	var prompt string
+	if runPrompt != "" {
+		if prompt, err = readPromptFile(runPrompt, os.Stdin); err != nil {
+			return err
+		}
+	} else {
+		prompt = args[1]
+	}
+
 	// Create dependencies
 	gitMgr, err := git.NewManager(cfg)
 	if err != nil {
@@ -86,7 +151,10 @@ func runRun(_ *cobra.Command, args []string) error {
 	}
 
 	// Create executor
-	exec := executor.NewExecutor(dockerMgr)
+	exec, err := newExecutor(cfg, dockerMgr)
+	if err != nil {
+		return fmt.Errorf("failed to create executor: %w", err)
+	}
 
 	// Create agent manager
 	agentMgr, err := agent.NewManager(cfg, gitMgr, dockerMgr, stateMgr, exec)
@@ -117,26 +185,82 @@ func runRun(_ *cobra.Command, args []string) error {
 	}
 
 	// Always use Ralph mode
-	return runRalphMode(agentMgr, agentName, prompt, opts)
+	_, err = runRalphMode(agentMgr, agentName, prompt, opts, criteria)
+	return err
+}
+
+// ralphCriteria controls when a Ralph mode loop stops.
+type ralphCriteria struct {
+	MaxIter  int
+	Signal   string
+	Verify   string
+	Cooldown time.Duration
 }
 
-func runRalphMode(agentMgr *agent.Manager, agentName string, prompt string, opts agent.RunOptions) error {
-	fmt.Printf("Running %s (max %d iterations)...\n", agentName, runMaxIter)
-	fmt.Printf("Completion signal: %q\n", runSignal)
-	if runVerify != "" {
-		fmt.Printf("Verify command: %s\n", runVerify)
+// defaultRalphCriteria returns the loop settings from the run flags, with
+// the ralph section of the config standing in for flags that weren't given.
+// cfg may be nil.
+func defaultRalphCriteria(cmd *cobra.Command, cfg *config.Config) ralphCriteria {
+	criteria := ralphCriteria{
+		MaxIter: runMaxIter,
+		Signal:  runSignal,
+		Verify:  runVerify,
+	}
+	if cfg == nil {
+		return criteria
+	}
+
+	if cfg.Ralph.MaxIterations > 0 && !cmd.Flags().Changed("max-iter") {
+		criteria.MaxIter = cfg.Ralph.MaxIterations
+	}
+	if cfg.Ralph.Signal != "" && !cmd.Flags().Changed("signal") {
+		criteria.Signal = cfg.Ralph.Signal
+	}
+	criteria.Cooldown = cfg.Ralph.GetCooldown()
+	return criteria
+}
+
+// taskRalphCriteria applies a task's ralph block and completion criteria
+// over the defaults.
+func taskRalphCriteria(t *task.Task, defaults ralphCriteria) ralphCriteria {
+	criteria := defaults
+	loop := t.RalphLoop()
+	if loop.MaxIterations > 0 {
+		criteria.MaxIter = loop.MaxIterations
+	}
+	if loop.Signal != "" {
+		criteria.Signal = loop.Signal
+	}
+	if loop.Verify != "" {
+		criteria.Verify = loop.Verify
+	}
+	criteria.Cooldown = loop.GetCooldown(defaults.Cooldown)
+	return criteria
+}
+
+// runRalphMode iterates on an agent until the completion criteria are met.
+// It reports whether completion was detected before the iteration limit.
+func runRalphMode(agentMgr *agent.Manager, agentName string, prompt string, opts agent.RunOptions, criteria ralphCriteria) (bool, error) {
+	fmt.Printf("Running %s (max %d iterations)...\n", agentName, criteria.MaxIter)
+	fmt.Printf("Completion signal: %q\n", criteria.Signal)
+	if criteria.Verify != "" {
+		fmt.Printf("Verify command: %s\n", criteria.Verify)
 	}
 	fmt.Println()
 
 	startTime := time.Now()
 
-	for i := 1; i <= runMaxIter; i++ {
-		fmt.Printf("=== Iteration %d/%d ===\n", i, runMaxIter)
+	for i := 1; i <= criteria.MaxIter; i++ {
+		if i > 1 && criteria.Cooldown > 0 {
+			fmt.Printf("\n--- Cooldown: %s ---\n", criteria.Cooldown)
+			time.Sleep(criteria.Cooldown)
+		}
+		fmt.Printf("=== Iteration %d/%d ===\n", i, criteria.MaxIter)
 
 		// Create a pipe to capture output
 		pr, pw, err := os.Pipe()
 		if err != nil {
-			return fmt.Errorf("failed to create pipe: %w", err)
+			return false, fmt.Errorf("failed to create pipe: %w", err)
 		}
 
 		// Create a buffer to capture output for signal detection
@@ -164,6 +288,9 @@ func runRalphMode(agentMgr *agent.Manager, agentName string, prompt string, opts
 		// Wait for output reading to finish
 		<-doneChan
 
+		if errors.Is(execErr, agent.ErrAgentBusy) {
+			return false, fmt.Errorf("agent %q is already working on a task\nUse 'tanuki logs %s' to see progress", agentName, agentName)
+		}
 		if execErr != nil {
 			fmt.Fprintf(os.Stderr, "\nWarning: execution error: %v\n", execErr)
 		}
@@ -172,19 +299,19 @@ func runRalphMode(agentMgr *agent.Manager, agentName string, prompt string, opts
 		signalFound := false
 		for len(outputChan) > 0 {
 			line := <-outputChan
-			if strings.Contains(line, runSignal) {
+			if strings.Contains(line, criteria.Signal) {
 				signalFound = true
 				break
 			}
 		}
 
 		// Run verify command if specified
-		if runVerify != "" {
-			fmt.Printf("\nRunning verification: %s\n", runVerify)
-			if verifyErr := runVerifyCommand(runVerify); verifyErr != nil {
+		if criteria.Verify != "" {
+			fmt.Printf("\nRunning verification: %s\n", criteria.Verify)
+			if verifyErr := runVerifyCommand(criteria.Verify); verifyErr != nil {
 				fmt.Printf("Verification failed: %v\n", verifyErr)
 				fmt.Println("Continuing to next iteration...")
-				prompt = fmt.Sprintf("Previous verification failed: %v\nPlease fix the issues and say %s when complete.", verifyErr, runSignal)
+				prompt = fmt.Sprintf("Previous verification failed: %v\nPlease fix the issues and say %s when complete.", verifyErr, criteria.Signal)
 				continue
 			}
 			fmt.Println("Verification passed!")
@@ -193,20 +320,20 @@ func runRalphMode(agentMgr *agent.Manager, agentName string, prompt string, opts
 
 		if signalFound {
 			duration := time.Since(startTime)
-			fmt.Printf("\n=== Completion signal detected: %s ===\n", runSignal)
+			fmt.Printf("\n=== Completion signal detected: %s ===\n", criteria.Signal)
 			fmt.Printf("Completed in %d iteration(s) (%s)\n", i, formatDuration(duration))
-			return nil
+			return true, nil
 		}
 
-		if i < runMaxIter {
+		if i < criteria.MaxIter {
 			fmt.Println("\nContinuing to next iteration...")
 			prompt = "Continue with the task."
 		}
 	}
 
-	fmt.Printf("\n=== Max iterations reached (%d) ===\n", runMaxIter)
+	fmt.Printf("\n=== Max iterations reached (%d) ===\n", criteria.MaxIter)
 	fmt.Println("Task may not be complete. Check logs for details.")
-	return nil
+	return false, nil
 }
 
 func runVerifyCommand(cmdStr string) error {
@@ -227,3 +354,23 @@ func truncate(s string, maxLen int) string {
 	}
 	return s[:maxLen-3] + "..."
 }
+
+// readPromptFile reads a prompt from path, or from stdin if path is "-".
+func readPromptFile(path string, stdin io.Reader) (string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(path) //nolint:gosec // G304: Prompt file path is user-provided
+	}
+	if err != nil {
+		return "", fmt.Errorf("read prompt file: %w", err)
+	}
+
+	prompt := strings.TrimSpace(string(data))
+	if prompt == "" {
+		return "", fmt.Errorf("prompt file %s is empty", path)
+	}
+	return prompt, nil
+}
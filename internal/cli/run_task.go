@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/bkonkle/tanuki/internal/agent"
+	"github.com/bkonkle/tanuki/internal/config"
+	"github.com/bkonkle/tanuki/internal/state"
+	"github.com/bkonkle/tanuki/internal/task"
+)
+
+// taskAgentNameInvalid matches runs of characters not allowed in agent names.
+var taskAgentNameInvalid = regexp.MustCompile(`[^a-z0-9]+`)
+
+// taskAgentName derives an agent name from a task ID, e.g. "TASK-001" becomes
+// "task-001". IDs that don't start with a letter get a "task-" prefix.
+func taskAgentName(taskID string) string {
+	name := taskAgentNameInvalid.ReplaceAllString(strings.ToLower(taskID), "-")
+	name = strings.Trim(name, "-")
+	if name == "" || name[0] < 'a' || name[0] > 'z' {
+		name = "task-" + name
+	}
+	if len(name) > 63 {
+		name = name[:63]
+	}
+	return strings.TrimRight(name, "-")
+}
+
+// runAssignTask runs a single task end to end: it finds the task, spawns (or
+// reuses) an agent named after it, runs the task, and records the result in
+// the task file. Tasks in Ralph mode, or any task with --ralph, loop with
+// the task's settings over defaults; others run once.
+func runAssignTask(taskID string, cfg *config.Config, defaults ralphCriteria) error {
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory: %w", err)
+	}
+
+	taskMgr := newTaskManagerFromConfig(projectRoot, cfg)
+	if _, err := taskMgr.Scan(); err != nil {
+		return fmt.Errorf("scan tasks: %w", err)
+	}
+
+	t, err := taskMgr.Get(taskID)
+	if err != nil {
+		return err
+	}
+
+	switch t.Status {
+	case task.StatusComplete:
+		return fmt.Errorf("task %s is already complete", t.ID)
+	case task.StatusAssigned, task.StatusInProgress:
+		return fmt.Errorf("task %s is already assigned to %s", t.ID, t.AssignedTo)
+	}
+
+	if t.BlockedReason != "" {
+		return fmt.Errorf("task %s is blocked: %s (run: tanuki task unblock %s)", t.ID, t.BlockedReason, t.ID)
+	}
+
+	blocking, err := taskMgr.GetBlockingTasks(t.ID)
+	if err != nil {
+		return err
+	}
+	if len(blocking) > 0 {
+		return fmt.Errorf("task %s is blocked by: %s", t.ID, strings.Join(blocking, ", "))
+	}
+
+	// Failed or review tasks go back to pending so they can be assigned again
+	if t.Status != task.StatusPending && t.Status != task.StatusBlocked {
+		if err := taskMgr.UpdateStatus(t.ID, task.StatusPending); err != nil {
+			return fmt.Errorf("reset task status: %w", err)
+		}
+	}
+
+	agentMgr, err := createAgentManager(projectRoot)
+	if err != nil {
+		return err
+	}
+
+	agentName := taskAgentName(t.ID)
+	if err := ensureTaskAgent(agentMgr, agentName); err != nil {
+		return err
+	}
+
+	if err := taskMgr.Assign(t.ID, agentName); err != nil {
+		return fmt.Errorf("assign task: %w", err)
+	}
+	if err := taskMgr.UpdateStatus(t.ID, task.StatusInProgress); err != nil {
+		return fmt.Errorf("update task status: %w", err)
+	}
+
+	criteria := taskRalphCriteria(t, defaults)
+	if !t.IsRalphMode() && !runRalph {
+		criteria.MaxIter = 1
+	}
+
+	opts := agent.RunOptions{
+		Follow:          true,
+		MaxTurns:        runMaxTurns,
+		AllowedTools:    runAllow,
+		DisallowedTools: runDeny,
+	}
+	agent.ApplyTaskTools(&opts, t)
+
+	fmt.Printf("Task %s: %s\n\n", t.ID, t.Title)
+	completed, runErr := runRalphMode(agentMgr, agentName, agent.BuildTaskPrompt(t), opts, criteria)
+
+	switch {
+	case runErr != nil:
+		if err := taskMgr.UpdateFailure(t.ID, runErr, ""); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to record task failure: %v\n", err)
+		}
+	case completed:
+		if err := taskMgr.UpdateStatus(t.ID, task.StatusComplete); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to update task status: %v\n", err)
+		}
+	default:
+		// Out of iterations without a completion signal; leave it for a human
+		if err := taskMgr.UpdateStatus(t.ID, task.StatusReview); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to update task status: %v\n", err)
+		}
+	}
+
+	if updated, err := taskMgr.Get(t.ID); err == nil {
+		fmt.Printf("Task %s is now %s\n", t.ID, updated.Status)
+	}
+
+	if runCleanup {
+		fmt.Printf("Removing agent %s (branch kept)...\n", agentName)
+		if err := agentMgr.Remove(agentName, agent.RemoveOptions{Force: true, KeepBranch: true}); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to remove agent: %v\n", err)
+		}
+	}
+
+	return runErr
+}
+
+// ensureTaskAgent makes sure an idle agent with the given name exists,
+// spawning or starting it as needed.
+func ensureTaskAgent(agentMgr *agent.Manager, name string) error {
+	ag, err := agentMgr.Get(name)
+	if errors.Is(err, agent.ErrAgentNotFound) {
+		fmt.Printf("Spawning agent %s...\n", name)
+		if _, err := agentMgr.Spawn(name, agent.SpawnOptions{}); err != nil {
+			return fmt.Errorf("spawn agent: %w", err)
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	switch ag.Status {
+	case state.StatusWorking:
+		return fmt.Errorf("agent %q is already working on a task\nUse 'tanuki logs %s' to see progress", name, name)
+	case state.StatusStopped:
+		fmt.Printf("Starting agent %s...\n", name)
+		if err := agentMgr.Start(name); err != nil {
+			return fmt.Errorf("start agent: %w", err)
+		}
+	default:
+		fmt.Printf("Reusing agent %s\n", name)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTaskAgentName(t *testing.T) {
+	tests := []struct {
+		taskID string
+		want   string
+	}{
+		{"TASK-001", "task-001"},
+		{"auth-login", "auth-login"},
+		{"Fix_Login Bug", "fix-login-bug"},
+		{"001", "task-001"},
+		{"--weird--", "weird"},
+		{"", "task"},
+		{strings.Repeat("a", 80), strings.Repeat("a", 63)},
+	}
+
+	for _, tt := range tests {
+		got := taskAgentName(tt.taskID)
+		if got != tt.want {
+			t.Errorf("taskAgentName(%q) = %q, want %q", tt.taskID, got, tt.want)
+		}
+		if err := validateAgentName(got); err != nil {
+			t.Errorf("taskAgentName(%q) = %q is not a valid agent name: %v", tt.taskID, got, err)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadPromptFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "prompt.md")
+	if err := os.WriteFile(path, []byte("Implement OAuth2 login.\n\nSay DONE when finished.\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	empty := filepath.Join(dir, "empty.md")
+	if err := os.WriteFile(empty, []byte("\n  \n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		stdin   string
+		want    string
+		wantErr bool
+	}{
+		{"file", path, "", "Implement OAuth2 login.\n\nSay DONE when finished.", false},
+		{"stdin", "-", "Fix the lint errors\n", "Fix the lint errors", false},
+		{"empty file", empty, "", "", true},
+		{"empty stdin", "-", "", "", true},
+		{"missing file", filepath.Join(dir, "missing.md"), "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readPromptFile(tt.path, strings.NewReader(tt.stdin))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readPromptFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("readPromptFile() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunArgs(t *testing.T) {
+	tests := []struct {
+		name       string
+		promptFile string
+		assign     bool
+		args       []string
+		wantErr    bool
+	}{
+		{"inline prompt", "", false, []string{"auth", "Add login"}, false},
+		{"missing prompt", "", false, []string{"auth"}, true},
+		{"prompt file", "prompt.md", false, []string{"auth"}, false},
+		{"prompt file and inline prompt", "prompt.md", false, []string{"auth", "Add login"}, true},
+		{"assign", "", true, []string{"TASK-001"}, false},
+		{"assign with prompt file", "prompt.md", true, []string{"TASK-001"}, true},
+	}
+
+	defer func() { runPrompt, runAssign = "", false }()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runPrompt, runAssign = tt.promptFile, tt.assign
+			err := runCmd.Args(runCmd, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Args(%v) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			}
+		})
+	}
+}
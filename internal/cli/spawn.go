@@ -6,9 +6,7 @@ import (
 	"time"
 
 	"github.com/bkonkle/tanuki/internal/agent"
-	"github.com/bkonkle/tanuki/internal/config"
 	"github.com/bkonkle/tanuki/internal/docker"
-	"github.com/bkonkle/tanuki/internal/executor"
 	"github.com/bkonkle/tanuki/internal/git"
 	"github.com/bkonkle/tanuki/internal/state"
 	"github.com/spf13/cobra"
@@ -18,6 +16,12 @@ var (
 	spawnCount      int
 	spawnBranch     string
 	spawnWorkstream string
+	spawnMemory     string
+	spawnCPUs       string
+	spawnLabels     []string
+	spawnNoNetwork  bool
+	spawnPinnedTask string
+	spawnSpec       string
 )
 
 var spawnCmd = &cobra.Command{
@@ -26,24 +30,61 @@ var spawnCmd = &cobra.Command{
 	Long: `Create a new agent with an isolated git worktree and Docker container.
 
 Examples:
-  tanuki spawn auth              # Create agent named "auth"
-  tanuki spawn -n 3              # Create agent-1, agent-2, agent-3
-  tanuki spawn auth -b main      # Use existing branch
-  tanuki spawn auth -w payments  # Spawn with workstream config`,
+  tanuki spawn auth                       # Create agent named "auth"
+  tanuki spawn -n 3                       # Create agent-1, agent-2, agent-3
+  tanuki spawn auth -b feature/login      # Work on an existing branch
+  tanuki spawn auth -w payments           # Spawn with workstream config
+  tanuki spawn big --memory 16g --cpus 8  # Override resource limits
+  tanuki spawn auth --label feature=auth  # Tag the agent for grouping
+  tanuki spawn migrate --pinned-task DB-007  # Reserve the agent for one task
+  tanuki spawn --spec agents/backend.yaml    # Spawn from a saved spec
+
+A spec file saves a spawn's settings as YAML so it can be version-controlled
+and shared. Flags given on the command line override the spec's values:
+
+  name: backend
+  branch: feature/api
+  workstream: api
+  memory: 8g
+  cpus: "4"
+  labels:
+    team: platform
+  no_network: false
+  pinned_task: API-001
+
+Instead of name, "count: 3" spawns agent-1 through agent-3. Unknown fields
+are reported as errors.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runSpawn,
 }
 
 func init() {
 	spawnCmd.Flags().IntVarP(&spawnCount, "count", "n", 1, "Number of agents to spawn")
-	spawnCmd.Flags().StringVarP(&spawnBranch, "branch", "b", "", "Base branch (default: current branch)")
+	spawnCmd.Flags().StringVarP(&spawnBranch, "branch", "b", "", "Work on an existing branch instead of creating tanuki/<name>")
 	spawnCmd.Flags().StringVarP(&spawnWorkstream, "workstream", "w", "", "Workstream to assign to agent")
+	spawnCmd.Flags().StringVar(&spawnMemory, "memory", "", "Container memory limit for this agent, overriding the config default (e.g. 8g)")
+	spawnCmd.Flags().StringVar(&spawnCPUs, "cpus", "", "Container CPU limit for this agent, overriding the config default (e.g. 4)")
+	spawnCmd.Flags().StringArrayVar(&spawnLabels, "label", nil, "Label to set on the agent as key=value (repeatable)")
+	spawnCmd.Flags().BoolVar(&spawnNoNetwork, "no-network", false, "Run the agent's container with networking disabled")
+	spawnCmd.Flags().StringVar(&spawnPinnedTask, "pinned-task", "", "Reserve the agent for this task: the orchestrator assigns it only this task, and only to this agent")
+	spawnCmd.Flags().StringVar(&spawnSpec, "spec", "", "YAML file of saved spawn settings; flags override its values")
 	rootCmd.AddCommand(spawnCmd)
 }
 
-func runSpawn(_ *cobra.Command, args []string) error {
+func runSpawn(cmd *cobra.Command, args []string) error {
+	spec := &agent.SpawnSpec{}
+	if spawnSpec != "" {
+		var err error
+		if spec, err = agent.LoadSpawnSpec(spawnSpec); err != nil {
+			return err
+		}
+	}
+	if err := applySpawnFlags(cmd, spec); err != nil {
+		return err
+	}
+
 	// Load config
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
@@ -65,7 +106,10 @@ func runSpawn(_ *cobra.Command, args []string) error {
 	}
 
 	// Create executor
-	exec := executor.NewExecutor(dockerMgr)
+	exec, err := newExecutor(cfg, dockerMgr)
+	if err != nil {
+		return fmt.Errorf("failed to create executor: %w", err)
+	}
 
 	// Create agent manager
 	agentMgr, err := agent.NewManager(cfg, gitMgr, dockerMgr, stateMgr, exec)
@@ -75,13 +119,16 @@ func runSpawn(_ *cobra.Command, args []string) error {
 
 	// Determine names
 	var names []string
+	count := max(spec.Count, 1)
 	if len(args) > 0 {
-		if spawnCount > 1 {
+		if count > 1 {
 			return fmt.Errorf("cannot use --count with explicit name")
 		}
 		names = []string{args[0]}
+	} else if spec.Name != "" {
+		names = []string{spec.Name}
 	} else {
-		for i := 1; i <= spawnCount; i++ {
+		for i := 1; i <= count; i++ {
 			names = append(names, fmt.Sprintf("agent-%d", i))
 		}
 	}
@@ -93,21 +140,22 @@ func runSpawn(_ *cobra.Command, args []string) error {
 		}
 	}
 
+	if spec.PinnedTask != "" && len(names) > 1 {
+		return fmt.Errorf("cannot pin %d agents to task %s; --pinned-task reserves a single agent", len(names), spec.PinnedTask)
+	}
+
+	spawnOpts := spec.Options()
+
 	// Spawn each agent
 	for _, name := range names {
-		if spawnWorkstream != "" {
-			fmt.Printf("Spawning agent %s with workstream %q...\n", name, spawnWorkstream)
+		if spawnOpts.Workstream != "" {
+			fmt.Printf("Spawning agent %s with workstream %q...\n", name, spawnOpts.Workstream)
 		} else {
 			fmt.Printf("Spawning agent %s...\n", name)
 		}
 
-		opts := agent.SpawnOptions{
-			Branch:     spawnBranch,
-			Workstream: spawnWorkstream,
-		}
-
 		start := time.Now()
-		ag, err := agentMgr.Spawn(name, opts)
+		ag, err := agentMgr.Spawn(name, spawnOpts)
 		elapsed := time.Since(start)
 
 		if err != nil {
@@ -122,6 +170,12 @@ func runSpawn(_ *cobra.Command, args []string) error {
 			fmt.Printf("    Workstream: %s\n", ag.Workstream)
 		}
 		fmt.Printf("    Worktree:  %s\n", ag.WorktreePath)
+		if len(ag.Labels) > 0 {
+			fmt.Printf("    Labels:    %s\n", agent.FormatLabels(ag.Labels))
+		}
+		if ag.PinnedTask != "" {
+			fmt.Printf("    Pinned to: %s\n", ag.PinnedTask)
+		}
 		fmt.Println()
 	}
 
@@ -136,6 +190,53 @@ func runSpawn(_ *cobra.Command, args []string) error {
 	return nil
 }
 
+// applySpawnFlags overrides a spawn spec with the flags given on the command
+// line and validates the result. Without --spec, every flag applies.
+func applySpawnFlags(cmd *cobra.Command, spec *agent.SpawnSpec) error {
+	flags := cmd.Flags()
+	set := func(name string) bool { return spawnSpec == "" || flags.Changed(name) }
+
+	if set("count") {
+		spec.Count = spawnCount
+		if flags.Changed("count") {
+			spec.Name = ""
+		}
+	}
+	if set("branch") {
+		spec.Branch = spawnBranch
+	}
+	if set("workstream") {
+		spec.Workstream = spawnWorkstream
+	}
+	if set("memory") {
+		spec.Memory = spawnMemory
+	}
+	if set("cpus") {
+		spec.CPUs = spawnCPUs
+	}
+	if set("label") {
+		labels, err := agent.ParseLabels(spawnLabels)
+		if err != nil {
+			return err
+		}
+		if spec.Labels == nil || labels == nil {
+			spec.Labels = labels
+		} else {
+			for key, value := range labels {
+				spec.Labels[key] = value
+			}
+		}
+	}
+	if set("no-network") {
+		spec.NoNetwork = spawnNoNetwork
+	}
+	if set("pinned-task") {
+		spec.PinnedTask = spawnPinnedTask
+	}
+
+	return spec.Validate()
+}
+
 // validNamePattern enforces agent name constraints:
 // - Must start with a lowercase letter
 // - Can contain lowercase letters, numbers, and hyphens
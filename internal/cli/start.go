@@ -4,16 +4,15 @@ import (
 	"fmt"
 
 	"github.com/bkonkle/tanuki/internal/agent"
-	"github.com/bkonkle/tanuki/internal/config"
 	"github.com/bkonkle/tanuki/internal/docker"
-	"github.com/bkonkle/tanuki/internal/executor"
 	"github.com/bkonkle/tanuki/internal/git"
 	"github.com/bkonkle/tanuki/internal/state"
 	"github.com/spf13/cobra"
 )
 
 var (
-	startAll bool
+	startAll    bool
+	startLabels []string
 )
 
 var startCmd = &cobra.Command{
@@ -21,21 +20,26 @@ var startCmd = &cobra.Command{
 	Short: "Start a stopped agent",
 	Long: `Start a previously stopped agent's container.
 
+Without an agent name in a terminal, you're asked to pick from the stopped
+agents.
+
 Examples:
   tanuki start auth-feature
-  tanuki start --all`,
+  tanuki start --all
+  tanuki start --label feature=auth`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runStart,
 }
 
 func init() {
 	startCmd.Flags().BoolVar(&startAll, "all", false, "Start all stopped agents")
+	startCmd.Flags().StringArrayVar(&startLabels, "label", nil, "Start agents with this key=value label (repeatable)")
 	rootCmd.AddCommand(startCmd)
 }
 
 func runStart(_ *cobra.Command, args []string) error {
 	// Load config
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
@@ -57,7 +61,10 @@ func runStart(_ *cobra.Command, args []string) error {
 	}
 
 	// Create executor
-	exec := executor.NewExecutor(dockerMgr)
+	exec, err := newExecutor(cfg, dockerMgr)
+	if err != nil {
+		return fmt.Errorf("failed to create executor: %w", err)
+	}
 
 	// Create agent manager
 	agentMgr, err := agent.NewManager(cfg, gitMgr, dockerMgr, stateMgr, exec)
@@ -65,8 +72,12 @@ func runStart(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create agent manager: %w", err)
 	}
 
-	if startAll {
-		agents, err := agentMgr.List()
+	if startAll || len(startLabels) > 0 {
+		if len(args) > 0 {
+			return fmt.Errorf("cannot use an agent name with --all or --label")
+		}
+
+		agents, err := selectAgentsByLabel(agentMgr, startLabels)
 		if err != nil {
 			return err
 		}
@@ -86,7 +97,11 @@ func runStart(_ *cobra.Command, args []string) error {
 	}
 
 	if len(args) == 0 {
-		return fmt.Errorf("agent name required (or use --all)")
+		name, err := promptAgentName(agentMgr, "start", func(ag *agent.Agent) bool { return ag.Status == state.StatusStopped }, fmt.Errorf("agent name required (or use --all or --label)"))
+		if err != nil || name == "" {
+			return err
+		}
+		args = []string{name}
 	}
 
 	agentName := args[0]
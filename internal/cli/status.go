@@ -3,15 +3,16 @@ package cli
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"sort"
 	"time"
 
 	"github.com/bkonkle/tanuki/internal/agent"
-	"github.com/bkonkle/tanuki/internal/config"
 	"github.com/bkonkle/tanuki/internal/docker"
-	"github.com/bkonkle/tanuki/internal/executor"
 	"github.com/bkonkle/tanuki/internal/git"
 	"github.com/bkonkle/tanuki/internal/state"
+	"github.com/bkonkle/tanuki/internal/tui"
 	"github.com/spf13/cobra"
 )
 
@@ -20,14 +21,17 @@ var (
 )
 
 var statusCmd = &cobra.Command{
-	Use:   "status <agent>",
+	Use:   "status [agent]",
 	Short: "Show detailed agent status",
 	Long: `Show detailed status information for an agent.
 
+With no agent, show the combined memory and CPU usage of all running agents.
+
 Examples:
+  tanuki status
   tanuki status auth-feature
   tanuki status auth-feature -o json`,
-	Args: cobra.ExactArgs(1),
+	Args: cobra.MaximumNArgs(1),
 	RunE: runStatus,
 }
 
@@ -37,10 +41,8 @@ func init() {
 }
 
 func runStatus(_ *cobra.Command, args []string) error {
-	agentName := args[0]
-
 	// Load config
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
@@ -62,7 +64,10 @@ func runStatus(_ *cobra.Command, args []string) error {
 	}
 
 	// Create executor
-	exec := executor.NewExecutor(dockerMgr)
+	exec, err := newExecutor(cfg, dockerMgr)
+	if err != nil {
+		return fmt.Errorf("failed to create executor: %w", err)
+	}
 
 	// Create agent manager
 	agentMgr, err := agent.NewManager(cfg, gitMgr, dockerMgr, stateMgr, exec)
@@ -70,6 +75,21 @@ func runStatus(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create agent manager: %w", err)
 	}
 
+	if len(args) == 0 {
+		total, err := agentMgr.TotalResourceUsage()
+		if err != nil {
+			return err
+		}
+		if statusOutput == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(total)
+		}
+		printTotalUsage(os.Stdout, total)
+		return nil
+	}
+	agentName := args[0]
+
 	// Get status
 	status, err := agentMgr.Status(agentName)
 	if err != nil {
@@ -89,6 +109,18 @@ func printStatusText(s *agent.Status) error {
 	fmt.Printf("Agent: %s\n", s.Name)
 	fmt.Printf("Status: %s\n", colorStatus(s.Status))
 	fmt.Printf("Uptime: %s\n", formatDuration(s.Uptime))
+	if len(s.Labels) > 0 {
+		fmt.Printf("Labels: %s\n", agent.FormatLabels(s.Labels))
+	}
+	if s.Notes != "" {
+		fmt.Printf("Notes: %s\n", s.Notes)
+	}
+	if s.PinnedTask != "" {
+		fmt.Printf("Pinned to: %s\n", s.PinnedTask)
+	}
+	if s.HeldFor != "" {
+		fmt.Printf("Held for: %s (release with: tanuki agent release %s)\n", s.HeldFor, s.Name)
+	}
 	fmt.Println()
 
 	fmt.Println("Container:")
@@ -105,6 +137,9 @@ func printStatusText(s *agent.Status) error {
 	fmt.Println("Git:")
 	fmt.Printf("  Branch:  %s\n", s.Git.Branch)
 	fmt.Printf("  Changes: %v\n", s.Git.HasChanges)
+	if s.Git.DiskUsage > 0 {
+		fmt.Printf("  Disk:    %s\n", tui.FormatBytes(s.Git.DiskUsage))
+	}
 	if s.Git.CommitsAhead > 0 {
 		fmt.Printf("  Ahead:   %d commits\n", s.Git.CommitsAhead)
 	}
@@ -121,9 +156,15 @@ func printStatusText(s *agent.Status) error {
 		} else if s.Status == "working" {
 			fmt.Printf("  Duration:  %s (in progress)\n", formatDuration(time.Since(s.LastTask.StartedAt)))
 		}
+		if s.LastTask.Model != "" {
+			fmt.Printf("  Model:     %s\n", s.LastTask.Model)
+		}
 		if s.LastTask.SessionID != "" {
 			fmt.Printf("  Session:   %s\n", s.LastTask.SessionID)
 		}
+		if s.LastTask.LogFilePath != "" {
+			fmt.Printf("  Log:       %s\n", s.LastTask.LogFilePath)
+		}
 	}
 
 	return nil
@@ -134,3 +175,33 @@ func printStatusJSON(s *agent.Status) error {
 	enc.SetIndent("", "  ")
 	return enc.Encode(s)
 }
+
+// printTotalUsage writes the combined resource usage of running agents,
+// followed by each agent's share.
+func printTotalUsage(w io.Writer, total *agent.TotalUsage) {
+	_, _ = fmt.Fprintln(w, resourceInfo(total).String())
+	if len(total.PerAgent) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(total.PerAgent))
+	for name := range total.PerAgent {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	_, _ = fmt.Fprintln(w)
+	for _, name := range names {
+		usage := total.PerAgent[name]
+		_, _ = fmt.Fprintf(w, "  %-20s Mem: %-20s CPU: %s\n", name, usage.Memory, usage.CPU)
+	}
+}
+
+// resourceInfo converts agent resource totals for display.
+func resourceInfo(total *agent.TotalUsage) *tui.ResourceInfo {
+	return &tui.ResourceInfo{
+		Agents:      total.Agents,
+		MemoryBytes: total.MemoryBytes,
+		CPUPercent:  total.CPUPercent,
+	}
+}
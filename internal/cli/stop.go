@@ -4,16 +4,15 @@ import (
 	"fmt"
 
 	"github.com/bkonkle/tanuki/internal/agent"
-	"github.com/bkonkle/tanuki/internal/config"
 	"github.com/bkonkle/tanuki/internal/docker"
-	"github.com/bkonkle/tanuki/internal/executor"
 	"github.com/bkonkle/tanuki/internal/git"
 	"github.com/bkonkle/tanuki/internal/state"
 	"github.com/spf13/cobra"
 )
 
 var (
-	stopAll bool
+	stopAll    bool
+	stopLabels []string
 )
 
 var stopCmd = &cobra.Command{
@@ -21,21 +20,26 @@ var stopCmd = &cobra.Command{
 	Short: "Stop an agent's container",
 	Long: `Stop an agent's container while preserving its worktree and branch.
 
+Without an agent name in a terminal, you're asked to pick from the running
+agents.
+
 Examples:
   tanuki stop auth-feature
-  tanuki stop --all`,
+  tanuki stop --all
+  tanuki stop --label feature=auth`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runStop,
 }
 
 func init() {
 	stopCmd.Flags().BoolVar(&stopAll, "all", false, "Stop all agents")
+	stopCmd.Flags().StringArrayVar(&stopLabels, "label", nil, "Stop agents with this key=value label (repeatable)")
 	rootCmd.AddCommand(stopCmd)
 }
 
 func runStop(_ *cobra.Command, args []string) error {
 	// Load config
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
@@ -57,7 +61,10 @@ func runStop(_ *cobra.Command, args []string) error {
 	}
 
 	// Create executor
-	exec := executor.NewExecutor(dockerMgr)
+	exec, err := newExecutor(cfg, dockerMgr)
+	if err != nil {
+		return fmt.Errorf("failed to create executor: %w", err)
+	}
 
 	// Create agent manager
 	agentMgr, err := agent.NewManager(cfg, gitMgr, dockerMgr, stateMgr, exec)
@@ -65,8 +72,12 @@ func runStop(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create agent manager: %w", err)
 	}
 
-	if stopAll {
-		agents, err := agentMgr.List()
+	if stopAll || len(stopLabels) > 0 {
+		if len(args) > 0 {
+			return fmt.Errorf("cannot use an agent name with --all or --label")
+		}
+
+		agents, err := selectAgentsByLabel(agentMgr, stopLabels)
 		if err != nil {
 			return err
 		}
@@ -86,7 +97,11 @@ func runStop(_ *cobra.Command, args []string) error {
 	}
 
 	if len(args) == 0 {
-		return fmt.Errorf("agent name required (or use --all)")
+		name, err := promptAgentName(agentMgr, "stop", func(ag *agent.Agent) bool { return ag.Status != state.StatusStopped }, fmt.Errorf("agent name required (or use --all or --label)"))
+		if err != nil || name == "" {
+			return err
+		}
+		args = []string{name}
 	}
 
 	agentName := args[0]
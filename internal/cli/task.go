@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var taskCmd = &cobra.Command{
+	Use:   "task",
+	Short: "Create and inspect individual tasks",
+	Long: `Task commands operate on single task files in the tasks directory.
+
+Commands:
+  new      - Create a task file with the next sequential ID
+  list     - List tasks, or only those ready or blocked
+  show     - Show a task's details and assignment history
+  deps     - Show what a task depends on and what it blocks
+  reset    - Reset tasks, or a whole workstream, to pending
+  timings  - Compare finished tasks' estimates to how long they took
+  snapshot - Save every task's status so it can be restored
+  restore  - Restore task statuses from a snapshot`,
+}
+
+func init() {
+	rootCmd.AddCommand(taskCmd)
+}
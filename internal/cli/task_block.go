@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/bkonkle/tanuki/internal/task"
+	"github.com/spf13/cobra"
+)
+
+var taskBlockCmd = &cobra.Command{
+	Use:   "block <id> <reason>",
+	Short: "Block a task for a reason outside its dependencies",
+	Long: `Block a task by hand, e.g. while it waits on an outside decision or a
+flaky upstream service. The reason is saved in the task file as
+blocked_reason and shown by "tanuki task show". The task isn't scheduled
+until it's unblocked, whatever its dependencies.
+
+Only tasks that haven't started can be blocked.
+
+Examples:
+  tanuki task block TASK-003 "waiting on the pricing decision"
+  tanuki task unblock TASK-003`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runTaskBlock,
+}
+
+var taskUnblockCmd = &cobra.Command{
+	Use:   "unblock <id>",
+	Short: "Unblock a task blocked with \"tanuki task block\"",
+	Long: `Clear a task's blocked_reason. The task goes back to pending, or stays
+blocked while its dependencies are incomplete.
+
+Examples:
+  tanuki task unblock TASK-003`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTaskUnblock,
+}
+
+func init() {
+	taskCmd.AddCommand(taskBlockCmd)
+	taskCmd.AddCommand(taskUnblockCmd)
+}
+
+func runTaskBlock(_ *cobra.Command, args []string) error {
+	taskMgr, err := scanBlockTaskManager()
+	if err != nil {
+		return err
+	}
+	return blockTask(os.Stdout, taskMgr, args[0], strings.Join(args[1:], " "))
+}
+
+func runTaskUnblock(_ *cobra.Command, args []string) error {
+	taskMgr, err := scanBlockTaskManager()
+	if err != nil {
+		return err
+	}
+	return unblockTask(os.Stdout, taskMgr, args[0])
+}
+
+// scanBlockTaskManager returns a task manager for the working directory with
+// its tasks scanned.
+func scanBlockTaskManager() (*task.Manager, error) {
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("get working directory: %w", err)
+	}
+
+	taskMgr := newTaskManager(projectRoot)
+	if _, err := taskMgr.Scan(); err != nil {
+		return nil, fmt.Errorf("scan tasks: %w", err)
+	}
+	return taskMgr, nil
+}
+
+// blockTask blocks a task by hand and reports it.
+func blockTask(w io.Writer, taskMgr *task.Manager, id, reason string) error {
+	if err := taskMgr.Block(id, reason); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(w, "Blocked %s: %s\n", id, strings.TrimSpace(reason))
+	return nil
+}
+
+// unblockTask clears a task's manual block and reports the status it's left in.
+func unblockTask(w io.Writer, taskMgr *task.Manager, id string) error {
+	if err := taskMgr.Unblock(id); err != nil {
+		return err
+	}
+	t, err := taskMgr.Get(id)
+	if err != nil {
+		return err
+	}
+	if t.Status == task.StatusBlocked {
+		_, _ = fmt.Fprintf(w, "Unblocked %s; still waiting on its dependencies\n", id)
+		return nil
+	}
+	_, _ = fmt.Fprintf(w, "Unblocked %s (%s)\n", id, t.Status)
+	return nil
+}
@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bkonkle/tanuki/internal/task"
+)
+
+func TestBlockAndUnblockTask(t *testing.T) {
+	dir := t.TempDir()
+	tasksDir := filepath.Join(dir, "tasks")
+	if err := os.MkdirAll(tasksDir, 0750); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []struct{ id, status, deps string }{
+		{"TASK-001", "pending", ""},
+		{"TASK-002", "pending", "\ndepends_on: [TASK-001]"},
+		{"TASK-003", "complete", ""},
+	} {
+		content := "---\nid: " + f.id + "\ntitle: Test\nstatus: " + f.status + f.deps + "\n---\n"
+		if err := os.WriteFile(filepath.Join(tasksDir, f.id+".md"), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	taskMgr := task.NewManager(&task.Config{ProjectRoot: dir})
+	if _, err := taskMgr.Scan(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		run     func(w *bytes.Buffer) error
+		want    string
+		wantErr string
+	}{
+		{
+			name: "block",
+			run:  func(w *bytes.Buffer) error { return blockTask(w, taskMgr, "TASK-001", "waiting on pricing") },
+			want: "Blocked TASK-001: waiting on pricing",
+		},
+		{
+			name: "unblock to pending",
+			run:  func(w *bytes.Buffer) error { return unblockTask(w, taskMgr, "TASK-001") },
+			want: "Unblocked TASK-001 (pending)",
+		},
+		{
+			name: "unblock with dependencies left",
+			run: func(w *bytes.Buffer) error {
+				if err := blockTask(w, taskMgr, "TASK-002", "flaky upstream"); err != nil {
+					return err
+				}
+				w.Reset()
+				return unblockTask(w, taskMgr, "TASK-002")
+			},
+			want: "still waiting on its dependencies",
+		},
+		{
+			name:    "unblock a task that isn't blocked",
+			run:     func(w *bytes.Buffer) error { return unblockTask(w, taskMgr, "TASK-001") },
+			wantErr: "not blocked by hand",
+		},
+		{
+			name:    "block a complete task",
+			run:     func(w *bytes.Buffer) error { return blockTask(w, taskMgr, "TASK-003", "too late") },
+			wantErr: "can't be blocked",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := tt.run(&out)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if !strings.Contains(out.String(), tt.want) {
+				t.Errorf("output = %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bkonkle/tanuki/internal/task"
+	"github.com/spf13/cobra"
+)
+
+// defaultMaxDependencyDepth is the longest dependency chain task check
+// accepts without a warning when neither --max-depth nor tanuki.yaml set one.
+const defaultMaxDependencyDepth = 5
+
+var taskCheckMaxDepth int
+
+var taskCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check task dependencies for cycles and long chains",
+	Long: `Check the dependency graph for cycles. A cycle is printed as a chain of
+task IDs, where each task depends on the next, followed by the depends_on
+entry behind each step and the file it's in.
+
+Exits with an error if a cycle is found.
+
+Also warns when the longest dependency chain has more tasks than --max-depth
+(or max_dependency_depth in tanuki.yaml, default 5). The tasks in a chain
+run one after another however many agents are available, so a long chain
+is worth breaking up. The warning doesn't fail the check.
+
+Examples:
+  tanuki task check
+  tanuki task check --max-depth 3`,
+	Args: cobra.NoArgs,
+	RunE: runTaskCheck,
+}
+
+func init() {
+	taskCheckCmd.Flags().IntVar(&taskCheckMaxDepth, "max-depth", 0, "Warn about dependency chains longer than this (default from config, or 5)")
+	taskCmd.AddCommand(taskCheckCmd)
+}
+
+func runTaskCheck(_ *cobra.Command, _ []string) error {
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory: %w", err)
+	}
+
+	taskMgr := newTaskManager(projectRoot)
+	tasks, err := taskMgr.Scan()
+	if err != nil {
+		return fmt.Errorf("scan tasks: %w", err)
+	}
+
+	maxDepth := taskCheckMaxDepth
+	if maxDepth < 0 {
+		return fmt.Errorf("invalid --max-depth %d: must be at least 1", maxDepth)
+	}
+	if maxDepth == 0 {
+		maxDepth = defaultMaxDependencyDepth
+		if cfg, err := loadConfig(); err == nil && cfg.MaxDependencyDepth > 0 {
+			maxDepth = cfg.MaxDependencyDepth
+		}
+	}
+
+	return checkTasks(os.Stdout, tasks, projectRoot, maxDepth)
+}
+
+// checkTasks reports the first dependency cycle among tasks, with file paths
+// relative to root, and warns if the longest dependency chain has more than
+// maxDepth tasks.
+func checkTasks(w io.Writer, tasks []*task.Task, root string, maxDepth int) error {
+	resolver := task.NewResolver(tasks)
+	cycle := resolver.DetectCycle()
+	if cycle == nil {
+		_, _ = fmt.Fprintf(w, "No dependency cycles in %d task(s).\n", len(tasks))
+		if chain := resolver.LongestChain(); len(chain) > maxDepth {
+			printLongChain(w, chain, maxDepth)
+		}
+		return nil
+	}
+
+	cycleErr := resolver.ExplainCycle(cycle)
+	printCycle(w, cycleErr, root)
+	return errors.New("dependency cycle detected")
+}
+
+// printLongChain warns about a dependency chain longer than maxDepth,
+// listing it in run order.
+func printLongChain(w io.Writer, chain []string, maxDepth int) {
+	_, _ = fmt.Fprintf(w, "\nWarning: the longest dependency chain has %d tasks (threshold %d):\n\n", len(chain), maxDepth)
+	_, _ = fmt.Fprintf(w, "  %s\n\n", strings.Join(chain, " → "))
+	_, _ = fmt.Fprintln(w, "These tasks run one after another regardless of concurrency; consider breaking the chain up.")
+}
+
+// printCycle writes a cycle as a chain followed by one line per depends_on
+// edge, with the file it's declared in.
+func printCycle(w io.Writer, cycleErr *task.CycleError, root string) {
+	_, _ = fmt.Fprintf(w, "Dependency cycle: %s\n\n", task.FormatCycle(cycleErr.Cycle))
+	for _, edge := range cycleErr.Edges {
+		path := "(unknown file)"
+		if edge.FilePath != "" {
+			path = edge.FilePath
+			if rel, err := filepath.Rel(root, edge.FilePath); err == nil {
+				path = rel
+			}
+		}
+		_, _ = fmt.Fprintf(w, "  %s depends_on %s  %s\n", edge.From, edge.To, path)
+	}
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bkonkle/tanuki/internal/task"
+)
+
+func TestCheckTasks(t *testing.T) {
+	root := t.TempDir()
+	tasks := []*task.Task{
+		{ID: "A-001", DependsOn: []string{"B-002"}, FilePath: filepath.Join(root, "tasks", "a.md")},
+		{ID: "B-002", DependsOn: []string{"A-001"}, FilePath: filepath.Join(root, "tasks", "b.md")},
+	}
+
+	var out bytes.Buffer
+	if err := checkTasks(&out, tasks, root, defaultMaxDependencyDepth); err == nil {
+		t.Fatal("checkTasks() expected an error for a cycle")
+	}
+
+	got := out.String()
+	for _, want := range []string{
+		"Dependency cycle: ",
+		"A-001 depends_on B-002  " + filepath.Join("tasks", "a.md"),
+		"B-002 depends_on A-001  " + filepath.Join("tasks", "b.md"),
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestCheckTasks_NoCycle(t *testing.T) {
+	tasks := []*task.Task{
+		{ID: "A-001"},
+		{ID: "B-002", DependsOn: []string{"A-001"}},
+	}
+
+	var out bytes.Buffer
+	if err := checkTasks(&out, tasks, t.TempDir(), defaultMaxDependencyDepth); err != nil {
+		t.Fatalf("checkTasks() error = %v", err)
+	}
+	if out.String() != "No dependency cycles in 2 task(s).\n" {
+		t.Errorf("output = %q", out.String())
+	}
+}
+
+func TestCheckTasks_LongChain(t *testing.T) {
+	tasks := []*task.Task{
+		{ID: "A-001"},
+		{ID: "B-002", DependsOn: []string{"A-001"}},
+		{ID: "C-003", DependsOn: []string{"B-002"}},
+		{ID: "D-004", DependsOn: []string{"A-001"}},
+	}
+
+	var out bytes.Buffer
+	if err := checkTasks(&out, tasks, t.TempDir(), 2); err != nil {
+		t.Fatalf("checkTasks() error = %v, want a long chain to only warn", err)
+	}
+	got := out.String()
+	for _, want := range []string{
+		"longest dependency chain has 3 tasks (threshold 2)",
+		"  A-001 → B-002 → C-003\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+
+	out.Reset()
+	_ = checkTasks(&out, tasks, t.TempDir(), 3)
+	if strings.Contains(out.String(), "Warning") {
+		t.Errorf("output = %q, want no warning at the threshold", out.String())
+	}
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/bkonkle/tanuki/internal/task"
+	"github.com/spf13/cobra"
+)
+
+var taskDepsReverse bool
+
+var taskDepsCmd = &cobra.Command{
+	Use:   "deps <id>",
+	Short: "Show what a task depends on and what it blocks",
+	Long: `Show a task's dependencies and the tasks that depend on it.
+
+With --reverse, only the tasks it blocks are shown, following dependents
+transitively so you can see the full ripple effect of cancelling or
+reprioritizing the task.
+
+Examples:
+  tanuki task deps TASK-001
+  tanuki task deps TASK-001 --reverse`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTaskDeps,
+}
+
+func init() {
+	taskDepsCmd.Flags().BoolVarP(&taskDepsReverse, "reverse", "r", false, "Show only the tasks this one blocks, transitively")
+	taskCmd.AddCommand(taskDepsCmd)
+}
+
+// taskGraph is the subset of task.Manager used to walk dependencies.
+type taskGraph interface {
+	Get(id string) (*task.Task, error)
+	GetDependents(id string) []string
+}
+
+func runTaskDeps(_ *cobra.Command, args []string) error {
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory: %w", err)
+	}
+
+	taskMgr := newTaskManager(projectRoot)
+	if _, err := taskMgr.Scan(); err != nil {
+		return fmt.Errorf("scan tasks: %w", err)
+	}
+
+	t, err := taskMgr.Get(args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s: %s [%s]\n\n", t.ID, t.Title, t.Status)
+
+	if taskDepsReverse {
+		printDependentsTree(os.Stdout, taskMgr, t.ID)
+		return nil
+	}
+
+	fmt.Println("Depends on:")
+	if len(t.DependsOn) == 0 {
+		fmt.Println("  (none)")
+	}
+	for _, depID := range t.DependsOn {
+		fmt.Printf("  %s\n", describeTask(taskMgr, depID))
+	}
+
+	fmt.Println()
+	fmt.Println("Blocks:")
+	dependents := taskMgr.GetDependents(t.ID)
+	if len(dependents) == 0 {
+		fmt.Println("  (none)")
+	}
+	for _, id := range dependents {
+		fmt.Printf("  %s\n", describeTask(taskMgr, id))
+	}
+
+	return nil
+}
+
+// printDependentsTree writes every task that transitively depends on id as
+// an indented tree. Tasks reachable along several paths are listed once, at
+// their first occurrence.
+func printDependentsTree(w io.Writer, graph taskGraph, id string) {
+	seen := map[string]bool{id: true}
+	var lines []string
+
+	var walk func(id string, depth int)
+	walk = func(id string, depth int) {
+		for _, depID := range graph.GetDependents(id) {
+			if seen[depID] {
+				continue
+			}
+			seen[depID] = true
+			lines = append(lines, strings.Repeat("  ", depth+1)+describeTask(graph, depID))
+			walk(depID, depth+1)
+		}
+	}
+	walk(id, 0)
+
+	if len(lines) == 0 {
+		_, _ = fmt.Fprintln(w, "Blocks no other tasks.")
+		return
+	}
+
+	noun := "tasks"
+	if len(lines) == 1 {
+		noun = "task"
+	}
+	_, _ = fmt.Fprintf(w, "Blocks %d %s:\n", len(lines), noun)
+	for _, line := range lines {
+		_, _ = fmt.Fprintln(w, line)
+	}
+}
+
+// describeTask formats a task as "ID  Title  [status]" for dependency lists.
+func describeTask(graph taskGraph, id string) string {
+	t, err := graph.Get(id)
+	if err != nil {
+		return fmt.Sprintf("%s  (missing)", id)
+	}
+	return fmt.Sprintf("%s  %s  [%s]", t.ID, truncate(t.Title, 40), t.Status)
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/bkonkle/tanuki/internal/task"
+)
+
+// fakeTaskGraph is an in-memory taskGraph for testing.
+type fakeTaskGraph struct {
+	tasks      map[string]*task.Task
+	dependents map[string][]string
+}
+
+func (g *fakeTaskGraph) Get(id string) (*task.Task, error) {
+	t, ok := g.tasks[id]
+	if !ok {
+		return nil, fmt.Errorf("task %q not found", id)
+	}
+	return t, nil
+}
+
+func (g *fakeTaskGraph) GetDependents(id string) []string {
+	return g.dependents[id]
+}
+
+func TestPrintDependentsTree(t *testing.T) {
+	graph := &fakeTaskGraph{
+		tasks: map[string]*task.Task{
+			"T1": {ID: "T1", Title: "Schema", Status: task.StatusPending},
+			"T2": {ID: "T2", Title: "API", Status: task.StatusBlocked},
+			"T3": {ID: "T3", Title: "UI", Status: task.StatusBlocked},
+			"T4": {ID: "T4", Title: "Deploy", Status: task.StatusBlocked},
+		},
+		dependents: map[string][]string{
+			"T1": {"T2", "T3"},
+			"T2": {"T4"},
+			"T3": {"T4"}, // T4 is reachable twice but listed once
+			"T4": {"T1"}, // cycles back to the root
+		},
+	}
+
+	var out bytes.Buffer
+	printDependentsTree(&out, graph, "T1")
+
+	want := `Blocks 3 tasks:
+  T2  API  [blocked]
+    T4  Deploy  [blocked]
+  T3  UI  [blocked]
+`
+	if out.String() != want {
+		t.Errorf("printDependentsTree() =\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestPrintDependentsTree_None(t *testing.T) {
+	graph := &fakeTaskGraph{
+		tasks: map[string]*task.Task{"T1": {ID: "T1"}},
+	}
+
+	var out bytes.Buffer
+	printDependentsTree(&out, graph, "T1")
+
+	if out.String() != "Blocks no other tasks.\n" {
+		t.Errorf("printDependentsTree() = %q", out.String())
+	}
+}
+
+func TestDescribeTask_Missing(t *testing.T) {
+	graph := &fakeTaskGraph{}
+	if got := describeTask(graph, "GONE"); got != "GONE  (missing)" {
+		t.Errorf("describeTask() = %q, want %q", got, "GONE  (missing)")
+	}
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/bkonkle/tanuki/internal/task"
+	"github.com/spf13/cobra"
+)
+
+var taskEditCmd = &cobra.Command{
+	Use:   "edit <id>",
+	Short: "Open a task's file in $EDITOR",
+	Long: `Open a task's file in $EDITOR (falling back to vi). When the editor exits,
+the file is parsed and validated again; if the edit broke the front matter,
+the error is shown and you can reopen the file to fix it.
+
+Examples:
+  tanuki task edit TASK-001
+  EDITOR="code --wait" tanuki task edit TASK-001`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTaskEdit,
+}
+
+func init() {
+	taskCmd.AddCommand(taskEditCmd)
+}
+
+func runTaskEdit(_ *cobra.Command, args []string) error {
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory: %w", err)
+	}
+
+	taskMgr := newTaskManager(projectRoot)
+	if _, err := taskMgr.Scan(); err != nil {
+		return fmt.Errorf("scan tasks: %w", err)
+	}
+
+	t, err := taskMgr.Get(args[0])
+	if err != nil {
+		return err
+	}
+	if t.FilePath == "" {
+		return fmt.Errorf("task %s has no file to edit", t.ID)
+	}
+
+	reopen := func(error) bool {
+		return confirm("Reopen the editor to fix it?")
+	}
+	edited, err := editTaskFile(os.Stdout, t.FilePath, openInEditor, reopen)
+	if err != nil {
+		return err
+	}
+
+	// Pick up the edit, including an ID change
+	if _, err := taskMgr.Scan(); err != nil {
+		return fmt.Errorf("scan tasks: %w", err)
+	}
+	if edited.ID != t.ID {
+		fmt.Printf("Note: task ID changed from %s to %s\n", t.ID, edited.ID)
+	}
+	return nil
+}
+
+// editTaskFile opens path with edit until it parses as a valid task, asking
+// reopen whether to try again after each failed edit.
+func editTaskFile(w io.Writer, path string, edit func(path string) error, reopen func(err error) bool) (*task.Task, error) {
+	for {
+		if err := edit(path); err != nil {
+			return nil, fmt.Errorf("run editor: %w", err)
+		}
+
+		t, err := task.ParseFile(path)
+		if err == nil {
+			_, _ = fmt.Fprintf(w, "Saved %s (%s)\n", t.ID, path)
+			return t, nil
+		}
+
+		_, _ = fmt.Fprintf(w, "Error: %s is no longer a valid task: %v\n", path, err)
+		if !reopen(err) {
+			return nil, fmt.Errorf("task file %s is invalid: %w", path, err)
+		}
+	}
+}
+
+// openInEditor opens path in $EDITOR, or vi if it's unset, and waits for
+// the editor to exit. $EDITOR may include arguments, e.g. "code --wait".
+func openInEditor(path string) error {
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		editor = []string{"vi"}
+	}
+
+	cmd := exec.Command(editor[0], append(editor[1:], path)...) // #nosec G204 - the user's own editor
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
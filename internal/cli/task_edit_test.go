@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEditTaskFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "TASK-001.md")
+	valid := "---\nid: TASK-001\ntitle: Edited\nstatus: pending\n---\nBody\n"
+	broken := "---\nid: TASK-001\ntitle: [\n---\n"
+
+	// The first edit breaks the front matter, the second fixes it
+	edits := []string{broken, valid}
+	edit := func(p string) error {
+		content := edits[0]
+		edits = edits[1:]
+		return os.WriteFile(p, []byte(content), 0600)
+	}
+	reopened := 0
+	reopen := func(error) bool {
+		reopened++
+		return true
+	}
+
+	var out bytes.Buffer
+	edited, err := editTaskFile(&out, path, edit, reopen)
+	if err != nil {
+		t.Fatalf("editTaskFile() error = %v", err)
+	}
+	if edited.Title != "Edited" || reopened != 1 {
+		t.Errorf("editTaskFile() = %q after %d reopen(s), want Edited after 1", edited.Title, reopened)
+	}
+	if !strings.Contains(out.String(), "no longer a valid task") || !strings.Contains(out.String(), "Saved TASK-001") {
+		t.Errorf("output = %q, want the parse error then the save", out.String())
+	}
+}
+
+func TestEditTaskFile_GiveUp(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "TASK-001.md")
+	edit := func(p string) error {
+		return os.WriteFile(p, []byte("no front matter"), 0600)
+	}
+	reopen := func(error) bool { return false }
+
+	if _, err := editTaskFile(&bytes.Buffer{}, path, edit, reopen); err == nil {
+		t.Error("editTaskFile() expected an error when not reopening a broken file")
+	}
+}
@@ -0,0 +1,195 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bkonkle/tanuki/internal/task"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	taskExportOut    string
+	taskExportFormat string
+	taskImportForce  bool
+)
+
+var taskExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Write every task to a single JSON or YAML bundle",
+	Long: `Bundle the whole task tree into one file: each task's front matter and
+body, its path under the tasks directory, and each project folder's README.
+Use it to share a project's design or feed it to another tool, and
+"tanuki task import" to write it back out as task files.
+
+The format comes from --format, or else the --out file's extension (.yaml
+or .yml for YAML). Without --out, the bundle is printed as JSON.
+
+Examples:
+  tanuki task export --out tasks.json
+  tanuki task export --out tasks.yaml
+  tanuki task export --format yaml | less`,
+	Args: cobra.NoArgs,
+	RunE: runTaskExport,
+}
+
+var taskImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Write the tasks in a bundle back out as task files",
+	Long: `Write the projects and tasks in a bundle made by "tanuki task export" to
+the tasks directory, each at the path it was exported from. Project folders
+are created with their READMEs, so the project and workstream structure is
+the same as where the bundle came from.
+
+Every task is validated before anything is written. Files that already
+exist with different contents are left alone and fail the import unless
+--force is given.
+
+Examples:
+  tanuki task import tasks.json
+  tanuki task import tasks.yaml --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTaskImport,
+}
+
+func init() {
+	taskExportCmd.Flags().StringVar(&taskExportOut, "out", "", "File to write the bundle to (default: stdout)")
+	taskExportCmd.Flags().StringVar(&taskExportFormat, "format", "", "Bundle format: json or yaml (default: from --out, else json)")
+	taskImportCmd.Flags().BoolVarP(&taskImportForce, "force", "f", false, "Overwrite existing task files")
+	taskCmd.AddCommand(taskExportCmd)
+	taskCmd.AddCommand(taskImportCmd)
+}
+
+func runTaskExport(_ *cobra.Command, _ []string) error {
+	format, err := bundleFormat(taskExportFormat, taskExportOut)
+	if err != nil {
+		return err
+	}
+
+	taskMgr, err := newBundleTaskManager()
+	if err != nil {
+		return err
+	}
+	if _, err := taskMgr.Scan(); err != nil {
+		return fmt.Errorf("scan tasks: %w", err)
+	}
+
+	bundle, err := taskMgr.Export()
+	if err != nil {
+		return err
+	}
+
+	if taskExportOut == "" {
+		return writeBundle(os.Stdout, bundle, format)
+	}
+
+	f, err := os.Create(taskExportOut)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", taskExportOut, err)
+	}
+	if err := writeBundle(f, bundle, format); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("write %s: %w", taskExportOut, err)
+	}
+
+	fmt.Printf("Exported %d tasks in %d projects to %s\n", len(bundle.Tasks), len(bundle.Projects), taskExportOut)
+	return nil
+}
+
+func runTaskImport(_ *cobra.Command, args []string) error {
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("read bundle: %w", err)
+	}
+
+	// JSON is valid YAML, so one decoder reads both formats
+	var bundle task.Bundle
+	if err := yaml.Unmarshal(data, &bundle); err != nil {
+		return fmt.Errorf("parse bundle %s: %w", args[0], err)
+	}
+
+	taskMgr, err := newBundleTaskManager()
+	if err != nil {
+		return err
+	}
+
+	written, err := task.ImportBundle(taskMgr.TasksDir(), &bundle, taskImportForce)
+	if err != nil {
+		return err
+	}
+
+	if len(written) == 0 {
+		fmt.Println("Task files already match the bundle.")
+		return nil
+	}
+	fmt.Printf("Wrote %d files to %s:\n", len(written), taskMgr.TasksDir())
+	for _, rel := range written {
+		fmt.Printf("  %s\n", rel)
+	}
+	return nil
+}
+
+// newBundleTaskManager returns a task manager for the tasks directory in
+// tanuki.yaml, or the default one without a config. Bundles hold the tasks
+// directory only, so extra task directories are left out.
+func newBundleTaskManager() (*task.Manager, error) {
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("get working directory: %w", err)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return newTaskManagerFromConfig(projectRoot, nil), nil
+	}
+	tasksDirOnly := *cfg
+	tasksDirOnly.ExtraTaskDirs = nil
+	return newTaskManagerFromConfig(projectRoot, &tasksDirOnly), nil
+}
+
+// bundleFormat picks the export format from --format, falling back to the
+// output file's extension and then JSON.
+func bundleFormat(format, out string) (string, error) {
+	switch strings.ToLower(format) {
+	case "json", "yaml":
+		return strings.ToLower(format), nil
+	case "yml":
+		return "yaml", nil
+	case "":
+	default:
+		return "", fmt.Errorf("invalid --format %q: must be json or yaml", format)
+	}
+
+	switch strings.ToLower(filepath.Ext(out)) {
+	case ".yaml", ".yml":
+		return "yaml", nil
+	}
+	return "json", nil
+}
+
+// writeBundle encodes a bundle to w as JSON or YAML.
+func writeBundle(w io.Writer, bundle *task.Bundle, format string) error {
+	if format == "yaml" {
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(bundle); err != nil {
+			return fmt.Errorf("encode bundle: %w", err)
+		}
+		return encoder.Close()
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(bundle); err != nil {
+		return fmt.Errorf("encode bundle: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package cli
+
+import "testing"
+
+func TestBundleFormat(t *testing.T) {
+	tests := []struct {
+		format, out string
+		want        string
+		wantErr     bool
+	}{
+		{"", "", "json", false},
+		{"", "tasks.json", "json", false},
+		{"", "tasks.yaml", "yaml", false},
+		{"", "tasks.YML", "yaml", false},
+		{"json", "tasks.yaml", "json", false},
+		{"yml", "", "yaml", false},
+		{"toml", "", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := bundleFormat(tt.format, tt.out)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("bundleFormat(%q, %q) = %q, %v, want %q (error: %v)", tt.format, tt.out, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/bkonkle/tanuki/internal/task"
+	"github.com/spf13/cobra"
+)
+
+var (
+	taskListReady   bool
+	taskListBlocked bool
+	taskListPeek    bool
+)
+
+var taskListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List tasks, optionally only those ready or blocked",
+	Long: `List the tasks in the tasks directory.
+
+With --ready, only tasks that haven't started and whose dependencies are all
+complete are shown, highest priority first, so you can pick what to assign
+next. With --blocked, only tasks still waiting on dependencies are shown,
+along with the IDs of the dependencies they are waiting on.
+
+With --peek, only the task at the head of each workstream's queue is shown:
+the task the orchestrator would assign the workstream's next idle agent.
+Nothing is assigned, so use it to preview the assignment plan.
+
+Examples:
+  tanuki task list
+  tanuki task list --ready
+  tanuki task list --blocked
+  tanuki task list --peek`,
+	Args: cobra.NoArgs,
+	RunE: runTaskList,
+}
+
+func init() {
+	taskListCmd.Flags().BoolVar(&taskListReady, "ready", false, "Show only tasks whose dependencies are complete")
+	taskListCmd.Flags().BoolVar(&taskListBlocked, "blocked", false, "Show only tasks waiting on dependencies")
+	taskListCmd.Flags().BoolVar(&taskListPeek, "peek", false, "Show the next task each workstream would be assigned, without assigning it")
+	taskListCmd.MarkFlagsMutuallyExclusive("ready", "blocked", "peek")
+	taskCmd.AddCommand(taskListCmd)
+}
+
+// taskDependencies is the subset of task.Manager used to check readiness.
+type taskDependencies interface {
+	IsBlocked(id string) (bool, error)
+	GetBlockingTasks(id string) ([]string, error)
+}
+
+// taskListEntry is a task with the dependencies it is waiting on.
+type taskListEntry struct {
+	Task     *task.Task
+	Blocking []string
+}
+
+func runTaskList(_ *cobra.Command, _ []string) error {
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory: %w", err)
+	}
+
+	taskMgr := newTaskManager(projectRoot)
+	if _, err := taskMgr.Scan(); err != nil {
+		return fmt.Errorf("scan tasks: %w", err)
+	}
+
+	tasks := taskMgr.List()
+	var entries []taskListEntry
+	switch {
+	case taskListReady:
+		entries = readyTasks(taskMgr, tasks)
+	case taskListBlocked:
+		entries = blockedTasks(taskMgr, tasks)
+	case taskListPeek:
+		entries = peekTasks(taskMgr, tasks)
+	default:
+		sortTasksByID(tasks)
+		for _, t := range tasks {
+			entries = append(entries, taskListEntry{Task: t})
+		}
+	}
+
+	if len(entries) == 0 {
+		switch {
+		case taskListReady, taskListPeek:
+			fmt.Println("No tasks are ready.")
+		case taskListBlocked:
+			fmt.Println("No tasks are blocked.")
+		default:
+			fmt.Println("No tasks found.")
+		}
+		return nil
+	}
+
+	return printTaskList(os.Stdout, entries, taskListBlocked)
+}
+
+// notStarted reports whether a task is waiting to be assigned.
+func notStarted(t *task.Task) bool {
+	return t.Status == task.StatusPending || t.Status == task.StatusBlocked
+}
+
+// readyTasks returns the unstarted tasks whose dependencies are all complete,
+// sorted by priority, then ID.
+func readyTasks(deps taskDependencies, tasks []*task.Task) []taskListEntry {
+	var ready []*task.Task
+	for _, t := range tasks {
+		if !notStarted(t) {
+			continue
+		}
+		if blocked, err := deps.IsBlocked(t.ID); err != nil || blocked {
+			continue
+		}
+		ready = append(ready, t)
+	}
+
+	sort.Slice(ready, func(i, j int) bool {
+		if ready[i].Priority.Order() != ready[j].Priority.Order() {
+			return ready[i].Priority.Order() < ready[j].Priority.Order()
+		}
+		return ready[i].ID < ready[j].ID
+	})
+
+	entries := make([]taskListEntry, 0, len(ready))
+	for _, t := range ready {
+		entries = append(entries, taskListEntry{Task: t})
+	}
+	return entries
+}
+
+// blockedTasks returns the unstarted tasks with incomplete dependencies,
+// sorted by ID, along with the dependencies each is waiting on.
+func blockedTasks(deps taskDependencies, tasks []*task.Task) []taskListEntry {
+	sortTasksByID(tasks)
+
+	var entries []taskListEntry
+	for _, t := range tasks {
+		if !notStarted(t) {
+			continue
+		}
+		if blocked, err := deps.IsBlocked(t.ID); err != nil || !blocked {
+			continue
+		}
+		blocking, _ := deps.GetBlockingTasks(t.ID)
+		if t.BlockedReason != "" {
+			blocking = append([]string{"blocked: " + truncate(t.BlockedReason, 40)}, blocking...)
+		}
+		entries = append(entries, taskListEntry{Task: t, Blocking: blocking})
+	}
+	return entries
+}
+
+// peekTasks queues the ready tasks and returns the head of each workstream's
+// queue, sorted by workstream, without dequeuing anything.
+func peekTasks(deps taskDependencies, tasks []*task.Task) []taskListEntry {
+	ready := readyTasks(deps, tasks)
+	queued := make([]*task.Task, 0, len(ready))
+	for _, e := range ready {
+		queued = append(queued, e.Task)
+	}
+	// Equal priorities dequeue in enqueue order, so ties go to the lowest ID
+	sortTasksByID(queued)
+
+	queue := task.NewQueue()
+	_ = queue.EnqueueAll(queued)
+
+	workstreams := queue.Workstreams()
+	sort.Strings(workstreams)
+
+	entries := make([]taskListEntry, 0, len(workstreams))
+	for _, ws := range workstreams {
+		if next, err := queue.Peek(ws); err == nil {
+			entries = append(entries, taskListEntry{Task: next})
+		}
+	}
+	return entries
+}
+
+// sortTasksByID orders tasks by ID in place.
+func sortTasksByID(tasks []*task.Task) {
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].ID < tasks[j].ID
+	})
+}
+
+// printTaskList writes entries as a table, with a BLOCKED BY column when
+// showBlocking is set.
+func printTaskList(out io.Writer, entries []taskListEntry, showBlocking bool) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	header := "ID\tPRIORITY\tSTATUS\tWORKSTREAM\tTITLE"
+	divider := "--\t--------\t------\t----------\t-----"
+	if showBlocking {
+		header += "\tBLOCKED BY"
+		divider += "\t----------"
+	}
+	_, _ = fmt.Fprintln(w, header)
+	_, _ = fmt.Fprintln(w, divider)
+
+	for _, e := range entries {
+		t := e.Task
+		line := fmt.Sprintf("%s\t%s\t%s\t%s\t%s", t.ID, t.Priority, t.Status, t.GetWorkstream(), truncate(t.Title, 40))
+		if showBlocking {
+			line += "\t" + strings.Join(e.Blocking, ", ")
+		}
+		_, _ = fmt.Fprintln(w, line)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/bkonkle/tanuki/internal/task"
+)
+
+// fakeTaskDeps is an in-memory taskDependencies for testing.
+type fakeTaskDeps struct {
+	tasks map[string]*task.Task
+}
+
+func (d *fakeTaskDeps) GetBlockingTasks(id string) ([]string, error) {
+	t, ok := d.tasks[id]
+	if !ok {
+		return nil, fmt.Errorf("task %q not found", id)
+	}
+	var blocking []string
+	for _, depID := range t.DependsOn {
+		if dep, ok := d.tasks[depID]; !ok || dep.Status != task.StatusComplete {
+			blocking = append(blocking, depID)
+		}
+	}
+	return blocking, nil
+}
+
+func (d *fakeTaskDeps) IsBlocked(id string) (bool, error) {
+	if t, ok := d.tasks[id]; ok && t.BlockedReason != "" {
+		return true, nil
+	}
+	blocking, err := d.GetBlockingTasks(id)
+	return len(blocking) > 0, err
+}
+
+func (d *fakeTaskDeps) list() []*task.Task {
+	var tasks []*task.Task
+	for _, t := range d.tasks {
+		tasks = append(tasks, t)
+	}
+	return tasks
+}
+
+func newFakeTaskDeps() *fakeTaskDeps {
+	return &fakeTaskDeps{tasks: map[string]*task.Task{
+		"T1": {ID: "T1", Title: "Schema", Priority: task.PriorityLow, Status: task.StatusComplete},
+		"T2": {ID: "T2", Title: "API", Priority: task.PriorityMedium, Status: task.StatusPending, DependsOn: []string{"T1"}},
+		"T3": {ID: "T3", Title: "Auth", Priority: task.PriorityCritical, Status: task.StatusBlocked, DependsOn: []string{"T1"}},
+		"T4": {ID: "T4", Title: "UI", Priority: task.PriorityHigh, Status: task.StatusPending, DependsOn: []string{"T2", "T1"}},
+		"T5": {ID: "T5", Title: "Docs", Priority: task.PriorityHigh, Status: task.StatusPending},
+		"T6": {ID: "T6", Title: "Deploy", Priority: task.PriorityCritical, Status: task.StatusBlocked, DependsOn: []string{"T4", "GONE"}},
+		"T7": {ID: "T7", Title: "Tests", Priority: task.PriorityCritical, Status: task.StatusInProgress},
+		"T9": {ID: "T9", Title: "Billing", Priority: task.PriorityHigh, Status: task.StatusBlocked, BlockedReason: "waiting on pricing"},
+	}}
+}
+
+func taskListIDs(entries []taskListEntry) []string {
+	var ids []string
+	for _, e := range entries {
+		ids = append(ids, e.Task.ID)
+	}
+	return ids
+}
+
+func TestReadyTasks(t *testing.T) {
+	deps := newFakeTaskDeps()
+
+	got := taskListIDs(readyTasks(deps, deps.list()))
+
+	// Sorted by priority; T1 is done, T7 is running, T4 and T6 are waiting,
+	// and T9 is blocked by hand
+	want := []string{"T3", "T5", "T2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readyTasks() = %v, want %v", got, want)
+	}
+}
+
+func TestBlockedTasks(t *testing.T) {
+	deps := newFakeTaskDeps()
+
+	entries := blockedTasks(deps, deps.list())
+
+	if got := taskListIDs(entries); !reflect.DeepEqual(got, []string{"T4", "T6", "T9"}) {
+		t.Fatalf("blockedTasks() = %v, want [T4 T6 T9]", got)
+	}
+	if !reflect.DeepEqual(entries[0].Blocking, []string{"T2"}) {
+		t.Errorf("T4 blocking = %v, want [T2]", entries[0].Blocking)
+	}
+	if !reflect.DeepEqual(entries[1].Blocking, []string{"T4", "GONE"}) {
+		t.Errorf("T6 blocking = %v, want [T4 GONE]", entries[1].Blocking)
+	}
+	if !reflect.DeepEqual(entries[2].Blocking, []string{"blocked: waiting on pricing"}) {
+		t.Errorf("T9 blocking = %v, want its reason", entries[2].Blocking)
+	}
+}
+
+func TestPeekTasks(t *testing.T) {
+	deps := newFakeTaskDeps()
+	deps.tasks["T2"].Workstream = "api"
+	deps.tasks["T3"].Workstream = "api"
+	deps.tasks["T5"].Workstream = "docs"
+	deps.tasks["T8"] = &task.Task{ID: "T8", Title: "Guide", Priority: task.PriorityHigh, Status: task.StatusPending, Workstream: "docs"}
+
+	got := taskListIDs(peekTasks(deps, deps.list()))
+
+	// The head of each workstream's queue; T5 and T8 tie, so the lower ID wins
+	want := []string{"T3", "T5"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("peekTasks() = %v, want %v", got, want)
+	}
+	if deps.tasks["T3"].Status != task.StatusBlocked || deps.tasks["T3"].AssignedTo != "" {
+		t.Error("peekTasks() changed a task, want a preview only")
+	}
+}
+
+func TestPrintTaskList_Blocking(t *testing.T) {
+	entries := []taskListEntry{
+		{
+			Task:     &task.Task{ID: "T4", Title: "UI", Priority: task.PriorityHigh, Status: task.StatusPending, Workstream: "frontend"},
+			Blocking: []string{"T2", "T3"},
+		},
+	}
+
+	var out bytes.Buffer
+	if err := printTaskList(&out, entries, true); err != nil {
+		t.Fatalf("printTaskList() error = %v", err)
+	}
+
+	want := `ID  PRIORITY  STATUS   WORKSTREAM  TITLE  BLOCKED BY
+--  --------  ------   ----------  -----  ----------
+T4  high      pending  frontend    UI     T2, T3
+`
+	if out.String() != want {
+		t.Errorf("printTaskList() =\n%s\nwant:\n%s", out.String(), want)
+	}
+}
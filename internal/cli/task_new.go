@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/bkonkle/tanuki/internal/task"
+	"github.com/spf13/cobra"
+)
+
+var (
+	taskNewID         string
+	taskNewWorkstream string
+	taskNewPriority   string
+	taskNewDependsOn  []string
+)
+
+var taskNewCmd = &cobra.Command{
+	Use:   "new <project> <title>",
+	Short: "Create a task file in a project",
+	Long: `Create a new pending task in a project folder.
+
+Without --id, the next sequential ID is generated from task_id_format in
+tanuki.yaml (default "{project}-{seq:03d}"), one past the highest existing
+sequence number in the project. An explicit --id must follow the same format.
+
+Examples:
+  tanuki task new auth "Add login endpoint"
+  tanuki task new auth "Add logout" --workstream api --depends-on auth-001
+  tanuki task new auth "Hotfix" --id auth-100 --priority critical`,
+	Args: cobra.ExactArgs(2),
+	RunE: runTaskNew,
+}
+
+func init() {
+	taskNewCmd.Flags().StringVar(&taskNewID, "id", "", "Task ID (default: next ID in the project)")
+	taskNewCmd.Flags().StringVarP(&taskNewWorkstream, "workstream", "w", "main", "Workstream for the task")
+	taskNewCmd.Flags().StringVarP(&taskNewPriority, "priority", "p", string(task.PriorityMedium), "Priority: critical, high, medium, or low")
+	taskNewCmd.Flags().StringSliceVar(&taskNewDependsOn, "depends-on", nil, "Task IDs that must complete first (comma-separated)")
+	taskCmd.AddCommand(taskNewCmd)
+}
+
+func runTaskNew(_ *cobra.Command, args []string) error {
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory: %w", err)
+	}
+
+	project, title := args[0], args[1]
+
+	// Extra task directories are scanned too, so the new ID is unique across them
+	taskMgr := newTaskManager(projectRoot)
+	if _, err := taskMgr.Scan(); err != nil {
+		return fmt.Errorf("scan tasks: %w", err)
+	}
+
+	projectPath := filepath.Join(taskMgr.TasksDir(), project)
+	if _, err := os.Stat(filepath.Join(projectPath, "README.md")); err != nil {
+		return fmt.Errorf("project %q not found\nRun: tanuki project init %s", project, project)
+	}
+
+	t, err := newTask(taskMgr, project, title)
+	if err != nil {
+		return err
+	}
+	t.FilePath = filepath.Join(projectPath, taskFileName(t.ID, t.GetWorkstream(), title))
+
+	if _, err := os.Stat(t.FilePath); err == nil {
+		return fmt.Errorf("task file already exists: %s", t.FilePath)
+	}
+	if err := task.WriteFile(t); err != nil {
+		return fmt.Errorf("write task: %w", err)
+	}
+
+	fmt.Printf("Created task %s: %s\n", t.ID, t.Title)
+	fmt.Printf("  File: %s\n", t.FilePath)
+	return nil
+}
+
+// newTask builds a pending task for the project from the command flags,
+// generating its ID if --id wasn't given.
+func newTask(taskMgr *task.Manager, project, title string) (*task.Task, error) {
+	id := taskNewID
+	if id == "" {
+		id = taskMgr.NextID(project)
+	} else if err := taskMgr.ValidateID(project, id); err != nil {
+		return nil, err
+	}
+
+	if _, err := taskMgr.Get(id); err == nil {
+		return nil, fmt.Errorf("task %s already exists", id)
+	}
+
+	t := &task.Task{
+		ID:         id,
+		Title:      title,
+		Workstream: taskNewWorkstream,
+		Priority:   task.Priority(taskNewPriority),
+		Status:     task.StatusPending,
+		DependsOn:  taskNewDependsOn,
+		Content:    fmt.Sprintf("# %s\n\nDescribe the task here.\n\n## Done When\n\n- Requirements are implemented\n- Tests pass", title),
+	}
+	if t.DependsOn == nil {
+		t.DependsOn = []string{}
+	}
+	if err := task.Validate(t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// taskFileTrailingNumber matches the sequence number at the end of a task ID.
+var taskFileTrailingNumber = regexp.MustCompile(`(\d+)$`)
+
+// taskFileName names a task file the way project init does, e.g.
+// "004-main-add-login-endpoint.md" for task auth-004 in workstream main.
+// IDs without a trailing number are used whole.
+func taskFileName(id, workstream, title string) string {
+	prefix := taskAgentNameInvalid.ReplaceAllString(strings.ToLower(id), "-")
+	if m := taskFileTrailingNumber.FindString(id); m != "" {
+		prefix = m
+	}
+
+	parts := []string{prefix, workstream, title}
+	for i, part := range parts {
+		parts[i] = strings.Trim(taskAgentNameInvalid.ReplaceAllString(strings.ToLower(part), "-"), "-")
+	}
+	return strings.Join(parts, "-") + ".md"
+}
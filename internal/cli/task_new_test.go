@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bkonkle/tanuki/internal/task"
+)
+
+func TestTaskFileName(t *testing.T) {
+	tests := []struct {
+		id, workstream, title string
+		want                  string
+	}{
+		{"auth-004", "main", "Add login endpoint", "004-main-add-login-endpoint.md"},
+		{"T10", "api", "Rate limits!", "10-api-rate-limits.md"},
+		{"auth-setup", "main", "Setup", "auth-setup-main-setup.md"},
+	}
+
+	for _, tt := range tests {
+		if got := taskFileName(tt.id, tt.workstream, tt.title); got != tt.want {
+			t.Errorf("taskFileName(%q, %q, %q) = %q, want %q", tt.id, tt.workstream, tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestTaskNew(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	defer func() { _ = os.Chdir(oldWd) }()
+	_ = os.Chdir(tempDir)
+
+	if err := runProjectInit(nil, []string{"auth"}); err != nil {
+		t.Fatalf("runProjectInit() error: %v", err)
+	}
+
+	taskNewID, taskNewWorkstream, taskNewPriority, taskNewDependsOn = "", "main", "high", []string{"auth-001"}
+	defer func() { taskNewID, taskNewPriority, taskNewDependsOn = "", "medium", nil }()
+
+	if err := runTaskNew(nil, []string{"auth", "Add login"}); err != nil {
+		t.Fatalf("runTaskNew() error: %v", err)
+	}
+
+	path := filepath.Join(tempDir, "tasks", "auth", "002-main-add-login.md")
+	created, err := task.ParseFile(path)
+	if err != nil {
+		t.Fatalf("parse created task: %v", err)
+	}
+	if created.ID != "auth-002" {
+		t.Errorf("ID = %q, want auth-002", created.ID)
+	}
+	if created.Priority != task.PriorityHigh || created.Status != task.StatusPending {
+		t.Errorf("priority/status = %s/%s, want high/pending", created.Priority, created.Status)
+	}
+	if len(created.DependsOn) != 1 || created.DependsOn[0] != "auth-001" {
+		t.Errorf("DependsOn = %v, want [auth-001]", created.DependsOn)
+	}
+
+	// An explicit ID must follow the format and be unused
+	taskNewID = "login"
+	if err := runTaskNew(nil, []string{"auth", "Bad ID"}); err == nil {
+		t.Error("expected error for an ID that doesn't match the format")
+	}
+	taskNewID = "auth-002"
+	if err := runTaskNew(nil, []string{"auth", "Duplicate"}); err == nil {
+		t.Error("expected error for an existing ID")
+	}
+
+	taskNewID = ""
+	if err := runTaskNew(nil, []string{"missing", "Nowhere"}); err == nil {
+		t.Error("expected error for an unknown project")
+	}
+}
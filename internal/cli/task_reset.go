@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/bkonkle/tanuki/internal/task"
+	"github.com/spf13/cobra"
+)
+
+var taskResetWorkstream string
+
+var taskResetCmd = &cobra.Command{
+	Use:   "reset [id...]",
+	Short: "Reset tasks to pending",
+	Long: `Reset tasks back to pending, releasing any agent they are assigned to.
+Name tasks by ID, or use --workstream to reset every task in a workstream.
+Tasks already pending are left alone.
+
+Take a snapshot first ("tanuki task snapshot") to be able to undo the reset.
+
+Examples:
+  tanuki task reset --workstream backend
+  tanuki task reset TASK-003 TASK-004`,
+	RunE: runTaskReset,
+}
+
+func init() {
+	taskResetCmd.Flags().StringVarP(&taskResetWorkstream, "workstream", "w", "", "Reset every task in this workstream")
+	taskCmd.AddCommand(taskResetCmd)
+}
+
+func runTaskReset(_ *cobra.Command, args []string) error {
+	if len(args) == 0 && taskResetWorkstream == "" {
+		return fmt.Errorf("task IDs or --workstream required")
+	}
+	if len(args) > 0 && taskResetWorkstream != "" {
+		return fmt.Errorf("cannot use task IDs with --workstream")
+	}
+
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory: %w", err)
+	}
+
+	taskMgr := newTaskManager(projectRoot)
+	if _, err := taskMgr.Scan(); err != nil {
+		return fmt.Errorf("scan tasks: %w", err)
+	}
+
+	return resetTasks(os.Stdout, taskMgr, args, taskResetWorkstream)
+}
+
+// resetTasks moves the named tasks, or every task in workstream, back to
+// pending and reports what changed.
+func resetTasks(w io.Writer, taskMgr *task.Manager, ids []string, workstream string) error {
+	var targets []string
+	if workstream != "" {
+		for _, t := range taskMgr.GetByWorkstream(workstream) {
+			if t.Status != task.StatusPending {
+				targets = append(targets, t.ID)
+			}
+		}
+	} else {
+		for _, id := range ids {
+			if t, err := taskMgr.Get(id); err == nil && t.Status == task.StatusPending {
+				continue
+			}
+			targets = append(targets, id)
+		}
+	}
+	if len(targets) == 0 {
+		_, _ = fmt.Fprintln(w, "No tasks to reset.")
+		return nil
+	}
+	sort.Strings(targets)
+
+	notFound, err := taskMgr.BulkUpdateStatus(targets, task.StatusPending)
+	if err != nil {
+		return err
+	}
+
+	reset := 0
+	for _, id := range targets {
+		if notFound[id] == nil {
+			_, _ = fmt.Fprintf(w, "Reset %s to pending\n", id)
+			reset++
+		}
+	}
+	if len(notFound) > 0 {
+		missing := make([]string, 0, len(notFound))
+		for id := range notFound {
+			missing = append(missing, id)
+		}
+		sort.Strings(missing)
+		return fmt.Errorf("tasks not found: %s", strings.Join(missing, ", "))
+	}
+
+	_, _ = fmt.Fprintf(w, "Reset %d task(s).\n", reset)
+	return nil
+}
@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bkonkle/tanuki/internal/task"
+)
+
+func TestResetTasks(t *testing.T) {
+	dir := t.TempDir()
+	tasksDir := filepath.Join(dir, "tasks")
+	if err := os.MkdirAll(tasksDir, 0750); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []struct{ id, workstream, status string }{
+		{"TASK-001", "backend", "complete"},
+		{"TASK-002", "backend", "pending"},
+		{"TASK-003", "frontend", "failed"},
+	} {
+		content := "---\nid: " + f.id + "\ntitle: Test\nworkstream: " + f.workstream + "\nstatus: " + f.status + "\n---\n"
+		if err := os.WriteFile(filepath.Join(tasksDir, f.id+".md"), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	taskMgr := task.NewManager(&task.Config{ProjectRoot: dir})
+	if _, err := taskMgr.Scan(); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := resetTasks(&out, taskMgr, nil, "backend"); err != nil {
+		t.Fatalf("resetTasks() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "Reset TASK-001 to pending") || strings.Contains(out.String(), "TASK-002") {
+		t.Errorf("output = %q, want only the non-pending backend task reset", out.String())
+	}
+	if tsk, _ := taskMgr.Get("TASK-003"); tsk.Status != task.StatusFailed {
+		t.Errorf("TASK-003 status = %s, want other workstreams untouched", tsk.Status)
+	}
+
+	out.Reset()
+	err := resetTasks(&out, taskMgr, []string{"TASK-003", "TASK-404"}, "")
+	if err == nil || !strings.Contains(err.Error(), "TASK-404") {
+		t.Errorf("resetTasks() error = %v, want TASK-404 not found", err)
+	}
+	if tsk, _ := taskMgr.Get("TASK-003"); tsk.Status != task.StatusPending {
+		t.Errorf("TASK-003 status = %s, want pending despite the missing ID", tsk.Status)
+	}
+}
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/bkonkle/tanuki/internal/task"
+	"github.com/spf13/cobra"
+)
+
+var taskShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Show a task's details and assignment history",
+	Long: `Show a task's status, assignment, and failure details, followed by its
+assignment history: each agent that took the task, when, and how its turn
+ended.
+
+Examples:
+  tanuki task show TASK-001`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTaskShow,
+}
+
+func init() {
+	taskCmd.AddCommand(taskShowCmd)
+}
+
+func runTaskShow(_ *cobra.Command, args []string) error {
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory: %w", err)
+	}
+
+	taskMgr := newTaskManager(projectRoot)
+	if _, err := taskMgr.Scan(); err != nil {
+		return fmt.Errorf("scan tasks: %w", err)
+	}
+
+	t, err := taskMgr.Get(args[0])
+	if err != nil {
+		return err
+	}
+
+	printTaskDetails(os.Stdout, t)
+	return nil
+}
+
+// printTaskDetails writes a task's summary and assignment history.
+func printTaskDetails(w io.Writer, t *task.Task) {
+	_, _ = fmt.Fprintf(w, "%s: %s\n\n", t.ID, t.Title)
+	_, _ = fmt.Fprintf(w, "Status:      %s\n", t.Status)
+	_, _ = fmt.Fprintf(w, "Workstream:  %s\n", t.GetWorkstream())
+	if t.Priority != "" {
+		_, _ = fmt.Fprintf(w, "Priority:    %s\n", t.Priority)
+	}
+	if len(t.DependsOn) > 0 {
+		_, _ = fmt.Fprintf(w, "Depends on:  %s\n", strings.Join(t.DependsOn, ", "))
+	}
+	if t.BlockedReason != "" {
+		_, _ = fmt.Fprintf(w, "Blocked:     %s\n", t.BlockedReason)
+	}
+	if t.AssignedTo != "" {
+		_, _ = fmt.Fprintf(w, "Assigned to: %s\n", t.AssignedTo)
+	}
+	if t.RetryCount > 0 {
+		_, _ = fmt.Fprintf(w, "Retries:     %d\n", t.RetryCount)
+	}
+	if t.FailureMessage != "" {
+		_, _ = fmt.Fprintf(w, "Failure:     %s\n", t.FailureMessage)
+	}
+	if t.LogFilePath != "" {
+		_, _ = fmt.Fprintf(w, "Log:         %s\n", t.LogFilePath)
+	}
+
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, "Assignment history:")
+	if len(t.AssignmentHistory) == 0 {
+		_, _ = fmt.Fprintln(w, "  (none)")
+		return
+	}
+	for _, rec := range t.AssignmentHistory {
+		_, _ = fmt.Fprintf(w, "  %s  %-20s %s\n", rec.At.Local().Format("2006-01-02 15:04:05"), rec.Agent, rec.Outcome)
+	}
+}
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bkonkle/tanuki/internal/task"
+)
+
+func TestPrintTaskDetails(t *testing.T) {
+	at := time.Date(2025, 1, 14, 9, 30, 0, 0, time.Local)
+	tsk := &task.Task{
+		ID:         "TASK-001",
+		Title:      "Add login",
+		Workstream: "api",
+		Status:     task.StatusInProgress,
+		AssignedTo: "api-2",
+		AssignmentHistory: []task.AssignmentRecord{
+			{Agent: "api-1", At: at, Outcome: task.OutcomeAssigned},
+			{Agent: "api-1", At: at.Add(time.Minute), Outcome: task.OutcomeReleased},
+			{Agent: "api-2", At: at.Add(2 * time.Minute), Outcome: task.OutcomeAssigned},
+		},
+	}
+
+	var out bytes.Buffer
+	printTaskDetails(&out, tsk)
+
+	for _, want := range []string{
+		"TASK-001: Add login",
+		"Assigned to: api-2",
+		"2025-01-14 09:31:00  api-1                released",
+		"2025-01-14 09:32:00  api-2                assigned",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestPrintTaskDetails_BlockedReason(t *testing.T) {
+	var out bytes.Buffer
+	printTaskDetails(&out, &task.Task{ID: "TASK-003", Title: "Billing", Status: task.StatusBlocked, BlockedReason: "waiting on pricing"})
+
+	if !strings.Contains(out.String(), "Blocked:     waiting on pricing") {
+		t.Errorf("output = %q, want the blocked reason", out.String())
+	}
+}
+
+func TestPrintTaskDetails_NoHistory(t *testing.T) {
+	var out bytes.Buffer
+	printTaskDetails(&out, &task.Task{ID: "TASK-002", Title: "Docs", Status: task.StatusPending})
+
+	if !strings.Contains(out.String(), "Assignment history:\n  (none)") {
+		t.Errorf("output = %q, want an empty history noted", out.String())
+	}
+}
@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/bkonkle/tanuki/internal/task"
+	"github.com/spf13/cobra"
+)
+
+var taskRestoreList bool
+
+var taskSnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Save every task's status so it can be restored later",
+	Long: `Save the status and assignment of every task to .tanuki/snapshots/,
+named by the time it was taken.
+
+Take a snapshot before a bulk change, such as restarting a project (which
+resets stale assignments), and use "tanuki task restore" to undo it.
+
+Examples:
+  tanuki task snapshot`,
+	Args: cobra.NoArgs,
+	RunE: runTaskSnapshot,
+}
+
+var taskRestoreCmd = &cobra.Command{
+	Use:   "restore [snapshot]",
+	Short: "Restore task statuses from a snapshot",
+	Long: `Put every task back to the status and assignment recorded in a snapshot,
+rewriting only the task files that changed. Without a snapshot name, the
+most recent snapshot is used.
+
+Tasks created after the snapshot was taken are left alone. Restoring while
+a project is running may be undone by the orchestrator, so stop it first.
+
+Examples:
+  tanuki task restore
+  tanuki task restore 20250114-093000
+  tanuki task restore --list`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTaskRestore,
+}
+
+func init() {
+	taskRestoreCmd.Flags().BoolVarP(&taskRestoreList, "list", "l", false, "List saved snapshots instead of restoring one")
+	taskCmd.AddCommand(taskSnapshotCmd)
+	taskCmd.AddCommand(taskRestoreCmd)
+}
+
+func runTaskSnapshot(_ *cobra.Command, _ []string) error {
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory: %w", err)
+	}
+
+	taskMgr := newTaskManager(projectRoot)
+	if _, err := taskMgr.Scan(); err != nil {
+		return fmt.Errorf("scan tasks: %w", err)
+	}
+
+	snapshot, err := taskMgr.Snapshot()
+	if err != nil {
+		return err
+	}
+	name, err := task.SaveSnapshot(projectRoot, snapshot)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Saved snapshot %s of %d tasks.\n", name, len(snapshot.Tasks))
+	fmt.Printf("Restore it with: tanuki task restore %s\n", name)
+	return nil
+}
+
+func runTaskRestore(_ *cobra.Command, args []string) error {
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory: %w", err)
+	}
+
+	if taskRestoreList {
+		names, err := task.ListSnapshots(projectRoot)
+		if err != nil {
+			return err
+		}
+		if len(names) == 0 {
+			fmt.Println("No snapshots found. Take one with: tanuki task snapshot")
+		}
+		for _, name := range names {
+			fmt.Println(name)
+		}
+		return nil
+	}
+
+	var name string
+	if len(args) > 0 {
+		name = args[0]
+	}
+	snapshot, err := task.LoadSnapshot(projectRoot, name)
+	if err != nil {
+		return err
+	}
+
+	taskMgr := newTaskManager(projectRoot)
+	if _, err := taskMgr.Scan(); err != nil {
+		return fmt.Errorf("scan tasks: %w", err)
+	}
+
+	changed := snapshot.Changed(taskMgr.List())
+	if err := taskMgr.Restore(snapshot); err != nil {
+		return err
+	}
+
+	if len(changed) == 0 {
+		fmt.Printf("Tasks already match the snapshot from %s.\n", snapshot.CreatedAt.Format("2006-01-02 15:04:05"))
+		return nil
+	}
+	fmt.Printf("Restored %d tasks to the snapshot from %s:\n", len(changed), snapshot.CreatedAt.Format("2006-01-02 15:04:05"))
+	for _, id := range changed {
+		entry := snapshot.Tasks[id]
+		fmt.Printf("  %s  [%s]\n", id, entry.Status)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/bkonkle/tanuki/internal/task"
+	"github.com/spf13/cobra"
+)
+
+var (
+	taskTimingsProject    string
+	taskTimingsWorkstream string
+)
+
+var taskTimingsCmd = &cobra.Command{
+	Use:   "timings",
+	Short: "Compare finished tasks' estimates to how long they took",
+	Long: `List finished tasks with their estimate, how long they actually took, and
+the variance between the two, to help calibrate the estimate field.
+
+A task's actual time runs from when it first went in progress to when it
+finished, as recorded in its started_at and completed_at front matter. Tasks
+without an estimate are listed without a variance and left out of the totals.
+
+Examples:
+  tanuki task timings
+  tanuki task timings --project auth-feature
+  tanuki task timings --workstream api`,
+	Args: cobra.NoArgs,
+	RunE: runTaskTimings,
+}
+
+func init() {
+	taskTimingsCmd.Flags().StringVarP(&taskTimingsProject, "project", "p", "", "Only show tasks in this project")
+	taskTimingsCmd.Flags().StringVarP(&taskTimingsWorkstream, "workstream", "w", "", "Only show tasks in this workstream")
+	taskCmd.AddCommand(taskTimingsCmd)
+}
+
+func runTaskTimings(_ *cobra.Command, _ []string) error {
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory: %w", err)
+	}
+
+	taskMgr := newTaskManager(projectRoot)
+	if _, err := taskMgr.Scan(); err != nil {
+		return fmt.Errorf("scan tasks: %w", err)
+	}
+
+	var tasks []*task.Task
+	for _, t := range taskMgr.List() {
+		if taskTimingsProject != "" && t.Project != taskTimingsProject {
+			continue
+		}
+		if taskTimingsWorkstream != "" && t.GetWorkstream() != taskTimingsWorkstream {
+			continue
+		}
+		if t.Duration() > 0 {
+			tasks = append(tasks, t)
+		}
+	}
+
+	if len(tasks) == 0 {
+		fmt.Println("No finished tasks with recorded timings.")
+		return nil
+	}
+
+	sortTasksByID(tasks)
+	return printTaskTimings(os.Stdout, tasks)
+}
+
+// printTaskTimings writes each task's estimate, actual time, and variance as
+// a table, followed by the totals for the tasks that have an estimate.
+func printTaskTimings(out io.Writer, tasks []*task.Task) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tWORKSTREAM\tSTATUS\tESTIMATE\tACTUAL\tVARIANCE")
+	_, _ = fmt.Fprintln(w, "--\t----------\t------\t--------\t------\t--------")
+
+	var estimated, actual time.Duration
+	var counted int
+	for _, t := range tasks {
+		estimate := t.GetEstimate(0)
+		if estimate > 0 {
+			estimated += estimate
+			actual += t.Duration()
+			counted++
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			t.ID,
+			truncate(t.GetWorkstream(), 15),
+			t.Status,
+			formatReportDuration(estimate),
+			formatReportDuration(t.Duration()),
+			formatVariance(estimate, t.Duration()),
+		)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if counted > 0 {
+		_, _ = fmt.Fprintf(out, "\n%d of %d tasks had estimates: %s estimated, %s actual, variance %s\n",
+			counted, len(tasks), formatReportDuration(estimated), formatReportDuration(actual),
+			formatVariance(estimated, actual))
+	}
+	return nil
+}
+
+// formatVariance renders how far actual was from estimate, e.g. "+15m (+50%)"
+// for a 30m task that took 45m, or "-" without an estimate.
+func formatVariance(estimate, actual time.Duration) string {
+	if estimate <= 0 {
+		return "-"
+	}
+
+	diff := actual - estimate
+	sign := "+"
+	if diff < 0 {
+		sign = "-"
+		diff = -diff
+	}
+	percent := int((actual - estimate) * 100 / estimate)
+
+	if diff < time.Second {
+		return "0 (0%)"
+	}
+	return fmt.Sprintf("%s%s (%+d%%)", sign, formatReportDuration(diff), percent)
+}
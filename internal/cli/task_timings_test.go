@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bkonkle/tanuki/internal/task"
+)
+
+func TestFormatVariance(t *testing.T) {
+	tests := []struct {
+		estimate, actual time.Duration
+		want             string
+	}{
+		{0, time.Hour, "-"},
+		{30 * time.Minute, 45 * time.Minute, "+15m (+50%)"},
+		{2 * time.Hour, 90 * time.Minute, "-30m (-25%)"},
+		{time.Hour, time.Hour, "0 (0%)"},
+	}
+	for _, tt := range tests {
+		if got := formatVariance(tt.estimate, tt.actual); got != tt.want {
+			t.Errorf("formatVariance(%v, %v) = %q, want %q", tt.estimate, tt.actual, got, tt.want)
+		}
+	}
+}
+
+func TestPrintTaskTimings(t *testing.T) {
+	base := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		ts := base.Add(d)
+		return &ts
+	}
+	tasks := []*task.Task{
+		{ID: "API-001", Workstream: "api", Status: task.StatusComplete, Estimate: "1h",
+			StartedAt: at(0), CompletedAt: at(90 * time.Minute)},
+		{ID: "API-002", Workstream: "api", Status: task.StatusFailed,
+			StartedAt: at(0), CompletedAt: at(20 * time.Minute)},
+	}
+
+	var buf bytes.Buffer
+	if err := printTaskTimings(&buf, tasks); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"+30m (+50%)",
+		"API-002  api",
+		"1 of 2 tasks had estimates: 1h estimated, 1h30m actual, variance +30m (+50%)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bkonkle/tanuki/internal/task"
+	"github.com/spf13/cobra"
+)
+
+// waitTimeoutExitCode is the exit status of "tanuki task wait" when it times
+// out, matching timeout(1) so scripts can tell a timeout from a failed task.
+const waitTimeoutExitCode = 124
+
+var (
+	taskWaitStatus   string
+	taskWaitTimeout  time.Duration
+	taskWaitInterval time.Duration
+)
+
+var taskWaitCmd = &cobra.Command{
+	Use:   "wait <id...>",
+	Short: "Wait for tasks to reach a status",
+	Long: `Block until every named task reaches the given status (default: complete).
+The task files are re-read every --interval, so changes made by other tanuki
+processes are picked up.
+
+Exits with status 1 as soon as any task fails (unless waiting for failed), and
+with status 124 if --timeout passes first.
+
+Examples:
+  tanuki task wait TASK-001
+  tanuki task wait TASK-001 TASK-002 --timeout 30m
+  tanuki run --assign TASK-001 && tanuki task wait TASK-001 && ./deploy.sh`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runTaskWait,
+}
+
+func init() {
+	taskWaitCmd.Flags().StringVarP(&taskWaitStatus, "status", "s", string(task.StatusComplete), "Status to wait for")
+	taskWaitCmd.Flags().DurationVarP(&taskWaitTimeout, "timeout", "t", 0, "Give up after this long (0 = wait forever)")
+	taskWaitCmd.Flags().DurationVar(&taskWaitInterval, "interval", 2*time.Second, "How often to re-read the tasks")
+	taskCmd.AddCommand(taskWaitCmd)
+}
+
+// taskPoller is the subset of task.Manager used to wait on tasks.
+type taskPoller interface {
+	Scan() ([]*task.Task, error)
+	Get(id string) (*task.Task, error)
+}
+
+func runTaskWait(cmd *cobra.Command, args []string) error {
+	status := task.Status(taskWaitStatus)
+	if status == "" || !status.IsValid() {
+		return fmt.Errorf("invalid status %q", taskWaitStatus)
+	}
+	if taskWaitInterval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if taskWaitTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, taskWaitTimeout)
+		defer cancel()
+	}
+
+	taskMgr := newTaskManager(projectRoot)
+	err = waitForTasks(ctx, os.Stdout, taskMgr, args, status, taskWaitInterval)
+	if errors.Is(err, context.DeadlineExceeded) {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: timed out after %s\n", taskWaitTimeout)
+		cmd.SilenceErrors = true
+		return &ExitCodeError{Code: waitTimeoutExitCode}
+	}
+	return err
+}
+
+// waitForTasks rescans tasks every interval until each of ids has status,
+// reporting each task as it gets there. It fails as soon as a task is missing
+// or fails, and returns ctx's error if ctx ends first.
+func waitForTasks(ctx context.Context, w io.Writer, tasks taskPoller, ids []string, status task.Status, interval time.Duration) error {
+	pending := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		pending[id] = true
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := tasks.Scan(); err != nil {
+			return fmt.Errorf("scan tasks: %w", err)
+		}
+
+		for _, id := range sortedKeys(pending) {
+			t, err := tasks.Get(id)
+			if err != nil {
+				return err
+			}
+			switch {
+			case t.Status == status:
+				_, _ = fmt.Fprintf(w, "%s is %s\n", id, status)
+				delete(pending, id)
+			case t.Status == task.StatusFailed:
+				msg := fmt.Sprintf("task %s failed", id)
+				if t.FailureMessage != "" {
+					msg += ": " + t.FailureMessage
+				}
+				return errors.New(msg)
+			}
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for %s: %w", strings.Join(sortedKeys(pending), ", "), ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// sortedKeys returns a set's keys in order.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bkonkle/tanuki/internal/task"
+)
+
+// fakeTaskPoller replays a sequence of statuses per task, one per Scan.
+type fakeTaskPoller struct {
+	statuses map[string][]task.Status
+	scans    int
+}
+
+func (p *fakeTaskPoller) Scan() ([]*task.Task, error) {
+	p.scans++
+	return nil, nil
+}
+
+func (p *fakeTaskPoller) Get(id string) (*task.Task, error) {
+	seq, ok := p.statuses[id]
+	if !ok {
+		return nil, fmt.Errorf("task %q not found", id)
+	}
+	i := p.scans - 1
+	if i >= len(seq) {
+		i = len(seq) - 1
+	}
+	return &task.Task{ID: id, Status: seq[i]}, nil
+}
+
+func TestWaitForTasks(t *testing.T) {
+	poller := &fakeTaskPoller{statuses: map[string][]task.Status{
+		"T1": {task.StatusInProgress, task.StatusComplete},
+		"T2": {task.StatusPending, task.StatusInProgress, task.StatusComplete},
+	}}
+
+	var out bytes.Buffer
+	err := waitForTasks(context.Background(), &out, poller, []string{"T1", "T2"}, task.StatusComplete, time.Millisecond)
+	if err != nil {
+		t.Fatalf("waitForTasks() error = %v", err)
+	}
+	if poller.scans != 3 {
+		t.Errorf("scans = %d, want 3 (until the last task completes)", poller.scans)
+	}
+	if out.String() != "T1 is complete\nT2 is complete\n" {
+		t.Errorf("output = %q", out.String())
+	}
+}
+
+func TestWaitForTasks_Failed(t *testing.T) {
+	poller := &fakeTaskPoller{statuses: map[string][]task.Status{
+		"T1": {task.StatusInProgress, task.StatusFailed},
+	}}
+
+	err := waitForTasks(context.Background(), &bytes.Buffer{}, poller, []string{"T1"}, task.StatusComplete, time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "task T1 failed") {
+		t.Errorf("waitForTasks() error = %v, want task T1 failed", err)
+	}
+
+	// Waiting for failed succeeds instead
+	poller.scans = 0
+	if err := waitForTasks(context.Background(), &bytes.Buffer{}, poller, []string{"T1"}, task.StatusFailed, time.Millisecond); err != nil {
+		t.Errorf("waitForTasks(failed) error = %v", err)
+	}
+}
+
+func TestWaitForTasks_Timeout(t *testing.T) {
+	poller := &fakeTaskPoller{statuses: map[string][]task.Status{
+		"T1": {task.StatusInProgress},
+	}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := waitForTasks(ctx, &bytes.Buffer{}, poller, []string{"T1"}, task.StatusComplete, time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("waitForTasks() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestWaitForTasks_Missing(t *testing.T) {
+	poller := &fakeTaskPoller{statuses: map[string][]task.Status{}}
+
+	if err := waitForTasks(context.Background(), &bytes.Buffer{}, poller, []string{"NOPE"}, task.StatusComplete, time.Millisecond); err == nil {
+		t.Error("waitForTasks() expected an error for an unknown task")
+	}
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var versionOutput string
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print version information",
+	Long: `Print the version, git commit, build date, and Go version of this build.
+
+Include this output when reporting a bug. The same text is printed by
+tanuki --version.
+
+Examples:
+  tanuki version
+  tanuki version -o json`,
+	Args: cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return printVersion(os.Stdout, currentBuildInfo(), versionOutput)
+	},
+}
+
+func init() {
+	versionCmd.Flags().StringVarP(&versionOutput, "output", "o", "text", "Output format (text, json)")
+	rootCmd.AddCommand(versionCmd)
+	setVersionFlag()
+}
+
+// buildInfo describes the running tanuki binary.
+type buildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	Date      string `json:"date"`
+	GoVersion string `json:"go_version"`
+	Platform  string `json:"platform"`
+}
+
+// currentBuildInfo returns the version details set via -ldflags. Builds
+// without them, such as go install, fall back to the module version and VCS
+// details Go embeds in the binary.
+func currentBuildInfo() buildInfo {
+	info := buildInfo{
+		Version:   version,
+		Commit:    commit,
+		Date:      date,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	if info.Version == "dev" && bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+		info.Version = bi.Main.Version
+	}
+	for _, s := range bi.Settings {
+		switch {
+		case s.Key == "vcs.revision" && info.Commit == "none":
+			info.Commit = s.Value
+		case s.Key == "vcs.time" && info.Date == "unknown":
+			info.Date = s.Value
+		}
+	}
+	return info
+}
+
+// formatVersion renders info as the text printed by tanuki version.
+func formatVersion(info buildInfo) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "tanuki %s\n", info.Version)
+	fmt.Fprintf(&b, "  commit: %s\n", info.Commit)
+	fmt.Fprintf(&b, "  built:  %s\n", info.Date)
+	fmt.Fprintf(&b, "  go:     %s %s\n", info.GoVersion, info.Platform)
+	return b.String()
+}
+
+func printVersion(w io.Writer, info buildInfo, output string) error {
+	switch output {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(info)
+	case "text", "":
+		_, err := io.WriteString(w, formatVersion(info))
+		return err
+	default:
+		return fmt.Errorf("unknown output format %q (use text or json)", output)
+	}
+}
+
+// setVersionFlag enables tanuki --version, printing the same text as
+// tanuki version.
+func setVersionFlag() {
+	info := currentBuildInfo()
+	rootCmd.Version = info.Version
+	rootCmd.SetVersionTemplate(formatVersion(info))
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestPrintVersion(t *testing.T) {
+	info := buildInfo{
+		Version:   "v1.2.3",
+		Commit:    "abc123",
+		Date:      "2026-01-13T00:00:00Z",
+		GoVersion: "go1.24.0",
+		Platform:  "linux/amd64",
+	}
+
+	tests := []struct {
+		name    string
+		output  string
+		want    []string
+		wantErr bool
+	}{
+		{"text", "text", []string{"tanuki v1.2.3", "commit: abc123", "built:  2026-01-13T00:00:00Z", "go:     go1.24.0 linux/amd64"}, false},
+		{"default", "", []string{"tanuki v1.2.3"}, false},
+		{"json", "json", []string{`"version": "v1.2.3"`, `"commit": "abc123"`, `"go_version": "go1.24.0"`}, false},
+		{"unknown format", "yaml", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := printVersion(&buf, info, tt.output)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("printVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestPrintVersion_JSONRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := printVersion(&buf, currentBuildInfo(), "json"); err != nil {
+		t.Fatalf("printVersion() error = %v", err)
+	}
+
+	var got buildInfo
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output isn't valid JSON: %v\n%s", err, buf.String())
+	}
+	if got.Version == "" || got.GoVersion == "" || got.Platform == "" {
+		t.Errorf("buildInfo = %+v, want version, Go version, and platform set", got)
+	}
+}
+
+func TestSetVersionInfo(t *testing.T) {
+	origVersion, origCommit, origDate := version, commit, date
+	t.Cleanup(func() { SetVersionInfo(origVersion, origCommit, origDate) })
+
+	SetVersionInfo("v9.9.9", "deadbeef", "2026-02-01")
+
+	if rootCmd.Version != "v9.9.9" {
+		t.Errorf("rootCmd.Version = %q, want v9.9.9", rootCmd.Version)
+	}
+	if !strings.Contains(rootCmd.VersionTemplate(), "commit: deadbeef") {
+		t.Errorf("--version template = %q, want the commit", rootCmd.VersionTemplate())
+	}
+}
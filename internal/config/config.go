@@ -3,20 +3,26 @@
 // Configuration is loaded from multiple sources with the following precedence
 // (highest to lowest):
 //  1. CLI flags (set via SetOverride)
-//  2. Project config: ./tanuki.yaml or ./.tanuki/config/tanuki.yaml
-//  3. Global config: ~/.config/tanuki/config.yaml
-//  4. Built-in defaults
+//  2. Environment variables (TANUKI_ + the upper-cased key path, see EnvPrefix)
+//  3. Selected profile (set via SetProfile or TANUKI_PROFILE)
+//  4. Project config: ./tanuki.yaml or ./.tanuki/config/tanuki.yaml
+//  5. Global config: ~/.config/tanuki/config.yaml
+//  6. Built-in defaults
 //
-// The package uses Viper for configuration merging and supports automatic
-// environment variable binding with the TANUKI_ prefix.
+// The package uses Viper for configuration merging.
 package config
 
 import (
 	"errors"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/spf13/viper"
@@ -26,13 +32,82 @@ import (
 // Config represents the tanuki.yaml configuration file.
 // This is the root configuration structure containing all settings for Tanuki.
 type Config struct {
-	// Version is the configuration schema version (currently "1")
+	// Version is the configuration schema version (currently "1"); older
+	// versions are upgraded on load, see Migrate
 	Version string `yaml:"version" mapstructure:"version" validate:"required,eq=1"`
 
 	// TasksDir is the directory for task files, relative to project root.
 	// Defaults to "tasks" (visible in project). Can be set to ".tanuki/tasks" for hidden tasks.
 	TasksDir string `yaml:"tasks_dir,omitempty" mapstructure:"tasks_dir"`
 
+	// ExtraTaskDirs are more directories to read tasks from, such as a folder
+	// of tasks shared between projects, relative to project root unless
+	// absolute. New tasks are still created in TasksDir.
+	ExtraTaskDirs []string `yaml:"extra_task_dirs,omitempty" mapstructure:"extra_task_dirs"`
+
+	// DefaultEstimate is the effort estimate assumed for tasks without one (e.g., "30m").
+	// Used only for ballpark ETA calculations. Defaults to 30m.
+	DefaultEstimate string `yaml:"default_estimate,omitempty" mapstructure:"default_estimate"`
+
+	// TaskIDFormat is the template for generated task IDs. {project} is the
+	// project folder name and {seq} (or {seq:03d} for zero padding) is the
+	// next sequence number. Defaults to "{project}-{seq:03d}".
+	TaskIDFormat string `yaml:"task_id_format,omitempty" mapstructure:"task_id_format"`
+
+	// RestartOnTaskChange makes the orchestrator cancel and rerun a task whose
+	// body is edited while an agent is working on it. By default the change
+	// is only logged as a warning.
+	RestartOnTaskChange bool `yaml:"restart_on_task_change,omitempty" mapstructure:"restart_on_task_change"`
+
+	// SkipVerify lets orchestrated tasks complete without running their
+	// completion.verify command in the agent's container after the run.
+	// By default a task only completes if its verify command passes.
+	SkipVerify bool `yaml:"skip_verify,omitempty" mapstructure:"skip_verify"`
+
+	// KeepContainerOnFailure makes the orchestrator stop, but not remove, the
+	// agent a task failed on and hold it out of the pool as "failed-held"
+	// until "tanuki agent release", so the failure can be inspected.
+	KeepContainerOnFailure bool `yaml:"keep_container_on_failure,omitempty" mapstructure:"keep_container_on_failure"`
+
+	// ResumeRetries makes a retry that runs on the agent its failed attempt
+	// ran on resume that attempt's Claude session, keeping its context. By
+	// default each retry starts a new session.
+	ResumeRetries bool `yaml:"resume_retries,omitempty" mapstructure:"resume_retries"`
+
+	// RequireSignal fails a single-shot task run that finishes without
+	// printing the task's completion.signal. By default the run succeeds
+	// and the missing signal is only reported as a warning.
+	RequireSignal bool `yaml:"require_signal,omitempty" mapstructure:"require_signal"`
+
+	// GroupFailure decides what happens to the rest of a task group when one
+	// of its members fails: "block" (the default) leaves them blocked, "fail"
+	// marks them failed.
+	GroupFailure string `yaml:"group_failure,omitempty" mapstructure:"group_failure"`
+
+	// MaxAssignmentHistory caps how many assignment history entries each
+	// task file keeps (default 20); the oldest are dropped first.
+	MaxAssignmentHistory int `yaml:"max_assignment_history,omitempty" mapstructure:"max_assignment_history" validate:"omitempty,gte=1,lte=1000"`
+
+	// MaxDependencyDepth is the longest dependency chain "tanuki task check"
+	// accepts without a warning (default 5). Tasks in a chain run one after
+	// another however many agents are available.
+	MaxDependencyDepth int `yaml:"max_dependency_depth,omitempty" mapstructure:"max_dependency_depth" validate:"omitempty,gte=1,lte=1000"`
+
+	// ProgressPattern is a regular expression matching the progress lines
+	// agents print, shown as a progress bar on in-progress tasks. With two
+	// capture groups they are read as done and total, with one as a
+	// percentage. Defaults to matching "Progress: 3/7" and "Progress: 40%".
+	ProgressPattern string `yaml:"progress_pattern,omitempty" mapstructure:"progress_pattern"`
+
+	// GlobalSystemPrompt is prepended to the system prompt of every run,
+	// ahead of workstream and run-specific instructions. Use it for
+	// project-wide guardrails.
+	GlobalSystemPrompt string `yaml:"global_system_prompt,omitempty" mapstructure:"global_system_prompt"`
+
+	// GlobalSystemPromptFile is a file, relative to the project root, holding
+	// the global system prompt. Mutually exclusive with GlobalSystemPrompt.
+	GlobalSystemPromptFile string `yaml:"global_system_prompt_file,omitempty" mapstructure:"global_system_prompt_file"`
+
 	// Image specifies the Docker image configuration for agent containers
 	Image ImageConfig `yaml:"image" mapstructure:"image"`
 
@@ -50,15 +125,51 @@ type Config struct {
 
 	// Network contains Docker network settings
 	Network NetworkConfig `yaml:"network" mapstructure:"network"`
+
+	// Dashboard tunes the TUI dashboard
+	Dashboard DashboardConfig `yaml:"dashboard,omitempty" mapstructure:"dashboard"`
+
+	// Spawn throttles agent creation during project runs
+	Spawn SpawnConfig `yaml:"spawn,omitempty" mapstructure:"spawn"`
+
+	// Executor selects the agent CLI that runs tasks
+	Executor ExecutorConfig `yaml:"executor,omitempty" mapstructure:"executor"`
+
+	// Ralph sets the Ralph mode loop defaults for "tanuki run" and for
+	// tasks without their own ralph settings
+	Ralph RalphConfig `yaml:"ralph,omitempty" mapstructure:"ralph"`
+
+	// Profiles contains named sets of partial overrides (e.g., "dev", "ci").
+	// The selected profile is deep-merged over the file config before CLI overrides.
+	Profiles map[string]map[string]interface{} `yaml:"profiles,omitempty" mapstructure:"profiles"`
 }
 
+// EnvPrefix prefixes the environment variables that override config keys.
+// A key's variable is its dotted path upper-cased with "." replaced by "_",
+// so defaults.model is TANUKI_DEFAULTS_MODEL and network.name is
+// TANUKI_NETWORK_NAME. List values such as defaults.allowed_tools are
+// comma-separated. Only keys with a built-in default or set in a config file
+// are read from the environment.
+const EnvPrefix = "TANUKI"
+
+// ProfileEnvVar is the environment variable used to select a config profile
+// when none is set explicitly on the Loader.
+const ProfileEnvVar = "TANUKI_PROFILE"
+
 // WorkstreamConfig contains configuration for a specific workstream.
 // These settings override AgentDefaults when an agent is spawned for this workstream.
 // Workstreams can be organized by feature area, discipline, or any grouping that fits your workflow.
 type WorkstreamConfig struct {
-	// Concurrency is the maximum number of concurrent instances for this workstream
+	// Concurrency is the agent concurrency limit: the maximum number of
+	// agents (workstream runners) working this workstream at once. Each
+	// agent works one task at a time.
 	Concurrency int `yaml:"concurrency,omitempty" mapstructure:"concurrency" validate:"omitempty,gte=1,lte=10"`
 
+	// MaxConcurrentTasks is the task concurrency limit: the maximum number of
+	// this workstream's tasks assigned or in progress at once, across all
+	// agents. Zero leaves tasks limited only by the number of agents.
+	MaxConcurrentTasks int `yaml:"max_concurrent_tasks,omitempty" mapstructure:"max_concurrent_tasks" validate:"omitempty,gte=1,lte=100"`
+
 	// SystemPrompt is the workstream-specific system prompt
 	SystemPrompt string `yaml:"system_prompt,omitempty" mapstructure:"system_prompt"`
 
@@ -79,6 +190,13 @@ type WorkstreamConfig struct {
 
 	// Resources overrides the default container resource limits
 	Resources *ResourceConfig `yaml:"resources,omitempty" mapstructure:"resources"`
+
+	// Network is the Docker network for this workstream's agents instead of
+	// network.name, created if missing. Use "none" to disable networking.
+	Network string `yaml:"network,omitempty" mapstructure:"network"`
+
+	// MaxRetries is how many times a failed task with a retry_prompt is retried
+	MaxRetries int `yaml:"max_retries,omitempty" mapstructure:"max_retries" validate:"omitempty,gte=1,lte=10"`
 }
 
 // GetConcurrency returns the concurrency setting with a default of 1.
@@ -89,6 +207,32 @@ func (w *WorkstreamConfig) GetConcurrency() int {
 	return w.Concurrency
 }
 
+// GetMaxConcurrentTasks returns the task concurrency limit, or 0 for no
+// limit beyond the number of agents.
+func (w *WorkstreamConfig) GetMaxConcurrentTasks() int {
+	if w == nil || w.MaxConcurrentTasks <= 0 {
+		return 0
+	}
+	return w.MaxConcurrentTasks
+}
+
+// GetMaxRetries returns the retry limit with a default of 1.
+func (w *WorkstreamConfig) GetMaxRetries() int {
+	if w == nil || w.MaxRetries <= 0 {
+		return 1
+	}
+	return w.MaxRetries
+}
+
+// GetSystemPrompt returns the workstream's system prompt: SystemPrompt if
+// set, else the contents of SystemPromptFile.
+func (w *WorkstreamConfig) GetSystemPrompt() (string, error) {
+	if w == nil {
+		return "", nil
+	}
+	return loadPrompt(w.SystemPrompt, w.SystemPromptFile, "system_prompt_file")
+}
+
 // ImageConfig specifies which Docker image to use for agents.
 // Either Name+Tag or Build should be specified, not both.
 type ImageConfig struct {
@@ -126,9 +270,27 @@ type AgentDefaults struct {
 	// When exceeded, the workstream session is saved and a fresh instance starts
 	MaxWorkstreamTurns int `yaml:"max_workstream_turns,omitempty" mapstructure:"max_workstream_turns" validate:"omitempty,gte=50,lte=1000"`
 
+	// AutoContinueOnTurnLimit resumes a run's session when it stops at
+	// MaxTurns, doubling the turn limit each time, up to MaxContinuations
+	// times. Off by default: a run that hits the limit fails as "max_turns".
+	AutoContinueOnTurnLimit bool `yaml:"auto_continue_on_turn_limit,omitempty" mapstructure:"auto_continue_on_turn_limit"`
+
+	// MaxContinuations caps automatic continuations per run (default 2)
+	MaxContinuations int `yaml:"max_continuations,omitempty" mapstructure:"max_continuations" validate:"omitempty,gte=1,lte=10"`
+
+	// MaxOutputBytes caps how much of a run's output Tanuki keeps in memory.
+	// Longer output keeps its first and last halves, with a truncation
+	// marker between them (default 10 MiB).
+	MaxOutputBytes int `yaml:"max_output_bytes,omitempty" mapstructure:"max_output_bytes" validate:"omitempty,gte=1024"`
+
 	// Model is the Claude model to use (e.g., "claude-haiku-4-5-20250514")
 	Model string `yaml:"model" mapstructure:"model" validate:"required"`
 
+	// ModelFallbacks are models to retry with, in order, when a run fails
+	// because Model is rate limited, overloaded, or unavailable. Empty (the
+	// default) disables fallback.
+	ModelFallbacks []string `yaml:"model_fallbacks,omitempty" mapstructure:"model_fallbacks"`
+
 	// Resources specifies container resource limits
 	Resources ResourceConfig `yaml:"resources" mapstructure:"resources"`
 }
@@ -141,6 +303,32 @@ func (a *AgentDefaults) GetMaxWorkstreamTurns() int {
 	return a.MaxWorkstreamTurns
 }
 
+// GetMaxContinuations returns how many times a run that hits the turn limit
+// is resumed: 0 unless AutoContinueOnTurnLimit is set, then MaxContinuations
+// with a default of 2.
+func (a *AgentDefaults) GetMaxContinuations() int {
+	if !a.AutoContinueOnTurnLimit {
+		return 0
+	}
+	if a.MaxContinuations <= 0 {
+		return 2 // Default
+	}
+	return a.MaxContinuations
+}
+
+// DefaultMaxOutputBytes is the run output kept in memory when
+// max_output_bytes isn't set.
+const DefaultMaxOutputBytes = 10 << 20
+
+// GetMaxOutputBytes returns the cap on a run's retained output, with a
+// default of DefaultMaxOutputBytes.
+func (a *AgentDefaults) GetMaxOutputBytes() int {
+	if a.MaxOutputBytes <= 0 {
+		return DefaultMaxOutputBytes
+	}
+	return a.MaxOutputBytes
+}
+
 // ResourceConfig specifies resource limits for agent containers.
 // These map directly to Docker container resource constraints.
 type ResourceConfig struct {
@@ -159,6 +347,31 @@ type GitConfig struct {
 
 	// AutoPush automatically pushes commits to remote when true
 	AutoPush bool `yaml:"auto_push" mapstructure:"auto_push"`
+
+	// SSHKeyPath is a private key (e.g. a deploy key) mounted read-only into
+	// agent containers so they can push to private remotes. It must not be
+	// readable by group or others.
+	SSHKeyPath string `yaml:"ssh_key_path,omitempty" mapstructure:"ssh_key_path"`
+
+	// ForwardSSHAgent forwards the host SSH agent socket into agent containers
+	ForwardSSHAgent bool `yaml:"forward_ssh_agent,omitempty" mapstructure:"forward_ssh_agent"`
+
+	// WorktreeDir is where agent worktrees are created, absolute or relative
+	// to the project root. Defaults to DefaultWorktreeDir.
+	WorktreeDir string `yaml:"worktree_dir,omitempty" mapstructure:"worktree_dir"`
+}
+
+// DefaultWorktreeDir is where agent worktrees are created when
+// git.worktree_dir isn't set, relative to the project root.
+const DefaultWorktreeDir = ".tanuki/worktrees"
+
+// GetWorktreeDir returns the configured worktree directory, or
+// DefaultWorktreeDir if none is set.
+func (g GitConfig) GetWorktreeDir() string {
+	if g.WorktreeDir == "" {
+		return DefaultWorktreeDir
+	}
+	return g.WorktreeDir
 }
 
 // NetworkConfig specifies Docker network settings for agent communication.
@@ -167,6 +380,76 @@ type NetworkConfig struct {
 	Name string `yaml:"name" mapstructure:"name" validate:"required"`
 }
 
+// DashboardConfig tunes the TUI dashboard.
+type DashboardConfig struct {
+	// MaxLogs is how many lines of scrollback the logs pane keeps
+	MaxLogs int `yaml:"max_logs,omitempty" mapstructure:"max_logs" validate:"omitempty,gte=100,lte=100000"`
+
+	// RefreshInterval is how often agents and tasks are refreshed (e.g., "1s").
+	// Slower refreshes use less CPU on large projects.
+	RefreshInterval string `yaml:"refresh_interval,omitempty" mapstructure:"refresh_interval"`
+}
+
+// SpawnConfig throttles agent creation, so starting many workstreams at once
+// doesn't overwhelm the Docker daemon and disk.
+type SpawnConfig struct {
+	// MaxConcurrent is how many agents may be created at the same time
+	MaxConcurrent int `yaml:"max_concurrent,omitempty" mapstructure:"max_concurrent" validate:"omitempty,gte=1,lte=20"`
+
+	// Interval is the minimum time between starting agent creations
+	// (e.g., "500ms"); "0s" disables the stagger
+	Interval string `yaml:"interval,omitempty" mapstructure:"interval"`
+}
+
+// ExecutorConfig selects the agent CLI that runs tasks.
+type ExecutorConfig struct {
+	// Backend is "claude" (Claude Code, the default) or "command"
+	Backend string `yaml:"backend,omitempty" mapstructure:"backend"`
+
+	// Command is the command line the "command" backend runs, with the
+	// prompt substituted for {prompt} (e.g., ["aider", "--yes", "--message", "{prompt}"])
+	Command []string `yaml:"command,omitempty" mapstructure:"command"`
+}
+
+// RalphConfig sets the Ralph mode loop defaults.
+type RalphConfig struct {
+	// MaxIterations stops the loop after this many iterations (default 30)
+	MaxIterations int `yaml:"max_iterations,omitempty" mapstructure:"max_iterations" validate:"omitempty,gte=1,lte=1000"`
+
+	// Signal is the completion signal to detect in output (default "DONE")
+	Signal string `yaml:"signal,omitempty" mapstructure:"signal"`
+
+	// Cooldown is the pause between iterations (e.g., "5s"; default none)
+	Cooldown string `yaml:"cooldown,omitempty" mapstructure:"cooldown"`
+}
+
+// GetCooldown returns the pause between iterations, or zero if unset.
+func (r *RalphConfig) GetCooldown() time.Duration {
+	if r.Cooldown == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(r.Cooldown)
+	if err != nil || d < 0 {
+		return 0
+	}
+	return d
+}
+
+// Dashboard defaults and refresh interval bounds.
+const (
+	DefaultDashboardMaxLogs         = 1000
+	DefaultDashboardRefreshInterval = time.Second
+	minDashboardRefreshInterval     = 100 * time.Millisecond
+	maxDashboardRefreshInterval     = time.Minute
+)
+
+// Spawn throttling defaults and interval bound.
+const (
+	DefaultSpawnMaxConcurrent = 2
+	DefaultSpawnInterval      = 500 * time.Millisecond
+	maxSpawnInterval          = time.Minute
+)
+
 // ValidationError represents a configuration validation error with field details.
 type ValidationError struct {
 	Field   string
@@ -198,12 +481,16 @@ type Loader struct {
 	v         *viper.Viper
 	validator *validator.Validate
 	overrides map[string]interface{}
+	profile   string
 }
 
 // NewLoader creates a new configuration loader.
 func NewLoader() *Loader {
 	v := viper.New()
 	v.SetConfigType("yaml")
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
 
 	return &Loader{
 		v:         v,
@@ -218,6 +505,12 @@ func (l *Loader) SetOverride(key string, value interface{}) {
 	l.overrides[key] = value
 }
 
+// SetProfile selects the named profile to merge over the file config.
+// An empty name falls back to the TANUKI_PROFILE environment variable.
+func (l *Loader) SetProfile(name string) {
+	l.profile = name
+}
+
 // Load reads configuration from all sources and returns the merged result.
 // It searches for config files in the following order:
 //  1. ./tanuki.yaml
@@ -226,34 +519,47 @@ func (l *Loader) SetOverride(key string, value interface{}) {
 //
 // All found configs are merged with CLI overrides taking highest precedence.
 func (l *Loader) Load() (*Config, error) {
-	// Start with defaults
-	cfg := DefaultConfig()
-	l.setDefaults()
+	return l.load("")
+}
 
-	// Load global config (~/.config/tanuki/config.yaml)
-	globalPath := l.globalConfigPath()
-	if globalPath != "" && fileExists(globalPath) {
-		if err := l.loadConfigFile(globalPath); err != nil {
-			return nil, fmt.Errorf("failed to load global config %s: %w", globalPath, err)
-		}
+// LoadFromPath loads configuration from a specific file path, skipping the
+// project and global config search. Defaults, profiles, and CLI overrides
+// still apply. This is useful for testing or when a config path is
+// explicitly specified.
+func (l *Loader) LoadFromPath(path string) (*Config, error) {
+	if !fileExists(path) {
+		return nil, fmt.Errorf("config file not found: %s", path)
 	}
+	return l.load(path)
+}
 
-	// Load project config (./tanuki.yaml or ./.tanuki/config/tanuki.yaml)
-	projectPath := l.findProjectConfig()
-	if projectPath != "" {
-		if err := l.loadConfigFile(projectPath); err != nil {
-			return nil, fmt.Errorf("failed to load project config %s: %w", projectPath, err)
-		}
+// Settings returns only what the config files, the selected profile, and CLI
+// overrides set, without defaults, keyed as in tanuki.yaml. An empty path
+// searches for config files as Load does. The result isn't validated.
+func (l *Loader) Settings(path string) (map[string]interface{}, error) {
+	if path != "" && !fileExists(path) {
+		return nil, fmt.Errorf("config file not found: %s", path)
 	}
+	if err := l.merge(path); err != nil {
+		return nil, err
+	}
+	return l.v.AllSettings(), nil
+}
 
-	// Apply CLI overrides (highest precedence)
-	for key, value := range l.overrides {
-		l.v.Set(key, value)
+// load merges the defaults and every config source, migrates the result to
+// the current version, then validates it.
+func (l *Loader) load(path string) (*Config, error) {
+	// Start with defaults
+	l.setDefaults()
+
+	if err := l.merge(path); err != nil {
+		return nil, err
 	}
 
-	// Unmarshal into config struct
-	if err := l.v.Unmarshal(cfg); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	// Upgrade older config versions and unmarshal into the config struct
+	cfg, err := Migrate(l.v.AllSettings())
+	if err != nil {
+		return nil, err
 	}
 
 	// Validate the config
@@ -264,30 +570,41 @@ func (l *Loader) Load() (*Config, error) {
 	return cfg, nil
 }
 
-// LoadFromPath loads configuration from a specific file path.
-// This is useful for testing or when a config path is explicitly specified.
-func (l *Loader) LoadFromPath(path string) (*Config, error) {
-	l.setDefaults()
-
-	if err := l.loadConfigFile(path); err != nil {
-		return nil, err
-	}
+// merge reads path, or the global and project config files if path is
+// empty, then applies the selected profile and CLI overrides.
+func (l *Loader) merge(path string) error {
+	if path != "" {
+		if err := l.loadConfigFile(path); err != nil {
+			return fmt.Errorf("failed to load config %s: %w", path, err)
+		}
+	} else {
+		// Load global config (~/.config/tanuki/config.yaml)
+		globalPath := l.globalConfigPath()
+		if globalPath != "" && fileExists(globalPath) {
+			if err := l.loadConfigFile(globalPath); err != nil {
+				return fmt.Errorf("failed to load global config %s: %w", globalPath, err)
+			}
+		}
 
-	// Apply CLI overrides
-	for key, value := range l.overrides {
-		l.v.Set(key, value)
+		// Load project config (./tanuki.yaml or ./.tanuki/config/tanuki.yaml)
+		projectPath := l.findProjectConfig()
+		if projectPath != "" {
+			if err := l.loadConfigFile(projectPath); err != nil {
+				return fmt.Errorf("failed to load project config %s: %w", projectPath, err)
+			}
+		}
 	}
 
-	cfg := &Config{}
-	if err := l.v.Unmarshal(cfg); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	// Apply the selected profile over the file config
+	if err := l.applyProfile(); err != nil {
+		return err
 	}
 
-	if err := l.Validate(cfg); err != nil {
-		return nil, err
+	// Apply CLI overrides (highest precedence)
+	for key, value := range l.overrides {
+		l.v.Set(key, value)
 	}
-
-	return cfg, nil
+	return nil
 }
 
 // Validate checks the configuration against the schema.
@@ -313,6 +630,132 @@ func (l *Loader) Validate(cfg *Config) error {
 		}
 	}
 
+	if cfg.DefaultEstimate != "" {
+		if d, err := time.ParseDuration(cfg.DefaultEstimate); err != nil || d <= 0 {
+			errs = append(errs, ValidationError{
+				Field:   "DefaultEstimate",
+				Tag:     "duration",
+				Value:   cfg.DefaultEstimate,
+				Message: fmt.Sprintf("'default_estimate' must be a positive duration like 30m or 2h (got '%s')", cfg.DefaultEstimate),
+			})
+		}
+	}
+
+	if cfg.Ralph.Cooldown != "" {
+		if d, err := time.ParseDuration(cfg.Ralph.Cooldown); err != nil || d < 0 {
+			errs = append(errs, ValidationError{
+				Field:   "Ralph.Cooldown",
+				Tag:     "duration",
+				Value:   cfg.Ralph.Cooldown,
+				Message: fmt.Sprintf("'ralph.cooldown' must be a duration like 5s or 1m (got '%s')", cfg.Ralph.Cooldown),
+			})
+		}
+	}
+
+	if cfg.TaskIDFormat != "" && len(taskIDSeqPattern.FindAllString(cfg.TaskIDFormat, -1)) != 1 {
+		errs = append(errs, ValidationError{
+			Field:   "TaskIDFormat",
+			Tag:     "format",
+			Value:   cfg.TaskIDFormat,
+			Message: fmt.Sprintf("'task_id_format' must contain exactly one {seq} or {seq:03d} placeholder (got '%s')", cfg.TaskIDFormat),
+		})
+	}
+
+	for i, dir := range cfg.ExtraTaskDirs {
+		if dir == "" || filepath.Clean(dir) == filepath.Clean(cfg.TasksDir) {
+			errs = append(errs, ValidationError{
+				Field:   fmt.Sprintf("ExtraTaskDirs[%d]", i),
+				Tag:     "ne",
+				Value:   dir,
+				Message: fmt.Sprintf("'extra_task_dirs' entries must be set and differ from 'tasks_dir' (got '%s')", dir),
+			})
+		}
+	}
+
+	if cfg.GlobalSystemPrompt != "" && cfg.GlobalSystemPromptFile != "" {
+		errs = append(errs, ValidationError{
+			Field:   "GlobalSystemPromptFile",
+			Tag:     "excluded_with",
+			Value:   cfg.GlobalSystemPromptFile,
+			Message: "'global_system_prompt' and 'global_system_prompt_file' cannot both be set",
+		})
+	}
+
+	if gf := cfg.GroupFailure; gf != "" && gf != "block" && gf != "fail" {
+		errs = append(errs, ValidationError{
+			Field:   "GroupFailure",
+			Tag:     "oneof",
+			Value:   gf,
+			Message: fmt.Sprintf("'group_failure' must be block or fail (got '%s')", gf),
+		})
+	}
+
+	switch backend := cfg.Executor.Backend; backend {
+	case "", "claude":
+	case "command":
+		if !hasPromptPlaceholder(cfg.Executor.Command) {
+			errs = append(errs, ValidationError{
+				Field:   "Executor.Command",
+				Tag:     "required_if",
+				Value:   cfg.Executor.Command,
+				Message: "'executor.command' must be set, with {prompt} in an argument, when 'executor.backend' is command",
+			})
+		}
+	default:
+		errs = append(errs, ValidationError{
+			Field:   "Executor.Backend",
+			Tag:     "oneof",
+			Value:   backend,
+			Message: fmt.Sprintf("'executor.backend' must be claude or command (got '%s')", backend),
+		})
+	}
+
+	if ri := cfg.Dashboard.RefreshInterval; ri != "" {
+		if d, err := time.ParseDuration(ri); err != nil || d < minDashboardRefreshInterval || d > maxDashboardRefreshInterval {
+			errs = append(errs, ValidationError{
+				Field:   "Dashboard.RefreshInterval",
+				Tag:     "duration",
+				Value:   ri,
+				Message: fmt.Sprintf("'dashboard.refresh_interval' must be a duration between %s and %s (got '%s')", minDashboardRefreshInterval, maxDashboardRefreshInterval, ri),
+			})
+		}
+	}
+
+	if si := cfg.Spawn.Interval; si != "" {
+		if d, err := time.ParseDuration(si); err != nil || d < 0 || d > maxSpawnInterval {
+			errs = append(errs, ValidationError{
+				Field:   "Spawn.Interval",
+				Tag:     "duration",
+				Value:   si,
+				Message: fmt.Sprintf("'spawn.interval' must be a duration between 0s and %s (got '%s')", maxSpawnInterval, si),
+			})
+		}
+	}
+
+	if pattern := cfg.ProgressPattern; pattern != "" {
+		if re, err := regexp.Compile(pattern); err != nil || re.NumSubexp() == 0 {
+			errs = append(errs, ValidationError{
+				Field:   "ProgressPattern",
+				Tag:     "regexp",
+				Value:   pattern,
+				Message: fmt.Sprintf("'progress_pattern' must be a regular expression with at least one capture group (got '%s')", pattern),
+			})
+		}
+	}
+
+	errs = append(errs, validateResources("Defaults.Resources", &cfg.Defaults.Resources)...)
+
+	names := make([]string, 0, len(cfg.Workstreams))
+	for name := range cfg.Workstreams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if ws := cfg.Workstreams[name]; ws != nil && ws.Resources != nil {
+			errs = append(errs, validateResources(fmt.Sprintf("Workstreams[%s].Resources", name), ws.Resources)...)
+		}
+	}
+
 	if len(errs) > 0 {
 		return errs
 	}
@@ -320,11 +763,73 @@ func (l *Loader) Validate(cfg *Config) error {
 	return nil
 }
 
+// taskIDSeqPattern matches the sequence placeholder in task_id_format.
+var taskIDSeqPattern = regexp.MustCompile(`\{seq(?::0?\d*d)?\}`)
+
+// memoryPattern matches Docker memory limits such as "512m", "4g", or "1024".
+var memoryPattern = regexp.MustCompile(`^\d+[kmgKMG]?[bB]?$`)
+
+// hasPromptPlaceholder reports whether a command backend's command line has
+// an executable and an argument for the prompt.
+func hasPromptPlaceholder(command []string) bool {
+	if len(command) == 0 || command[0] == "" {
+		return false
+	}
+	for _, arg := range command[1:] {
+		if strings.Contains(arg, "{prompt}") {
+			return true
+		}
+	}
+	return false
+}
+
+// validateResources checks that resource limits use Docker's syntax, so typos
+// are reported at load time rather than as a cryptic container creation error.
+// Empty values are left to the "required" struct tag.
+func validateResources(field string, r *ResourceConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	if r.Memory != "" && !ValidMemoryLimit(r.Memory) {
+		errs = append(errs, ValidationError{
+			Field:   field + ".Memory",
+			Tag:     "memory",
+			Value:   r.Memory,
+			Message: fmt.Sprintf("'%s.Memory' must be a Docker memory limit like 512m or 4g (got '%s')", field, r.Memory),
+		})
+	}
+
+	if r.CPUs != "" && !ValidCPULimit(r.CPUs) {
+		errs = append(errs, ValidationError{
+			Field:   field + ".CPUs",
+			Tag:     "cpus",
+			Value:   r.CPUs,
+			Message: fmt.Sprintf("'%s.CPUs' must be a positive number like 2 or 0.5 (got '%s')", field, r.CPUs),
+		})
+	}
+
+	return errs
+}
+
+// ValidMemoryLimit reports whether s is a Docker memory limit such as "512m"
+// or "4g". It is the check config validation uses, exported so limits given
+// elsewhere (such as spawn flags) are held to the same rules.
+func ValidMemoryLimit(s string) bool {
+	return memoryPattern.MatchString(s)
+}
+
+// ValidCPULimit reports whether s is a Docker CPU limit: a positive number
+// such as "2" or "0.5".
+func ValidCPULimit(s string) bool {
+	n, err := strconv.ParseFloat(s, 64)
+	return err == nil && n > 0 && !math.IsNaN(n) && !math.IsInf(n, 0)
+}
+
 func (l *Loader) setDefaults() {
 	defaults := DefaultConfig()
 
 	l.v.SetDefault("version", defaults.Version)
 	l.v.SetDefault("tasks_dir", defaults.TasksDir)
+	l.v.SetDefault("task_id_format", defaults.TaskIDFormat)
 	l.v.SetDefault("image.name", defaults.Image.Name)
 	l.v.SetDefault("image.tag", defaults.Image.Tag)
 	l.v.SetDefault("defaults.allowed_tools", defaults.Defaults.AllowedTools)
@@ -334,7 +839,49 @@ func (l *Loader) setDefaults() {
 	l.v.SetDefault("defaults.resources.cpus", defaults.Defaults.Resources.CPUs)
 	l.v.SetDefault("git.branch_prefix", defaults.Git.BranchPrefix)
 	l.v.SetDefault("git.auto_push", defaults.Git.AutoPush)
+	l.v.SetDefault("git.ssh_key_path", defaults.Git.SSHKeyPath)
+	l.v.SetDefault("git.forward_ssh_agent", defaults.Git.ForwardSSHAgent)
+	l.v.SetDefault("git.worktree_dir", defaults.Git.WorktreeDir)
 	l.v.SetDefault("network.name", defaults.Network.Name)
+	l.v.SetDefault("dashboard.max_logs", defaults.Dashboard.MaxLogs)
+	l.v.SetDefault("dashboard.refresh_interval", defaults.Dashboard.RefreshInterval)
+	l.v.SetDefault("spawn.max_concurrent", defaults.Spawn.MaxConcurrent)
+	l.v.SetDefault("spawn.interval", defaults.Spawn.Interval)
+	l.v.SetDefault("executor.backend", defaults.Executor.Backend)
+}
+
+// activeProfile returns the explicitly selected profile, or the value of
+// TANUKI_PROFILE if none was set.
+func (l *Loader) activeProfile() string {
+	if l.profile != "" {
+		return l.profile
+	}
+	return os.Getenv(ProfileEnvVar)
+}
+
+// applyProfile deep-merges the active profile's overrides into the loaded config.
+// Returns an error if a profile was requested but is not defined.
+func (l *Loader) applyProfile() error {
+	name := l.activeProfile()
+	if name == "" {
+		return nil
+	}
+
+	profiles := l.v.GetStringMap("profiles")
+	raw, ok := profiles[strings.ToLower(name)]
+	if !ok {
+		return fmt.Errorf("profile %q not found in config", name)
+	}
+
+	overrides, ok := raw.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("profile %q must be a map of config overrides", name)
+	}
+
+	if err := l.v.MergeConfigMap(overrides); err != nil {
+		return fmt.Errorf("failed to apply profile %q: %w", name, err)
+	}
+	return nil
 }
 
 func (l *Loader) loadConfigFile(path string) error {
@@ -394,8 +941,9 @@ func formatValidationError(e validator.FieldError) string {
 // DefaultConfig returns a new Config with sensible defaults.
 func DefaultConfig() *Config {
 	return &Config{
-		Version:  "1",
-		TasksDir: "tasks",
+		Version:      CurrentVersion,
+		TasksDir:     "tasks",
+		TaskIDFormat: "{project}-{seq:03d}",
 		Image: ImageConfig{
 			Name: "node",
 			Tag:  "22",
@@ -423,6 +971,17 @@ func DefaultConfig() *Config {
 		Network: NetworkConfig{
 			Name: "tanuki-net",
 		},
+		Dashboard: DashboardConfig{
+			MaxLogs:         DefaultDashboardMaxLogs,
+			RefreshInterval: DefaultDashboardRefreshInterval.String(),
+		},
+		Spawn: SpawnConfig{
+			MaxConcurrent: DefaultSpawnMaxConcurrent,
+			Interval:      DefaultSpawnInterval.String(),
+		},
+		Executor: ExecutorConfig{
+			Backend: "claude",
+		},
 	}
 }
 
@@ -472,6 +1031,19 @@ func FindProjectConfig() string {
 	return NewLoader().findProjectConfig()
 }
 
+// ExpandHome replaces a leading "~/" in path with the user's home directory.
+func ExpandHome(path string) (string, error) {
+	rest, ok := strings.CutPrefix(path, "~/")
+	if !ok {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	return filepath.Join(home, rest), nil
+}
+
 // GetWorkstreamConfig returns the configuration for a specific workstream.
 // Returns nil if no workstream-specific config is defined.
 func (c *Config) GetWorkstreamConfig(workstreamName string) *WorkstreamConfig {
@@ -481,9 +1053,97 @@ func (c *Config) GetWorkstreamConfig(workstreamName string) *WorkstreamConfig {
 	return c.Workstreams[workstreamName]
 }
 
-// GetWorkstreamConcurrency returns the concurrency for a specific workstream.
+// GetGlobalSystemPrompt returns the global system prompt: GlobalSystemPrompt
+// if set, else the contents of GlobalSystemPromptFile.
+func (c *Config) GetGlobalSystemPrompt() (string, error) {
+	return loadPrompt(c.GlobalSystemPrompt, c.GlobalSystemPromptFile, "global_system_prompt_file")
+}
+
+// loadPrompt returns inline if set, else the trimmed contents of file.
+// Relative file paths are resolved from the current directory, the project
+// root. field names the setting in errors.
+func loadPrompt(inline, file, field string) (string, error) {
+	if inline != "" || file == "" {
+		return inline, nil
+	}
+	data, err := os.ReadFile(file) //nolint:gosec // G304: Prompt file path is user-provided config
+	if err != nil {
+		return "", fmt.Errorf("read %s: %w", field, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// DefaultTaskEstimate is the estimate assumed for tasks without one when
+// default_estimate isn't set.
+const DefaultTaskEstimate = 30 * time.Minute
+
+// GetDefaultEstimate returns the default task estimate, falling back to
+// DefaultTaskEstimate when unset or invalid.
+func (c *Config) GetDefaultEstimate() time.Duration {
+	if c.DefaultEstimate != "" {
+		if d, err := time.ParseDuration(c.DefaultEstimate); err == nil && d > 0 {
+			return d
+		}
+	}
+	return DefaultTaskEstimate
+}
+
+// GetDashboardMaxLogs returns the logs pane scrollback, defaulting to 1000 lines.
+func (c *Config) GetDashboardMaxLogs() int {
+	if c.Dashboard.MaxLogs > 0 {
+		return c.Dashboard.MaxLogs
+	}
+	return DefaultDashboardMaxLogs
+}
+
+// GetDashboardRefreshInterval returns how often the dashboard refreshes,
+// defaulting to one second.
+func (c *Config) GetDashboardRefreshInterval() time.Duration {
+	if c.Dashboard.RefreshInterval != "" {
+		if d, err := time.ParseDuration(c.Dashboard.RefreshInterval); err == nil && d > 0 {
+			return d
+		}
+	}
+	return DefaultDashboardRefreshInterval
+}
+
+// GetSpawnMaxConcurrent returns how many agents may be created at once,
+// defaulting to 2.
+func (c *Config) GetSpawnMaxConcurrent() int {
+	if c.Spawn.MaxConcurrent > 0 {
+		return c.Spawn.MaxConcurrent
+	}
+	return DefaultSpawnMaxConcurrent
+}
+
+// GetSpawnInterval returns the minimum time between agent creations,
+// defaulting to 500ms.
+func (c *Config) GetSpawnInterval() time.Duration {
+	if c.Spawn.Interval != "" {
+		if d, err := time.ParseDuration(c.Spawn.Interval); err == nil && d >= 0 {
+			return d
+		}
+	}
+	return DefaultSpawnInterval
+}
+
+// GetWorkstreamConcurrency returns the agent concurrency for a specific workstream.
 // Returns 1 (default) if the workstream has no specific config.
 func (c *Config) GetWorkstreamConcurrency(workstreamName string) int {
 	wc := c.GetWorkstreamConfig(workstreamName)
 	return wc.GetConcurrency()
 }
+
+// GetWorkstreamTaskConcurrency returns the task concurrency for a specific
+// workstream. Returns 0 (no limit beyond agents) if none is configured.
+func (c *Config) GetWorkstreamTaskConcurrency(workstreamName string) int {
+	wc := c.GetWorkstreamConfig(workstreamName)
+	return wc.GetMaxConcurrentTasks()
+}
+
+// GetWorkstreamMaxRetries returns the retry limit for failed tasks in a workstream.
+// Returns 1 (default) if the workstream has no specific config.
+func (c *Config) GetWorkstreamMaxRetries(workstreamName string) int {
+	wc := c.GetWorkstreamConfig(workstreamName)
+	return wc.GetMaxRetries()
+}
@@ -1,9 +1,13 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -75,6 +79,64 @@ func TestWriteAndLoad(t *testing.T) {
 	}
 }
 
+func TestLoadFromPath_NotFound(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.yaml")
+
+	_, err := NewLoader().LoadFromPath(path)
+	if err == nil {
+		t.Fatal("expected error for missing config file")
+	}
+	if !strings.Contains(err.Error(), "config file not found") {
+		t.Errorf("error = %q, want it to mention the missing file", err)
+	}
+}
+
+func TestLoadFromPath_AppliesDefaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "alt.yaml")
+	if err := os.WriteFile(path, []byte("version: \"1\"\ndefaults:\n  max_turns: 7\n"), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := NewLoader().LoadFromPath(path)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if cfg.Defaults.MaxTurns != 7 {
+		t.Errorf("expected max_turns 7, got %d", cfg.Defaults.MaxTurns)
+	}
+	if cfg.Image.Name != DefaultConfig().Image.Name {
+		t.Errorf("expected default image name %q, got %q", DefaultConfig().Image.Name, cfg.Image.Name)
+	}
+}
+
+func TestLoader_Settings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tanuki.yaml")
+	if err := os.WriteFile(path, []byte("version: \"1\"\ndefaults:\n  max_turns: 7\n"), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	loader := NewLoader()
+	loader.SetOverride("tasks_dir", "work")
+	settings, err := loader.Settings(path)
+	if err != nil {
+		t.Fatalf("Settings() error = %v", err)
+	}
+
+	if settings["tasks_dir"] != "work" {
+		t.Errorf("tasks_dir = %v, want the override", settings["tasks_dir"])
+	}
+	defaults, _ := settings["defaults"].(map[string]interface{})
+	if defaults["max_turns"] != 7 {
+		t.Errorf("defaults.max_turns = %v, want 7 from the file", defaults["max_turns"])
+	}
+	if _, ok := defaults["model"]; ok {
+		t.Error("defaults.model set; Settings() shouldn't include defaults")
+	}
+	if _, ok := settings["image"]; ok {
+		t.Error("image set; Settings() shouldn't include defaults")
+	}
+}
+
 func TestLoadWithOverrides(t *testing.T) {
 	// Create temp directory
 	tmpDir, err := os.MkdirTemp("", "tanuki-config-test")
@@ -161,6 +223,200 @@ func TestValidation(t *testing.T) {
 			expectError: true,
 			errorField:  "Name",
 		},
+		{
+			name: "task_id_format without seq",
+			modify: func(c *Config) {
+				c.TaskIDFormat = "{project}-task"
+			},
+			expectError: true,
+			errorField:  "TaskIDFormat",
+		},
+		{
+			name: "task_id_format with two seqs",
+			modify: func(c *Config) {
+				c.TaskIDFormat = "{seq}-{seq:03d}"
+			},
+			expectError: true,
+			errorField:  "TaskIDFormat",
+		},
+		{
+			name: "task_id_format unpadded",
+			modify: func(c *Config) {
+				c.TaskIDFormat = "T{seq}"
+			},
+			expectError: false,
+		},
+		{
+			name: "extra_task_dirs",
+			modify: func(c *Config) {
+				c.ExtraTaskDirs = []string{"../shared-tasks", "/opt/tasks"}
+			},
+			expectError: false,
+		},
+		{
+			name: "extra_task_dirs repeats tasks_dir",
+			modify: func(c *Config) {
+				c.ExtraTaskDirs = []string{"./tasks"}
+			},
+			expectError: true,
+			errorField:  "ExtraTaskDirs[0]",
+		},
+		{
+			name: "extra_task_dirs empty entry",
+			modify: func(c *Config) {
+				c.ExtraTaskDirs = []string{""}
+			},
+			expectError: true,
+			errorField:  "ExtraTaskDirs[0]",
+		},
+		{
+			name: "dashboard max_logs too small",
+			modify: func(c *Config) {
+				c.Dashboard.MaxLogs = 50
+			},
+			expectError: true,
+			errorField:  "MaxLogs",
+		},
+		{
+			name: "dashboard max_logs larger scrollback",
+			modify: func(c *Config) {
+				c.Dashboard.MaxLogs = 20000
+			},
+			expectError: false,
+		},
+		{
+			name: "dashboard refresh_interval too fast",
+			modify: func(c *Config) {
+				c.Dashboard.RefreshInterval = "10ms"
+			},
+			expectError: true,
+			errorField:  "Dashboard.RefreshInterval",
+		},
+		{
+			name: "dashboard refresh_interval not a duration",
+			modify: func(c *Config) {
+				c.Dashboard.RefreshInterval = "fast"
+			},
+			expectError: true,
+			errorField:  "Dashboard.RefreshInterval",
+		},
+		{
+			name: "dashboard refresh_interval slower",
+			modify: func(c *Config) {
+				c.Dashboard.RefreshInterval = "5s"
+			},
+			expectError: false,
+		},
+		{
+			name: "spawn interval negative",
+			modify: func(c *Config) {
+				c.Spawn.Interval = "-1s"
+			},
+			expectError: true,
+			errorField:  "Spawn.Interval",
+		},
+		{
+			name: "spawn interval disabled",
+			modify: func(c *Config) {
+				c.Spawn.Interval = "0s"
+			},
+			expectError: false,
+		},
+		{
+			name: "progress_pattern without groups",
+			modify: func(c *Config) {
+				c.ProgressPattern = `Step \d+`
+			},
+			expectError: true,
+			errorField:  "ProgressPattern",
+		},
+		{
+			name: "progress_pattern custom",
+			modify: func(c *Config) {
+				c.ProgressPattern = `Step (\d+) of (\d+)`
+			},
+			expectError: false,
+		},
+		{
+			name: "spawn max_concurrent too high",
+			modify: func(c *Config) {
+				c.Spawn.MaxConcurrent = 50
+			},
+			expectError: true,
+			errorField:  "Spawn.MaxConcurrent",
+		},
+		{
+			name: "global system prompt inline and file",
+			modify: func(c *Config) {
+				c.GlobalSystemPrompt = "Follow the style guide."
+				c.GlobalSystemPromptFile = "prompts/global.md"
+			},
+			expectError: true,
+			errorField:  "GlobalSystemPromptFile",
+		},
+		{
+			name: "executor command backend",
+			modify: func(c *Config) {
+				c.Executor.Backend = "command"
+				c.Executor.Command = []string{"aider", "--yes", "--message", "{prompt}"}
+			},
+			expectError: false,
+		},
+		{
+			name: "executor command backend without prompt",
+			modify: func(c *Config) {
+				c.Executor.Backend = "command"
+				c.Executor.Command = []string{"aider", "--yes"}
+			},
+			expectError: true,
+			errorField:  "Executor.Command",
+		},
+		{
+			name: "executor unknown backend",
+			modify: func(c *Config) {
+				c.Executor.Backend = "gpt"
+			},
+			expectError: true,
+			errorField:  "Executor.Backend",
+		},
+		{
+			name: "group_failure fail",
+			modify: func(c *Config) {
+				c.GroupFailure = "fail"
+			},
+			expectError: false,
+		},
+		{
+			name: "group_failure unknown policy",
+			modify: func(c *Config) {
+				c.GroupFailure = "cancel"
+			},
+			expectError: true,
+			errorField:  "GroupFailure",
+		},
+		{
+			name: "ralph cooldown",
+			modify: func(c *Config) {
+				c.Ralph.Cooldown = "5s"
+			},
+			expectError: false,
+		},
+		{
+			name: "ralph cooldown invalid",
+			modify: func(c *Config) {
+				c.Ralph.Cooldown = "soon"
+			},
+			expectError: true,
+			errorField:  "Ralph.Cooldown",
+		},
+		{
+			name: "ralph max_iterations too high",
+			modify: func(c *Config) {
+				c.Ralph.MaxIterations = 1001
+			},
+			expectError: true,
+			errorField:  "MaxIterations",
+		},
 	}
 
 	for _, tt := range tests {
@@ -182,6 +438,76 @@ func TestValidation(t *testing.T) {
 	}
 }
 
+func TestValidateResources(t *testing.T) {
+	tests := []struct {
+		name       string
+		memory     string
+		cpus       string
+		wantFields []string
+	}{
+		{name: "valid", memory: "4g", cpus: "2"},
+		{name: "fractional cpus", memory: "512m", cpus: "0.5"},
+		{name: "plain bytes", memory: "1073741824", cpus: "1"},
+		// Docker accepts an optional trailing b, so "4gb" is fine
+		{name: "byte suffix", memory: "4gb", cpus: "1"},
+		{name: "spaced unit", memory: "4 GB", cpus: "2", wantFields: []string{"Defaults.Resources.Memory"}},
+		{name: "fractional memory", memory: "1.5g", cpus: "2", wantFields: []string{"Defaults.Resources.Memory"}},
+		{name: "binary unit", memory: "4GiB", cpus: "2", wantFields: []string{"Defaults.Resources.Memory"}},
+		{name: "unknown cpu suffix", memory: "4g", cpus: "2.5x", wantFields: []string{"Defaults.Resources.CPUs"}},
+		{name: "zero cpus", memory: "4g", cpus: "0", wantFields: []string{"Defaults.Resources.CPUs"}},
+		{name: "negative cpus", memory: "4g", cpus: "-1", wantFields: []string{"Defaults.Resources.CPUs"}},
+		{name: "empty", memory: "", cpus: "", wantFields: []string{"Defaults.Resources.Memory", "Defaults.Resources.CPUs"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Defaults.Resources = ResourceConfig{Memory: tt.memory, CPUs: tt.cpus}
+
+			err := NewLoader().Validate(cfg)
+			if len(tt.wantFields) == 0 {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+
+			var errs ValidationErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("Validate() = %v, want ValidationErrors", err)
+			}
+			if len(errs) != len(tt.wantFields) {
+				t.Fatalf("Validate() returned %d errors (%v), want %d", len(errs), errs, len(tt.wantFields))
+			}
+			for i, want := range tt.wantFields {
+				if !strings.HasSuffix(errs[i].Field, want) {
+					t.Errorf("errs[%d].Field = %q, want suffix %q", i, errs[i].Field, want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateResources_Workstream(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Workstreams = map[string]*WorkstreamConfig{
+		"backend": {Resources: &ResourceConfig{Memory: "8gigs", CPUs: "4"}},
+	}
+
+	err := NewLoader().Validate(cfg)
+
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 1 {
+		t.Fatalf("Validate() = %v, want one ValidationError", err)
+	}
+	if errs[0].Field != "Workstreams[backend].Resources.Memory" {
+		t.Errorf("Field = %q, want Workstreams[backend].Resources.Memory", errs[0].Field)
+	}
+	if !strings.Contains(errs[0].Message, "8gigs") {
+		t.Errorf("Message = %q, want it to mention the offending value", errs[0].Message)
+	}
+}
+
 func TestValidationErrorMessages(t *testing.T) {
 	cfg := DefaultConfig()
 	cfg.Version = "2" // Invalid
@@ -397,3 +723,232 @@ network:
 		t.Errorf("expected dockerfile 'Dockerfile.tanuki', got '%s'", cfg.Image.Build.Dockerfile)
 	}
 }
+
+func TestEnvOverrides(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "tanuki.yaml")
+	fileConfig := `version: "1"
+defaults:
+  model: "claude-haiku-4-5-20251001"
+  max_turns: 50
+network:
+  name: "file-net"
+`
+	if err := os.WriteFile(configPath, []byte(fileConfig), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	t.Setenv("TANUKI_DEFAULTS_MODEL", "claude-sonnet-4-5-20250929")
+	t.Setenv("TANUKI_DEFAULTS_MAX_TURNS", "75")
+	t.Setenv("TANUKI_NETWORK_NAME", "env-net")
+	t.Setenv("TANUKI_DEFAULTS_ALLOWED_TOOLS", "Read,Grep")
+
+	t.Run("env wins over file values", func(t *testing.T) {
+		cfg, err := NewLoader().LoadFromPath(configPath)
+		if err != nil {
+			t.Fatalf("failed to load config: %v", err)
+		}
+		if cfg.Defaults.Model != "claude-sonnet-4-5-20250929" {
+			t.Errorf("model = %q, want the TANUKI_DEFAULTS_MODEL value", cfg.Defaults.Model)
+		}
+		if cfg.Defaults.MaxTurns != 75 {
+			t.Errorf("max_turns = %d, want the TANUKI_DEFAULTS_MAX_TURNS value 75", cfg.Defaults.MaxTurns)
+		}
+		if cfg.Network.Name != "env-net" {
+			t.Errorf("network name = %q, want the TANUKI_NETWORK_NAME value", cfg.Network.Name)
+		}
+		if want := []string{"Read", "Grep"}; !reflect.DeepEqual(cfg.Defaults.AllowedTools, want) {
+			t.Errorf("allowed_tools = %v, want %v from TANUKI_DEFAULTS_ALLOWED_TOOLS", cfg.Defaults.AllowedTools, want)
+		}
+	})
+
+	t.Run("CLI overrides win over env", func(t *testing.T) {
+		loader := NewLoader()
+		loader.SetOverride("defaults.model", "claude-opus-4-1-20250805")
+		loader.SetOverride("network.name", "flag-net")
+
+		cfg, err := loader.LoadFromPath(configPath)
+		if err != nil {
+			t.Fatalf("failed to load config: %v", err)
+		}
+		if cfg.Defaults.Model != "claude-opus-4-1-20250805" {
+			t.Errorf("model = %q, want the CLI override", cfg.Defaults.Model)
+		}
+		if cfg.Network.Name != "flag-net" {
+			t.Errorf("network name = %q, want the CLI override", cfg.Network.Name)
+		}
+		if cfg.Defaults.MaxTurns != 75 {
+			t.Errorf("max_turns = %d, want the env value 75 where no override is set", cfg.Defaults.MaxTurns)
+		}
+	})
+}
+
+func TestProfileMergePrecedence(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "tanuki-config-test")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	profileConfig := `version: "1"
+defaults:
+  max_turns: 50
+  model: "claude-haiku-4-5-20251001"
+  resources:
+    memory: "4g"
+    cpus: "2"
+network:
+  name: "tanuki-net"
+profiles:
+  ci:
+    defaults:
+      max_turns: 200
+      model: "claude-sonnet-4-5-20250929"
+      resources:
+        memory: "8g"
+`
+
+	configPath := filepath.Join(tmpDir, "tanuki.yaml")
+	if err = os.WriteFile(configPath, []byte(profileConfig), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	t.Run("no profile uses file values", func(t *testing.T) {
+		t.Setenv(ProfileEnvVar, "")
+
+		cfg, err := NewLoader().LoadFromPath(configPath)
+		if err != nil {
+			t.Fatalf("failed to load config: %v", err)
+		}
+		if cfg.Defaults.MaxTurns != 50 {
+			t.Errorf("expected max_turns 50, got %d", cfg.Defaults.MaxTurns)
+		}
+		if len(cfg.Profiles) != 1 {
+			t.Errorf("expected 1 profile, got %d", len(cfg.Profiles))
+		}
+	})
+
+	t.Run("profile overrides file and keeps unset fields", func(t *testing.T) {
+		loader := NewLoader()
+		loader.SetProfile("ci")
+
+		cfg, err := loader.LoadFromPath(configPath)
+		if err != nil {
+			t.Fatalf("failed to load config: %v", err)
+		}
+		if cfg.Defaults.MaxTurns != 200 {
+			t.Errorf("expected profile max_turns 200, got %d", cfg.Defaults.MaxTurns)
+		}
+		if cfg.Defaults.Model != "claude-sonnet-4-5-20250929" {
+			t.Errorf("expected profile model, got %q", cfg.Defaults.Model)
+		}
+		if cfg.Defaults.Resources.Memory != "8g" {
+			t.Errorf("expected profile memory '8g', got %q", cfg.Defaults.Resources.Memory)
+		}
+		if cfg.Defaults.Resources.CPUs != "2" {
+			t.Errorf("expected file cpus '2' to survive deep merge, got %q", cfg.Defaults.Resources.CPUs)
+		}
+	})
+
+	t.Run("CLI overrides beat profile", func(t *testing.T) {
+		loader := NewLoader()
+		loader.SetProfile("ci")
+		loader.SetOverride("defaults.max_turns", 300)
+
+		cfg, err := loader.LoadFromPath(configPath)
+		if err != nil {
+			t.Fatalf("failed to load config: %v", err)
+		}
+		if cfg.Defaults.MaxTurns != 300 {
+			t.Errorf("expected override max_turns 300, got %d", cfg.Defaults.MaxTurns)
+		}
+		if cfg.Defaults.Model != "claude-sonnet-4-5-20250929" {
+			t.Errorf("expected profile model, got %q", cfg.Defaults.Model)
+		}
+	})
+
+	t.Run("profile selected from environment", func(t *testing.T) {
+		t.Setenv(ProfileEnvVar, "ci")
+
+		cfg, err := NewLoader().LoadFromPath(configPath)
+		if err != nil {
+			t.Fatalf("failed to load config: %v", err)
+		}
+		if cfg.Defaults.MaxTurns != 200 {
+			t.Errorf("expected profile max_turns 200, got %d", cfg.Defaults.MaxTurns)
+		}
+	})
+
+	t.Run("unknown profile is an error", func(t *testing.T) {
+		loader := NewLoader()
+		loader.SetProfile("staging")
+
+		if _, err := loader.LoadFromPath(configPath); err == nil {
+			t.Error("expected error for unknown profile")
+		}
+	})
+}
+
+func TestConfig_DashboardGetters(t *testing.T) {
+	cfg := &Config{}
+	if got := cfg.GetDashboardMaxLogs(); got != DefaultDashboardMaxLogs {
+		t.Errorf("GetDashboardMaxLogs() = %d, want %d", got, DefaultDashboardMaxLogs)
+	}
+	if got := cfg.GetDashboardRefreshInterval(); got != DefaultDashboardRefreshInterval {
+		t.Errorf("GetDashboardRefreshInterval() = %v, want %v", got, DefaultDashboardRefreshInterval)
+	}
+
+	cfg.Dashboard = DashboardConfig{MaxLogs: 5000, RefreshInterval: "3s"}
+	if got := cfg.GetDashboardMaxLogs(); got != 5000 {
+		t.Errorf("GetDashboardMaxLogs() = %d, want 5000", got)
+	}
+	if got := cfg.GetDashboardRefreshInterval(); got != 3*time.Second {
+		t.Errorf("GetDashboardRefreshInterval() = %v, want 3s", got)
+	}
+}
+
+func TestConfig_SpawnGetters(t *testing.T) {
+	cfg := &Config{}
+	if got := cfg.GetSpawnMaxConcurrent(); got != DefaultSpawnMaxConcurrent {
+		t.Errorf("GetSpawnMaxConcurrent() = %d, want %d", got, DefaultSpawnMaxConcurrent)
+	}
+	if got := cfg.GetSpawnInterval(); got != DefaultSpawnInterval {
+		t.Errorf("GetSpawnInterval() = %v, want %v", got, DefaultSpawnInterval)
+	}
+
+	cfg.Spawn = SpawnConfig{MaxConcurrent: 4, Interval: "0s"}
+	if got := cfg.GetSpawnMaxConcurrent(); got != 4 {
+		t.Errorf("GetSpawnMaxConcurrent() = %d, want 4", got)
+	}
+	if got := cfg.GetSpawnInterval(); got != 0 {
+		t.Errorf("GetSpawnInterval() = %v, want 0 to disable the stagger", got)
+	}
+}
+
+func TestAgentDefaults_GetMaxOutputBytes(t *testing.T) {
+	defaults := AgentDefaults{}
+	if got := defaults.GetMaxOutputBytes(); got != DefaultMaxOutputBytes {
+		t.Errorf("GetMaxOutputBytes() = %d, want default %d", got, DefaultMaxOutputBytes)
+	}
+
+	defaults.MaxOutputBytes = 4096
+	if got := defaults.GetMaxOutputBytes(); got != 4096 {
+		t.Errorf("GetMaxOutputBytes() = %d, want 4096", got)
+	}
+}
+
+func TestAgentDefaults_GetMaxContinuations(t *testing.T) {
+	defaults := AgentDefaults{MaxContinuations: 5}
+	if got := defaults.GetMaxContinuations(); got != 0 {
+		t.Errorf("GetMaxContinuations() = %d, want 0 without auto_continue_on_turn_limit", got)
+	}
+
+	defaults.AutoContinueOnTurnLimit = true
+	if got := defaults.GetMaxContinuations(); got != 5 {
+		t.Errorf("GetMaxContinuations() = %d, want 5", got)
+	}
+
+	defaults.MaxContinuations = 0
+	if got := defaults.GetMaxContinuations(); got != 2 {
+		t.Errorf("GetMaxContinuations() = %d, want default 2", got)
+	}
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/spf13/viper"
+)
+
+// CurrentVersion is the config schema version this build of Tanuki reads.
+// Older configs are upgraded to it by Migrate when they're loaded.
+const CurrentVersion = "1"
+
+// migration upgrades config settings from one schema version to the next.
+type migration struct {
+	from  string
+	to    string
+	apply func(settings map[string]interface{}) error
+}
+
+// migrations are applied in order to bring a config up to CurrentVersion.
+// When the schema changes, bump CurrentVersion and add a step here that
+// rewrites the old keys, so existing tanuki.yaml files keep loading.
+var migrations = []migration{
+	// Version 0 is an explicit "version: 0", from before the field was
+	// checked. Its schema is the same as version 1.
+	{from: "0", to: "1", apply: func(map[string]interface{}) error { return nil }},
+}
+
+// Migrate upgrades settings, keyed as in tanuki.yaml, from their version to
+// CurrentVersion, then decodes them over DefaultConfig. A missing version is
+// taken to be current. Each step applied is logged. The result isn't
+// validated.
+func Migrate(settings map[string]interface{}) (*Config, error) {
+	if err := migrate(settings, migrations); err != nil {
+		return nil, err
+	}
+
+	v := viper.New()
+	if err := v.MergeConfigMap(settings); err != nil {
+		return nil, fmt.Errorf("failed to read migrated config: %w", err)
+	}
+	cfg := DefaultConfig()
+	if err := v.Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+	return cfg, nil
+}
+
+// migrate applies steps to settings in place until its version is
+// CurrentVersion.
+func migrate(settings map[string]interface{}, steps []migration) error {
+	version := settingsVersion(settings)
+	if version == "" {
+		settings["version"] = CurrentVersion
+		return nil
+	}
+
+	for version != CurrentVersion {
+		step, ok := findMigration(steps, version)
+		if !ok {
+			if newerVersion(version) {
+				return fmt.Errorf("config version %s is newer than this version of tanuki supports (%s); upgrade tanuki", version, CurrentVersion)
+			}
+			return fmt.Errorf("config version %s can't be upgraded to version %s", version, CurrentVersion)
+		}
+
+		if err := step.apply(settings); err != nil {
+			return fmt.Errorf("migrate config from version %s to %s: %w", step.from, step.to, err)
+		}
+		settings["version"] = step.to
+		log.Printf("Migrated config from version %s to %s", step.from, step.to)
+		version = step.to
+	}
+	return nil
+}
+
+// settingsVersion returns the version setting as a string, since YAML reads
+// "version: 1" as a number.
+func settingsVersion(settings map[string]interface{}) string {
+	switch v := settings["version"].(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func findMigration(steps []migration, from string) (migration, bool) {
+	for _, step := range steps {
+		if step.from == from {
+			return step, true
+		}
+	}
+	return migration{}, false
+}
+
+// newerVersion reports whether version is a number above CurrentVersion.
+func newerVersion(version string) bool {
+	n, err := strconv.Atoi(version)
+	if err != nil {
+		return false
+	}
+	current, _ := strconv.Atoi(CurrentVersion)
+	return n > current
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMigrate(t *testing.T) {
+	tests := []struct {
+		name    string
+		version interface{}
+		wantErr string
+	}{
+		{"current version", "1", ""},
+		{"version read as a number", 1, ""},
+		{"missing version", nil, ""},
+		{"version 0", "0", ""},
+		{"newer version", 2, "newer than this version of tanuki supports"},
+		{"unknown version", "beta", "can't be upgraded"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := map[string]interface{}{"tasks_dir": "work"}
+			if tt.version != nil {
+				settings["version"] = tt.version
+			}
+
+			cfg, err := Migrate(settings)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Migrate() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Migrate() error = %v", err)
+			}
+			if cfg.Version != CurrentVersion {
+				t.Errorf("Version = %q, want %q", cfg.Version, CurrentVersion)
+			}
+			if cfg.TasksDir != "work" {
+				t.Errorf("TasksDir = %q, want work", cfg.TasksDir)
+			}
+			if cfg.Image.Name != DefaultConfig().Image.Name {
+				t.Errorf("Image.Name = %q, want the default", cfg.Image.Name)
+			}
+		})
+	}
+}
+
+func TestMigrate_AppliesSteps(t *testing.T) {
+	steps := []migration{
+		{from: "0", to: "1", apply: func(settings map[string]interface{}) error {
+			settings["tasks_dir"] = settings["task_dir"]
+			delete(settings, "task_dir")
+			return nil
+		}},
+	}
+	settings := map[string]interface{}{"version": 0, "task_dir": "work"}
+
+	if err := migrate(settings, steps); err != nil {
+		t.Fatalf("migrate() error = %v", err)
+	}
+	if settings["version"] != "1" || settings["tasks_dir"] != "work" {
+		t.Errorf("settings = %v, want version 1 with task_dir renamed", settings)
+	}
+}
+
+func TestLoadFromPath_MigratesOldVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "old.yaml")
+	if err := os.WriteFile(path, []byte("version: 0\ndefaults:\n  max_turns: 7\n"), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := NewLoader().LoadFromPath(path)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if cfg.Version != CurrentVersion || cfg.Defaults.MaxTurns != 7 {
+		t.Errorf("Version = %q, MaxTurns = %d, want %s and 7", cfg.Version, cfg.Defaults.MaxTurns, CurrentVersion)
+	}
+}
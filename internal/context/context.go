@@ -33,17 +33,22 @@ type CopyResult struct {
 	// Skipped lists patterns that had no matches
 	Skipped []string
 
+	// Ignored lists files that matched a pattern but are excluded by .tanukiignore
+	Ignored []string
+
 	// Errors lists any errors encountered during copying
 	Errors []error
 }
 
 // CopyContextFiles copies context files to the agent's worktree.
 // Files are placed in .tanuki/context/ within the worktree, maintaining
-// their relative path structure from the project root.
+// their relative path structure from the project root. Files excluded by
+// the project's .tanukiignore are never copied.
 func (m *Manager) CopyContextFiles(worktreePath string, contextPatterns []string) (*CopyResult, error) {
 	result := &CopyResult{
 		Copied:  make([]string, 0),
 		Skipped: make([]string, 0),
+		Ignored: make([]string, 0),
 		Errors:  make([]error, 0),
 	}
 
@@ -51,6 +56,12 @@ func (m *Manager) CopyContextFiles(worktreePath string, contextPatterns []string
 		return result, nil
 	}
 
+	// Fail rather than risk copying files the ignore file was meant to protect
+	ignore, err := LoadIgnoreFile(m.projectRoot)
+	if err != nil {
+		return nil, err
+	}
+
 	contextDir := filepath.Join(worktreePath, ".tanuki", "context")
 	if err := os.MkdirAll(contextDir, 0750); err != nil {
 		return nil, fmt.Errorf("create context directory: %w", err)
@@ -75,6 +86,11 @@ func (m *Manager) CopyContextFiles(worktreePath string, contextPatterns []string
 				continue
 			}
 
+			if ignore.Match(relPath) {
+				result.Ignored = append(result.Ignored, relPath)
+				continue
+			}
+
 			dstPath := filepath.Join(contextDir, relPath)
 
 			if err := m.copyFile(srcPath, dstPath); err != nil {
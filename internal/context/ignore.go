@@ -0,0 +1,184 @@
+package context
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// IgnoreFileName is the file in the project root that lists paths agents
+// should never receive as context. It uses gitignore syntax.
+const IgnoreFileName = ".tanukiignore"
+
+// ignoreRule is a single parsed line of a .tanukiignore file.
+type ignoreRule struct {
+	segments []string // pattern split on "/"
+	negate   bool     // line started with "!"
+	dirOnly  bool     // line ended with "/"
+	anchored bool     // pattern contained a "/" so it matches from the root
+}
+
+// IgnoreMatcher reports whether project-relative paths are excluded by a
+// .tanukiignore file. The zero value ignores nothing.
+type IgnoreMatcher struct {
+	rules    []ignoreRule
+	patterns []string
+}
+
+// ParseIgnore parses gitignore-style rules from r.
+//
+// Supported syntax: blank lines and "#" comments, "!" negation, a trailing
+// "/" to match only directories, a leading or inner "/" to anchor the pattern
+// to the project root, "*", "?", "[...]" globs, and "**" for any number of
+// directories. As in git, the last matching rule wins and a file cannot be
+// re-included if one of its parent directories is excluded.
+func ParseIgnore(r io.Reader) (*IgnoreMatcher, error) {
+	m := &IgnoreMatcher{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule := ignoreRule{}
+		pattern := line
+		switch {
+		case strings.HasPrefix(pattern, "!"):
+			rule.negate = true
+			pattern = pattern[1:]
+		case strings.HasPrefix(pattern, `\!`), strings.HasPrefix(pattern, `\#`):
+			pattern = pattern[1:]
+		}
+
+		if strings.HasSuffix(pattern, "/") {
+			rule.dirOnly = true
+			pattern = strings.TrimRight(pattern, "/")
+		}
+		if strings.Contains(pattern, "/") {
+			rule.anchored = true
+			pattern = strings.TrimPrefix(pattern, "/")
+		}
+		if pattern == "" {
+			continue
+		}
+
+		rule.segments = strings.Split(pattern, "/")
+		for _, seg := range rule.segments {
+			if _, err := path.Match(seg, ""); err != nil {
+				return nil, fmt.Errorf("invalid pattern %q: %w", line, err)
+			}
+		}
+
+		m.rules = append(m.rules, rule)
+		m.patterns = append(m.patterns, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read ignore rules: %w", err)
+	}
+
+	return m, nil
+}
+
+// LoadIgnoreFile reads the .tanukiignore file from the project root.
+// A missing file is not an error and yields a matcher that ignores nothing.
+func LoadIgnoreFile(projectRoot string) (*IgnoreMatcher, error) {
+	f, err := os.Open(filepath.Join(projectRoot, IgnoreFileName)) //nolint:gosec // G304: path is constructed from projectRoot which is trusted
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &IgnoreMatcher{}, nil
+		}
+		return nil, fmt.Errorf("open %s: %w", IgnoreFileName, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	m, err := ParseIgnore(f)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", IgnoreFileName, err)
+	}
+	return m, nil
+}
+
+// Patterns returns the rules as written in the ignore file, for display.
+func (m *IgnoreMatcher) Patterns() []string {
+	if m == nil {
+		return nil
+	}
+	return m.patterns
+}
+
+// Match reports whether the project-relative file path is ignored.
+func (m *IgnoreMatcher) Match(relPath string) bool {
+	if m == nil || len(m.rules) == 0 {
+		return false
+	}
+
+	parts := strings.Split(path.Clean(filepath.ToSlash(relPath)), "/")
+
+	// An excluded parent directory excludes everything beneath it
+	for i := 1; i < len(parts); i++ {
+		if m.matchPath(parts[:i], true) {
+			return true
+		}
+	}
+
+	return m.matchPath(parts, false)
+}
+
+// matchPath applies the rules in order to a single path; the last match wins.
+func (m *IgnoreMatcher) matchPath(parts []string, isDir bool) bool {
+	ignored := false
+	for _, rule := range m.rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		if rule.matches(parts) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+// matches reports whether the rule's pattern matches the path segments.
+func (r ignoreRule) matches(parts []string) bool {
+	if r.anchored {
+		return matchSegments(r.segments, parts)
+	}
+	// Unanchored patterns match the last path component at any depth
+	ok, _ := path.Match(r.segments[0], parts[len(parts)-1])
+	return ok
+}
+
+// matchSegments matches glob segments against path segments, treating "**"
+// as zero or more whole segments.
+func matchSegments(pattern, parts []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			rest := pattern[1:]
+			if len(rest) == 0 {
+				// A trailing "**" matches everything inside, but not the directory itself
+				return len(parts) > 0
+			}
+			for i := 0; i <= len(parts); i++ {
+				if matchSegments(rest, parts[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(parts) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], parts[0]); !ok {
+			return false
+		}
+		pattern, parts = pattern[1:], parts[1:]
+	}
+	return len(parts) == 0
+}
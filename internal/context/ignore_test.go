@@ -0,0 +1,118 @@
+package context
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIgnoreMatcher_Match(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules string
+		path  string
+		want  bool
+	}{
+		{"no rules", "", ".env", false},
+		{"exact name", ".env", ".env", true},
+		{"name at any depth", ".env", "services/api/.env", true},
+		{"star suffix", "*.log", "logs/app.log", true},
+		{"star no match", "*.log", "logs/app.txt", false},
+		{"name glob prefix", ".env.*", ".env.local", true},
+		{"question mark", "file?.md", "docs/file1.md", true},
+		{"character class", "secret[0-9].txt", "secret7.txt", true},
+		{"comment ignored", "# .env", ".env", false},
+		{"escaped hash", `\#notes`, "#notes", true},
+		{"directory rule excludes contents", "build/", "build/out/app.js", true},
+		{"directory rule skips files", "build/", "build", false},
+		{"anchored root only", "/config.yaml", "config.yaml", true},
+		{"anchored not nested", "/config.yaml", "sub/config.yaml", false},
+		{"inner slash anchors", "docs/private.md", "docs/private.md", true},
+		{"inner slash not nested", "docs/private.md", "x/docs/private.md", false},
+		{"double star prefix", "**/secrets.json", "a/b/secrets.json", true},
+		{"double star prefix at root", "**/secrets.json", "secrets.json", true},
+		{"double star middle", "docs/**/draft.md", "docs/a/b/draft.md", true},
+		{"double star middle zero dirs", "docs/**/draft.md", "docs/draft.md", true},
+		{"double star suffix", "dist/**", "dist/js/app.js", true},
+		{"negation re-includes", "*.env\n!example.env", "example.env", false},
+		{"negation keeps others", "*.env\n!example.env", "prod.env", true},
+		{"last rule wins", "!keep.txt\n*.txt", "keep.txt", true},
+		{"negation inside double star", "logs/**\n!logs/keep.txt", "logs/keep.txt", false},
+		{"no re-include under excluded dir", "build/\n!build/keep.txt", "build/keep.txt", true},
+		{"escaped bang", `\!important`, "!important", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := ParseIgnore(strings.NewReader(tt.rules))
+			if err != nil {
+				t.Fatalf("ParseIgnore() error = %v", err)
+			}
+			if got := m.Match(tt.path); got != tt.want {
+				t.Errorf("Match(%q) with rules %q = %v, want %v", tt.path, tt.rules, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseIgnore_InvalidPattern(t *testing.T) {
+	if _, err := ParseIgnore(strings.NewReader("[abc")); err == nil {
+		t.Error("ParseIgnore() error = nil, want error for malformed pattern")
+	}
+}
+
+func TestParseIgnore_Patterns(t *testing.T) {
+	m, err := ParseIgnore(strings.NewReader("# secrets\n.env\n\n!example.env\n"))
+	if err != nil {
+		t.Fatalf("ParseIgnore() error = %v", err)
+	}
+
+	got := m.Patterns()
+	want := []string{".env", "!example.env"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Patterns() = %v, want %v", got, want)
+	}
+}
+
+func TestLoadIgnoreFile_Missing(t *testing.T) {
+	m, err := LoadIgnoreFile(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadIgnoreFile() error = %v", err)
+	}
+	if m.Match(".env") {
+		t.Error("Match() = true, want false without an ignore file")
+	}
+}
+
+func TestManager_CopyContextFiles_TanukiIgnore(t *testing.T) {
+	projectRoot := t.TempDir()
+	worktreeRoot := t.TempDir()
+
+	_ = os.WriteFile(filepath.Join(projectRoot, IgnoreFileName), []byte(".env*\n!.env.example\nbuild/\n"), 0600)
+	_ = os.WriteFile(filepath.Join(projectRoot, ".env"), []byte("SECRET=1"), 0600)
+	_ = os.WriteFile(filepath.Join(projectRoot, ".env.example"), []byte("SECRET="), 0600)
+	_ = os.MkdirAll(filepath.Join(projectRoot, "build"), 0750)
+	_ = os.WriteFile(filepath.Join(projectRoot, "build", "app.js"), []byte("//"), 0600)
+
+	m := NewManager(projectRoot, false)
+	result, err := m.CopyContextFiles(worktreeRoot, []string{".env*", "build/*"})
+	if err != nil {
+		t.Fatalf("CopyContextFiles failed: %v", err)
+	}
+
+	if len(result.Copied) != 1 || result.Copied[0] != ".env.example" {
+		t.Errorf("Copied = %v, want [.env.example]", result.Copied)
+	}
+	if len(result.Ignored) != 2 {
+		t.Errorf("Ignored = %v, want .env and build/app.js", result.Ignored)
+	}
+
+	contextDir := filepath.Join(worktreeRoot, ".tanuki", "context")
+	if _, err := os.Stat(filepath.Join(contextDir, ".env")); !os.IsNotExist(err) {
+		t.Error(".env was copied despite .tanukiignore")
+	}
+	if _, err := os.Stat(filepath.Join(contextDir, "build", "app.js")); !os.IsNotExist(err) {
+		t.Error("build/app.js was copied despite .tanukiignore")
+	}
+}
@@ -3,6 +3,7 @@ package docker
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -72,40 +73,19 @@ type Manager struct {
 	config *config.Config
 }
 
-// NewManager creates a new Docker container manager.
+// NewManager creates a new Docker container manager. It fails with a
+// *DaemonUnreachableError if the Docker daemon doesn't answer a Ping.
 func NewManager(cfg *config.Config) (*Manager, error) {
-	// Verify Docker is running
-	if err := checkDockerRunning(); err != nil {
-		return nil, err
-	}
-
-	return &Manager{
+	m := &Manager{
 		config: cfg,
-	}, nil
-}
+	}
 
-// checkDockerRunning verifies the Docker daemon is accessible.
-func checkDockerRunning() error {
-	cmd := exec.Command("docker", "info")
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
-	if err := cmd.Run(); err != nil {
-		stderrStr := strings.TrimSpace(stderr.String())
-		// Check for common Docker not running indicators
-		if strings.Contains(stderrStr, "Cannot connect") ||
-			strings.Contains(stderrStr, "connection refused") ||
-			strings.Contains(stderrStr, "Is the docker daemon running") ||
-			stderrStr == "" {
-			return ErrDockerNotRunning
-		}
-		return fmt.Errorf("docker check failed: %s", stderrStr)
+	// Verify Docker is running
+	if err := m.Ping(context.Background()); err != nil {
+		return nil, err
 	}
-	return nil
-}
 
-// EnsureNetwork creates a Docker network if it doesn't already exist.
-func (m *Manager) EnsureNetwork(name string) error {
-	return EnsureNetwork(name)
+	return m, nil
 }
 
 // CreateContainer creates a new container with the given configuration.
@@ -166,8 +146,28 @@ func (m *Manager) CreateContainer(config ContainerConfig) (string, error) {
 type AgentContainerOptions struct {
 	// ServiceEnv contains environment variables for service connections
 	ServiceEnv map[string]string
+
+	// SSHKeyPath is a host private key to mount read-only for git over SSH
+	SSHKeyPath string
+
+	// ForwardSSHAgent mounts the host SSH agent socket into the container
+	ForwardSSHAgent bool
+
+	// Memory overrides the configured default memory limit (optional)
+	Memory string
+
+	// CPUs overrides the configured default CPU limit (optional)
+	CPUs string
+
+	// Network overrides the configured Docker network (optional).
+	// NoNetwork runs the container without networking.
+	Network string
 }
 
+// NoNetwork is Docker's built-in network for containers with networking
+// disabled.
+const NoNetwork = "none"
+
 // CreateAgentContainer creates a container configured for a Tanuki agent.
 func (m *Manager) CreateAgentContainer(name string, worktreePath string) (string, error) {
 	return m.CreateAgentContainerWithOptions(name, worktreePath, AgentContainerOptions{})
@@ -205,6 +205,30 @@ func (m *Manager) CreateAgentContainerWithOptions(name string, worktreePath stri
 		}
 	}
 
+	sshMounts, sshEnv, err := sshSettings(opts)
+	if err != nil {
+		return "", err
+	}
+	for k, v := range sshEnv {
+		env[k] = v
+	}
+
+	resources := ResourceLimits{
+		Memory: m.config.Defaults.Resources.Memory,
+		CPUs:   m.config.Defaults.Resources.CPUs,
+	}
+	if opts.Memory != "" {
+		resources.Memory = opts.Memory
+	}
+	if opts.CPUs != "" {
+		resources.CPUs = opts.CPUs
+	}
+
+	network := m.config.Network.Name
+	if opts.Network != "" {
+		network = opts.Network
+	}
+
 	config := ContainerConfig{
 		Name:    fmt.Sprintf("tanuki-%s", name),
 		Image:   image,
@@ -221,13 +245,11 @@ func (m *Manager) CreateAgentContainerWithOptions(name string, worktreePath stri
 				ReadOnly: false,
 			},
 		},
-		Network: m.config.Network.Name,
-		Resources: ResourceLimits{
-			Memory: m.config.Defaults.Resources.Memory,
-			CPUs:   m.config.Defaults.Resources.CPUs,
-		},
-		Env: env,
+		Network:   network,
+		Resources: resources,
+		Env:       env,
 	}
+	config.Mounts = append(config.Mounts, sshMounts...)
 
 	return m.CreateContainer(config)
 }
@@ -396,6 +418,67 @@ func (m *Manager) ExecWithOutput(containerID string, command []string) (string,
 	return stdout.String(), nil
 }
 
+// ExecCommand runs a command in a container as the node user and returns its
+// exit code. Unlike Exec, the command runs directly rather than through the
+// shell and log tee, so its arguments and exit code pass through unchanged and
+// nothing is added to the agent's log. err is only set if the command could
+// not be run at all.
+func (m *Manager) ExecCommand(containerID string, command []string, opts ExecOptions) (int, error) {
+	args := make([]string, 0, 6+len(command))
+	args = append(args, "exec")
+	if opts.Interactive {
+		args = append(args, "-i")
+	}
+	if opts.TTY {
+		args = append(args, "-t")
+	}
+	args = append(args, "--user", "node", containerID)
+	args = append(args, command...)
+
+	cmd := exec.Command("docker", args...) //nolint:gosec // G204: docker args are constructed from trusted caller
+	cmd.Stdin = opts.Stdin
+	cmd.Stdout = opts.Stdout
+	cmd.Stderr = opts.Stderr
+
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		// A non-zero exit is the command's result (docker itself uses 125-127)
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return -1, fmt.Errorf("exec failed: %w", err)
+	}
+	return 0, nil
+}
+
+// CopyToContainer copies srcHost, a file or directory, to dstContainer in a
+// container, with docker cp semantics: directories are copied recursively,
+// and dstContainer is created if it doesn't exist. Files keep their mode
+// and ownership, so the node user can still edit files it owns on the host.
+// The container doesn't have to be running.
+func (m *Manager) CopyToContainer(containerID, srcHost, dstContainer string) error {
+	return dockerCopy("--archive", srcHost, containerID+":"+dstContainer)
+}
+
+// CopyFromContainer copies srcContainer, a file or directory in a container,
+// to dstHost, with docker cp semantics. Files keep their mode and are owned
+// by the user running tanuki.
+func (m *Manager) CopyFromContainer(containerID, srcContainer, dstHost string) error {
+	return dockerCopy(containerID+":"+srcContainer, dstHost)
+}
+
+// dockerCopy runs docker cp with args.
+func dockerCopy(args ...string) error {
+	cmd := exec.Command("docker", append([]string{"cp"}, args...)...) //nolint:gosec // G204: docker args are constructed from trusted caller
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("copy failed: %s", strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
 // StreamLogs returns a reader for streaming container logs.
 func (m *Manager) StreamLogs(containerID string, follow bool) (io.ReadCloser, error) {
 	args := []string{"logs"}
@@ -2,6 +2,8 @@ package docker
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"io"
 	"os"
 	"os/exec"
@@ -16,7 +18,7 @@ import (
 // skipIfDockerNotRunning skips the test if Docker is not running.
 func skipIfDockerNotRunning(t *testing.T) {
 	t.Helper()
-	if err := checkDockerRunning(); err != nil {
+	if err := ping(context.Background()); err != nil {
 		t.Skip("Docker is not running")
 	}
 }
@@ -311,6 +313,96 @@ func TestExec(t *testing.T) {
 	}
 }
 
+func TestExecCommand(t *testing.T) {
+	manager := createTestManager(t)
+	imageName := createTestImage(t)
+
+	config := ContainerConfig{
+		Name:  "tanuki-test-exec-command",
+		Image: imageName,
+	}
+
+	containerID, err := manager.CreateContainer(config)
+	if err != nil {
+		t.Fatalf("CreateContainer failed: %v", err)
+	}
+	defer cleanupContainer(t, containerID)
+
+	if err := manager.StartContainer(containerID); err != nil {
+		t.Fatalf("StartContainer failed: %v", err)
+	}
+
+	var stdout bytes.Buffer
+	code, err := manager.ExecCommand(containerID, []string{"echo", "two words"}, ExecOptions{Stdout: &stdout})
+	if err != nil {
+		t.Fatalf("ExecCommand failed: %v", err)
+	}
+	if code != 0 {
+		t.Errorf("exit code = %d, want 0", code)
+	}
+	if got := strings.TrimSpace(stdout.String()); got != "two words" {
+		t.Errorf("stdout = %q, want %q", got, "two words")
+	}
+
+	code, err = manager.ExecCommand(containerID, []string{"sh", "-c", "exit 3"}, ExecOptions{})
+	if err != nil {
+		t.Fatalf("ExecCommand failed: %v", err)
+	}
+	if code != 3 {
+		t.Errorf("exit code = %d, want 3", code)
+	}
+}
+
+func TestCopyToFromContainer(t *testing.T) {
+	manager := createTestManager(t)
+	imageName := createTestImage(t)
+
+	containerID, err := manager.CreateContainer(ContainerConfig{
+		Name:  "tanuki-test-copy",
+		Image: imageName,
+	})
+	if err != nil {
+		t.Fatalf("CreateContainer failed: %v", err)
+	}
+	defer cleanupContainer(t, containerID)
+
+	if err := manager.StartContainer(containerID); err != nil {
+		t.Fatalf("StartContainer failed: %v", err)
+	}
+
+	src := filepath.Join(t.TempDir(), "bundle")
+	if err := os.MkdirAll(filepath.Join(src, "bin"), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "bin", "run.sh"), []byte("#!/bin/sh\necho ok\n"), 0750); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := manager.CopyToContainer(containerID, src, "/tmp/bundle"); err != nil {
+		t.Fatalf("CopyToContainer failed: %v", err)
+	}
+	out, err := manager.ExecWithOutput(containerID, []string{"/tmp/bundle/bin/run.sh"})
+	if err != nil || strings.TrimSpace(out) != "ok" {
+		t.Errorf("copied script output = %q, %v; want it executable in the container", out, err)
+	}
+
+	dst := filepath.Join(t.TempDir(), "back")
+	if err := manager.CopyFromContainer(containerID, "/tmp/bundle", dst); err != nil {
+		t.Fatalf("CopyFromContainer failed: %v", err)
+	}
+	info, err := os.Stat(filepath.Join(dst, "bin", "run.sh"))
+	if err != nil {
+		t.Fatalf("copied file missing: %v", err)
+	}
+	if info.Mode().Perm() != 0750 {
+		t.Errorf("mode = %v, want 0750 preserved", info.Mode().Perm())
+	}
+
+	if err := manager.CopyFromContainer(containerID, "/tmp/missing", dst); err == nil {
+		t.Error("CopyFromContainer of a missing path expected an error")
+	}
+}
+
 func TestStreamLogs(t *testing.T) {
 	manager := createTestManager(t)
 	imageName := createTestImage(t)
@@ -473,7 +565,7 @@ func TestEnsureNetwork(t *testing.T) {
 	defer func() { _ = exec.Command("docker", "network", "rm", networkName).Run() }()
 
 	// Verify it exists
-	exists, err := NetworkExists(networkName)
+	exists, err := manager.NetworkExists(networkName)
 	if err != nil {
 		t.Fatalf("NetworkExists failed: %v", err)
 	}
@@ -487,6 +579,38 @@ func TestEnsureNetwork(t *testing.T) {
 	}
 }
 
+func TestNetworkExists_Missing(t *testing.T) {
+	manager := createTestManager(t)
+
+	exists, err := manager.NetworkExists("tanuki-test-no-such-network")
+	if err != nil {
+		t.Fatalf("NetworkExists failed: %v", err)
+	}
+	if exists {
+		t.Error("NetworkExists should return false for a missing network")
+	}
+}
+
+func TestInspectNetworkError(t *testing.T) {
+	exitErr := errors.New("exit status 1")
+
+	if err := inspectNetworkError("tanuki-net", "Error response from daemon: network tanuki-net not found", exitErr); err != nil {
+		t.Errorf("missing network error = %v, want nil", err)
+	}
+	if err := inspectNetworkError("tanuki-net", "Error: No such network: tanuki-net", exitErr); err != nil {
+		t.Errorf("missing network error = %v, want nil", err)
+	}
+
+	denied := "permission denied while trying to connect to the Docker daemon socket at unix:///var/run/docker.sock"
+	if err := inspectNetworkError("tanuki-net", denied, exitErr); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("permission error = %v, want ErrPermissionDenied", err)
+	}
+
+	if err := inspectNetworkError("tanuki-net", "Cannot connect to the Docker daemon", exitErr); err == nil || errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("daemon error = %v, want a plain error", err)
+	}
+}
+
 func TestImageExists(t *testing.T) {
 	manager := createTestManager(t)
 	imageName := createTestImage(t)
@@ -0,0 +1,91 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// PingTimeout bounds how long NewManager waits for the daemon to answer.
+const PingTimeout = 5 * time.Second
+
+// ErrDockerNotInstalled indicates the docker CLI could not be found.
+var ErrDockerNotInstalled = errors.New("docker command not found in PATH; is Docker installed?")
+
+// DaemonUnreachableError reports that the Docker daemon did not answer. It
+// matches ErrDockerNotRunning with errors.Is.
+type DaemonUnreachableError struct {
+	// Host is the daemon address the docker CLI tried
+	Host string
+
+	// Err is the underlying failure, usually the docker CLI's stderr
+	Err error
+}
+
+func (e *DaemonUnreachableError) Error() string {
+	return fmt.Sprintf("Docker daemon not reachable at %s; is Docker running?", e.Host)
+}
+
+// Unwrap returns the underlying failure.
+func (e *DaemonUnreachableError) Unwrap() error { return e.Err }
+
+// Is reports whether target is ErrDockerNotRunning.
+func (e *DaemonUnreachableError) Is(target error) bool { return target == ErrDockerNotRunning }
+
+// Ping checks that the Docker daemon is answering. It asks only for the
+// server version, so it returns quickly whether or not Docker is up. If
+// ctx has no deadline, PingTimeout applies.
+func (m *Manager) Ping(ctx context.Context) error {
+	return ping(ctx)
+}
+
+// ping implements Manager.Ping without needing a Manager.
+func ping(ctx context.Context) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, PingTimeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(ctx, "docker", "version", "--format", "{{.Server.Version}}")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return ErrDockerNotInstalled
+		}
+		if ctx.Err() != nil {
+			err = fmt.Errorf("no response: %w", ctx.Err())
+		} else if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = errors.New(msg)
+		}
+		return &DaemonUnreachableError{Host: DockerHost(), Err: err}
+	}
+	return nil
+}
+
+// DockerHost returns the daemon address the docker CLI uses: $DOCKER_HOST,
+// else the current docker context's endpoint, else the platform default.
+func DockerHost() string {
+	if host := os.Getenv("DOCKER_HOST"); host != "" {
+		return host
+	}
+
+	out, err := exec.Command("docker", "context", "inspect", "--format", "{{.Endpoints.docker.Host}}").Output()
+	if err == nil {
+		if host := strings.TrimSpace(string(out)); host != "" {
+			return host
+		}
+	}
+
+	if runtime.GOOS == "windows" {
+		return "npipe:////./pipe/docker_engine"
+	}
+	return "unix:///var/run/docker.sock"
+}
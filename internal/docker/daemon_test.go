@@ -0,0 +1,55 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"testing"
+)
+
+func TestDaemonUnreachableError(t *testing.T) {
+	cause := errors.New("connection refused")
+	err := fmt.Errorf("failed to create docker manager: %w", &DaemonUnreachableError{Host: "tcp://10.0.0.5:2375", Err: cause})
+
+	var daemonErr *DaemonUnreachableError
+	if !errors.As(err, &daemonErr) {
+		t.Fatal("errors.As() = false, want true")
+	}
+	want := "Docker daemon not reachable at tcp://10.0.0.5:2375; is Docker running?"
+	if daemonErr.Error() != want {
+		t.Errorf("Error() = %q, want %q", daemonErr.Error(), want)
+	}
+	if !errors.Is(err, ErrDockerNotRunning) {
+		t.Error("errors.Is(err, ErrDockerNotRunning) = false, want true")
+	}
+	if !errors.Is(err, cause) {
+		t.Error("errors.Is(err, cause) = false, want true")
+	}
+}
+
+func TestDockerHost_Env(t *testing.T) {
+	t.Setenv("DOCKER_HOST", "tcp://10.0.0.5:2375")
+
+	if got := DockerHost(); got != "tcp://10.0.0.5:2375" {
+		t.Errorf("DockerHost() = %q, want %q", got, "tcp://10.0.0.5:2375")
+	}
+}
+
+func TestPing_Unreachable(t *testing.T) {
+	if _, err := exec.LookPath("docker"); err != nil {
+		t.Skip("docker CLI not installed")
+	}
+	host := "unix://" + t.TempDir() + "/missing.sock"
+	t.Setenv("DOCKER_HOST", host)
+
+	err := ping(context.Background())
+
+	var daemonErr *DaemonUnreachableError
+	if !errors.As(err, &daemonErr) {
+		t.Fatalf("ping() error = %v, want *DaemonUnreachableError", err)
+	}
+	if daemonErr.Host != host {
+		t.Errorf("Host = %q, want %q", daemonErr.Host, host)
+	}
+}
@@ -2,48 +2,83 @@ package docker
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os/exec"
 	"strings"
 )
 
-// EnsureNetwork creates a Docker network if it doesn't already exist.
+// ErrPermissionDenied indicates the Docker daemon refused an operation,
+// usually because the user can't use the Docker socket.
+var ErrPermissionDenied = errors.New("permission denied by Docker")
+
+// EnsureNetwork creates a Docker network if it doesn't already exist. It is
+// safe to call repeatedly; an existing network is left alone.
+func (m *Manager) EnsureNetwork(name string) error {
+	return EnsureNetwork(name)
+}
+
+// NetworkExists reports whether a Docker network exists, without creating it.
+func (m *Manager) NetworkExists(name string) (bool, error) {
+	return NetworkExists(name)
+}
+
+// EnsureNetwork implements Manager.EnsureNetwork without needing a Manager.
 func EnsureNetwork(name string) error {
-	// Check if network exists
-	cmd := exec.Command("docker", "network", "ls", "--format", "{{.Name}}")
-	output, err := cmd.Output()
+	exists, err := NetworkExists(name)
 	if err != nil {
-		return fmt.Errorf("failed to list networks: %w", err)
+		return err
 	}
-
-	networks := strings.Split(strings.TrimSpace(string(output)), "\n")
-	for _, n := range networks {
-		if n == name {
-			return nil // Network already exists
-		}
+	if exists {
+		return nil
 	}
 
-	// Create network
-	cmd = exec.Command("docker", "network", "create", name)
+	cmd := exec.Command("docker", "network", "create", name)
 	var stderr bytes.Buffer
 	cmd.Stderr = &stderr
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to create network %s: %s", name, stderr.String())
+		msg := strings.TrimSpace(stderr.String())
+		if isPermissionDenied(msg) {
+			return fmt.Errorf("%w: can't create network %s (%s); create it with \"docker network create %s\" or check your access to the Docker daemon",
+				ErrPermissionDenied, name, msg, name)
+		}
+		return fmt.Errorf("failed to create network %s: %s", name, msg)
 	}
 
 	return nil
 }
 
-// NetworkExists checks if a Docker network exists.
+// NetworkExists implements Manager.NetworkExists without needing a Manager.
 func NetworkExists(name string) (bool, error) {
-	cmd := exec.Command("docker", "network", "inspect", name)
-	err := cmd.Run()
-	if err != nil {
-		// Network doesn't exist
-		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
-			return false, nil
+	cmd := exec.Command("docker", "network", "inspect", "--format", "{{.Name}}", name)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return false, ErrDockerNotInstalled
 		}
-		return false, err
+		return false, inspectNetworkError(name, strings.TrimSpace(stderr.String()), err)
 	}
 	return true, nil
 }
+
+// inspectNetworkError interprets a failed "docker network inspect". A
+// missing network isn't an error; anything else, such as an unreachable
+// daemon, is, so callers don't mistake it for a network to create.
+func inspectNetworkError(name, stderr string, err error) error {
+	lower := strings.ToLower(stderr)
+	switch {
+	case strings.Contains(lower, "no such network"), strings.Contains(lower, "not found"):
+		return nil
+	case isPermissionDenied(stderr):
+		return fmt.Errorf("%w: can't inspect network %s: %s", ErrPermissionDenied, name, stderr)
+	case stderr != "":
+		return fmt.Errorf("failed to inspect network %s: %s", name, stderr)
+	}
+	return fmt.Errorf("failed to inspect network %s: %w", name, err)
+}
+
+// isPermissionDenied reports whether docker CLI output is a permission error.
+func isPermissionDenied(stderr string) bool {
+	return strings.Contains(strings.ToLower(stderr), "permission denied")
+}
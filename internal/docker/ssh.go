@@ -0,0 +1,112 @@
+package docker
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/bkonkle/tanuki/internal/config"
+)
+
+const (
+	// containerSSHKeyPath is where a configured deploy key is mounted.
+	containerSSHKeyPath = "/home/node/.ssh/tanuki_key"
+
+	// containerSSHAgentSock is where a forwarded SSH agent socket is mounted.
+	containerSSHAgentSock = "/run/tanuki-ssh-agent.sock"
+
+	// dockerDesktopSSHAgentSock is the socket Docker Desktop for Mac exposes
+	// for forwarding the host SSH agent into containers.
+	dockerDesktopSSHAgentSock = "/run/host-services/ssh-auth.sock"
+
+	// sshCommandBase accepts new host keys without prompting, since agents
+	// can't answer the prompt, but still rejects changed ones.
+	sshCommandBase = "ssh -o StrictHostKeyChecking=accept-new"
+)
+
+// sshSettings returns the mounts and environment needed to give an agent
+// container git access over SSH, as requested by opts. It returns no mounts
+// when neither a key nor agent forwarding is configured.
+func sshSettings(opts AgentContainerOptions) ([]Mount, map[string]string, error) {
+	var mounts []Mount
+	env := map[string]string{}
+	sshCommand := sshCommandBase
+
+	if opts.SSHKeyPath != "" {
+		keyPath, err := checkSSHKey(opts.SSHKeyPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		mounts = append(mounts, Mount{
+			Source:   keyPath,
+			Target:   containerSSHKeyPath,
+			ReadOnly: true,
+		})
+		sshCommand += " -i " + containerSSHKeyPath + " -o IdentitiesOnly=yes"
+	}
+
+	if opts.ForwardSSHAgent {
+		sock, err := hostSSHAgentSocket()
+		if err != nil {
+			return nil, nil, err
+		}
+		mounts = append(mounts, Mount{
+			Source: sock,
+			Target: containerSSHAgentSock,
+		})
+		env["SSH_AUTH_SOCK"] = containerSSHAgentSock
+	}
+
+	if len(mounts) == 0 {
+		return nil, nil, nil
+	}
+
+	env["GIT_SSH_COMMAND"] = sshCommand
+	return mounts, env, nil
+}
+
+// checkSSHKey resolves the key path and makes sure the key is readable only
+// by its owner (0400 or 0600), as ssh itself requires. Bind mounts keep the
+// host file mode, so this is what the container sees.
+func checkSSHKey(path string) (string, error) {
+	path, err := config.ExpandHome(path)
+	if err != nil {
+		return "", err
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	info, err := os.Stat(absPath)
+	if err != nil {
+		return "", fmt.Errorf("ssh key: %w", err)
+	}
+	if info.IsDir() {
+		return "", fmt.Errorf("ssh key %s is a directory", absPath)
+	}
+
+	// Windows doesn't report Unix permission bits
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0 {
+		return "", fmt.Errorf("ssh key %s is accessible by other users (mode %04o); run: chmod 400 %s",
+			absPath, info.Mode().Perm(), absPath)
+	}
+
+	return absPath, nil
+}
+
+// hostSSHAgentSocket returns the host path of the SSH agent socket to mount.
+func hostSSHAgentSocket() (string, error) {
+	// Docker Desktop VMs can't see host sockets, so it provides its own
+	if runtime.GOOS == "darwin" {
+		return dockerDesktopSSHAgentSock, nil
+	}
+
+	sock := os.Getenv("SSH_AUTH_SOCK")
+	if sock == "" {
+		return "", fmt.Errorf("git.forward_ssh_agent is enabled but SSH_AUTH_SOCK is not set; start an ssh-agent first")
+	}
+	return sock, nil
+}
@@ -0,0 +1,118 @@
+package docker
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func writeTestKey(t *testing.T, mode os.FileMode) string {
+	t.Helper()
+	keyPath := filepath.Join(t.TempDir(), "id_ed25519")
+	if err := os.WriteFile(keyPath, []byte("test key"), mode); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+	if err := os.Chmod(keyPath, mode); err != nil {
+		t.Fatalf("failed to chmod key: %v", err)
+	}
+	return keyPath
+}
+
+func TestSSHSettings_None(t *testing.T) {
+	mounts, env, err := sshSettings(AgentContainerOptions{})
+	if err != nil {
+		t.Fatalf("sshSettings() error = %v", err)
+	}
+	if len(mounts) != 0 || len(env) != 0 {
+		t.Errorf("sshSettings() = %v, %v, want no mounts or env", mounts, env)
+	}
+}
+
+func TestSSHSettings_Key(t *testing.T) {
+	keyPath := writeTestKey(t, 0400)
+
+	mounts, env, err := sshSettings(AgentContainerOptions{SSHKeyPath: keyPath})
+	if err != nil {
+		t.Fatalf("sshSettings() error = %v", err)
+	}
+
+	if len(mounts) != 1 {
+		t.Fatalf("expected 1 mount, got %d", len(mounts))
+	}
+	if mounts[0].Source != keyPath || mounts[0].Target != containerSSHKeyPath {
+		t.Errorf("mount = %+v, want %s -> %s", mounts[0], keyPath, containerSSHKeyPath)
+	}
+	if !mounts[0].ReadOnly {
+		t.Error("expected key mount to be read-only")
+	}
+
+	sshCmd := env["GIT_SSH_COMMAND"]
+	if !strings.Contains(sshCmd, "-i "+containerSSHKeyPath) {
+		t.Errorf("GIT_SSH_COMMAND = %q, want it to use the mounted key", sshCmd)
+	}
+	if !strings.Contains(sshCmd, "StrictHostKeyChecking=accept-new") {
+		t.Errorf("GIT_SSH_COMMAND = %q, want host keys accepted without a prompt", sshCmd)
+	}
+	if _, ok := env["SSH_AUTH_SOCK"]; ok {
+		t.Error("expected SSH_AUTH_SOCK to be unset without agent forwarding")
+	}
+}
+
+func TestSSHSettings_KeyErrors(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes aren't checked on Windows")
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr string
+	}{
+		{"world readable", writeTestKey(t, 0644), "accessible by other users"},
+		{"group readable", writeTestKey(t, 0640), "accessible by other users"},
+		{"missing", filepath.Join(t.TempDir(), "missing"), "ssh key"},
+		{"directory", t.TempDir(), "is a directory"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := sshSettings(AgentContainerOptions{SSHKeyPath: tt.path})
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("sshSettings() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSSHSettings_ForwardAgent(t *testing.T) {
+	if runtime.GOOS == "darwin" {
+		t.Skip("Docker Desktop provides its own socket on macOS")
+	}
+
+	t.Setenv("SSH_AUTH_SOCK", "/tmp/ssh-agent.sock")
+
+	mounts, env, err := sshSettings(AgentContainerOptions{ForwardSSHAgent: true})
+	if err != nil {
+		t.Fatalf("sshSettings() error = %v", err)
+	}
+
+	if len(mounts) != 1 || mounts[0].Source != "/tmp/ssh-agent.sock" || mounts[0].Target != containerSSHAgentSock {
+		t.Errorf("mounts = %+v, want agent socket mount", mounts)
+	}
+	if env["SSH_AUTH_SOCK"] != containerSSHAgentSock {
+		t.Errorf("SSH_AUTH_SOCK = %q, want %q", env["SSH_AUTH_SOCK"], containerSSHAgentSock)
+	}
+	if strings.Contains(env["GIT_SSH_COMMAND"], "-i ") {
+		t.Errorf("GIT_SSH_COMMAND = %q, want no identity file", env["GIT_SSH_COMMAND"])
+	}
+
+	t.Setenv("SSH_AUTH_SOCK", "")
+	if _, _, err := sshSettings(AgentContainerOptions{ForwardSSHAgent: true}); err == nil {
+		t.Error("expected error when SSH_AUTH_SOCK is not set")
+	}
+}
@@ -0,0 +1,56 @@
+package docker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// memoryUnits maps the unit suffixes used by "docker stats" to bytes.
+// Docker reports binary units (KiB, MiB) for memory but decimal units
+// (kB, MB) appear in some versions, so both are accepted.
+var memoryUnits = map[string]float64{
+	"B":   1,
+	"KIB": 1 << 10,
+	"MIB": 1 << 20,
+	"GIB": 1 << 30,
+	"TIB": 1 << 40,
+	"KB":  1e3,
+	"MB":  1e6,
+	"GB":  1e9,
+	"TB":  1e12,
+}
+
+// ParseMemoryUsage parses a "docker stats" memory column such as
+// "1.2GiB / 4GiB" and returns the bytes in use (the part before the slash).
+func ParseMemoryUsage(s string) (int64, error) {
+	used, _, _ := strings.Cut(s, "/")
+	used = strings.TrimSpace(used)
+
+	i := strings.IndexFunc(used, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i <= 0 {
+		return 0, fmt.Errorf("invalid memory usage %q", s)
+	}
+
+	n, err := strconv.ParseFloat(used[:i], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid memory usage %q: %w", s, err)
+	}
+	unit, ok := memoryUnits[strings.ToUpper(strings.TrimSpace(used[i:]))]
+	if !ok {
+		return 0, fmt.Errorf("invalid memory unit in %q", s)
+	}
+	return int64(n * unit), nil
+}
+
+// ParseCPUPercent parses a "docker stats" CPU column such as "12.34%".
+// Values above 100 mean more than one core is busy.
+func ParseCPUPercent(s string) (float64, error) {
+	n, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "%"), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid CPU percentage %q: %w", s, err)
+	}
+	return n, nil
+}
@@ -0,0 +1,58 @@
+package docker
+
+import "testing"
+
+func TestParseMemoryUsage(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{"1.2GiB / 4GiB", 1288490188, false},
+		{"512MiB / 8GiB", 512 << 20, false},
+		{"0B / 0B", 0, false},
+		{"12.5kB / 1MB", 12500, false},
+		{"3GiB", 3 << 30, false},
+		{"--", 0, true},
+		{"", 0, true},
+		{"12XB / 1GiB", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseMemoryUsage(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMemoryUsage(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseMemoryUsage(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCPUPercent(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    float64
+		wantErr bool
+	}{
+		{"12.34%", 12.34, false},
+		{"0.00%", 0, false},
+		{"250.5%", 250.5, false},
+		{"--", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseCPUPercent(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCPUPercent(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseCPUPercent(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package executor
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Backend names accepted by NewBackend.
+const (
+	// BackendClaude runs Claude Code. It is the default.
+	BackendClaude = "claude"
+
+	// BackendCommand runs an arbitrary agent CLI from a command template.
+	BackendCommand = "command"
+)
+
+// PromptPlaceholder is replaced with the prompt in each argument of a
+// CommandBackend's command line.
+const PromptPlaceholder = "{prompt}"
+
+// Backend is the agent CLI an Executor drives. It decides how a prompt
+// becomes a command line and how that command's output is read, so agents
+// other than Claude Code can run tasks without changes to the executor or
+// the agent manager.
+type Backend interface {
+	// Name identifies the backend in logs and errors
+	Name() string
+
+	// Binary is the CLI executable, used to check it is installed and running
+	Binary() string
+
+	// BuildCommand returns the command line that runs prompt with opts
+	BuildCommand(prompt string, opts ExecuteOptions) []string
+
+	// ExtractSessionID returns the session identifier from a run's output,
+	// or "" if the CLI doesn't report one
+	ExtractSessionID(output string) string
+
+	// SignalDetected reports whether a run's output contains the Ralph mode
+	// completion signal
+	SignalDetected(output, signal string) bool
+
+	// ModelUnavailable reports whether a failed run's output shows the model
+	// couldn't serve the request, so the next fallback model should be tried
+	ModelUnavailable(output string) bool
+
+	// TurnLimitReached reports whether a run's output shows it stopped at
+	// ExecuteOptions.MaxTurns rather than finishing
+	TurnLimitReached(output string) bool
+}
+
+// NewBackend returns the named backend. An empty name is BackendClaude.
+// command is the command template for BackendCommand and is ignored otherwise.
+func NewBackend(name string, command []string) (Backend, error) {
+	switch name {
+	case "", BackendClaude:
+		return ClaudeBackend{}, nil
+	case BackendCommand:
+		return NewCommandBackend(command)
+	default:
+		return nil, fmt.Errorf("unknown executor backend %q (want %s or %s)", name, BackendClaude, BackendCommand)
+	}
+}
+
+// ClaudeBackend runs Claude Code in print mode with stream-json output.
+type ClaudeBackend struct{}
+
+// Name implements Backend.
+func (ClaudeBackend) Name() string { return BackendClaude }
+
+// Binary implements Backend.
+func (ClaudeBackend) Binary() string { return "claude" }
+
+// BuildCommand implements Backend.
+func (ClaudeBackend) BuildCommand(prompt string, opts ExecuteOptions) []string {
+	cmd := []string{"claude", "-p", prompt}
+
+	// Use stream-json format for machine-parseable output
+	// --verbose is required when using --print with --output-format stream-json
+	cmd = append(cmd, "--output-format", "stream-json", "--verbose")
+
+	// Allowed tools
+	if len(opts.AllowedTools) > 0 {
+		cmd = append(cmd, "--allowedTools", strings.Join(opts.AllowedTools, ","))
+	}
+
+	// Disallowed tools
+	if len(opts.DisallowedTools) > 0 {
+		cmd = append(cmd, "--disallowedTools", strings.Join(opts.DisallowedTools, ","))
+	}
+
+	// Max turns
+	if opts.MaxTurns > 0 {
+		cmd = append(cmd, "--max-turns", strconv.Itoa(opts.MaxTurns))
+	}
+
+	// Continue an earlier session
+	if opts.ResumeSessionID != "" {
+		cmd = append(cmd, "--resume", opts.ResumeSessionID)
+	}
+
+	// Model
+	if opts.Model != "" {
+		cmd = append(cmd, "--model", opts.Model)
+	}
+
+	// System prompt
+	if opts.SystemPrompt != "" {
+		cmd = append(cmd, "--append-system-prompt", opts.SystemPrompt)
+	}
+
+	return cmd
+}
+
+// ExtractSessionID implements Backend by finding the first stream-json
+// message with a session ID.
+func (ClaudeBackend) ExtractSessionID(output string) string {
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// Skip empty lines
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		var msg StreamMessage
+		if err := json.Unmarshal([]byte(line), &msg); err == nil {
+			if msg.SessionID != "" {
+				return msg.SessionID
+			}
+		}
+	}
+	return ""
+}
+
+// SignalDetected implements Backend.
+func (ClaudeBackend) SignalDetected(output, signal string) bool {
+	return strings.Contains(output, signal)
+}
+
+// ModelUnavailable implements Backend.
+func (ClaudeBackend) ModelUnavailable(output string) bool {
+	return isModelUnavailable(output)
+}
+
+// TurnLimitReached implements Backend by looking for an error_max_turns
+// result message.
+func (ClaudeBackend) TurnLimitReached(output string) bool {
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "{") {
+			continue
+		}
+
+		var msg StreamMessage
+		if err := json.Unmarshal([]byte(line), &msg); err == nil && msg.Type == "result" && msg.Subtype == "error_max_turns" {
+			return true
+		}
+	}
+	return false
+}
+
+// CommandBackend runs any agent CLI from a command template, such as
+// ["aider", "--yes", "--message", "{prompt}"]. Output is treated as plain
+// text: there are no session IDs or model fallbacks, and of the
+// ExecuteOptions only SystemPrompt is used, prepended to the prompt. Tool
+// lists, turn limits and models are Claude Code flags and are ignored.
+type CommandBackend struct {
+	command []string
+}
+
+// NewCommandBackend validates a command template. Its first element is the
+// executable, and at least one argument must contain PromptPlaceholder.
+func NewCommandBackend(command []string) (*CommandBackend, error) {
+	if len(command) == 0 || command[0] == "" {
+		return nil, errors.New("command backend needs a command")
+	}
+	for _, arg := range command[1:] {
+		if strings.Contains(arg, PromptPlaceholder) {
+			return &CommandBackend{command: command}, nil
+		}
+	}
+	return nil, fmt.Errorf("command backend needs an argument containing %s", PromptPlaceholder)
+}
+
+// Name implements Backend.
+func (b *CommandBackend) Name() string { return b.command[0] }
+
+// Binary implements Backend.
+func (b *CommandBackend) Binary() string { return b.command[0] }
+
+// BuildCommand implements Backend.
+func (b *CommandBackend) BuildCommand(prompt string, opts ExecuteOptions) []string {
+	if opts.SystemPrompt != "" {
+		prompt = opts.SystemPrompt + "\n\n" + prompt
+	}
+
+	cmd := make([]string, len(b.command))
+	for i, arg := range b.command {
+		cmd[i] = strings.ReplaceAll(arg, PromptPlaceholder, prompt)
+	}
+	return cmd
+}
+
+// ExtractSessionID implements Backend. Plain CLIs don't report sessions.
+func (b *CommandBackend) ExtractSessionID(_ string) string { return "" }
+
+// SignalDetected implements Backend.
+func (b *CommandBackend) SignalDetected(output, signal string) bool {
+	return strings.Contains(output, signal)
+}
+
+// ModelUnavailable implements Backend. Model fallback is Claude-specific.
+func (b *CommandBackend) ModelUnavailable(_ string) bool { return false }
+
+// TurnLimitReached implements Backend. Turn limits are Claude-specific.
+func (b *CommandBackend) TurnLimitReached(_ string) bool { return false }
@@ -0,0 +1,127 @@
+package executor
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bkonkle/tanuki/internal/docker"
+)
+
+func TestNewBackend(t *testing.T) {
+	tests := []struct {
+		name     string
+		backend  string
+		command  []string
+		wantName string
+		wantErr  bool
+	}{
+		{"default", "", nil, "claude", false},
+		{"claude", "claude", nil, "claude", false},
+		{"command", "command", []string{"aider", "--message", "{prompt}"}, "aider", false},
+		{"command without command", "command", nil, "", true},
+		{"command without placeholder", "command", []string{"aider", "--yes"}, "", true},
+		{"unknown", "gpt", nil, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, err := NewBackend(tt.backend, tt.command)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewBackend() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && backend.Name() != tt.wantName {
+				t.Errorf("Name() = %q, want %q", backend.Name(), tt.wantName)
+			}
+		})
+	}
+}
+
+func TestCommandBackend_BuildCommand(t *testing.T) {
+	backend, err := NewCommandBackend([]string{"aider", "--yes", "--message", "{prompt}"})
+	if err != nil {
+		t.Fatalf("NewCommandBackend() error = %v", err)
+	}
+
+	got := backend.BuildCommand("Fix the bug", ExecuteOptions{
+		SystemPrompt: "Be brief.",
+		Model:        "ignored",
+		MaxTurns:     5,
+	})
+	want := []string{"aider", "--yes", "--message", "Be brief.\n\nFix the bug"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("BuildCommand() = %q, want %q", got, want)
+	}
+}
+
+func TestBackendExecutor_RunFollow(t *testing.T) {
+	backend, _ := NewCommandBackend([]string{"aider", "--message", "{prompt}"})
+
+	var gotCmd []string
+	dockerMgr := &mockDockerManager{
+		execFn: func(_ string, cmd []string, opts docker.ExecOptions) error {
+			gotCmd = cmd
+			_, _ = opts.Stdout.Write([]byte(`{"session_id": "not-parsed"}` + "\n"))
+			return nil
+		},
+	}
+
+	var out bytes.Buffer
+	result, err := NewBackendExecutor(dockerMgr, backend).RunFollow("c1", "task", ExecuteOptions{}, &out)
+	if err != nil {
+		t.Fatalf("RunFollow() error = %v", err)
+	}
+	if gotCmd[0] != "aider" {
+		t.Errorf("command = %q, want the backend's command", gotCmd)
+	}
+	if result.SessionID != "" {
+		t.Errorf("SessionID = %q, want none from a plain CLI", result.SessionID)
+	}
+}
+
+func TestBackendExecutor_NoFallback(t *testing.T) {
+	backend, _ := NewCommandBackend([]string{"aider", "--message", "{prompt}"})
+
+	calls := 0
+	dockerMgr := &mockDockerManager{
+		execFn: func(_ string, _ []string, opts docker.ExecOptions) error {
+			calls++
+			_, _ = opts.Stdout.Write([]byte("rate_limit_error\n"))
+			return errors.New("exit status 1")
+		},
+	}
+
+	opts := ExecuteOptions{ModelFallbacks: []string{"backup"}}
+	_, err := NewBackendExecutor(dockerMgr, backend).RunFollow("c1", "task", opts, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "aider execution failed") {
+		t.Errorf("RunFollow() error = %v, want aider execution failed", err)
+	}
+	if calls != 1 {
+		t.Errorf("ran %d times, want 1; model fallback only applies to Claude Code", calls)
+	}
+}
+
+func TestBackendExecutor_CheckContainer(t *testing.T) {
+	backend, _ := NewCommandBackend([]string{"aider", "--message", "{prompt}"})
+
+	var gotCmd []string
+	dockerMgr := &mockDockerManager{
+		execWithOutputFn: func(_ string, cmd []string) (string, error) {
+			gotCmd = cmd
+			return "", errors.New("exit status 1")
+		},
+	}
+
+	err := NewBackendExecutor(dockerMgr, backend).CheckContainer("c1")
+	if err == nil || !strings.Contains(err.Error(), "aider not found") {
+		t.Errorf("CheckContainer() error = %v, want aider not found", err)
+	}
+	if errors.Is(err, ErrClaudeNotFound) {
+		t.Error("CheckContainer() error is ErrClaudeNotFound, want one naming the backend")
+	}
+	if !reflect.DeepEqual(gotCmd, []string{"which", "aider"}) {
+		t.Errorf("check command = %q, want which aider", gotCmd)
+	}
+}
@@ -0,0 +1,123 @@
+package executor
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// CheckpointMarker prefixes a line an agent prints to record a completed step,
+// e.g. "TANUKI_CHECKPOINT: database schema migrated".
+const CheckpointMarker = "TANUKI_CHECKPOINT:"
+
+// ParseCheckpoints extracts checkpoint descriptions from Claude Code output.
+// Stream-json lines are decoded so markers inside message text are found.
+// Duplicates are dropped, preserving first-seen order.
+func ParseCheckpoints(output string) []string {
+	w := newCheckpointWriter(nil)
+	_, _ = w.Write([]byte(output))
+	w.Flush()
+	return w.checkpoints
+}
+
+// checkpointWriter scans streamed output line by line for checkpoint markers.
+type checkpointWriter struct {
+	lineWriter
+	seen         map[string]bool
+	checkpoints  []string
+	onCheckpoint func(checkpoint string)
+}
+
+func newCheckpointWriter(onCheckpoint func(checkpoint string)) *checkpointWriter {
+	w := &checkpointWriter{
+		seen:         make(map[string]bool),
+		onCheckpoint: onCheckpoint,
+	}
+	w.onLine = w.processLine
+	return w
+}
+
+func (w *checkpointWriter) processLine(line string) {
+	if !strings.Contains(line, CheckpointMarker) {
+		return
+	}
+
+	for _, l := range lineTexts(line) {
+		idx := strings.Index(l, CheckpointMarker)
+		if idx < 0 {
+			continue
+		}
+		checkpoint := strings.TrimSpace(l[idx+len(CheckpointMarker):])
+		if checkpoint == "" || w.seen[checkpoint] {
+			continue
+		}
+		w.seen[checkpoint] = true
+		w.checkpoints = append(w.checkpoints, checkpoint)
+		if w.onCheckpoint != nil {
+			w.onCheckpoint(checkpoint)
+		}
+	}
+}
+
+// lineWriter buffers streamed output and calls onLine with each complete
+// line.
+type lineWriter struct {
+	buf    []byte
+	onLine func(line string)
+}
+
+// Write buffers output and processes every complete line.
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		idx := bytes.IndexByte(w.buf, '\n')
+		if idx < 0 {
+			break
+		}
+		w.onLine(string(w.buf[:idx]))
+		w.buf = w.buf[idx+1:]
+	}
+	return len(p), nil
+}
+
+// Flush processes any trailing partial line.
+func (w *lineWriter) Flush() {
+	if len(w.buf) > 0 {
+		w.onLine(string(w.buf))
+		w.buf = nil
+	}
+}
+
+// lineTexts returns the lines of agent text in a line of output. Stream-json
+// wraps agent text in JSON, so its decoded strings are searched instead.
+func lineTexts(line string) []string {
+	var texts []string
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(line), &decoded); err == nil {
+		collectStrings(decoded, &texts)
+	} else {
+		texts = []string{line}
+	}
+
+	var lines []string
+	for _, text := range texts {
+		lines = append(lines, strings.Split(text, "\n")...)
+	}
+	return lines
+}
+
+// collectStrings appends every string value nested in a decoded JSON value.
+func collectStrings(v interface{}, out *[]string) {
+	switch val := v.(type) {
+	case string:
+		*out = append(*out, val)
+	case []interface{}:
+		for _, item := range val {
+			collectStrings(item, out)
+		}
+	case map[string]interface{}:
+		for _, item := range val {
+			collectStrings(item, out)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package executor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCheckpoints(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   []string
+	}{
+		{
+			name:   "no markers",
+			output: "working...\ndone\n",
+			want:   nil,
+		},
+		{
+			name:   "plain text",
+			output: "TANUKI_CHECKPOINT: schema migrated\nother\n  TANUKI_CHECKPOINT:  handlers added  \n",
+			want:   []string{"schema migrated", "handlers added"},
+		},
+		{
+			name: "stream json",
+			output: `{"type":"assistant","message":{"content":[{"type":"text","text":"Done.\nTANUKI_CHECKPOINT: tests written"}]}}` + "\n" +
+				`{"type":"result","result":"TANUKI_CHECKPOINT: tests written"}`,
+			want: []string{"tests written"},
+		},
+		{
+			name:   "empty checkpoint ignored",
+			output: "TANUKI_CHECKPOINT:   \n",
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseCheckpoints(tt.output)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCheckpoints() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckpointWriter_PartialWrites(t *testing.T) {
+	var reported []string
+	w := newCheckpointWriter(func(checkpoint string) {
+		reported = append(reported, checkpoint)
+	})
+
+	_, _ = w.Write([]byte("TANUKI_CHECK"))
+	_, _ = w.Write([]byte("POINT: step one\nTANUKI_CHECKPOINT: step"))
+	if len(reported) != 1 {
+		t.Fatalf("reported %v before flush, want 1 checkpoint", reported)
+	}
+
+	_, _ = w.Write([]byte(" two"))
+	w.Flush()
+
+	want := []string{"step one", "step two"}
+	if !reflect.DeepEqual(reported, want) {
+		t.Errorf("reported = %v, want %v", reported, want)
+	}
+	if !reflect.DeepEqual(w.checkpoints, want) {
+		t.Errorf("checkpoints = %v, want %v", w.checkpoints, want)
+	}
+}
@@ -0,0 +1,71 @@
+package executor
+
+import (
+	"fmt"
+	"io"
+	"slices"
+)
+
+// continuePrompt resumes a session that stopped at its turn limit.
+const continuePrompt = "You ran out of turns before finishing. Continue the task from where you left off."
+
+// runWithContinuation calls run with prompt, then, while the run stopped at
+// its turn limit and opts.MaxContinuations allows, resumes the session with
+// double the turns. Output and checkpoints of every run are combined in the
+// returned result, and ExecutionResult.Continuations counts the resumes.
+// Continuation notices are written to log if it is non-nil.
+//
+// A run that still stops at the limit returns ErrMaxTurns, so callers don't
+// mistake it for a finished task.
+func runWithContinuation(prompt string, opts ExecuteOptions, log io.Writer, backend Backend, run func(prompt string, opts ExecuteOptions) (*ExecutionResult, error)) (*ExecutionResult, error) {
+	result, err := run(prompt, opts)
+	// Only the latest run's output says whether it hit the limit
+	last := ""
+	if result != nil {
+		last = result.Output
+	}
+	for err == nil && result != nil && backend.TurnLimitReached(last) {
+		if result.Continuations >= opts.MaxContinuations || result.SessionID == "" {
+			err = fmt.Errorf("%w (%d turns)", ErrMaxTurns, opts.MaxTurns)
+			result.Error = err
+			return result, err
+		}
+
+		if opts.MaxTurns > 0 {
+			opts.MaxTurns *= 2
+		}
+		opts.ResumeSessionID = result.SessionID
+		if log != nil {
+			_, _ = fmt.Fprintf(log, "\n--- Hit the turn limit, continuing with %d turns (%d/%d) ---\n",
+				opts.MaxTurns, result.Continuations+1, opts.MaxContinuations)
+		}
+
+		next, nextErr := run(continuePrompt, opts)
+		if next == nil {
+			return result, nextErr
+		}
+		last = next.Output
+		next.Output = result.Output + next.Output
+		next.OutputTruncated += result.OutputTruncated
+		next.SignalsSeen = mergeSignals(result.SignalsSeen, next.SignalsSeen)
+		next.Checkpoints = append(result.Checkpoints, next.Checkpoints...)
+		next.StartedAt = result.StartedAt
+		next.Continuations = result.Continuations + 1
+		if next.SessionID == "" {
+			next.SessionID = result.SessionID
+		}
+		result, err = next, nextErr
+	}
+	return result, err
+}
+
+// mergeSignals returns the signals in a or b, without duplicates.
+func mergeSignals(a, b []string) []string {
+	merged := append([]string(nil), a...)
+	for _, signal := range b {
+		if !slices.Contains(merged, signal) {
+			merged = append(merged, signal)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,106 @@
+package executor
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bkonkle/tanuki/internal/docker"
+)
+
+func TestClaudeBackend_TurnLimitReached(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   bool
+	}{
+		{"max turns result", `{"type":"system","session_id":"s1"}` + "\n" + `{"type":"result","subtype":"error_max_turns","session_id":"s1"}`, true},
+		{"successful result", `{"type":"result","subtype":"success","session_id":"s1"}`, false},
+		{"text mentioning it", `grep error_max_turns`, false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (ClaudeBackend{}).TurnLimitReached(tt.output); got != tt.want {
+				t.Errorf("TurnLimitReached() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// flagValue returns the argument after flag in a claude command.
+func flagValue(cmd []string, flag string) string {
+	for i, arg := range cmd {
+		if arg == flag && i+1 < len(cmd) {
+			return cmd[i+1]
+		}
+	}
+	return ""
+}
+
+func TestRunFollow_TurnLimit(t *testing.T) {
+	const limited = `{"type":"result","subtype":"error_max_turns","session_id":"s1"}`
+	const done = `{"type":"result","subtype":"success","session_id":"s1"}`
+
+	tests := []struct {
+		name              string
+		maxContinuations  int
+		limitedRuns       int
+		wantRuns          int
+		wantContinuations int
+		wantErr           bool
+	}{
+		{"finishes within the limit", 2, 0, 1, 0, false},
+		{"fails without continuations", 0, 1, 1, 0, true},
+		{"continues until done", 2, 1, 2, 1, false},
+		{"continuations exhausted", 2, 5, 3, 2, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cmds [][]string
+			dockerMgr := &mockDockerManager{
+				execFn: func(_ string, cmd []string, opts docker.ExecOptions) error {
+					cmds = append(cmds, cmd)
+					out := done
+					if len(cmds) <= tt.limitedRuns {
+						out = limited
+					}
+					_, _ = opts.Stdout.Write([]byte(out + "\n"))
+					return nil
+				},
+			}
+
+			var log bytes.Buffer
+			opts := ExecuteOptions{MaxTurns: 10, MaxContinuations: tt.maxContinuations}
+			result, err := NewExecutor(dockerMgr).RunFollow("c1", "task", opts, &log)
+
+			if tt.wantErr != errors.Is(err, ErrMaxTurns) {
+				t.Fatalf("RunFollow() error = %v, want ErrMaxTurns: %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("RunFollow() error = %v", err)
+			}
+			if len(cmds) != tt.wantRuns {
+				t.Fatalf("ran %d times, want %d", len(cmds), tt.wantRuns)
+			}
+			if result.Continuations != tt.wantContinuations {
+				t.Errorf("Continuations = %d, want %d", result.Continuations, tt.wantContinuations)
+			}
+			if got := strings.Count(result.Output, `"type":"result"`); got != tt.wantRuns {
+				t.Errorf("result has output of %d runs, want %d", got, tt.wantRuns)
+			}
+
+			for i, cmd := range cmds[1:] {
+				if flagValue(cmd, "--resume") != "s1" {
+					t.Errorf("continuation %d = %q, want --resume s1", i+1, cmd)
+				}
+				if want := []string{"20", "40"}[i]; flagValue(cmd, "--max-turns") != want {
+					t.Errorf("continuation %d --max-turns = %q, want %s", i+1, flagValue(cmd, "--max-turns"), want)
+				}
+			}
+		})
+	}
+}
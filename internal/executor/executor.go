@@ -1,4 +1,5 @@
 // Package executor provides Claude Code execution functionality for agents.
+// Other agent CLIs can be run in its place through a Backend.
 //
 // The executor supports three execution modes:
 // 1. Fire-and-forget: Run task and return immediately
@@ -7,14 +8,12 @@
 package executor
 
 import (
-	"bufio"
-	"bytes"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os/exec"
-	"strconv"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -26,15 +25,24 @@ var (
 	ErrAlreadyRunning = errors.New("claude code is already running in this container")
 
 	// ErrClaudeNotFound indicates Claude Code is not installed in the container.
+	// Other backends report a missing CLI with a plain error naming it.
 	ErrClaudeNotFound = errors.New("claude code not found in container")
 
 	// ErrMaxIterations indicates Ralph mode reached max iterations without completion.
 	ErrMaxIterations = errors.New("reached max iterations without completion")
+
+	// ErrMaxTurns indicates a run stopped at its turn limit before finishing.
+	ErrMaxTurns = errors.New("hit max turns before finishing")
+
+	// ErrNoCompletionSignal indicates a single-shot run finished without
+	// printing its task's completion signal.
+	ErrNoCompletionSignal = errors.New("completion signal not found in output")
 )
 
 // Executor handles Claude Code execution in Docker containers.
 type Executor struct {
-	docker DockerManager
+	docker  DockerManager
+	backend Backend
 }
 
 // DockerManager defines the interface for Docker operations needed by the executor.
@@ -58,11 +66,47 @@ type ExecuteOptions struct {
 	// Model specifies which Claude model to use
 	Model string
 
+	// ModelFallbacks are tried in order, once each, when a run fails because
+	// Model is unavailable (rate limited, overloaded, or unknown). Task
+	// failures never trigger a fallback. Empty disables fallback.
+	ModelFallbacks []string
+
 	// SystemPrompt is appended to the default system prompt
 	SystemPrompt string
 
 	// WorkDir is the working directory inside the container
 	WorkDir string
+
+	// OnCheckpoint is called for each checkpoint the agent reports (optional).
+	// See CheckpointMarker.
+	OnCheckpoint func(checkpoint string)
+
+	// OnProgress is called when the agent reports a new progress percentage
+	// (optional). See DefaultProgressPattern.
+	OnProgress func(percent int)
+
+	// ProgressPattern matches progress lines (nil = DefaultProgressPattern).
+	// See CompileProgressPattern.
+	ProgressPattern *regexp.Regexp
+
+	// MaxContinuations is how many times Run and RunFollow resume a session
+	// that stopped at MaxTurns, doubling the turn limit each time. Zero
+	// returns ErrMaxTurns as soon as the limit is hit.
+	MaxContinuations int
+
+	// ResumeSessionID continues an earlier session instead of starting a
+	// new one
+	ResumeSessionID string
+
+	// MaxOutputBytes caps the output kept in ExecutionResult.Output. Past
+	// the cap, the first and last halves are kept with a truncation marker
+	// between them. Zero keeps all output.
+	MaxOutputBytes int
+
+	// Signals are strings to watch for in the full output stream. Those that
+	// appear are reported in ExecutionResult.SignalsSeen, even if the output
+	// around them was truncated.
+	Signals []string
 }
 
 // RalphOptions configures Ralph mode (autonomous loop) execution.
@@ -78,6 +122,11 @@ type RalphOptions struct {
 	// VerifyCommand is an optional command to verify task completion
 	VerifyCommand string
 
+	// Verifiers decide completion after each iteration, checked in order.
+	// If empty, a SignalVerifier for CompletionSignal is used, followed by a
+	// CommandVerifier for VerifyCommand when one is set.
+	Verifiers []CompletionVerifier
+
 	// CooldownSeconds is the pause between iterations
 	CooldownSeconds int
 }
@@ -87,12 +136,23 @@ type ExecutionResult struct {
 	// SessionID is the Claude Code session identifier
 	SessionID string
 
-	// Output is the complete output from Claude Code
+	// Output is the output from Claude Code, truncated in the middle if it
+	// exceeded ExecuteOptions.MaxOutputBytes
 	Output string
 
+	// OutputTruncated is how many bytes were dropped from Output
+	OutputTruncated int
+
+	// SignalsSeen are the ExecuteOptions.Signals that appeared in the output
+	SignalsSeen []string
+
 	// ExitCode is the process exit code
 	ExitCode int
 
+	// Model is the model the result came from, which differs from
+	// ExecuteOptions.Model if a fallback was used
+	Model string
+
 	// StartedAt is when execution started
 	StartedAt time.Time
 
@@ -102,6 +162,13 @@ type ExecutionResult struct {
 	// LogFilePath is the path to the execution log file (if captured)
 	LogFilePath string
 
+	// Checkpoints are the progress markers reported during execution
+	Checkpoints []string
+
+	// Continuations is how many times the session was resumed after
+	// stopping at the turn limit
+	Continuations int
+
 	// Error is any error that occurred during execution
 	Error error
 }
@@ -113,14 +180,15 @@ type RalphResult struct {
 	// Iterations is the number of loops completed
 	Iterations int
 
-	// CompletedBy indicates how the loop completed
-	// Values: "signal", "verify", "max_iterations", "error"
+	// CompletedBy indicates how the loop completed: the Name of the verifier
+	// that passed (e.g. "signal", "verify", "http"), "max_iterations", or "error"
 	CompletedBy string
 }
 
 // StreamMessage represents a single message from Claude Code stream-json output.
 type StreamMessage struct {
 	Type      string `json:"type"`
+	Subtype   string `json:"subtype,omitempty"`
 	SessionID string `json:"session_id,omitempty"`
 	Content   string `json:"content,omitempty"`
 	Error     string `json:"error,omitempty"`
@@ -128,11 +196,23 @@ type StreamMessage struct {
 
 // NewExecutor creates a new Claude Code executor.
 func NewExecutor(dockerMgr DockerManager) *Executor {
+	return NewBackendExecutor(dockerMgr, ClaudeBackend{})
+}
+
+// NewBackendExecutor creates an executor that runs backend instead of
+// Claude Code.
+func NewBackendExecutor(dockerMgr DockerManager, backend Backend) *Executor {
 	return &Executor{
-		docker: dockerMgr,
+		docker:  dockerMgr,
+		backend: backend,
 	}
 }
 
+// Backend returns the agent CLI the executor runs.
+func (e *Executor) Backend() Backend {
+	return e.backend
+}
+
 // Run executes a Claude Code prompt in fire-and-forget mode.
 // Returns immediately after starting execution.
 func (e *Executor) Run(containerID string, prompt string, opts ExecuteOptions) (*ExecutionResult, error) {
@@ -140,6 +220,15 @@ func (e *Executor) Run(containerID string, prompt string, opts ExecuteOptions) (
 		return nil, errors.New("container is not running")
 	}
 
+	return runWithContinuation(prompt, opts, nil, e.backend, func(prompt string, opts ExecuteOptions) (*ExecutionResult, error) {
+		return runWithFallback(opts, nil, e.backend.ModelUnavailable, func(opts ExecuteOptions) (*ExecutionResult, error) {
+			return e.run(containerID, prompt, opts)
+		})
+	})
+}
+
+// run executes a single fire-and-forget attempt with opts.Model.
+func (e *Executor) run(containerID string, prompt string, opts ExecuteOptions) (*ExecutionResult, error) {
 	cmd := e.buildCommand(prompt, opts)
 	startedAt := time.Now()
 
@@ -147,24 +236,29 @@ func (e *Executor) Run(containerID string, prompt string, opts ExecuteOptions) (
 	output, err := e.docker.ExecWithOutput(containerID, cmd)
 	completedAt := time.Now()
 
-	result := &ExecutionResult{
-		Output:      output,
-		StartedAt:   startedAt,
-		CompletedAt: completedAt,
-	}
+	// Output is only available once execution finishes
+	outputBuf := newOutputBuffer(opts.MaxOutputBytes, e.backend, opts.Signals)
+	checkpoints := newCheckpointWriter(opts.OnCheckpoint)
+	progress := newProgressWriter(opts.ProgressPattern, opts.OnProgress)
+	_, _ = io.MultiWriter(outputBuf, checkpoints, progress).Write([]byte(output))
+	outputBuf.Flush()
+	checkpoints.Flush()
+	progress.Flush()
+
+	result := newExecutionResult(outputBuf, startedAt, completedAt)
+	result.Checkpoints = checkpoints.checkpoints
 
 	if err != nil {
 		result.Error = err
 		result.ExitCode = 1
 		// Try to parse error from output
-		if strings.Contains(output, "claude: command not found") || strings.Contains(output, "not found") {
-			return result, fmt.Errorf("%w: %s", ErrClaudeNotFound, output)
+		if strings.Contains(result.Output, "not found") {
+			return result, fmt.Errorf("%w: %s", e.errNotFound(), result.Output)
 		}
-		return result, fmt.Errorf("claude execution failed: %w", err)
+		return result, fmt.Errorf("%s execution failed: %w", e.backend.Name(), err)
 	}
 
-	// Extract session ID from output
-	result.SessionID = e.extractSessionID(output)
+	result.SessionID = outputBuf.sessionID
 
 	return result, nil
 }
@@ -176,39 +270,16 @@ func (e *Executor) RunFollow(containerID string, prompt string, opts ExecuteOpti
 		return nil, errors.New("container is not running")
 	}
 
-	cmd := e.buildCommand(prompt, opts)
-	startedAt := time.Now()
-
-	// Create a buffer to capture output while streaming
-	var outputBuf bytes.Buffer
-	multiWriter := io.MultiWriter(output, &outputBuf)
-
-	// Execute with streaming output
-	execOpts := docker.ExecOptions{
-		Stdout: multiWriter,
-		Stderr: multiWriter,
-		TTY:    false,
-	}
-
-	err := e.docker.Exec(containerID, cmd, execOpts)
-	completedAt := time.Now()
-
-	result := &ExecutionResult{
-		Output:      outputBuf.String(),
-		StartedAt:   startedAt,
-		CompletedAt: completedAt,
-	}
-
-	if err != nil {
-		result.Error = err
-		result.ExitCode = 1
-		return result, fmt.Errorf("claude execution failed: %w", err)
-	}
-
-	// Extract session ID from captured output
-	result.SessionID = e.extractSessionID(outputBuf.String())
-
-	return result, nil
+	result, err := runWithContinuation(prompt, opts, output, e.backend, func(prompt string, opts ExecuteOptions) (*ExecutionResult, error) {
+		result, err := runWithFallback(opts, output, e.backend.ModelUnavailable, func(opts ExecuteOptions) (*ExecutionResult, error) {
+			return e.runSingleIteration(containerID, prompt, opts, output)
+		})
+		if err != nil {
+			return result, fmt.Errorf("%s execution failed: %w", e.backend.Name(), err)
+		}
+		return result, nil
+	})
+	return result, err
 }
 
 // RunRalph executes a Claude Code prompt in Ralph mode (autonomous loop).
@@ -235,6 +306,19 @@ func (e *Executor) RunRalph(containerID string, prompt string, opts RalphOptions
 		},
 	}
 
+	verifiers := opts.Verifiers
+	if len(verifiers) == 0 {
+		verifiers = defaultVerifiers(opts)
+	}
+
+	// Watch the full output for signals, in case the output is truncated
+	execOpts := opts.ExecuteOptions
+	for _, v := range verifiers {
+		if sv, ok := v.(*SignalVerifier); ok && sv.Signal != "" && !slices.Contains(execOpts.Signals, sv.Signal) {
+			execOpts.Signals = append(slices.Clip(execOpts.Signals), sv.Signal)
+		}
+	}
+
 	_, _ = fmt.Fprintf(output, "Running Ralph mode (max %d iterations, signal: %q)\n\n",
 		opts.MaxIterations, opts.CompletionSignal)
 
@@ -244,7 +328,12 @@ func (e *Executor) RunRalph(containerID string, prompt string, opts RalphOptions
 		_, _ = fmt.Fprintf(output, "\n=== Ralph iteration %d/%d ===\n", i, opts.MaxIterations)
 
 		// Run single iteration
-		iterResult, err := e.runSingleIteration(containerID, prompt, opts.ExecuteOptions, output)
+		iterResult, err := runWithFallback(execOpts, output, e.backend.ModelUnavailable, func(execOpts ExecuteOptions) (*ExecutionResult, error) {
+			return e.runSingleIteration(containerID, prompt, execOpts, output)
+		})
+		if iterResult != nil {
+			result.Model = iterResult.Model
+		}
 		if err != nil {
 			result.CompletedBy = "error"
 			result.Error = err
@@ -256,26 +345,28 @@ func (e *Executor) RunRalph(containerID string, prompt string, opts RalphOptions
 		if i == 1 && result.SessionID == "" {
 			result.SessionID = iterResult.SessionID
 		}
-
-		// Check for completion signal in output
-		if strings.Contains(iterResult.Output, opts.CompletionSignal) {
-			_, _ = fmt.Fprintf(output, "\n=== Completion signal detected: %s ===\n", opts.CompletionSignal)
-			result.CompletedBy = "signal"
-			result.CompletedAt = time.Now()
-			return result, nil
+		result.Checkpoints = append(result.Checkpoints, iterResult.Checkpoints...)
+
+		// Ask each verifier whether the task is complete
+		in := VerifyInput{
+			ContainerID: containerID,
+			Output:      iterResult.Output,
+			SignalsSeen: iterResult.SignalsSeen,
+			Docker:      e.docker,
+			Backend:     e.backend,
+			Log:         output,
 		}
-
-		// Run verify command if specified
-		if opts.VerifyCommand != "" {
-			_, _ = fmt.Fprintf(output, "\n--- Running verify command: %s ---\n", opts.VerifyCommand)
-			err := e.runVerifyCommand(containerID, opts.VerifyCommand, output)
-			if err == nil {
-				_, _ = fmt.Fprintf(output, "\n=== Verify command passed ===\n")
-				result.CompletedBy = "verify"
+		for _, v := range verifiers {
+			done, verifyErr := v.Verify(in)
+			if verifyErr != nil {
+				_, _ = fmt.Fprintf(output, "Verify failed: %v\n", verifyErr)
+				continue
+			}
+			if done {
+				result.CompletedBy = v.Name()
 				result.CompletedAt = time.Now()
 				return result, nil
 			}
-			_, _ = fmt.Fprintf(output, "Verify failed: %v\n", err)
 		}
 
 		// Cooldown between iterations
@@ -295,14 +386,14 @@ func (e *Executor) RunRalph(containerID string, prompt string, opts RalphOptions
 }
 
 // IsRunning checks if Claude Code is currently executing in a container.
-// This is a best-effort check by looking for claude processes.
+// This is a best-effort check by looking for the backend's processes.
 func (e *Executor) IsRunning(containerID string) (bool, error) {
 	if !e.docker.ContainerRunning(containerID) {
 		return false, nil
 	}
 
-	// Check for running claude processes
-	output, err := e.docker.ExecWithOutput(containerID, []string{"pgrep", "-f", "claude"})
+	// Check for running agent CLI processes
+	output, err := e.docker.ExecWithOutput(containerID, []string{"pgrep", "-f", e.backend.Binary()})
 	if err != nil {
 		// pgrep returns exit code 1 if no processes found (not an error)
 		return false, nil
@@ -312,50 +403,22 @@ func (e *Executor) IsRunning(containerID string) (bool, error) {
 	return strings.TrimSpace(output) != "", nil
 }
 
-// buildCommand constructs the Claude Code command line arguments.
+// buildCommand constructs the backend's command line arguments.
 func (e *Executor) buildCommand(prompt string, opts ExecuteOptions) []string {
-	cmd := []string{"claude", "-p", prompt}
-
-	// Use stream-json format for machine-parseable output
-	// --verbose is required when using --print with --output-format stream-json
-	cmd = append(cmd, "--output-format", "stream-json", "--verbose")
-
-	// Allowed tools
-	if len(opts.AllowedTools) > 0 {
-		cmd = append(cmd, "--allowedTools", strings.Join(opts.AllowedTools, ","))
-	}
-
-	// Disallowed tools
-	if len(opts.DisallowedTools) > 0 {
-		cmd = append(cmd, "--disallowedTools", strings.Join(opts.DisallowedTools, ","))
-	}
-
-	// Max turns
-	if opts.MaxTurns > 0 {
-		cmd = append(cmd, "--max-turns", strconv.Itoa(opts.MaxTurns))
-	}
-
-	// Model
-	if opts.Model != "" {
-		cmd = append(cmd, "--model", opts.Model)
-	}
-
-	// System prompt
-	if opts.SystemPrompt != "" {
-		cmd = append(cmd, "--append-system-prompt", opts.SystemPrompt)
-	}
-
-	return cmd
+	return e.backend.BuildCommand(prompt, opts)
 }
 
-// runSingleIteration executes a single Ralph iteration.
+// runSingleIteration executes a single streaming attempt with opts.Model. It
+// backs both RunFollow and each Ralph iteration.
 func (e *Executor) runSingleIteration(containerID string, prompt string, opts ExecuteOptions, output io.Writer) (*ExecutionResult, error) {
 	cmd := e.buildCommand(prompt, opts)
 	startedAt := time.Now()
 
 	// Create a buffer to capture output while streaming
-	var outputBuf bytes.Buffer
-	multiWriter := io.MultiWriter(output, &outputBuf)
+	outputBuf := newOutputBuffer(opts.MaxOutputBytes, e.backend, opts.Signals)
+	checkpoints := newCheckpointWriter(opts.OnCheckpoint)
+	progress := newProgressWriter(opts.ProgressPattern, opts.OnProgress)
+	multiWriter := io.MultiWriter(output, outputBuf, checkpoints, progress)
 
 	// Execute with streaming output
 	execOpts := docker.ExecOptions{
@@ -366,12 +429,12 @@ func (e *Executor) runSingleIteration(containerID string, prompt string, opts Ex
 
 	err := e.docker.Exec(containerID, cmd, execOpts)
 	completedAt := time.Now()
+	outputBuf.Flush()
+	checkpoints.Flush()
+	progress.Flush()
 
-	result := &ExecutionResult{
-		Output:      outputBuf.String(),
-		StartedAt:   startedAt,
-		CompletedAt: completedAt,
-	}
+	result := newExecutionResult(outputBuf, startedAt, completedAt)
+	result.Checkpoints = checkpoints.checkpoints
 
 	if err != nil {
 		result.Error = err
@@ -379,48 +442,29 @@ func (e *Executor) runSingleIteration(containerID string, prompt string, opts Ex
 		return result, err
 	}
 
-	// Extract session ID
-	result.SessionID = e.extractSessionID(outputBuf.String())
+	result.SessionID = outputBuf.sessionID
 
 	return result, nil
 }
 
-// runVerifyCommand executes a verification command and returns nil if it succeeds.
-func (e *Executor) runVerifyCommand(containerID string, command string, output io.Writer) error {
-	// Parse the command string into args
-	args := parseCommand(command)
-	if len(args) == 0 {
-		return errors.New("empty verify command")
+// newExecutionResult returns the result of a run whose output was captured
+// in outputBuf.
+func newExecutionResult(outputBuf *outputBuffer, startedAt, completedAt time.Time) *ExecutionResult {
+	return &ExecutionResult{
+		Output:          outputBuf.String(),
+		OutputTruncated: outputBuf.Truncated(),
+		SignalsSeen:     outputBuf.SignalsSeen(),
+		StartedAt:       startedAt,
+		CompletedAt:     completedAt,
 	}
-
-	execOpts := docker.ExecOptions{
-		Stdout: output,
-		Stderr: output,
-		TTY:    false,
-	}
-
-	return e.docker.Exec(containerID, args, execOpts)
 }
 
-// extractSessionID parses stream-json output to find the session ID.
-func (e *Executor) extractSessionID(output string) string {
-	scanner := bufio.NewScanner(strings.NewReader(output))
-	for scanner.Scan() {
-		line := scanner.Text()
-
-		// Skip empty lines
-		if strings.TrimSpace(line) == "" {
-			continue
-		}
-
-		var msg StreamMessage
-		if err := json.Unmarshal([]byte(line), &msg); err == nil {
-			if msg.SessionID != "" {
-				return msg.SessionID
-			}
-		}
+// errNotFound is the error for the backend's CLI missing from a container.
+func (e *Executor) errNotFound() error {
+	if _, ok := e.backend.(ClaudeBackend); ok {
+		return ErrClaudeNotFound
 	}
-	return ""
+	return fmt.Errorf("%s not found in container", e.backend.Binary())
 }
 
 // parseCommand splits a command string into arguments.
@@ -460,33 +504,36 @@ func parseCommand(cmd string) []string {
 	return args
 }
 
-// VerifyClaudeInstalled checks if Claude Code is installed in the container.
+// VerifyClaudeInstalled checks if Claude Code, or the configured backend's
+// CLI, is installed in the container.
 func (e *Executor) VerifyClaudeInstalled(containerID string) error {
 	if !e.docker.ContainerRunning(containerID) {
 		return errors.New("container is not running")
 	}
 
-	output, err := e.docker.ExecWithOutput(containerID, []string{"which", "claude"})
+	output, err := e.docker.ExecWithOutput(containerID, []string{"which", e.backend.Binary()})
 	if err != nil || strings.TrimSpace(output) == "" {
-		return ErrClaudeNotFound
+		return e.errNotFound()
 	}
 
 	return nil
 }
 
-// GetClaudeVersion returns the Claude Code version installed in the container.
+// GetClaudeVersion returns the version of Claude Code, or the configured
+// backend's CLI, installed in the container.
 func (e *Executor) GetClaudeVersion(containerID string) (string, error) {
 	if !e.docker.ContainerRunning(containerID) {
 		return "", errors.New("container is not running")
 	}
 
 	// Try to get version - some CLIs use --version, some use version subcommand
-	output, err := e.docker.ExecWithOutput(containerID, []string{"claude", "--version"})
+	bin := e.backend.Binary()
+	output, err := e.docker.ExecWithOutput(containerID, []string{bin, "--version"})
 	if err != nil {
 		// Try alternative
-		output, err = e.docker.ExecWithOutput(containerID, []string{"claude", "version"})
+		output, err = e.docker.ExecWithOutput(containerID, []string{bin, "version"})
 		if err != nil {
-			return "", fmt.Errorf("failed to get claude version: %w", err)
+			return "", fmt.Errorf("failed to get %s version: %w", bin, err)
 		}
 	}
 
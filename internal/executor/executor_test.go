@@ -175,9 +175,6 @@ func TestRunFollow(t *testing.T) {
 }
 
 func TestExtractSessionID(t *testing.T) {
-	docker := &mockDockerManager{}
-	executor := NewExecutor(docker)
-
 	tests := []struct {
 		name     string
 		output   string
@@ -214,8 +211,10 @@ func TestExtractSessionID(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := executor.extractSessionID(tt.output)
-			if result != tt.expected {
+			buf := newOutputBuffer(0, ClaudeBackend{}, nil)
+			_, _ = buf.Write([]byte(tt.output))
+			buf.Flush()
+			if result := buf.sessionID; result != tt.expected {
 				t.Errorf("expected %q, got %q", tt.expected, result)
 			}
 		})
@@ -0,0 +1,107 @@
+package executor
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// modelUnavailablePatterns are lowercase fragments of Claude Code and API
+// errors that mean the model itself couldn't serve the request, as opposed
+// to the task going wrong.
+var modelUnavailablePatterns = []string{
+	"rate_limit_error",
+	"overloaded_error",
+	"api error: 429",
+	"api error: 529",
+	"model_not_found",
+	"invalid model",
+	"model not found",
+}
+
+// isModelUnavailable reports whether a failed run's output shows a model
+// availability error (rate limiting, overload, or an unknown model).
+//
+// Only error results and non-JSON lines (the CLI's own stderr) are checked,
+// so an agent that merely reads or writes these strings while working on a
+// task doesn't trigger a fallback.
+func isModelUnavailable(output string) bool {
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		text := line
+		var msg struct {
+			Type    string `json:"type"`
+			IsError bool   `json:"is_error"`
+			Result  string `json:"result"`
+			Error   string `json:"error"`
+		}
+		if strings.HasPrefix(line, "{") && json.Unmarshal([]byte(line), &msg) == nil {
+			if msg.Type != "error" && !(msg.Type == "result" && msg.IsError) {
+				continue
+			}
+			text = msg.Result + " " + msg.Error
+		}
+
+		if matchesModelUnavailable(text) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesModelUnavailable checks a single error message against the known
+// model availability errors.
+func matchesModelUnavailable(text string) bool {
+	text = strings.ToLower(text)
+	for _, pattern := range modelUnavailablePatterns {
+		if strings.Contains(text, pattern) {
+			return true
+		}
+	}
+	// The API reports unknown models as a generic not_found_error
+	return strings.Contains(text, "not_found_error") && strings.Contains(text, "model")
+}
+
+// runWithFallback calls run with opts.Model, then once with each of
+// opts.ModelFallbacks in turn for as long as runs fail because the model is
+// unavailable, as judged by unavailable. The model that produced the returned result is recorded in
+// ExecutionResult.Model. Fallback notices are written to log if it is non-nil.
+func runWithFallback(opts ExecuteOptions, log io.Writer, unavailable func(output string) bool, run func(ExecuteOptions) (*ExecutionResult, error)) (*ExecutionResult, error) {
+	models := append([]string{opts.Model}, opts.ModelFallbacks...)
+
+	var result *ExecutionResult
+	var err error
+	for i, model := range models {
+		opts.Model = model
+		result, err = run(opts)
+		if result != nil {
+			result.Model = model
+		}
+
+		if err == nil || i == len(models)-1 || result == nil || !unavailable(result.Output) {
+			return result, err
+		}
+
+		if log != nil {
+			_, _ = fmt.Fprintf(log, "\n--- Model %s unavailable, retrying with %s ---\n", displayModel(model), models[i+1])
+		}
+	}
+	return result, err
+}
+
+// displayModel names a model for log output; an empty model is Claude Code's
+// default.
+func displayModel(model string) string {
+	if model == "" {
+		return "(default)"
+	}
+	return model
+}
@@ -0,0 +1,180 @@
+package executor
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bkonkle/tanuki/internal/docker"
+)
+
+func TestIsModelUnavailable(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   bool
+	}{
+		{
+			name:   "rate limited result",
+			output: `{"type":"result","is_error":true,"result":"API Error: 429 {\"type\":\"error\",\"error\":{\"type\":\"rate_limit_error\"}}"}`,
+			want:   true,
+		},
+		{
+			name:   "overloaded result",
+			output: `{"type":"result","is_error":true,"result":"API Error: 529 overloaded_error"}`,
+			want:   true,
+		},
+		{
+			name:   "unknown model",
+			output: `{"type":"result","is_error":true,"result":"API Error: 404 {\"error\":{\"type\":\"not_found_error\",\"message\":\"model: claude-nope\"}}"}`,
+			want:   true,
+		},
+		{
+			name:   "cli stderr",
+			output: "Error: Invalid model name claude-nope\n",
+			want:   true,
+		},
+		{
+			name:   "task failure",
+			output: `{"type":"result","is_error":true,"result":"Error: max turns reached"}`,
+			want:   false,
+		},
+		{
+			name:   "agent text mentioning rate limits",
+			output: `{"type":"assistant","content":"handle rate_limit_error from upstream"}` + "\n" + `{"type":"result","is_error":true,"result":"tests failed"}`,
+			want:   false,
+		},
+		{
+			name:   "successful result",
+			output: `{"type":"result","is_error":false,"result":"rate_limit_error handled"}`,
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isModelUnavailable(tt.output); got != tt.want {
+				t.Errorf("isModelUnavailable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// modelFromCommand returns the --model argument of a claude command.
+func modelFromCommand(cmd []string) string {
+	for i, arg := range cmd {
+		if arg == "--model" && i+1 < len(cmd) {
+			return cmd[i+1]
+		}
+	}
+	return ""
+}
+
+func TestRunFollow_ModelFallback(t *testing.T) {
+	unavailable := map[string]string{
+		"primary": `{"type":"result","is_error":true,"result":"API Error: 529 overloaded_error"}`,
+		"broken":  `{"type":"result","is_error":true,"result":"tests failed"}`,
+	}
+
+	tests := []struct {
+		name      string
+		model     string
+		fallbacks []string
+		wantTried []string
+		wantModel string
+		wantErr   bool
+	}{
+		{
+			name:      "falls back on availability errors",
+			model:     "primary",
+			fallbacks: []string{"primary", "backup"},
+			wantTried: []string{"primary", "primary", "backup"},
+			wantModel: "backup",
+		},
+		{
+			name:      "no fallback configured",
+			model:     "primary",
+			wantTried: []string{"primary"},
+			wantModel: "primary",
+			wantErr:   true,
+		},
+		{
+			name:      "task failures don't fall back",
+			model:     "broken",
+			fallbacks: []string{"backup"},
+			wantTried: []string{"broken"},
+			wantModel: "broken",
+			wantErr:   true,
+		},
+		{
+			name:      "fallbacks exhausted",
+			model:     "primary",
+			fallbacks: []string{"broken"},
+			wantTried: []string{"primary", "broken"},
+			wantModel: "broken",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tried []string
+			dockerMgr := &mockDockerManager{
+				execFn: func(_ string, cmd []string, opts docker.ExecOptions) error {
+					model := modelFromCommand(cmd)
+					tried = append(tried, model)
+					if out, ok := unavailable[model]; ok {
+						_, _ = opts.Stdout.Write([]byte(out + "\n"))
+						return errors.New("exit status 1")
+					}
+					_, _ = opts.Stdout.Write([]byte(`{"type":"result","session_id":"s1"}` + "\n"))
+					return nil
+				},
+			}
+
+			var out bytes.Buffer
+			opts := ExecuteOptions{Model: tt.model, ModelFallbacks: tt.fallbacks}
+			result, err := NewExecutor(dockerMgr).RunFollow("c1", "task", opts, &out)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RunFollow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if strings.Join(tried, ",") != strings.Join(tt.wantTried, ",") {
+				t.Errorf("tried models %v, want %v", tried, tt.wantTried)
+			}
+			if result.Model != tt.wantModel {
+				t.Errorf("result.Model = %q, want %q", result.Model, tt.wantModel)
+			}
+			if len(tt.wantTried) > 1 && !strings.Contains(out.String(), "unavailable, retrying with") {
+				t.Error("expected a fallback notice in the output")
+			}
+		})
+	}
+}
+
+func TestRun_ModelFallback(t *testing.T) {
+	var tried []string
+	dockerMgr := &mockDockerManager{
+		execWithOutputFn: func(_ string, cmd []string) (string, error) {
+			model := modelFromCommand(cmd)
+			tried = append(tried, model)
+			if model == "primary" {
+				return "API Error: 429 rate_limit_error", errors.New("exit status 1")
+			}
+			return `{"type":"result","session_id":"s1"}`, nil
+		},
+	}
+
+	opts := ExecuteOptions{Model: "primary", ModelFallbacks: []string{"backup"}}
+	result, err := NewExecutor(dockerMgr).Run("c1", "task", opts)
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if result.Model != "backup" || result.SessionID != "s1" {
+		t.Errorf("result = model %q, session %q; want backup, s1", result.Model, result.SessionID)
+	}
+	if len(tried) != 2 {
+		t.Errorf("tried %v, want primary then backup", tried)
+	}
+}
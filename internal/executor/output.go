@@ -0,0 +1,139 @@
+package executor
+
+import (
+	"fmt"
+	"strings"
+)
+
+// outputBuffer captures a run's output for ExecutionResult.Output. With a
+// limit it keeps the first and last halves of the limit and drops the
+// middle, so a runaway agent can't exhaust Tanuki's memory. Signals and the
+// session ID are found by scanning each line as it streams past, so they are
+// reported even when the lines themselves are dropped.
+type outputBuffer struct {
+	lineWriter
+	max     int
+	head    []byte
+	tail    []byte
+	written int
+
+	backend   Backend
+	signals   []string
+	seen      map[string]bool
+	sessionID string
+}
+
+func newOutputBuffer(max int, backend Backend, signals []string) *outputBuffer {
+	b := &outputBuffer{
+		max:     max,
+		backend: backend,
+		signals: signals,
+		seen:    make(map[string]bool),
+	}
+	b.onLine = b.processLine
+	return b
+}
+
+// Write keeps p within the limit and scans it for signals and the session ID.
+func (b *outputBuffer) Write(p []byte) (int, error) {
+	b.written += len(p)
+	if b.max <= 0 {
+		b.head = append(b.head, p...)
+	} else {
+		b.retain(p)
+	}
+	if b.sessionID == "" || len(b.signals) > 0 {
+		_, _ = b.lineWriter.Write(p)
+		// A line too long to keep is scanned as far as it has gone
+		if b.max > 0 && len(b.buf) > b.max {
+			b.onLine(string(b.buf))
+			b.buf = b.buf[:0]
+		}
+	}
+	return len(p), nil
+}
+
+// retain appends p to the head until it is full, then to the tail, which is
+// compacted to its last half of the limit once it grows to twice that.
+func (b *outputBuffer) retain(p []byte) {
+	headMax := b.max / 2
+	tailMax := b.max - headMax
+
+	if room := headMax - len(b.head); room > 0 {
+		n := min(room, len(p))
+		b.head = append(b.head, p[:n]...)
+		p = p[n:]
+	}
+
+	if len(p) >= tailMax {
+		b.tail = append(b.tail[:0], p[len(p)-tailMax:]...)
+		return
+	}
+	b.tail = append(b.tail, p...)
+	if len(b.tail) >= 2*tailMax {
+		b.tail = append(b.tail[:0], b.tail[len(b.tail)-tailMax:]...)
+	}
+}
+
+func (b *outputBuffer) processLine(line string) {
+	if b.sessionID == "" {
+		b.sessionID = b.backend.ExtractSessionID(line)
+	}
+	for _, signal := range b.signals {
+		if !b.seen[signal] && b.backend.SignalDetected(line, signal) {
+			b.seen[signal] = true
+		}
+	}
+}
+
+// Flush scans any final line without a trailing newline.
+func (b *outputBuffer) Flush() {
+	b.lineWriter.Flush()
+}
+
+// Truncated is how many bytes of output were dropped from the middle.
+func (b *outputBuffer) Truncated() int {
+	if b.max <= 0 {
+		return 0
+	}
+	return b.written - len(b.head) - len(b.tailKept())
+}
+
+// String returns the retained output, with a marker where output was dropped.
+func (b *outputBuffer) String() string {
+	tail := b.tailKept()
+	dropped := b.Truncated()
+	if dropped == 0 {
+		return string(b.head) + string(tail)
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(b.head) + len(tail) + 64)
+	sb.Write(b.head)
+	fmt.Fprintf(&sb, "\n... [%d bytes of output truncated] ...\n", dropped)
+	sb.Write(tail)
+	return sb.String()
+}
+
+// SignalsSeen returns the watched signals that appeared in the output, in
+// the order they were given.
+func (b *outputBuffer) SignalsSeen() []string {
+	var seen []string
+	for _, signal := range b.signals {
+		if b.seen[signal] {
+			seen = append(seen, signal)
+		}
+	}
+	return seen
+}
+
+// tailKept is the part of the tail within the limit.
+func (b *outputBuffer) tailKept() []byte {
+	if b.max <= 0 {
+		return b.tail
+	}
+	if tailMax := b.max - b.max/2; len(b.tail) > tailMax {
+		return b.tail[len(b.tail)-tailMax:]
+	}
+	return b.tail
+}
@@ -0,0 +1,108 @@
+package executor
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bkonkle/tanuki/internal/docker"
+)
+
+func TestOutputBuffer_Unlimited(t *testing.T) {
+	buf := newOutputBuffer(0, ClaudeBackend{}, nil)
+	_, _ = buf.Write([]byte("hello "))
+	_, _ = buf.Write([]byte("world\n"))
+	buf.Flush()
+
+	if got := buf.String(); got != "hello world\n" {
+		t.Errorf("String() = %q, want all output", got)
+	}
+	if buf.Truncated() != 0 {
+		t.Errorf("Truncated() = %d, want 0", buf.Truncated())
+	}
+}
+
+func TestOutputBuffer_KeepsHeadAndTail(t *testing.T) {
+	buf := newOutputBuffer(20, ClaudeBackend{}, nil)
+	for i := 0; i < 100; i++ {
+		_, _ = fmt.Fprintf(buf, "%03d\n", i)
+	}
+	buf.Flush()
+
+	got := buf.String()
+	if !strings.HasPrefix(got, "000\n001\n00") {
+		t.Errorf("String() = %q, want the first 10 bytes first", got)
+	}
+	if !strings.HasSuffix(got, "7\n098\n099\n") {
+		t.Errorf("String() = %q, want the last 10 bytes last", got)
+	}
+	if buf.Truncated() != 380 {
+		t.Errorf("Truncated() = %d, want 380", buf.Truncated())
+	}
+	if !strings.Contains(got, "[380 bytes of output truncated]") {
+		t.Errorf("String() = %q, want a truncation marker", got)
+	}
+}
+
+func TestOutputBuffer_LargeWrite(t *testing.T) {
+	buf := newOutputBuffer(10, ClaudeBackend{}, nil)
+	_, _ = buf.Write([]byte("abcdefghijklmnopqrstuvwxyz"))
+
+	got := buf.String()
+	if !strings.HasPrefix(got, "abcde") || !strings.HasSuffix(got, "vwxyz") {
+		t.Errorf("String() = %q, want abcde...vwxyz", got)
+	}
+	if buf.Truncated() != 16 {
+		t.Errorf("Truncated() = %d, want 16", buf.Truncated())
+	}
+}
+
+func TestOutputBuffer_ScansTruncatedOutput(t *testing.T) {
+	buf := newOutputBuffer(64, ClaudeBackend{}, []string{"DONE", "NEVER"})
+	_, _ = buf.Write([]byte(strings.Repeat("x", 100) + "\n"))
+	_, _ = buf.Write([]byte(`{"type":"init","session_id":"abc-123"}` + "\n"))
+	_, _ = buf.Write([]byte("all DONE here\n"))
+	_, _ = buf.Write([]byte(strings.Repeat("y", 100) + "\n"))
+	buf.Flush()
+
+	if strings.Contains(buf.String(), "DONE") || strings.Contains(buf.String(), "abc-123") {
+		t.Fatalf("String() = %q, want the signal and session truncated", buf.String())
+	}
+	if buf.sessionID != "abc-123" {
+		t.Errorf("sessionID = %q, want abc-123", buf.sessionID)
+	}
+	if got := buf.SignalsSeen(); !reflect.DeepEqual(got, []string{"DONE"}) {
+		t.Errorf("SignalsSeen() = %v, want [DONE]", got)
+	}
+}
+
+func TestRunFollow_MaxOutputBytes(t *testing.T) {
+	dm := &mockDockerManager{
+		containerRunningFn: func(string) bool { return true },
+		execFn: func(_ string, _ []string, opts docker.ExecOptions) error {
+			_, _ = io.WriteString(opts.Stdout, `{"type":"init","session_id":"s-1"}`+"\n")
+			_, _ = io.WriteString(opts.Stdout, strings.Repeat("z", 4096)+"\n")
+			return nil
+		},
+	}
+	e := NewExecutor(dm)
+
+	var streamed bytes.Buffer
+	result, err := e.RunFollow("c1", "prompt", ExecuteOptions{MaxOutputBytes: 1024}, &streamed)
+	if err != nil {
+		t.Fatalf("RunFollow() error = %v", err)
+	}
+
+	if streamed.Len() < 4096 {
+		t.Errorf("streamed %d bytes, want all output streamed", streamed.Len())
+	}
+	if result.OutputTruncated == 0 || len(result.Output) > 1100 {
+		t.Errorf("Output is %d bytes with %d truncated, want it capped near 1024", len(result.Output), result.OutputTruncated)
+	}
+	if result.SessionID != "s-1" {
+		t.Errorf("SessionID = %q, want s-1", result.SessionID)
+	}
+}
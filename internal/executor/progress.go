@@ -0,0 +1,113 @@
+package executor
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// DefaultProgressPattern matches progress lines like "Progress: 3/7 files" or
+// "Progress: 40%".
+const DefaultProgressPattern = `Progress:\s*(?:(\d+)\s*/\s*(\d+)|(\d+(?:\.\d+)?)\s*%)`
+
+var defaultProgressRegexp = regexp.MustCompile(DefaultProgressPattern)
+
+// CompileProgressPattern compiles a progress pattern, or returns the default
+// pattern when it is empty. A pattern must have at least one capture group:
+// with two matched groups they are read as done and total, with one as a
+// percentage.
+func CompileProgressPattern(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return defaultProgressRegexp, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if re.NumSubexp() == 0 {
+		return nil, fmt.Errorf("progress pattern %q has no capture groups", pattern)
+	}
+	return re, nil
+}
+
+// ParseProgress returns the last progress percentage reported in output, or
+// false if the output has no progress lines. A nil pattern uses
+// DefaultProgressPattern.
+func ParseProgress(output string, pattern *regexp.Regexp) (int, bool) {
+	w := newProgressWriter(pattern, nil)
+	_, _ = w.Write([]byte(output))
+	w.Flush()
+	if w.percent < 0 {
+		return 0, false
+	}
+	return w.percent, true
+}
+
+// progressWriter scans streamed output line by line for progress lines.
+type progressWriter struct {
+	lineWriter
+	pattern    *regexp.Regexp
+	percent    int
+	onProgress func(percent int)
+}
+
+func newProgressWriter(pattern *regexp.Regexp, onProgress func(percent int)) *progressWriter {
+	if pattern == nil {
+		pattern = defaultProgressRegexp
+	}
+	w := &progressWriter{
+		pattern:    pattern,
+		percent:    -1,
+		onProgress: onProgress,
+	}
+	w.onLine = w.processLine
+	return w
+}
+
+func (w *progressWriter) processLine(line string) {
+	for _, l := range lineTexts(line) {
+		percent, ok := matchProgress(w.pattern, l)
+		if !ok || percent == w.percent {
+			continue
+		}
+		w.percent = percent
+		if w.onProgress != nil {
+			w.onProgress(percent)
+		}
+	}
+}
+
+// matchProgress converts a progress line to a percentage clamped to 0-100.
+func matchProgress(pattern *regexp.Regexp, line string) (int, bool) {
+	match := pattern.FindStringSubmatch(line)
+	if match == nil {
+		return 0, false
+	}
+
+	var groups []float64
+	for _, group := range match[1:] {
+		if group == "" {
+			continue
+		}
+		n, err := strconv.ParseFloat(group, 64)
+		if err != nil {
+			return 0, false
+		}
+		groups = append(groups, n)
+	}
+
+	var percent float64
+	switch {
+	case len(groups) >= 2:
+		if groups[1] <= 0 {
+			return 0, false
+		}
+		percent = groups[0] / groups[1] * 100
+	case len(groups) == 1:
+		percent = groups[0]
+	default:
+		return 0, false
+	}
+
+	return int(min(max(percent, 0), 100)), true
+}
@@ -0,0 +1,98 @@
+package executor
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestParseProgress(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		pattern string
+		want    int
+		wantOK  bool
+	}{
+		{
+			name:   "no markers",
+			output: "working...\ndone\n",
+		},
+		{
+			name:   "done and total",
+			output: "Progress: 1/7 files\nProgress: 3/7 files\n",
+			want:   42,
+			wantOK: true,
+		},
+		{
+			name:   "percentage",
+			output: "Progress: 65.5%\n",
+			want:   65,
+			wantOK: true,
+		},
+		{
+			name:   "clamped",
+			output: "Progress: 9/7 files\n",
+			want:   100,
+			wantOK: true,
+		},
+		{
+			name:   "zero total ignored",
+			output: "Progress: 2/0\n",
+		},
+		{
+			name:   "stream json",
+			output: `{"type":"assistant","message":{"content":[{"type":"text","text":"Migrating.\nProgress: 2/4 tables"}]}}` + "\n",
+			want:   50,
+			wantOK: true,
+		},
+		{
+			name:    "custom pattern",
+			output:  "[step 3 of 4]\n",
+			pattern: `^\[step (\d+) of (\d+)\]$`,
+			want:    75,
+			wantOK:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pattern, err := CompileProgressPattern(tt.pattern)
+			if err != nil {
+				t.Fatalf("CompileProgressPattern() error = %v", err)
+			}
+			got, ok := ParseProgress(tt.output, pattern)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("ParseProgress() = %d, %v, want %d, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestCompileProgressPattern(t *testing.T) {
+	if re, err := CompileProgressPattern(""); err != nil || re.String() != DefaultProgressPattern {
+		t.Errorf("CompileProgressPattern(\"\") = %v, %v, want the default pattern", re, err)
+	}
+	if _, err := CompileProgressPattern(`Progress: \d+`); err == nil {
+		t.Error("CompileProgressPattern() without groups should fail")
+	}
+	if _, err := CompileProgressPattern(`(`); err == nil {
+		t.Error("CompileProgressPattern() with an invalid regex should fail")
+	}
+}
+
+func TestProgressWriter_ReportsChanges(t *testing.T) {
+	var reported []int
+	w := newProgressWriter(regexp.MustCompile(DefaultProgressPattern), func(percent int) {
+		reported = append(reported, percent)
+	})
+
+	_, _ = w.Write([]byte("Progress: 1/4\nProgress: 1/4\nProg"))
+	_, _ = w.Write([]byte("ress: 2/4\nProgress: 100%"))
+	w.Flush()
+
+	want := []int{25, 50, 100}
+	if !reflect.DeepEqual(reported, want) {
+		t.Errorf("reported = %v, want %v", reported, want)
+	}
+}
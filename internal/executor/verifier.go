@@ -0,0 +1,192 @@
+package executor
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bkonkle/tanuki/internal/docker"
+)
+
+// VerifyInput is what a CompletionVerifier sees after each Ralph iteration.
+type VerifyInput struct {
+	// ContainerID is the agent container the iteration ran in
+	ContainerID string
+
+	// Output is the agent's output from this iteration
+	Output string
+
+	// SignalsSeen are the signals found while the output streamed, including
+	// any in output truncated from Output
+	SignalsSeen []string
+
+	// Docker runs commands in the agent container
+	Docker DockerManager
+
+	// Backend reads the agent's output (optional; plain text if nil)
+	Backend Backend
+
+	// Log receives any diagnostic output from the verifier
+	Log io.Writer
+}
+
+// CompletionVerifier decides whether a Ralph mode iteration completed the task.
+// Verify returns true when the task is complete. A non-nil error means the
+// check itself failed; the loop logs it and keeps iterating.
+type CompletionVerifier interface {
+	// Name identifies the verifier, and is reported in RalphResult.CompletedBy
+	Name() string
+
+	Verify(in VerifyInput) (bool, error)
+}
+
+// SignalVerifier completes when the agent output contains Signal.
+type SignalVerifier struct {
+	Signal string
+}
+
+// Name implements CompletionVerifier.
+func (v *SignalVerifier) Name() string { return "signal" }
+
+// Verify implements CompletionVerifier.
+func (v *SignalVerifier) Verify(in VerifyInput) (bool, error) {
+	detected := strings.Contains
+	if in.Backend != nil {
+		detected = in.Backend.SignalDetected
+	}
+	if v.Signal == "" || (!slices.Contains(in.SignalsSeen, v.Signal) && !detected(in.Output, v.Signal)) {
+		return false, nil
+	}
+	_, _ = fmt.Fprintf(in.Log, "\n=== Completion signal detected: %s ===\n", v.Signal)
+	return true, nil
+}
+
+// CommandVerifier runs Command in the agent container and completes when it
+// exits 0. If the last line of output is a JSON object with a boolean
+// "complete" field, that field must also be true, so scripts can report
+// "ran fine, but not done yet".
+type CommandVerifier struct {
+	Command string
+}
+
+// Name implements CompletionVerifier.
+func (v *CommandVerifier) Name() string { return "verify" }
+
+// Verify implements CompletionVerifier.
+func (v *CommandVerifier) Verify(in VerifyInput) (bool, error) {
+	args := parseCommand(v.Command)
+	if len(args) == 0 {
+		return false, errors.New("empty verify command")
+	}
+
+	_, _ = fmt.Fprintf(in.Log, "\n--- Running verify command: %s ---\n", v.Command)
+
+	var out bytes.Buffer
+	w := io.MultiWriter(in.Log, &out)
+	execOpts := docker.ExecOptions{
+		Stdout: w,
+		Stderr: w,
+		TTY:    false,
+	}
+	if err := in.Docker.Exec(in.ContainerID, args, execOpts); err != nil {
+		return false, err
+	}
+
+	if complete, message, ok := parseVerifyStatus(out.String()); ok && !complete {
+		msg := "verify command reported incomplete"
+		if message != "" {
+			msg += ": " + message
+		}
+		return false, errors.New(msg)
+	}
+
+	_, _ = fmt.Fprintf(in.Log, "\n=== Verify command passed ===\n")
+	return true, nil
+}
+
+// parseVerifyStatus looks for a JSON status object such as
+// {"complete": false, "message": "2 tests failing"} on the last non-empty
+// line of output. ok is false if there is no such object.
+func parseVerifyStatus(output string) (complete bool, message string, ok bool) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	last := strings.TrimSpace(lines[len(lines)-1])
+	if !strings.HasPrefix(last, "{") {
+		return false, "", false
+	}
+
+	var status struct {
+		Complete *bool  `json:"complete"`
+		Message  string `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(last), &status); err != nil || status.Complete == nil {
+		return false, "", false
+	}
+	return *status.Complete, status.Message, true
+}
+
+// DefaultHTTPVerifyTimeout bounds each HTTPVerifier request.
+const DefaultHTTPVerifyTimeout = 30 * time.Second
+
+// HTTPVerifier completes when a GET request to URL returns the expected
+// status code (200 if Status is zero). The request is made from the host,
+// so URL must be reachable from where tanuki runs.
+type HTTPVerifier struct {
+	URL    string
+	Status int
+
+	// Client overrides the HTTP client (optional)
+	Client *http.Client
+}
+
+// Name implements CompletionVerifier.
+func (v *HTTPVerifier) Name() string { return "http" }
+
+// ExpectedStatus returns the status code that counts as complete.
+func (v *HTTPVerifier) ExpectedStatus() int {
+	if v.Status == 0 {
+		return http.StatusOK
+	}
+	return v.Status
+}
+
+// Verify implements CompletionVerifier.
+func (v *HTTPVerifier) Verify(in VerifyInput) (bool, error) {
+	want := v.ExpectedStatus()
+
+	client := v.Client
+	if client == nil {
+		client = &http.Client{Timeout: DefaultHTTPVerifyTimeout}
+	}
+
+	_, _ = fmt.Fprintf(in.Log, "\n--- Checking %s (want %d) ---\n", v.URL, want)
+
+	resp, err := client.Get(v.URL) //nolint:gosec // G107: URL comes from the task's completion config
+	if err != nil {
+		return false, fmt.Errorf("request %s: %w", v.URL, err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != want {
+		return false, fmt.Errorf("%s returned %d, want %d", v.URL, resp.StatusCode, want)
+	}
+
+	_, _ = fmt.Fprintf(in.Log, "\n=== HTTP check passed ===\n")
+	return true, nil
+}
+
+// defaultVerifiers returns the verifiers implied by the signal and verify
+// command options, in the order RunRalph has always checked them.
+func defaultVerifiers(opts RalphOptions) []CompletionVerifier {
+	verifiers := []CompletionVerifier{&SignalVerifier{Signal: opts.CompletionSignal}}
+	if opts.VerifyCommand != "" {
+		verifiers = append(verifiers, &CommandVerifier{Command: opts.VerifyCommand})
+	}
+	return verifiers
+}
@@ -0,0 +1,183 @@
+package executor
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bkonkle/tanuki/internal/docker"
+)
+
+// scriptDocker returns a mock whose Exec writes output and returns err.
+func scriptDocker(output string, err error) *mockDockerManager {
+	return &mockDockerManager{
+		execFn: func(_ string, _ []string, opts docker.ExecOptions) error {
+			_, _ = io.WriteString(opts.Stdout, output)
+			return err
+		},
+	}
+}
+
+func TestSignalVerifier(t *testing.T) {
+	v := &SignalVerifier{Signal: "DONE"}
+
+	tests := []struct {
+		output string
+		want   bool
+	}{
+		{"all finished. DONE", true},
+		{"still working", false},
+	}
+
+	for _, tt := range tests {
+		got, err := v.Verify(VerifyInput{Output: tt.output, Log: io.Discard})
+		if err != nil {
+			t.Errorf("Verify(%q) error = %v", tt.output, err)
+		}
+		if got != tt.want {
+			t.Errorf("Verify(%q) = %v, want %v", tt.output, got, tt.want)
+		}
+	}
+
+	// A signal seen in output that was truncated still completes
+	in := VerifyInput{Output: "head ... tail", SignalsSeen: []string{"DONE"}, Log: io.Discard}
+	if got, _ := v.Verify(in); !got {
+		t.Error("Verify() = false, want true for a signal in SignalsSeen")
+	}
+}
+
+func TestCommandVerifier(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		execErr error
+		want    bool
+		wantErr string
+	}{
+		{name: "exit zero", output: "ok\n", want: true},
+		{name: "non-zero exit", output: "FAIL\n", execErr: errors.New("exit status 1"), wantErr: "exit status 1"},
+		{name: "json complete", output: "running\n{\"complete\": true}\n", want: true},
+		{name: "json incomplete", output: "{\"complete\": false, \"message\": \"2 tests failing\"}", wantErr: "2 tests failing"},
+		{name: "json without complete field", output: "{\"coverage\": 80}", want: true},
+		{name: "json not on last line", output: "{\"complete\": false}\nok", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &CommandVerifier{Command: "./check.sh"}
+			got, err := v.Verify(VerifyInput{
+				ContainerID: "c1",
+				Docker:      scriptDocker(tt.output, tt.execErr),
+				Log:         io.Discard,
+			})
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Verify() error = %v, want containing %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Errorf("Verify() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommandVerifier_Empty(t *testing.T) {
+	v := &CommandVerifier{Command: "  "}
+	if _, err := v.Verify(VerifyInput{Docker: &mockDockerManager{}, Log: io.Discard}); err == nil {
+		t.Error("Verify() error = nil, want error for empty command")
+	}
+}
+
+func TestHTTPVerifier(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ready":
+			w.WriteHeader(http.StatusOK)
+		case "/created":
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name   string
+		path   string
+		status int
+		want   bool
+	}{
+		{"default 200", "/ready", 0, true},
+		{"custom status", "/created", http.StatusCreated, true},
+		{"unexpected status", "/down", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &HTTPVerifier{URL: srv.URL + tt.path, Status: tt.status, Client: srv.Client()}
+			got, err := v.Verify(VerifyInput{Log: io.Discard})
+			if got != tt.want {
+				t.Errorf("Verify() = %v (err %v), want %v", got, err, tt.want)
+			}
+			if !tt.want && err == nil {
+				t.Error("Verify() error = nil, want a reason for the failure")
+			}
+		})
+	}
+}
+
+func TestRunRalph_Verifiers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name   string
+		opts   RalphOptions
+		output string
+		want   string
+	}{
+		{
+			name:   "default signal",
+			output: "work complete DONE",
+			want:   "signal",
+		},
+		{
+			name:   "default verify command",
+			opts:   RalphOptions{VerifyCommand: "make test"},
+			output: "no signal here",
+			want:   "verify",
+		},
+		{
+			name: "custom http verifier",
+			opts: RalphOptions{Verifiers: []CompletionVerifier{
+				&HTTPVerifier{URL: srv.URL, Client: srv.Client()},
+			}},
+			output: "DONE is ignored without a signal verifier",
+			want:   "http",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exec := NewExecutor(scriptDocker(tt.output, nil))
+			tt.opts.MaxIterations = 1
+
+			result, err := exec.RunRalph("c1", "do it", tt.opts, io.Discard)
+			if err != nil {
+				t.Fatalf("RunRalph() error = %v", err)
+			}
+			if result.CompletedBy != tt.want {
+				t.Errorf("CompletedBy = %q, want %q", result.CompletedBy, tt.want)
+			}
+		})
+	}
+}
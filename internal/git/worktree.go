@@ -8,7 +8,9 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/bkonkle/tanuki/internal/config"
 )
@@ -22,14 +24,26 @@ var ErrBranchExists = errors.New("branch already exists")
 // ErrWorktreeExists indicates the worktree path already exists.
 var ErrWorktreeExists = errors.New("worktree already exists")
 
+// ErrBranchNotFound indicates a requested existing branch does not exist.
+var ErrBranchNotFound = errors.New("branch not found")
+
 // Manager handles Git worktree operations for agent isolation.
 type Manager struct {
 	repoRoot     string
 	branchPrefix string
+	sshKeyPath   string
+
+	// worktreeDir is git.worktree_dir as configured; see WorktreeDir
+	worktreeDir string
+
+	// mainBranch caches the detected default branch; see InvalidateMainBranch
+	mainBranchMu sync.Mutex
+	mainBranch   string
 }
 
 // NewManager creates a new Git worktree manager.
-// It requires the current directory to be within a Git repository.
+// It requires the current directory to be within a Git repository, and the
+// worktree directory to be writable.
 func NewManager(cfg *config.Config) (*Manager, error) {
 	if !IsGitRepo() {
 		return nil, ErrNotGitRepo
@@ -40,18 +54,66 @@ func NewManager(cfg *config.Config) (*Manager, error) {
 		return nil, fmt.Errorf("failed to get repo root: %w", err)
 	}
 
-	return &Manager{
+	m := &Manager{
 		repoRoot:     root,
 		branchPrefix: cfg.Git.BranchPrefix,
-	}, nil
+		sshKeyPath:   cfg.Git.SSHKeyPath,
+		worktreeDir:  cfg.Git.WorktreeDir,
+	}
+	if err := checkWritable(m.WorktreeDir()); err != nil {
+		return nil, fmt.Errorf("worktree directory %s is not writable: %w", m.WorktreeDir(), err)
+	}
+	return m, nil
+}
+
+// checkWritable reports whether files can be created in dir. A directory
+// that doesn't exist yet is checked through its nearest existing parent,
+// since CreateWorktree creates it as needed.
+func checkWritable(dir string) error {
+	for {
+		info, err := os.Stat(dir)
+		if err == nil {
+			if !info.IsDir() {
+				return fmt.Errorf("%s is not a directory", dir)
+			}
+			break
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return err
+		}
+		dir = parent
+	}
+
+	f, err := os.CreateTemp(dir, ".tanuki-write-check-*")
+	if err != nil {
+		return err
+	}
+	_ = f.Close()
+	return os.Remove(f.Name())
+}
+
+// WorktreeDir returns the absolute directory new agent worktrees are created
+// in, from git.worktree_dir.
+func (m *Manager) WorktreeDir() string {
+	dir := m.worktreeDir
+	if dir == "" {
+		dir = config.DefaultWorktreeDir
+	}
+	if filepath.IsAbs(dir) {
+		return filepath.Clean(dir)
+	}
+	return filepath.Join(m.repoRoot, dir)
 }
 
 // CreateWorktree creates a new worktree with a new branch for an agent.
-// The worktree is created at .tanuki/worktrees/<name>/ with branch tanuki/<name>.
+// The worktree is created at <worktree dir>/<name>/ with branch tanuki/<name>.
 func (m *Manager) CreateWorktree(name string) (string, error) {
 	branchName := m.branchName(name)
-	worktreePath := m.worktreePath(name)
-	absWorktreePath := filepath.Join(m.repoRoot, worktreePath)
+	absWorktreePath := filepath.Join(m.WorktreeDir(), name)
 
 	// Check if branch already exists
 	if m.branchExists(branchName) {
@@ -60,7 +122,7 @@ func (m *Manager) CreateWorktree(name string) (string, error) {
 
 	// Check if worktree path already exists
 	if _, err := os.Stat(absWorktreePath); err == nil {
-		return "", fmt.Errorf("%w: %s", ErrWorktreeExists, worktreePath)
+		return "", fmt.Errorf("%w: %s", ErrWorktreeExists, absWorktreePath)
 	}
 
 	// Ensure parent directory exists
@@ -81,10 +143,45 @@ func (m *Manager) CreateWorktree(name string) (string, error) {
 	return absWorktreePath, nil
 }
 
+// CreateWorktreeForBranch creates a worktree for an agent that checks out an
+// existing branch, instead of creating a new tanuki/<name> branch. The branch
+// must exist and must not be checked out in another worktree.
+func (m *Manager) CreateWorktreeForBranch(name string, branch string) (string, error) {
+	absWorktreePath := filepath.Join(m.WorktreeDir(), name)
+
+	if !m.branchExists(branch) {
+		return "", fmt.Errorf("%w: %s", ErrBranchNotFound, branch)
+	}
+
+	// Check if worktree path already exists
+	if _, err := os.Stat(absWorktreePath); err == nil {
+		return "", fmt.Errorf("%w: %s", ErrWorktreeExists, absWorktreePath)
+	}
+
+	// Ensure parent directory exists
+	parentDir := filepath.Dir(absWorktreePath)
+	if err := os.MkdirAll(parentDir, 0750); err != nil {
+		return "", fmt.Errorf("failed to create worktree parent directory: %w", err)
+	}
+
+	cmd := exec.Command("git", "worktree", "add", absWorktreePath, branch) //nolint:gosec // G204: branch was verified to exist and absWorktreePath is derived from validated config inputs
+	cmd.Dir = m.repoRoot
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to create worktree: %s", stderr.String())
+	}
+
+	return absWorktreePath, nil
+}
+
 // RemoveWorktree removes the worktree and optionally the associated branch.
+// Only tanuki's own <prefix><name> branch is ever deleted; an existing branch
+// the agent was spawned on is left alone.
 func (m *Manager) RemoveWorktree(name string, deleteBranch bool) error {
-	absWorktreePath := filepath.Join(m.repoRoot, m.worktreePath(name))
+	absWorktreePath := m.worktreePath(name)
 	branchName := m.branchName(name)
+	ownBranch := m.agentBranch(name) == branchName
 
 	// Remove worktree
 	cmd := exec.Command("git", "worktree", "remove", absWorktreePath, "--force") //nolint:gosec // G204: absWorktreePath is derived from validated config inputs
@@ -96,7 +193,7 @@ func (m *Manager) RemoveWorktree(name string, deleteBranch bool) error {
 	}
 
 	// Optionally delete branch
-	if deleteBranch {
+	if deleteBranch && ownBranch {
 		cmd = exec.Command("git", "branch", "-D", branchName) //nolint:gosec // G204: branchName is derived from validated config inputs
 		cmd.Dir = m.repoRoot
 		// Ignore error if branch doesn't exist
@@ -109,7 +206,7 @@ func (m *Manager) RemoveWorktree(name string, deleteBranch bool) error {
 // GetDiff returns the diff between the agent's branch and the base branch.
 // The diff shows all changes made in the agent's worktree.
 func (m *Manager) GetDiff(name string, baseBranch string) (string, error) {
-	branchName := m.branchName(name)
+	branchName := m.agentBranch(name)
 
 	// Use three-dot syntax to show changes introduced in branchName
 	cmd := exec.Command("git", "diff", baseBranch+"..."+branchName) //nolint:gosec // G204: baseBranch and branchName are derived from validated config inputs
@@ -126,9 +223,59 @@ func (m *Manager) GetDiff(name string, baseBranch string) (string, error) {
 	return string(output), nil
 }
 
+// CommitsAhead returns how many commits the agent's branch has that the base
+// branch doesn't.
+func (m *Manager) CommitsAhead(name string, baseBranch string) (int, error) {
+	branchName := m.agentBranch(name)
+
+	cmd := exec.Command("git", "rev-list", "--count", baseBranch+".."+branchName) //nolint:gosec // G204: baseBranch and branchName are derived from validated config inputs
+	cmd.Dir = m.repoRoot
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return 0, fmt.Errorf("failed to count commits: %s", string(exitErr.Stderr))
+		}
+		return 0, fmt.Errorf("failed to count commits: %w", err)
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(string(output)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse commit count: %w", err)
+	}
+	return count, nil
+}
+
+// Push pushes the agent's branch to origin and sets it as the upstream.
+// If git.ssh_key_path is configured, that key is used for SSH remotes, the
+// same key agents use inside their containers.
+func (m *Manager) Push(name string) error {
+	branchName := m.agentBranch(name)
+
+	cmd := exec.Command("git", "push", "--set-upstream", "origin", branchName) //nolint:gosec // G204: branchName is derived from validated config inputs
+	cmd.Dir = m.repoRoot
+
+	if m.sshKeyPath != "" {
+		keyPath, err := config.ExpandHome(m.sshKeyPath)
+		if err != nil {
+			return err
+		}
+		cmd.Env = append(os.Environ(),
+			fmt.Sprintf("GIT_SSH_COMMAND=ssh -i '%s' -o IdentitiesOnly=yes", keyPath))
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to push %s: %s", branchName, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
 // GetStatus returns uncommitted changes in the agent's worktree.
 func (m *Manager) GetStatus(name string) (string, error) {
-	absWorktreePath := filepath.Join(m.repoRoot, m.worktreePath(name))
+	absWorktreePath := m.worktreePath(name)
 
 	// Check if worktree exists
 	if _, err := os.Stat(absWorktreePath); os.IsNotExist(err) {
@@ -170,27 +317,53 @@ func (m *Manager) GetCurrentBranch() (string, error) {
 	return branch, nil
 }
 
-// GetMainBranch detects the main branch (main, master, or trunk).
+// GetMainBranch returns the repository's default branch.
+// The result is detected once and cached; call InvalidateMainBranch to force
+// detection again, e.g. after the remote's default branch changes.
 func (m *Manager) GetMainBranch() (string, error) {
-	// Check common main branch names in order of preference
-	candidates := []string{"main", "master", "trunk"}
+	m.mainBranchMu.Lock()
+	defer m.mainBranchMu.Unlock()
 
-	for _, candidate := range candidates {
-		if m.branchExists(candidate) {
-			return candidate, nil
-		}
+	if m.mainBranch != "" {
+		return m.mainBranch, nil
 	}
 
-	// Fall back to checking remote references
-	cmd := exec.Command("git", "symbolic-ref", "refs/remotes/origin/HEAD")
+	branch, err := m.detectMainBranch()
+	if err != nil {
+		return "", err
+	}
+
+	m.mainBranch = branch
+	return branch, nil
+}
+
+// InvalidateMainBranch clears the cached default branch so the next
+// GetMainBranch call detects it again.
+func (m *Manager) InvalidateMainBranch() {
+	m.mainBranchMu.Lock()
+	defer m.mainBranchMu.Unlock()
+	m.mainBranch = ""
+}
+
+// detectMainBranch finds the default branch, preferring the remote's HEAD
+// and falling back to the first existing local branch of main, master, or trunk.
+func (m *Manager) detectMainBranch() (string, error) {
+	cmd := exec.Command("git", "symbolic-ref", "--quiet", "refs/remotes/origin/HEAD")
 	cmd.Dir = m.repoRoot
-	output, err := cmd.Output()
-	if err == nil {
+	if output, err := cmd.Output(); err == nil {
 		// Output is like "refs/remotes/origin/main"
 		ref := strings.TrimSpace(string(output))
-		parts := strings.Split(ref, "/")
-		if len(parts) > 0 {
-			return parts[len(parts)-1], nil
+		if branch := strings.TrimPrefix(ref, "refs/remotes/origin/"); branch != "" && branch != ref {
+			return branch, nil
+		}
+	}
+
+	// Check common main branch names in order of preference
+	candidates := []string{"main", "master", "trunk"}
+
+	for _, candidate := range candidates {
+		if m.branchExists(candidate) {
+			return candidate, nil
 		}
 	}
 
@@ -199,7 +372,7 @@ func (m *Manager) GetMainBranch() (string, error) {
 
 // WorktreeExists checks if a worktree exists for the given agent name.
 func (m *Manager) WorktreeExists(name string) bool {
-	absWorktreePath := filepath.Join(m.repoRoot, m.worktreePath(name))
+	absWorktreePath := m.worktreePath(name)
 	_, err := os.Stat(absWorktreePath)
 	return err == nil
 }
@@ -211,11 +384,72 @@ func (m *Manager) BranchExists(name string) bool {
 
 // GetWorktreePath returns the path to the worktree for an agent.
 func (m *Manager) GetWorktreePath(name string) string {
-	return filepath.Join(m.repoRoot, m.worktreePath(name))
+	return m.worktreePath(name)
 }
 
-// GetBranchName returns the branch name for an agent.
+// WorktreeDiskUsage returns the total size in bytes of the files in an agent's
+// worktree. Git metadata (.git) is excluded, so the result reflects only the
+// working tree contents.
+func (m *Manager) WorktreeDiskUsage(name string) (int64, error) {
+	root := m.worktreePath(name)
+	if _, err := os.Stat(root); err != nil {
+		return 0, fmt.Errorf("worktree not found at %s: %w", root, err)
+	}
+
+	var total int64
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Name() == ".git" {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		total += info.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to measure worktree disk usage: %w", err)
+	}
+
+	return total, nil
+}
+
+// GetBranchName returns the branch checked out in an agent's worktree. This
+// is <prefix><name> unless the agent was spawned on an existing branch.
 func (m *Manager) GetBranchName(name string) string {
+	return m.agentBranch(name)
+}
+
+// agentBranch returns the branch checked out in the agent's worktree, falling
+// back to the default <prefix><name> branch if there is no worktree or its
+// HEAD is detached.
+func (m *Manager) agentBranch(name string) string {
+	absWorktreePath := m.worktreePath(name)
+
+	// Without its .git file, git would report the main repository's branch
+	if _, err := os.Stat(filepath.Join(absWorktreePath, ".git")); err != nil {
+		return m.branchName(name)
+	}
+
+	cmd := exec.Command("git", "symbolic-ref", "--quiet", "--short", "HEAD")
+	cmd.Dir = absWorktreePath
+	output, err := cmd.Output()
+	if err != nil {
+		return m.branchName(name)
+	}
+	if branch := strings.TrimSpace(string(output)); branch != "" {
+		return branch
+	}
 	return m.branchName(name)
 }
 
@@ -224,9 +458,72 @@ func (m *Manager) branchName(name string) string {
 	return m.branchPrefix + name
 }
 
-// worktreePath returns the relative path to the worktree for an agent.
+// worktreePath returns the absolute path to the worktree for an agent. An
+// agent keeps the worktree it was created with, so when git.worktree_dir has
+// changed since, its worktree is found among git's registered worktrees.
 func (m *Manager) worktreePath(name string) string {
-	return filepath.Join(".tanuki", "worktrees", name)
+	path := filepath.Join(m.WorktreeDir(), name)
+	if _, err := os.Stat(path); err == nil {
+		return path
+	}
+	if registered, ok := m.registeredWorktree(name); ok {
+		return registered
+	}
+	return path
+}
+
+// registeredWorktree looks up a linked worktree named after an agent in
+// "git worktree list". Tanuki always names an agent's worktree directory
+// after the agent, wherever the worktree directory was at the time.
+func (m *Manager) registeredWorktree(name string) (string, bool) {
+	cmd := exec.Command("git", "worktree", "list", "--porcelain")
+	cmd.Dir = m.repoRoot
+	output, err := cmd.Output()
+	if err != nil {
+		return "", false
+	}
+
+	for _, line := range strings.Split(string(output), "\n") {
+		path, ok := strings.CutPrefix(line, "worktree ")
+		if !ok || filepath.Base(path) != name || filepath.Clean(path) == filepath.Clean(m.repoRoot) {
+			continue
+		}
+		if _, err := os.Stat(path); err == nil {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// BranchCheckedOut reports where branch is checked out: the path of the main
+// working tree or of a linked worktree. It returns "" if the branch isn't
+// checked out anywhere. Git refuses to check a branch out twice, so this lets
+// callers explain the conflict before creating a worktree.
+func (m *Manager) BranchCheckedOut(branch string) (string, error) {
+	cmd := exec.Command("git", "worktree", "list", "--porcelain")
+	cmd.Dir = m.repoRoot
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", fmt.Errorf("failed to list worktrees: %s", string(exitErr.Stderr))
+		}
+		return "", fmt.Errorf("failed to list worktrees: %w", err)
+	}
+
+	// Each worktree is a block of lines starting with "worktree <path>",
+	// followed by "branch refs/heads/<name>" unless its HEAD is detached.
+	var path string
+	for _, line := range strings.Split(string(output), "\n") {
+		if p, ok := strings.CutPrefix(line, "worktree "); ok {
+			path = p
+			continue
+		}
+		if ref, ok := strings.CutPrefix(line, "branch "); ok && ref == "refs/heads/"+branch {
+			return path, nil
+		}
+	}
+	return "", nil
 }
 
 // branchExists checks if a branch exists in the repository.
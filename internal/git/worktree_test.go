@@ -1,6 +1,7 @@
 package git
 
 import (
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -175,6 +176,111 @@ func TestCreateWorktree(t *testing.T) {
 	}
 }
 
+func TestCreateWorktree_WorktreeDir(t *testing.T) {
+	repoPath, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	manager := createTestManager(t, repoPath)
+	manager.worktreeDir = filepath.Join(t.TempDir(), "worktrees")
+
+	worktreePath, err := manager.CreateWorktree("test-agent")
+	if err != nil {
+		t.Fatalf("CreateWorktree failed: %v", err)
+	}
+	if want := filepath.Join(manager.worktreeDir, "test-agent"); worktreePath != want {
+		t.Errorf("worktreePath = %q, want %q", worktreePath, want)
+	}
+
+	// Moving worktree_dir afterwards leaves the existing worktree in use
+	manager.worktreeDir = "elsewhere"
+	if got := manager.GetWorktreePath("test-agent"); got != worktreePath {
+		t.Errorf("GetWorktreePath after moving worktree_dir = %q, want %q", got, worktreePath)
+	}
+	if !manager.WorktreeExists("test-agent") {
+		t.Error("WorktreeExists should find the worktree in its old directory")
+	}
+	if want := filepath.Join(repoPath, "elsewhere", "new-agent"); manager.GetWorktreePath("new-agent") != want {
+		t.Errorf("GetWorktreePath for a new agent = %q, want %q", manager.GetWorktreePath("new-agent"), want)
+	}
+}
+
+func TestCheckWritable(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := checkWritable(filepath.Join(dir, "not", "created", "yet")); err != nil {
+		t.Errorf("checkWritable of a missing directory under a writable one = %v, want nil", err)
+	}
+
+	file := filepath.Join(dir, "file")
+	if err := os.WriteFile(file, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkWritable(file); err == nil {
+		t.Error("checkWritable of a file should fail")
+	}
+
+	if os.Geteuid() == 0 {
+		t.Skip("root can write to read-only directories")
+	}
+	readOnly := filepath.Join(dir, "read-only")
+	if err := os.Mkdir(readOnly, 0500); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkWritable(readOnly); err == nil {
+		t.Error("checkWritable of a read-only directory should fail")
+	}
+}
+
+func TestCreateWorktreeForBranch(t *testing.T) {
+	repoPath, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	cmd := exec.Command("git", "branch", "feature/login")
+	cmd.Dir = repoPath
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git branch failed: %v\n%s", err, out)
+	}
+
+	manager := createTestManager(t, repoPath)
+	worktreePath, err := manager.CreateWorktreeForBranch("test-agent", "feature/login")
+	if err != nil {
+		t.Fatalf("CreateWorktreeForBranch failed: %v", err)
+	}
+
+	if manager.BranchExists("test-agent") {
+		t.Error("CreateWorktreeForBranch should not create a tanuki/ branch")
+	}
+	if got := manager.GetBranchName("test-agent"); got != "feature/login" {
+		t.Errorf("GetBranchName = %q, want feature/login", got)
+	}
+
+	// Diffs and commit counts follow the checked-out branch
+	commitInWorktree(t, worktreePath, "login.txt")
+	baseBranch, _ := manager.GetCurrentBranch()
+	if ahead, err := manager.CommitsAhead("test-agent", baseBranch); err != nil || ahead != 1 {
+		t.Errorf("CommitsAhead = %d, %v, want 1", ahead, err)
+	}
+
+	// Removing the agent never deletes a branch it didn't create
+	if err := manager.RemoveWorktree("test-agent", true); err != nil {
+		t.Fatalf("RemoveWorktree failed: %v", err)
+	}
+	if !manager.branchExists("feature/login") {
+		t.Error("RemoveWorktree deleted the existing branch")
+	}
+}
+
+func TestCreateWorktreeForBranch_NotFound(t *testing.T) {
+	repoPath, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	manager := createTestManager(t, repoPath)
+	_, err := manager.CreateWorktreeForBranch("test-agent", "no-such-branch")
+	if !errors.Is(err, ErrBranchNotFound) {
+		t.Errorf("CreateWorktreeForBranch error = %v, want ErrBranchNotFound", err)
+	}
+}
+
 func TestCreateWorktree_BranchExists(t *testing.T) {
 	repoPath, cleanup := setupTestRepo(t)
 	defer cleanup()
@@ -381,6 +487,108 @@ func TestGetDiff(t *testing.T) {
 	}
 }
 
+// commitInWorktree commits a new file in the given worktree.
+func commitInWorktree(t *testing.T, worktreePath, file string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(worktreePath, file), []byte("content\n"), 0600); err != nil {
+		t.Fatalf("failed to create %s: %v", file, err)
+	}
+	for _, args := range [][]string{{"add", "."}, {"commit", "-m", "Add " + file}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = worktreePath
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+}
+
+func TestCommitsAhead(t *testing.T) {
+	repoPath, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	manager := createTestManager(t, repoPath)
+	baseBranch, err := manager.GetCurrentBranch()
+	if err != nil {
+		t.Fatalf("GetCurrentBranch failed: %v", err)
+	}
+
+	worktreePath, err := manager.CreateWorktree("test-agent")
+	if err != nil {
+		t.Fatalf("CreateWorktree failed: %v", err)
+	}
+
+	ahead, err := manager.CommitsAhead("test-agent", baseBranch)
+	if err != nil {
+		t.Fatalf("CommitsAhead failed: %v", err)
+	}
+	if ahead != 0 {
+		t.Errorf("CommitsAhead = %d, want 0", ahead)
+	}
+
+	commitInWorktree(t, worktreePath, "one.txt")
+	commitInWorktree(t, worktreePath, "two.txt")
+
+	ahead, err = manager.CommitsAhead("test-agent", baseBranch)
+	if err != nil {
+		t.Fatalf("CommitsAhead failed: %v", err)
+	}
+	if ahead != 2 {
+		t.Errorf("CommitsAhead = %d, want 2", ahead)
+	}
+
+	if _, err := manager.CommitsAhead("test-agent", "no-such-branch"); err == nil {
+		t.Error("CommitsAhead should fail for an unknown base branch")
+	}
+}
+
+func TestPush(t *testing.T) {
+	repoPath, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	// A bare repository stands in for the remote
+	remotePath := t.TempDir()
+	for _, cmd := range []*exec.Cmd{
+		exec.Command("git", "init", "--bare", remotePath),
+		exec.Command("git", "-C", repoPath, "remote", "add", "origin", remotePath),
+	} {
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("%v failed: %v\n%s", cmd.Args, err, out)
+		}
+	}
+
+	manager := createTestManager(t, repoPath)
+	worktreePath, err := manager.CreateWorktree("test-agent")
+	if err != nil {
+		t.Fatalf("CreateWorktree failed: %v", err)
+	}
+	commitInWorktree(t, worktreePath, "pushed.txt")
+
+	if err := manager.Push("test-agent"); err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+
+	branch := manager.GetBranchName("test-agent")
+	cmd := exec.Command("git", "show-ref", "--verify", "--quiet", "refs/heads/"+branch)
+	cmd.Dir = remotePath
+	if err := cmd.Run(); err != nil {
+		t.Errorf("expected %s to exist on the remote", branch)
+	}
+}
+
+func TestPush_NoRemote(t *testing.T) {
+	repoPath, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	manager := createTestManager(t, repoPath)
+	if _, err := manager.CreateWorktree("test-agent"); err != nil {
+		t.Fatalf("CreateWorktree failed: %v", err)
+	}
+
+	if err := manager.Push("test-agent"); err == nil {
+		t.Error("Push should fail without an origin remote")
+	}
+}
+
 func TestGetCurrentBranch(t *testing.T) {
 	repoPath, cleanup := setupTestRepo(t)
 	defer cleanup()
@@ -415,6 +623,48 @@ func TestGetMainBranch(t *testing.T) {
 	}
 }
 
+func TestGetMainBranch_RemoteHead(t *testing.T) {
+	repoPath, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	setOriginHead := func(branch string) {
+		t.Helper()
+		for _, args := range [][]string{
+			{"update-ref", "refs/remotes/origin/" + branch, "HEAD"},
+			{"symbolic-ref", "refs/remotes/origin/HEAD", "refs/remotes/origin/" + branch},
+		} {
+			cmd := exec.Command("git", args...)
+			cmd.Dir = repoPath
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Fatalf("git %v failed: %v\n%s", args, err, out)
+			}
+		}
+	}
+
+	// The remote's HEAD wins over a local main/master branch
+	setOriginHead("trunk")
+	manager := createTestManager(t, repoPath)
+
+	mainBranch, err := manager.GetMainBranch()
+	if err != nil {
+		t.Fatalf("GetMainBranch failed: %v", err)
+	}
+	if mainBranch != "trunk" {
+		t.Errorf("GetMainBranch = %q, want trunk", mainBranch)
+	}
+
+	// Cached until invalidated
+	setOriginHead("release/v2")
+	if mainBranch, _ = manager.GetMainBranch(); mainBranch != "trunk" {
+		t.Errorf("GetMainBranch = %q, want cached trunk", mainBranch)
+	}
+
+	manager.InvalidateMainBranch()
+	if mainBranch, _ = manager.GetMainBranch(); mainBranch != "release/v2" {
+		t.Errorf("GetMainBranch after invalidate = %q, want release/v2", mainBranch)
+	}
+}
+
 func TestWorktreeExists(t *testing.T) {
 	repoPath, cleanup := setupTestRepo(t)
 	defer cleanup()
@@ -438,6 +688,42 @@ func TestWorktreeExists(t *testing.T) {
 	}
 }
 
+func TestWorktreeDiskUsage(t *testing.T) {
+	repoPath, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	manager := createTestManager(t, repoPath)
+
+	// Should fail before creation
+	if _, err := manager.WorktreeDiskUsage("test-agent"); err == nil {
+		t.Error("WorktreeDiskUsage should fail for missing worktree")
+	}
+
+	worktreePath, err := manager.CreateWorktree("test-agent")
+	if err != nil {
+		t.Fatalf("CreateWorktree failed: %v", err)
+	}
+
+	// Add a nested file alongside the committed README.md (7 bytes)
+	nested := filepath.Join(worktreePath, "src")
+	if mkErr := os.MkdirAll(nested, 0750); mkErr != nil {
+		t.Fatalf("failed to create dir: %v", mkErr)
+	}
+	if writeErr := os.WriteFile(filepath.Join(nested, "data.txt"), make([]byte, 100), 0600); writeErr != nil {
+		t.Fatalf("failed to create test file: %v", writeErr)
+	}
+
+	usage, err := manager.WorktreeDiskUsage("test-agent")
+	if err != nil {
+		t.Fatalf("WorktreeDiskUsage failed: %v", err)
+	}
+
+	// .git metadata must not be counted
+	if usage != 107 {
+		t.Errorf("WorktreeDiskUsage() = %d, want 107", usage)
+	}
+}
+
 func TestBranchExists(t *testing.T) {
 	repoPath, cleanup := setupTestRepo(t)
 	defer cleanup()
@@ -461,6 +747,54 @@ func TestBranchExists(t *testing.T) {
 	}
 }
 
+func TestBranchCheckedOut(t *testing.T) {
+	repoPath, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	manager := createTestManager(t, repoPath)
+	worktreePath, err := manager.CreateWorktree("test-agent")
+	if err != nil {
+		t.Fatalf("CreateWorktree failed: %v", err)
+	}
+	mainBranch, _ := manager.GetCurrentBranch()
+
+	cmd := exec.Command("git", "branch", "feature/login")
+	cmd.Dir = repoPath
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git branch failed: %v\n%s", err, out)
+	}
+
+	tests := []struct {
+		name   string
+		branch string
+		want   string
+	}{
+		{"main working tree", mainBranch, repoPath},
+		{"linked worktree", "tanuki/test-agent", worktreePath},
+		{"not checked out", "feature/login", ""},
+		{"missing branch", "no-such-branch", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := manager.BranchCheckedOut(tt.branch)
+			if err != nil {
+				t.Fatalf("BranchCheckedOut failed: %v", err)
+			}
+			if tt.want != "" {
+				// Compare resolved paths, since the temp dir may be a symlink
+				want, _ := filepath.EvalSymlinks(tt.want)
+				got, _ = filepath.EvalSymlinks(got)
+				if got != want {
+					t.Errorf("BranchCheckedOut(%q) = %q, want %q", tt.branch, got, want)
+				}
+			} else if got != "" {
+				t.Errorf("BranchCheckedOut(%q) = %q, want \"\"", tt.branch, got)
+			}
+		})
+	}
+}
+
 func TestGetWorktreePath(t *testing.T) {
 	repoPath, cleanup := setupTestRepo(t)
 	defer cleanup()
@@ -0,0 +1,176 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/bkonkle/tanuki/internal/agent"
+	"github.com/bkonkle/tanuki/internal/task"
+)
+
+// taskManagerAdapter adapts task.Manager to the TaskManager interface.
+type taskManagerAdapter struct {
+	*task.Manager
+}
+
+// NewTaskManagerAdapter wraps a task.Manager so it satisfies TaskManager.
+func NewTaskManagerAdapter(m *task.Manager) TaskManager {
+	return &taskManagerAdapter{Manager: m}
+}
+
+func (m *taskManagerAdapter) Stats() *TaskStats {
+	stats := m.Manager.Stats()
+	return &TaskStats{
+		Total:        stats.Total,
+		ByStatus:     stats.ByStatus,
+		ByWorkstream: stats.ByWorkstream,
+		ByPriority:   stats.ByPriority,
+	}
+}
+
+// dependencyResolver adapts task.Manager to the DependencyResolver interface.
+type dependencyResolver struct {
+	taskMgr *task.Manager
+}
+
+// NewDependencyResolver returns a DependencyResolver over the tasks m holds,
+// so dependencies completed during a run unblock their dependents. Tasks m
+// doesn't know are treated as blocked.
+func NewDependencyResolver(m *task.Manager) DependencyResolver {
+	return &dependencyResolver{taskMgr: m}
+}
+
+func (r *dependencyResolver) IsBlocked(taskID string) bool {
+	blocked, err := r.taskMgr.IsBlocked(taskID)
+	return err != nil || blocked
+}
+
+func (r *dependencyResolver) DetectCycle() []string {
+	return task.NewResolver(r.taskMgr.List()).DetectCycle()
+}
+
+// agentTaskRunner executes orchestrator-assigned tasks on agents.
+type agentTaskRunner struct {
+	taskMgr    *task.Manager
+	agentMgr   *agent.Manager
+	logs       *task.LogWriter
+	skipVerify bool
+	resume     bool
+}
+
+// AgentTaskRunnerOptions configures NewAgentTaskRunnerWithOptions.
+type AgentTaskRunnerOptions struct {
+	// Logs saves each run's output to a task log file (optional)
+	Logs *task.LogWriter
+	// SkipVerify completes tasks without running their completion.verify
+	// command after a successful run
+	SkipVerify bool
+	// ResumeRetries resumes the failed attempt's Claude session when a
+	// retry runs on the same agent. See agent.Manager.RetrySession.
+	ResumeRetries bool
+}
+
+// NewAgentTaskRunner returns a TaskRunner that runs each task's prompt on the
+// assigned agent, recording checkpoints, failures, and completion. A task
+// with a completion.verify command only completes if the command passes in
+// the agent's container afterwards, and a task with expects only completes if
+// every glob matches a file in the agent's worktree.
+func NewAgentTaskRunner(taskMgr *task.Manager, agentMgr *agent.Manager) TaskRunner {
+	return NewAgentTaskRunnerWithOptions(taskMgr, agentMgr, AgentTaskRunnerOptions{})
+}
+
+// NewLoggingAgentTaskRunner is like NewAgentTaskRunner, but also saves each
+// run's output to a task log file. The log's path is recorded on failed tasks
+// and in the agent's last task.
+func NewLoggingAgentTaskRunner(taskMgr *task.Manager, agentMgr *agent.Manager, logs *task.LogWriter) TaskRunner {
+	return NewAgentTaskRunnerWithOptions(taskMgr, agentMgr, AgentTaskRunnerOptions{Logs: logs})
+}
+
+// NewAgentTaskRunnerWithOptions is like NewAgentTaskRunner, configured by opts.
+func NewAgentTaskRunnerWithOptions(taskMgr *task.Manager, agentMgr *agent.Manager, opts AgentTaskRunnerOptions) TaskRunner {
+	return &agentTaskRunner{
+		taskMgr:    taskMgr,
+		agentMgr:   agentMgr,
+		logs:       opts.Logs,
+		skipVerify: opts.SkipVerify,
+		resume:     opts.ResumeRetries,
+	}
+}
+
+func (r *agentTaskRunner) RunTask(ctx context.Context, taskID, agentName string) error {
+	t, err := r.taskMgr.Get(taskID)
+	if err != nil {
+		return fmt.Errorf("get task: %w", err)
+	}
+
+	if err := r.taskMgr.UpdateStatus(taskID, task.StatusInProgress); err != nil {
+		return fmt.Errorf("update status: %w", err)
+	}
+
+	runOpts := agent.RunOptions{
+		TaskID: taskID,
+		OnCheckpoint: func(checkpoint string) {
+			if err := r.taskMgr.AddCheckpoint(taskID, checkpoint); err != nil {
+				log.Printf("Warning: failed to record checkpoint: %v", err)
+			}
+		},
+		OnProgress: func(percent int) {
+			if err := r.taskMgr.SetProgress(taskID, percent); err != nil {
+				log.Printf("Warning: failed to record progress: %v", err)
+			}
+		},
+	}
+	agent.ApplyTaskTools(&runOpts, t)
+	agent.ApplyTaskSignal(&runOpts, t)
+	if r.resume {
+		runOpts.ResumeSessionID = r.agentMgr.RetrySession(agentName, t)
+	}
+
+	if r.logs != nil {
+		logFile, logPath, err := r.logs.CreateTaskLogFile(taskID)
+		if err != nil {
+			log.Printf("Warning: not saving output of %s: %v", taskID, err)
+		} else {
+			defer func() { _ = logFile.Close() }()
+			runOpts.Follow = true
+			runOpts.Output = logFile
+			runOpts.LogFilePath = logPath
+		}
+	}
+
+	if err := r.agentMgr.Run(agentName, agent.BuildTaskPrompt(t), runOpts); err != nil {
+		// A busy or cancelled run is requeued by the orchestrator, not failed
+		if !errors.Is(err, agent.ErrAgentBusy) && ctx.Err() == nil {
+			_ = r.taskMgr.UpdateFailure(taskID, err, runOpts.LogFilePath)
+		}
+		return err
+	}
+
+	if !r.skipVerify && t.Completion != nil && t.Completion.Verify != "" {
+		out := runOpts.Output
+		if out == nil {
+			out = io.Discard
+		}
+		verify := func(command string) (bool, string, error) {
+			return r.agentMgr.Verify(agentName, command)
+		}
+		if err := agent.VerifyTask(t, out, verify); err != nil {
+			if ctx.Err() == nil {
+				_ = r.taskMgr.UpdateFailure(taskID, err, runOpts.LogFilePath)
+			}
+			return err
+		}
+	}
+
+	if err := r.agentMgr.CheckExpects(agentName, t); err != nil {
+		if ctx.Err() == nil {
+			_ = r.taskMgr.UpdateFailure(taskID, err, runOpts.LogFilePath)
+		}
+		return err
+	}
+
+	return r.taskMgr.UpdateStatus(taskID, task.StatusComplete)
+}
@@ -0,0 +1,171 @@
+package project
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/bkonkle/tanuki/internal/agent"
+)
+
+// Fairness controls the order in which idle agents are served when the
+// orchestrator assigns tasks. It only matters when capacity is limited (for
+// example by MaxTotalAgents); otherwise every idle agent gets a task.
+//
+//   - FairnessPriority serves workstreams whose next task is most urgent first.
+//     Critical work finishes soonest, but a workstream with a long run of
+//     high-priority tasks can starve its siblings.
+//   - FairnessRoundRobin rotates through workstreams regardless of priority.
+//     Nothing starves, but urgent tasks may wait behind low-priority ones.
+//   - FairnessWeighted serves the workstream using the smallest share of its
+//     configured concurrency first, so capacity splits in proportion to
+//     workstream concurrency.
+type Fairness string
+
+const (
+	// FairnessPriority serves the workstream with the most urgent next task first.
+	FairnessPriority Fairness = "priority"
+	// FairnessRoundRobin rotates through workstreams in turn.
+	FairnessRoundRobin Fairness = "round_robin"
+	// FairnessWeighted shares capacity in proportion to workstream concurrency.
+	FairnessWeighted Fairness = "weighted"
+)
+
+// ParseFairness validates a fairness mode name. An empty name is priority.
+func ParseFairness(s string) (Fairness, error) {
+	switch f := Fairness(s); f {
+	case "":
+		return FairnessPriority, nil
+	case FairnessPriority, FairnessRoundRobin, FairnessWeighted:
+		return f, nil
+	default:
+		return "", fmt.Errorf("invalid fairness %q (want priority, round_robin, or weighted)", s)
+	}
+}
+
+// unqueuedRank sorts workstreams with nothing queued after every priority.
+const unqueuedRank = 4
+
+// orderIdleAgents returns the idle workstream agents in the order they should
+// be offered tasks under the configured Fairness.
+func (o *Orchestrator) orderIdleAgents(agents []*agent.Agent) []*agent.Agent {
+	byWorkstream := make(map[string][]*agent.Agent)
+	working := make(map[string]int)
+	var workstreams []string
+
+	for _, ag := range agents {
+		if ag.Workstream == "" {
+			continue
+		}
+		if ag.Status == "working" {
+			working[ag.Workstream]++
+		}
+		if ag.Status != "idle" {
+			continue
+		}
+		if _, ok := byWorkstream[ag.Workstream]; !ok {
+			workstreams = append(workstreams, ag.Workstream)
+		}
+		byWorkstream[ag.Workstream] = append(byWorkstream[ag.Workstream], ag)
+	}
+
+	switch o.config.Fairness {
+	case FairnessRoundRobin:
+		return o.orderRoundRobin(workstreams, byWorkstream)
+	case FairnessWeighted:
+		return o.orderWeighted(workstreams, byWorkstream, working)
+	default:
+		return o.orderByPriority(agents, byWorkstream)
+	}
+}
+
+// orderByPriority keeps agent order but moves agents whose workstream has a
+// more urgent next task ahead. Agents with nothing queued go last.
+func (o *Orchestrator) orderByPriority(agents []*agent.Agent, byWorkstream map[string][]*agent.Agent) []*agent.Agent {
+	rank := make(map[string]int, len(byWorkstream))
+	for ws := range byWorkstream {
+		rank[ws] = unqueuedRank
+		if next, err := o.queue.Peek(ws); err == nil {
+			rank[ws] = next.Priority.Order()
+		}
+	}
+
+	var ordered []*agent.Agent
+	for _, ag := range agents {
+		if _, ok := byWorkstream[ag.Workstream]; ok && ag.Status == "idle" {
+			ordered = append(ordered, ag)
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return rank[ordered[i].Workstream] < rank[ordered[j].Workstream]
+	})
+	return ordered
+}
+
+// orderRoundRobin interleaves agents one workstream at a time, starting after
+// the workstream that was served last.
+func (o *Orchestrator) orderRoundRobin(workstreams []string, byWorkstream map[string][]*agent.Agent) []*agent.Agent {
+	sort.Strings(workstreams)
+
+	start := 0
+	for i, ws := range workstreams {
+		if ws > o.lastServed {
+			start = i
+			break
+		}
+	}
+	rotated := make([]string, 0, len(workstreams))
+	rotated = append(rotated, workstreams[start:]...)
+	rotated = append(rotated, workstreams[:start]...)
+
+	var ordered []*agent.Agent
+	for round := 0; len(ordered) < countAgents(byWorkstream); round++ {
+		for _, ws := range rotated {
+			if round < len(byWorkstream[ws]) {
+				ordered = append(ordered, byWorkstream[ws][round])
+			}
+		}
+	}
+	return ordered
+}
+
+// orderWeighted repeatedly serves the workstream using the smallest fraction
+// of its concurrency, counting agents already working.
+func (o *Orchestrator) orderWeighted(workstreams []string, byWorkstream map[string][]*agent.Agent, working map[string]int) []*agent.Agent {
+	sort.Strings(workstreams)
+
+	load := make(map[string]int, len(working))
+	for ws, n := range working {
+		load[ws] = n
+	}
+	next := make(map[string]int, len(workstreams))
+
+	var ordered []*agent.Agent
+	for len(ordered) < countAgents(byWorkstream) {
+		best := ""
+		for _, ws := range workstreams {
+			if next[ws] >= len(byWorkstream[ws]) {
+				continue
+			}
+			if best == "" || o.shareUsed(ws, load[ws]) < o.shareUsed(best, load[best]) {
+				best = ws
+			}
+		}
+		ordered = append(ordered, byWorkstream[best][next[best]])
+		next[best]++
+		load[best]++
+	}
+	return ordered
+}
+
+// shareUsed returns the fraction of a workstream's concurrency in use.
+func (o *Orchestrator) shareUsed(workstream string, active int) float64 {
+	return float64(active) / float64(o.config.GetWorkstreamConcurrency(workstream))
+}
+
+func countAgents(byWorkstream map[string][]*agent.Agent) int {
+	n := 0
+	for _, agents := range byWorkstream {
+		n += len(agents)
+	}
+	return n
+}
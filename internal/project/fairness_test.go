@@ -0,0 +1,119 @@
+package project
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bkonkle/tanuki/internal/agent"
+	"github.com/bkonkle/tanuki/internal/task"
+)
+
+func TestParseFairness(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Fairness
+		wantErr bool
+	}{
+		{"", FairnessPriority, false},
+		{"priority", FairnessPriority, false},
+		{"round_robin", FairnessRoundRobin, false},
+		{"weighted", FairnessWeighted, false},
+		{"random", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseFairness(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseFairness(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseFairness(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+// newFairnessOrchestrator sets up one backend and one frontend agent with a
+// long run of high-priority backend work and a single low-priority frontend task.
+func newFairnessOrchestrator(fairness Fairness) (*Orchestrator, *mockTaskManager) {
+	taskMgr := newMockTaskManager()
+	queue := newMockTaskQueue()
+	for _, id := range []string{"BE-1", "BE-2", "BE-3"} {
+		tsk := &task.Task{ID: id, Workstream: "backend", Priority: task.PriorityHigh, Status: task.StatusPending}
+		taskMgr.addTask(tsk)
+		_ = queue.Enqueue(tsk)
+	}
+	fe := &task.Task{ID: "FE-1", Workstream: "frontend", Priority: task.PriorityLow, Status: task.StatusPending}
+	taskMgr.addTask(fe)
+	_ = queue.Enqueue(fe)
+
+	agentMgr := newMockAgentManager()
+	agentMgr.addAgent(&agent.Agent{Name: "be-1", Workstream: "backend", Status: "idle"})
+	agentMgr.addAgent(&agent.Agent{Name: "fe-1", Workstream: "frontend", Status: "idle"})
+
+	config := DefaultOrchestratorConfig()
+	config.MaxTotalAgents = 1
+	config.Fairness = fairness
+
+	return NewOrchestrator(taskMgr, agentMgr, queue, config), taskMgr
+}
+
+func TestOrchestrator_Fairness_Priority(t *testing.T) {
+	orch, taskMgr := newFairnessOrchestrator(FairnessPriority)
+
+	for i := 0; i < 3; i++ {
+		orch.assignPendingTasks(context.Background())
+	}
+
+	if fe, _ := taskMgr.Get("FE-1"); fe.AssignedTo != "" {
+		t.Errorf("FE-1 assigned to %q; priority mode should serve backend's high-priority tasks first", fe.AssignedTo)
+	}
+}
+
+func TestOrchestrator_Fairness_RoundRobin(t *testing.T) {
+	orch, taskMgr := newFairnessOrchestrator(FairnessRoundRobin)
+
+	orch.assignPendingTasks(context.Background())
+	orch.assignPendingTasks(context.Background())
+
+	if fe, _ := taskMgr.Get("FE-1"); fe.AssignedTo != "fe-1" {
+		t.Errorf("FE-1 assigned to %q, want fe-1 within two rounds", fe.AssignedTo)
+	}
+
+	assignedBackend := 0
+	for _, id := range []string{"BE-1", "BE-2", "BE-3"} {
+		if tsk, _ := taskMgr.Get(id); tsk.AssignedTo != "" {
+			assignedBackend++
+		}
+	}
+	if assignedBackend != 1 {
+		t.Errorf("assigned %d backend tasks, want 1", assignedBackend)
+	}
+}
+
+func TestOrchestrator_Fairness_WeightedOrder(t *testing.T) {
+	config := DefaultOrchestratorConfig()
+	config.Fairness = FairnessWeighted
+	config.WorkstreamConcurrency = map[string]int{"backend": 3, "frontend": 1}
+	orch := NewOrchestrator(newMockTaskManager(), newMockAgentManager(), newMockTaskQueue(), config)
+
+	agents := []*agent.Agent{
+		{Name: "be-1", Workstream: "backend", Status: "idle"},
+		{Name: "be-2", Workstream: "backend", Status: "idle"},
+		{Name: "be-3", Workstream: "backend", Status: "working"},
+		{Name: "fe-1", Workstream: "frontend", Status: "idle"},
+		{Name: "misc", Status: "idle"},
+	}
+
+	// backend uses 1/3 of its share, frontend 0/1: frontend first, then backend
+	want := []string{"fe-1", "be-1", "be-2"}
+	got := orch.orderIdleAgents(agents)
+	if len(got) != len(want) {
+		t.Fatalf("orderIdleAgents() returned %d agents, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].Name != want[i] {
+			t.Errorf("orderIdleAgents()[%d] = %s, want %s", i, got[i].Name, want[i])
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package project
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/bkonkle/tanuki/internal/task"
+)
+
+// GroupFailure controls what happens to the rest of a task group when one of
+// its members fails for good (after any retries). Running members are
+// cancelled either way; the policy decides the status they and the members
+// not yet started are left in.
+//
+//   - GroupFailureBlock marks them blocked, so they can be resumed once the
+//     failed task is fixed and set back to pending.
+//   - GroupFailureFail marks them failed along with the task that broke the
+//     group.
+//
+// Completed members are left complete: tanuki can't undo work an agent has
+// already committed.
+type GroupFailure string
+
+const (
+	// GroupFailureBlock leaves the rest of a failed group blocked.
+	GroupFailureBlock GroupFailure = "block"
+	// GroupFailureFail marks the rest of a failed group failed.
+	GroupFailureFail GroupFailure = "fail"
+)
+
+// ParseGroupFailure validates a group failure policy name. An empty name is block.
+func ParseGroupFailure(s string) (GroupFailure, error) {
+	switch g := GroupFailure(s); g {
+	case "":
+		return GroupFailureBlock, nil
+	case GroupFailureBlock, GroupFailureFail:
+		return g, nil
+	default:
+		return "", fmt.Errorf("invalid group failure policy %q (want block or fail)", s)
+	}
+}
+
+// status returns the task status group members are left in.
+func (g GroupFailure) status() task.Status {
+	if g == GroupFailureFail {
+		return task.StatusFailed
+	}
+	return task.StatusBlocked
+}
+
+// failGroup stops the rest of the failed task's group: running members are
+// cancelled and members not yet started are set aside under the
+// configured GroupFailure policy.
+func (o *Orchestrator) failGroup(taskID string) {
+	t, err := o.taskMgr.Get(taskID)
+	if err != nil || t.Group == "" {
+		return
+	}
+
+	o.mu.Lock()
+	if o.failedGroups[t.Group] {
+		o.mu.Unlock()
+		return
+	}
+	o.failedGroups[t.Group] = true
+	o.mu.Unlock()
+
+	log.Printf("Task %s failed, stopping the rest of group %s", taskID, t.Group)
+
+	for _, member := range o.taskMgr.GetByGroup(t.Group) {
+		if member.ID == taskID {
+			continue
+		}
+
+		switch member.Status {
+		case task.StatusComplete:
+			log.Printf("Warning: task %s in group %s already completed; its changes are not reverted", member.ID, t.Group)
+
+		case task.StatusFailed:
+			// Already failed on its own
+
+		default:
+			if o.cancelGroupMember(member.ID) {
+				continue
+			}
+			o.setAsideGroupMember(member.ID)
+		}
+	}
+}
+
+// cancelGroupMember cancels a running member of a failed group. The run's
+// goroutine reports the cancellation once the agent has stopped. Returns
+// false if the member isn't running.
+func (o *Orchestrator) cancelGroupMember(taskID string) bool {
+	o.mu.Lock()
+	rt, ok := o.running[taskID]
+	if !ok || rt.restart || rt.groupFailed {
+		o.mu.Unlock()
+		return ok
+	}
+	rt.groupFailed = true
+	o.mu.Unlock()
+
+	log.Printf("Cancelling task %s on %s", taskID, rt.agentName)
+	// As with restarts, the run only ends when its container stops
+	if err := o.agentMgr.Stop(rt.agentName); err != nil {
+		log.Printf("Warning: failed to stop agent %s: %v", rt.agentName, err)
+	}
+	rt.cancel()
+	return true
+}
+
+// setAsideGroupMember leaves a member of a failed group unassigned in the
+// status the GroupFailure policy calls for.
+func (o *Orchestrator) setAsideGroupMember(taskID string) {
+	status := o.config.GroupFailure.status()
+	_ = o.taskMgr.Unassign(taskID)
+	if err := o.taskMgr.UpdateStatus(taskID, status); err != nil {
+		log.Printf("Warning: failed to mark task %s %s: %v", taskID, status, err)
+		return
+	}
+	if status == task.StatusFailed {
+		if err := o.wsScheduler.FailTask(taskID); err != nil {
+			log.Printf("Warning: failed to update workstream for failed task %s: %v", taskID, err)
+		}
+	}
+}
+
+// reportCancelledGroupMember brings back the agent stopped by
+// cancelGroupMember and reports the cancelled task.
+func (o *Orchestrator) reportCancelledGroupMember(taskID, agentName string) {
+	if err := o.agentMgr.Start(agentName); err != nil {
+		log.Printf("Warning: failed to restart agent %s: %v", agentName, err)
+	}
+	o.events <- task.Event{
+		Type:      task.EventTaskCancelled,
+		TaskID:    taskID,
+		AgentName: agentName,
+		Message:   "another task in its group failed",
+		Timestamp: time.Now(),
+	}
+}
+
+// onTaskCancelled sets aside a running task cancelled because its group failed.
+func (o *Orchestrator) onTaskCancelled(event task.Event) {
+	if o.balancer != nil {
+		o.balancer.TrackCompletion(event.AgentName)
+	}
+	o.setAsideGroupMember(event.TaskID)
+}
+
+// inFailedGroup reports whether a task belongs to a group that has failed.
+func (o *Orchestrator) inFailedGroup(t *task.Task) bool {
+	if t.Group == "" {
+		return false
+	}
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.failedGroups[t.Group]
+}
@@ -0,0 +1,173 @@
+package project
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bkonkle/tanuki/internal/agent"
+	"github.com/bkonkle/tanuki/internal/task"
+)
+
+func TestParseGroupFailure(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    GroupFailure
+		wantErr bool
+	}{
+		{"", GroupFailureBlock, false},
+		{"block", GroupFailureBlock, false},
+		{"fail", GroupFailureFail, false},
+		{"cancel", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseGroupFailure(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseGroupFailure(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseGroupFailure(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+// newGroupOrchestrator sets up a "schema" group of three tasks, one already
+// complete, plus an ungrouped task, with an idle agent for each workstream.
+func newGroupOrchestrator(policy GroupFailure) (*Orchestrator, *mockTaskManager) {
+	taskMgr := newMockTaskManager()
+	queue := newMockTaskQueue()
+	taskMgr.addTask(&task.Task{ID: "DB-1", Workstream: "db", Group: "schema", Status: task.StatusFailed})
+	taskMgr.addTask(&task.Task{ID: "DB-2", Workstream: "db", Group: "schema", Status: task.StatusComplete})
+	for _, tsk := range []*task.Task{
+		{ID: "API-1", Workstream: "api", Group: "schema", Status: task.StatusPending},
+		{ID: "UI-1", Workstream: "ui", Status: task.StatusPending},
+	} {
+		taskMgr.addTask(tsk)
+		_ = queue.Enqueue(tsk)
+	}
+
+	agentMgr := newMockAgentManager()
+	agentMgr.addAgent(&agent.Agent{Name: "api-1", Workstream: "api", Status: "idle"})
+	agentMgr.addAgent(&agent.Agent{Name: "ui-1", Workstream: "ui", Status: "idle"})
+
+	config := DefaultOrchestratorConfig()
+	config.GroupFailure = policy
+
+	return NewOrchestrator(taskMgr, agentMgr, queue, config), taskMgr
+}
+
+func TestOrchestrator_GroupFailure_Block(t *testing.T) {
+	orch, taskMgr := newGroupOrchestrator(GroupFailureBlock)
+
+	orch.onTaskFailed(context.Background(), task.Event{Type: task.EventTaskFailed, TaskID: "DB-1"})
+
+	api, _ := taskMgr.Get("API-1")
+	if api.Status != task.StatusBlocked {
+		t.Errorf("API-1 status = %s, want blocked", api.Status)
+	}
+	if api.AssignedTo != "" {
+		t.Errorf("API-1 assigned to %q; members of a failed group shouldn't be assigned", api.AssignedTo)
+	}
+	if db, _ := taskMgr.Get("DB-2"); db.Status != task.StatusComplete {
+		t.Errorf("DB-2 status = %s, want complete members left alone", db.Status)
+	}
+	if ui, _ := taskMgr.Get("UI-1"); ui.AssignedTo != "ui-1" {
+		t.Errorf("UI-1 assigned to %q, want ungrouped work to carry on", ui.AssignedTo)
+	}
+
+	// Completions elsewhere don't revive the group
+	orch.onTaskComplete(context.Background(), task.Event{Type: task.EventTaskCompleted, TaskID: "UI-1", AgentName: "ui-1"})
+	if api.Status != task.StatusBlocked {
+		t.Errorf("API-1 status = %s after another task completed, want blocked", api.Status)
+	}
+
+	// Nothing left can run, so the project counts as done
+	_ = taskMgr.UpdateStatus("UI-1", task.StatusComplete)
+	if !orch.isComplete() {
+		t.Error("isComplete() = false, want true when only a failed group's blocked tasks remain")
+	}
+}
+
+func TestOrchestrator_GroupFailure_Fail(t *testing.T) {
+	orch, taskMgr := newGroupOrchestrator(GroupFailureFail)
+
+	orch.onTaskFailed(context.Background(), task.Event{Type: task.EventTaskFailed, TaskID: "DB-1"})
+
+	if api, _ := taskMgr.Get("API-1"); api.Status != task.StatusFailed {
+		t.Errorf("API-1 status = %s, want failed", api.Status)
+	}
+	if ui, _ := taskMgr.Get("UI-1"); ui.Status == task.StatusFailed {
+		t.Error("UI-1 failed; ungrouped tasks shouldn't be affected")
+	}
+}
+
+func TestOrchestrator_GroupFailure_RetryKeepsGroup(t *testing.T) {
+	orch, taskMgr := newGroupOrchestrator(GroupFailureBlock)
+	db, _ := taskMgr.Get("DB-1")
+	db.RetryPrompt = "Check the migration output"
+
+	orch.onTaskFailed(context.Background(), task.Event{Type: task.EventTaskFailed, TaskID: "DB-1"})
+
+	if api, _ := taskMgr.Get("API-1"); api.Status == task.StatusBlocked {
+		t.Error("API-1 blocked; the group only fails once retries are exhausted")
+	}
+}
+
+func TestOrchestrator_GroupFailure_CancelsRunningMembers(t *testing.T) {
+	runner := &blockingRunner{started: make(chan string, 1), release: make(chan struct{}), honorCtx: true}
+	orch, taskMgr, agentMgr := newShutdownTestOrchestrator(runner, time.Second)
+	taskMgr.tasks["T1"].Group = "schema"
+	taskMgr.addTask(&task.Task{ID: "T2", Workstream: "db", Group: "schema", Status: task.StatusFailed})
+
+	tsk, _ := taskMgr.Get("T1")
+	orch.assignTask(context.Background(), tsk, "be-1")
+	<-runner.started
+
+	orch.failGroup("T2")
+
+	var ev task.Event
+	select {
+	case ev = <-orch.Events():
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the running member to be cancelled")
+	}
+	if ev.Type != task.EventTaskCancelled {
+		t.Fatalf("event = %s, want %s", ev.Type, task.EventTaskCancelled)
+	}
+	orch.inflight.Wait()
+
+	if ag, _ := agentMgr.Get("be-1"); ag.Status != "idle" {
+		t.Errorf("agent status = %s, want idle after cancelling its task", ag.Status)
+	}
+
+	orch.handleEvent(context.Background(), ev)
+	tsk, _ = taskMgr.Get("T1")
+	if tsk.Status != task.StatusBlocked {
+		t.Errorf("Status = %s, want blocked after group failure", tsk.Status)
+	}
+	if tsk.AssignedTo != "" {
+		t.Errorf("AssignedTo = %q, want empty after cancellation", tsk.AssignedTo)
+	}
+}
+
+func TestOrchestrator_Initialize_RemembersFailedGroups(t *testing.T) {
+	orch, taskMgr := newGroupOrchestrator(GroupFailureBlock)
+	orch.queue.Clear()
+	orch.config.AutoSpawnAgents = false
+
+	if err := orch.initialize(context.Background()); err != nil {
+		t.Fatalf("initialize() error = %v", err)
+	}
+
+	if orch.queue.Contains("API-1") {
+		t.Error("API-1 queued; a group that failed in an earlier run should stay stopped")
+	}
+	if !orch.queue.Contains("UI-1") {
+		t.Error("UI-1 not queued")
+	}
+	if api, _ := taskMgr.Get("API-1"); api.Status != task.StatusBlocked {
+		t.Errorf("API-1 status = %s, want blocked", api.Status)
+	}
+}
@@ -16,12 +16,20 @@ type TaskManager interface {
 	// Scan loads all task files from .tanuki/tasks/
 	Scan() ([]*task.Task, error)
 
+	// ScanStrict loads all task files, returning those that failed to parse
+	// instead of logging them. Tasks whose files break keep their last good
+	// version.
+	ScanStrict() ([]*task.Task, []*task.ParseError, error)
+
 	// Get returns a task by ID
 	Get(id string) (*task.Task, error)
 
 	// GetByWorkstream returns tasks for a specific workstream
 	GetByWorkstream(workstream string) []*task.Task
 
+	// GetByGroup returns the tasks in a group
+	GetByGroup(group string) []*task.Task
+
 	// GetByStatus returns tasks with a specific status
 	GetByStatus(status task.Status) []*task.Task
 
@@ -34,12 +42,19 @@ type TaskManager interface {
 	// Assign assigns a task to an agent
 	Assign(id string, agentName string) error
 
+	// Claim atomically assigns a pending, unassigned task to an agent,
+	// returning false if another process already claimed it
+	Claim(id string, agentName string) (bool, error)
+
 	// Unassign removes agent assignment from a task
 	Unassign(id string) error
 
 	// IsBlocked checks if a task's dependencies are all complete
 	IsBlocked(id string) (bool, error)
 
+	// Retry requeues a failed task and increments its retry count
+	Retry(id string) error
+
 	// Stats returns task statistics
 	Stats() *TaskStats
 }
@@ -53,7 +68,8 @@ type TaskQueue interface {
 	// Dequeue removes and returns the highest priority task for a workstream
 	Dequeue(workstream string) (*task.Task, error)
 
-	// Peek returns the highest priority task without removing it
+	// Peek returns the highest priority task without removing it, the same
+	// task Dequeue would return next
 	Peek(workstream string) (*task.Task, error)
 
 	// Size returns total number of tasks in queue
@@ -87,6 +103,10 @@ type AgentManager interface {
 	// Stop stops an agent's container without removing it
 	Stop(name string) error
 
+	// Hold stops an agent's container after taskID failed on it and keeps
+	// the agent out of the pool until it is released
+	Hold(name, taskID string) error
+
 	// Remove deletes an agent and cleans up all associated resources
 	Remove(name string, opts agent.RemoveOptions) error
 
@@ -2,12 +2,17 @@ package project
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"os"
+	"os/signal"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/bkonkle/tanuki/internal/agent"
+	"github.com/bkonkle/tanuki/internal/config"
 	"github.com/bkonkle/tanuki/internal/task"
 )
 
@@ -31,25 +36,139 @@ type OrchestratorConfig struct {
 	PollInterval time.Duration
 	// MaxAgentsPerWorkstream is the maximum agents to spawn per workstream (deprecated, use WorkstreamConcurrency).
 	MaxAgentsPerWorkstream int
-	// WorkstreamConcurrency maps workstream names to their concurrency limits.
+	// WorkstreamConcurrency maps workstream names to their agent concurrency
+	// limits: how many agents may work the workstream at once.
 	WorkstreamConcurrency map[string]int
+	// WorkstreamTaskConcurrency maps workstream names to their task
+	// concurrency limits: how many of the workstream's tasks may be assigned
+	// or in progress at once, across all agents. Workstreams without one are
+	// limited only by their agents.
+	WorkstreamTaskConcurrency map[string]int
+	// MaxTotalAgents caps the number of agents working at once across all
+	// workstreams. Zero means no global cap.
+	MaxTotalAgents int
 	// AutoSpawnAgents enables automatic agent spawning.
 	AutoSpawnAgents bool
 	// StopWhenComplete stops orchestrator when all tasks complete.
 	StopWhenComplete bool
+	// DefaultEstimate is used for tasks without an estimate when computing ETAs.
+	DefaultEstimate time.Duration
+	// WorkstreamMaxRetries maps workstream names to how many times a failed
+	// task with a retry_prompt is retried.
+	WorkstreamMaxRetries map[string]int
+	// HandleSignals installs a SIGINT/SIGTERM handler during Start that shuts
+	// down gracefully. A second signal forces the process to exit.
+	HandleSignals bool
+	// ShutdownGracePeriod bounds how long shutdown waits for in-flight tasks
+	// before returning them to pending.
+	ShutdownGracePeriod time.Duration
+	// Fairness controls how limited agent capacity is shared across
+	// workstreams. Defaults to FairnessPriority.
+	Fairness Fairness
+	// RestartOnTaskChange cancels a running task whose file body was edited
+	// since assignment and requeues it with the fresh content. When false the
+	// change is only logged.
+	RestartOnTaskChange bool
+	// GroupFailure decides what happens to the rest of a task group when one
+	// of its members fails. Defaults to GroupFailureBlock.
+	GroupFailure GroupFailure
+	// SkipVerify is passed to the agent task runner, so tasks complete
+	// without running their completion.verify command after the run.
+	SkipVerify bool
+	// KeepContainerOnFailure holds the agent a task failed on: its container
+	// is stopped but kept for inspection, and the agent gets no more tasks
+	// until "tanuki agent release".
+	KeepContainerOnFailure bool
+	// ResumeRetries is passed to the agent task runner, so a retry on the
+	// agent its failed attempt ran on resumes that attempt's session.
+	ResumeRetries bool
 }
 
+// DefaultTaskEstimate is the fallback estimate for tasks without one.
+const DefaultTaskEstimate = config.DefaultTaskEstimate
+
+// DefaultMaxRetries is the retry limit for workstreams without one.
+const DefaultMaxRetries = 1
+
+// DefaultShutdownGracePeriod is how long shutdown waits for in-flight tasks.
+const DefaultShutdownGracePeriod = 30 * time.Second
+
+// Signal handling hooks, replaced in tests.
+var (
+	notifySignals = func(c chan<- os.Signal) { signal.Notify(c, os.Interrupt, syscall.SIGTERM) }
+	stopSignals   = signal.Stop
+	exitProcess   = os.Exit
+)
+
 // DefaultOrchestratorConfig returns sensible default configuration.
 func DefaultOrchestratorConfig() OrchestratorConfig {
 	return OrchestratorConfig{
-		PollInterval:           10 * time.Second,
-		MaxAgentsPerWorkstream: 1,
-		WorkstreamConcurrency:  make(map[string]int),
-		AutoSpawnAgents:        true,
-		StopWhenComplete:       false,
+		PollInterval:              10 * time.Second,
+		MaxAgentsPerWorkstream:    1,
+		WorkstreamConcurrency:     make(map[string]int),
+		WorkstreamTaskConcurrency: make(map[string]int),
+		WorkstreamMaxRetries:      make(map[string]int),
+		AutoSpawnAgents:           true,
+		StopWhenComplete:          false,
+		DefaultEstimate:           DefaultTaskEstimate,
+		HandleSignals:             true,
+		ShutdownGracePeriod:       DefaultShutdownGracePeriod,
+		Fairness:                  FairnessPriority,
+		GroupFailure:              GroupFailureBlock,
 	}
 }
 
+// ApplyConfig fills c from tanuki.yaml settings, so every way of starting an
+// orchestrator honors the same config. skip_verify,
+// keep_container_on_failure, restart_on_task_change, and resume_retries are
+// turned on if set in either, a group_failure of "fail" replaces the default
+// policy, default_estimate sets the ETA fallback, and each workstream's agent
+// concurrency, task concurrency, and retry limit from conf fill any limit not
+// already in c. The workstreams are those configured in conf plus any named
+// in workstreams. The limit maps are copied first, so a caller's maps aren't
+// modified.
+func (c *OrchestratorConfig) ApplyConfig(conf *config.Config, workstreams ...string) {
+	c.SkipVerify = c.SkipVerify || conf.SkipVerify
+	c.KeepContainerOnFailure = c.KeepContainerOnFailure || conf.KeepContainerOnFailure
+	c.RestartOnTaskChange = c.RestartOnTaskChange || conf.RestartOnTaskChange
+	c.ResumeRetries = c.ResumeRetries || conf.ResumeRetries
+	c.DefaultEstimate = conf.GetDefaultEstimate()
+	if gf, err := ParseGroupFailure(conf.GroupFailure); err == nil && gf != GroupFailureBlock {
+		c.GroupFailure = gf
+	}
+
+	c.WorkstreamConcurrency = copyLimits(c.WorkstreamConcurrency)
+	c.WorkstreamTaskConcurrency = copyLimits(c.WorkstreamTaskConcurrency)
+	c.WorkstreamMaxRetries = copyLimits(c.WorkstreamMaxRetries)
+
+	names := append([]string(nil), workstreams...)
+	for ws := range conf.Workstreams {
+		names = append(names, ws)
+	}
+	for _, ws := range names {
+		if _, ok := c.WorkstreamConcurrency[ws]; !ok {
+			c.WorkstreamConcurrency[ws] = conf.GetWorkstreamConcurrency(ws)
+		}
+		if _, ok := c.WorkstreamTaskConcurrency[ws]; !ok {
+			if limit := conf.GetWorkstreamTaskConcurrency(ws); limit > 0 {
+				c.WorkstreamTaskConcurrency[ws] = limit
+			}
+		}
+		if _, ok := c.WorkstreamMaxRetries[ws]; !ok {
+			c.WorkstreamMaxRetries[ws] = conf.GetWorkstreamMaxRetries(ws)
+		}
+	}
+}
+
+// copyLimits copies a per-workstream limit map.
+func copyLimits(limits map[string]int) map[string]int {
+	out := make(map[string]int, len(limits))
+	for ws, n := range limits {
+		out[ws] = n
+	}
+	return out
+}
+
 // GetWorkstreamConcurrency returns the concurrency limit for a workstream.
 // Falls back to MaxAgentsPerWorkstream if no specific limit is set.
 func (c *OrchestratorConfig) GetWorkstreamConcurrency(workstream string) int {
@@ -64,6 +183,25 @@ func (c *OrchestratorConfig) GetWorkstreamConcurrency(workstream string) int {
 	return 1
 }
 
+// GetWorkstreamTaskConcurrency returns the task concurrency limit for a
+// workstream, or 0 if its tasks are limited only by its agents.
+func (c *OrchestratorConfig) GetWorkstreamTaskConcurrency(workstream string) int {
+	if limit, ok := c.WorkstreamTaskConcurrency[workstream]; ok && limit > 0 {
+		return limit
+	}
+	return 0
+}
+
+// GetWorkstreamMaxRetries returns the retry limit for failed tasks in a workstream.
+func (c *OrchestratorConfig) GetWorkstreamMaxRetries(workstream string) int {
+	if c.WorkstreamMaxRetries != nil {
+		if retries, ok := c.WorkstreamMaxRetries[workstream]; ok && retries > 0 {
+			return retries
+		}
+	}
+	return DefaultMaxRetries
+}
+
 // Orchestrator manages the project lifecycle, coordinating tasks and agents.
 type Orchestrator struct {
 	// Dependencies (interfaces for loose coupling)
@@ -84,10 +222,44 @@ type Orchestrator struct {
 	started time.Time
 	events  chan task.Event
 
+	// In-flight task executions by task ID
+	running  map[string]*runningTask
+	inflight sync.WaitGroup
+
+	// lastServed is the workstream most recently given a task, for round-robin
+	lastServed string
+
+	// failedGroups holds the task groups with a failed member
+	failedGroups map[string]bool
+
+	// brokenFiles maps task files that failed to parse on the last tick to
+	// the error reported, so each problem is logged once. Only tick uses it.
+	brokenFiles map[string]string
+
+	// progress is the snapshot GetProgress returns, rebuilt from the tasks
+	// seen on each tick and after each event. progressTasks are those tasks.
+	progressMu    sync.RWMutex
+	progress      *Progress
+	progressTasks []*task.Task
+
 	// Config
 	config OrchestratorConfig
 }
 
+// runningTask tracks a task execution in flight.
+type runningTask struct {
+	agentName string
+	// hash is the task's ContentHash as of assignment (or the last warning)
+	hash string
+	// cancel cancels the execution's context
+	cancel context.CancelFunc
+	// restart is set when the execution was cancelled because the task changed
+	restart bool
+	// groupFailed is set when the execution was cancelled because another
+	// task in its group failed
+	groupFailed bool
+}
+
 // TaskBalancer selects agents for tasks.
 type TaskBalancer interface {
 	GetIdleAgents(agents []*AgentInfo, workstream string) []*AgentInfo
@@ -133,22 +305,34 @@ func NewOrchestrator(
 	}
 
 	return &Orchestrator{
-		taskMgr:     taskMgr,
-		agentMgr:    agentMgr,
-		queue:       queue,
-		wsScheduler: wsScheduler,
-		status:      StatusStopped,
-		events:      make(chan task.Event, 100),
-		config:      config,
+		taskMgr:      taskMgr,
+		agentMgr:     agentMgr,
+		queue:        queue,
+		wsScheduler:  wsScheduler,
+		status:       StatusStopped,
+		events:       make(chan task.Event, 100),
+		running:      make(map[string]*runningTask),
+		failedGroups: make(map[string]bool),
+		brokenFiles:  make(map[string]string),
+		config:       config,
 	}
 }
 
-// SetWorkstreamConcurrency sets the concurrency for a workstream.
+// SetWorkstreamConcurrency sets the agent concurrency for a workstream.
 func (o *Orchestrator) SetWorkstreamConcurrency(workstream string, concurrency int) {
 	o.config.WorkstreamConcurrency[workstream] = concurrency
 	o.wsScheduler.SetWorkstreamConcurrency(workstream, concurrency)
 }
 
+// SetWorkstreamTaskConcurrency sets the task concurrency for a workstream.
+// Zero removes the limit.
+func (o *Orchestrator) SetWorkstreamTaskConcurrency(workstream string, limit int) {
+	if o.config.WorkstreamTaskConcurrency == nil {
+		o.config.WorkstreamTaskConcurrency = make(map[string]int)
+	}
+	o.config.WorkstreamTaskConcurrency[workstream] = limit
+}
+
 // GetWorkstreamScheduler returns the workstream scheduler.
 func (o *Orchestrator) GetWorkstreamScheduler() *WorkstreamScheduler {
 	return o.wsScheduler
@@ -174,7 +358,10 @@ func (o *Orchestrator) SetRunner(r TaskRunner) {
 	o.runner = r
 }
 
-// Start begins the orchestration loop.
+// Start begins the orchestration loop. It blocks until ctx is cancelled or,
+// with StopWhenComplete, all tasks are done. On cancellation it shuts down
+// gracefully: in-flight tasks get ShutdownGracePeriod to finish, unfinished
+// tasks return to pending, and project agents are stopped.
 func (o *Orchestrator) Start(ctx context.Context) error {
 	o.mu.Lock()
 	if o.status != StatusStopped {
@@ -187,6 +374,20 @@ func (o *Orchestrator) Start(ctx context.Context) error {
 
 	log.Println("Starting project orchestrator...")
 
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if o.config.HandleSignals {
+		sigCh := make(chan os.Signal, 2)
+		done := make(chan struct{})
+		notifySignals(sigCh)
+		defer func() {
+			stopSignals(sigCh)
+			close(done)
+		}()
+		go o.watchSignals(sigCh, cancel, done)
+	}
+
 	// Initialize
 	if err := o.initialize(ctx); err != nil {
 		o.setStatus(StatusStopped)
@@ -197,7 +398,80 @@ func (o *Orchestrator) Start(ctx context.Context) error {
 	log.Println("Project orchestrator running")
 
 	// Run main loop
-	return o.runLoop(ctx)
+	err := o.runLoop(ctx)
+	if ctx.Err() != nil {
+		o.shutdown()
+	}
+	return err
+}
+
+// watchSignals cancels the run on the first signal and forces the process
+// to exit on the second.
+func (o *Orchestrator) watchSignals(sigCh <-chan os.Signal, cancel context.CancelFunc, done <-chan struct{}) {
+	select {
+	case sig := <-sigCh:
+		log.Printf("Received %s, shutting down (repeat to force exit)", sig)
+		cancel()
+	case <-done:
+		return
+	}
+
+	select {
+	case sig := <-sigCh:
+		log.Printf("Received %s again, forcing exit", sig)
+		exitProcess(1)
+	case <-done:
+	}
+}
+
+// shutdown waits for in-flight tasks, returns unfinished ones to pending,
+// and stops project agents.
+func (o *Orchestrator) shutdown() {
+	grace := o.config.ShutdownGracePeriod
+	if !o.waitInflight(grace) {
+		log.Printf("Shutdown grace period (%s) elapsed with tasks still running", grace)
+	}
+
+	o.mu.RLock()
+	unfinished := make([]string, 0, len(o.running))
+	for taskID := range o.running {
+		unfinished = append(unfinished, taskID)
+	}
+	o.mu.RUnlock()
+
+	for _, taskID := range unfinished {
+		o.requeueInterrupted(taskID)
+	}
+
+	// Already stopped if Stop was called before cancellation
+	_ = o.Stop()
+}
+
+// waitInflight waits up to timeout for running tasks to return.
+// Returns false if tasks are still running when the timeout elapses.
+func (o *Orchestrator) waitInflight(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		o.inflight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// requeueInterrupted returns a task cut short by shutdown to pending so the
+// next run picks it up again.
+func (o *Orchestrator) requeueInterrupted(taskID string) {
+	log.Printf("Task %s interrupted by shutdown, returning to pending", taskID)
+	_ = o.taskMgr.Unassign(taskID)
+	if err := o.taskMgr.UpdateStatus(taskID, task.StatusPending); err != nil {
+		log.Printf("Warning: failed to reset task %s: %v", taskID, err)
+	}
 }
 
 // Stop gracefully stops the orchestrator.
@@ -255,10 +529,59 @@ type Status struct {
 	IdleAgents int
 }
 
-// GetProgress returns detailed progress information.
+// GetProgress returns detailed progress information. It returns the
+// snapshot taken on the last tick or task event, so it's safe to poll from
+// other goroutines without rereading task files. Before the first snapshot
+// it falls back to ForceRefresh.
 func (o *Orchestrator) GetProgress() *Progress {
+	o.progressMu.RLock()
+	progress := o.progress
+	o.progressMu.RUnlock()
+
+	if progress == nil {
+		return o.ForceRefresh()
+	}
+	return progress.clone()
+}
+
+// ForceRefresh rescans the task files, updates the progress snapshot and
+// returns it. Use it when the progress must reflect edits made since the
+// last tick.
+func (o *Orchestrator) ForceRefresh() *Progress {
 	tasks, _ := o.taskMgr.Scan()
+	return o.updateProgress(tasks).clone()
+}
+
+// updateProgress replaces the progress snapshot with one built from tasks.
+func (o *Orchestrator) updateProgress(tasks []*task.Task) *Progress {
+	progress := progressFromTasks(tasks)
+
+	o.progressMu.Lock()
+	defer o.progressMu.Unlock()
+	o.progress = progress
+	o.progressTasks = tasks
+	return progress
+}
+
+// refreshProgress rebuilds the progress snapshot from the task manager's
+// cached copies of the tasks last scanned, picking up status changes made
+// since without touching the disk.
+func (o *Orchestrator) refreshProgress() {
+	o.progressMu.RLock()
+	previous := o.progressTasks
+	o.progressMu.RUnlock()
+
+	tasks := make([]*task.Task, 0, len(previous))
+	for _, t := range previous {
+		if current, err := o.taskMgr.Get(t.ID); err == nil {
+			tasks = append(tasks, current)
+		}
+	}
+	o.updateProgress(tasks)
+}
 
+// progressFromTasks counts tasks by status and workstream.
+func progressFromTasks(tasks []*task.Task) *Progress {
 	progress := &Progress{
 		Total:        len(tasks),
 		ByStatus:     make(map[task.Status]int),
@@ -275,22 +598,74 @@ func (o *Orchestrator) GetProgress() *Progress {
 			progress.ByWorkstream[ws] = wp
 		}
 		wp.Total++
-		if t.Status == task.StatusComplete {
+		switch t.Status {
+		case task.StatusComplete:
 			wp.Complete++
+		case task.StatusInProgress, task.StatusAssigned:
+			wp.InProgress++
 		}
 	}
 
-	progress.Complete = progress.ByStatus[task.StatusComplete]
-	progress.InProgress = progress.ByStatus[task.StatusInProgress] + progress.ByStatus[task.StatusAssigned]
-	progress.Pending = progress.ByStatus[task.StatusPending] + progress.ByStatus[task.StatusBlocked]
+	progress.summarize()
+	return progress
+}
+
+// ProgressFromStats builds progress information from task manager statistics.
+// Unlike GetProgress it does not rescan task files, so it is cheap enough to
+// poll while tasks are running.
+func ProgressFromStats(stats *task.Stats) *Progress {
+	progress := &Progress{
+		Total:        stats.Total,
+		ByStatus:     make(map[task.Status]int, len(stats.ByStatus)),
+		ByWorkstream: make(map[string]*WorkstreamProgress, len(stats.ByWorkstreamStatus)),
+	}
+
+	for status, n := range stats.ByStatus {
+		progress.ByStatus[status] = n
+	}
 
-	if progress.Total > 0 {
-		progress.Percentage = float64(progress.Complete) / float64(progress.Total) * 100
+	for ws, counts := range stats.ByWorkstreamStatus {
+		wp := &WorkstreamProgress{
+			Workstream: ws,
+			Complete:   counts[task.StatusComplete],
+			InProgress: counts[task.StatusInProgress] + counts[task.StatusAssigned],
+		}
+		for _, n := range counts {
+			wp.Total += n
+		}
+		progress.ByWorkstream[ws] = wp
 	}
 
+	progress.summarize()
 	return progress
 }
 
+// clone returns a deep copy of p, so callers can't modify the snapshot.
+func (p *Progress) clone() *Progress {
+	c := *p
+	c.ByStatus = make(map[task.Status]int, len(p.ByStatus))
+	for status, n := range p.ByStatus {
+		c.ByStatus[status] = n
+	}
+	c.ByWorkstream = make(map[string]*WorkstreamProgress, len(p.ByWorkstream))
+	for ws, wp := range p.ByWorkstream {
+		wpCopy := *wp
+		c.ByWorkstream[ws] = &wpCopy
+	}
+	return &c
+}
+
+// summarize derives the aggregate counts and percentage from ByStatus.
+func (p *Progress) summarize() {
+	p.Complete = p.ByStatus[task.StatusComplete]
+	p.InProgress = p.ByStatus[task.StatusInProgress] + p.ByStatus[task.StatusAssigned]
+	p.Pending = p.ByStatus[task.StatusPending] + p.ByStatus[task.StatusBlocked]
+
+	if p.Total > 0 {
+		p.Percentage = float64(p.Complete) / float64(p.Total) * 100
+	}
+}
+
 // Progress contains detailed progress information.
 type Progress struct {
 	Total        int
@@ -307,6 +682,63 @@ type WorkstreamProgress struct {
 	Workstream string
 	Total      int
 	Complete   int
+	InProgress int
+}
+
+// EstimatedRemaining returns a ballpark ETA for the remaining work.
+// It sums the estimates of unfinished tasks and divides by the effective
+// parallelism reported by the workstream scheduler.
+func (o *Orchestrator) EstimatedRemaining() time.Duration {
+	tasks, _ := o.taskMgr.Scan()
+
+	def := o.config.DefaultEstimate
+	if def <= 0 {
+		def = DefaultTaskEstimate
+	}
+
+	return EstimateRemaining(tasks, o.wsScheduler.EffectiveParallelism(tasks), def)
+}
+
+// EstimateRemaining sums the estimates of pending and in-progress tasks and
+// divides by parallelism. Tasks without an estimate count as def.
+func EstimateRemaining(tasks []*task.Task, parallelism int, def time.Duration) time.Duration {
+	var total time.Duration
+	for _, t := range tasks {
+		if isRemaining(t.Status) {
+			total += t.GetEstimate(def)
+		}
+	}
+
+	if parallelism <= 0 {
+		parallelism = 1
+	}
+	return total / time.Duration(parallelism)
+}
+
+// EstimateFromRate returns how long the pending and in-progress tasks take
+// to finish at rate tasks per hour, or 0 if rate isn't positive.
+func EstimateFromRate(tasks []*task.Task, rate float64) time.Duration {
+	if rate <= 0 {
+		return 0
+	}
+
+	remaining := 0
+	for _, t := range tasks {
+		if isRemaining(t.Status) {
+			remaining++
+		}
+	}
+	return time.Duration(float64(remaining) / rate * float64(time.Hour))
+}
+
+// isRemaining reports whether a task status still counts toward remaining work.
+func isRemaining(s task.Status) bool {
+	switch s {
+	case task.StatusPending, task.StatusBlocked, task.StatusAssigned, task.StatusInProgress:
+		return true
+	default:
+		return false
+	}
 }
 
 // Events returns the event channel for subscribing to task events.
@@ -327,11 +759,12 @@ func (o *Orchestrator) initialize(_ context.Context) error {
 	}
 
 	log.Printf("Found %d tasks", len(tasks))
+	o.updateProgress(tasks)
 
 	// Check for cycles if resolver is set
 	if o.resolver != nil {
 		if cycle := o.resolver.DetectCycle(); cycle != nil {
-			return fmt.Errorf("dependency cycle detected: %v", cycle)
+			return fmt.Errorf("dependency cycle detected: %s", task.FormatCycle(cycle))
 		}
 	}
 
@@ -343,9 +776,16 @@ func (o *Orchestrator) initialize(_ context.Context) error {
 	wsStats := o.wsScheduler.Stats()
 	log.Printf("Workstreams initialized: %d total, %d pending", wsStats.Total, wsStats.ByStatus[WorkstreamPending])
 
+	// Groups that failed in an earlier run stay failed
+	for _, t := range tasks {
+		if t.Group != "" && t.Status == task.StatusFailed {
+			o.failGroup(t.ID)
+		}
+	}
+
 	// Build queue with pending tasks
 	for _, t := range tasks {
-		if t.Status == task.StatusPending {
+		if t.Status == task.StatusPending && !o.inFailedGroup(t) {
 			// Skip blocked tasks
 			if o.resolver != nil && o.resolver.IsBlocked(t.ID) {
 				continue
@@ -378,10 +818,17 @@ func (o *Orchestrator) spawnAgentsForWorkstreams(tasks []*task.Task) error {
 	}
 
 	// Spawn agents for each workstream based on concurrency
+	total := 0
 	for workstream := range workstreams {
 		concurrency := o.config.GetWorkstreamConcurrency(workstream)
 
 		for i := 0; i < concurrency; i++ {
+			if o.config.MaxTotalAgents > 0 && total >= o.config.MaxTotalAgents {
+				log.Printf("Max total agents (%d) reached, not spawning more", o.config.MaxTotalAgents)
+				return nil
+			}
+			total++
+
 			agentName := fmt.Sprintf("%s-agent", workstream)
 			if concurrency > 1 {
 				agentName = fmt.Sprintf("%s-agent-%d", workstream, i+1)
@@ -430,11 +877,18 @@ func (o *Orchestrator) runLoop(ctx context.Context) error {
 // tick performs periodic maintenance.
 func (o *Orchestrator) tick(ctx context.Context) {
 	// Refresh task states
-	tasks, _ := o.taskMgr.Scan()
+	tasks, parseErrors, err := o.taskMgr.ScanStrict()
+	o.reportBrokenFiles(parseErrors)
+	if err == nil {
+		o.updateProgress(tasks)
+	}
+
+	// Catch edits to tasks agents are already working on
+	o.checkChangedTasks(tasks)
 
 	// Check for newly unblocked tasks
 	for _, t := range tasks {
-		if t.Status == task.StatusPending && !o.queue.Contains(t.ID) {
+		if t.Status == task.StatusPending && !o.queue.Contains(t.ID) && !o.inFailedGroup(t) {
 			if o.resolver == nil || !o.resolver.IsBlocked(t.ID) {
 				_ = o.queue.Enqueue(t)
 				log.Printf("Task %s unblocked, added to queue", t.ID)
@@ -446,21 +900,62 @@ func (o *Orchestrator) tick(ctx context.Context) {
 	o.assignPendingTasks(ctx)
 }
 
-// assignPendingTasks assigns tasks to idle agents.
+// assignPendingTasks assigns tasks to idle agents. Pinned agents are
+// offered only their pinned task, ahead of the queue; both kinds count
+// toward MaxTotalAgents.
 func (o *Orchestrator) assignPendingTasks(ctx context.Context) {
 	agents, _ := o.agentMgr.List()
+	working := 0
+	pinned := make(map[string]bool)
+	for _, ag := range agents {
+		if ag.Status == "working" {
+			working++
+		}
+		if ag.PinnedTask != "" {
+			pinned[ag.PinnedTask] = true
+		}
+	}
+	activeTasks := o.countActiveTasks()
 
 	for _, ag := range agents {
-		if ag.Status != "idle" || ag.Workstream == "" {
+		if ag.PinnedTask == "" || ag.Status != "idle" {
+			continue
+		}
+		if o.config.MaxTotalAgents > 0 && working >= o.config.MaxTotalAgents {
+			return
+		}
+		if o.assignPinnedTask(ctx, ag, activeTasks) {
+			working++
+		}
+	}
+
+	for _, ag := range o.orderIdleAgents(agents) {
+		// Pinned agents never take queued work
+		if ag.PinnedTask != "" {
+			continue
+		}
+
+		// Respect the global cap on concurrently working agents
+		if o.config.MaxTotalAgents > 0 && working >= o.config.MaxTotalAgents {
+			return
+		}
+
+		// Respect the workstream's cap on in-flight tasks
+		if o.atTaskLimit(ag.Workstream, activeTasks) {
 			continue
 		}
 
 		// Try to get next task for this workstream
-		t, err := o.queue.Dequeue(ag.Workstream)
+		t, err := o.dequeueUnpinned(ag.Workstream, pinned)
 		if err != nil {
 			continue // No tasks for this workstream
 		}
 
+		// Drop tasks queued before their group failed
+		if o.inFailedGroup(t) {
+			continue
+		}
+
 		// Check if blocked
 		if o.resolver != nil && o.resolver.IsBlocked(t.ID) {
 			_ = o.queue.Enqueue(t) // Put back
@@ -468,15 +963,78 @@ func (o *Orchestrator) assignPendingTasks(ctx context.Context) {
 		}
 
 		// Assign
-		o.assignTask(ctx, t, ag.Name)
+		if !o.assignTask(ctx, t, ag.Name) {
+			continue
+		}
+		o.lastServed = ag.Workstream
+		activeTasks[t.GetWorkstream()]++
+		working++
 	}
 }
 
-// assignTask assigns a task to an agent and starts execution.
-func (o *Orchestrator) assignTask(ctx context.Context, t *task.Task, agentName string) {
-	log.Printf("Assigning %s to %s", t.ID, agentName)
+// countActiveTasks returns the number of assigned and in-progress tasks in
+// each workstream, whichever agent or orchestrator is working them.
+func (o *Orchestrator) countActiveTasks() map[string]int {
+	active := make(map[string]int)
+	for _, status := range []task.Status{task.StatusAssigned, task.StatusInProgress} {
+		for _, t := range o.taskMgr.GetByStatus(status) {
+			active[t.GetWorkstream()]++
+		}
+	}
+	return active
+}
+
+// atTaskLimit reports whether a workstream already has as many tasks in
+// flight as its task concurrency allows.
+func (o *Orchestrator) atTaskLimit(workstream string, activeTasks map[string]int) bool {
+	limit := o.config.GetWorkstreamTaskConcurrency(workstream)
+	return limit > 0 && activeTasks[workstream] >= limit
+}
+
+// assignPinnedTask assigns an idle pinned agent its task if the task is
+// pending, unblocked, and within its workstream's task concurrency. Once the
+// task completes the agent stays idle.
+func (o *Orchestrator) assignPinnedTask(ctx context.Context, ag *agent.Agent, activeTasks map[string]int) bool {
+	t, err := o.taskMgr.Get(ag.PinnedTask)
+	if err != nil || t.Status != task.StatusPending || o.inFailedGroup(t) {
+		return false
+	}
+	if o.resolver != nil && o.resolver.IsBlocked(t.ID) {
+		return false
+	}
+	if o.atTaskLimit(t.GetWorkstream(), activeTasks) || !o.assignTask(ctx, t, ag.Name) {
+		return false
+	}
+	activeTasks[t.GetWorkstream()]++
+	return true
+}
 
-	_ = o.taskMgr.Assign(t.ID, agentName)
+// dequeueUnpinned dequeues the next task for a workstream, dropping tasks
+// pinned to an agent, since those are assigned outside the queue.
+func (o *Orchestrator) dequeueUnpinned(workstream string, pinned map[string]bool) (*task.Task, error) {
+	for {
+		t, err := o.queue.Dequeue(workstream)
+		if err != nil || !pinned[t.ID] {
+			return t, err
+		}
+	}
+}
+
+// assignTask claims a task for an agent and starts execution. It returns
+// false if the task couldn't be claimed, for example because another
+// orchestrator got to it first.
+func (o *Orchestrator) assignTask(ctx context.Context, t *task.Task, agentName string) bool {
+	claimed, err := o.taskMgr.Claim(t.ID, agentName)
+	if err != nil {
+		log.Printf("Warning: failed to claim %s for %s: %v", t.ID, agentName, err)
+		return false
+	}
+	if !claimed {
+		log.Printf("Task %s already claimed, skipping", t.ID)
+		return false
+	}
+
+	log.Printf("Assigning %s to %s", t.ID, agentName)
 
 	if o.balancer != nil {
 		o.balancer.TrackAssignment(agentName)
@@ -484,9 +1042,66 @@ func (o *Orchestrator) assignTask(ctx context.Context, t *task.Task, agentName s
 
 	// Start task execution if runner is set
 	if o.runner != nil {
+		// The queued copy may predate the last scan; hash what the runner will read
+		hash := t.ContentHash
+		if current, err := o.taskMgr.Get(t.ID); err == nil {
+			hash = current.ContentHash
+		}
+
+		taskCtx, cancel := context.WithCancel(ctx)
+		rt := &runningTask{agentName: agentName, hash: hash, cancel: cancel}
+
+		o.mu.Lock()
+		o.running[t.ID] = rt
+		o.mu.Unlock()
+
+		o.inflight.Add(1)
 		go func() {
-			if err := o.runner.RunTask(ctx, t.ID, agentName); err != nil {
+			defer o.inflight.Done()
+			defer cancel()
+
+			err := o.runner.RunTask(taskCtx, t.ID, agentName)
+
+			o.mu.Lock()
+			delete(o.running, t.ID)
+			restart := rt.restart
+			groupFailed := rt.groupFailed
+			o.mu.Unlock()
+
+			// Errors caused by shutdown aren't task failures
+			if err != nil && ctx.Err() != nil {
+				o.requeueInterrupted(t.ID)
+				return
+			}
+
+			if restart {
+				o.restartChangedTask(t.ID, agentName)
+				return
+			}
+
+			if groupFailed {
+				o.reportCancelledGroupMember(t.ID, agentName)
+				return
+			}
+
+			if err != nil {
+				if errors.Is(err, agent.ErrAgentBusy) {
+					log.Printf("Agent %s busy, requeueing %s", agentName, t.ID)
+					o.events <- task.Event{
+						Type:      task.EventTaskRequeued,
+						TaskID:    t.ID,
+						AgentName: agentName,
+						Message:   err.Error(),
+						Timestamp: time.Now(),
+					}
+					return
+				}
 				log.Printf("Task %s failed: %v", t.ID, err)
+				// Hold before reporting, so the agent can't be handed
+				// another task while the failure is being handled
+				if o.config.KeepContainerOnFailure {
+					o.holdFailedAgent(agentName, t.ID)
+				}
 				o.events <- task.Event{
 					Type:      task.EventTaskFailed,
 					TaskID:    t.ID,
@@ -504,6 +1119,89 @@ func (o *Orchestrator) assignTask(ctx context.Context, t *task.Task, agentName s
 			}
 		}()
 	}
+
+	return true
+}
+
+// holdFailedAgent keeps the agent a task failed on out of the pool, with its
+// container stopped for inspection.
+func (o *Orchestrator) holdFailedAgent(agentName, taskID string) {
+	if err := o.agentMgr.Hold(agentName, taskID); err != nil {
+		log.Printf("Warning: failed to hold agent %s after %s failed: %v", agentName, taskID, err)
+		return
+	}
+	log.Printf("Holding agent %s for inspection after %s failed; release it with: tanuki agent release %s", agentName, taskID, agentName)
+}
+
+// reportBrokenFiles logs task files that started failing to parse, and those
+// that parse again, once per change rather than on every tick.
+func (o *Orchestrator) reportBrokenFiles(parseErrors []*task.ParseError) {
+	broken := make(map[string]string, len(parseErrors))
+	for _, pe := range parseErrors {
+		msg := pe.Err.Error()
+		broken[pe.Path] = msg
+		if o.brokenFiles[pe.Path] == msg {
+			continue
+		}
+		if pe.Kept != "" {
+			log.Printf("Warning: task file %s is invalid, keeping the last good version of %s until it is fixed: %v", pe.Path, pe.Kept, pe.Err)
+		} else {
+			log.Printf("Warning: task file %s is invalid and was skipped: %v", pe.Path, pe.Err)
+		}
+	}
+
+	for path := range o.brokenFiles {
+		if _, ok := broken[path]; !ok {
+			log.Printf("Task file %s parses again", path)
+		}
+	}
+	o.brokenFiles = broken
+}
+
+// checkChangedTasks compares freshly scanned tasks against the content each
+// running execution started with. A changed task is logged once per edit and,
+// with RestartOnTaskChange, cancelled so it can rerun with the new content.
+func (o *Orchestrator) checkChangedTasks(tasks []*task.Task) {
+	for _, t := range tasks {
+		o.mu.Lock()
+		rt, ok := o.running[t.ID]
+		if !ok || rt.restart || t.ContentHash == "" || t.ContentHash == rt.hash {
+			o.mu.Unlock()
+			continue
+		}
+		rt.hash = t.ContentHash
+		rt.restart = o.config.RestartOnTaskChange
+		restart := rt.restart
+		o.mu.Unlock()
+
+		if !restart {
+			log.Printf("Warning: task %s changed while %s is working on it; the agent is using the old content", t.ID, rt.agentName)
+			continue
+		}
+
+		log.Printf("Task %s changed while %s is working on it, restarting with the new content", t.ID, rt.agentName)
+		// Runs don't watch their context, so stop the container to end this
+		// one. Stop before cancelling so the restart can't race the stop.
+		if err := o.agentMgr.Stop(rt.agentName); err != nil {
+			log.Printf("Warning: failed to stop agent %s: %v", rt.agentName, err)
+		}
+		rt.cancel()
+	}
+}
+
+// restartChangedTask brings back the agent stopped by checkChangedTasks and
+// requeues the task so it runs again with its current content.
+func (o *Orchestrator) restartChangedTask(taskID, agentName string) {
+	if err := o.agentMgr.Start(agentName); err != nil {
+		log.Printf("Warning: failed to restart agent %s: %v", agentName, err)
+	}
+	o.events <- task.Event{
+		Type:      task.EventTaskRequeued,
+		TaskID:    taskID,
+		AgentName: agentName,
+		Message:   "task file changed during run",
+		Timestamp: time.Now(),
+	}
 }
 
 // handleEvent processes task events.
@@ -519,7 +1217,15 @@ func (o *Orchestrator) handleEvent(ctx context.Context, event task.Event) {
 
 	case task.EventTaskBlocked:
 		o.onTaskBlocked(event)
+
+	case task.EventTaskRequeued:
+		o.onTaskRequeued(event)
+
+	case task.EventTaskCancelled:
+		o.onTaskCancelled(event)
 	}
+
+	o.refreshProgress()
 }
 
 // onTaskComplete handles task completion.
@@ -540,7 +1246,7 @@ func (o *Orchestrator) onTaskComplete(ctx context.Context, event task.Event) {
 	// Check for newly unblocked tasks
 	tasks, _ := o.taskMgr.Scan()
 	for _, t := range tasks {
-		if t.Status == task.StatusBlocked {
+		if t.Status == task.StatusBlocked && !o.inFailedGroup(t) {
 			if o.resolver == nil || !o.resolver.IsBlocked(t.ID) {
 				_ = o.taskMgr.UpdateStatus(t.ID, task.StatusPending)
 				_ = o.queue.Enqueue(t)
@@ -563,16 +1269,106 @@ func (o *Orchestrator) onTaskFailed(ctx context.Context, event task.Event) {
 	// Log failure
 	log.Printf("Task %s failed: %s", event.TaskID, event.Message)
 
+	// Retry with the task's retry_prompt guidance while attempts remain
+	if o.retryTask(ctx, event.TaskID) {
+		o.assignPendingTasks(ctx)
+		return
+	}
+
 	// Update workstream scheduler - marks entire workstream as failed
 	if err := o.wsScheduler.FailTask(event.TaskID); err != nil {
 		log.Printf("Warning: failed to update workstream for failed task %s: %v", event.TaskID, err)
 	}
 
+	// Tasks sharing a group with it can't succeed now either
+	o.failGroup(event.TaskID)
+
 	// Task stays failed, agent becomes idle
 	// assignPendingTasks will pick up next task for idle agent
 	o.assignPendingTasks(ctx)
 }
 
+// retryTask retries a failed task if it has a retry_prompt and retries left.
+// The retry goes straight back to the agent the attempt failed on if it is
+// still idle, to reuse its worktree; otherwise the task is requeued for any
+// idle agent. Returns true if the task was retried.
+func (o *Orchestrator) retryTask(ctx context.Context, taskID string) bool {
+	t, err := o.taskMgr.Get(taskID)
+	if err != nil {
+		return false
+	}
+
+	maxRetries := o.config.GetWorkstreamMaxRetries(t.GetWorkstream())
+	if !t.CanRetry(maxRetries) {
+		if t.RetryPrompt != "" {
+			log.Printf("Task %s exhausted %d retries, leaving it failed", taskID, maxRetries)
+		}
+		return false
+	}
+
+	if err := o.taskMgr.Retry(taskID); err != nil {
+		log.Printf("Warning: failed to retry task %s: %v", taskID, err)
+		return false
+	}
+
+	// Reload for the retry count and failed agent Retry just recorded
+	if retried, err := o.taskMgr.Get(taskID); err == nil {
+		t = retried
+	}
+
+	log.Printf("Retrying task %s (retry %d/%d)", taskID, t.RetryCount, maxRetries)
+	if o.assignToFailedAgent(ctx, t) {
+		return true
+	}
+	if !o.queue.Contains(taskID) {
+		_ = o.queue.Enqueue(t)
+	}
+	return true
+}
+
+// assignToFailedAgent assigns a retried task to the agent its last attempt
+// failed on, if that agent is idle and not pinned to another task. It
+// returns false if the task should be queued instead.
+func (o *Orchestrator) assignToFailedAgent(ctx context.Context, t *task.Task) bool {
+	if t.FailedAgent == "" {
+		return false
+	}
+	ag, err := o.agentMgr.Get(t.FailedAgent)
+	if err != nil || ag.Status != "idle" {
+		return false
+	}
+	if ag.PinnedTask != "" && ag.PinnedTask != t.ID {
+		return false
+	}
+
+	if !o.assignTask(ctx, t, ag.Name) {
+		return false
+	}
+	log.Printf("Retry of %s kept on %s", t.ID, ag.Name)
+	return true
+}
+
+// onTaskRequeued returns a task to the queue after its agent turned out to be busy.
+// The task is not counted as a failure.
+func (o *Orchestrator) onTaskRequeued(event task.Event) {
+	if o.balancer != nil {
+		o.balancer.TrackCompletion(event.AgentName)
+	}
+
+	// Its group failed while it was waiting to rerun
+	if t, err := o.taskMgr.Get(event.TaskID); err == nil && o.inFailedGroup(t) {
+		o.setAsideGroupMember(event.TaskID)
+		return
+	}
+
+	_ = o.taskMgr.Unassign(event.TaskID)
+	_ = o.taskMgr.UpdateStatus(event.TaskID, task.StatusPending)
+
+	if t, err := o.taskMgr.Get(event.TaskID); err == nil && !o.queue.Contains(t.ID) {
+		_ = o.queue.Enqueue(t)
+	}
+}
+
 // onTaskBlocked handles task becoming blocked.
 func (o *Orchestrator) onTaskBlocked(event task.Event) {
 	log.Printf("Task %s became blocked", event.TaskID)
@@ -581,13 +1377,20 @@ func (o *Orchestrator) onTaskBlocked(event task.Event) {
 	_ = o.taskMgr.UpdateStatus(event.TaskID, task.StatusBlocked)
 }
 
-// isComplete checks if all tasks are complete.
+// isComplete checks if all tasks are complete. Blocked members of a failed
+// group can't run until someone intervenes, so they don't hold up completion.
 func (o *Orchestrator) isComplete() bool {
-	tasks, _ := o.taskMgr.Scan()
+	_, _ = o.taskMgr.Scan()
 
-	for _, t := range tasks {
-		switch t.Status {
-		case task.StatusPending, task.StatusAssigned, task.StatusInProgress, task.StatusBlocked:
+	// Filter by status through the manager, since runners update task
+	// statuses concurrently
+	for _, status := range []task.Status{task.StatusPending, task.StatusAssigned, task.StatusInProgress} {
+		if len(o.taskMgr.GetByStatus(status)) > 0 {
+			return false
+		}
+	}
+	for _, t := range o.taskMgr.GetByStatus(task.StatusBlocked) {
+		if !o.inFailedGroup(t) {
 			return false
 		}
 	}
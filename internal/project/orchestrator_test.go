@@ -2,17 +2,27 @@ package project
 
 import (
 	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
 	"testing"
 	"time"
 
 	"github.com/bkonkle/tanuki/internal/agent"
+	"github.com/bkonkle/tanuki/internal/config"
+	"github.com/bkonkle/tanuki/internal/state"
 	"github.com/bkonkle/tanuki/internal/task"
 )
 
 // Mock implementations for testing
 
 type mockTaskManager struct {
-	tasks map[string]*task.Task
+	tasks       map[string]*task.Task
+	parseErrors []*task.ParseError
+	scans       int
 }
 
 func newMockTaskManager() *mockTaskManager {
@@ -26,6 +36,7 @@ func (m *mockTaskManager) addTask(t *task.Task) {
 }
 
 func (m *mockTaskManager) Scan() ([]*task.Task, error) {
+	m.scans++
 	tasks := make([]*task.Task, 0, len(m.tasks))
 	for _, t := range m.tasks {
 		tasks = append(tasks, t)
@@ -33,6 +44,11 @@ func (m *mockTaskManager) Scan() ([]*task.Task, error) {
 	return tasks, nil
 }
 
+func (m *mockTaskManager) ScanStrict() ([]*task.Task, []*task.ParseError, error) {
+	tasks, err := m.Scan()
+	return tasks, m.parseErrors, err
+}
+
 func (m *mockTaskManager) Get(id string) (*task.Task, error) {
 	t, ok := m.tasks[id]
 	if !ok {
@@ -51,6 +67,16 @@ func (m *mockTaskManager) GetByWorkstream(workstream string) []*task.Task {
 	return tasks
 }
 
+func (m *mockTaskManager) GetByGroup(group string) []*task.Task {
+	var tasks []*task.Task
+	for _, t := range m.tasks {
+		if group != "" && t.Group == group {
+			tasks = append(tasks, t)
+		}
+	}
+	return tasks
+}
+
 func (m *mockTaskManager) GetByStatus(status task.Status) []*task.Task {
 	var tasks []*task.Task
 	for _, t := range m.tasks {
@@ -84,6 +110,19 @@ func (m *mockTaskManager) Assign(id string, agentName string) error {
 	return nil
 }
 
+func (m *mockTaskManager) Claim(id string, agentName string) (bool, error) {
+	t, ok := m.tasks[id]
+	if !ok {
+		return false, &task.ValidationError{Message: "not found"}
+	}
+	if t.AssignedTo != "" || (t.Status != task.StatusPending && t.Status != task.StatusBlocked) {
+		return false, nil
+	}
+	t.AssignedTo = agentName
+	t.Status = task.StatusAssigned
+	return true, nil
+}
+
 func (m *mockTaskManager) Unassign(id string) error {
 	t, ok := m.tasks[id]
 	if !ok {
@@ -93,6 +132,20 @@ func (m *mockTaskManager) Unassign(id string) error {
 	return nil
 }
 
+func (m *mockTaskManager) Retry(id string) error {
+	t, ok := m.tasks[id]
+	if !ok {
+		return &task.ValidationError{Message: "not found"}
+	}
+	t.RetryCount++
+	if t.AssignedTo != "" {
+		t.FailedAgent = t.AssignedTo
+	}
+	t.AssignedTo = ""
+	t.Status = task.StatusPending
+	return nil
+}
+
 func (m *mockTaskManager) IsBlocked(_ string) (bool, error) {
 	return false, nil
 }
@@ -170,6 +223,16 @@ func (m *mockAgentManager) Stop(name string) error {
 	return nil
 }
 
+func (m *mockAgentManager) Hold(name, taskID string) error {
+	ag, ok := m.agents[name]
+	if !ok {
+		return agent.ErrAgentNotFound
+	}
+	ag.Status = "failed-held"
+	ag.HeldFor = taskID
+	return nil
+}
+
 func (m *mockAgentManager) Remove(name string, _ agent.RemoveOptions) error {
 	delete(m.agents, name)
 	return nil
@@ -255,6 +318,60 @@ func TestNewOrchestrator(t *testing.T) {
 	}
 }
 
+func TestOrchestratorConfig_ApplyConfig(t *testing.T) {
+	conf := config.DefaultConfig()
+	conf.SkipVerify = true
+	conf.KeepContainerOnFailure = true
+	conf.RestartOnTaskChange = true
+	conf.ResumeRetries = true
+	conf.GroupFailure = "fail"
+	conf.DefaultEstimate = "2h"
+	conf.Workstreams = map[string]*config.WorkstreamConfig{
+		"api": {Concurrency: 3, MaxRetries: 4, MaxConcurrentTasks: 2},
+		"ui":  {Concurrency: 2, MaxConcurrentTasks: 3},
+	}
+
+	orchCfg := DefaultOrchestratorConfig()
+	orchCfg.WorkstreamConcurrency["ui"] = 5
+	orchCfg.WorkstreamTaskConcurrency["ui"] = 1
+	callerLimits := orchCfg.WorkstreamConcurrency
+
+	orchCfg.ApplyConfig(conf, "docs")
+
+	if !orchCfg.SkipVerify || !orchCfg.KeepContainerOnFailure || !orchCfg.RestartOnTaskChange || !orchCfg.ResumeRetries {
+		t.Errorf("flags not applied: %+v", orchCfg)
+	}
+	if orchCfg.GroupFailure != GroupFailureFail {
+		t.Errorf("GroupFailure = %q, want fail", orchCfg.GroupFailure)
+	}
+	if orchCfg.DefaultEstimate != 2*time.Hour {
+		t.Errorf("DefaultEstimate = %v, want 2h", orchCfg.DefaultEstimate)
+	}
+	if len(callerLimits) != 1 {
+		t.Errorf("caller's concurrency map was modified: %v", callerLimits)
+	}
+
+	tests := []struct {
+		workstream                  string
+		concurrency, tasks, retries int
+	}{
+		{"api", 3, 2, 4},
+		{"ui", 5, 1, 1},   // limits already set are kept
+		{"docs", 1, 0, 1}, // named but not configured
+	}
+	for _, tt := range tests {
+		if got := orchCfg.GetWorkstreamConcurrency(tt.workstream); got != tt.concurrency {
+			t.Errorf("%s concurrency = %d, want %d", tt.workstream, got, tt.concurrency)
+		}
+		if got := orchCfg.GetWorkstreamTaskConcurrency(tt.workstream); got != tt.tasks {
+			t.Errorf("%s task concurrency = %d, want %d", tt.workstream, got, tt.tasks)
+		}
+		if got := orchCfg.GetWorkstreamMaxRetries(tt.workstream); got != tt.retries {
+			t.Errorf("%s max retries = %d, want %d", tt.workstream, got, tt.retries)
+		}
+	}
+}
+
 func TestOrchestrator_StartWithNoTasks(t *testing.T) {
 	taskMgr := newMockTaskManager()
 	agentMgr := newMockAgentManager()
@@ -325,6 +442,166 @@ func TestOrchestrator_GetProgress(t *testing.T) {
 	}
 }
 
+func TestOrchestrator_GetProgress_Cached(t *testing.T) {
+	taskMgr := newMockTaskManager()
+	taskMgr.addTask(&task.Task{ID: "T1", Workstream: "backend", Status: task.StatusPending})
+	taskMgr.addTask(&task.Task{ID: "T2", Workstream: "backend", Status: task.StatusPending})
+
+	orch := NewOrchestrator(taskMgr, newMockAgentManager(), newMockTaskQueue(), DefaultOrchestratorConfig())
+	orch.tick(context.Background())
+	scans := taskMgr.scans
+
+	// Edits aren't picked up until the next tick or a forced refresh
+	taskMgr.tasks["T1"].Status = task.StatusComplete
+	taskMgr.addTask(&task.Task{ID: "T3", Workstream: "frontend", Status: task.StatusPending})
+
+	if progress := orch.GetProgress(); progress.Total != 2 || progress.Complete != 0 {
+		t.Errorf("GetProgress() = %+v, want the snapshot from the last tick", progress)
+	}
+	if taskMgr.scans != scans {
+		t.Errorf("GetProgress() scanned tasks %d times, want 0", taskMgr.scans-scans)
+	}
+
+	// Callers can't change the snapshot
+	orch.GetProgress().ByWorkstream["backend"].Total = 99
+	if got := orch.GetProgress().ByWorkstream["backend"].Total; got != 2 {
+		t.Errorf("backend Total = %d after modifying a copy, want 2", got)
+	}
+
+	if progress := orch.ForceRefresh(); progress.Total != 3 || progress.Complete != 1 {
+		t.Errorf("ForceRefresh() = %+v, want total 3, complete 1", progress)
+	}
+	if progress := orch.GetProgress(); progress.Total != 3 {
+		t.Errorf("GetProgress() Total = %d after ForceRefresh, want 3", progress.Total)
+	}
+}
+
+func TestOrchestrator_GetProgress_AfterEvent(t *testing.T) {
+	taskMgr := newMockTaskManager()
+	taskMgr.addTask(&task.Task{ID: "T1", Workstream: "backend", Status: task.StatusInProgress, AssignedTo: "agent-1"})
+	taskMgr.addTask(&task.Task{ID: "T2", Workstream: "backend", Status: task.StatusPending})
+
+	orch := NewOrchestrator(taskMgr, newMockAgentManager(), newMockTaskQueue(), DefaultOrchestratorConfig())
+	orch.tick(context.Background())
+
+	taskMgr.tasks["T1"].Status = task.StatusComplete
+	orch.handleEvent(context.Background(), task.Event{Type: task.EventTaskCompleted, TaskID: "T1", AgentName: "agent-1"})
+
+	if progress := orch.GetProgress(); progress.Complete != 1 {
+		t.Errorf("GetProgress() Complete = %d after a completion event, want 1", progress.Complete)
+	}
+}
+
+func TestOrchestrator_GetProgress_ConcurrentWithTick(t *testing.T) {
+	taskMgr := newMockTaskManager()
+	taskMgr.addTask(&task.Task{ID: "T1", Workstream: "backend", Status: task.StatusComplete})
+	taskMgr.addTask(&task.Task{ID: "T2", Workstream: "frontend", Status: task.StatusPending})
+
+	orch := NewOrchestrator(taskMgr, newMockAgentManager(), newMockTaskQueue(), DefaultOrchestratorConfig())
+	orch.tick(context.Background())
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				if progress := orch.GetProgress(); progress.Total != 2 || progress.Complete != 1 {
+					t.Errorf("GetProgress() = %+v, want total 2, complete 1", progress)
+					return
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 100; i++ {
+		orch.tick(context.Background())
+	}
+	close(done)
+	wg.Wait()
+}
+
+func TestProgressFromStats(t *testing.T) {
+	stats := &task.Stats{
+		Total: 4,
+		ByStatus: map[task.Status]int{
+			task.StatusComplete:   2,
+			task.StatusInProgress: 1,
+			task.StatusPending:    1,
+		},
+		ByWorkstreamStatus: map[string]map[task.Status]int{
+			"backend":  {task.StatusComplete: 2, task.StatusInProgress: 1},
+			"frontend": {task.StatusPending: 1},
+		},
+	}
+
+	progress := ProgressFromStats(stats)
+
+	if progress.Total != 4 || progress.Complete != 2 || progress.InProgress != 1 || progress.Pending != 1 {
+		t.Errorf("ProgressFromStats() = %+v, want total 4, complete 2, in progress 1, pending 1", progress)
+	}
+	if progress.Percentage != 50 {
+		t.Errorf("Percentage = %f, want 50", progress.Percentage)
+	}
+
+	backend := progress.ByWorkstream["backend"]
+	if backend == nil || backend.Total != 3 || backend.Complete != 2 || backend.InProgress != 1 {
+		t.Errorf("ByWorkstream[backend] = %+v, want total 3, complete 2, in progress 1", backend)
+	}
+	frontend := progress.ByWorkstream["frontend"]
+	if frontend == nil || frontend.Total != 1 || frontend.Complete != 0 {
+		t.Errorf("ByWorkstream[frontend] = %+v, want total 1, complete 0", frontend)
+	}
+}
+
+func TestOrchestrator_EstimatedRemaining(t *testing.T) {
+	taskMgr := newMockTaskManager()
+	taskMgr.addTask(&task.Task{ID: "T1", Workstream: "backend", Status: task.StatusComplete, Estimate: "8h"})
+	taskMgr.addTask(&task.Task{ID: "T2", Workstream: "backend", Status: task.StatusPending, Estimate: "1h"})
+	taskMgr.addTask(&task.Task{ID: "T3", Workstream: "backend", Status: task.StatusPending})
+	taskMgr.addTask(&task.Task{ID: "T4", Workstream: "frontend", Status: task.StatusInProgress, Estimate: "2h"})
+	agentMgr := newMockAgentManager()
+	queue := newMockTaskQueue()
+	config := DefaultOrchestratorConfig()
+	config.DefaultEstimate = time.Hour
+
+	orch := NewOrchestrator(taskMgr, agentMgr, queue, config)
+
+	// 1h + 1h (default) + 2h = 4h across two single-concurrency workstreams
+	if got := orch.EstimatedRemaining(); got != 2*time.Hour {
+		t.Errorf("EstimatedRemaining() = %v, want 2h", got)
+	}
+
+	// Raising backend concurrency lets both backend tasks run at once
+	orch.SetWorkstreamConcurrency("backend", 3)
+	if got := orch.EstimatedRemaining(); got != 80*time.Minute {
+		t.Errorf("EstimatedRemaining() with concurrency = %v, want 1h20m", got)
+	}
+}
+
+func TestEstimateFromRate(t *testing.T) {
+	tasks := []*task.Task{
+		{ID: "T1", Status: task.StatusComplete},
+		{ID: "T2", Status: task.StatusPending},
+		{ID: "T3", Status: task.StatusInProgress},
+		{ID: "T4", Status: task.StatusBlocked},
+		{ID: "T5", Status: task.StatusFailed},
+	}
+
+	if got := EstimateFromRate(tasks, 2); got != 90*time.Minute {
+		t.Errorf("EstimateFromRate(2/hr) = %v, want 1h30m", got)
+	}
+	if got := EstimateFromRate(tasks, 0); got != 0 {
+		t.Errorf("EstimateFromRate(0) = %v, want 0", got)
+	}
+}
+
 func TestOrchestrator_Status(t *testing.T) {
 	taskMgr := newMockTaskManager()
 	taskMgr.addTask(&task.Task{ID: "T1", Workstream: "backend", Status: task.StatusPending})
@@ -443,6 +720,14 @@ func TestDefaultOrchestratorConfig(t *testing.T) {
 	if config.StopWhenComplete {
 		t.Error("StopWhenComplete should default to false")
 	}
+
+	if !config.HandleSignals {
+		t.Error("HandleSignals should default to true")
+	}
+
+	if config.ShutdownGracePeriod != DefaultShutdownGracePeriod {
+		t.Errorf("ShutdownGracePeriod = %v, want %v", config.ShutdownGracePeriod, DefaultShutdownGracePeriod)
+	}
 }
 
 func TestOrchestrator_Events(t *testing.T) {
@@ -508,3 +793,619 @@ func TestOrchestrator_HandleEvent_TaskCompleted(t *testing.T) {
 		t.Errorf("Task should be unassigned after completion")
 	}
 }
+
+func TestOrchestrator_HandleEvent_TaskRequeued(t *testing.T) {
+	taskMgr := newMockTaskManager()
+	taskMgr.addTask(&task.Task{
+		ID:         "T1",
+		Workstream: "backend",
+		Status:     task.StatusFailed,
+		AssignedTo: "be-1",
+	})
+	agentMgr := newMockAgentManager()
+	queue := newMockTaskQueue()
+	config := DefaultOrchestratorConfig()
+
+	orch := NewOrchestrator(taskMgr, agentMgr, queue, config)
+
+	event := task.Event{
+		Type:      task.EventTaskRequeued,
+		TaskID:    "T1",
+		AgentName: "be-1",
+	}
+
+	orch.handleEvent(context.Background(), event)
+
+	tsk, _ := taskMgr.Get("T1")
+	if tsk.Status != task.StatusPending {
+		t.Errorf("Status = %s, want pending after requeue", tsk.Status)
+	}
+	if tsk.AssignedTo != "" {
+		t.Errorf("Task should be unassigned after requeue")
+	}
+	if !queue.Contains("T1") {
+		t.Error("Task should be back in the queue")
+	}
+}
+
+func TestOrchestrator_AssignPendingTasks_MaxTotalAgents(t *testing.T) {
+	taskMgr := newMockTaskManager()
+	agentMgr := newMockAgentManager()
+	queue := newMockTaskQueue()
+
+	for _, id := range []string{"T1", "T2", "T3"} {
+		tsk := &task.Task{ID: id, Workstream: "backend", Status: task.StatusPending}
+		taskMgr.addTask(tsk)
+		_ = queue.Enqueue(tsk)
+	}
+	for _, name := range []string{"be-1", "be-2", "be-3"} {
+		agentMgr.addAgent(&agent.Agent{Name: name, Workstream: "backend", Status: "idle"})
+	}
+
+	config := DefaultOrchestratorConfig()
+	config.MaxTotalAgents = 2
+	orch := NewOrchestrator(taskMgr, agentMgr, queue, config)
+
+	orch.assignPendingTasks(context.Background())
+
+	assigned := 0
+	for _, id := range []string{"T1", "T2", "T3"} {
+		if tsk, _ := taskMgr.Get(id); tsk.AssignedTo != "" {
+			assigned++
+		}
+	}
+	if assigned != 2 {
+		t.Errorf("assigned = %d, want 2 with MaxTotalAgents=2", assigned)
+	}
+	if queue.Size() != 1 {
+		t.Errorf("queue size = %d, want 1", queue.Size())
+	}
+}
+
+func TestOrchestrator_AssignPendingTasks_PinnedAgent(t *testing.T) {
+	taskMgr := newMockTaskManager()
+	agentMgr := newMockAgentManager()
+	queue := newMockTaskQueue()
+
+	for _, id := range []string{"T1", "T2"} {
+		tsk := &task.Task{ID: id, Workstream: "backend", Status: task.StatusPending}
+		taskMgr.addTask(tsk)
+		_ = queue.Enqueue(tsk)
+	}
+	agentMgr.addAgent(&agent.Agent{Name: "be-1", Workstream: "backend", Status: "idle"})
+	agentMgr.addAgent(&agent.Agent{Name: "pin-1", Status: "idle", PinnedTask: "T2"})
+
+	orch := NewOrchestrator(taskMgr, agentMgr, queue, DefaultOrchestratorConfig())
+	orch.assignPendingTasks(context.Background())
+
+	if tsk, _ := taskMgr.Get("T2"); tsk.AssignedTo != "pin-1" {
+		t.Errorf("T2 assigned to %q, want its pinned agent pin-1", tsk.AssignedTo)
+	}
+	if tsk, _ := taskMgr.Get("T1"); tsk.AssignedTo != "be-1" {
+		t.Errorf("T1 assigned to %q, want be-1", tsk.AssignedTo)
+	}
+
+	// Once its task is done, the pinned agent doesn't pull queued work
+	done, _ := taskMgr.Get("T2")
+	done.Status = task.StatusComplete
+	tsk := &task.Task{ID: "T3", Workstream: "backend", Status: task.StatusPending}
+	taskMgr.addTask(tsk)
+	_ = queue.Enqueue(tsk)
+	agentMgr.agents["be-1"].Status = "working"
+
+	orch.assignPendingTasks(context.Background())
+	if tsk, _ := taskMgr.Get("T3"); tsk.AssignedTo != "" {
+		t.Errorf("T3 assigned to %q, want it left for a workstream agent", tsk.AssignedTo)
+	}
+}
+
+func TestOrchestrator_AssignPendingTasks_TaskConcurrency(t *testing.T) {
+	taskMgr := newMockTaskManager()
+	agentMgr := newMockAgentManager()
+	queue := newMockTaskQueue()
+
+	taskMgr.addTask(&task.Task{ID: "T1", Workstream: "backend", Status: task.StatusInProgress, AssignedTo: "be-1"})
+	for _, id := range []string{"T2", "T3", "T4"} {
+		tsk := &task.Task{ID: id, Workstream: "backend", Status: task.StatusPending}
+		taskMgr.addTask(tsk)
+		_ = queue.Enqueue(tsk)
+	}
+	agentMgr.addAgent(&agent.Agent{Name: "be-1", Workstream: "backend", Status: "working"})
+	for _, name := range []string{"be-2", "be-3", "be-4"} {
+		agentMgr.addAgent(&agent.Agent{Name: name, Workstream: "backend", Status: "idle"})
+	}
+
+	config := DefaultOrchestratorConfig()
+	config.WorkstreamConcurrency["backend"] = 4
+	config.WorkstreamTaskConcurrency["backend"] = 2
+
+	orch := NewOrchestrator(taskMgr, agentMgr, queue, config)
+	orch.assignPendingTasks(context.Background())
+
+	// T1 is already in flight, so only one more task fits under the cap
+	assigned := 0
+	for _, id := range []string{"T2", "T3", "T4"} {
+		if tsk, _ := taskMgr.Get(id); tsk.AssignedTo != "" {
+			assigned++
+		}
+	}
+	if assigned != 1 {
+		t.Errorf("assigned %d tasks, want 1 with max 2 in flight", assigned)
+	}
+	if queue.Size() != 2 {
+		t.Errorf("queue size = %d, want the other 2 tasks left queued", queue.Size())
+	}
+}
+
+func TestOrchestrator_DependencyResolver_BlockedTaskNotAssigned(t *testing.T) {
+	dir := t.TempDir()
+	tasksDir := filepath.Join(dir, "tasks")
+	if err := os.MkdirAll(tasksDir, 0750); err != nil {
+		t.Fatal(err)
+	}
+	createTestTask(t, tasksDir, &task.Task{ID: "T1", Title: "First", Workstream: "backend", Status: task.StatusPending})
+	createTestTask(t, tasksDir, &task.Task{ID: "T2", Title: "Second", Workstream: "backend", Status: task.StatusPending, DependsOn: []string{"T1"}})
+
+	taskMgr := task.NewManager(&task.Config{ProjectRoot: dir})
+	agentMgr := newMockAgentManager()
+	for _, name := range []string{"be-1", "be-2"} {
+		agentMgr.addAgent(&agent.Agent{Name: name, Workstream: "backend", Status: "idle"})
+	}
+
+	config := DefaultOrchestratorConfig()
+	config.AutoSpawnAgents = false
+	config.WorkstreamConcurrency["backend"] = 2
+	orch := NewOrchestrator(NewTaskManagerAdapter(taskMgr), agentMgr, task.NewQueue(), config)
+	resolver := NewDependencyResolver(taskMgr)
+	orch.SetResolver(resolver)
+
+	if err := orch.initialize(context.Background()); err != nil {
+		t.Fatalf("initialize() error: %v", err)
+	}
+	orch.assignPendingTasks(context.Background())
+
+	if tsk, _ := taskMgr.Get("T1"); tsk.AssignedTo == "" {
+		t.Error("T1 not assigned, want it assigned")
+	}
+	if tsk, _ := taskMgr.Get("T2"); tsk.AssignedTo != "" {
+		t.Errorf("T2 assigned to %q, want it left waiting on T1", tsk.AssignedTo)
+	}
+
+	// The resolver reads the live tasks, so completing T1 unblocks T2
+	if err := taskMgr.UpdateStatus("T1", task.StatusComplete); err != nil {
+		t.Fatal(err)
+	}
+	if resolver.IsBlocked("T2") {
+		t.Error("IsBlocked(T2) = true after T1 completed, want false")
+	}
+}
+
+func TestOrchestrator_DependencyResolver_Cycle(t *testing.T) {
+	dir := t.TempDir()
+	tasksDir := filepath.Join(dir, "tasks")
+	if err := os.MkdirAll(tasksDir, 0750); err != nil {
+		t.Fatal(err)
+	}
+	createTestTask(t, tasksDir, &task.Task{ID: "T1", Title: "First", Status: task.StatusPending, DependsOn: []string{"T2"}})
+	createTestTask(t, tasksDir, &task.Task{ID: "T2", Title: "Second", Status: task.StatusPending, DependsOn: []string{"T1"}})
+
+	taskMgr := task.NewManager(&task.Config{ProjectRoot: dir})
+	config := DefaultOrchestratorConfig()
+	config.AutoSpawnAgents = false
+	orch := NewOrchestrator(NewTaskManagerAdapter(taskMgr), newMockAgentManager(), task.NewQueue(), config)
+	orch.SetResolver(NewDependencyResolver(taskMgr))
+
+	err := orch.initialize(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "dependency cycle") {
+		t.Errorf("initialize() error = %v, want a dependency cycle error", err)
+	}
+}
+
+func TestOrchestrator_HandleEvent_TaskFailedRetry(t *testing.T) {
+	taskMgr := newMockTaskManager()
+	taskMgr.addTask(&task.Task{
+		ID:          "T1",
+		Workstream:  "backend",
+		Status:      task.StatusFailed,
+		AssignedTo:  "be-1",
+		RetryPrompt: "Focus on the failing test",
+	})
+	agentMgr := newMockAgentManager()
+	queue := newMockTaskQueue()
+	config := DefaultOrchestratorConfig()
+	config.WorkstreamMaxRetries["backend"] = 2
+
+	orch := NewOrchestrator(taskMgr, agentMgr, queue, config)
+
+	event := task.Event{
+		Type:      task.EventTaskFailed,
+		TaskID:    "T1",
+		AgentName: "be-1",
+		Message:   "tests failed",
+	}
+
+	// Two retries are allowed, then the task stays failed
+	for want := 1; want <= 2; want++ {
+		orch.handleEvent(context.Background(), event)
+
+		tsk, _ := taskMgr.Get("T1")
+		if tsk.Status != task.StatusPending {
+			t.Fatalf("retry %d: Status = %s, want pending", want, tsk.Status)
+		}
+		if tsk.RetryCount != want {
+			t.Errorf("retry %d: RetryCount = %d, want %d", want, tsk.RetryCount, want)
+		}
+		if !queue.Contains("T1") {
+			t.Errorf("retry %d: task should be requeued", want)
+		}
+
+		_, _ = queue.Dequeue("backend")
+		_ = taskMgr.UpdateStatus("T1", task.StatusFailed)
+	}
+
+	orch.handleEvent(context.Background(), event)
+
+	tsk, _ := taskMgr.Get("T1")
+	if tsk.Status != task.StatusFailed {
+		t.Errorf("Status = %s, want failed after retries exhausted", tsk.Status)
+	}
+	if tsk.RetryCount != 2 {
+		t.Errorf("RetryCount = %d, want 2", tsk.RetryCount)
+	}
+	if queue.Contains("T1") {
+		t.Error("task should not be requeued after retries exhausted")
+	}
+}
+
+func TestOrchestrator_HandleEvent_RetryPrefersFailedAgent(t *testing.T) {
+	tests := []struct {
+		name        string
+		failedState state.Status
+		wantAgent   string
+	}{
+		{"idle failed agent keeps the retry", state.StatusIdle, "be-1"},
+		{"held failed agent falls back to the queue", state.StatusHeld, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskMgr := newMockTaskManager()
+			taskMgr.addTask(&task.Task{
+				ID:          "T1",
+				Workstream:  "backend",
+				Status:      task.StatusFailed,
+				AssignedTo:  "be-1",
+				RetryPrompt: "Focus on the failing test",
+			})
+			agentMgr := newMockAgentManager()
+			agentMgr.addAgent(&agent.Agent{Name: "be-1", Workstream: "backend", Status: tt.failedState})
+			agentMgr.addAgent(&agent.Agent{Name: "be-2", Workstream: "other", Status: "idle"})
+			queue := newMockTaskQueue()
+
+			orch := NewOrchestrator(taskMgr, agentMgr, queue, DefaultOrchestratorConfig())
+			orch.handleEvent(context.Background(), task.Event{
+				Type:      task.EventTaskFailed,
+				TaskID:    "T1",
+				AgentName: "be-1",
+				Message:   "tests failed",
+			})
+
+			tsk, _ := taskMgr.Get("T1")
+			if tsk.AssignedTo != tt.wantAgent {
+				t.Errorf("AssignedTo = %q, want %q", tsk.AssignedTo, tt.wantAgent)
+			}
+			if queued := queue.Contains("T1"); queued != (tt.wantAgent == "") {
+				t.Errorf("queued = %v, want %v", queued, tt.wantAgent == "")
+			}
+		})
+	}
+}
+
+func TestOrchestrator_HandleEvent_TaskFailedNoRetryPrompt(t *testing.T) {
+	taskMgr := newMockTaskManager()
+	taskMgr.addTask(&task.Task{ID: "T1", Workstream: "backend", Status: task.StatusFailed})
+	queue := newMockTaskQueue()
+
+	orch := NewOrchestrator(taskMgr, newMockAgentManager(), queue, DefaultOrchestratorConfig())
+	orch.handleEvent(context.Background(), task.Event{Type: task.EventTaskFailed, TaskID: "T1"})
+
+	tsk, _ := taskMgr.Get("T1")
+	if tsk.Status != task.StatusFailed || tsk.RetryCount != 0 {
+		t.Errorf("task without retry_prompt should stay failed, got status=%s retries=%d", tsk.Status, tsk.RetryCount)
+	}
+}
+
+// blockingRunner blocks until released or, if honorCtx is set, until its
+// context is cancelled.
+type blockingRunner struct {
+	started  chan string
+	release  chan struct{}
+	honorCtx bool
+}
+
+func (r *blockingRunner) RunTask(ctx context.Context, taskID, _ string) error {
+	r.started <- taskID
+	if r.honorCtx {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-r.release:
+			return nil
+		}
+	}
+	<-r.release
+	return errors.New("agent stopped")
+}
+
+func newShutdownTestOrchestrator(runner *blockingRunner, grace time.Duration) (*Orchestrator, *mockTaskManager, *mockAgentManager) {
+	taskMgr := newMockTaskManager()
+	taskMgr.addTask(&task.Task{ID: "T1", Workstream: "backend", Status: task.StatusPending})
+	agentMgr := newMockAgentManager()
+	agentMgr.addAgent(&agent.Agent{Name: "be-1", Workstream: "backend", Status: "working"})
+
+	config := DefaultOrchestratorConfig()
+	config.HandleSignals = false
+	config.ShutdownGracePeriod = grace
+
+	orch := NewOrchestrator(taskMgr, agentMgr, newMockTaskQueue(), config)
+	orch.SetRunner(runner)
+	orch.setStatus(StatusRunning)
+	return orch, taskMgr, agentMgr
+}
+
+func TestOrchestrator_KeepContainerOnFailure(t *testing.T) {
+	runner := &blockingRunner{started: make(chan string, 1), release: make(chan struct{})}
+	orch, taskMgr, agentMgr := newShutdownTestOrchestrator(runner, time.Second)
+	orch.config.KeepContainerOnFailure = true
+
+	tsk, _ := taskMgr.Get("T1")
+	orch.assignTask(context.Background(), tsk, "be-1")
+	<-runner.started
+	close(runner.release)
+
+	select {
+	case ev := <-orch.Events():
+		if ev.Type != task.EventTaskFailed {
+			t.Fatalf("event = %s, want task failed", ev.Type)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for the task to fail")
+	}
+
+	ag, _ := agentMgr.Get("be-1")
+	if ag.Status != "failed-held" || ag.HeldFor != "T1" {
+		t.Errorf("agent = %s held for %q, want failed-held for T1", ag.Status, ag.HeldFor)
+	}
+	if idle := orch.orderIdleAgents([]*agent.Agent{ag}); len(idle) != 0 {
+		t.Errorf("orderIdleAgents() = %v, want the held agent excluded", idle)
+	}
+}
+
+func TestOrchestrator_Shutdown_InterruptedTaskReturnsToPending(t *testing.T) {
+	runner := &blockingRunner{started: make(chan string, 1), release: make(chan struct{}), honorCtx: true}
+	orch, taskMgr, agentMgr := newShutdownTestOrchestrator(runner, time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	tsk, _ := taskMgr.Get("T1")
+	orch.assignTask(ctx, tsk, "be-1")
+	<-runner.started
+
+	cancel()
+	orch.shutdown()
+
+	tsk, _ = taskMgr.Get("T1")
+	if tsk.Status != task.StatusPending {
+		t.Errorf("Status = %s, want pending after interrupted run", tsk.Status)
+	}
+	if tsk.AssignedTo != "" {
+		t.Errorf("AssignedTo = %q, want empty", tsk.AssignedTo)
+	}
+	if ag, _ := agentMgr.Get("be-1"); ag.Status != "stopped" {
+		t.Errorf("agent status = %s, want stopped", ag.Status)
+	}
+	if got := orch.GetStatus().Status; got != StatusStopped {
+		t.Errorf("orchestrator status = %s, want stopped", got)
+	}
+	select {
+	case ev := <-orch.Events():
+		t.Errorf("interrupted task should not emit events, got %s", ev.Type)
+	default:
+	}
+}
+
+func TestOrchestrator_Shutdown_GracePeriodElapses(t *testing.T) {
+	runner := &blockingRunner{started: make(chan string, 1), release: make(chan struct{})}
+	orch, taskMgr, _ := newShutdownTestOrchestrator(runner, 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	tsk, _ := taskMgr.Get("T1")
+	orch.assignTask(ctx, tsk, "be-1")
+	<-runner.started
+
+	cancel()
+	start := time.Now()
+	orch.shutdown()
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("shutdown took %v, want it bounded by the grace period", elapsed)
+	}
+
+	tsk, _ = taskMgr.Get("T1")
+	if tsk.Status != task.StatusPending {
+		t.Errorf("Status = %s, want pending for task still running after grace period", tsk.Status)
+	}
+
+	// Let the runner finish so it doesn't outlive the test
+	close(runner.release)
+	orch.inflight.Wait()
+}
+
+func TestOrchestrator_CheckChangedTasks_WarnsOnly(t *testing.T) {
+	runner := &blockingRunner{started: make(chan string, 1), release: make(chan struct{}), honorCtx: true}
+	orch, taskMgr, agentMgr := newShutdownTestOrchestrator(runner, time.Second)
+
+	tsk, _ := taskMgr.Get("T1")
+	tsk.ContentHash = task.HashContent("original")
+	orch.assignTask(context.Background(), tsk, "be-1")
+	<-runner.started
+
+	tsk.ContentHash = task.HashContent("edited")
+	tasks, _ := taskMgr.Scan()
+	orch.checkChangedTasks(tasks)
+
+	if ag, _ := agentMgr.Get("be-1"); ag.Status != "working" {
+		t.Errorf("agent status = %s, want working when restart is disabled", ag.Status)
+	}
+	orch.mu.RLock()
+	hash := orch.running["T1"].hash
+	orch.mu.RUnlock()
+	if hash != tsk.ContentHash {
+		t.Error("running hash not updated, want the warning logged once per edit")
+	}
+
+	close(runner.release)
+	if ev := <-orch.Events(); ev.Type != task.EventTaskCompleted {
+		t.Errorf("event = %s, want %s", ev.Type, task.EventTaskCompleted)
+	}
+}
+
+func TestOrchestrator_CheckChangedTasks_Restarts(t *testing.T) {
+	runner := &blockingRunner{started: make(chan string, 1), release: make(chan struct{}), honorCtx: true}
+	orch, taskMgr, agentMgr := newShutdownTestOrchestrator(runner, time.Second)
+	orch.config.RestartOnTaskChange = true
+
+	tsk, _ := taskMgr.Get("T1")
+	tsk.ContentHash = task.HashContent("original")
+	orch.assignTask(context.Background(), tsk, "be-1")
+	<-runner.started
+
+	// Unchanged tasks are left alone
+	tasks, _ := taskMgr.Scan()
+	orch.checkChangedTasks(tasks)
+	if ag, _ := agentMgr.Get("be-1"); ag.Status != "working" {
+		t.Fatalf("agent status = %s, want working for an unchanged task", ag.Status)
+	}
+
+	tsk.ContentHash = task.HashContent("edited")
+	orch.checkChangedTasks(tasks)
+
+	var ev task.Event
+	select {
+	case ev = <-orch.Events():
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the restarted task to be requeued")
+	}
+	if ev.Type != task.EventTaskRequeued {
+		t.Fatalf("event = %s, want %s", ev.Type, task.EventTaskRequeued)
+	}
+	orch.inflight.Wait()
+
+	if ag, _ := agentMgr.Get("be-1"); ag.Status != "idle" {
+		t.Errorf("agent status = %s, want idle after restart", ag.Status)
+	}
+
+	orch.handleEvent(context.Background(), ev)
+	tsk, _ = taskMgr.Get("T1")
+	if tsk.Status != task.StatusPending {
+		t.Errorf("Status = %s, want pending after restart", tsk.Status)
+	}
+	if !orch.queue.Contains("T1") {
+		t.Error("Task should be back in the queue")
+	}
+}
+
+func TestOrchestrator_WatchSignals(t *testing.T) {
+	origExit := exitProcess
+	t.Cleanup(func() { exitProcess = origExit })
+
+	exited := make(chan int, 1)
+	exitProcess = func(code int) { exited <- code }
+
+	orch := NewOrchestrator(newMockTaskManager(), newMockAgentManager(), newMockTaskQueue(), DefaultOrchestratorConfig())
+
+	sigCh := make(chan os.Signal, 2)
+	done := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	finished := make(chan struct{})
+	go func() {
+		orch.watchSignals(sigCh, cancel, done)
+		close(finished)
+	}()
+
+	sigCh <- syscall.SIGTERM
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("first signal should cancel the context")
+	}
+
+	sigCh <- os.Interrupt
+	select {
+	case code := <-exited:
+		if code != 1 {
+			t.Errorf("exit code = %d, want 1", code)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("second signal should force exit")
+	}
+	<-finished
+}
+
+func TestOrchestrator_Start_SignalShutsDown(t *testing.T) {
+	origNotify, origStop := notifySignals, stopSignals
+	t.Cleanup(func() { notifySignals, stopSignals = origNotify, origStop })
+
+	registered := make(chan chan<- os.Signal, 1)
+	notifySignals = func(c chan<- os.Signal) { registered <- c }
+	stopSignals = func(chan<- os.Signal) {}
+
+	taskMgr := newMockTaskManager()
+	taskMgr.addTask(&task.Task{ID: "T1", Workstream: "backend", Status: task.StatusComplete})
+
+	config := DefaultOrchestratorConfig()
+	config.PollInterval = time.Hour
+	config.AutoSpawnAgents = false
+	orch := NewOrchestrator(taskMgr, newMockAgentManager(), newMockTaskQueue(), config)
+
+	result := make(chan error, 1)
+	go func() { result <- orch.Start(context.Background()) }()
+
+	sigCh := <-registered
+	sigCh <- syscall.SIGTERM
+
+	select {
+	case err := <-result:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Start() error = %v, want context.Canceled", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Start() did not return after signal")
+	}
+
+	if got := orch.GetStatus().Status; got != StatusStopped {
+		t.Errorf("status = %s, want stopped", got)
+	}
+}
+
+func TestOrchestrator_Tick_ReportsBrokenTaskFiles(t *testing.T) {
+	taskMgr := newMockTaskManager()
+	taskMgr.addTask(&task.Task{ID: "T1", Workstream: "be", Status: task.StatusInProgress})
+	taskMgr.parseErrors = []*task.ParseError{{Path: "T1.md", Kept: "T1", Err: errors.New("yaml: line 2: did not find expected node content")}}
+
+	orch := NewOrchestrator(taskMgr, newMockAgentManager(), newMockTaskQueue(), DefaultOrchestratorConfig())
+	orch.tick(context.Background())
+
+	if orch.brokenFiles["T1.md"] == "" {
+		t.Error("broken task file not tracked")
+	}
+	if tsk, err := taskMgr.Get("T1"); err != nil || tsk.Status != task.StatusInProgress {
+		t.Errorf("T1 = %v, %v; want its kept version left alone", tsk, err)
+	}
+
+	taskMgr.parseErrors = nil
+	orch.tick(context.Background())
+	if len(orch.brokenFiles) != 0 {
+		t.Errorf("brokenFiles = %v, want cleared once the file parses again", orch.brokenFiles)
+	}
+}
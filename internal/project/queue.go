@@ -0,0 +1,117 @@
+package project
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/bkonkle/tanuki/internal/state"
+)
+
+// queueLockTimeout bounds how long queue override reads and writes wait for
+// another process.
+const queueLockTimeout = 5 * time.Second
+
+// QueueOverride is a manual reordering of the ready queue, requested with
+// `tanuki project promote` or `tanuki project demote` and applied by the
+// running project before it picks its next workstream.
+type QueueOverride struct {
+	Workstream string `json:"workstream"`
+
+	// Demote moves the workstream to the back instead of the front
+	Demote bool `json:"demote,omitempty"`
+}
+
+// QueueOverridesPath returns the file pending queue overrides are kept in.
+func QueueOverridesPath(projectRoot string) string {
+	return filepath.Join(projectRoot, ".tanuki", "state", "queue-overrides.json")
+}
+
+// AddQueueOverride records an override for the running project to apply.
+func AddQueueOverride(projectRoot string, override QueueOverride) error {
+	path := QueueOverridesPath(projectRoot)
+	lock, err := lockQueueOverrides(path)
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
+	overrides, err := readQueueOverrides(path)
+	if err != nil {
+		return err
+	}
+	overrides = append(overrides, override)
+
+	data, err := json.MarshalIndent(overrides, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode queue overrides: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("write queue overrides: %w", err)
+	}
+	return nil
+}
+
+// TakeQueueOverrides returns the pending overrides in the order they were
+// added and clears them, so each is applied once.
+func TakeQueueOverrides(projectRoot string) ([]QueueOverride, error) {
+	path := QueueOverridesPath(projectRoot)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	lock, err := lockQueueOverrides(path)
+	if err != nil {
+		return nil, err
+	}
+	defer lock.Release()
+
+	overrides, err := readQueueOverrides(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("clear queue overrides: %w", err)
+	}
+	return overrides, nil
+}
+
+// ApplyQueueOverrides promotes or demotes each workstream in turn, so the
+// most recent promotion ends up at the front.
+func (s *ReadinessAwareScheduler) ApplyQueueOverrides(overrides []QueueOverride) {
+	for _, o := range overrides {
+		if o.Demote {
+			s.DemoteWorkstream(o.Workstream)
+		} else {
+			s.PromoteWorkstream(o.Workstream)
+		}
+	}
+}
+
+// lockQueueOverrides takes the cross-process lock guarding path.
+func lockQueueOverrides(path string) (*state.FileLock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return nil, fmt.Errorf("create state directory: %w", err)
+	}
+	return state.AcquireFileLock(path+".lock", true, queueLockTimeout)
+}
+
+// readQueueOverrides reads the overrides in path, if it exists.
+func readQueueOverrides(path string) ([]QueueOverride, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is under the project's .tanuki directory
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read queue overrides: %w", err)
+	}
+
+	var overrides []QueueOverride
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("parse queue overrides: %w", err)
+	}
+	return overrides, nil
+}
@@ -0,0 +1,61 @@
+package project
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bkonkle/tanuki/internal/task"
+)
+
+func TestQueueOverrides_AddAndTake(t *testing.T) {
+	root := t.TempDir()
+
+	if got, err := TakeQueueOverrides(root); err != nil || got != nil {
+		t.Fatalf("TakeQueueOverrides() on empty = %v, %v; want nil, nil", got, err)
+	}
+
+	want := []QueueOverride{{Workstream: "api"}, {Workstream: "docs", Demote: true}}
+	for _, o := range want {
+		if err := AddQueueOverride(root, o); err != nil {
+			t.Fatalf("AddQueueOverride() error = %v", err)
+		}
+	}
+
+	got, err := TakeQueueOverrides(root)
+	if err != nil {
+		t.Fatalf("TakeQueueOverrides() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TakeQueueOverrides() = %v, want %v", got, want)
+	}
+
+	if _, err := os.Stat(QueueOverridesPath(root)); !os.IsNotExist(err) {
+		t.Errorf("overrides file still exists after take: %v", err)
+	}
+}
+
+func TestReadinessAwareScheduler_ApplyQueueOverrides(t *testing.T) {
+	tasks := []*task.Task{
+		{ID: "WS-A-001", Title: "Task A1", Workstream: "A", Status: task.StatusPending, Priority: task.PriorityLow},
+		{ID: "WS-B-001", Title: "Task B1", Workstream: "B", Status: task.StatusPending, Priority: task.PriorityHigh},
+		{ID: "WS-C-001", Title: "Task C1", Workstream: "C", Status: task.StatusPending, Priority: task.PriorityCritical},
+	}
+
+	scheduler, _ := setupTestScheduler(t, tasks)
+	if err := scheduler.Initialize(); err != nil {
+		t.Fatalf("Initialize() error: %v", err)
+	}
+
+	// The latest promotion ends up first
+	scheduler.ApplyQueueOverrides([]QueueOverride{
+		{Workstream: "A"},
+		{Workstream: "B"},
+		{Workstream: "C", Demote: true},
+	})
+
+	if got := strings.Join(readyOrder(scheduler), ","); got != "B,A,C" {
+		t.Errorf("order = %s, want B,A,C", got)
+	}
+}
@@ -27,6 +27,9 @@ type WorkstreamReadiness struct {
 	// TotalTaskCount is total pending tasks in this workstream
 	TotalTaskCount int
 
+	// ActiveTaskCount is the number of tasks currently assigned or in progress
+	ActiveTaskCount int
+
 	// FirstReadyTaskID is the ID of the first ready task (for display/logging)
 	FirstReadyTaskID string
 
@@ -89,12 +92,20 @@ type ReadinessAwareScheduler struct {
 	// resolver for dependency checking
 	resolver *task.Resolver
 
-	// workstreamConcurrency maps workstream names to their concurrency limits
+	// workstreamConcurrency maps workstream names to their agent concurrency
+	// limits: how many runners may work the workstream at once
 	workstreamConcurrency map[string]int
 
+	// workstreamTaskConcurrency maps workstream names to their task
+	// concurrency limits: how many of their tasks may be in flight at once
+	workstreamTaskConcurrency map[string]int
+
 	// activeWorkstreams tracks currently running workstreams
 	activeWorkstreams map[string]*WorkstreamReadiness
 
+	// activeRunners counts the runners activated for each workstream
+	activeRunners map[string]int
+
 	// readyQueue holds sorted list of ready workstreams
 	readyQueue []*WorkstreamReadiness
 
@@ -114,17 +125,19 @@ type ReadinessAwareScheduler struct {
 // NewReadinessAwareScheduler creates a new scheduler with deadlock prevention.
 func NewReadinessAwareScheduler(taskMgr *task.Manager) *ReadinessAwareScheduler {
 	return &ReadinessAwareScheduler{
-		taskMgr:               taskMgr,
-		workstreamConcurrency: make(map[string]int),
-		activeWorkstreams:     make(map[string]*WorkstreamReadiness),
-		readyQueue:            []*WorkstreamReadiness{},
-		blockedWorkstreams:    make(map[string]*WorkstreamReadiness),
-		allWorkstreams:        make(map[string]*WorkstreamReadiness),
-		taskToWorkstream:      make(map[string]string),
+		taskMgr:                   taskMgr,
+		workstreamConcurrency:     make(map[string]int),
+		workstreamTaskConcurrency: make(map[string]int),
+		activeWorkstreams:         make(map[string]*WorkstreamReadiness),
+		activeRunners:             make(map[string]int),
+		readyQueue:                []*WorkstreamReadiness{},
+		blockedWorkstreams:        make(map[string]*WorkstreamReadiness),
+		allWorkstreams:            make(map[string]*WorkstreamReadiness),
+		taskToWorkstream:          make(map[string]string),
 	}
 }
 
-// SetWorkstreamConcurrency sets the concurrency limit for a workstream.
+// SetWorkstreamConcurrency sets the agent concurrency limit for a workstream.
 func (s *ReadinessAwareScheduler) SetWorkstreamConcurrency(workstream string, limit int) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -134,7 +147,7 @@ func (s *ReadinessAwareScheduler) SetWorkstreamConcurrency(workstream string, li
 	s.workstreamConcurrency[workstream] = limit
 }
 
-// GetWorkstreamConcurrency returns the concurrency limit for a workstream.
+// GetWorkstreamConcurrency returns the agent concurrency limit for a workstream.
 func (s *ReadinessAwareScheduler) GetWorkstreamConcurrency(workstream string) int {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -144,6 +157,27 @@ func (s *ReadinessAwareScheduler) GetWorkstreamConcurrency(workstream string) in
 	return 1
 }
 
+// SetWorkstreamTaskConcurrency sets the task concurrency limit for a
+// workstream: how many of its tasks may be assigned or in progress at once,
+// across all runners. Zero removes the limit.
+func (s *ReadinessAwareScheduler) SetWorkstreamTaskConcurrency(workstream string, limit int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if limit <= 0 {
+		delete(s.workstreamTaskConcurrency, workstream)
+		return
+	}
+	s.workstreamTaskConcurrency[workstream] = limit
+}
+
+// GetWorkstreamTaskConcurrency returns the task concurrency limit for a
+// workstream, or 0 if its tasks are limited only by its runners.
+func (s *ReadinessAwareScheduler) GetWorkstreamTaskConcurrency(workstream string) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.workstreamTaskConcurrency[workstream]
+}
+
 // Initialize analyzes all tasks and builds the readiness graph.
 // Returns an error if a dependency cycle is detected.
 func (s *ReadinessAwareScheduler) Initialize() error {
@@ -164,7 +198,7 @@ func (s *ReadinessAwareScheduler) Initialize() error {
 
 	// Check for cycles - fail fast
 	if cycle := s.resolver.DetectCycle(); cycle != nil {
-		return fmt.Errorf("dependency cycle detected: %v", cycle)
+		return s.resolver.ExplainCycle(cycle)
 	}
 
 	// Group tasks by project/workstream
@@ -219,6 +253,9 @@ func (s *ReadinessAwareScheduler) computeReadiness(project, workstream string, t
 		}
 
 		readiness.TotalTaskCount++
+		if t.Status == task.StatusAssigned || t.Status == task.StatusInProgress {
+			readiness.ActiveTaskCount++
+		}
 
 		blocked := s.resolver.IsBlocked(t.ID)
 		if blocked {
@@ -271,21 +308,51 @@ func (s *ReadinessAwareScheduler) addToReadyQueue(ws *WorkstreamReadiness) {
 	})
 }
 
+// PromoteWorkstream moves a ready workstream to the front of the ready queue,
+// overriding its readiness score. The manual order lasts until the queue is
+// next re-sorted, when a newly ready workstream is added. Workstreams that
+// aren't in the ready queue are ignored.
+func (s *ReadinessAwareScheduler) PromoteWorkstream(workstream string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if ws := s.removeFromReadyQueue(workstream); ws != nil {
+		s.readyQueue = append([]*WorkstreamReadiness{ws}, s.readyQueue...)
+	}
+}
+
+// DemoteWorkstream moves a ready workstream to the back of the ready queue.
+// Like PromoteWorkstream, the order lasts until the queue is next re-sorted.
+func (s *ReadinessAwareScheduler) DemoteWorkstream(workstream string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if ws := s.removeFromReadyQueue(workstream); ws != nil {
+		s.readyQueue = append(s.readyQueue, ws)
+	}
+}
+
+// removeFromReadyQueue removes and returns the named workstream, or nil if
+// it isn't in the ready queue. The caller must hold the lock.
+func (s *ReadinessAwareScheduler) removeFromReadyQueue(workstream string) *WorkstreamReadiness {
+	for i, ws := range s.readyQueue {
+		if ws.Key() == workstream {
+			s.readyQueue = append(s.readyQueue[:i], s.readyQueue[i+1:]...)
+			return ws
+		}
+	}
+	return nil
+}
+
 // GetNextWorkstream returns the best workstream to spawn.
 // Returns nil if no ready workstreams are available or concurrency limit is reached.
 func (s *ReadinessAwareScheduler) GetNextWorkstream(workstream string) *WorkstreamReadiness {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	// Check concurrency limit for this specific workstream
-	limit := s.workstreamConcurrency[workstream]
-	if limit <= 0 {
-		limit = 1
-	}
-
-	activeCount := s.countActiveForWorkstream(workstream)
-	if activeCount >= limit {
-		return nil // At capacity
+	// Check concurrency limits for this specific workstream
+	if s.atCapacity(workstream) {
+		return nil
 	}
 
 	// Find the workstream in the ready queue
@@ -310,15 +377,9 @@ func (s *ReadinessAwareScheduler) GetNextReadyWorkstream() *WorkstreamReadiness
 		return nil
 	}
 
-	// Find first workstream that hasn't reached its concurrency limit
+	// Find first workstream that hasn't reached its concurrency limits
 	for i, ws := range s.readyQueue {
-		limit := s.workstreamConcurrency[ws.Workstream]
-		if limit <= 0 {
-			limit = 1
-		}
-
-		activeCount := s.countActiveForWorkstream(ws.Workstream)
-		if activeCount < limit {
+		if !s.atCapacity(ws.Workstream) {
 			// Remove from queue
 			s.readyQueue = append(s.readyQueue[:i], s.readyQueue[i+1:]...)
 			return ws
@@ -328,31 +389,66 @@ func (s *ReadinessAwareScheduler) GetNextReadyWorkstream() *WorkstreamReadiness
 	return nil // All ready workstreams are at capacity
 }
 
-// countActiveForWorkstream returns the number of active runners for a workstream.
+// atCapacity reports whether a workstream has reached its agent concurrency
+// (active runners) or task concurrency (tasks in flight) limit. The caller
+// must hold the lock.
+func (s *ReadinessAwareScheduler) atCapacity(workstream string) bool {
+	limit := s.workstreamConcurrency[workstream]
+	if limit <= 0 {
+		limit = 1
+	}
+	if s.countActiveForWorkstream(workstream) >= limit {
+		return true
+	}
+
+	taskLimit := s.workstreamTaskConcurrency[workstream]
+	return taskLimit > 0 && s.countActiveTasksForWorkstream(workstream) >= taskLimit
+}
+
+// countActiveForWorkstream returns the number of active runners for a
+// workstream, counted against its agent concurrency.
 func (s *ReadinessAwareScheduler) countActiveForWorkstream(workstream string) int {
-	if ws, ok := s.activeWorkstreams[workstream]; ok {
-		if ws != nil {
-			return 1
-		}
+	return s.activeRunners[workstream]
+}
+
+// countActiveTasksForWorkstream returns the number of a workstream's tasks
+// assigned or in progress, counted against its task concurrency.
+func (s *ReadinessAwareScheduler) countActiveTasksForWorkstream(workstream string) int {
+	if ws, ok := s.allWorkstreams[workstream]; ok {
+		return ws.ActiveTaskCount
 	}
 	return 0
 }
 
-// ActivateWorkstream marks a workstream as active.
+// ActivateWorkstream marks a workstream as active, counting one more runner
+// against its agent concurrency.
 func (s *ReadinessAwareScheduler) ActivateWorkstream(workstream string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	if ws, ok := s.allWorkstreams[workstream]; ok {
 		s.activeWorkstreams[workstream] = ws
+		s.activeRunners[workstream]++
 	}
 }
 
-// ReleaseWorkstream marks a workstream as no longer active.
+// ReleaseWorkstream releases one of a workstream's runners. The workstream
+// stays active until all of its runners are released.
 func (s *ReadinessAwareScheduler) ReleaseWorkstream(workstream string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	s.releaseRunner(workstream)
+}
+
+// releaseRunner releases one runner of a workstream. The caller must hold
+// the lock.
+func (s *ReadinessAwareScheduler) releaseRunner(workstream string) {
+	if s.activeRunners[workstream] > 1 {
+		s.activeRunners[workstream]--
+		return
+	}
+	delete(s.activeRunners, workstream)
 	delete(s.activeWorkstreams, workstream)
 }
 
@@ -420,12 +516,13 @@ func (s *ReadinessAwareScheduler) getTasksForWorkstream(tasks []*task.Task, proj
 	return result
 }
 
-// OnWorkstreamComplete is called when all tasks in a workstream finish.
+// OnWorkstreamComplete is called when one of a workstream's runners finishes
+// all the tasks it can.
 func (s *ReadinessAwareScheduler) OnWorkstreamComplete(workstream string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	delete(s.activeWorkstreams, workstream)
+	s.releaseRunner(workstream)
 }
 
 // SetOnWorkstreamReady sets a callback for when a workstream becomes ready.
@@ -544,6 +641,87 @@ func (s *ReadinessAwareScheduler) GetAllWorkstreams() []string {
 	return workstreams
 }
 
+// ReadinessStatus summarizes where a workstream stands for scheduling.
+type ReadinessStatus string
+
+const (
+	// ReadinessReady - Has tasks that can start now
+	ReadinessReady ReadinessStatus = "ready"
+	// ReadinessBlocked - Every remaining task waits on a dependency
+	ReadinessBlocked ReadinessStatus = "blocked"
+	// ReadinessActive - An agent is working on the workstream
+	ReadinessActive ReadinessStatus = "active"
+	// ReadinessComplete - No tasks remain
+	ReadinessComplete ReadinessStatus = "complete"
+)
+
+// WorkstreamBreakdown is a point-in-time view of one workstream's
+// scheduling state, for display.
+type WorkstreamBreakdown struct {
+	Project             string          `json:"project,omitempty"`
+	Workstream          string          `json:"workstream"`
+	Status              ReadinessStatus `json:"status"`
+	ReadyTasks          int             `json:"ready_tasks"`
+	BlockedTasks        int             `json:"blocked_tasks"`
+	ActiveTasks         int             `json:"active_tasks"`
+	RemainingTasks      int             `json:"remaining_tasks"`
+	Concurrency         int             `json:"concurrency"`
+	TaskConcurrency     int             `json:"task_concurrency,omitempty"`
+	NextTask            string          `json:"next_task,omitempty"`
+	BlockingWorkstreams []string        `json:"blocking_workstreams,omitempty"`
+}
+
+// Breakdown returns the scheduling state of every workstream, ordered by
+// project and workstream name. A workstream is active if it was activated
+// or has tasks assigned or in progress.
+func (s *ReadinessAwareScheduler) Breakdown() []*WorkstreamBreakdown {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]*WorkstreamBreakdown, 0, len(s.allWorkstreams))
+	for key, ws := range s.allWorkstreams {
+		limit := s.workstreamConcurrency[ws.Workstream]
+		if limit <= 0 {
+			limit = 1
+		}
+
+		b := &WorkstreamBreakdown{
+			Project:             ws.Project,
+			Workstream:          ws.Workstream,
+			ReadyTasks:          ws.ReadyTaskCount,
+			BlockedTasks:        ws.BlockedTaskCount,
+			ActiveTasks:         ws.ActiveTaskCount,
+			RemainingTasks:      ws.TotalTaskCount,
+			Concurrency:         limit,
+			TaskConcurrency:     s.workstreamTaskConcurrency[ws.Workstream],
+			NextTask:            ws.FirstReadyTaskID,
+			BlockingWorkstreams: ws.BlockingWorkstreams,
+		}
+
+		_, activated := s.activeWorkstreams[key]
+		switch {
+		case ws.TotalTaskCount == 0:
+			b.Status = ReadinessComplete
+		case activated || ws.ActiveTaskCount > 0:
+			b.Status = ReadinessActive
+		case ws.IsReady():
+			b.Status = ReadinessReady
+		default:
+			b.Status = ReadinessBlocked
+		}
+
+		result = append(result, b)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Project != result[j].Project {
+			return result[i].Project < result[j].Project
+		}
+		return result[i].Workstream < result[j].Workstream
+	})
+	return result
+}
+
 // appendUnique appends a string to a slice only if it's not already present.
 func appendUnique(slice []string, s string) []string {
 	if slices.Contains(slice, s) {
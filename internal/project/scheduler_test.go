@@ -3,6 +3,7 @@ package project
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/bkonkle/tanuki/internal/task"
@@ -121,6 +122,51 @@ func TestReadinessAwareScheduler_SameWorkstreamDependency(t *testing.T) {
 	}
 }
 
+func TestReadinessAwareScheduler_AgentAndTaskConcurrency(t *testing.T) {
+	tasks := []*task.Task{
+		{ID: "API-001", Title: "Task 1", Workstream: "api", Status: task.StatusInProgress, AssignedTo: "api-1"},
+		{ID: "API-002", Title: "Task 2", Workstream: "api", Status: task.StatusPending},
+		{ID: "API-003", Title: "Task 3", Workstream: "api", Status: task.StatusPending},
+	}
+
+	scheduler, _ := setupTestScheduler(t, tasks)
+	scheduler.SetWorkstreamConcurrency("api", 3)
+	scheduler.SetWorkstreamTaskConcurrency("api", 1)
+	if err := scheduler.Initialize(); err != nil {
+		t.Fatalf("Initialize() error: %v", err)
+	}
+
+	// API-001 is already in flight, so the task limit is reached even though
+	// more agents are allowed
+	if next := scheduler.GetNextWorkstream("api"); next != nil {
+		t.Errorf("GetNextWorkstream('api') = %v, want nil at the task limit", next.Workstream)
+	}
+
+	scheduler.SetWorkstreamTaskConcurrency("api", 0)
+	if next := scheduler.GetNextWorkstream("api"); next == nil {
+		t.Fatal("GetNextWorkstream('api') = nil, want api without a task limit")
+	}
+
+	// Runners count against agent concurrency one at a time
+	scheduler.ActivateWorkstream("api")
+	scheduler.ActivateWorkstream("api")
+	if got := scheduler.countActiveForWorkstream("api"); got != 2 {
+		t.Errorf("countActiveForWorkstream() = %d, want 2 runners", got)
+	}
+	if got := scheduler.countActiveTasksForWorkstream("api"); got != 1 {
+		t.Errorf("countActiveTasksForWorkstream() = %d, want 1 task", got)
+	}
+
+	scheduler.ReleaseWorkstream("api")
+	if got := len(scheduler.GetActiveWorkstreams()); got != 1 {
+		t.Errorf("active workstreams = %d, want api still active with one runner", got)
+	}
+	scheduler.OnWorkstreamComplete("api")
+	if got := len(scheduler.GetActiveWorkstreams()); got != 0 {
+		t.Errorf("active workstreams = %d, want none once every runner finished", got)
+	}
+}
+
 func TestReadinessAwareScheduler_CrossWorkstreamDependency(t *testing.T) {
 	// Task in "main" workstream depends on another task in "main" - dependency should work
 	tasks := []*task.Task{
@@ -358,6 +404,54 @@ func TestReadinessAwareScheduler_MultipleReadyWorkstreams(t *testing.T) {
 	}
 }
 
+// readyOrder returns the ready queue's workstream names in order.
+func readyOrder(s *ReadinessAwareScheduler) []string {
+	var names []string
+	for _, ws := range s.GetReadyWorkstreams() {
+		names = append(names, ws.Workstream)
+	}
+	return names
+}
+
+func TestReadinessAwareScheduler_PromoteDemote(t *testing.T) {
+	tasks := []*task.Task{
+		{ID: "WS-A-001", Title: "Task A1", Workstream: "A", Status: task.StatusPending, Priority: task.PriorityLow},
+		{ID: "WS-B-001", Title: "Task B1", Workstream: "B", Status: task.StatusPending, Priority: task.PriorityHigh},
+		{ID: "WS-C-001", Title: "Task C1", Workstream: "C", Status: task.StatusPending, Priority: task.PriorityCritical},
+		{ID: "WS-D-001", Title: "Task D1", Workstream: "D", Status: task.StatusPending, DependsOn: []string{"WS-C-001"}},
+	}
+
+	scheduler, _ := setupTestScheduler(t, tasks)
+	if err := scheduler.Initialize(); err != nil {
+		t.Fatalf("Initialize() error: %v", err)
+	}
+
+	if got := strings.Join(readyOrder(scheduler), ","); got != "C,B,A" {
+		t.Fatalf("initial order = %s, want C,B,A", got)
+	}
+
+	scheduler.PromoteWorkstream("A")
+	if got := strings.Join(readyOrder(scheduler), ","); got != "A,C,B" {
+		t.Errorf("after promote A = %s, want A,C,B", got)
+	}
+
+	scheduler.DemoteWorkstream("C")
+	if got := strings.Join(readyOrder(scheduler), ","); got != "A,B,C" {
+		t.Errorf("after demote C = %s, want A,B,C", got)
+	}
+
+	// Blocked and unknown workstreams aren't in the queue
+	scheduler.PromoteWorkstream("D")
+	scheduler.PromoteWorkstream("missing")
+	if got := strings.Join(readyOrder(scheduler), ","); got != "A,B,C" {
+		t.Errorf("after promoting non-ready workstreams = %s, want A,B,C", got)
+	}
+
+	if next := scheduler.GetNextReadyWorkstream(); next == nil || next.Workstream != "A" {
+		t.Errorf("GetNextReadyWorkstream() = %v, want A", next)
+	}
+}
+
 func TestReadinessAwareScheduler_WorkstreamWithMultipleTasks(t *testing.T) {
 	// Workstream with multiple tasks, some blocked
 	tasks := []*task.Task{
@@ -442,6 +536,54 @@ func TestReadinessAwareScheduler_DeadlockDetection(t *testing.T) {
 	}
 }
 
+func TestReadinessAwareScheduler_Breakdown(t *testing.T) {
+	tasks := []*task.Task{
+		{ID: "DB-001", Title: "Schema", Workstream: "db", Status: task.StatusComplete},
+		{ID: "API-001", Title: "Handlers", Workstream: "api", Status: task.StatusInProgress},
+		{ID: "UI-001", Title: "Pages", Workstream: "ui", Status: task.StatusPending, DependsOn: []string{"API-001"}},
+		{ID: "DOC-001", Title: "Docs", Workstream: "docs", Status: task.StatusPending},
+	}
+
+	scheduler, _ := setupTestScheduler(t, tasks)
+	if err := scheduler.Initialize(); err != nil {
+		t.Fatalf("Initialize() error: %v", err)
+	}
+	scheduler.SetWorkstreamConcurrency("docs", 3)
+
+	got := make(map[string]*WorkstreamBreakdown)
+	var order []string
+	for _, b := range scheduler.Breakdown() {
+		got[b.Workstream] = b
+		order = append(order, b.Workstream)
+	}
+
+	if strings.Join(order, ",") != "api,db,docs,ui" {
+		t.Errorf("Breakdown() order = %v, want sorted by name", order)
+	}
+
+	want := map[string]ReadinessStatus{
+		"api":  ReadinessActive,
+		"db":   ReadinessComplete,
+		"docs": ReadinessReady,
+		"ui":   ReadinessBlocked,
+	}
+	for ws, status := range want {
+		if got[ws].Status != status {
+			t.Errorf("%s status = %s, want %s", ws, got[ws].Status, status)
+		}
+	}
+
+	if got["docs"].Concurrency != 3 || got["ui"].Concurrency != 1 {
+		t.Errorf("concurrency = docs %d, ui %d; want 3, 1", got["docs"].Concurrency, got["ui"].Concurrency)
+	}
+	if got["docs"].NextTask != "DOC-001" {
+		t.Errorf("docs NextTask = %q, want DOC-001", got["docs"].NextTask)
+	}
+	if got["ui"].BlockedTasks != 1 || strings.Join(got["ui"].BlockingWorkstreams, ",") != "api" {
+		t.Errorf("ui = %+v, want 1 blocked task waiting on api", got["ui"])
+	}
+}
+
 func TestWorkstreamReadiness_IsReady(t *testing.T) {
 	tests := []struct {
 		name           string
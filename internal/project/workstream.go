@@ -319,6 +319,43 @@ func (s *WorkstreamScheduler) GetAllWorkstreamStates() []*WorkstreamState {
 	return result
 }
 
+// EffectiveParallelism returns how many of the given tasks can run at once
+// under this scheduler's per-workstream concurrency limits.
+func (s *WorkstreamScheduler) EffectiveParallelism(tasks []*task.Task) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return EffectiveParallelism(tasks, func(ws string) int {
+		return s.workstreamConcurrency[ws]
+	})
+}
+
+// EffectiveParallelism returns how many tasks can run at once. Each workstream
+// with remaining work contributes its concurrency limit, capped at the number
+// of tasks it has left. Always returns at least 1.
+func EffectiveParallelism(tasks []*task.Task, limit func(workstream string) int) int {
+	remaining := make(map[string]int)
+	for _, t := range tasks {
+		if isRemaining(t.Status) {
+			remaining[t.GetWorkstream()]++
+		}
+	}
+
+	parallelism := 0
+	for ws, count := range remaining {
+		l := limit(ws)
+		if l <= 0 {
+			l = 1
+		}
+		parallelism += min(l, count)
+	}
+
+	if parallelism == 0 {
+		return 1
+	}
+	return parallelism
+}
+
 // Stats returns workstream statistics.
 func (s *WorkstreamScheduler) Stats() *WorkstreamStats {
 	s.mu.RLock()
@@ -0,0 +1,58 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// DefaultLockTimeout is how long state operations wait for another tanuki
+// process to release the state file lock.
+const DefaultLockTimeout = 10 * time.Second
+
+// lockRetryInterval is how often a contended lock is retried.
+const lockRetryInterval = 10 * time.Millisecond
+
+// ErrLockTimeout is returned when the state file lock can't be acquired
+// within the manager's lock timeout.
+var ErrLockTimeout = errors.New("timed out waiting for state lock")
+
+// FileLock is an advisory lock on a file shared by all tanuki processes. The
+// task manager also uses it to serialize claims on task files.
+type FileLock struct {
+	f *os.File
+}
+
+// AcquireFileLock locks the file at path, creating it if needed. Exclusive
+// locks are held by one process at a time; shared locks only exclude
+// exclusive ones. It retries until timeout expires.
+func AcquireFileLock(path string, exclusive bool, timeout time.Duration) (*FileLock, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600) //nolint:gosec // G304: path is derived from the state file path
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		locked, err := tryLockFile(f, exclusive)
+		if err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+		}
+		if locked {
+			return &FileLock{f: f}, nil
+		}
+		if time.Now().After(deadline) {
+			_ = f.Close()
+			return nil, fmt.Errorf("%w %s after %s (is another tanuki command running?)", ErrLockTimeout, path, timeout)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// Release unlocks and closes the lock file.
+func (l *FileLock) Release() {
+	_ = unlockFile(l.f)
+	_ = l.f.Close()
+}
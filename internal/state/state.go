@@ -28,6 +28,9 @@ const (
 	StatusStopped Status = "stopped"
 	// StatusError indicates something went wrong
 	StatusError Status = "error"
+	// StatusHeld indicates the container was stopped after a task failed and
+	// is kept for inspection; the agent takes no work until released
+	StatusHeld Status = "failed-held"
 )
 
 // State represents the complete agent state for a project.
@@ -79,6 +82,19 @@ type Agent struct {
 
 	// DisallowedTools is the list of tools this agent is not allowed to use
 	DisallowedTools []string `json:"disallowed_tools,omitempty"`
+
+	// Labels are user-defined key=value tags for grouping and selecting agents
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Notes is free-form text describing what the agent is for
+	Notes string `json:"notes,omitempty"`
+
+	// HeldFor is the failed task an agent in StatusHeld is kept for
+	HeldFor string `json:"held_for,omitempty"`
+
+	// PinnedTask reserves the agent for a single task: the orchestrator
+	// gives it only that task, and no other agent gets the task
+	PinnedTask string `json:"pinned_task,omitempty"`
 }
 
 // TaskInfo contains information about a task execution.
@@ -86,6 +102,9 @@ type TaskInfo struct {
 	// Prompt is the task description
 	Prompt string `json:"prompt"`
 
+	// TaskID is the task file the run worked on, if it ran one
+	TaskID string `json:"task_id,omitempty"`
+
 	// StartedAt is when the task started
 	StartedAt time.Time `json:"started_at"`
 
@@ -95,6 +114,12 @@ type TaskInfo struct {
 	// SessionID is the Claude Code session identifier
 	SessionID string `json:"session_id"`
 
+	// Model is the model that ran the task, which may be a fallback
+	Model string `json:"model,omitempty"`
+
+	// LogFilePath is where the run's output was saved, if it was written to a file
+	LogFilePath string `json:"log_file,omitempty"`
+
 	// Workstream is the workstream this task belongs to
 	Workstream string `json:"workstream,omitempty"`
 
@@ -162,6 +187,10 @@ type Manager interface {
 	// SetAgent updates or creates an agent's state
 	SetAgent(agent *Agent) error
 
+	// UpdateAgent applies fn to an agent's current state and saves the
+	// result in one locked read-modify-write
+	UpdateAgent(name string, fn func(*Agent) error) error
+
 	// RemoveAgent deletes an agent from the state
 	RemoveAgent(name string) error
 
@@ -173,11 +202,17 @@ type Manager interface {
 }
 
 // FileStateManager implements Manager using a JSON file.
+//
+// Several tanuki processes (a dashboard and a CLI command, say) may share the
+// same state file. Every operation takes an advisory lock on a sibling
+// ".lock" file and reloads the state from disk first, so writes from
+// different processes are serialized and don't clobber each other.
 type FileStateManager struct {
-	path    string
-	mu      sync.RWMutex
-	state   *State
-	checker ContainerChecker
+	path        string
+	mu          sync.Mutex
+	state       *State
+	checker     ContainerChecker
+	lockTimeout time.Duration
 }
 
 // ContainerChecker checks Docker container status.
@@ -191,8 +226,9 @@ type ContainerChecker interface {
 // The path should point to the agents.json file location.
 func NewFileStateManager(path string, checker ContainerChecker) (*FileStateManager, error) {
 	m := &FileStateManager{
-		path:    path,
-		checker: checker,
+		path:        path,
+		checker:     checker,
+		lockTimeout: DefaultLockTimeout,
 	}
 
 	// Try to load existing state
@@ -216,10 +252,24 @@ func NewFileStateManager(path string, checker ContainerChecker) (*FileStateManag
 	return m, nil
 }
 
+// SetLockTimeout sets how long operations wait for the state file lock
+// before failing with ErrLockTimeout.
+func (m *FileStateManager) SetLockTimeout(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lockTimeout = d
+}
+
 // Load returns the current state.
 func (m *FileStateManager) Load() (*State, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	lock, err := m.lockAndReload(false)
+	if err != nil {
+		return nil, err
+	}
+	defer lock.Release()
 
 	// Return a copy to prevent external modifications
 	stateCopy := *m.state
@@ -233,31 +283,20 @@ func (m *FileStateManager) Load() (*State, error) {
 }
 
 // Save writes the state to disk atomically.
+// The given state replaces whatever is on disk, including changes made by
+// other processes since it was loaded.
 func (m *FileStateManager) Save(state *State) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	// Ensure directory exists
-	dir := filepath.Dir(m.path)
-	if err := os.MkdirAll(dir, 0750); err != nil {
-		return fmt.Errorf("failed to create state directory: %w", err)
-	}
-
-	// Write to temp file first
-	tmpPath := m.path + ".tmp"
-	data, err := json.MarshalIndent(state, "", "  ")
+	lock, err := m.lock(true)
 	if err != nil {
-		return fmt.Errorf("failed to marshal state: %w", err)
-	}
-
-	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
-		return fmt.Errorf("failed to write temp file: %w", err)
+		return err
 	}
+	defer lock.Release()
 
-	// Atomic rename
-	if err := os.Rename(tmpPath, m.path); err != nil {
-		_ = os.Remove(tmpPath) // Clean up temp file
-		return fmt.Errorf("failed to rename temp file: %w", err)
+	if err := writeStateFile(m.path, state); err != nil {
+		return err
 	}
 
 	m.state = state
@@ -266,8 +305,14 @@ func (m *FileStateManager) Save(state *State) error {
 
 // GetAgent retrieves a specific agent's state.
 func (m *FileStateManager) GetAgent(name string) (*Agent, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	lock, err := m.lockAndReload(false)
+	if err != nil {
+		return nil, err
+	}
+	defer lock.Release()
 
 	agent, exists := m.state.Agents[name]
 	if !exists {
@@ -284,6 +329,12 @@ func (m *FileStateManager) SetAgent(agent *Agent) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	lock, err := m.lockAndReload(true)
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
 	agent.UpdatedAt = time.Now()
 
 	// If this is a new agent, set CreatedAt
@@ -299,11 +350,48 @@ func (m *FileStateManager) SetAgent(agent *Agent) error {
 	return m.saveToDisk()
 }
 
+// UpdateAgent reloads an agent's state, applies fn to it, and saves it, all
+// under the state file lock. Only the fields fn changes are written, so
+// changes other processes made to the agent since the caller last read it
+// aren't lost, as they can be with GetAgent followed by SetAgent. If fn
+// returns an error nothing is saved and the error is returned.
+func (m *FileStateManager) UpdateAgent(name string, fn func(*Agent) error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	lock, err := m.lockAndReload(true)
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
+	agent, exists := m.state.Agents[name]
+	if !exists {
+		return fmt.Errorf("agent %q not found", name)
+	}
+
+	// Work on a copy so a failed fn leaves the in-memory state alone
+	agentCopy := *agent
+	if err := fn(&agentCopy); err != nil {
+		return err
+	}
+	agentCopy.UpdatedAt = time.Now()
+	m.state.Agents[name] = &agentCopy
+
+	return m.saveToDisk()
+}
+
 // RemoveAgent deletes an agent from the state.
 func (m *FileStateManager) RemoveAgent(name string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	lock, err := m.lockAndReload(true)
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
 	if _, exists := m.state.Agents[name]; !exists {
 		return fmt.Errorf("agent %q not found", name)
 	}
@@ -316,8 +404,14 @@ func (m *FileStateManager) RemoveAgent(name string) error {
 
 // ListAgents returns all agents.
 func (m *FileStateManager) ListAgents() ([]*Agent, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	lock, err := m.lockAndReload(false)
+	if err != nil {
+		return nil, err
+	}
+	defer lock.Release()
 
 	agents := make([]*Agent, 0, len(m.state.Agents))
 	for _, agent := range m.state.Agents {
@@ -339,6 +433,12 @@ func (m *FileStateManager) Reconcile() error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	lock, err := m.lockAndReload(true)
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
 	changed := false
 	for _, agent := range m.state.Agents {
 		if agent.ContainerID == "" {
@@ -399,17 +499,53 @@ func (m *FileStateManager) loadFromDisk() (*State, error) {
 	return &state, nil
 }
 
-// saveToDisk writes the current state to disk (must be called with lock held).
+// lock takes the cross-process state file lock (must be called with mu held).
+func (m *FileStateManager) lock(exclusive bool) (*FileLock, error) {
+	if err := os.MkdirAll(filepath.Dir(m.path), 0750); err != nil {
+		return nil, fmt.Errorf("failed to create state directory: %w", err)
+	}
+	return AcquireFileLock(m.path+".lock", exclusive, m.lockTimeout)
+}
+
+// lockAndReload takes the state file lock and refreshes the in-memory state
+// from disk, picking up changes made by other processes. A missing file
+// keeps the in-memory state. The caller must release the returned lock.
+func (m *FileStateManager) lockAndReload(exclusive bool) (*FileLock, error) {
+	lock, err := m.lock(exclusive)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := m.loadFromDisk()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return lock, nil
+		}
+		lock.Release()
+		return nil, err
+	}
+
+	m.state = state
+	return lock, nil
+}
+
+// saveToDisk writes the current state to disk (must be called with both
+// mu and the state file lock held).
 func (m *FileStateManager) saveToDisk() error {
+	return writeStateFile(m.path, m.state)
+}
+
+// writeStateFile writes state to path atomically via a temp file.
+func writeStateFile(path string, state *State) error {
 	// Ensure directory exists
-	dir := filepath.Dir(m.path)
+	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0750); err != nil {
 		return fmt.Errorf("failed to create state directory: %w", err)
 	}
 
 	// Write to temp file first
-	tmpPath := m.path + ".tmp"
-	data, err := json.MarshalIndent(m.state, "", "  ")
+	tmpPath := path + ".tmp"
+	data, err := json.MarshalIndent(state, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal state: %w", err)
 	}
@@ -419,7 +555,7 @@ func (m *FileStateManager) saveToDisk() error {
 	}
 
 	// Atomic rename
-	if err := os.Rename(tmpPath, m.path); err != nil {
+	if err := os.Rename(tmpPath, path); err != nil {
 		_ = os.Remove(tmpPath) // Clean up temp file
 		return fmt.Errorf("failed to rename temp file: %w", err)
 	}
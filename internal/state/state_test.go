@@ -2,8 +2,11 @@ package state
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 )
@@ -482,6 +485,7 @@ func TestStatePersistence(t *testing.T) {
 		Name:        "persistent-agent",
 		ContainerID: "xyz789",
 		Status:      StatusIdle,
+		Labels:      map[string]string{"feature": "auth"},
 	}
 	_ = mgr1.SetAgent(agent)
 
@@ -500,6 +504,192 @@ func TestStatePersistence(t *testing.T) {
 	if retrieved.ContainerID != "xyz789" {
 		t.Errorf("expected container ID 'xyz789', got '%s'", retrieved.ContainerID)
 	}
+	if retrieved.Labels["feature"] != "auth" {
+		t.Errorf("expected label feature=auth, got %v", retrieved.Labels)
+	}
+}
+
+func TestConcurrentManagers(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), ".tanuki", "state", "agents.json")
+
+	// Two managers on the same file, as if from two tanuki processes
+	mgr1, err := NewFileStateManager(statePath, nil)
+	if err != nil {
+		t.Fatalf("failed to create first state manager: %v", err)
+	}
+	mgr2, err := NewFileStateManager(statePath, nil)
+	if err != nil {
+		t.Fatalf("failed to create second state manager: %v", err)
+	}
+
+	const perManager = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*perManager)
+	for prefix, mgr := range map[string]*FileStateManager{"a": mgr1, "b": mgr2} {
+		wg.Add(1)
+		go func(prefix string, mgr *FileStateManager) {
+			defer wg.Done()
+			for i := 0; i < perManager; i++ {
+				if err := mgr.SetAgent(&Agent{Name: fmt.Sprintf("%s-%d", prefix, i), Status: StatusIdle}); err != nil {
+					errs <- err
+				}
+			}
+		}(prefix, mgr)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("SetAgent() error = %v", err)
+	}
+
+	fresh, err := NewFileStateManager(statePath, nil)
+	if err != nil {
+		t.Fatalf("failed to create fresh state manager: %v", err)
+	}
+	agents, err := fresh.ListAgents()
+	if err != nil {
+		t.Fatalf("ListAgents() error = %v", err)
+	}
+	if len(agents) != 2*perManager {
+		t.Errorf("expected %d agents to survive, got %d", 2*perManager, len(agents))
+	}
+}
+
+func TestReloadBeforeWrite(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), ".tanuki", "state", "agents.json")
+
+	mgr1, err := NewFileStateManager(statePath, nil)
+	if err != nil {
+		t.Fatalf("failed to create first state manager: %v", err)
+	}
+	mgr2, err := NewFileStateManager(statePath, nil)
+	if err != nil {
+		t.Fatalf("failed to create second state manager: %v", err)
+	}
+
+	if err := mgr1.SetAgent(&Agent{Name: "first", Status: StatusIdle}); err != nil {
+		t.Fatalf("SetAgent() error = %v", err)
+	}
+
+	// mgr2 loaded before "first" existed, but must not drop it on write
+	if err := mgr2.SetAgent(&Agent{Name: "second", Status: StatusIdle}); err != nil {
+		t.Fatalf("SetAgent() error = %v", err)
+	}
+
+	// Reads see the other manager's writes too
+	if _, err := mgr1.GetAgent("second"); err != nil {
+		t.Errorf("expected mgr1 to see agent written by mgr2: %v", err)
+	}
+	if _, err := mgr2.GetAgent("first"); err != nil {
+		t.Errorf("expected mgr2 to see agent written by mgr1: %v", err)
+	}
+
+	// Removing from one manager is visible to the other
+	if err := mgr2.RemoveAgent("first"); err != nil {
+		t.Fatalf("RemoveAgent() error = %v", err)
+	}
+	agents, err := mgr1.ListAgents()
+	if err != nil {
+		t.Fatalf("ListAgents() error = %v", err)
+	}
+	if len(agents) != 1 || agents[0].Name != "second" {
+		t.Errorf("expected only 'second' to remain, got %v", agents)
+	}
+}
+
+func TestUpdateAgent(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), ".tanuki", "state", "agents.json")
+
+	mgr1, err := NewFileStateManager(statePath, nil)
+	if err != nil {
+		t.Fatalf("failed to create first state manager: %v", err)
+	}
+	mgr2, err := NewFileStateManager(statePath, nil)
+	if err != nil {
+		t.Fatalf("failed to create second state manager: %v", err)
+	}
+
+	if err := mgr1.SetAgent(&Agent{Name: "worker", Status: StatusIdle}); err != nil {
+		t.Fatalf("SetAgent() error = %v", err)
+	}
+
+	// mgr1 reads the agent, then mgr2 sets notes before mgr1 writes
+	stale, err := mgr1.GetAgent("worker")
+	if err != nil {
+		t.Fatalf("GetAgent() error = %v", err)
+	}
+	if err := mgr2.UpdateAgent("worker", func(a *Agent) error {
+		a.Notes = "flaky tests"
+		return nil
+	}); err != nil {
+		t.Fatalf("UpdateAgent() error = %v", err)
+	}
+	if err := mgr1.UpdateAgent("worker", func(a *Agent) error {
+		a.Status = StatusWorking
+		return nil
+	}); err != nil {
+		t.Fatalf("UpdateAgent() error = %v", err)
+	}
+
+	got, err := mgr2.GetAgent("worker")
+	if err != nil {
+		t.Fatalf("GetAgent() error = %v", err)
+	}
+	if got.Notes != "flaky tests" || got.Status != StatusWorking {
+		t.Errorf("agent = %q/%q, want both updates kept", got.Notes, got.Status)
+	}
+	if !got.UpdatedAt.After(stale.UpdatedAt) {
+		t.Error("UpdatedAt should advance")
+	}
+
+	// An error from fn saves nothing
+	errBusy := errors.New("busy")
+	if err := mgr1.UpdateAgent("worker", func(a *Agent) error {
+		a.Status = StatusIdle
+		return errBusy
+	}); !errors.Is(err, errBusy) {
+		t.Errorf("UpdateAgent() error = %v, want %v", err, errBusy)
+	}
+	if got, _ := mgr2.GetAgent("worker"); got.Status != StatusWorking {
+		t.Errorf("Status = %q after a failed update, want working", got.Status)
+	}
+
+	if err := mgr1.UpdateAgent("missing", func(*Agent) error { return nil }); err == nil {
+		t.Error("UpdateAgent() on a missing agent should fail")
+	}
+}
+
+func TestLockTimeout(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), ".tanuki", "state", "agents.json")
+
+	mgr, err := NewFileStateManager(statePath, nil)
+	if err != nil {
+		t.Fatalf("failed to create state manager: %v", err)
+	}
+	mgr.SetLockTimeout(50 * time.Millisecond)
+
+	// Hold the lock as another process would
+	if err := os.MkdirAll(filepath.Dir(statePath), 0750); err != nil {
+		t.Fatalf("failed to create state dir: %v", err)
+	}
+	held, err := AcquireFileLock(statePath+".lock", true, time.Second)
+	if err != nil {
+		t.Fatalf("failed to acquire lock: %v", err)
+	}
+
+	err = mgr.SetAgent(&Agent{Name: "blocked", Status: StatusIdle})
+	if !errors.Is(err, ErrLockTimeout) {
+		t.Errorf("SetAgent() error = %v, want ErrLockTimeout", err)
+	}
+	if _, err := mgr.ListAgents(); !errors.Is(err, ErrLockTimeout) {
+		t.Errorf("ListAgents() error = %v, want ErrLockTimeout", err)
+	}
+
+	held.Release()
+
+	if err := mgr.SetAgent(&Agent{Name: "unblocked", Status: StatusIdle}); err != nil {
+		t.Errorf("SetAgent() after release error = %v", err)
+	}
 }
 
 func TestTaskInfo(t *testing.T) {
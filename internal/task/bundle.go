@@ -0,0 +1,181 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Bundle is a whole task tree in one document, for sharing a project's
+// design or feeding it to another tool. ImportBundle writes one back out as
+// task files.
+type Bundle struct {
+	ExportedAt time.Time        `yaml:"exported_at" json:"exported_at"`
+	Projects   []BundledProject `yaml:"projects,omitempty" json:"projects,omitempty"`
+	Tasks      []BundledTask    `yaml:"tasks" json:"tasks"`
+}
+
+// BundledProject is a project folder in a Bundle.
+type BundledProject struct {
+	// Name is the project folder's name under the tasks directory
+	Name string `yaml:"name" json:"name"`
+
+	// README is the folder's README.md, which marks it as a project
+	README string `yaml:"readme" json:"readme"`
+}
+
+// BundledTask is one task file in a Bundle.
+type BundledTask struct {
+	// Path is the task file relative to the tasks directory, with forward
+	// slashes: "<file>.md", or "<project>/<file>.md" for a project's task
+	Path string `yaml:"path" json:"path"`
+
+	// FrontMatter holds the fields written to the file's YAML front matter
+	FrontMatter map[string]any `yaml:"front_matter" json:"front_matter"`
+
+	// Body is the markdown after the front matter
+	Body string `yaml:"body" json:"body"`
+}
+
+// Export bundles every task in the tasks directory, with the README of each
+// project folder, ordered by path.
+func (m *Manager) Export() (*Bundle, error) {
+	b := &Bundle{ExportedAt: time.Now()}
+
+	for _, project := range m.GetProjects() {
+		readme, err := os.ReadFile(filepath.Join(m.tasksDir, project, "README.md")) // #nosec G304 - project is a scanned folder name
+		if err != nil {
+			return nil, fmt.Errorf("read project %s: %w", project, err)
+		}
+		b.Projects = append(b.Projects, BundledProject{Name: project, README: string(readme)})
+	}
+
+	for _, t := range m.List() {
+		rel, err := filepath.Rel(m.tasksDir, t.FilePath)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return nil, fmt.Errorf("task %s is not in the tasks directory: %s", t.ID, t.FilePath)
+		}
+
+		data, err := yaml.Marshal(newFrontMatter(t))
+		if err != nil {
+			return nil, fmt.Errorf("marshal front matter for %s: %w", t.ID, err)
+		}
+		var fm map[string]any
+		if err := yaml.Unmarshal(data, &fm); err != nil {
+			return nil, fmt.Errorf("marshal front matter for %s: %w", t.ID, err)
+		}
+
+		b.Tasks = append(b.Tasks, BundledTask{Path: filepath.ToSlash(rel), FrontMatter: fm, Body: t.Content})
+	}
+	sort.Slice(b.Tasks, func(i, j int) bool { return b.Tasks[i].Path < b.Tasks[j].Path })
+
+	return b, nil
+}
+
+// ImportBundle writes a bundle's projects and tasks out as files under
+// tasksDir, returning the paths written relative to it. Every task is
+// validated before anything is written. Existing files with different
+// contents are only replaced when overwrite is set; otherwise the import
+// fails listing them.
+func ImportBundle(tasksDir string, b *Bundle, overwrite bool) ([]string, error) {
+	if b == nil {
+		return nil, errors.New("bundle is nil")
+	}
+
+	files := make(map[string]string) // Path relative to tasksDir -> contents
+	projects := make(map[string]bool)
+	for _, p := range b.Projects {
+		if p.Name == "" || p.Name != path.Base(p.Name) || p.Name == "." || p.Name == ".." {
+			return nil, fmt.Errorf("invalid project name %q", p.Name)
+		}
+		projects[p.Name] = true
+		files[p.Name+"/README.md"] = p.README
+	}
+
+	ids := make(map[string]string)
+	for _, bt := range b.Tasks {
+		project, err := bundledTaskProject(bt.Path)
+		if err != nil {
+			return nil, err
+		}
+		if project != "" && !projects[project] {
+			if _, err := os.Stat(filepath.Join(tasksDir, project, "README.md")); err != nil {
+				return nil, fmt.Errorf("%s: project %s isn't in the bundle or the tasks directory", bt.Path, project)
+			}
+		}
+		if _, dup := files[bt.Path]; dup {
+			return nil, fmt.Errorf("%s: listed more than once", bt.Path)
+		}
+
+		data, err := yaml.Marshal(bt.FrontMatter)
+		if err != nil {
+			return nil, fmt.Errorf("%s: marshal front matter: %w", bt.Path, err)
+		}
+		t, err := Parse(fmt.Sprintf("---\n%s---\n\n%s\n", data, bt.Body), filepath.Join(tasksDir, filepath.FromSlash(bt.Path)))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", bt.Path, err)
+		}
+		if other, dup := ids[t.ID]; dup {
+			return nil, fmt.Errorf("%s: task ID %s is also used by %s", bt.Path, t.ID, other)
+		}
+		ids[t.ID] = bt.Path
+
+		content, err := Serialize(t)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", bt.Path, err)
+		}
+		files[bt.Path] = content
+	}
+
+	paths := make([]string, 0, len(files))
+	var conflicts []string
+	for rel, content := range files {
+		existing, err := os.ReadFile(filepath.Join(tasksDir, filepath.FromSlash(rel))) // #nosec G304 - rel was validated above
+		switch {
+		case err == nil && string(existing) == content:
+			continue // Already up to date
+		case err == nil && !overwrite:
+			conflicts = append(conflicts, rel)
+		}
+		paths = append(paths, rel)
+	}
+	sort.Strings(paths)
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return nil, fmt.Errorf("%d files already exist (use --force to overwrite): %s", len(conflicts), strings.Join(conflicts, ", "))
+	}
+
+	for _, rel := range paths {
+		dest := filepath.Join(tasksDir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(dest), 0750); err != nil {
+			return nil, fmt.Errorf("create %s: %w", filepath.Dir(rel), err)
+		}
+		if err := os.WriteFile(dest, []byte(files[rel]), 0600); err != nil {
+			return nil, fmt.Errorf("write %s: %w", rel, err)
+		}
+	}
+	return paths, nil
+}
+
+// bundledTaskProject checks that a bundled task's path is a task file the
+// tasks directory would load, and returns its project folder, if any.
+func bundledTaskProject(p string) (string, error) {
+	dir, file := path.Split(p)
+	dir = strings.TrimSuffix(dir, "/")
+	switch {
+	case p == "" || path.IsAbs(p) || path.Clean(p) != p:
+		return "", fmt.Errorf("invalid task path %q", p)
+	case path.Ext(file) != ".md" || file == "README.md":
+		return "", fmt.Errorf("invalid task path %q: must be a .md file other than README.md", p)
+	case strings.Contains(dir, "/") || dir == "..":
+		return "", fmt.Errorf("invalid task path %q: must be in the tasks directory or a project folder", p)
+	}
+	return dir, nil
+}
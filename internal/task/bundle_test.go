@@ -0,0 +1,156 @@
+package task
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// writeBundleTree writes a tasks directory with a loose task and a project.
+func writeBundleTree(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	tasksDir := filepath.Join(dir, "tasks")
+	files := map[string]string{
+		"setup.md":          "---\nid: SETUP-001\ntitle: Set up\npriority: high\nstatus: complete\ncompleted_at: 2025-01-14T09:30:00Z\n---\n\nInstall things.\n",
+		"auth/README.md":    "# Auth\n\nLogin and sessions.\n",
+		"auth/001-login.md": "---\nid: auth-001\ntitle: Login\nworkstream: api\npriority: medium\nstatus: pending\ndepends_on:\n  - SETUP-001\ncompletion:\n  verify: go test ./auth\n  max_iterations: 5\n---\n\n# Login\n\nAdd a login endpoint.\n",
+	}
+	for rel, content := range files {
+		path := filepath.Join(tasksDir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestBundle_RoundTrip(t *testing.T) {
+	src := NewManager(&Config{ProjectRoot: writeBundleTree(t)})
+	if _, err := src.Scan(); err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+
+	bundle, err := src.Export()
+	if err != nil {
+		t.Fatalf("Export() error: %v", err)
+	}
+	if len(bundle.Projects) != 1 || bundle.Projects[0].Name != "auth" || !strings.Contains(bundle.Projects[0].README, "# Auth") {
+		t.Errorf("Projects = %+v, want the auth project with its README", bundle.Projects)
+	}
+	if len(bundle.Tasks) != 2 || bundle.Tasks[0].Path != "auth/001-login.md" || bundle.Tasks[1].Path != "setup.md" {
+		t.Fatalf("Tasks = %+v, want auth/001-login.md and setup.md", bundle.Tasks)
+	}
+
+	// Through JSON and back, the way the CLI reads a bundle
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		t.Fatalf("json.Marshal() error: %v", err)
+	}
+	var decoded Bundle
+	if err := yaml.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("yaml.Unmarshal() error: %v", err)
+	}
+
+	dstRoot := t.TempDir()
+	dst := NewManager(&Config{ProjectRoot: dstRoot})
+	written, err := ImportBundle(dst.TasksDir(), &decoded, false)
+	if err != nil {
+		t.Fatalf("ImportBundle() error: %v", err)
+	}
+	if want := []string{"auth/001-login.md", "auth/README.md", "setup.md"}; !reflect.DeepEqual(written, want) {
+		t.Errorf("written = %v, want %v", written, want)
+	}
+
+	if _, err := dst.Scan(); err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+	for _, want := range src.List() {
+		got, err := dst.Get(want.ID)
+		if err != nil {
+			t.Fatalf("Get(%s) error: %v", want.ID, err)
+		}
+		if got.Project != want.Project || got.Workstream != want.Workstream || got.Status != want.Status ||
+			got.Content != want.Content || !reflect.DeepEqual(got.DependsOn, want.DependsOn) ||
+			!reflect.DeepEqual(got.Completion, want.Completion) {
+			t.Errorf("imported %s = %+v, want %+v", want.ID, got, want)
+		}
+		if (got.CompletedAt == nil) != (want.CompletedAt == nil) || (got.CompletedAt != nil && !got.CompletedAt.Equal(*want.CompletedAt)) {
+			t.Errorf("imported %s CompletedAt = %v, want %v", want.ID, got.CompletedAt, want.CompletedAt)
+		}
+	}
+
+	// Importing again changes nothing
+	if written, err := ImportBundle(dst.TasksDir(), &decoded, false); err != nil || len(written) != 0 {
+		t.Errorf("second ImportBundle() = %v, %v, want nothing written", written, err)
+	}
+}
+
+func TestImportBundle_RefusesOverwrite(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "setup.md")
+	original := "---\nid: SETUP-001\ntitle: Original\n---\n\nKeep me.\n"
+	if err := os.WriteFile(path, []byte(original), 0600); err != nil {
+		t.Fatal(err)
+	}
+	bundle := &Bundle{Tasks: []BundledTask{{
+		Path:        "setup.md",
+		FrontMatter: map[string]any{"id": "SETUP-001", "title": "Replacement"},
+		Body:        "New body.",
+	}}}
+
+	if _, err := ImportBundle(dir, bundle, false); err == nil || !strings.Contains(err.Error(), "setup.md") {
+		t.Errorf("ImportBundle() error = %v, want the existing file refused", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != original {
+		t.Errorf("file = %q, want it left alone", data)
+	}
+
+	if _, err := ImportBundle(dir, bundle, true); err != nil {
+		t.Fatalf("ImportBundle(force) error: %v", err)
+	}
+	replaced, err := ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile() error: %v", err)
+	}
+	if replaced.Title != "Replacement" || replaced.Content != "New body." {
+		t.Errorf("task = %q/%q, want the bundled task", replaced.Title, replaced.Content)
+	}
+}
+
+func TestImportBundle_Invalid(t *testing.T) {
+	valid := map[string]any{"id": "TASK-001", "title": "Task"}
+	tests := []struct {
+		name   string
+		bundle *Bundle
+	}{
+		{"escapes the tasks directory", &Bundle{Tasks: []BundledTask{{Path: "../task.md", FrontMatter: valid}}}},
+		{"absolute path", &Bundle{Tasks: []BundledTask{{Path: "/tmp/task.md", FrontMatter: valid}}}},
+		{"nested too deep", &Bundle{Tasks: []BundledTask{{Path: "a/b/task.md", FrontMatter: valid}}}},
+		{"not markdown", &Bundle{Tasks: []BundledTask{{Path: "task.txt", FrontMatter: valid}}}},
+		{"unknown project", &Bundle{Tasks: []BundledTask{{Path: "auth/task.md", FrontMatter: valid}}}},
+		{"invalid task", &Bundle{Tasks: []BundledTask{{Path: "task.md", FrontMatter: map[string]any{"title": "No ID"}}}}},
+		{"duplicate ID", &Bundle{Tasks: []BundledTask{{Path: "a.md", FrontMatter: valid}, {Path: "b.md", FrontMatter: valid}}}},
+		{"bad project name", &Bundle{Projects: []BundledProject{{Name: "../auth"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if _, err := ImportBundle(dir, tt.bundle, false); err == nil {
+				t.Error("ImportBundle() error = nil, want error")
+			}
+			if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+				t.Errorf("ImportBundle() wrote %d entries, want nothing written", len(entries))
+			}
+		})
+	}
+}
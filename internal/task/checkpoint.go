@@ -0,0 +1,28 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bkonkle/tanuki/internal/executor"
+)
+
+// CheckpointPrompt returns prompt instructions for reporting checkpoints.
+// When the task already has checkpoints from an interrupted run, they are
+// listed so the agent can resume rather than start over.
+func CheckpointPrompt(t *Task) string {
+	var prompt strings.Builder
+
+	prompt.WriteString("\n\n## Checkpoints\n\n")
+	prompt.WriteString(fmt.Sprintf("After completing each significant step, output a line like `%s <short description>`.\n", executor.CheckpointMarker))
+
+	if len(t.Checkpoints) > 0 {
+		prompt.WriteString("\nPreviously completed:\n")
+		for _, cp := range t.Checkpoints {
+			prompt.WriteString(fmt.Sprintf("- %s\n", cp))
+		}
+		prompt.WriteString("\nThese steps were finished in an earlier run. Verify them briefly and do not redo them.\n")
+	}
+
+	return prompt.String()
+}
@@ -0,0 +1,21 @@
+package task
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckpointPrompt(t *testing.T) {
+	prompt := CheckpointPrompt(&Task{})
+	if !strings.Contains(prompt, "TANUKI_CHECKPOINT:") {
+		t.Error("prompt missing checkpoint marker instructions")
+	}
+	if strings.Contains(prompt, "Previously completed") {
+		t.Error("prompt should not list completed steps without checkpoints")
+	}
+
+	prompt = CheckpointPrompt(&Task{Checkpoints: []string{"schema migrated", "handlers added"}})
+	if !strings.Contains(prompt, "Previously completed:\n- schema migrated\n- handlers added\n") {
+		t.Errorf("prompt missing completed steps:\n%s", prompt)
+	}
+}
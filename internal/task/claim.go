@@ -0,0 +1,68 @@
+package task
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/bkonkle/tanuki/internal/state"
+)
+
+// claimLockTimeout bounds how long Claim waits for a claim in another process.
+const claimLockTimeout = 5 * time.Second
+
+// claimLockPath returns the lock file that serializes claims across processes.
+func (m *Manager) claimLockPath() string {
+	return filepath.Join(m.config.ProjectRoot, ".tanuki", "state", "tasks.lock")
+}
+
+// Claim assigns a task to an agent only if it is still pending or blocked
+// and unassigned. Unlike Assign, the check is made against the task file
+// on disk under a cross-process lock, so two orchestrators sharing a tasks
+// directory can't both take the same task. It returns false without an
+// error when the task has already been claimed.
+func (m *Manager) Claim(id, agentName string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	task, ok := m.tasks[id]
+	if !ok {
+		return false, fmt.Errorf("task %q not found", id)
+	}
+
+	lockPath := m.claimLockPath()
+	if err := os.MkdirAll(filepath.Dir(lockPath), 0750); err != nil {
+		return false, fmt.Errorf("create lock directory: %w", err)
+	}
+	lock, err := state.AcquireFileLock(lockPath, true, claimLockTimeout)
+	if err != nil {
+		return false, err
+	}
+	defer lock.Release()
+
+	// Another process may have claimed it since the last scan
+	current, err := m.taskStore().Get(id)
+	if err != nil {
+		return false, fmt.Errorf("read task file: %w", err)
+	}
+	current.Project = task.Project
+	*task = *current
+
+	if task.AssignedTo != "" || task.BlockedReason != "" || (task.Status != StatusPending && task.Status != StatusBlocked) {
+		m.notifyUpdated(id)
+		return false, nil
+	}
+
+	task.AssignedTo = agentName
+	task.LastAgent = agentName
+	task.Status = StatusAssigned
+	m.recordAssignment(task, agentName, OutcomeAssigned)
+
+	if err := m.taskStore().Write(task); err != nil {
+		return false, fmt.Errorf("write task file: %w", err)
+	}
+
+	m.notifyUpdated(id)
+	return true, nil
+}
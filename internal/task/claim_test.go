@@ -0,0 +1,116 @@
+package task
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestManager_Claim(t *testing.T) {
+	dir := t.TempDir()
+	writeTestTask(t, filepath.Join(dir, "tasks"), "TASK-001", "workstream: backend\n")
+	mgr := NewManager(&Config{ProjectRoot: dir})
+	_, _ = mgr.Scan()
+
+	claimed, err := mgr.Claim("TASK-001", "agent-1")
+	if err != nil {
+		t.Fatalf("Claim() error: %v", err)
+	}
+	if !claimed {
+		t.Fatal("Claim() = false, want true for a pending task")
+	}
+
+	task, _ := mgr.Get("TASK-001")
+	if task.AssignedTo != "agent-1" || task.Status != StatusAssigned {
+		t.Errorf("task = %s/%q, want assigned to agent-1", task.Status, task.AssignedTo)
+	}
+
+	claimed, err = mgr.Claim("TASK-001", "agent-2")
+	if err != nil {
+		t.Fatalf("Claim() error: %v", err)
+	}
+	if claimed {
+		t.Error("Claim() = true, want false for an already claimed task")
+	}
+	if task.AssignedTo != "agent-1" {
+		t.Errorf("AssignedTo = %q, want agent-1 kept", task.AssignedTo)
+	}
+}
+
+func TestManager_Claim_OtherProcess(t *testing.T) {
+	dir := t.TempDir()
+	writeTestTask(t, filepath.Join(dir, "tasks"), "TASK-001", "workstream: backend\n")
+
+	// Two managers stand in for two orchestrators sharing the tasks directory
+	first := NewManager(&Config{ProjectRoot: dir})
+	second := NewManager(&Config{ProjectRoot: dir})
+	_, _ = first.Scan()
+	_, _ = second.Scan()
+
+	if claimed, err := first.Claim("TASK-001", "agent-1"); err != nil || !claimed {
+		t.Fatalf("first Claim() = %v, %v; want true, nil", claimed, err)
+	}
+
+	// second's cache still says pending, but the file on disk doesn't
+	claimed, err := second.Claim("TASK-001", "agent-2")
+	if err != nil {
+		t.Fatalf("second Claim() error: %v", err)
+	}
+	if claimed {
+		t.Error("second Claim() = true, want false for a task claimed by another manager")
+	}
+
+	task, _ := second.Get("TASK-001")
+	if task.AssignedTo != "agent-1" {
+		t.Errorf("AssignedTo = %q, want the cache refreshed to agent-1", task.AssignedTo)
+	}
+	if onDisk, _ := ParseFile(task.FilePath); onDisk.AssignedTo != "agent-1" {
+		t.Errorf("file AssignedTo = %q, want agent-1", onDisk.AssignedTo)
+	}
+}
+
+func TestManager_Claim_Concurrent(t *testing.T) {
+	dir := t.TempDir()
+	writeTestTask(t, filepath.Join(dir, "tasks"), "TASK-001", "workstream: backend\n")
+
+	const n = 8
+	managers := make([]*Manager, n)
+	for i := range managers {
+		managers[i] = NewManager(&Config{ProjectRoot: dir})
+		_, _ = managers[i].Scan()
+	}
+
+	var wg sync.WaitGroup
+	results := make(chan bool, n)
+	for i, mgr := range managers {
+		wg.Add(1)
+		go func(i int, mgr *Manager) {
+			defer wg.Done()
+			claimed, err := mgr.Claim("TASK-001", fmt.Sprintf("agent-%d", i))
+			if err != nil {
+				t.Errorf("Claim() error: %v", err)
+			}
+			results <- claimed
+		}(i, mgr)
+	}
+	wg.Wait()
+	close(results)
+
+	wins := 0
+	for claimed := range results {
+		if claimed {
+			wins++
+		}
+	}
+	if wins != 1 {
+		t.Errorf("%d managers claimed the task, want exactly 1", wins)
+	}
+}
+
+func TestManager_Claim_NotFound(t *testing.T) {
+	mgr := NewManager(&Config{ProjectRoot: t.TempDir()})
+	if _, err := mgr.Claim("missing", "agent-1"); err == nil {
+		t.Error("Claim() expected error for unknown task")
+	}
+}
@@ -58,7 +58,7 @@ func (m *mockTaskMgr) Unassign(id string) error {
 func TestCompletionHandler_HandleAgentOutput(t *testing.T) {
 	taskMgr := newMockTaskMgr()
 	events := make(chan Event, 10)
-	handler := NewCompletionHandler(taskMgr, "", events)
+	handler := NewCompletionHandler(taskMgr, t.TempDir(), events)
 
 	tests := []struct {
 		name       string
@@ -125,7 +125,7 @@ func TestCompletionHandler_HandleAgentOutput(t *testing.T) {
 func TestCompletionHandler_EmitsEvents(t *testing.T) {
 	taskMgr := newMockTaskMgr()
 	events := make(chan Event, 10)
-	handler := NewCompletionHandler(taskMgr, "", events)
+	handler := NewCompletionHandler(taskMgr, t.TempDir(), events)
 
 	task := &Task{
 		ID:    "T1",
@@ -11,6 +11,8 @@ const (
 	EventTaskFailed    = "task.failed"
 	EventTaskBlocked   = "task.blocked"
 	EventTaskUnblocked = "task.unblocked"
+	EventTaskRequeued  = "task.requeued"
+	EventTaskCancelled = "task.cancelled"
 )
 
 // Event represents a task lifecycle event.
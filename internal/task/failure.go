@@ -0,0 +1,46 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/bkonkle/tanuki/internal/docker"
+	"github.com/bkonkle/tanuki/internal/executor"
+)
+
+// ErrVerifyFailed indicates a task's verify command did not pass.
+var ErrVerifyFailed = errors.New("verify command failed")
+
+// ErrExpectedFilesMissing indicates a run finished without producing files
+// matching the task's expects globs.
+var ErrExpectedFilesMissing = errors.New("expected files missing")
+
+// CategorizeFailure infers a FailureCategory from an error by matching it
+// against known sentinel errors. Returns "" for a nil error.
+func CategorizeFailure(err error) FailureCategory {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, executor.ErrClaudeNotFound):
+		return FailureClaudeNotFound
+	case errors.Is(err, executor.ErrMaxIterations):
+		return FailureMaxIterations
+	case errors.Is(err, executor.ErrMaxTurns):
+		return FailureMaxTurns
+	case errors.Is(err, executor.ErrNoCompletionSignal):
+		return FailureNoSignal
+	case errors.Is(err, ErrVerifyFailed):
+		return FailureVerify
+	case errors.Is(err, ErrExpectedFilesMissing):
+		return FailureMissingFiles
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, os.ErrDeadlineExceeded):
+		return FailureTimeout
+	case errors.Is(err, docker.ErrDockerNotRunning),
+		errors.Is(err, docker.ErrContainerNotFound),
+		errors.Is(err, docker.ErrImageNotFound):
+		return FailureContainer
+	default:
+		return FailureOther
+	}
+}
@@ -0,0 +1,39 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/bkonkle/tanuki/internal/docker"
+	"github.com/bkonkle/tanuki/internal/executor"
+)
+
+func TestCategorizeFailure(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want FailureCategory
+	}{
+		{"nil", nil, ""},
+		{"claude not found", fmt.Errorf("%w: output", executor.ErrClaudeNotFound), FailureClaudeNotFound},
+		{"max iterations", fmt.Errorf("agent run: %w", executor.ErrMaxIterations), FailureMaxIterations},
+		{"max turns", fmt.Errorf("agent run: %w (50 turns)", executor.ErrMaxTurns), FailureMaxTurns},
+		{"no completion signal", fmt.Errorf("agent run: %w", executor.ErrNoCompletionSignal), FailureNoSignal},
+		{"verify failed", fmt.Errorf("task failed: exit 1: %w", ErrVerifyFailed), FailureVerify},
+		{"expected files missing", fmt.Errorf("%w: dist/*.js", ErrExpectedFilesMissing), FailureMissingFiles},
+		{"timeout", fmt.Errorf("run: %w", context.DeadlineExceeded), FailureTimeout},
+		{"docker not running", docker.ErrDockerNotRunning, FailureContainer},
+		{"container not found", fmt.Errorf("start: %w", docker.ErrContainerNotFound), FailureContainer},
+		{"other", errors.New("something broke"), FailureOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CategorizeFailure(tt.err); got != tt.want {
+				t.Errorf("CategorizeFailure() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
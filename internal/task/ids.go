@@ -0,0 +1,135 @@
+package task
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DefaultIDFormat is the task ID template used when none is configured.
+// It produces IDs like "auth-001".
+const DefaultIDFormat = "{project}-{seq:03d}"
+
+// idFormatSeq matches the sequence placeholder: {seq} or {seq:0Nd}.
+var idFormatSeq = regexp.MustCompile(`\{seq(?::0?(\d*)d)?\}`)
+
+// idFormat is a parsed ID template. The sequence number sits between a
+// literal prefix and suffix; {project} is expanded when the format is
+// applied to a project.
+type idFormat struct {
+	raw    string
+	prefix string
+	suffix string
+	width  int
+}
+
+// parseIDFormat parses a template such as "{project}-{seq:03d}".
+// It must contain exactly one sequence placeholder.
+func parseIDFormat(format string) (*idFormat, error) {
+	if format == "" {
+		format = DefaultIDFormat
+	}
+
+	locs := idFormatSeq.FindAllStringSubmatchIndex(format, -1)
+	if len(locs) != 1 {
+		return nil, fmt.Errorf("task ID format %q must contain exactly one {seq} or {seq:0Nd} placeholder", format)
+	}
+	loc := locs[0]
+
+	f := &idFormat{
+		raw:    format,
+		prefix: format[:loc[0]],
+		suffix: format[loc[1]:],
+	}
+	if loc[2] >= 0 && loc[3] > loc[2] {
+		width, err := strconv.Atoi(format[loc[2]:loc[3]])
+		if err != nil {
+			return nil, fmt.Errorf("task ID format %q: invalid width: %w", format, err)
+		}
+		f.width = width
+	}
+	return f, nil
+}
+
+// ValidateIDFormat reports whether format is a usable task ID template.
+func ValidateIDFormat(format string) error {
+	_, err := parseIDFormat(format)
+	return err
+}
+
+// expand returns the prefix and suffix with {project} substituted.
+func (f *idFormat) expand(project string) (prefix, suffix string) {
+	return strings.ReplaceAll(f.prefix, "{project}", project),
+		strings.ReplaceAll(f.suffix, "{project}", project)
+}
+
+// format renders the ID for a project and sequence number.
+func (f *idFormat) format(project string, seq int) string {
+	prefix, suffix := f.expand(project)
+	return fmt.Sprintf("%s%0*d%s", prefix, f.width, seq, suffix)
+}
+
+// seq extracts the sequence number from id, if it was produced by this
+// format for the project.
+func (f *idFormat) seq(project, id string) (int, bool) {
+	prefix, suffix := f.expand(project)
+	if len(id) <= len(prefix)+len(suffix) || !strings.HasPrefix(id, prefix) || !strings.HasSuffix(id, suffix) {
+		return 0, false
+	}
+
+	digits := id[len(prefix) : len(id)-len(suffix)]
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return 0, false
+		}
+	}
+	n, err := strconv.Atoi(digits)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// NextID returns the next sequential task ID for the project, one past the
+// highest sequence number among its tasks that match the configured ID
+// format. Gaps are not reused, so after 001 and 003 the next ID is 004.
+// An empty project starts at 1. An invalid format falls back to
+// DefaultIDFormat; use ValidateIDFormat to check it up front.
+func (m *Manager) NextID(project string) string {
+	f := m.idFormat()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	highest := 0
+	for _, t := range m.tasks {
+		if t.Project != project {
+			continue
+		}
+		if n, ok := f.seq(project, t.ID); ok && n > highest {
+			highest = n
+		}
+	}
+	return f.format(project, highest+1)
+}
+
+// ValidateID reports whether id follows the configured ID format for the
+// project.
+func (m *Manager) ValidateID(project, id string) error {
+	f := m.idFormat()
+	if _, ok := f.seq(project, id); !ok {
+		return fmt.Errorf("task ID %q does not match format %q (e.g. %s)", id, f.raw, f.format(project, 1))
+	}
+	return nil
+}
+
+// idFormat returns the parsed configured ID format, or the default if it is
+// unset or invalid.
+func (m *Manager) idFormat() *idFormat {
+	if f, err := parseIDFormat(m.config.IDFormat); err == nil {
+		return f
+	}
+	f, _ := parseIDFormat(DefaultIDFormat)
+	return f
+}
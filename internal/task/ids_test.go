@@ -0,0 +1,123 @@
+package task
+
+import (
+	"testing"
+)
+
+func TestParseIDFormat(t *testing.T) {
+	tests := []struct {
+		format  string
+		project string
+		seq     int
+		want    string
+		wantErr bool
+	}{
+		{format: "", project: "auth", seq: 1, want: "auth-001"},
+		{format: "{project}-{seq:03d}", project: "auth", seq: 12, want: "auth-012"},
+		{format: "{project}-{seq:3d}", project: "auth", seq: 7, want: "auth-007"},
+		{format: "{project}-{seq}", project: "auth", seq: 7, want: "auth-7"},
+		{format: "TASK-{seq:04d}", project: "auth", seq: 1234, want: "TASK-1234"},
+		{format: "{project}/{seq:02d}-x", project: "ui", seq: 3, want: "ui/03-x"},
+		{format: "{project}", wantErr: true},
+		{format: "{seq}-{seq}", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			f, err := parseIDFormat(tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseIDFormat(%q) error = %v, wantErr %v", tt.format, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := f.format(tt.project, tt.seq); got != tt.want {
+				t.Errorf("format(%q, %d) = %q, want %q", tt.project, tt.seq, got, tt.want)
+			}
+			if n, ok := f.seq(tt.project, tt.want); !ok || n != tt.seq {
+				t.Errorf("seq(%q, %q) = %d, %v, want %d, true", tt.project, tt.want, n, ok, tt.seq)
+			}
+		})
+	}
+}
+
+func TestManager_NextID(t *testing.T) {
+	newMgr := func(format string, tasks ...*Task) *Manager {
+		m := NewManager(&Config{ProjectRoot: t.TempDir(), IDFormat: format})
+		for _, task := range tasks {
+			m.tasks[task.ID] = task
+		}
+		return m
+	}
+
+	tests := []struct {
+		name    string
+		mgr     *Manager
+		project string
+		want    string
+	}{
+		{
+			name:    "empty project",
+			mgr:     newMgr(""),
+			project: "auth",
+			want:    "auth-001",
+		},
+		{
+			name: "gaps are not reused",
+			mgr: newMgr("",
+				&Task{ID: "auth-001", Project: "auth"},
+				&Task{ID: "auth-003", Project: "auth"},
+			),
+			project: "auth",
+			want:    "auth-004",
+		},
+		{
+			name: "other projects and formats are ignored",
+			mgr: newMgr("",
+				&Task{ID: "auth-002", Project: "auth"},
+				&Task{ID: "billing-009", Project: "billing"},
+				&Task{ID: "auth-setup", Project: "auth"},
+				&Task{ID: "auth-050", Project: ""},
+			),
+			project: "auth",
+			want:    "auth-003",
+		},
+		{
+			name: "custom format",
+			mgr: newMgr("T{seq}",
+				&Task{ID: "T9", Project: "auth"},
+			),
+			project: "auth",
+			want:    "T10",
+		},
+		{
+			name: "sequence grows past padding",
+			mgr: newMgr("",
+				&Task{ID: "auth-999", Project: "auth"},
+			),
+			project: "auth",
+			want:    "auth-1000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.mgr.NextID(tt.project); got != tt.want {
+				t.Errorf("NextID(%q) = %q, want %q", tt.project, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_ValidateID(t *testing.T) {
+	m := NewManager(&Config{ProjectRoot: t.TempDir()})
+
+	if err := m.ValidateID("auth", "auth-010"); err != nil {
+		t.Errorf("ValidateID(auth-010) = %v, want nil", err)
+	}
+	for _, id := range []string{"auth-x", "billing-001", "auth-"} {
+		if err := m.ValidateID("auth", id); err == nil {
+			t.Errorf("ValidateID(%q) = nil, want error", id)
+		}
+	}
+}
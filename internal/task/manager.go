@@ -1,21 +1,33 @@
 package task
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"sync"
+	"time"
 )
 
 // Manager handles scanning, loading, querying, and updating tasks.
 // It maintains an in-memory cache for fast reads but always writes through
-// to disk for persistence.
+// to its TaskStore for persistence.
 type Manager struct {
-	config   *Config
-	tasksDir string
-	tasks    map[string]*Task
-	mu       sync.RWMutex
+	config    *Config
+	tasksDir  string
+	extraDirs []string
+	store     TaskStore
+	tasks     map[string]*Task
+	mu        sync.RWMutex
+	subs      subscribers
+
+	// dependents is a lazily built reverse dependency index (task ID ->
+	// IDs of tasks that depend on it). It is reset whenever dependencies
+	// may have changed; depsMu guards building it under a read lock.
+	dependents map[string][]string
+	depsMu     sync.Mutex
 }
 
 // Config holds configuration for the TaskManager.
@@ -24,125 +36,153 @@ type Config struct {
 	// TasksDir is the directory for task files, relative to ProjectRoot.
 	// Defaults to "tasks" if empty.
 	TasksDir string
+	// ExtraTaskDirs are more directories to read tasks from, relative to
+	// ProjectRoot unless absolute. Tasks are written back to the directory
+	// they were read from; new tasks go in TasksDir.
+	ExtraTaskDirs []string
+	// IDFormat is the template used by NextID, e.g. "{project}-{seq:03d}".
+	// Defaults to DefaultIDFormat if empty.
+	IDFormat string
+	// MaxAssignmentHistory caps each task's assignment history; the oldest
+	// entries are dropped first. Defaults to DefaultMaxAssignmentHistory if 0.
+	MaxAssignmentHistory int
+	// Store loads and saves tasks. Defaults to a FileStore for TasksDir and
+	// ExtraTaskDirs.
+	Store TaskStore
 }
 
+// DefaultMaxAssignmentHistory is how many assignment history entries a task
+// keeps by default.
+const DefaultMaxAssignmentHistory = 20
+
 // NewManager creates a new TaskManager.
 func NewManager(cfg *Config) *Manager {
 	tasksDir := cfg.TasksDir
 	if tasksDir == "" {
 		tasksDir = "tasks"
 	}
+	extraDirs := make([]string, 0, len(cfg.ExtraTaskDirs))
+	for _, dir := range cfg.ExtraTaskDirs {
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(cfg.ProjectRoot, dir)
+		}
+		extraDirs = append(extraDirs, dir)
+	}
 	return &Manager{
-		config:   cfg,
-		tasksDir: filepath.Join(cfg.ProjectRoot, tasksDir),
-		tasks:    make(map[string]*Task),
+		config:    cfg,
+		tasksDir:  filepath.Join(cfg.ProjectRoot, tasksDir),
+		extraDirs: extraDirs,
+		store:     cfg.Store,
+		tasks:     make(map[string]*Task),
 	}
 }
 
-// Scan loads all task files from the configured tasks directory.
-// It supports project folder structure (tasks/project-name/*.md where
-// project-name contains a README.md to identify it as a project).
-// Invalid task files are logged as warnings but don't stop the scan.
-func (m *Manager) Scan() ([]*Task, error) {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
-	// Clear existing cache
-	m.tasks = make(map[string]*Task)
-
-	// Check if directory exists
-	if _, err := os.Stat(m.tasksDir); os.IsNotExist(err) {
-		return nil, nil // No tasks directory - not an error
+// taskStore returns the manager's store, falling back to a FileStore for the
+// tasks directory if the manager wasn't built by NewManager. The caller must
+// hold m.mu for writing.
+func (m *Manager) taskStore() TaskStore {
+	if m.store == nil {
+		m.store = NewFileStore(m.tasksDir, m.extraDirs...)
 	}
+	return m.store
+}
 
-	entries, err := os.ReadDir(m.tasksDir)
-	if err != nil {
-		return nil, fmt.Errorf("read tasks directory: %w", err)
-	}
-
-	tasks := make([]*Task, 0, len(entries))
-	var parseErrors []error
-
-	for _, entry := range entries {
-		if entry.IsDir() {
-			// Check if it's a project folder (contains README.md)
-			readmePath := filepath.Join(m.tasksDir, entry.Name(), "README.md")
-			if _, err := os.Stat(readmePath); err == nil {
-				// It's a project folder - scan it for tasks
-				projectName := entry.Name()
-				projectTasks, errs := m.scanProjectDir(filepath.Join(m.tasksDir, entry.Name()), projectName)
-				tasks = append(tasks, projectTasks...)
-				parseErrors = append(parseErrors, errs...)
-			}
-			continue
-		}
+// ParseError is a task file that failed to parse during a scan.
+type ParseError struct {
+	// Path is the task file, relative to the tasks directory
+	Path string
 
-		if filepath.Ext(entry.Name()) != ".md" {
-			continue
-		}
+	// FilePath is the task file's full path, matched against Task.FilePath
+	// to find the task's last good version
+	FilePath string
 
-		// Skip README.md in root tasks directory
-		if entry.Name() == "README.md" {
-			continue
-		}
+	// Kept is the ID of the task whose last successfully parsed version stays
+	// in the cache while its file is broken, or "" if the file never parsed
+	Kept string
 
-		path := filepath.Join(m.tasksDir, entry.Name())
-		task, err := ParseFile(path)
-		if err != nil {
-			// Log warning but continue scanning
-			parseErrors = append(parseErrors, fmt.Errorf("parse %s: %w", entry.Name(), err))
-			continue
-		}
+	Err error
+}
 
-		// Root tasks have no project
-		task.Project = ""
-		m.tasks[task.ID] = task
-		tasks = append(tasks, task)
+func (e *ParseError) Error() string {
+	if e.Kept != "" {
+		return fmt.Sprintf("parse %s: %v (keeping the last good version of %s)", e.Path, e.Err, e.Kept)
 	}
+	return fmt.Sprintf("parse %s: %v", e.Path, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Scan loads all tasks from the store, by default the task files in the
+// configured tasks directory. The file store supports project folder
+// structure (tasks/project-name/*.md where project-name contains a README.md
+// to identify it as a project). Invalid task files are logged as warnings
+// but don't stop the scan; see ScanStrict for what happens to tasks whose
+// files break.
+func (m *Manager) Scan() ([]*Task, error) {
+	tasks, parseErrors, err := m.ScanStrict()
 
 	// Log any errors encountered
 	for _, err := range parseErrors {
 		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
 	}
 
-	return tasks, nil
+	return tasks, err
 }
 
-// scanProjectDir scans a project folder for task files.
-// The projectName is set on each task's Project field.
-func (m *Manager) scanProjectDir(dir, projectName string) ([]*Task, []error) {
-	entries, err := os.ReadDir(dir)
-	if err != nil {
-		return nil, []error{fmt.Errorf("read project directory %s: %w", projectName, err)}
-	}
+// ScanStrict loads all tasks like Scan, but returns the files that failed
+// to parse instead of logging them. A task whose file parsed in an earlier
+// scan keeps its last good version in the cache while the file is broken,
+// so a half-saved edit doesn't make it look deleted mid-run. Updates tanuki
+// writes to such a task, like status changes, rewrite its file from the
+// kept version.
+func (m *Manager) ScanStrict() ([]*Task, []*ParseError, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
-	tasks := make([]*Task, 0, len(entries))
-	var parseErrors []error
+	// Clear existing cache, notifying subscribers of the difference once done
+	previous := m.tasks
+	m.tasks = make(map[string]*Task)
+	m.dependents = nil
+	defer func() { m.notify(diffTasks(previous, m.tasks)) }()
 
-	for _, entry := range entries {
-		if entry.IsDir() || filepath.Ext(entry.Name()) != ".md" {
-			continue
-		}
+	tasks, parseErrors, err := m.taskStore().List()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, task := range tasks {
+		m.tasks[task.ID] = task
+	}
 
-		// Skip README.md - it's project metadata, not a task
-		if entry.Name() == "README.md" {
-			continue
+	lastGood := make(map[string]*Task, len(previous))
+	for _, t := range previous {
+		lastGood[t.FilePath] = t
+	}
+	for _, pe := range parseErrors {
+		if prev, ok := lastGood[pe.FilePath]; ok && pe.FilePath != "" && m.tasks[prev.ID] == nil {
+			m.tasks[prev.ID] = prev
+			tasks = append(tasks, prev)
+			pe.Kept = prev.ID
 		}
+	}
 
-		path := filepath.Join(dir, entry.Name())
-		task, err := ParseFile(path)
-		if err != nil {
-			parseErrors = append(parseErrors, fmt.Errorf("parse %s/%s: %w", projectName, entry.Name(), err))
-			continue
-		}
+	return tasks, parseErrors, nil
+}
 
-		// Set project name on task
-		task.Project = projectName
-		m.tasks[task.ID] = task
-		tasks = append(tasks, task)
-	}
+// Watch rescans whenever the store reports that tasks changed outside the
+// manager, such as a task file edited by hand, until ctx is done.
+// Subscribers are notified of the differences each rescan finds.
+func (m *Manager) Watch(ctx context.Context) error {
+	m.mu.Lock()
+	store := m.taskStore()
+	m.mu.Unlock()
 
-	return tasks, parseErrors
+	return store.Watch(ctx, func() {
+		if _, err := m.Scan(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: rescan tasks: %v\n", err)
+		}
+	})
 }
 
 // Get returns a task by ID.
@@ -237,6 +277,30 @@ func (m *Manager) GetByWorkstream(workstream string) []*Task {
 	return tasks
 }
 
+// GetByGroup returns the tasks in a group, sorted by ID. Ungrouped tasks
+// belong to no group, so an empty group returns nothing.
+func (m *Manager) GetByGroup(group string) []*Task {
+	if group == "" {
+		return nil
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var tasks []*Task
+	for _, t := range m.tasks {
+		if t.Group == group {
+			tasks = append(tasks, t)
+		}
+	}
+
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].ID < tasks[j].ID
+	})
+
+	return tasks
+}
+
 // GetWorkstreams returns all unique workstreams.
 // Returns workstreams sorted by the priority of their highest-priority pending task.
 func (m *Manager) GetWorkstreams() []string {
@@ -329,17 +393,89 @@ func (m *Manager) UpdateStatus(id string, status Status) error {
 	}
 
 	task.Status = status
+	recordStatusTime(task, status)
 
 	// Write back to file
-	if err := WriteFile(task); err != nil {
+	if err := m.taskStore().Write(task); err != nil {
 		return fmt.Errorf("write task file: %w", err)
 	}
 
+	m.notifyUpdated(id)
 	return nil
 }
 
+// BulkUpdateStatus sets the status of many tasks under a single lock, writing
+// each task file once. Moving tasks back to pending also releases their
+// agent, as Retry does. IDs that don't match a task are reported in the
+// returned map instead of stopping the update; the error is set only if a
+// task file can't be written, leaving the tasks before it updated.
+func (m *Manager) BulkUpdateStatus(ids []string, status Status) (map[string]error, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, notFound, err := m.bulkUpdateStatus(ids, status)
+	return notFound, err
+}
+
+// bulkUpdateStatus implements BulkUpdateStatus, also returning the IDs of the
+// tasks it wrote. The caller must hold m.mu.
+func (m *Manager) bulkUpdateStatus(ids []string, status Status) ([]string, map[string]error, error) {
+	var notFound map[string]error
+	var changed []string
+	defer func() { m.notifyUpdated(changed...) }()
+
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		task, ok := m.tasks[id]
+		if !ok {
+			if notFound == nil {
+				notFound = make(map[string]error)
+			}
+			notFound[id] = fmt.Errorf("task %q not found", id)
+			continue
+		}
+
+		if status == StatusPending {
+			m.recordRelease(task)
+			task.AssignedTo = ""
+		}
+		task.Status = status
+		recordStatusTime(task, status)
+
+		if err := m.taskStore().Write(task); err != nil {
+			return changed, notFound, fmt.Errorf("write task %s: %w", id, err)
+		}
+		changed = append(changed, id)
+	}
+
+	return changed, notFound, nil
+}
+
+// recordStatusTime stamps when a task first starts and when it finishes, so
+// run durations survive in the task file.
+func recordStatusTime(task *Task, status Status) {
+	now := time.Now()
+	switch status {
+	case StatusInProgress:
+		if task.StartedAt == nil {
+			task.StartedAt = &now
+		}
+		task.CompletedAt = nil
+		// Progress from an earlier run is stale
+		task.ProgressPercent = nil
+	case StatusComplete, StatusFailed, StatusReview:
+		task.CompletedAt = &now
+	}
+}
+
 // UpdateFailure marks a task as failed and persists error information to the task file.
-// This method sets the task status to failed and stores the error message and log file path.
+// This method sets the task status to failed and stores the error message, its
+// inferred FailureCategory, and the log file path.
 func (m *Manager) UpdateFailure(id string, err error, logPath string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -350,16 +486,102 @@ func (m *Manager) UpdateFailure(id string, err error, logPath string) error {
 	}
 
 	task.Status = StatusFailed
+	recordStatusTime(task, StatusFailed)
 	if err != nil {
 		task.FailureMessage = err.Error()
+		task.FailureCategory = CategorizeFailure(err)
 	}
 	task.LogFilePath = logPath
 
 	// Write back to file
-	if writeErr := WriteFile(task); writeErr != nil {
+	if writeErr := m.taskStore().Write(task); writeErr != nil {
 		return fmt.Errorf("write task file: %w", writeErr)
 	}
 
+	m.notifyUpdated(id)
+	return nil
+}
+
+// Retry requeues a failed task for another attempt, incrementing its retry
+// count. The failure message is kept so the next prompt can reference it.
+func (m *Manager) Retry(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	task, ok := m.tasks[id]
+	if !ok {
+		return fmt.Errorf("task %q not found", id)
+	}
+
+	task.RetryCount++
+	if task.AssignedTo != "" {
+		task.FailedAgent = task.AssignedTo
+	} else {
+		task.FailedAgent = task.LastAgent
+	}
+	m.recordRelease(task)
+	task.AssignedTo = ""
+	task.Status = StatusPending
+	task.CompletedAt = nil
+
+	// Write back to file
+	if err := m.taskStore().Write(task); err != nil {
+		return fmt.Errorf("write task file: %w", err)
+	}
+
+	m.notifyUpdated(id)
+	return nil
+}
+
+// AddCheckpoint records a completed step for a task and persists it.
+// Checkpoints already recorded are ignored.
+func (m *Manager) AddCheckpoint(id, checkpoint string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	task, ok := m.tasks[id]
+	if !ok {
+		return fmt.Errorf("task %q not found", id)
+	}
+
+	for _, existing := range task.Checkpoints {
+		if existing == checkpoint {
+			return nil
+		}
+	}
+	task.Checkpoints = append(task.Checkpoints, checkpoint)
+
+	// Write back to file
+	if err := m.taskStore().Write(task); err != nil {
+		return fmt.Errorf("write task file: %w", err)
+	}
+
+	m.notifyUpdated(id)
+	return nil
+}
+
+// SetProgress records the progress percentage the agent last reported for a
+// task and persists it. Unchanged progress isn't rewritten.
+func (m *Manager) SetProgress(id string, percent int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	task, ok := m.tasks[id]
+	if !ok {
+		return fmt.Errorf("task %q not found", id)
+	}
+
+	if task.ProgressPercent != nil && *task.ProgressPercent == percent {
+		return nil
+	}
+	task.ProgressPercent = &percent
+
+	// Write back to file
+	if err := m.taskStore().Write(task); err != nil {
+		return fmt.Errorf("write task file: %w", err)
+	}
+
+	m.notifyUpdated(id)
 	return nil
 }
 
@@ -378,14 +600,16 @@ func (m *Manager) Update(task *Task) error {
 		return fmt.Errorf("task %q not found", task.ID)
 	}
 
-	// Update cache
+	// Update cache; dependencies may have changed
 	m.tasks[task.ID] = task
+	m.dependents = nil
 
 	// Write back to file
-	if err := WriteFile(task); err != nil {
+	if err := m.taskStore().Write(task); err != nil {
 		return fmt.Errorf("write task file: %w", err)
 	}
 
+	m.notifyUpdated(task.ID)
 	return nil
 }
 
@@ -405,12 +629,15 @@ func (m *Manager) Assign(id string, agentName string) error {
 	}
 
 	task.AssignedTo = agentName
+	task.LastAgent = agentName
 	task.Status = StatusAssigned
+	m.recordAssignment(task, agentName, OutcomeAssigned)
 
-	if err := WriteFile(task); err != nil {
+	if err := m.taskStore().Write(task); err != nil {
 		return fmt.Errorf("write task file: %w", err)
 	}
 
+	m.notifyUpdated(id)
 	return nil
 }
 
@@ -425,6 +652,7 @@ func (m *Manager) Unassign(id string) error {
 		return fmt.Errorf("task %q not found", id)
 	}
 
+	m.recordRelease(task)
 	task.AssignedTo = ""
 
 	// Don't change status if complete/failed
@@ -432,14 +660,49 @@ func (m *Manager) Unassign(id string) error {
 		task.Status = StatusPending
 	}
 
-	if err := WriteFile(task); err != nil {
+	if err := m.taskStore().Write(task); err != nil {
 		return fmt.Errorf("write task file: %w", err)
 	}
 
+	m.notifyUpdated(id)
 	return nil
 }
 
-// IsBlocked checks if a task's dependencies are all complete.
+// recordRelease adds the current assignee letting go of task to its history.
+// The outcome is the status the task ended in, or OutcomeReleased if it
+// hasn't finished.
+func (m *Manager) recordRelease(task *Task) {
+	if task.AssignedTo == "" {
+		return
+	}
+	outcome := OutcomeReleased
+	switch task.Status {
+	case StatusComplete, StatusFailed, StatusReview:
+		outcome = string(task.Status)
+	}
+	m.recordAssignment(task, task.AssignedTo, outcome)
+}
+
+// recordAssignment appends to task's assignment history, dropping the oldest
+// entries beyond the configured limit.
+func (m *Manager) recordAssignment(task *Task, agentName, outcome string) {
+	limit := m.config.MaxAssignmentHistory
+	if limit <= 0 {
+		limit = DefaultMaxAssignmentHistory
+	}
+
+	task.AssignmentHistory = append(task.AssignmentHistory, AssignmentRecord{
+		Agent:   agentName,
+		At:      time.Now(),
+		Outcome: outcome,
+	})
+	if over := len(task.AssignmentHistory) - limit; over > 0 {
+		task.AssignmentHistory = append([]AssignmentRecord(nil), task.AssignmentHistory[over:]...)
+	}
+}
+
+// IsBlocked reports whether a task is blocked, either blocked by hand with
+// Block or waiting on dependencies that aren't complete.
 func (m *Manager) IsBlocked(id string) (bool, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -455,6 +718,9 @@ func (m *Manager) isBlockedInternal(id string) (bool, error) {
 		return false, fmt.Errorf("task %q not found", id)
 	}
 
+	if task.BlockedReason != "" {
+		return true, nil
+	}
 	if len(task.DependsOn) == 0 {
 		return false, nil
 	}
@@ -494,6 +760,101 @@ func (m *Manager) GetBlockingTasks(id string) ([]string, error) {
 	return blocking, nil
 }
 
+// Block marks a task blocked for a reason outside its dependencies, such as
+// waiting on a decision, and persists the reason. The task isn't scheduled
+// until Unblock. Only tasks that haven't started can be blocked.
+func (m *Manager) Block(id, reason string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	task, ok := m.tasks[id]
+	if !ok {
+		return fmt.Errorf("task %q not found", id)
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return fmt.Errorf("a reason is required to block task %q", id)
+	}
+	if task.Status != StatusPending && task.Status != StatusBlocked {
+		return fmt.Errorf("task %q can't be blocked (status: %s)", id, task.Status)
+	}
+
+	task.BlockedReason = reason
+	task.Status = StatusBlocked
+
+	if err := m.taskStore().Write(task); err != nil {
+		return fmt.Errorf("write task file: %w", err)
+	}
+
+	m.notifyUpdated(id)
+	return nil
+}
+
+// Unblock clears a task's BlockedReason. It goes back to pending, or stays
+// blocked if its dependencies still aren't complete.
+func (m *Manager) Unblock(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	task, ok := m.tasks[id]
+	if !ok {
+		return fmt.Errorf("task %q not found", id)
+	}
+	if task.BlockedReason == "" {
+		return fmt.Errorf("task %q is not blocked by hand", id)
+	}
+
+	task.BlockedReason = ""
+	if task.Status == StatusBlocked {
+		if blocked, _ := m.isBlockedInternal(id); !blocked {
+			task.Status = StatusPending
+		}
+	}
+
+	if err := m.taskStore().Write(task); err != nil {
+		return fmt.Errorf("write task file: %w", err)
+	}
+
+	m.notifyUpdated(id)
+	return nil
+}
+
+// GetDependents returns the IDs of tasks that list id in their DependsOn,
+// sorted. The reverse index is built once and reused until tasks are
+// rescanned or updated, so calling this for many tasks is cheap.
+func (m *Manager) GetDependents(id string) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	deps := m.dependentsIndex()[id]
+	if len(deps) == 0 {
+		return nil
+	}
+	return append([]string(nil), deps...)
+}
+
+// dependentsIndex returns the reverse dependency index, building it if
+// needed. The caller must hold at least a read lock.
+func (m *Manager) dependentsIndex() map[string][]string {
+	m.depsMu.Lock()
+	defer m.depsMu.Unlock()
+
+	if m.dependents == nil {
+		index := make(map[string][]string)
+		for id, task := range m.tasks {
+			for _, depID := range task.DependsOn {
+				index[depID] = append(index[depID], id)
+			}
+		}
+		for _, ids := range index {
+			sort.Strings(ids)
+		}
+		m.dependents = index
+	}
+	return m.dependents
+}
+
 // UpdateBlockedStatus checks all tasks and updates blocked status.
 // Tasks with unmet dependencies are marked as blocked.
 // Tasks that were blocked but now have all dependencies complete are marked as pending.
@@ -501,6 +862,7 @@ func (m *Manager) UpdateBlockedStatus() error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	var changed []string
 	for _, task := range m.tasks {
 		if task.Status == StatusPending || task.Status == StatusBlocked {
 			blocked, err := m.isBlockedInternal(task.ID)
@@ -509,14 +871,17 @@ func (m *Manager) UpdateBlockedStatus() error {
 			}
 			if blocked && task.Status != StatusBlocked {
 				task.Status = StatusBlocked
-				_ = WriteFile(task)
+				_ = m.taskStore().Write(task)
+				changed = append(changed, task.ID)
 			} else if !blocked && task.Status == StatusBlocked {
 				task.Status = StatusPending
-				_ = WriteFile(task)
+				_ = m.taskStore().Write(task)
+				changed = append(changed, task.ID)
 			}
 		}
 	}
 
+	m.notifyUpdated(changed...)
 	return nil
 }
 
@@ -526,6 +891,12 @@ type Stats struct {
 	ByStatus     map[Status]int
 	ByPriority   map[Priority]int
 	ByWorkstream map[string]int // Workstream -> task count
+
+	// ByWorkstreamStatus counts tasks by status within each workstream
+	ByWorkstreamStatus map[string]map[Status]int
+
+	// FailuresByCategory counts failed tasks by their FailureCategory
+	FailuresByCategory map[FailureCategory]int
 }
 
 // Stats returns task statistics.
@@ -534,21 +905,86 @@ func (m *Manager) Stats() *Stats {
 	defer m.mu.RUnlock()
 
 	stats := &Stats{
-		ByStatus:     make(map[Status]int),
-		ByPriority:   make(map[Priority]int),
-		ByWorkstream: make(map[string]int),
+		ByStatus:           make(map[Status]int),
+		ByPriority:         make(map[Priority]int),
+		ByWorkstream:       make(map[string]int),
+		ByWorkstreamStatus: make(map[string]map[Status]int),
+		FailuresByCategory: make(map[FailureCategory]int),
 	}
 
 	for _, t := range m.tasks {
+		ws := t.GetWorkstream()
 		stats.Total++
 		stats.ByStatus[t.Status]++
 		stats.ByPriority[t.Priority]++
-		stats.ByWorkstream[t.GetWorkstream()]++
+		stats.ByWorkstream[ws]++
+		if stats.ByWorkstreamStatus[ws] == nil {
+			stats.ByWorkstreamStatus[ws] = make(map[Status]int)
+		}
+		stats.ByWorkstreamStatus[ws][t.Status]++
+		if t.Status == StatusFailed {
+			category := t.FailureCategory
+			if category == "" {
+				category = FailureOther
+			}
+			stats.FailuresByCategory[category]++
+		}
 	}
 
 	return stats
 }
 
+// CompletionRate returns how many tasks were completed per hour within the
+// last window, based on their completed_at timestamps.
+func (m *Manager) CompletionRate(window time.Duration) float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	tasks := make([]*Task, 0, len(m.tasks))
+	for _, t := range m.tasks {
+		tasks = append(tasks, t)
+	}
+	return CompletionRate(tasks, window, time.Now())
+}
+
+// CompletionRate returns how many of tasks were completed per hour in the
+// window ending at now. When work started less than a window ago, the rate
+// is measured from the earliest started_at instead, so a project that began
+// an hour ago isn't averaged over a full window. It returns 0 if no task was
+// completed in the window.
+func CompletionRate(tasks []*Task, window time.Duration, now time.Time) float64 {
+	if window <= 0 {
+		return 0
+	}
+	from := now.Add(-window)
+
+	completed := 0
+	var earliest time.Time
+	for _, t := range tasks {
+		if t.StartedAt != nil && (earliest.IsZero() || t.StartedAt.Before(earliest)) {
+			earliest = *t.StartedAt
+		}
+		if t.Status != StatusComplete || t.CompletedAt == nil {
+			continue
+		}
+		if t.CompletedAt.After(from) && !t.CompletedAt.After(now) {
+			completed++
+		}
+	}
+	if completed == 0 {
+		return 0
+	}
+
+	if earliest.After(from) {
+		from = earliest
+	}
+	elapsed := now.Sub(from)
+	if elapsed < time.Minute {
+		elapsed = time.Minute
+	}
+	return float64(completed) / elapsed.Hours()
+}
+
 // TasksDir returns the path to the tasks directory.
 func (m *Manager) TasksDir() string {
 	return m.tasksDir
@@ -665,7 +1101,7 @@ func (m *Manager) ReconcileStaleAssignments(activeAgents map[string]bool) (int,
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	count := 0
+	var stale []string
 	for _, task := range m.tasks {
 		// Only reset non-terminal, non-pending states
 		if task.Status != StatusAssigned && task.Status != StatusInProgress && task.Status != StatusFailed {
@@ -674,17 +1110,13 @@ func (m *Manager) ReconcileStaleAssignments(activeAgents map[string]bool) (int,
 
 		// If no active agents provided, reset all stale tasks
 		// Otherwise, only reset if agent is not in the active set
-		shouldReset := activeAgents == nil || !activeAgents[task.AssignedTo]
-
-		if shouldReset {
-			task.AssignedTo = ""
-			task.Status = StatusPending
-			if err := WriteFile(task); err != nil {
-				return count, fmt.Errorf("write task %s: %w", task.ID, err)
-			}
-			count++
+		if activeAgents == nil || !activeAgents[task.AssignedTo] {
+			stale = append(stale, task.ID)
 		}
 	}
+	sort.Strings(stale)
 
-	return count, nil
+	// Every ID comes from the cache, so none can be missing
+	reset, _, err := m.bulkUpdateStatus(stale, StatusPending)
+	return len(reset), err
 }
@@ -1,10 +1,15 @@
 package task
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"sync"
 	"testing"
+	"time"
+
+	"github.com/bkonkle/tanuki/internal/executor"
 )
 
 func TestNewManager(t *testing.T) {
@@ -229,6 +234,37 @@ func TestManager_GetByWorkstream(t *testing.T) {
 	})
 }
 
+func TestManager_GetByGroup(t *testing.T) {
+	mgr := &Manager{
+		tasks: map[string]*Task{
+			"T3": {ID: "T3", Group: "migration", Priority: PriorityCritical},
+			"T1": {ID: "T1", Group: "migration", Priority: PriorityLow},
+			"T2": {ID: "T2", Group: "other"},
+			"T4": {ID: "T4"},
+		},
+	}
+
+	tests := []struct {
+		group string
+		want  []string
+	}{
+		{"migration", []string{"T1", "T3"}},
+		{"other", []string{"T2"}},
+		{"nonexistent", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		for _, task := range mgr.GetByGroup(tt.group) {
+			got = append(got, task.ID)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("GetByGroup(%q) = %v, want %v", tt.group, got, tt.want)
+		}
+	}
+}
+
 func TestManager_GetByStatus(t *testing.T) {
 	mgr := &Manager{
 		tasks: map[string]*Task{
@@ -329,10 +365,11 @@ func TestManager_IsBlocked(t *testing.T) {
 		tasks: map[string]*Task{
 			"T1": {ID: "T1", Status: StatusComplete},
 			"T2": {ID: "T2", Status: StatusPending},
-			"T3": {ID: "T3", DependsOn: []string{"T1"}},       // Not blocked
-			"T4": {ID: "T4", DependsOn: []string{"T2"}},       // Blocked
-			"T5": {ID: "T5", DependsOn: []string{"T1", "T2"}}, // Blocked
-			"T6": {ID: "T6", DependsOn: []string{"missing"}},  // Blocked (missing dep)
+			"T3": {ID: "T3", DependsOn: []string{"T1"}},         // Not blocked
+			"T4": {ID: "T4", DependsOn: []string{"T2"}},         // Blocked
+			"T5": {ID: "T5", DependsOn: []string{"T1", "T2"}},   // Blocked
+			"T6": {ID: "T6", DependsOn: []string{"missing"}},    // Blocked (missing dep)
+			"T7": {ID: "T7", BlockedReason: "waiting on legal"}, // Blocked by hand
 		},
 	}
 
@@ -346,6 +383,7 @@ func TestManager_IsBlocked(t *testing.T) {
 		{"T4", true},  // T2 not complete
 		{"T5", true},  // T2 not complete
 		{"T6", true},  // missing dependency
+		{"T7", true},  // blocked by hand
 	}
 
 	for _, tt := range tests {
@@ -390,6 +428,70 @@ func TestManager_GetBlockingTasks(t *testing.T) {
 	}
 }
 
+func TestManager_GetDependents(t *testing.T) {
+	mgr := &Manager{
+		tasks: map[string]*Task{
+			"T1": {ID: "T1"},
+			"T2": {ID: "T2", DependsOn: []string{"T1"}},
+			"T3": {ID: "T3", DependsOn: []string{"T1", "T2"}},
+			"T4": {ID: "T4", DependsOn: []string{"T3"}},
+		},
+	}
+
+	tests := []struct {
+		id   string
+		want []string
+	}{
+		{"T1", []string{"T2", "T3"}},
+		{"T2", []string{"T3"}},
+		{"T3", []string{"T4"}},
+		{"T4", nil},
+		{"missing", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			got := mgr.GetDependents(tt.id)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetDependents(%s) = %v, want %v", tt.id, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("result is a copy", func(t *testing.T) {
+		got := mgr.GetDependents("T1")
+		got[0] = "changed"
+		if again := mgr.GetDependents("T1"); again[0] != "T2" {
+			t.Errorf("GetDependents(T1)[0] = %s after caller mutation, want T2", again[0])
+		}
+	})
+}
+
+func TestManager_GetDependents_AfterUpdate(t *testing.T) {
+	dir := t.TempDir()
+	t2 := &Task{ID: "T2", Title: "Second", Status: StatusPending, DependsOn: []string{"T1"}, FilePath: filepath.Join(dir, "T2.md")}
+	mgr := &Manager{
+		tasks: map[string]*Task{
+			"T1": {ID: "T1"},
+			"T2": t2,
+		},
+	}
+
+	if got := mgr.GetDependents("T1"); !reflect.DeepEqual(got, []string{"T2"}) {
+		t.Fatalf("GetDependents(T1) = %v, want [T2]", got)
+	}
+
+	// Updating dependencies must invalidate the cached index
+	updated := *t2
+	updated.DependsOn = nil
+	if err := mgr.Update(&updated); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if got := mgr.GetDependents("T1"); got != nil {
+		t.Errorf("GetDependents(T1) after update = %v, want none", got)
+	}
+}
+
 func TestManager_UpdateStatus(t *testing.T) {
 	dir := t.TempDir()
 	tasksDir := filepath.Join(dir, "tasks")
@@ -436,6 +538,129 @@ Content
 	}
 }
 
+func TestManager_BlockUnblock(t *testing.T) {
+	dir := t.TempDir()
+	tasksDir := filepath.Join(dir, "tasks")
+	_ = os.MkdirAll(tasksDir, 0750)
+	writeTestTask(t, tasksDir, "TASK-001", "")
+
+	mgr := NewManager(&Config{ProjectRoot: dir})
+	_, _ = mgr.Scan()
+
+	if err := mgr.Block("TASK-001", "  "); err == nil {
+		t.Error("Block() with an empty reason expected an error")
+	}
+	if err := mgr.Block("TASK-001", "waiting on legal"); err != nil {
+		t.Fatalf("Block() error: %v", err)
+	}
+	if blocked, _ := mgr.IsBlocked("TASK-001"); !blocked {
+		t.Error("IsBlocked() = false after Block()")
+	}
+	if len(NewResolver(mgr.List()).GetReady()) != 0 {
+		t.Error("a task blocked by hand should never be ready")
+	}
+
+	// UpdateBlockedStatus leaves it blocked, though it has no dependencies
+	_ = mgr.UpdateBlockedStatus()
+
+	// The reason is persisted
+	task, err := ParseFile(filepath.Join(tasksDir, "TASK-001.md"))
+	if err != nil {
+		t.Fatalf("ParseFile() error: %v", err)
+	}
+	if task.Status != StatusBlocked || task.BlockedReason != "waiting on legal" {
+		t.Errorf("persisted status = %s, reason = %q; want blocked, waiting on legal", task.Status, task.BlockedReason)
+	}
+
+	if err := mgr.Unblock("TASK-001"); err != nil {
+		t.Fatalf("Unblock() error: %v", err)
+	}
+	task, _ = mgr.Get("TASK-001")
+	if task.Status != StatusPending || task.BlockedReason != "" {
+		t.Errorf("status = %s, reason = %q after Unblock(); want pending, no reason", task.Status, task.BlockedReason)
+	}
+	if err := mgr.Unblock("TASK-001"); err == nil {
+		t.Error("Unblock() of a task that isn't blocked expected an error")
+	}
+}
+
+func TestManager_ExtraTaskDirs(t *testing.T) {
+	dir := t.TempDir()
+	tasksDir := filepath.Join(dir, "tasks")
+	sharedDir := filepath.Join(dir, "shared")
+	_ = os.MkdirAll(tasksDir, 0750)
+	_ = os.MkdirAll(sharedDir, 0750)
+	writeTestTask(t, tasksDir, "TASK-001", "")
+	writeTestTask(t, sharedDir, "SHARED-001", "")
+
+	mgr := NewManager(&Config{ProjectRoot: dir, ExtraTaskDirs: []string{"shared"}})
+	tasks, err := mgr.Scan()
+	if err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("Scan() found %d tasks, want 2", len(tasks))
+	}
+
+	if err := mgr.UpdateStatus("SHARED-001", StatusInProgress); err != nil {
+		t.Fatalf("UpdateStatus() error: %v", err)
+	}
+
+	// The update is written back to the shared directory
+	task, err := ParseFile(filepath.Join(sharedDir, "SHARED-001.md"))
+	if err != nil {
+		t.Fatalf("ParseFile() error: %v", err)
+	}
+	if task.Status != StatusInProgress {
+		t.Errorf("Persisted status = %v, want in_progress", task.Status)
+	}
+	if _, err := os.Stat(filepath.Join(tasksDir, "SHARED-001.md")); !os.IsNotExist(err) {
+		t.Errorf("SHARED-001 was written to the tasks directory")
+	}
+}
+
+func TestManager_UpdateFailure_PersistsCategory(t *testing.T) {
+	dir := t.TempDir()
+	tasksDir := filepath.Join(dir, "tasks")
+	_ = os.MkdirAll(tasksDir, 0750)
+
+	taskPath := filepath.Join(tasksDir, "TASK-001.md")
+	_ = os.WriteFile(taskPath, []byte(`---
+id: TASK-001
+title: Test
+workstream: backend
+status: in_progress
+---
+
+Content
+`), 0600)
+
+	mgr := NewManager(&Config{ProjectRoot: dir})
+	_, _ = mgr.Scan()
+
+	runErr := fmt.Errorf("agent run: %w", executor.ErrMaxIterations)
+	if err := mgr.UpdateFailure("TASK-001", runErr, ".tanuki/logs/task.log"); err != nil {
+		t.Fatalf("UpdateFailure() error: %v", err)
+	}
+
+	// Verify on disk (re-scan)
+	mgr2 := NewManager(&Config{ProjectRoot: dir})
+	_, _ = mgr2.Scan()
+	task, err := mgr2.Get("TASK-001")
+	if err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+	if task.Status != StatusFailed {
+		t.Errorf("Status = %v, want failed", task.Status)
+	}
+	if task.FailureCategory != FailureMaxIterations {
+		t.Errorf("FailureCategory = %q, want %q", task.FailureCategory, FailureMaxIterations)
+	}
+	if task.FailureMessage != runErr.Error() {
+		t.Errorf("FailureMessage = %q, want %q", task.FailureMessage, runErr.Error())
+	}
+}
+
 func TestManager_UpdateStatus_NotFound(t *testing.T) {
 	mgr := &Manager{tasks: make(map[string]*Task)}
 
@@ -656,6 +881,83 @@ func TestManager_Stats(t *testing.T) {
 	}
 }
 
+func TestManager_Stats_FailuresByCategory(t *testing.T) {
+	mgr := &Manager{
+		tasks: map[string]*Task{
+			"T1": {ID: "T1", Status: StatusFailed, FailureCategory: FailureTimeout},
+			"T2": {ID: "T2", Status: StatusFailed, FailureCategory: FailureTimeout},
+			"T3": {ID: "T3", Status: StatusFailed},
+			"T4": {ID: "T4", Status: StatusComplete, FailureCategory: FailureVerify},
+		},
+	}
+
+	stats := mgr.Stats()
+
+	if stats.FailuresByCategory[FailureTimeout] != 2 {
+		t.Errorf("FailuresByCategory[timeout] = %d, want 2", stats.FailuresByCategory[FailureTimeout])
+	}
+	if stats.FailuresByCategory[FailureOther] != 1 {
+		t.Errorf("FailuresByCategory[other] = %d, want 1", stats.FailuresByCategory[FailureOther])
+	}
+	if stats.FailuresByCategory[FailureVerify] != 0 {
+		t.Errorf("FailuresByCategory[verify_failed] = %d, want 0 for non-failed task", stats.FailuresByCategory[FailureVerify])
+	}
+}
+
+func TestCompletionRate(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	at := func(ago time.Duration) *time.Time {
+		ts := now.Add(-ago)
+		return &ts
+	}
+
+	tests := []struct {
+		name  string
+		tasks []*Task
+		want  float64
+	}{
+		{
+			name:  "no tasks",
+			tasks: nil,
+			want:  0,
+		},
+		{
+			name: "over the whole window",
+			tasks: []*Task{
+				{ID: "T1", Status: StatusComplete, StartedAt: at(48 * time.Hour), CompletedAt: at(20 * time.Hour)},
+				{ID: "T2", Status: StatusComplete, StartedAt: at(10 * time.Hour), CompletedAt: at(2 * time.Hour)},
+				{ID: "T3", Status: StatusComplete, StartedAt: at(30 * time.Hour), CompletedAt: at(28 * time.Hour)},
+			},
+			want: 2.0 / 24,
+		},
+		{
+			name: "work started within the window",
+			tasks: []*Task{
+				{ID: "T1", Status: StatusComplete, StartedAt: at(2 * time.Hour), CompletedAt: at(time.Hour)},
+				{ID: "T2", Status: StatusComplete, StartedAt: at(90 * time.Minute), CompletedAt: at(30 * time.Minute)},
+				{ID: "T3", Status: StatusInProgress, StartedAt: at(time.Hour)},
+			},
+			want: 1,
+		},
+		{
+			name: "reopened tasks don't count",
+			tasks: []*Task{
+				{ID: "T1", Status: StatusPending, StartedAt: at(2 * time.Hour), CompletedAt: at(time.Hour)},
+			},
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CompletionRate(tt.tasks, 24*time.Hour, now)
+			if diff := got - tt.want; diff > 1e-9 || diff < -1e-9 {
+				t.Errorf("CompletionRate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestManager_TasksDir(t *testing.T) {
 	cfg := &Config{ProjectRoot: "/test/project"}
 	mgr := NewManager(cfg)
@@ -931,3 +1233,342 @@ func TestManager_Stats_IncludesWorkstreams(t *testing.T) {
 		t.Errorf("ByWorkstream[ws2] = %d, want 1", stats.ByWorkstream["ws2"])
 	}
 }
+
+func TestManager_AddCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	tasksDir := filepath.Join(dir, "tasks")
+	_ = os.MkdirAll(tasksDir, 0750)
+
+	taskPath := filepath.Join(tasksDir, "TASK-001.md")
+	_ = os.WriteFile(taskPath, []byte(`---
+id: TASK-001
+title: Test
+workstream: backend
+status: in_progress
+---
+
+Content
+`), 0600)
+
+	mgr := NewManager(&Config{ProjectRoot: dir})
+	_, _ = mgr.Scan()
+
+	for _, cp := range []string{"schema migrated", "handlers added", "schema migrated"} {
+		if err := mgr.AddCheckpoint("TASK-001", cp); err != nil {
+			t.Fatalf("AddCheckpoint(%q) error: %v", cp, err)
+		}
+	}
+
+	if err := mgr.AddCheckpoint("TASK-999", "nope"); err == nil {
+		t.Error("AddCheckpoint() on unknown task should error")
+	}
+
+	// Verify on disk (re-scan)
+	mgr2 := NewManager(&Config{ProjectRoot: dir})
+	_, _ = mgr2.Scan()
+	task, err := mgr2.Get("TASK-001")
+	if err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+	want := []string{"schema migrated", "handlers added"}
+	if len(task.Checkpoints) != len(want) {
+		t.Fatalf("Checkpoints = %v, want %v", task.Checkpoints, want)
+	}
+	for i := range want {
+		if task.Checkpoints[i] != want[i] {
+			t.Errorf("Checkpoints[%d] = %q, want %q", i, task.Checkpoints[i], want[i])
+		}
+	}
+}
+
+func TestManager_SetProgress(t *testing.T) {
+	dir := t.TempDir()
+	tasksDir := filepath.Join(dir, "tasks")
+	_ = os.MkdirAll(tasksDir, 0750)
+
+	taskPath := filepath.Join(tasksDir, "TASK-001.md")
+	_ = os.WriteFile(taskPath, []byte(`---
+id: TASK-001
+title: Test
+workstream: backend
+status: pending
+---
+
+Content
+`), 0600)
+
+	mgr := NewManager(&Config{ProjectRoot: dir})
+	_, _ = mgr.Scan()
+
+	if err := mgr.UpdateStatus("TASK-001", StatusInProgress); err != nil {
+		t.Fatalf("UpdateStatus() error: %v", err)
+	}
+	if err := mgr.SetProgress("TASK-001", 42); err != nil {
+		t.Fatalf("SetProgress() error: %v", err)
+	}
+	if err := mgr.SetProgress("TASK-999", 10); err == nil {
+		t.Error("SetProgress() on unknown task should error")
+	}
+
+	// Verify on disk (re-scan)
+	mgr2 := NewManager(&Config{ProjectRoot: dir})
+	_, _ = mgr2.Scan()
+	task, err := mgr2.Get("TASK-001")
+	if err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+	if task.ProgressPercent == nil || *task.ProgressPercent != 42 {
+		t.Fatalf("ProgressPercent = %v, want 42", task.ProgressPercent)
+	}
+
+	// A new run starts with unknown progress
+	if err := mgr2.UpdateStatus("TASK-001", StatusInProgress); err != nil {
+		t.Fatalf("UpdateStatus() error: %v", err)
+	}
+	if task, _ := mgr2.Get("TASK-001"); task.ProgressPercent != nil {
+		t.Errorf("ProgressPercent = %d after restart, want nil", *task.ProgressPercent)
+	}
+}
+
+func TestManager_Retry(t *testing.T) {
+	dir := t.TempDir()
+	tasksDir := filepath.Join(dir, "tasks")
+	_ = os.MkdirAll(tasksDir, 0750)
+
+	taskPath := filepath.Join(tasksDir, "TASK-001.md")
+	_ = os.WriteFile(taskPath, []byte(`---
+id: TASK-001
+title: Test
+status: failed
+assigned_to: agent-1
+failure_message: tests failed
+retry_prompt: Focus on the failing test
+---
+
+Content
+`), 0600)
+
+	mgr := NewManager(&Config{ProjectRoot: dir})
+	_, _ = mgr.Scan()
+
+	if err := mgr.Retry("TASK-001"); err != nil {
+		t.Fatalf("Retry() error: %v", err)
+	}
+
+	// Verify on disk (re-scan)
+	mgr2 := NewManager(&Config{ProjectRoot: dir})
+	_, _ = mgr2.Scan()
+	task, err := mgr2.Get("TASK-001")
+	if err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+	if task.Status != StatusPending {
+		t.Errorf("Status = %v, want pending", task.Status)
+	}
+	if task.RetryCount != 1 {
+		t.Errorf("RetryCount = %d, want 1", task.RetryCount)
+	}
+	if task.AssignedTo != "" {
+		t.Errorf("AssignedTo = %q, want empty", task.AssignedTo)
+	}
+	if task.FailedAgent != "agent-1" {
+		t.Errorf("FailedAgent = %q, want agent-1", task.FailedAgent)
+	}
+	if task.FailureMessage != "tests failed" {
+		t.Errorf("FailureMessage = %q, want it preserved", task.FailureMessage)
+	}
+}
+
+func TestManager_AssignmentHistory(t *testing.T) {
+	dir := t.TempDir()
+	writeTestTask(t, filepath.Join(dir, "tasks"), "TASK-001", "workstream: backend\n")
+	mgr := NewManager(&Config{ProjectRoot: dir, MaxAssignmentHistory: 3})
+	_, _ = mgr.Scan()
+
+	if err := mgr.Assign("TASK-001", "agent-1"); err != nil {
+		t.Fatalf("Assign() error: %v", err)
+	}
+	if err := mgr.Unassign("TASK-001"); err != nil {
+		t.Fatalf("Unassign() error: %v", err)
+	}
+	if err := mgr.Assign("TASK-001", "agent-2"); err != nil {
+		t.Fatalf("Assign() error: %v", err)
+	}
+	_ = mgr.UpdateStatus("TASK-001", StatusComplete)
+	if err := mgr.Unassign("TASK-001"); err != nil {
+		t.Fatalf("Unassign() error: %v", err)
+	}
+	// Nothing to record once no agent holds the task
+	if err := mgr.Unassign("TASK-001"); err != nil {
+		t.Fatalf("Unassign() error: %v", err)
+	}
+
+	fresh := NewManager(&Config{ProjectRoot: dir})
+	_, _ = fresh.Scan()
+	task, _ := fresh.Get("TASK-001")
+
+	want := []AssignmentRecord{
+		{Agent: "agent-1", Outcome: OutcomeReleased},
+		{Agent: "agent-2", Outcome: OutcomeAssigned},
+		{Agent: "agent-2", Outcome: string(StatusComplete)},
+	}
+	if len(task.AssignmentHistory) != len(want) {
+		t.Fatalf("AssignmentHistory = %+v, want the last %d entries", task.AssignmentHistory, len(want))
+	}
+	for i, rec := range task.AssignmentHistory {
+		if rec.Agent != want[i].Agent || rec.Outcome != want[i].Outcome {
+			t.Errorf("AssignmentHistory[%d] = %s/%s, want %s/%s", i, rec.Agent, rec.Outcome, want[i].Agent, want[i].Outcome)
+		}
+		if rec.At.IsZero() {
+			t.Errorf("AssignmentHistory[%d] has no timestamp", i)
+		}
+	}
+}
+
+func TestManager_ScanStrict_KeepsLastGoodVersion(t *testing.T) {
+	dir := t.TempDir()
+	writeTestTask(t, filepath.Join(dir, "tasks"), "TASK-001", "workstream: backend\n")
+	mgr := NewManager(&Config{ProjectRoot: dir})
+	if _, _, err := mgr.ScanStrict(); err != nil {
+		t.Fatalf("ScanStrict() error: %v", err)
+	}
+	_ = mgr.UpdateStatus("TASK-001", StatusInProgress)
+
+	// A half-saved edit breaks the front matter, and a new file is broken from the start
+	tasksDir := filepath.Join(dir, "tasks")
+	if err := os.WriteFile(filepath.Join(tasksDir, "TASK-001.md"), []byte("---\nid: TASK-001\ntitle: [oops\n---\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tasksDir, "TASK-002.md"), []byte("---\nid: [\n---\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tasks, parseErrors, err := mgr.ScanStrict()
+	if err != nil {
+		t.Fatalf("ScanStrict() error: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != "TASK-001" {
+		t.Fatalf("ScanStrict() tasks = %v, want TASK-001 kept", tasks)
+	}
+	if task, err := mgr.Get("TASK-001"); err != nil || task.Status != StatusInProgress {
+		t.Errorf("Get(TASK-001) = %v, %v; want the last good version, in progress", task, err)
+	}
+
+	kept := map[string]string{}
+	for _, pe := range parseErrors {
+		kept[pe.Path] = pe.Kept
+	}
+	if len(parseErrors) != 2 || kept["TASK-001.md"] != "TASK-001" || kept["TASK-002.md"] != "" {
+		t.Errorf("ScanStrict() parse errors = %v, want TASK-001.md kept and TASK-002.md skipped", parseErrors)
+	}
+
+	// A manager that never saw the file parse has nothing to keep
+	fresh := NewManager(&Config{ProjectRoot: dir})
+	if tasks, _, _ := fresh.ScanStrict(); len(tasks) != 0 {
+		t.Errorf("fresh ScanStrict() tasks = %v, want none", tasks)
+	}
+}
+
+// writeTestTask writes a pending task file for id into dir, creating dir if
+// needed. extra holds more front matter lines, e.g. "workstream: backend\n".
+func writeTestTask(tb testing.TB, dir, id, extra string) {
+	tb.Helper()
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		tb.Fatal(err)
+	}
+	content := "---\nid: " + id + "\ntitle: Test " + id + "\nstatus: pending\n" + extra + "---\n\nContent\n"
+	if err := os.WriteFile(filepath.Join(dir, id+".md"), []byte(content), 0600); err != nil {
+		tb.Fatalf("write task: %v", err)
+	}
+}
+
+// writeBulkTasks writes n pending tasks, TASK-001 onward, all in the backend
+// workstream, and returns a scanned manager and their IDs.
+func writeBulkTasks(tb testing.TB, n int) (*Manager, []string) {
+	tb.Helper()
+	dir := tb.TempDir()
+
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("TASK-%03d", i+1)
+		writeTestTask(tb, filepath.Join(dir, "tasks"), ids[i], "workstream: backend\n")
+	}
+
+	mgr := NewManager(&Config{ProjectRoot: dir})
+	if _, err := mgr.Scan(); err != nil {
+		tb.Fatal(err)
+	}
+	return mgr, ids
+}
+
+func TestManager_BulkUpdateStatus(t *testing.T) {
+	mgr, ids := writeBulkTasks(t, 3)
+	if err := mgr.Assign("TASK-002", "agent-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	notFound, err := mgr.BulkUpdateStatus(append(ids[:2:2], "TASK-404", "TASK-001"), StatusComplete)
+	if err != nil {
+		t.Fatalf("BulkUpdateStatus() error: %v", err)
+	}
+	if len(notFound) != 1 || notFound["TASK-404"] == nil {
+		t.Errorf("BulkUpdateStatus() not found = %v, want only TASK-404", notFound)
+	}
+
+	// Changes are on disk, not just in the cache
+	reloaded := NewManager(&Config{ProjectRoot: filepath.Dir(mgr.TasksDir())})
+	if _, err := reloaded.Scan(); err != nil {
+		t.Fatal(err)
+	}
+	for id, want := range map[string]Status{"TASK-001": StatusComplete, "TASK-002": StatusComplete, "TASK-003": StatusPending} {
+		task, _ := reloaded.Get(id)
+		if task.Status != want {
+			t.Errorf("%s status = %s, want %s", id, task.Status, want)
+		}
+		if want == StatusComplete && task.CompletedAt == nil {
+			t.Errorf("%s CompletedAt not set", id)
+		}
+	}
+
+	// Resetting to pending releases the agent
+	if err := mgr.Assign("TASK-003", "agent-2"); err != nil {
+		t.Fatal(err)
+	}
+	notFound, err = mgr.BulkUpdateStatus([]string{"TASK-003"}, StatusPending)
+	if err != nil || notFound != nil {
+		t.Fatalf("BulkUpdateStatus() = %v, %v", notFound, err)
+	}
+	task, _ := mgr.Get("TASK-003")
+	if task.AssignedTo != "" {
+		t.Errorf("AssignedTo = %q, want released", task.AssignedTo)
+	}
+	if last := task.AssignmentHistory[len(task.AssignmentHistory)-1]; last.Outcome != OutcomeReleased {
+		t.Errorf("last history outcome = %q, want %q", last.Outcome, OutcomeReleased)
+	}
+}
+
+func BenchmarkManager_UpdateStatus_Loop(b *testing.B) {
+	mgr, ids := writeBulkTasks(b, 100)
+	statuses := []Status{StatusComplete, StatusPending}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, id := range ids {
+			if err := mgr.UpdateStatus(id, statuses[i%2]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkManager_BulkUpdateStatus(b *testing.B) {
+	mgr, ids := writeBulkTasks(b, 100)
+	statuses := []Status{StatusComplete, StatusPending}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := mgr.BulkUpdateStatus(ids, statuses[i%2]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
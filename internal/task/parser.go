@@ -1,10 +1,15 @@
 package task
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
+	"path"
 	"strings"
+	"time"
 
+	"github.com/bkonkle/tanuki/internal/tools"
 	"gopkg.in/yaml.v3"
 )
 
@@ -41,6 +46,7 @@ func Parse(content string, filePath string) (*Task, error) {
 	// Set derived fields
 	task.FilePath = filePath
 	task.Content = strings.TrimSpace(parts[2])
+	task.ContentHash = HashContent(task.Content)
 
 	// Validate
 	if err := Validate(&task); err != nil {
@@ -50,6 +56,13 @@ func Parse(content string, filePath string) (*Task, error) {
 	return &task, nil
 }
 
+// HashContent returns the hex SHA-256 of a task body. Only the body is
+// hashed, since the front matter is rewritten on every status change.
+func HashContent(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
 // Validate checks required fields and values.
 func Validate(t *Task) error {
 	if t == nil {
@@ -98,15 +111,88 @@ func Validate(t *Task) error {
 		t.Status = StatusPending
 	}
 
+	// Validate estimate if present
+	if t.Estimate != "" {
+		if d, err := time.ParseDuration(t.Estimate); err != nil || d <= 0 {
+			return &ValidationError{
+				Field:   "estimate",
+				Message: fmt.Sprintf("invalid duration %q: use a format like 30m or 2h", t.Estimate),
+			}
+		}
+	}
+
+	// Validate ralph config if present
+	if r := t.Ralph; r != nil {
+		if r.MaxIterations < 0 {
+			return &ValidationError{
+				Field:   "ralph.max_iterations",
+				Message: fmt.Sprintf("must not be negative (got %d)", r.MaxIterations),
+			}
+		}
+		if r.Cooldown != "" {
+			if d, err := time.ParseDuration(r.Cooldown); err != nil || d < 0 {
+				return &ValidationError{
+					Field:   "ralph.cooldown",
+					Message: fmt.Sprintf("invalid duration %q: use a format like 5s or 1m", r.Cooldown),
+				}
+			}
+		}
+	}
+
 	// Validate completion config if present
 	if t.Completion != nil {
-		if t.Completion.Verify == "" && t.Completion.Signal == "" {
+		if h := t.Completion.HTTP; h != nil {
+			if h.URL == "" {
+				return &ValidationError{
+					Field:   "completion.http",
+					Message: "must have url",
+				}
+			}
+			if h.Status != 0 && (h.Status < 100 || h.Status > 599) {
+				return &ValidationError{
+					Field:   "completion.http.status",
+					Message: fmt.Sprintf("invalid HTTP status %d", h.Status),
+				}
+			}
+		}
+		if t.Completion.Verify == "" && t.Completion.Signal == "" && !t.Completion.hasHTTP() {
 			return &ValidationError{
 				Field:   "completion",
-				Message: "must have verify or signal",
+				Message: "must have verify, signal, or http",
+			}
+		}
+	}
+
+	// Validate expected file globs
+	for _, pattern := range t.Expects {
+		if _, err := path.Match(pattern, ""); err != nil || pattern == "" || path.IsAbs(pattern) {
+			return &ValidationError{
+				Field:   "expects",
+				Message: fmt.Sprintf("invalid glob %q: use a pattern relative to the worktree, like dist/*.js", pattern),
 			}
 		}
 	}
 
+	// Validate tool overrides
+	if err := validateTools("allowed_tools", t.AllowedTools); err != nil {
+		return err
+	}
+	if err := validateTools("disallowed_tools", t.DisallowedTools); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateTools checks that every tool in a front matter list is known.
+func validateTools(field string, list []string) error {
+	for _, tool := range list {
+		if !tools.IsValidTool(tool) {
+			return &ValidationError{
+				Field:   field,
+				Message: fmt.Sprintf("unknown tool %q (valid tools: %s)", tool, strings.Join(tools.ValidTools, ", ")),
+			}
+		}
+	}
 	return nil
 }
@@ -1,6 +1,7 @@
 package task
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -323,6 +324,31 @@ Do the thing.
 	})
 }
 
+func TestParse_ContentHash(t *testing.T) {
+	const frontMatter = "---\nid: TASK-001\ntitle: Test Task\nstatus: %s\n---\n"
+
+	parse := func(status, body string) *Task {
+		t.Helper()
+		task, err := Parse(fmt.Sprintf(frontMatter, status)+body, "task.md")
+		if err != nil {
+			t.Fatalf("Parse() error = %v", err)
+		}
+		return task
+	}
+
+	original := parse("pending", "Do the thing.")
+	if original.ContentHash != HashContent("Do the thing.") {
+		t.Errorf("ContentHash = %q, want hash of the body", original.ContentHash)
+	}
+
+	if got := parse("in_progress", "\nDo the thing.\n").ContentHash; got != original.ContentHash {
+		t.Error("ContentHash changed with front matter or surrounding whitespace, want it stable")
+	}
+	if got := parse("pending", "Do the other thing.").ContentHash; got == original.ContentHash {
+		t.Error("ContentHash did not change when the body changed")
+	}
+}
+
 func TestValidate(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -354,12 +380,59 @@ func TestValidate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "priority",
 		},
+		{
+			name:    "invalid estimate",
+			task:    &Task{ID: "T1", Title: "Test", Workstream: "backend", Estimate: "soon"},
+			wantErr: true,
+			errMsg:  "estimate",
+		},
+		{
+			name:    "invalid ralph cooldown",
+			task:    &Task{ID: "T1", Title: "Test", Workstream: "backend", Ralph: &RalphConfig{Cooldown: "a bit"}},
+			wantErr: true,
+			errMsg:  "ralph.cooldown",
+		},
+		{
+			name:    "negative ralph max iterations",
+			task:    &Task{ID: "T1", Title: "Test", Workstream: "backend", Ralph: &RalphConfig{MaxIterations: -1}},
+			wantErr: true,
+			errMsg:  "ralph.max_iterations",
+		},
+		{
+			name:    "invalid expects glob",
+			task:    &Task{ID: "T1", Title: "Test", Workstream: "backend", Expects: []string{"dist/[.js"}},
+			wantErr: true,
+			errMsg:  "expects",
+		},
+		{
+			name:    "absolute expects glob",
+			task:    &Task{ID: "T1", Title: "Test", Workstream: "backend", Expects: []string{"/etc/passwd"}},
+			wantErr: true,
+			errMsg:  "expects",
+		},
 		{
 			name:    "invalid status",
 			task:    &Task{ID: "T1", Title: "Test", Workstream: "backend", Status: "done"},
 			wantErr: true,
 			errMsg:  "status",
 		},
+		{
+			name:    "unknown allowed tool",
+			task:    &Task{ID: "T1", Title: "Test", AllowedTools: []string{"Read", "Teleport"}},
+			wantErr: true,
+			errMsg:  "allowed_tools",
+		},
+		{
+			name:    "unknown disallowed tool",
+			task:    &Task{ID: "T1", Title: "Test", DisallowedTools: []string{"Teleport"}},
+			wantErr: true,
+			errMsg:  "disallowed_tools",
+		},
+		{
+			name:    "valid tool overrides",
+			task:    &Task{ID: "T1", Title: "Test", AllowedTools: []string{"Read", "WebFetch"}, DisallowedTools: []string{"Bash"}},
+			wantErr: false,
+		},
 		{
 			name: "empty completion config",
 			task: &Task{
@@ -371,6 +444,35 @@ func TestValidate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "completion",
 		},
+		{
+			name: "http completion without url",
+			task: &Task{
+				ID:         "T1",
+				Title:      "Test",
+				Completion: &CompletionConfig{HTTP: &HTTPCompletion{Status: 200}},
+			},
+			wantErr: true,
+			errMsg:  "completion.http",
+		},
+		{
+			name: "http completion with invalid status",
+			task: &Task{
+				ID:         "T1",
+				Title:      "Test",
+				Completion: &CompletionConfig{HTTP: &HTTPCompletion{URL: "http://localhost:8080/health", Status: 42}},
+			},
+			wantErr: true,
+			errMsg:  "completion.http.status",
+		},
+		{
+			name: "valid http completion",
+			task: &Task{
+				ID:         "T1",
+				Title:      "Test",
+				Completion: &CompletionConfig{HTTP: &HTTPCompletion{URL: "http://localhost:8080/health"}},
+			},
+			wantErr: false,
+		},
 		{
 			name: "valid minimal task",
 			task: &Task{
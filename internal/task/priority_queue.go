@@ -3,8 +3,9 @@ package task
 // queueItem wraps a task with its priority for heap operations
 type queueItem struct {
 	task     *Task
-	priority int // Lower = higher priority (0 = critical)
-	index    int // Index in heap, maintained by heap.Interface
+	priority int    // Lower = higher priority (0 = critical)
+	seq      uint64 // Enqueue order, breaking priority ties first-in first-out
+	index    int    // Index in heap, maintained by heap.Interface
 }
 
 // priorityQueue implements heap.Interface for priority-based task ordering
@@ -14,7 +15,10 @@ func (pq priorityQueue) Len() int { return len(pq) }
 
 func (pq priorityQueue) Less(i, j int) bool {
 	// Lower priority value = higher priority (pop first)
-	return pq[i].priority < pq[j].priority
+	if pq[i].priority != pq[j].priority {
+		return pq[i].priority < pq[j].priority
+	}
+	return pq[i].seq < pq[j].seq
 }
 
 func (pq priorityQueue) Swap(i, j int) {
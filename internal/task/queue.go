@@ -9,9 +9,11 @@ import (
 // Queue is a priority queue for tasks, organized by workstream.
 // It supports workstream-aware dequeuing, allowing agents to request tasks
 // matching their specific workstream while maintaining priority ordering.
+// Tasks of equal priority come out in the order they were enqueued.
 type Queue struct {
 	mu     sync.RWMutex
 	queues map[string]*priorityQueue // workstream -> queue
+	seq    uint64                    // Next enqueue sequence number
 }
 
 // NewQueue creates a new task queue.
@@ -39,7 +41,8 @@ func (q *Queue) Enqueue(t *Task) error {
 		q.queues[ws] = pq
 	}
 
-	heap.Push(pq, &queueItem{task: t, priority: priorityValue(t.Priority)})
+	heap.Push(pq, &queueItem{task: t, priority: priorityValue(t.Priority), seq: q.seq})
+	q.seq++
 	return nil
 }
 
@@ -58,8 +61,13 @@ func (q *Queue) Dequeue(workstream string) (*Task, error) {
 	return item.task, nil
 }
 
-// Peek returns the highest priority task without removing it.
-// Returns an error if there are no tasks for the specified workstream.
+// Peek returns the highest priority task without removing it: the task
+// Dequeue would return next, absent other changes to the queue. Returns an
+// error if there are no tasks for the specified workstream.
+//
+// Another caller may dequeue the task between Peek and acting on it. To
+// commit to a peeked task, Remove it by ID: Remove reports false if the task
+// was already taken.
 func (q *Queue) Peek(workstream string) (*Task, error) {
 	q.mu.RLock()
 	defer q.mu.RUnlock()
@@ -389,23 +389,62 @@ func TestQueue_DefaultPriority(t *testing.T) {
 func TestQueue_SamePriorityFIFO(t *testing.T) {
 	q := NewQueue()
 
-	// Tasks with same priority should maintain order relative to heap behavior
-	// Note: heap doesn't guarantee FIFO for same priority, but should be stable
+	// Tasks with the same priority come out in enqueue order
 	_ = q.Enqueue(&Task{ID: "T1", Workstream: "backend", Priority: PriorityHigh})
 	_ = q.Enqueue(&Task{ID: "T2", Workstream: "backend", Priority: PriorityHigh})
+	_ = q.Enqueue(&Task{ID: "T0", Workstream: "backend", Priority: PriorityCritical})
 	_ = q.Enqueue(&Task{ID: "T3", Workstream: "backend", Priority: PriorityHigh})
 
-	// All should come out, we don't guarantee FIFO but should get all 3
-	ids := make(map[string]bool)
-	for i := 0; i < 3; i++ {
+	for _, want := range []string{"T0", "T1", "T2", "T3"} {
+		peeked, err := q.Peek("backend")
+		if err != nil {
+			t.Fatalf("Peek() error: %v", err)
+		}
 		task, err := q.Dequeue("backend")
 		if err != nil {
 			t.Fatalf("Dequeue() error: %v", err)
 		}
-		ids[task.ID] = true
+		if peeked.ID != want || task.ID != want {
+			t.Errorf("Peek() = %s, Dequeue() = %s, want %s", peeked.ID, task.ID, want)
+		}
+	}
+}
+
+func TestQueue_PeekThenRemoveConcurrent(t *testing.T) {
+	q := NewQueue()
+	for i := 0; i < 100; i++ {
+		_ = q.Enqueue(&Task{ID: fmt.Sprintf("T%d", i), Workstream: "backend"})
 	}
 
-	if len(ids) != 3 {
-		t.Errorf("Should have dequeued 3 unique tasks, got %d", len(ids))
+	// Schedulers peek and commit with Remove; each task is taken once
+	var mu sync.Mutex
+	claimed := make(map[string]int)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				next, err := q.Peek("backend")
+				if err != nil {
+					return
+				}
+				if q.Remove(next.ID) {
+					mu.Lock()
+					claimed[next.ID]++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(claimed) != 100 {
+		t.Errorf("claimed %d tasks, want 100", len(claimed))
+	}
+	for id, n := range claimed {
+		if n != 1 {
+			t.Errorf("task %s claimed %d times", id, n)
+		}
 	}
 }
@@ -2,6 +2,7 @@ package task
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -39,8 +40,31 @@ func (r *Resolver) GetReady() []*Task {
 	return ready
 }
 
-// isReady checks if all dependencies are complete.
+// GetReadyTasks returns the IDs of pending tasks whose dependencies are all
+// complete, across every workstream, sorted by priority, then ID. A task
+// depending on a task the resolver doesn't know is never ready.
+func (r *Resolver) GetReadyTasks() []string {
+	ready := r.GetReady()
+	sort.Slice(ready, func(i, j int) bool {
+		if ready[i].Priority.Order() != ready[j].Priority.Order() {
+			return ready[i].Priority.Order() < ready[j].Priority.Order()
+		}
+		return ready[i].ID < ready[j].ID
+	})
+
+	ids := make([]string, len(ready))
+	for i, t := range ready {
+		ids[i] = t.ID
+	}
+	return ids
+}
+
+// isReady checks if the task isn't blocked by hand and all dependencies are
+// complete.
 func (r *Resolver) isReady(t *Task) bool {
+	if t.BlockedReason != "" {
+		return false
+	}
 	for _, depID := range t.DependsOn {
 		dep, ok := r.tasks[depID]
 		if !ok {
@@ -76,8 +100,12 @@ func (r *Resolver) GetBlocking(taskID string) ([]string, error) {
 	return blocking, nil
 }
 
-// IsBlocked returns true if task has incomplete dependencies.
+// IsBlocked returns true if task was blocked by hand or has incomplete
+// dependencies.
 func (r *Resolver) IsBlocked(taskID string) bool {
+	if t, ok := r.tasks[taskID]; ok && t.BlockedReason != "" {
+		return true
+	}
 	blocking, err := r.GetBlocking(taskID)
 	if err != nil {
 		return true // Not found = blocked
@@ -90,7 +118,7 @@ func (r *Resolver) IsBlocked(taskID string) bool {
 func (r *Resolver) TopologicalSort() ([]*Task, error) {
 	// Check for cycles first
 	if cycle := r.DetectCycle(); cycle != nil {
-		return nil, fmt.Errorf("dependency cycle detected: %s", strings.Join(cycle, " → "))
+		return nil, r.ExplainCycle(cycle)
 	}
 
 	// Kahn's algorithm
@@ -200,6 +228,104 @@ func (r *Resolver) reconstructCycle(start, end string, parent map[string]string)
 	return path
 }
 
+// CycleEdge is one depends_on entry in a dependency cycle: the task From
+// lists To in its depends_on, in the file at FilePath.
+type CycleEdge struct {
+	From     string
+	To       string
+	FilePath string
+}
+
+// CycleError describes a dependency cycle and the depends_on entries that
+// form it.
+type CycleError struct {
+	Cycle []string    // Task IDs, starting and ending with the same task
+	Edges []CycleEdge // One per step in Cycle
+}
+
+func (e *CycleError) Error() string {
+	return "dependency cycle detected: " + FormatCycle(e.Cycle)
+}
+
+// FormatCycle renders a cycle as a readable chain, e.g.
+// "A-001 → B-002 → C-003 → A-001", where each task depends on the next.
+func FormatCycle(cycle []string) string {
+	return strings.Join(cycle, " → ")
+}
+
+// ExplainCycle returns a CycleError for a cycle from DetectCycle, naming the
+// task file each depends_on edge comes from.
+func (r *Resolver) ExplainCycle(cycle []string) *CycleError {
+	cycleErr := &CycleError{Cycle: cycle}
+	for i := 0; i+1 < len(cycle); i++ {
+		edge := CycleEdge{From: cycle[i], To: cycle[i+1]}
+		if t, ok := r.tasks[edge.From]; ok {
+			edge.FilePath = t.FilePath
+		}
+		cycleErr.Edges = append(cycleErr.Edges, edge)
+	}
+	return cycleErr
+}
+
+// MaxDepth returns the number of tasks in the longest dependency chain, which
+// is how many tasks must run one after another no matter how many agents are
+// available. Returns 0 if there are no tasks or the graph has a cycle.
+func (r *Resolver) MaxDepth() int {
+	return len(r.LongestChain())
+}
+
+// LongestChain returns the task IDs of the longest dependency chain in run
+// order: each task depends on the one before it. Ties go to the chain ending
+// in the lowest task ID. Returns nil if the graph has a cycle.
+func (r *Resolver) LongestChain() []string {
+	if len(r.tasks) == 0 || r.DetectCycle() != nil {
+		return nil
+	}
+
+	// depth is the length of the longest chain ending at each task, and
+	// prev the dependency that chain comes through
+	depth := make(map[string]int, len(r.tasks))
+	prev := make(map[string]string, len(r.tasks))
+
+	var visit func(id string) int
+	visit = func(id string) int {
+		if d, ok := depth[id]; ok {
+			return d
+		}
+		depth[id] = 1
+		for _, depID := range r.tasks[id].DependsOn {
+			if _, ok := r.tasks[depID]; !ok {
+				continue // Missing dependency
+			}
+			d := visit(depID) + 1
+			if d > depth[id] || (d == depth[id] && depID < prev[id]) {
+				depth[id] = d
+				prev[id] = depID
+			}
+		}
+		return depth[id]
+	}
+
+	ids := make([]string, 0, len(r.tasks))
+	for id := range r.tasks {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	end := ids[0]
+	for _, id := range ids {
+		if visit(id) > visit(end) {
+			end = id
+		}
+	}
+
+	chain := make([]string, depth[end])
+	for i, id := len(chain)-1, end; i >= 0; i, id = i-1, prev[id] {
+		chain[i] = id
+	}
+	return chain
+}
+
 // GetLevels returns tasks grouped by dependency level.
 // Level 0 = no dependencies, Level 1 = depends only on Level 0, etc.
 func (r *Resolver) GetLevels() ([][]*Task, error) {
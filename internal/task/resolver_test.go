@@ -1,6 +1,8 @@
 package task
 
 import (
+	"errors"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -43,6 +45,21 @@ func TestResolver_GetReady(t *testing.T) {
 	}
 }
 
+func TestResolver_BlockedReason(t *testing.T) {
+	tasks := []*Task{
+		{ID: "T1", Status: StatusComplete},
+		{ID: "T2", Status: StatusPending, DependsOn: []string{"T1"}, BlockedReason: "waiting on design"},
+	}
+
+	resolver := NewResolver(tasks)
+	if !resolver.IsBlocked("T2") {
+		t.Error("IsBlocked(T2) = false, want a task blocked by hand to stay blocked")
+	}
+	if ready := resolver.GetReady(); len(ready) != 0 {
+		t.Errorf("GetReady() returned %d tasks, want 0", len(ready))
+	}
+}
+
 func TestResolver_GetReadyAfterComplete(t *testing.T) {
 	tasks := []*Task{
 		{ID: "T1", Status: StatusComplete, DependsOn: nil},
@@ -203,6 +220,33 @@ func TestResolver_TopologicalSort_Empty(t *testing.T) {
 	}
 }
 
+func TestResolver_GetReadyTasks(t *testing.T) {
+	tasks := []*Task{
+		{ID: "T1", Status: StatusComplete},
+		{ID: "T2", Status: StatusPending, Priority: PriorityLow, DependsOn: []string{"T1"}},
+		{ID: "T3", Status: StatusPending, Priority: PriorityCritical, Workstream: "frontend"},
+		{ID: "T4", Status: StatusPending, Priority: PriorityHigh, DependsOn: []string{"T1", "T5"}},
+		{ID: "T5", Status: StatusInProgress},
+		{ID: "T6", Status: StatusPending, DependsOn: []string{"MISSING"}},
+		{ID: "T7", Status: StatusPending, Priority: PriorityLow},
+		{ID: "T8", Status: StatusFailed},
+	}
+
+	got := NewResolver(tasks).GetReadyTasks()
+
+	// T4 waits on T5 and T6 on a missing task; the rest sort by priority, then ID
+	want := []string{"T3", "T2", "T7"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetReadyTasks() = %v, want %v", got, want)
+	}
+}
+
+func TestResolver_GetReadyTasks_Empty(t *testing.T) {
+	if got := NewResolver(nil).GetReadyTasks(); len(got) != 0 {
+		t.Errorf("GetReadyTasks() = %v, want none", got)
+	}
+}
+
 func TestResolver_DetectCycle(t *testing.T) {
 	// A → B → C → A (cycle)
 	tasks := []*Task{
@@ -227,6 +271,53 @@ func TestResolver_DetectCycle(t *testing.T) {
 	}
 }
 
+func TestResolver_ExplainCycle(t *testing.T) {
+	tasks := []*Task{
+		{ID: "A", DependsOn: []string{"C"}, FilePath: "tasks/a.md"},
+		{ID: "B", DependsOn: []string{"A"}, FilePath: "tasks/b.md"},
+		{ID: "C", DependsOn: []string{"B"}, FilePath: "tasks/c.md"},
+		{ID: "D", DependsOn: []string{"A"}, FilePath: "tasks/d.md"},
+	}
+
+	resolver := NewResolver(tasks)
+	cycle := resolver.DetectCycle()
+	if cycle == nil {
+		t.Fatal("DetectCycle() should find cycle")
+	}
+
+	cycleErr := resolver.ExplainCycle(cycle)
+	if len(cycleErr.Edges) != 3 {
+		t.Fatalf("ExplainCycle() edges = %v, want 3", cycleErr.Edges)
+	}
+
+	// Every edge should be a real depends_on entry, from the right file
+	byID := map[string]*Task{}
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+	for _, edge := range cycleErr.Edges {
+		from := byID[edge.From]
+		if from.DependsOn[0] != edge.To || edge.FilePath != from.FilePath {
+			t.Errorf("edge %+v doesn't match %s's depends_on %v in %s", edge, from.ID, from.DependsOn, from.FilePath)
+		}
+	}
+
+	if !strings.HasPrefix(cycleErr.Error(), "dependency cycle detected: "+cycle[0]+" → ") {
+		t.Errorf("Error() = %q, want a readable chain", cycleErr.Error())
+	}
+
+	if _, err := resolver.TopologicalSort(); !errors.As(err, &cycleErr) {
+		t.Errorf("TopologicalSort() error = %v, want a *CycleError", err)
+	}
+}
+
+func TestFormatCycle(t *testing.T) {
+	got := FormatCycle([]string{"A-001", "B-002", "C-003", "A-001"})
+	if want := "A-001 → B-002 → C-003 → A-001"; got != want {
+		t.Errorf("FormatCycle() = %q, want %q", got, want)
+	}
+}
+
 func TestResolver_DetectCycle_NoCycle(t *testing.T) {
 	tasks := []*Task{
 		{ID: "T1", DependsOn: nil},
@@ -334,6 +425,35 @@ func TestResolver_GetLevels_WithCycle(t *testing.T) {
 	}
 }
 
+func TestResolver_LongestChain(t *testing.T) {
+	tasks := []*Task{
+		{ID: "A"},
+		{ID: "B", DependsOn: []string{"A"}},
+		{ID: "C", DependsOn: []string{"B", "X"}},
+		{ID: "D", DependsOn: []string{"A", "missing"}},
+		{ID: "X"},
+	}
+
+	resolver := NewResolver(tasks)
+	if got := resolver.LongestChain(); !reflect.DeepEqual(got, []string{"A", "B", "C"}) {
+		t.Errorf("LongestChain() = %v, want [A B C]", got)
+	}
+	if got := resolver.MaxDepth(); got != 3 {
+		t.Errorf("MaxDepth() = %d, want 3", got)
+	}
+
+	if got := NewResolver(nil).MaxDepth(); got != 0 {
+		t.Errorf("MaxDepth() = %d, want 0 for no tasks", got)
+	}
+	cyclic := NewResolver([]*Task{
+		{ID: "A", DependsOn: []string{"B"}},
+		{ID: "B", DependsOn: []string{"A"}},
+	})
+	if got := cyclic.LongestChain(); got != nil {
+		t.Errorf("LongestChain() = %v, want nil for a cycle", got)
+	}
+}
+
 func TestResolver_MissingDependency(t *testing.T) {
 	tasks := []*Task{
 		{ID: "T1", DependsOn: []string{"missing"}},
@@ -0,0 +1,33 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CanRetry reports whether a failed task should be retried under the given
+// limit. Only tasks with a retry_prompt are retried.
+func (t *Task) CanRetry(maxRetries int) bool {
+	return t.RetryPrompt != "" && t.RetryCount < maxRetries
+}
+
+// RetryGuidance returns prompt text for a retried task, describing the
+// previous failure and the task's retry_prompt. It is empty on the first attempt.
+func RetryGuidance(t *Task) string {
+	if t.RetryCount == 0 || t.RetryPrompt == "" {
+		return ""
+	}
+
+	var prompt strings.Builder
+
+	prompt.WriteString("\n\n## Retry Guidance\n\n")
+	prompt.WriteString(fmt.Sprintf("This is retry %d of this task.", t.RetryCount))
+	if t.FailureMessage != "" {
+		prompt.WriteString(fmt.Sprintf(" The previous attempt failed with: %s", t.FailureMessage))
+	}
+	prompt.WriteString("\n\n")
+	prompt.WriteString(strings.TrimSpace(t.RetryPrompt))
+	prompt.WriteString("\n")
+
+	return prompt.String()
+}
@@ -0,0 +1,44 @@
+package task
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTask_CanRetry(t *testing.T) {
+	tests := []struct {
+		name       string
+		task       Task
+		maxRetries int
+		want       bool
+	}{
+		{"no retry prompt", Task{}, 3, false},
+		{"retries left", Task{RetryPrompt: "try again", RetryCount: 1}, 2, true},
+		{"retries exhausted", Task{RetryPrompt: "try again", RetryCount: 2}, 2, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.task.CanRetry(tt.maxRetries); got != tt.want {
+				t.Errorf("CanRetry(%d) = %v, want %v", tt.maxRetries, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryGuidance(t *testing.T) {
+	if got := RetryGuidance(&Task{RetryPrompt: "Focus on Y"}); got != "" {
+		t.Errorf("RetryGuidance() on first attempt = %q, want empty", got)
+	}
+
+	got := RetryGuidance(&Task{
+		RetryPrompt:    "Focus on Y",
+		RetryCount:     1,
+		FailureMessage: "verify failed",
+	})
+	for _, want := range []string{"## Retry Guidance", "retry 1", "verify failed", "Focus on Y"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RetryGuidance() missing %q:\n%s", want, got)
+		}
+	}
+}
@@ -50,14 +50,15 @@ func (r *Runner) RunTask(ctx context.Context, taskID, agentName string) error {
 	now := time.Now()
 	t.StartedAt = &now
 
-	// Build prompt
-	prompt := r.buildPrompt(t)
-
 	// Run in Ralph mode if completion criteria defined
 	if t.IsRalphMode() {
-		return r.runRalphMode(ctx, t, agentName, prompt)
+		t = ralphCriteria(t)
+		return r.runRalphMode(ctx, t, agentName, r.buildPrompt(t))
 	}
 
+	// Build prompt
+	prompt := r.buildPrompt(t)
+
 	// Single-shot execution
 	output, err := r.agentMgr.Run(agentName, prompt)
 	if err != nil {
@@ -73,7 +74,9 @@ func (r *Runner) RunTask(ctx context.Context, taskID, agentName string) error {
 
 // runRalphMode iterates until completion criteria are met or max iterations reached.
 func (r *Runner) runRalphMode(ctx context.Context, t *Task, agentName, prompt string) error {
+	loop := t.RalphLoop()
 	maxIterations := t.Completion.GetMaxIterations()
+	cooldown := loop.GetCooldown(r.cooldown)
 
 	for i := 1; i <= maxIterations; i++ {
 		select {
@@ -108,15 +111,15 @@ func (r *Runner) runRalphMode(ctx context.Context, t *Task, agentName, prompt st
 			if err := r.taskMgr.UpdateStatus(t.ID, StatusFailed); err != nil {
 				log.Printf("Warning: failed to update task status: %v", err)
 			}
-			return fmt.Errorf("task failed: %s", result.Message)
+			return fmt.Errorf("task failed: %s: %w", result.Message, ErrVerifyFailed)
 		}
 
 		// Cooldown before next iteration
-		log.Printf("Task %s: not complete, waiting %v before retry", t.ID, r.cooldown)
+		log.Printf("Task %s: not complete, waiting %v before retry", t.ID, cooldown)
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case <-time.After(r.cooldown):
+		case <-time.After(cooldown):
 		}
 	}
 
@@ -127,6 +130,31 @@ func (r *Runner) runRalphMode(ctx context.Context, t *Task, agentName, prompt st
 	return fmt.Errorf("max iterations (%d) reached without completion", maxIterations)
 }
 
+// ralphCriteria returns the task to check each Ralph mode iteration against:
+// t itself, or with a ralph block, a copy whose completion criteria use the
+// loop's signal, verify, and max iterations. A loop with no criteria waits
+// for the default "DONE" signal.
+func ralphCriteria(t *Task) *Task {
+	if t.Ralph == nil {
+		return t
+	}
+
+	loop := t.RalphLoop()
+	completion := CompletionConfig{}
+	if t.Completion != nil {
+		completion = *t.Completion
+	}
+	completion.Signal, completion.Verify = loop.Signal, loop.Verify
+	completion.MaxIterations = loop.MaxIterations
+	if completion.Signal == "" && completion.Verify == "" && !completion.hasHTTP() {
+		completion.Signal = "DONE"
+	}
+
+	c := *t
+	c.Completion = &completion
+	return &c
+}
+
 // buildPrompt creates the prompt to send to an agent for a task.
 func (r *Runner) buildPrompt(t *Task) string {
 	var prompt strings.Builder
@@ -145,6 +173,10 @@ func (r *Runner) buildPrompt(t *Task) string {
 			prompt.WriteString(fmt.Sprintf("**Completion Signal:** When you are done, output exactly: `%s`\n\n", t.Completion.Signal))
 		}
 
+		if t.Completion.hasHTTP() {
+			prompt.WriteString(fmt.Sprintf("**HTTP Check:** `%s` must return status %d.\n\n", t.Completion.HTTP.URL, t.Completion.HTTP.verifier().ExpectedStatus()))
+		}
+
 		prompt.WriteString("Do not say you are done until all criteria are met.\n")
 	}
 
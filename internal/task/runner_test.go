@@ -41,7 +41,7 @@ func TestRunner_RunTask_SingleShot(t *testing.T) {
 	taskMgr := newMockTaskMgr()
 	agentMgr := newMockAgentExecutor([]string{"Output with DONE"}, nil)
 	events := make(chan Event, 10)
-	completion := NewCompletionHandler(taskMgr, "", events)
+	completion := NewCompletionHandler(taskMgr, t.TempDir(), events)
 	runner := NewRunner(taskMgr, agentMgr, completion)
 
 	task := &Task{
@@ -71,7 +71,7 @@ func TestRunner_RunTask_RalphMode_Success(t *testing.T) {
 	// First two calls don't have signal, third does
 	agentMgr := newMockAgentExecutor([]string{"Working...", "Still working...", "DONE"}, nil)
 	events := make(chan Event, 10)
-	completion := NewCompletionHandler(taskMgr, "", events)
+	completion := NewCompletionHandler(taskMgr, t.TempDir(), events)
 	runner := NewRunner(taskMgr, agentMgr, completion)
 	runner.SetCooldown(1 * time.Millisecond) // Speed up tests
 
@@ -107,7 +107,7 @@ func TestRunner_RunTask_RalphMode_MaxIterations(t *testing.T) {
 	// Never outputs the signal
 	agentMgr := newMockAgentExecutor([]string{"Working...", "Working...", "Working..."}, nil)
 	events := make(chan Event, 10)
-	completion := NewCompletionHandler(taskMgr, "", events)
+	completion := NewCompletionHandler(taskMgr, t.TempDir(), events)
 	runner := NewRunner(taskMgr, agentMgr, completion)
 	runner.SetCooldown(1 * time.Millisecond)
 
@@ -134,6 +134,37 @@ func TestRunner_RunTask_RalphMode_MaxIterations(t *testing.T) {
 	}
 }
 
+func TestRunner_RunTask_RalphBlock(t *testing.T) {
+	taskMgr := newMockTaskMgr()
+	agentMgr := newMockAgentExecutor([]string{"DONE", "Working...", "SHIPPED"}, nil)
+	events := make(chan Event, 10)
+	completion := NewCompletionHandler(taskMgr, t.TempDir(), events)
+	runner := NewRunner(taskMgr, agentMgr, completion)
+	runner.SetCooldown(time.Hour) // The ralph block's cooldown wins
+
+	task := &Task{
+		ID:         "T1",
+		Title:      "Test Task",
+		Status:     StatusAssigned,
+		Completion: &CompletionConfig{Signal: "DONE", MaxIterations: 1},
+		Ralph:      &RalphConfig{Signal: "SHIPPED", MaxIterations: 5, Cooldown: "1ms"},
+	}
+	taskMgr.addTask(task)
+
+	if err := runner.RunTask(context.Background(), "T1", "agent-1"); err != nil {
+		t.Fatalf("RunTask() error: %v", err)
+	}
+
+	// The ralph signal replaces completion's, so DONE alone doesn't finish
+	if agentMgr.calls != 3 {
+		t.Errorf("Agent called %d times, want 3", agentMgr.calls)
+	}
+	updatedTask, _ := taskMgr.Get("T1")
+	if updatedTask.Status != StatusComplete {
+		t.Errorf("Status = %v, want complete", updatedTask.Status)
+	}
+}
+
 func TestRunner_BuildPrompt(t *testing.T) {
 	runner := NewRunner(nil, nil, nil)
 
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -14,17 +15,82 @@ type taskFrontMatter struct {
 	ID         string            `yaml:"id"`
 	Title      string            `yaml:"title"`
 	Workstream string            `yaml:"workstream,omitempty"`
+	Group      string            `yaml:"group,omitempty"`
 	Priority   Priority          `yaml:"priority,omitempty"`
 	Status     Status            `yaml:"status,omitempty"`
 	DependsOn  []string          `yaml:"depends_on,omitempty"`
 	AssignedTo string            `yaml:"assigned_to,omitempty"`
 	Completion *CompletionConfig `yaml:"completion,omitempty"`
+	Ralph      *RalphConfig      `yaml:"ralph,omitempty"`
+	Expects    []string          `yaml:"expects,omitempty"`
 	Tags       []string          `yaml:"tags,omitempty"`
+	Estimate   string            `yaml:"estimate,omitempty"`
+	LastAgent  string            `yaml:"last_agent,omitempty"`
+
+	// Tool overrides
+	AllowedTools    []string `yaml:"allowed_tools,omitempty"`
+	DisallowedTools []string `yaml:"disallowed_tools,omitempty"`
+
+	// Timing
+	StartedAt   *time.Time `yaml:"started_at,omitempty"`
+	CompletedAt *time.Time `yaml:"completed_at,omitempty"`
 
 	// Error and log tracking
-	FailureMessage string `yaml:"failure_message,omitempty"`
-	LogFilePath    string `yaml:"log_file,omitempty"`
-	ValidationLog  string `yaml:"validation_log,omitempty"`
+	FailureMessage  string          `yaml:"failure_message,omitempty"`
+	FailureCategory FailureCategory `yaml:"failure_category,omitempty"`
+	LogFilePath     string          `yaml:"log_file,omitempty"`
+	ValidationLog   string          `yaml:"validation_log,omitempty"`
+
+	// Progress tracking
+	Checkpoints     []string `yaml:"checkpoints,omitempty"`
+	ProgressPercent *int     `yaml:"progress_percent,omitempty"`
+
+	// Retry policy
+	RetryPrompt string `yaml:"retry_prompt,omitempty"`
+	RetryCount  int    `yaml:"retry_count,omitempty"`
+	FailedAgent string `yaml:"failed_agent,omitempty"`
+
+	// Manual blocking
+	BlockedReason string `yaml:"blocked_reason,omitempty"`
+
+	// Audit
+	AssignmentHistory []AssignmentRecord `yaml:"assignment_history,omitempty"`
+}
+
+// newFrontMatter returns the serializable front matter fields of t.
+func newFrontMatter(t *Task) taskFrontMatter {
+	return taskFrontMatter{
+		ID:              t.ID,
+		Title:           t.Title,
+		Workstream:      t.Workstream,
+		Group:           t.Group,
+		Priority:        t.Priority,
+		Status:          t.Status,
+		DependsOn:       t.DependsOn,
+		AssignedTo:      t.AssignedTo,
+		Completion:      t.Completion,
+		Ralph:           t.Ralph,
+		Expects:         t.Expects,
+		Tags:            t.Tags,
+		Estimate:        t.Estimate,
+		AllowedTools:    t.AllowedTools,
+		DisallowedTools: t.DisallowedTools,
+		LastAgent:       t.LastAgent,
+		StartedAt:       t.StartedAt,
+		CompletedAt:     t.CompletedAt,
+		FailureMessage:  t.FailureMessage,
+		FailureCategory: t.FailureCategory,
+		LogFilePath:     t.LogFilePath,
+		ValidationLog:   t.ValidationLog,
+		Checkpoints:     t.Checkpoints,
+		ProgressPercent: t.ProgressPercent,
+		RetryPrompt:     t.RetryPrompt,
+		RetryCount:      t.RetryCount,
+		FailedAgent:     t.FailedAgent,
+		BlockedReason:   t.BlockedReason,
+
+		AssignmentHistory: t.AssignmentHistory,
+	}
 }
 
 // WriteFile writes task back to file, preserving markdown content.
@@ -37,21 +103,7 @@ func WriteFile(t *Task) error {
 		return fmt.Errorf("task has no file path")
 	}
 
-	// Create front matter struct with only serializable fields
-	fm := taskFrontMatter{
-		ID:             t.ID,
-		Title:          t.Title,
-		Workstream:     t.Workstream,
-		Priority:       t.Priority,
-		Status:         t.Status,
-		DependsOn:      t.DependsOn,
-		AssignedTo:     t.AssignedTo,
-		Completion:     t.Completion,
-		Tags:           t.Tags,
-		FailureMessage: t.FailureMessage,
-		LogFilePath:    t.LogFilePath,
-		ValidationLog:  t.ValidationLog,
-	}
+	fm := newFrontMatter(t)
 
 	// Marshal front matter with proper YAML formatting
 	var buf bytes.Buffer
@@ -76,21 +128,7 @@ func Serialize(t *Task) (string, error) {
 		return "", fmt.Errorf("task is nil")
 	}
 
-	// Create front matter struct with only serializable fields
-	fm := taskFrontMatter{
-		ID:             t.ID,
-		Title:          t.Title,
-		Workstream:     t.Workstream,
-		Priority:       t.Priority,
-		Status:         t.Status,
-		DependsOn:      t.DependsOn,
-		AssignedTo:     t.AssignedTo,
-		Completion:     t.Completion,
-		Tags:           t.Tags,
-		FailureMessage: t.FailureMessage,
-		LogFilePath:    t.LogFilePath,
-		ValidationLog:  t.ValidationLog,
-	}
+	fm := newFrontMatter(t)
 
 	// Marshal front matter with proper YAML formatting
 	var buf bytes.Buffer
@@ -219,14 +219,17 @@ func TestWriteFile_UpdateStatus(t *testing.T) {
 
 func TestSerialize_RoundTrip(t *testing.T) {
 	original := &Task{
-		ID:         "TASK-001",
-		Title:      "Test Task",
-		Workstream: "backend",
-		Priority:   PriorityHigh,
-		Status:     StatusInProgress,
-		DependsOn:  []string{"TASK-000"},
-		AssignedTo: "agent-1",
-		Tags:       []string{"test"},
+		ID:              "TASK-001",
+		Title:           "Test Task",
+		Workstream:      "backend",
+		Group:           "migration",
+		Priority:        PriorityHigh,
+		Status:          StatusInProgress,
+		DependsOn:       []string{"TASK-000"},
+		AssignedTo:      "agent-1",
+		Tags:            []string{"test"},
+		AllowedTools:    []string{"Read", "WebFetch"},
+		DisallowedTools: []string{"Bash"},
 		Completion: &CompletionConfig{
 			Verify:        "npm test",
 			Signal:        "DONE",
@@ -257,6 +260,9 @@ func TestSerialize_RoundTrip(t *testing.T) {
 	if parsed.Workstream != original.Workstream {
 		t.Errorf("Workstream = %q, want %q", parsed.Workstream, original.Workstream)
 	}
+	if parsed.Group != original.Group {
+		t.Errorf("Group = %q, want %q", parsed.Group, original.Group)
+	}
 	if parsed.Priority != original.Priority {
 		t.Errorf("Priority = %q, want %q", parsed.Priority, original.Priority)
 	}
@@ -272,6 +278,12 @@ func TestSerialize_RoundTrip(t *testing.T) {
 	if len(parsed.Tags) != len(original.Tags) {
 		t.Errorf("Tags length = %d, want %d", len(parsed.Tags), len(original.Tags))
 	}
+	if strings.Join(parsed.AllowedTools, ",") != "Read,WebFetch" {
+		t.Errorf("AllowedTools = %v, want %v", parsed.AllowedTools, original.AllowedTools)
+	}
+	if strings.Join(parsed.DisallowedTools, ",") != "Bash" {
+		t.Errorf("DisallowedTools = %v, want %v", parsed.DisallowedTools, original.DisallowedTools)
+	}
 	if parsed.Completion.MaxIterations != original.Completion.MaxIterations {
 		t.Errorf("MaxIterations = %d, want %d", parsed.Completion.MaxIterations, original.Completion.MaxIterations)
 	}
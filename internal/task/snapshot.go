@@ -0,0 +1,193 @@
+package task
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SnapshotsDir is the directory under .tanuki/ where task snapshots are stored
+const SnapshotsDir = "snapshots"
+
+// TaskSnapshot records every task's status and assignment at a point in
+// time, so a bulk change such as ReconcileStaleAssignments can be undone.
+type TaskSnapshot struct {
+	CreatedAt time.Time                `json:"created_at"`
+	Tasks     map[string]SnapshotEntry `json:"tasks"` // By task ID
+}
+
+// SnapshotEntry is the state of one task in a TaskSnapshot.
+type SnapshotEntry struct {
+	Status     Status `json:"status"`
+	AssignedTo string `json:"assigned_to,omitempty"`
+}
+
+// Snapshot captures the current status and assignment of every task.
+func (m *Manager) Snapshot() (*TaskSnapshot, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if len(m.tasks) == 0 {
+		return nil, errors.New("no tasks to snapshot")
+	}
+
+	s := &TaskSnapshot{
+		CreatedAt: time.Now(),
+		Tasks:     make(map[string]SnapshotEntry, len(m.tasks)),
+	}
+	for id, t := range m.tasks {
+		s.Tasks[id] = SnapshotEntry{Status: t.Status, AssignedTo: t.AssignedTo}
+	}
+	return s, nil
+}
+
+// Restore puts every task in the snapshot back to its recorded status and
+// assignment, rewriting only the task files that changed. Tasks created
+// since the snapshot are left alone, and tasks deleted since are skipped.
+func (m *Manager) Restore(s *TaskSnapshot) error {
+	if s == nil {
+		return errors.New("snapshot is nil")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var changed []string
+	defer func() { m.notifyUpdated(changed...) }()
+
+	for _, id := range s.Changed(m.list()) {
+		task := m.tasks[id]
+		entry := s.Tasks[id]
+		task.Status = entry.Status
+		task.AssignedTo = entry.AssignedTo
+		if err := m.taskStore().Write(task); err != nil {
+			return fmt.Errorf("write task %s: %w", id, err)
+		}
+		changed = append(changed, id)
+	}
+
+	return nil
+}
+
+// Changed returns the IDs of tasks whose status or assignment differs from
+// the snapshot, sorted. Tasks not in the snapshot are ignored.
+func (s *TaskSnapshot) Changed(tasks []*Task) []string {
+	var ids []string
+	for _, t := range tasks {
+		entry, ok := s.Tasks[t.ID]
+		if ok && (entry.Status != t.Status || entry.AssignedTo != t.AssignedTo) {
+			ids = append(ids, t.ID)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// list returns the cached tasks. The caller must hold m.mu.
+func (m *Manager) list() []*Task {
+	tasks := make([]*Task, 0, len(m.tasks))
+	for _, t := range m.tasks {
+		tasks = append(tasks, t)
+	}
+	return tasks
+}
+
+// snapshotDir returns the directory snapshots are stored in.
+func snapshotDir(projectRoot string) string {
+	return filepath.Join(projectRoot, ".tanuki", SnapshotsDir)
+}
+
+// SaveSnapshot writes s to .tanuki/snapshots/ under a name taken from its
+// creation time, and returns the snapshot's name.
+func SaveSnapshot(projectRoot string, s *TaskSnapshot) (string, error) {
+	dir := snapshotDir(projectRoot)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return "", fmt.Errorf("create snapshot directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("encode snapshot: %w", err)
+	}
+
+	// Snapshots taken within the same second get a numeric suffix, padded
+	// so names still sort in the order they were taken
+	base := s.CreatedAt.Format("20060102-150405")
+	for i := 1; ; i++ {
+		name := base
+		if i > 1 {
+			name = fmt.Sprintf("%s-%02d", base, i)
+		}
+		path := filepath.Join(dir, name+".json")
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600) //nolint:gosec // G304: path is under the project's .tanuki directory
+		if errors.Is(err, os.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("create snapshot: %w", err)
+		}
+		_, err = f.Write(data)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return "", fmt.Errorf("write snapshot: %w", err)
+		}
+		return name, nil
+	}
+}
+
+// ListSnapshots returns the names of saved snapshots, oldest first.
+func ListSnapshots(projectRoot string) ([]string, error) {
+	entries, err := os.ReadDir(snapshotDir(projectRoot))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read snapshot directory: %w", err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".json") {
+			names = append(names, strings.TrimSuffix(e.Name(), ".json"))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// LoadSnapshot reads a saved snapshot by name. An empty name loads the
+// most recent one.
+func LoadSnapshot(projectRoot, name string) (*TaskSnapshot, error) {
+	if name == "" {
+		names, err := ListSnapshots(projectRoot)
+		if err != nil {
+			return nil, err
+		}
+		if len(names) == 0 {
+			return nil, errors.New("no snapshots found")
+		}
+		name = names[len(names)-1]
+	}
+
+	path := filepath.Join(snapshotDir(projectRoot), filepath.Base(strings.TrimSuffix(name, ".json"))+".json")
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is under the project's .tanuki directory
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("snapshot %q not found", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read snapshot: %w", err)
+	}
+
+	var s TaskSnapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parse snapshot %s: %w", name, err)
+	}
+	return &s, nil
+}
@@ -0,0 +1,134 @@
+package task
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestManager_SnapshotRestore(t *testing.T) {
+	dir := t.TempDir()
+	writeTestTask(t, filepath.Join(dir, "tasks"), "TASK-001", "workstream: backend\n")
+	mgr := NewManager(&Config{ProjectRoot: dir})
+	_, _ = mgr.Scan()
+
+	if _, err := mgr.Claim("TASK-001", "agent-1"); err != nil {
+		t.Fatalf("Claim() error: %v", err)
+	}
+	snapshot, err := mgr.Snapshot()
+	if err != nil {
+		t.Fatalf("Snapshot() error: %v", err)
+	}
+
+	if got := snapshot.Changed(mgr.List()); len(got) != 0 {
+		t.Errorf("Changed() = %v, want none right after the snapshot", got)
+	}
+
+	// A bulk reset clears the assignment
+	if _, err := mgr.ReconcileStaleAssignments(nil); err != nil {
+		t.Fatalf("ReconcileStaleAssignments() error: %v", err)
+	}
+	if got := snapshot.Changed(mgr.List()); len(got) != 1 || got[0] != "TASK-001" {
+		t.Errorf("Changed() = %v, want [TASK-001]", got)
+	}
+
+	if err := mgr.Restore(snapshot); err != nil {
+		t.Fatalf("Restore() error: %v", err)
+	}
+	task, _ := mgr.Get("TASK-001")
+	if task.Status != StatusAssigned || task.AssignedTo != "agent-1" {
+		t.Errorf("task = %s/%q, want assigned to agent-1 restored", task.Status, task.AssignedTo)
+	}
+
+	// The task file is rewritten too
+	fresh := NewManager(&Config{ProjectRoot: dir})
+	_, _ = fresh.Scan()
+	task, _ = fresh.Get("TASK-001")
+	if task.Status != StatusAssigned || task.AssignedTo != "agent-1" {
+		t.Errorf("task file = %s/%q, want assigned to agent-1", task.Status, task.AssignedTo)
+	}
+}
+
+func TestManager_Snapshot_NoTasks(t *testing.T) {
+	mgr := NewManager(&Config{ProjectRoot: t.TempDir()})
+	if _, err := mgr.Snapshot(); err == nil {
+		t.Error("Snapshot() error = nil, want error with no tasks")
+	}
+}
+
+func TestManager_Restore_SkipsUnknownTasks(t *testing.T) {
+	dir := t.TempDir()
+	writeTestTask(t, filepath.Join(dir, "tasks"), "TASK-001", "workstream: backend\n")
+	mgr := NewManager(&Config{ProjectRoot: dir})
+	_, _ = mgr.Scan()
+
+	snapshot := &TaskSnapshot{Tasks: map[string]SnapshotEntry{
+		"TASK-404": {Status: StatusComplete},
+	}}
+	if err := mgr.Restore(snapshot); err != nil {
+		t.Fatalf("Restore() error: %v", err)
+	}
+	if task, _ := mgr.Get("TASK-001"); task.Status != StatusPending {
+		t.Errorf("TASK-001 status = %s, want pending left alone", task.Status)
+	}
+}
+
+func TestSaveLoadSnapshot(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, err := LoadSnapshot(dir, ""); err == nil {
+		t.Error("LoadSnapshot() error = nil, want error with no snapshots")
+	}
+
+	created := time.Date(2025, 1, 14, 9, 30, 0, 0, time.UTC)
+	first := &TaskSnapshot{CreatedAt: created, Tasks: map[string]SnapshotEntry{
+		"TASK-001": {Status: StatusPending},
+	}}
+	second := &TaskSnapshot{CreatedAt: created, Tasks: map[string]SnapshotEntry{
+		"TASK-001": {Status: StatusInProgress, AssignedTo: "agent-1"},
+	}}
+
+	name1, err := SaveSnapshot(dir, first)
+	if err != nil {
+		t.Fatalf("SaveSnapshot() error: %v", err)
+	}
+	name2, err := SaveSnapshot(dir, second)
+	if err != nil {
+		t.Fatalf("SaveSnapshot() error: %v", err)
+	}
+	if name1 != "20250114-093000" || name2 != "20250114-093000-02" {
+		t.Errorf("SaveSnapshot() names = %q, %q, want a suffix for the same second", name1, name2)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".tanuki", "snapshots", name1+".json")); err != nil {
+		t.Errorf("snapshot file not written: %v", err)
+	}
+
+	names, err := ListSnapshots(dir)
+	if err != nil {
+		t.Fatalf("ListSnapshots() error: %v", err)
+	}
+	if len(names) != 2 || names[1] != name2 {
+		t.Errorf("ListSnapshots() = %v, want [%s %s]", names, name1, name2)
+	}
+
+	latest, err := LoadSnapshot(dir, "")
+	if err != nil {
+		t.Fatalf("LoadSnapshot() error: %v", err)
+	}
+	if got := latest.Tasks["TASK-001"]; got.Status != StatusInProgress || got.AssignedTo != "agent-1" {
+		t.Errorf("latest snapshot entry = %+v, want the second snapshot", got)
+	}
+
+	loaded, err := LoadSnapshot(dir, name1)
+	if err != nil {
+		t.Fatalf("LoadSnapshot(%q) error: %v", name1, err)
+	}
+	if got := loaded.Tasks["TASK-001"]; got.Status != StatusPending {
+		t.Errorf("snapshot %s entry = %+v, want pending", name1, got)
+	}
+
+	if _, err := LoadSnapshot(dir, "missing"); err == nil {
+		t.Error("LoadSnapshot(missing) error = nil, want error")
+	}
+}
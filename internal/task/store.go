@@ -0,0 +1,287 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// TaskStore is where a Manager loads tasks from and writes them back to.
+// The Manager keeps what List returns in its in-memory cache, and all of its
+// query methods (List, GetByWorkstream, and so on) read only the cache. The
+// default store is a FileStore over the tasks directory; set Config.Store to
+// source tasks from somewhere else, such as an issue tracker.
+type TaskStore interface {
+	// List loads every task. Tasks that fail to load are returned as
+	// ParseErrors instead of failing the whole list; error is for the store
+	// itself being unreadable.
+	List() ([]*Task, []*ParseError, error)
+
+	// Get reloads a single task from the store, bypassing any cache, so a
+	// claim can see changes made by other processes since the last List
+	Get(id string) (*Task, error)
+
+	// Write saves a task. A read-only store returns an error.
+	Write(t *Task) error
+
+	// Watch calls onChange whenever tasks may have changed outside the
+	// Manager, until ctx is done. It returns nil when ctx is done.
+	Watch(ctx context.Context, onChange func()) error
+}
+
+// DefaultWatchInterval is how often a FileStore checks its directory for
+// changes while watching.
+const DefaultWatchInterval = time.Second
+
+// FileStore stores tasks as markdown files with YAML front matter in a tasks
+// directory. Subdirectories containing a README.md are project folders, and
+// their tasks get the folder name as Task.Project. Extra directories, such as
+// a folder of tasks shared between repositories, are read the same way, and
+// each task is written back to the directory it came from.
+type FileStore struct {
+	dir   string
+	extra []string
+
+	// WatchInterval is how often Watch checks for changes. Defaults to
+	// DefaultWatchInterval if 0.
+	WatchInterval time.Duration
+
+	mu    sync.Mutex
+	paths map[string]string // Task ID -> file, from the last List or Write
+}
+
+// NewFileStore returns a FileStore for the tasks directory dir, also reading
+// tasks from any extra directories.
+func NewFileStore(dir string, extra ...string) *FileStore {
+	return &FileStore{dir: dir, extra: extra, paths: make(map[string]string)}
+}
+
+// Dir returns the tasks directory, where new tasks are created.
+func (s *FileStore) Dir() string {
+	return s.dir
+}
+
+// Dirs returns the tasks directory followed by the extra directories.
+func (s *FileStore) Dirs() []string {
+	return append([]string{s.dir}, s.extra...)
+}
+
+// List implements TaskStore. A missing tasks directory has no tasks. A task
+// ID may only be used once across all of the directories; later files with
+// the same ID are reported as ParseErrors and skipped.
+func (s *FileStore) List() ([]*Task, []*ParseError, error) {
+	files, parseErrors, err := s.files()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tasks := make([]*Task, 0, len(files))
+	paths := make(map[string]string, len(files))
+
+	for _, f := range files {
+		task, err := ParseFile(f.path)
+		if err != nil {
+			// Record the error but continue loading
+			parseErrors = append(parseErrors, &ParseError{Path: f.name, FilePath: f.path, Err: err})
+			continue
+		}
+		if first, ok := paths[task.ID]; ok {
+			parseErrors = append(parseErrors, &ParseError{
+				Path:     f.name,
+				FilePath: f.path,
+				Err:      fmt.Errorf("duplicate task ID %q, already used by %s", task.ID, first),
+			})
+			continue
+		}
+		task.Project = f.project
+		paths[task.ID] = f.path
+		tasks = append(tasks, task)
+	}
+
+	s.mu.Lock()
+	s.paths = paths
+	s.mu.Unlock()
+
+	return tasks, parseErrors, nil
+}
+
+// Get implements TaskStore by rereading the task's file.
+func (s *FileStore) Get(id string) (*Task, error) {
+	s.mu.Lock()
+	path, ok := s.paths[id]
+	s.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("task %q not found", id)
+	}
+
+	task, err := ParseFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if dir := filepath.Dir(path); !s.isRoot(dir) {
+		task.Project = filepath.Base(dir)
+	}
+	return task, nil
+}
+
+// isRoot reports whether dir is one of the store's directories, rather than
+// a project folder inside one.
+func (s *FileStore) isRoot(dir string) bool {
+	for _, root := range s.Dirs() {
+		if filepath.Clean(root) == filepath.Clean(dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// Write implements TaskStore by rewriting the task's file at Task.FilePath.
+func (s *FileStore) Write(t *Task) error {
+	if err := WriteFile(t); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.paths[t.ID] = t.FilePath
+	s.mu.Unlock()
+	return nil
+}
+
+// Watch implements TaskStore by polling the task files' sizes and
+// modification times every WatchInterval.
+func (s *FileStore) Watch(ctx context.Context, onChange func()) error {
+	interval := s.WatchInterval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := s.fingerprint()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if current := s.fingerprint(); current != last {
+				last = current
+				onChange()
+			}
+		}
+	}
+}
+
+// taskFile is a task file found in the tasks directory.
+type taskFile struct {
+	path    string
+	name    string // Relative to the tasks directory, or full in an extra one, for errors
+	project string
+}
+
+// files lists the task files in the tasks directory, then each extra
+// directory, and their project folders.
+func (s *FileStore) files() ([]taskFile, []*ParseError, error) {
+	files, parseErrors, err := dirFiles(s.dir, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, dir := range s.extra {
+		// Name files in extra directories by full path, so errors say which
+		// directory they're in
+		extraFiles, extraErrors, err := dirFiles(dir, dir)
+		if err != nil {
+			return nil, nil, err
+		}
+		files = append(files, extraFiles...)
+		parseErrors = append(parseErrors, extraErrors...)
+	}
+	return files, parseErrors, nil
+}
+
+// dirFiles lists the task files in one tasks directory and its project
+// folders, naming them relative to prefix. README.md files are project
+// metadata, not tasks, and are skipped. A project folder that can't be read
+// is reported as a ParseError.
+func dirFiles(dir, prefix string) ([]taskFile, []*ParseError, error) {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil, nil, nil // No tasks directory - not an error
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read tasks directory: %w", err)
+	}
+
+	var files []taskFile
+	var parseErrors []*ParseError
+	for _, entry := range entries {
+		if entry.IsDir() {
+			// Only folders with a README.md are projects
+			projectDir := filepath.Join(dir, entry.Name())
+			if _, err := os.Stat(filepath.Join(projectDir, "README.md")); err == nil {
+				projectFiles, err := projectFiles(projectDir, entry.Name(), prefix)
+				if err != nil {
+					parseErrors = append(parseErrors, &ParseError{Path: filepath.Join(prefix, entry.Name()), Err: err})
+				}
+				files = append(files, projectFiles...)
+			}
+			continue
+		}
+
+		if isTaskFile(entry) {
+			files = append(files, taskFile{path: filepath.Join(dir, entry.Name()), name: filepath.Join(prefix, entry.Name())})
+		}
+	}
+	return files, parseErrors, nil
+}
+
+// projectFiles lists the task files in a project folder.
+func projectFiles(dir, project, prefix string) ([]taskFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read project directory: %w", err)
+	}
+
+	var files []taskFile
+	for _, entry := range entries {
+		if isTaskFile(entry) {
+			files = append(files, taskFile{
+				path:    filepath.Join(dir, entry.Name()),
+				name:    filepath.Join(prefix, project, entry.Name()),
+				project: project,
+			})
+		}
+	}
+	return files, nil
+}
+
+// isTaskFile reports whether a directory entry is a task file.
+func isTaskFile(entry os.DirEntry) bool {
+	return !entry.IsDir() && filepath.Ext(entry.Name()) == ".md" && entry.Name() != "README.md"
+}
+
+// fingerprint summarizes the task files' names, sizes, and modification
+// times, so Watch can tell when any of them changed.
+func (s *FileStore) fingerprint() string {
+	files, _, err := s.files()
+	if err != nil {
+		return "error: " + err.Error()
+	}
+
+	entries := make([]string, 0, len(files))
+	for _, f := range files {
+		info, err := os.Stat(f.path)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, fmt.Sprintf("%s %d %d", f.path, info.Size(), info.ModTime().UnixNano()))
+	}
+	sort.Strings(entries)
+	return fmt.Sprint(entries)
+}
@@ -0,0 +1,269 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFileStore_List(t *testing.T) {
+	dir := t.TempDir()
+	writeTestTask(t, dir, "ROOT-1", "")
+	_ = os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Tasks\n"), 0600)
+	_ = os.WriteFile(filepath.Join(dir, "broken.md"), []byte("---\ntitle: [\n---\n"), 0600)
+
+	projectDir := filepath.Join(dir, "auth")
+	_ = os.MkdirAll(projectDir, 0750)
+	_ = os.WriteFile(filepath.Join(projectDir, "README.md"), []byte("# Auth\n"), 0600)
+	writeTestTask(t, projectDir, "AUTH-1", "")
+
+	// Folders without a README.md aren't projects
+	writeTestTask(t, filepath.Join(dir, "notes"), "NOTE-1", "")
+
+	store := NewFileStore(dir)
+	tasks, parseErrors, err := store.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	projects := make(map[string]string)
+	for _, task := range tasks {
+		projects[task.ID] = task.Project
+	}
+	if len(projects) != 2 || projects["ROOT-1"] != "" || projects["AUTH-1"] != "auth" {
+		t.Errorf("List() tasks = %v, want ROOT-1 and AUTH-1 (project auth)", projects)
+	}
+
+	if len(parseErrors) != 1 || parseErrors[0].Path != "broken.md" || parseErrors[0].FilePath != filepath.Join(dir, "broken.md") {
+		t.Errorf("List() parse errors = %v, want broken.md", parseErrors)
+	}
+}
+
+func TestFileStore_List_MissingDir(t *testing.T) {
+	tasks, parseErrors, err := NewFileStore(filepath.Join(t.TempDir(), "missing")).List()
+	if err != nil || len(tasks) != 0 || len(parseErrors) != 0 {
+		t.Errorf("List() = %v, %v, %v, want nothing for a missing directory", tasks, parseErrors, err)
+	}
+}
+
+func TestFileStore_List_ExtraDirs(t *testing.T) {
+	dir := t.TempDir()
+	shared := t.TempDir()
+	writeTestTask(t, dir, "ROOT-1", "")
+	writeTestTask(t, shared, "SHARED-1", "")
+
+	projectDir := filepath.Join(shared, "common")
+	_ = os.MkdirAll(projectDir, 0750)
+	_ = os.WriteFile(filepath.Join(projectDir, "README.md"), []byte("# Common\n"), 0600)
+	writeTestTask(t, projectDir, "COMMON-1", "")
+
+	store := NewFileStore(dir, shared, filepath.Join(t.TempDir(), "missing"))
+	tasks, parseErrors, err := store.List()
+	if err != nil || len(parseErrors) != 0 {
+		t.Fatalf("List() error = %v, parse errors = %v", err, parseErrors)
+	}
+
+	paths := make(map[string]string)
+	for _, task := range tasks {
+		paths[task.ID] = task.FilePath
+	}
+	want := map[string]string{
+		"ROOT-1":   filepath.Join(dir, "ROOT-1.md"),
+		"SHARED-1": filepath.Join(shared, "SHARED-1.md"),
+		"COMMON-1": filepath.Join(projectDir, "COMMON-1.md"),
+	}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("List() task files = %v, want %v", paths, want)
+	}
+
+	got, err := store.Get("SHARED-1")
+	if err != nil || got.Project != "" {
+		t.Errorf("Get(SHARED-1) = project %q, %v; want no project", got.Project, err)
+	}
+	got, err = store.Get("COMMON-1")
+	if err != nil || got.Project != "common" {
+		t.Errorf("Get(COMMON-1) = project %q, %v; want project common", got.Project, err)
+	}
+}
+
+func TestFileStore_List_DuplicateIDs(t *testing.T) {
+	tests := []struct {
+		name  string
+		extra bool
+	}{
+		{"same directory", false},
+		{"across directories", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeTestTask(t, dir, "TASK-1", "")
+
+			dupDir := dir
+			var extra []string
+			if tt.extra {
+				dupDir = t.TempDir()
+				extra = []string{dupDir}
+			}
+			content := "---\nid: TASK-1\ntitle: Copy\nstatus: pending\n---\n"
+			_ = os.WriteFile(filepath.Join(dupDir, "zz-copy.md"), []byte(content), 0600)
+
+			tasks, parseErrors, err := NewFileStore(dir, extra...).List()
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if len(tasks) != 1 || tasks[0].FilePath != filepath.Join(dir, "TASK-1.md") {
+				t.Errorf("List() tasks = %v, want only the first TASK-1", tasks)
+			}
+			if len(parseErrors) != 1 || parseErrors[0].FilePath != filepath.Join(dupDir, "zz-copy.md") ||
+				!strings.Contains(parseErrors[0].Error(), "duplicate task ID") {
+				t.Errorf("List() parse errors = %v, want the copy reported as a duplicate", parseErrors)
+			}
+		})
+	}
+}
+
+func TestFileStore_GetRereadsFile(t *testing.T) {
+	dir := t.TempDir()
+	projectDir := filepath.Join(dir, "auth")
+	_ = os.MkdirAll(projectDir, 0750)
+	_ = os.WriteFile(filepath.Join(projectDir, "README.md"), []byte("# Auth\n"), 0600)
+	writeTestTask(t, projectDir, "AUTH-1", "")
+
+	store := NewFileStore(dir)
+	tasks, _, _ := store.List()
+
+	// Another process takes the task after the list
+	tasks[0].AssignedTo = "other-agent"
+	if err := WriteFile(tasks[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := store.Get("AUTH-1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.AssignedTo != "other-agent" || got.Project != "auth" {
+		t.Errorf("Get() = assigned %q, project %q; want the file's current content in project auth", got.AssignedTo, got.Project)
+	}
+
+	if _, err := store.Get("MISSING"); err == nil {
+		t.Error("Get() of an unknown task expected an error")
+	}
+}
+
+func TestFileStore_Watch(t *testing.T) {
+	dir := t.TempDir()
+	writeTestTask(t, dir, "TASK-001", "")
+
+	store := NewFileStore(dir)
+	store.WatchInterval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	changed := make(chan struct{}, 1)
+	done := make(chan error)
+	go func() {
+		done <- store.Watch(ctx, func() {
+			select {
+			case changed <- struct{}{}:
+			default:
+			}
+		})
+	}()
+
+	// Let the watch take its first fingerprint, then add a task
+	time.Sleep(30 * time.Millisecond)
+	writeTestTask(t, dir, "TASK-002", "")
+
+	select {
+	case <-changed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Watch() didn't report the new task file")
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Watch() error = %v, want nil after cancel", err)
+	}
+}
+
+// memStore is an in-memory TaskStore, standing in for a non-file store.
+type memStore struct {
+	mu     sync.Mutex
+	tasks  map[string]Task
+	writes []string
+}
+
+func (s *memStore) List() ([]*Task, []*ParseError, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tasks := make([]*Task, 0, len(s.tasks))
+	for _, t := range s.tasks {
+		t := t
+		tasks = append(tasks, &t)
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	return tasks, nil, nil
+}
+
+func (s *memStore) Get(id string) (*Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.tasks[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return &t, nil
+}
+
+func (s *memStore) Write(t *Task) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tasks[t.ID] = *t
+	s.writes = append(s.writes, t.ID)
+	return nil
+}
+
+func (s *memStore) Watch(ctx context.Context, _ func()) error {
+	<-ctx.Done()
+	return nil
+}
+
+func TestManager_CustomStore(t *testing.T) {
+	store := &memStore{tasks: map[string]Task{
+		"GH-1": {ID: "GH-1", Title: "Login", Workstream: "backend", Status: StatusPending},
+		"GH-2": {ID: "GH-2", Title: "Signup", Workstream: "frontend", Status: StatusPending},
+	}}
+	mgr := NewManager(&Config{ProjectRoot: t.TempDir(), Store: store})
+
+	tasks, err := mgr.Scan()
+	if err != nil || len(tasks) != 2 {
+		t.Fatalf("Scan() = %d tasks, %v; want 2", len(tasks), err)
+	}
+	if got := mgr.GetByWorkstream("backend"); len(got) != 1 || got[0].ID != "GH-1" {
+		t.Errorf("GetByWorkstream(backend) = %v, want GH-1", got)
+	}
+
+	if err := mgr.UpdateStatus("GH-2", StatusComplete); err != nil {
+		t.Fatalf("UpdateStatus() error = %v", err)
+	}
+	if store.tasks["GH-2"].Status != StatusComplete {
+		t.Errorf("store status = %q, want the update written to the store", store.tasks["GH-2"].Status)
+	}
+
+	claimed, err := mgr.Claim("GH-1", "agent-1")
+	if err != nil || !claimed {
+		t.Fatalf("Claim() = %v, %v; want claimed", claimed, err)
+	}
+	if store.tasks["GH-1"].AssignedTo != "agent-1" {
+		t.Errorf("store assignee = %q, want agent-1", store.tasks["GH-1"].AssignedTo)
+	}
+}
@@ -0,0 +1,159 @@
+package task
+
+import (
+	"sort"
+	"sync"
+)
+
+// subscriberBuffer is the number of pending changes buffered per subscriber
+// before further changes are coalesced.
+const subscriberBuffer = 16
+
+// TaskChange describes tasks that were added, updated, or removed.
+// A single change may cover several tasks when notifications are coalesced.
+type TaskChange struct {
+	Added   []string
+	Updated []string
+	Removed []string
+}
+
+// IsEmpty reports whether the change covers no tasks.
+func (c TaskChange) IsEmpty() bool {
+	return len(c.Added) == 0 && len(c.Updated) == 0 && len(c.Removed) == 0
+}
+
+// merge combines two changes, preserving order and dropping duplicates.
+func (c TaskChange) merge(other TaskChange) TaskChange {
+	return TaskChange{
+		Added:   appendUnique(c.Added, other.Added),
+		Updated: appendUnique(c.Updated, other.Updated),
+		Removed: appendUnique(c.Removed, other.Removed),
+	}
+}
+
+func appendUnique(dst, src []string) []string {
+	for _, id := range src {
+		found := false
+		for _, existing := range dst {
+			if existing == id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			dst = append(dst, id)
+		}
+	}
+	return dst
+}
+
+// subscribers tracks change notification channels for a Manager.
+type subscribers struct {
+	mu    sync.Mutex
+	next  int
+	chans map[int]chan TaskChange
+}
+
+// Subscribe returns a channel of task changes and a function to unsubscribe.
+// Changes are emitted whenever the manager mutates a task or a Scan finds
+// added, removed, or modified tasks. The channel is buffered; if a subscriber
+// falls behind, pending changes are coalesced rather than blocking the
+// manager. The channel is closed on unsubscribe.
+func (m *Manager) Subscribe() (<-chan TaskChange, func()) {
+	m.subs.mu.Lock()
+	defer m.subs.mu.Unlock()
+
+	if m.subs.chans == nil {
+		m.subs.chans = make(map[int]chan TaskChange)
+	}
+
+	id := m.subs.next
+	m.subs.next++
+	ch := make(chan TaskChange, subscriberBuffer)
+	m.subs.chans[id] = ch
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			m.subs.mu.Lock()
+			defer m.subs.mu.Unlock()
+			delete(m.subs.chans, id)
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+// notify delivers a change to all subscribers without blocking.
+func (m *Manager) notify(change TaskChange) {
+	if change.IsEmpty() {
+		return
+	}
+
+	m.subs.mu.Lock()
+	defer m.subs.mu.Unlock()
+
+	for _, ch := range m.subs.chans {
+		select {
+		case ch <- change:
+			continue
+		default:
+		}
+
+		// Buffer full: fold the oldest pending change into this one.
+		// Only notify sends, so a slot is free after the receive.
+		merged := change
+		select {
+		case oldest := <-ch:
+			merged = oldest.merge(change)
+		default:
+		}
+		select {
+		case ch <- merged:
+		default:
+		}
+	}
+}
+
+// notifyUpdated emits an update for the given task IDs.
+func (m *Manager) notifyUpdated(ids ...string) {
+	m.notify(TaskChange{Updated: ids})
+}
+
+// diffTasks compares two task caches and describes what changed between them.
+// Tasks are considered updated when their serialized form differs.
+func diffTasks(before, after map[string]*Task) TaskChange {
+	var change TaskChange
+
+	for id, t := range after {
+		prev, ok := before[id]
+		if !ok {
+			change.Added = append(change.Added, id)
+			continue
+		}
+		if taskModified(prev, t) {
+			change.Updated = append(change.Updated, id)
+		}
+	}
+	for id := range before {
+		if _, ok := after[id]; !ok {
+			change.Removed = append(change.Removed, id)
+		}
+	}
+
+	sort.Strings(change.Added)
+	sort.Strings(change.Updated)
+	sort.Strings(change.Removed)
+	return change
+}
+
+// taskModified reports whether two versions of a task differ on disk.
+func taskModified(a, b *Task) bool {
+	if a.FilePath != b.FilePath || a.Project != b.Project {
+		return true
+	}
+	sa, errA := Serialize(a)
+	sb, errB := Serialize(b)
+	return errA != nil || errB != nil || sa != sb
+}
@@ -0,0 +1,115 @@
+package task
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestManager_Subscribe_Mutations(t *testing.T) {
+	dir := t.TempDir()
+	tasksDir := filepath.Join(dir, "tasks")
+	writeTestTask(t, tasksDir, "TASK-001", "")
+
+	mgr := NewManager(&Config{ProjectRoot: dir})
+	_, _ = mgr.Scan()
+
+	changes, unsubscribe := mgr.Subscribe()
+	defer unsubscribe()
+
+	if err := mgr.Assign("TASK-001", "agent-1"); err != nil {
+		t.Fatalf("Assign() error: %v", err)
+	}
+	if err := mgr.UpdateStatus("TASK-001", StatusInProgress); err != nil {
+		t.Fatalf("UpdateStatus() error: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		change := <-changes
+		if !reflect.DeepEqual(change.Updated, []string{"TASK-001"}) {
+			t.Errorf("change %d Updated = %v, want [TASK-001]", i, change.Updated)
+		}
+	}
+
+	// Failed mutations don't notify
+	_ = mgr.UpdateStatus("TASK-999", StatusComplete)
+	select {
+	case change := <-changes:
+		t.Errorf("unexpected change for failed mutation: %+v", change)
+	default:
+	}
+}
+
+func TestManager_Subscribe_Scan(t *testing.T) {
+	dir := t.TempDir()
+	tasksDir := filepath.Join(dir, "tasks")
+	writeTestTask(t, tasksDir, "TASK-001", "")
+	writeTestTask(t, tasksDir, "TASK-002", "")
+
+	mgr := NewManager(&Config{ProjectRoot: dir})
+	_, _ = mgr.Scan()
+
+	changes, unsubscribe := mgr.Subscribe()
+	defer unsubscribe()
+
+	// Unchanged rescan emits nothing
+	_, _ = mgr.Scan()
+	select {
+	case change := <-changes:
+		t.Fatalf("unexpected change for unchanged scan: %+v", change)
+	default:
+	}
+
+	_ = os.Remove(filepath.Join(tasksDir, "TASK-001.md"))
+	writeTestTask(t, tasksDir, "TASK-003", "")
+	_ = os.WriteFile(filepath.Join(tasksDir, "TASK-002.md"),
+		[]byte("---\nid: TASK-002\ntitle: Test TASK-002\nstatus: complete\n---\n\nContent\n"), 0600)
+	_, _ = mgr.Scan()
+
+	want := TaskChange{
+		Added:   []string{"TASK-003"},
+		Updated: []string{"TASK-002"},
+		Removed: []string{"TASK-001"},
+	}
+	if got := <-changes; !reflect.DeepEqual(got, want) {
+		t.Errorf("Scan() change = %+v, want %+v", got, want)
+	}
+}
+
+func TestManager_Subscribe_Coalesces(t *testing.T) {
+	mgr := NewManager(&Config{ProjectRoot: t.TempDir()})
+
+	changes, unsubscribe := mgr.Subscribe()
+	defer unsubscribe()
+
+	// Overflow the buffer without reading
+	for i := 0; i < subscriberBuffer+5; i++ {
+		mgr.notifyUpdated(string(rune('A' + i)))
+	}
+
+	seen := make(map[string]bool)
+	for len(changes) > 0 {
+		for _, id := range (<-changes).Updated {
+			seen[id] = true
+		}
+	}
+	if len(seen) != subscriberBuffer+5 {
+		t.Errorf("saw %d distinct updates, want %d", len(seen), subscriberBuffer+5)
+	}
+}
+
+func TestManager_Subscribe_Unsubscribe(t *testing.T) {
+	mgr := NewManager(&Config{ProjectRoot: t.TempDir()})
+
+	changes, unsubscribe := mgr.Subscribe()
+	unsubscribe()
+	unsubscribe() // Safe to call twice
+
+	if _, ok := <-changes; ok {
+		t.Error("channel should be closed after unsubscribe")
+	}
+
+	// Notifying with no subscribers must not panic
+	mgr.notifyUpdated("TASK-001")
+}
@@ -17,26 +17,74 @@ type Task struct {
 	ID         string            `yaml:"id"`
 	Title      string            `yaml:"title"`
 	Workstream string            `yaml:"workstream,omitempty"` // Groups related tasks for sequential execution
+	Group      string            `yaml:"group,omitempty"`      // Tasks that succeed or fail as a unit
 	Priority   Priority          `yaml:"priority"`
 	Status     Status            `yaml:"status"`
 	DependsOn  []string          `yaml:"depends_on"`
 	AssignedTo string            `yaml:"assigned_to,omitempty"`
+	LastAgent  string            `yaml:"last_agent,omitempty"` // Most recent assignee, kept after unassignment
 	Completion *CompletionConfig `yaml:"completion,omitempty"`
+	Ralph      *RalphConfig      `yaml:"ralph,omitempty"`   // How the task loops in Ralph mode
+	Expects    []string          `yaml:"expects,omitempty"` // File globs a run must produce in the worktree
 	Tags       []string          `yaml:"tags,omitempty"`
+	Estimate   string            `yaml:"estimate,omitempty"` // Rough effort estimate (e.g., "30m", "2h")
+
+	// Tool overrides for this task only, taking precedence over the
+	// workstream's and the defaults. Allowed tools replace the inherited
+	// list; a tool allowed here is also removed from inherited disallowed tools.
+	AllowedTools    []string `yaml:"allowed_tools,omitempty"`
+	DisallowedTools []string `yaml:"disallowed_tools,omitempty"`
 
 	// Derived fields (not in YAML)
 	FilePath    string     `yaml:"-"`
 	Content     string     `yaml:"-"` // Markdown body (after front matter)
 	Project     string     `yaml:"-"` // Project folder name (derived from directory)
+	ContentHash string     `yaml:"-"` // SHA-256 of Content, for detecting edits mid-run
 	CompletedAt *time.Time `yaml:"completed_at,omitempty"`
 	StartedAt   *time.Time `yaml:"started_at,omitempty"`
 
 	// Error and log tracking
-	FailureMessage string `yaml:"failure_message,omitempty"` // Human-readable error
-	LogFilePath    string `yaml:"log_file,omitempty"`        // Path to execution log
-	ValidationLog  string `yaml:"validation_log,omitempty"`  // Validation output path
+	FailureMessage  string          `yaml:"failure_message,omitempty"`  // Human-readable error
+	FailureCategory FailureCategory `yaml:"failure_category,omitempty"` // Classified cause, for triage
+	LogFilePath     string          `yaml:"log_file,omitempty"`         // Path to execution log
+	ValidationLog   string          `yaml:"validation_log,omitempty"`   // Validation output path
+
+	// Progress tracking
+	Checkpoints     []string `yaml:"checkpoints,omitempty"`      // Steps the agent reported as done
+	ProgressPercent *int     `yaml:"progress_percent,omitempty"` // Last reported progress (nil = unknown)
+
+	// Retry policy
+	RetryPrompt string `yaml:"retry_prompt,omitempty"` // Guidance appended when retrying after failure
+	RetryCount  int    `yaml:"retry_count,omitempty"`  // Retries attempted so far
+	FailedAgent string `yaml:"failed_agent,omitempty"` // Agent the last failed attempt ran on, preferred for the retry
+
+	// BlockedReason is set when the task was blocked by hand with
+	// Manager.Block, e.g. while waiting on an outside decision. The task
+	// stays blocked until Unblock, whatever its dependencies.
+	BlockedReason string `yaml:"blocked_reason,omitempty"`
+
+	// AssignmentHistory lists each time an agent was assigned the task or
+	// released it, oldest first, capped at Config.MaxAssignmentHistory entries
+	AssignmentHistory []AssignmentRecord `yaml:"assignment_history,omitempty"`
+}
+
+// AssignmentRecord is one entry in a task's assignment history.
+type AssignmentRecord struct {
+	Agent string    `yaml:"agent"`
+	At    time.Time `yaml:"at"`
+
+	// Outcome is "assigned" when the agent took the task. When it let go,
+	// Outcome is the status the task ended in (complete, failed, or review),
+	// or "released" if the task went back to be assigned again.
+	Outcome string `yaml:"outcome"`
 }
 
+// Assignment outcomes that aren't task statuses.
+const (
+	OutcomeAssigned = "assigned"
+	OutcomeReleased = "released"
+)
+
 // GetWorkstream returns the workstream identifier for this task.
 // If not explicitly set, returns the task ID (single-task workstream).
 func (t *Task) GetWorkstream() string {
@@ -46,6 +94,28 @@ func (t *Task) GetWorkstream() string {
 	return t.ID
 }
 
+// GetEstimate returns the parsed effort estimate, or def if none is set
+// or the value cannot be parsed.
+func (t *Task) GetEstimate(def time.Duration) time.Duration {
+	if t.Estimate == "" {
+		return def
+	}
+	d, err := time.ParseDuration(t.Estimate)
+	if err != nil || d <= 0 {
+		return def
+	}
+	return d
+}
+
+// Duration returns how long the task took, from when it first started to
+// when it finished. It is zero if the task hasn't both started and finished.
+func (t *Task) Duration() time.Duration {
+	if t.StartedAt == nil || t.CompletedAt == nil || !t.CompletedAt.After(*t.StartedAt) {
+		return 0
+	}
+	return t.CompletedAt.Sub(*t.StartedAt)
+}
+
 // Priority levels for tasks.
 // Tasks are ordered by priority with critical being highest.
 type Priority string
@@ -81,23 +151,118 @@ const (
 	StatusBlocked Status = "blocked"
 )
 
+// FailureCategory classifies why a task failed.
+type FailureCategory string
+
+const (
+	// FailureContainer - Docker daemon or container problem
+	FailureContainer FailureCategory = "container"
+	// FailureClaudeNotFound - Claude Code is not installed in the container
+	FailureClaudeNotFound FailureCategory = "claude_not_found"
+	// FailureVerify - Verify command did not pass
+	FailureVerify FailureCategory = "verify_failed"
+	// FailureTimeout - Execution exceeded its deadline
+	FailureTimeout FailureCategory = "timeout"
+	// FailureMaxIterations - Ralph mode ran out of iterations
+	FailureMaxIterations FailureCategory = "max_iterations"
+	// FailureMaxTurns - Run stopped at its turn limit before finishing
+	FailureMaxTurns FailureCategory = "max_turns"
+	// FailureNoSignal - Run finished without printing the completion signal
+	FailureNoSignal FailureCategory = "no_signal"
+	// FailureMissingFiles - Run finished without producing its expected files
+	FailureMissingFiles FailureCategory = "missing_files"
+	// FailureOther - Anything not covered above
+	FailureOther FailureCategory = "other"
+)
+
 // CompletionConfig defines how to determine task completion (Ralph-style).
-// Either Verify or Signal (or both) can be specified for autonomous validation.
+// Any combination of Verify, Signal, and HTTP can be specified for autonomous
+// validation.
 type CompletionConfig struct {
-	// Verify is a command that must exit 0 for completion
+	// Verify is a command that must exit 0 for completion. If its last line
+	// of output is JSON with a boolean "complete" field, that must be true too.
 	Verify string `yaml:"verify,omitempty"`
 
 	// Signal is a string to detect in agent output
 	Signal string `yaml:"signal,omitempty"`
 
+	// HTTP is an endpoint that must return the expected status for completion
+	HTTP *HTTPCompletion `yaml:"http,omitempty"`
+
 	// MaxIterations for Ralph mode (default: 30)
 	MaxIterations int `yaml:"max_iterations,omitempty"`
 }
 
+// HTTPCompletion checks an HTTP endpoint to determine task completion.
+type HTTPCompletion struct {
+	// URL is requested with GET from the host running tanuki
+	URL string `yaml:"url"`
+
+	// Status is the expected response status code (default: 200)
+	Status int `yaml:"status,omitempty"`
+}
+
+// RalphConfig sets how a task loops in Ralph mode, so tasks can loop
+// differently without juggling run flags. Its signal and verify take
+// precedence over the completion block's while looping.
+type RalphConfig struct {
+	// MaxIterations stops the loop after this many iterations
+	MaxIterations int `yaml:"max_iterations,omitempty"`
+
+	// Signal is a string to detect in agent output
+	Signal string `yaml:"signal,omitempty"`
+
+	// Verify is a command that must exit 0 for completion
+	Verify string `yaml:"verify,omitempty"`
+
+	// Cooldown is the pause between iterations (e.g., "10s")
+	Cooldown string `yaml:"cooldown,omitempty"`
+}
+
+// GetCooldown returns the parsed cooldown, or def if none is set or the
+// value cannot be parsed.
+func (r RalphConfig) GetCooldown(def time.Duration) time.Duration {
+	if r.Cooldown == "" {
+		return def
+	}
+	d, err := time.ParseDuration(r.Cooldown)
+	if err != nil || d < 0 {
+		return def
+	}
+	return d
+}
+
+// RalphLoop returns the task's Ralph mode settings: its ralph block, with
+// signal, verify, and max iterations falling back to the completion block.
+// Settings neither sets are left zero for the caller's defaults.
+func (t *Task) RalphLoop() RalphConfig {
+	var loop RalphConfig
+	if t.Ralph != nil {
+		loop = *t.Ralph
+	}
+	if c := t.Completion; c != nil {
+		if loop.MaxIterations == 0 {
+			loop.MaxIterations = c.MaxIterations
+		}
+		if loop.Signal == "" {
+			loop.Signal = c.Signal
+		}
+		if loop.Verify == "" {
+			loop.Verify = c.Verify
+		}
+	}
+	return loop
+}
+
 // IsRalphMode returns true if task should use Ralph-style iteration.
 // Ralph mode continuously runs until completion criteria are met.
 func (t *Task) IsRalphMode() bool {
-	return t.Completion != nil && (t.Completion.Verify != "" || t.Completion.Signal != "")
+	return t.Ralph != nil || (t.Completion != nil && (t.Completion.Verify != "" || t.Completion.Signal != "" || t.Completion.hasHTTP()))
+}
+
+// hasHTTP reports whether an HTTP completion check is configured.
+func (c *CompletionConfig) hasHTTP() bool {
+	return c != nil && c.HTTP != nil && c.HTTP.URL != ""
 }
 
 // GetMaxIterations returns max iterations with default fallback.
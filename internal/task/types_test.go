@@ -2,6 +2,7 @@ package task
 
 import (
 	"testing"
+	"time"
 )
 
 func TestPriority_IsValid(t *testing.T) {
@@ -124,6 +125,13 @@ func TestTask_IsRalphMode(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "with ralph block",
+			task: &Task{
+				Ralph: &RalphConfig{MaxIterations: 5},
+			},
+			want: true,
+		},
 		{
 			name: "with both",
 			task: &Task{
@@ -199,6 +207,105 @@ func TestCompletionConfig_GetMaxIterations(t *testing.T) {
 	}
 }
 
+func TestTask_GetEstimate(t *testing.T) {
+	def := 30 * time.Minute
+
+	tests := []struct {
+		name     string
+		estimate string
+		want     time.Duration
+	}{
+		{"empty uses default", "", def},
+		{"minutes", "45m", 45 * time.Minute},
+		{"hours", "2h", 2 * time.Hour},
+		{"invalid uses default", "soon", def},
+		{"negative uses default", "-1h", def},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{Estimate: tt.estimate}
+			if got := task.GetEstimate(def); got != tt.want {
+				t.Errorf("Task.GetEstimate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTask_RalphLoop(t *testing.T) {
+	tests := []struct {
+		name string
+		task *Task
+		want RalphConfig
+	}{
+		{"none", &Task{}, RalphConfig{}},
+		{
+			"completion only",
+			&Task{Completion: &CompletionConfig{Signal: "DONE", Verify: "make test", MaxIterations: 10}},
+			RalphConfig{Signal: "DONE", Verify: "make test", MaxIterations: 10},
+		},
+		{
+			"ralph overrides completion",
+			&Task{
+				Completion: &CompletionConfig{Signal: "DONE", Verify: "make test", MaxIterations: 10},
+				Ralph:      &RalphConfig{Signal: "SHIPPED", Cooldown: "10s"},
+			},
+			RalphConfig{Signal: "SHIPPED", Verify: "make test", MaxIterations: 10, Cooldown: "10s"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.task.RalphLoop(); got != tt.want {
+				t.Errorf("Task.RalphLoop() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRalphConfig_GetCooldown(t *testing.T) {
+	def := 5 * time.Second
+	for cooldown, want := range map[string]time.Duration{
+		"":      def,
+		"10s":   10 * time.Second,
+		"0s":    0,
+		"later": def,
+	} {
+		if got := (RalphConfig{Cooldown: cooldown}).GetCooldown(def); got != want {
+			t.Errorf("GetCooldown(%q) = %v, want %v", cooldown, got, want)
+		}
+	}
+}
+
+func TestTask_Duration(t *testing.T) {
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		ts := start.Add(d)
+		return &ts
+	}
+
+	tests := []struct {
+		name      string
+		started   *time.Time
+		completed *time.Time
+		want      time.Duration
+	}{
+		{"finished", at(0), at(90 * time.Minute), 90 * time.Minute},
+		{"not started", nil, at(time.Hour), 0},
+		{"not finished", at(0), nil, 0},
+		{"finished before start", at(time.Hour), at(0), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{StartedAt: tt.started, CompletedAt: tt.completed}
+			if got := task.Duration(); got != tt.want {
+				t.Errorf("Task.Duration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestValidationError_Error(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -3,9 +3,12 @@ package task
 import (
 	"context"
 	"fmt"
+	"io"
 	"os/exec"
 	"strings"
 	"time"
+
+	"github.com/bkonkle/tanuki/internal/executor"
 )
 
 // Validator checks if task completion criteria are met.
@@ -36,6 +39,7 @@ type ValidationResult struct {
 	VerifyPassed  bool
 	VerifyOutput  string
 	VerifyError   error
+	HTTPPassed    bool
 	Status        Status // complete, review, failed, in_progress
 	Message       string
 	ValidationLog string // Path to validation log file
@@ -94,9 +98,21 @@ func (v *Validator) Validate(ctx context.Context, t *Task, agentOutput string) *
 		}
 	}
 
-	// Both criteria passed (or only one was specified)
+	// Check HTTP endpoint
+	if t.Completion.hasHTTP() {
+		passed, err := t.Completion.HTTP.verifier().Verify(executor.VerifyInput{Log: io.Discard})
+		result.HTTPPassed = passed
+		if !passed {
+			result.Status = StatusReview
+			result.Message = fmt.Sprintf("HTTP check failed: %v", err)
+			return result
+		}
+	}
+
+	// All specified criteria passed
 	if (t.Completion.Signal == "" || result.SignalFound) &&
-		(t.Completion.Verify == "" || result.VerifyPassed) {
+		(t.Completion.Verify == "" || result.VerifyPassed) &&
+		(!t.Completion.hasHTTP() || result.HTTPPassed) {
 		result.Status = StatusComplete
 		result.Message = "All completion criteria met"
 	}
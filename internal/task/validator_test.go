@@ -2,13 +2,15 @@ package task
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 )
 
 func TestValidator_ValidateWithSignal(t *testing.T) {
-	v := NewValidator("")
+	v := NewValidator(t.TempDir())
 
 	task := &Task{
 		ID:    "T1",
@@ -58,7 +60,7 @@ func TestValidator_ValidateWithSignal(t *testing.T) {
 }
 
 func TestValidator_ValidateWithVerify(t *testing.T) {
-	v := NewValidator("")
+	v := NewValidator(t.TempDir())
 	v.SetTimeout(5 * time.Second)
 
 	tests := []struct {
@@ -109,7 +111,7 @@ func TestValidator_ValidateWithVerify(t *testing.T) {
 }
 
 func TestValidator_ValidateWithBothCriteria(t *testing.T) {
-	v := NewValidator("")
+	v := NewValidator(t.TempDir())
 
 	task := &Task{
 		ID:    "T1",
@@ -148,7 +150,7 @@ func TestValidator_ValidateWithBothCriteria(t *testing.T) {
 }
 
 func TestValidator_NoCompletionCriteria(t *testing.T) {
-	v := NewValidator("")
+	v := NewValidator(t.TempDir())
 
 	task := &Task{
 		ID:    "T1",
@@ -163,7 +165,7 @@ func TestValidator_NoCompletionCriteria(t *testing.T) {
 }
 
 func TestValidator_VerifyTimeout(t *testing.T) {
-	v := NewValidator("")
+	v := NewValidator(t.TempDir())
 	v.SetTimeout(100 * time.Millisecond)
 
 	task := &Task{
@@ -206,3 +208,60 @@ func TestValidator_VerifyWithWorkdir(t *testing.T) {
 		t.Errorf("Verify should run in workdir: %s", result.VerifyOutput)
 	}
 }
+
+func TestValidator_ValidateWithHTTP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ready" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	v := NewValidator(t.TempDir())
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus Status
+	}{
+		{"endpoint ready", "/ready", StatusComplete},
+		{"endpoint down", "/down", StatusReview},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{
+				ID:         "T1",
+				Title:      "Test",
+				Completion: &CompletionConfig{HTTP: &HTTPCompletion{URL: srv.URL + tt.path}},
+			}
+
+			result := v.Validate(context.Background(), task, "")
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (%s)", result.Status, tt.wantStatus, result.Message)
+			}
+		})
+	}
+}
+
+func TestCompletionConfig_Verifiers(t *testing.T) {
+	c := &CompletionConfig{
+		Signal: "DONE",
+		Verify: "make test",
+		HTTP:   &HTTPCompletion{URL: "http://localhost:8080/health"},
+	}
+
+	var names []string
+	for _, v := range c.Verifiers() {
+		names = append(names, v.Name())
+	}
+	if got := strings.Join(names, ","); got != "signal,verify,http" {
+		t.Errorf("Verifiers() = %s, want signal,verify,http", got)
+	}
+
+	if verifiers := (*CompletionConfig)(nil).Verifiers(); verifiers != nil {
+		t.Errorf("nil config Verifiers() = %v, want nil", verifiers)
+	}
+}
@@ -0,0 +1,29 @@
+package task
+
+import "github.com/bkonkle/tanuki/internal/executor"
+
+// Verifiers returns the completion verifiers configured for the task, in the
+// order they are checked: signal, then verify command, then HTTP check.
+// Returns nil if no criteria are set.
+func (c *CompletionConfig) Verifiers() []executor.CompletionVerifier {
+	if c == nil {
+		return nil
+	}
+
+	var verifiers []executor.CompletionVerifier
+	if c.Signal != "" {
+		verifiers = append(verifiers, &executor.SignalVerifier{Signal: c.Signal})
+	}
+	if c.Verify != "" {
+		verifiers = append(verifiers, &executor.CommandVerifier{Command: c.Verify})
+	}
+	if c.hasHTTP() {
+		verifiers = append(verifiers, c.HTTP.verifier())
+	}
+	return verifiers
+}
+
+// verifier builds the executor verifier for an HTTP completion check.
+func (h *HTTPCompletion) verifier() *executor.HTTPVerifier {
+	return &executor.HTTPVerifier{URL: h.URL, Status: h.Status}
+}
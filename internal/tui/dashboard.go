@@ -2,6 +2,8 @@ package tui
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -30,23 +32,54 @@ type AgentInfo struct {
 	CurrentTask string
 	Branch      string
 	Uptime      time.Duration
+	DiskUsage   int64  // Worktree size in bytes; 0 if unknown
+	LogFilePath string // Saved output of the agent's last task, if any
+	Labels      map[string]string
+	Notes       string // Human notes on what the agent is for
 }
 
 // TaskInfo represents task information for display.
 type TaskInfo struct {
-	ID             string
-	Title          string
-	Status         string
-	Workstream     string
-	AssignedTo     string
-	Priority       string
-	FailureMessage string
-	LogFilePath    string
-	ValidationLog  string
-	DependsOn      []string
-	StartedAt      *time.Time
-	CompletedAt    *time.Time
-	ErrorPreview   string // Truncated error for list display
+	ID              string
+	Title           string
+	Status          string
+	Workstream      string
+	AssignedTo      string
+	Priority        string
+	FailureMessage  string
+	FailureCategory string
+	LogFilePath     string
+	ValidationLog   string
+	DependsOn       []string
+	BlockedReason   string // Why the task was blocked by hand, if it was
+	ProgressPercent *int   // Last reported progress (nil = unknown)
+	StartedAt       *time.Time
+	CompletedAt     *time.Time
+	ErrorPreview    string // Truncated error for list display
+}
+
+// OrchestratorInfo represents project orchestrator status and progress for display.
+type OrchestratorInfo struct {
+	Status     string // "stopped", "starting", "running", "stopping"
+	Total      int
+	Complete   int
+	InProgress int
+	Pending    int
+	Percentage float64
+	Uptime     time.Duration
+	LastError  string // Why the last run exited, if it failed
+}
+
+// ResourceInfo is the combined resource usage of running agents.
+type ResourceInfo struct {
+	Agents      int
+	MemoryBytes int64
+	CPUPercent  float64 // Sum across agents; above 100 means more than one core
+}
+
+// String renders the totals as "Agents: 5, Mem: 18.2 GB, CPU: 340%".
+func (r *ResourceInfo) String() string {
+	return fmt.Sprintf("Agents: %d, Mem: %s, CPU: %.0f%%", r.Agents, FormatBytes(r.MemoryBytes), r.CPUPercent)
 }
 
 // LogLine represents a log entry.
@@ -69,6 +102,19 @@ type TaskProvider interface {
 	ListTasks() ([]*TaskInfo, error)
 }
 
+// OrchestratorProvider is the interface for controlling the project orchestrator.
+// Start must return without blocking while the orchestrator runs in the background.
+type OrchestratorProvider interface {
+	Start() error
+	Stop() error
+	GetProgress() (*OrchestratorInfo, error)
+}
+
+// ResourceProvider is the interface for fetching total agent resource usage.
+type ResourceProvider interface {
+	ResourceUsage() (*ResourceInfo, error)
+}
+
 // KeyMap defines the key bindings for the dashboard.
 type KeyMap struct {
 	Quit             key.Binding
@@ -81,14 +127,22 @@ type KeyMap struct {
 	Stop             key.Binding
 	Start            key.Binding
 	Attach           key.Binding
+	Orchestrator     key.Binding
 	Diff             key.Binding
 	Follow           key.Binding
 	Filter           key.Binding
 	FilterWorkstream key.Binding
+	GroupLabel       key.Binding
 	Clear            key.Binding
 	Pause            key.Binding
+	Wrap             key.Binding
+	Search           key.Binding
+	NextMatch        key.Binding
+	PrevMatch        key.Binding
 	Top              key.Binding
 	Bottom           key.Binding
+	Focus            key.Binding
+	LogLevel         key.Binding
 }
 
 // DefaultKeyMap returns the default key bindings.
@@ -134,6 +188,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("a"),
 			key.WithHelp("a", "attach"),
 		),
+		Orchestrator: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "start/stop orchestrator"),
+		),
 		Diff: key.NewBinding(
 			key.WithKeys("d"),
 			key.WithHelp("d", "show diff"),
@@ -150,6 +208,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("F"),
 			key.WithHelp("F", "filter workstream"),
 		),
+		GroupLabel: key.NewBinding(
+			key.WithKeys("l"),
+			key.WithHelp("l", "group by label"),
+		),
 		Clear: key.NewBinding(
 			key.WithKeys("c"),
 			key.WithHelp("c", "clear logs"),
@@ -158,6 +220,22 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("p"),
 			key.WithHelp("p", "pause logs"),
 		),
+		Wrap: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "wrap logs"),
+		),
+		Search: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "search logs"),
+		),
+		NextMatch: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "next match/failed task"),
+		),
+		PrevMatch: key.NewBinding(
+			key.WithKeys("N"),
+			key.WithHelp("N", "previous match/failed task"),
+		),
 		Top: key.NewBinding(
 			key.WithKeys("g"),
 			key.WithHelp("g", "go to top"),
@@ -166,15 +244,25 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("G"),
 			key.WithHelp("G", "go to bottom"),
 		),
+		Focus: key.NewBinding(
+			key.WithKeys("z"),
+			key.WithHelp("z", "focus pane"),
+		),
+		LogLevel: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "cycle minimum log level"),
+		),
 	}
 }
 
 // Model is the main dashboard model.
 type Model struct {
 	// Data
-	agents []*AgentInfo
-	tasks  []*TaskInfo
-	logs   []LogLine
+	agents       []*AgentInfo
+	tasks        []*TaskInfo
+	logs         []LogLine
+	orchestrator *OrchestratorInfo
+	resources    *ResourceInfo
 
 	// UI State
 	activePane       Pane
@@ -183,11 +271,20 @@ type Model struct {
 	logOffset        int
 	logFollow        bool
 	logPaused        bool
+	logWrap          bool
+	logSearching     bool           // typing a search query
+	logSearch        string         // current search query
+	logSearchRe      *regexp.Regexp // compiled query; nil when not filtering
+	logSearchErr     bool           // query is an invalid regex
+	logMatch         int            // index of the current match in visibleLogs
+	logMinLevel      int            // index in logLevels of the lowest level shown
 	showHelp         bool
+	focusMode        bool // only the active pane is shown, full screen
 	showTaskDetails  bool
 	taskDetailsModal *TaskDetailsModal
 	statusFilter     string
 	workstreamFilter string
+	agentGroupLabel  string // label key the agents pane groups by; "" for none
 	statusMsg        string
 	errorMsg         string
 
@@ -199,8 +296,10 @@ type Model struct {
 	keys KeyMap
 
 	// Providers (injected dependencies)
-	agentProvider AgentProvider
-	taskProvider  TaskProvider
+	agentProvider        AgentProvider
+	taskProvider         TaskProvider
+	orchestratorProvider OrchestratorProvider
+	resourceProvider     ResourceProvider
 
 	// Log streaming
 	logReader      *LogReader
@@ -228,10 +327,42 @@ func NewModel(agentProvider AgentProvider, taskProvider TaskProvider) Model {
 		keys:             DefaultKeyMap(),
 		agentProvider:    agentProvider,
 		taskProvider:     taskProvider,
-		maxLogs:          1000,
+		maxLogs:          DefaultMaxLogs,
 		logCheckTicker:   100 * time.Millisecond,
-		refreshInterval:  time.Second,
+		refreshInterval:  DefaultRefreshInterval,
+	}
+}
+
+// Log buffer and refresh limits.
+const (
+	DefaultMaxLogs         = 1000
+	MinMaxLogs             = 100
+	DefaultRefreshInterval = time.Second
+	MinRefreshInterval     = 100 * time.Millisecond
+
+	// ResourceRefreshInterval is how often resource usage is refreshed.
+	// It's slower than other refreshes because "docker stats" takes about
+	// a second per container.
+	ResourceRefreshInterval = 5 * time.Second
+)
+
+// SetMaxLogs sets how many log lines are kept for scrollback. Values below
+// MinMaxLogs are raised to it. Shrinking the limit drops the oldest lines.
+func (m *Model) SetMaxLogs(n int) {
+	if n < MinMaxLogs {
+		n = MinMaxLogs
 	}
+	m.maxLogs = n
+	m.trimLogs()
+}
+
+// SetRefreshInterval sets how often agents, tasks, and orchestrator status
+// are refreshed. Values below MinRefreshInterval are raised to it.
+func (m *Model) SetRefreshInterval(d time.Duration) {
+	if d < MinRefreshInterval {
+		d = MinRefreshInterval
+	}
+	m.refreshInterval = d
 }
 
 // SetProjectRoot sets the project root path for resolving log files.
@@ -239,6 +370,16 @@ func (m *Model) SetProjectRoot(projectRoot string) {
 	m.projectRoot = projectRoot
 }
 
+// SetOrchestratorProvider enables in-dashboard control of the project orchestrator.
+func (m *Model) SetOrchestratorProvider(provider OrchestratorProvider) {
+	m.orchestratorProvider = provider
+}
+
+// SetResourceProvider enables the resource usage summary in the status bar.
+func (m *Model) SetResourceProvider(provider ResourceProvider) {
+	m.resourceProvider = provider
+}
+
 // tickMsg is sent on each refresh interval.
 type tickMsg time.Time
 
@@ -254,6 +395,21 @@ type tasksRefreshedMsg struct {
 	err   error
 }
 
+// orchestratorRefreshedMsg contains refreshed orchestrator data.
+type orchestratorRefreshedMsg struct {
+	info *OrchestratorInfo
+	err  error
+}
+
+// resourceTickMsg is sent on each resource refresh interval.
+type resourceTickMsg time.Time
+
+// resourcesRefreshedMsg contains refreshed resource usage.
+type resourcesRefreshedMsg struct {
+	info *ResourceInfo
+	err  error
+}
+
 // actionResultMsg contains the result of an action.
 type actionResultMsg struct {
 	action string
@@ -275,6 +431,8 @@ func (m Model) Init() tea.Cmd {
 		m.tick(),
 		m.refreshAgents(),
 		m.refreshTasks(),
+		m.refreshOrchestrator(),
+		m.refreshResources(),
 		m.checkLogsTick(),
 	)
 }
@@ -308,6 +466,36 @@ func (m Model) refreshTasks() tea.Cmd {
 	}
 }
 
+// refreshOrchestrator fetches orchestrator progress from the provider.
+func (m Model) refreshOrchestrator() tea.Cmd {
+	if m.orchestratorProvider == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		info, err := m.orchestratorProvider.GetProgress()
+		return orchestratorRefreshedMsg{info: info, err: err}
+	}
+}
+
+// refreshResources fetches resource usage from the provider.
+func (m Model) refreshResources() tea.Cmd {
+	if m.resourceProvider == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		info, err := m.resourceProvider.ResourceUsage()
+		return resourcesRefreshedMsg{info: info, err: err}
+	}
+}
+
+// resourceTick returns a command that sends resourceTickMsg after the
+// resource refresh interval.
+func (m Model) resourceTick() tea.Cmd {
+	return tea.Tick(ResourceRefreshInterval, func(t time.Time) tea.Msg {
+		return resourceTickMsg(t)
+	})
+}
+
 // checkLogsTick returns a command that periodically checks for new log lines.
 func (m Model) checkLogsTick() tea.Cmd {
 	return tea.Tick(m.logCheckTicker, func(t time.Time) tea.Msg {
@@ -352,6 +540,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.showHelp = false
 				return m, nil
 			}
+			if m.logSearching || m.logSearchRe != nil {
+				m.clearLogSearch()
+				return m, nil
+			}
 		}
 
 		// If modal is open, pass keys to modal for tab navigation and scrolling
@@ -360,6 +552,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
+		// While typing a search query, keys edit the query
+		if m.logSearching {
+			m.updateLogSearch(msg)
+			return m, nil
+		}
+
 		// Clear any error/status messages on key press
 		m.errorMsg = ""
 		m.statusMsg = ""
@@ -398,6 +596,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, m.startSelectedAgent()
 			}
 
+		case key.Matches(msg, m.keys.Orchestrator):
+			return m, m.toggleOrchestrator()
+
 		case key.Matches(msg, m.keys.Follow):
 			switch m.activePane {
 			case PaneLogs:
@@ -416,16 +617,60 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
+		case key.Matches(msg, m.keys.GroupLabel):
+			if m.activePane == PaneAgents {
+				m.cycleAgentGroup()
+			}
+			return m, nil
+
 		case key.Matches(msg, m.keys.Pause):
 			if m.activePane == PaneLogs {
 				m.logPaused = !m.logPaused
 			}
 			return m, nil
 
+		case key.Matches(msg, m.keys.Wrap):
+			if m.activePane == PaneLogs {
+				m.toggleLogWrap()
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keys.LogLevel):
+			if m.activePane == PaneLogs {
+				m.cycleLogLevel()
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keys.Search):
+			if m.activePane == PaneLogs {
+				m.logSearching = true
+				m.setLogSearch("")
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keys.NextMatch):
+			switch {
+			case m.activePane == PaneLogs && m.logSearchRe != nil:
+				m.jumpLogMatch(1)
+			case m.activePane == PaneTasks:
+				m.jumpFailedTask(1)
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keys.PrevMatch):
+			switch {
+			case m.activePane == PaneLogs && m.logSearchRe != nil:
+				m.jumpLogMatch(-1)
+			case m.activePane == PaneTasks:
+				m.jumpFailedTask(-1)
+			}
+			return m, nil
+
 		case key.Matches(msg, m.keys.Clear):
 			if m.activePane == PaneLogs {
 				m.logs = make([]LogLine, 0)
 				m.logOffset = 0
+				m.logMatch = 0
 			}
 			return m, nil
 
@@ -442,6 +687,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
+		case key.Matches(msg, m.keys.Focus):
+			m.toggleFocusMode()
+			return m, nil
+
 		case key.Matches(msg, m.keys.Enter):
 			if m.activePane == PaneAgents && len(m.agents) > 0 {
 				// Select agent for log viewing
@@ -454,7 +703,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				filteredTasks := m.filteredTasks()
 				if m.taskCursor < len(filteredTasks) {
 					task := filteredTasks[m.taskCursor]
-					m.taskDetailsModal = NewTaskDetailsModal(task, m.projectRoot, m.width, m.height)
+					m.taskDetailsModal = NewTaskDetailsModal(task, m.tasks, m.projectRoot, m.width, m.height)
 					m.showTaskDetails = true
 				}
 			}
@@ -466,6 +715,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.tick(),
 			m.refreshAgents(),
 			m.refreshTasks(),
+			m.refreshOrchestrator(),
 		)
 
 	case agentsRefreshedMsg:
@@ -473,6 +723,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.errorMsg = fmt.Sprintf("Error refreshing agents: %v", msg.err)
 		} else {
 			m.agents = msg.agents
+			m.groupAgents()
 			// Keep cursor in bounds
 			if m.agentCursor >= len(m.agents) {
 				m.agentCursor = max(0, len(m.agents)-1)
@@ -493,13 +744,31 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case orchestratorRefreshedMsg:
+		if msg.err != nil {
+			m.errorMsg = fmt.Sprintf("Error refreshing orchestrator: %v", msg.err)
+		} else if msg.info != nil {
+			m.orchestrator = msg.info
+		}
+		return m, nil
+
+	case resourceTickMsg:
+		return m, m.refreshResources()
+
+	case resourcesRefreshedMsg:
+		// Stats are best-effort; keep the last totals if a refresh fails
+		if msg.err == nil && msg.info != nil {
+			m.resources = msg.info
+		}
+		return m, m.resourceTick()
+
 	case actionResultMsg:
 		if msg.err != nil {
 			m.errorMsg = fmt.Sprintf("%s %s failed: %v", msg.action, msg.agent, msg.err)
 		} else {
 			m.statusMsg = fmt.Sprintf("%s %s: success", msg.action, msg.agent)
 		}
-		return m, m.refreshAgents()
+		return m, tea.Batch(m.refreshAgents(), m.refreshOrchestrator())
 
 	case logLineMsg:
 		if !m.logPaused {
@@ -550,26 +819,130 @@ func (m *Model) navigateDown() {
 			m.taskCursor++
 		}
 	case PaneLogs:
-		visibleLines := m.logPaneHeight()
-		if m.logOffset < len(m.logs)-visibleLines {
+		if m.logOffset < m.maxLogOffset() {
 			m.logOffset++
 			m.logFollow = false
 		}
 	}
 }
 
+// jumpFailedTask moves the task cursor to the next (delta > 0) or previous
+// (delta < 0) failed task, wrapping around at either end. Only tasks shown
+// by the current filters are considered.
+func (m *Model) jumpFailedTask(delta int) {
+	tasks := m.filteredTasks()
+	var failed []int
+	for i, t := range tasks {
+		if t.Status == "failed" {
+			failed = append(failed, i)
+		}
+	}
+	if len(failed) == 0 {
+		m.statusMsg = "No failed tasks"
+		if m.statusFilter != "all" || m.workstreamFilter != "all" {
+			m.statusMsg += " match the current filter"
+		}
+		return
+	}
+
+	// The failed task at or after the cursor going forward, before it going back
+	next := -1
+	for i, idx := range failed {
+		if (delta > 0 && idx > m.taskCursor) || (delta < 0 && idx >= m.taskCursor) {
+			next = i
+			break
+		}
+	}
+	switch {
+	case delta < 0 && next <= 0:
+		next = len(failed) - 1
+	case delta < 0:
+		next--
+	case next < 0:
+		next = 0
+	}
+
+	m.taskCursor = failed[next]
+	m.statusMsg = fmt.Sprintf("Failed task %d/%d: %s", next+1, len(failed), tasks[m.taskCursor].ID)
+}
+
 // scrollLogsToBottom scrolls the log view to the bottom.
 func (m *Model) scrollLogsToBottom() {
+	m.logOffset = m.maxLogOffset()
+}
+
+// toggleLogWrap switches between truncating and wrapping long log lines,
+// keeping the offset in range for the new row counts.
+func (m *Model) toggleLogWrap() {
+	m.logWrap = !m.logWrap
+	if m.logFollow {
+		m.scrollLogsToBottom()
+		return
+	}
+	m.logOffset = min(m.logOffset, m.maxLogOffset())
+}
+
+// maxLogOffset returns the largest log offset that still fills the pane.
+// With wrapping enabled, lines can take more than one row each.
+func (m *Model) maxLogOffset() int {
+	logs := m.visibleLogs()
 	visibleLines := m.logPaneHeight()
-	m.logOffset = max(0, len(m.logs)-visibleLines)
+	if !m.logWrap {
+		return max(0, len(logs)-visibleLines)
+	}
+
+	width := logContentWidth(m.width - 4)
+	rows := 0
+	for i := len(logs) - 1; i >= 0; i-- {
+		rows += len(WrapText(logs[i].Content, width))
+		if rows > visibleLines {
+			// Never scroll past the last line, even if it alone overflows
+			return min(i+1, len(logs)-1)
+		}
+	}
+	return 0
+}
+
+// logContentWidth returns the width left for log content in a log pane of
+// the given width, after the timestamp.
+func logContentWidth(paneWidth int) int {
+	return max(1, paneWidth-12)
 }
 
 // logPaneHeight returns the height available for log lines.
 func (m *Model) logPaneHeight() int {
 	// Account for header, border, etc.
+	if m.focusMode {
+		return max(1, m.height-m.reservedRows()-4)
+	}
 	return max(1, m.height/3-4)
 }
 
+// reservedRows is the height taken by the title, status lines, and status
+// bar, around the panes.
+func (m *Model) reservedRows() int {
+	if m.orchestratorProvider != nil {
+		return 5
+	}
+	return 4
+}
+
+// toggleFocusMode switches between the three-pane layout and showing only
+// the active pane, keeping the log offset in range for the new pane height.
+func (m *Model) toggleFocusMode() {
+	m.focusMode = !m.focusMode
+	if m.logFollow {
+		m.scrollLogsToBottom()
+	} else {
+		m.logOffset = min(m.logOffset, m.maxLogOffset())
+	}
+	if m.focusMode {
+		m.statusMsg = "Focus mode: tab switches pane, z shows all panes"
+	} else {
+		m.statusMsg = ""
+	}
+}
+
 // stopSelectedAgent stops the selected agent.
 func (m Model) stopSelectedAgent() tea.Cmd {
 	if m.agentCursor >= len(m.agents) || m.agentProvider == nil {
@@ -594,6 +967,51 @@ func (m Model) startSelectedAgent() tea.Cmd {
 	}
 }
 
+// toggleOrchestrator starts a stopped orchestrator or stops a running one.
+// While a transition is in flight, further presses are ignored so the
+// orchestrator is never started twice.
+func (m *Model) toggleOrchestrator() tea.Cmd {
+	if m.orchestratorProvider == nil {
+		m.errorMsg = "Orchestrator not available"
+		return nil
+	}
+
+	status := "stopped"
+	if m.orchestrator != nil {
+		status = m.orchestrator.Status
+	}
+
+	provider := m.orchestratorProvider
+	switch status {
+	case "stopped":
+		m.setOrchestratorStatus("starting")
+		return func() tea.Msg {
+			err := provider.Start()
+			return actionResultMsg{action: "Start", agent: "orchestrator", err: err}
+		}
+	case "running":
+		m.setOrchestratorStatus("stopping")
+		return func() tea.Msg {
+			err := provider.Stop()
+			return actionResultMsg{action: "Stop", agent: "orchestrator", err: err}
+		}
+	default:
+		m.statusMsg = fmt.Sprintf("Orchestrator is %s", status)
+		return nil
+	}
+}
+
+// setOrchestratorStatus optimistically updates the displayed orchestrator status.
+func (m *Model) setOrchestratorStatus(status string) {
+	info := &OrchestratorInfo{}
+	if m.orchestrator != nil {
+		copied := *m.orchestrator
+		info = &copied
+	}
+	info.Status = status
+	m.orchestrator = info
+}
+
 // cycleStatusFilter cycles through status filter options.
 func (m *Model) cycleStatusFilter() {
 	filters := []string{"all", "pending", "in_progress", "complete", "failed", "blocked"}
@@ -622,6 +1040,64 @@ func (m *Model) cycleWorkstreamFilter() {
 	m.workstreamFilter = "all"
 }
 
+// cycleAgentGroup cycles the agents pane through grouping by each label key
+// the agents use, then back to no grouping.
+func (m *Model) cycleAgentGroup() {
+	options := append([]string{""}, m.agentLabelKeys()...)
+
+	next := ""
+	for i, k := range options {
+		if k == m.agentGroupLabel {
+			next = options[(i+1)%len(options)]
+			break
+		}
+	}
+	m.agentGroupLabel = next
+	m.agentCursor = 0
+	m.groupAgents()
+
+	if next == "" {
+		m.statusMsg = "Agents ungrouped"
+	} else {
+		m.statusMsg = fmt.Sprintf("Agents grouped by %s", next)
+	}
+}
+
+// agentLabelKeys returns the sorted label keys used by any agent.
+func (m *Model) agentLabelKeys() []string {
+	keySet := make(map[string]bool)
+	for _, a := range m.agents {
+		for k := range a.Labels {
+			keySet[k] = true
+		}
+	}
+	keys := make([]string, 0, len(keySet))
+	for k := range keySet {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// groupAgents orders agents by their value for the grouping label, then by
+// name, with agents lacking the label last. It does nothing when ungrouped.
+func (m *Model) groupAgents() {
+	if m.agentGroupLabel == "" {
+		return
+	}
+	sort.SliceStable(m.agents, func(i, j int) bool {
+		vi, iok := m.agents[i].Labels[m.agentGroupLabel]
+		vj, jok := m.agents[j].Labels[m.agentGroupLabel]
+		if iok != jok {
+			return iok
+		}
+		if vi != vj {
+			return vi < vj
+		}
+		return m.agents[i].Name < m.agents[j].Name
+	})
+}
+
 // getUniqueWorkstreams returns unique workstreams from tasks.
 func (m *Model) getUniqueWorkstreams() []string {
 	wsSet := make(map[string]bool)
@@ -688,9 +1164,24 @@ func (m Model) renderMainView() string {
 	sb.WriteString(title)
 	sb.WriteString("\n")
 
+	// Orchestrator status line
+	reserved := m.reservedRows()
+	if m.orchestratorProvider != nil {
+		sb.WriteString(m.renderOrchestratorLine())
+		sb.WriteString("\n")
+	}
+
+	// Focus mode shows only the active pane, for narrow terminals
+	if m.focusMode {
+		sb.WriteString(m.renderFocusedPane(m.height - reserved))
+		sb.WriteString("\n")
+		sb.WriteString(m.renderStatusBar())
+		return sb.String()
+	}
+
 	// Calculate pane dimensions
-	topHeight := (m.height - 4) * 2 / 3 // 2/3 for top panes
-	bottomHeight := m.height - 4 - topHeight
+	topHeight := (m.height - reserved) * 2 / 3 // 2/3 for top panes
+	bottomHeight := m.height - reserved - topHeight
 	leftWidth := m.width / 2
 	rightWidth := m.width - leftWidth
 
@@ -729,12 +1220,33 @@ func (m Model) renderMainView() string {
 	return sb.String()
 }
 
+// renderFocusedPane renders the active pane alone at the full width, with
+// the given height.
+func (m Model) renderFocusedPane(height int) string {
+	var content string
+	switch m.activePane {
+	case PaneAgents:
+		content = m.renderAgentPane(m.width-4, height-2)
+	case PaneTasks:
+		content = m.renderTaskPane(m.width-4, height-2)
+	default:
+		content = m.renderLogPane(m.width-4, height-2)
+	}
+	return PaneBorder(true).
+		Width(m.width - 2).
+		Height(height).
+		Render(content)
+}
+
 // renderAgentPane renders the agents list pane.
 func (m Model) renderAgentPane(width, height int) string {
 	var sb strings.Builder
 
 	// Header
 	header := HeaderStyle.Render(fmt.Sprintf("Agents [%d]", len(m.agents)))
+	if m.agentGroupLabel != "" {
+		header += " " + MutedStyle.Render(fmt.Sprintf("[Group: %s]", m.agentGroupLabel))
+	}
 	sb.WriteString(header)
 	sb.WriteString("\n")
 	sb.WriteString(strings.Repeat("─", width))
@@ -745,12 +1257,33 @@ func (m Model) renderAgentPane(width, height int) string {
 		return sb.String()
 	}
 
-	// Agent list
+	// Agent list, with a heading before each group when grouping by label
+	rows := 0
+	group := ""
 	for i, agent := range m.agents {
-		if i >= height-3 {
+		if m.agentGroupLabel != "" {
+			value, ok := agent.Labels[m.agentGroupLabel]
+			heading := fmt.Sprintf("%s=%s", m.agentGroupLabel, value)
+			if !ok {
+				heading = fmt.Sprintf("no %s label", m.agentGroupLabel)
+			}
+			if i == 0 || heading != group {
+				if rows >= height-4 {
+					sb.WriteString(MutedStyle.Render(fmt.Sprintf("... and %d more", len(m.agents)-i)))
+					break
+				}
+				group = heading
+				sb.WriteString(MutedStyle.Render(heading))
+				sb.WriteString("\n")
+				rows++
+			}
+		}
+
+		if rows >= height-3 {
 			sb.WriteString(MutedStyle.Render(fmt.Sprintf("... and %d more", len(m.agents)-i)))
 			break
 		}
+		rows++
 
 		// Selection indicator
 		prefix := "  "
@@ -778,7 +1311,13 @@ func (m Model) renderAgentPane(width, height int) string {
 			task = MutedStyle.Render(fmt.Sprintf("→ %s", Truncate(agent.CurrentTask, 15)))
 		}
 
-		line := fmt.Sprintf("%s%s %s %s %s", prefix, icon, name, status, task)
+		// Worktree disk usage
+		disk := ""
+		if agent.DiskUsage > 0 {
+			disk = MutedStyle.Render(FormatBytes(agent.DiskUsage))
+		}
+
+		line := fmt.Sprintf("%s%s %s %s %s %s", prefix, icon, name, status, disk, task)
 		if i == m.agentCursor && m.activePane == PaneAgents {
 			line = SelectedStyle.Render(line)
 		}
@@ -837,13 +1376,16 @@ func (m Model) renderTaskPane(width, height int) string {
 		// Status icon
 		icon := TaskStatusIcon(task.Status)
 
+		// Priority badge
+		priority := PriorityBadge(task.Priority)
+
 		// Task ID
 		id := MutedStyle.Width(10).Render(Truncate(task.ID, 9))
 
-		// Title
+		// Title, narrowed to make room for the priority badge
 		title := lipgloss.NewStyle().
-			Width(18).
-			Render(Truncate(task.Title, 17))
+			Width(13).
+			Render(Truncate(task.Title, 12))
 
 		// Workstream
 		workstream := InfoStyle.Width(10).Render(Truncate(task.Workstream, 9))
@@ -854,7 +1396,10 @@ func (m Model) renderTaskPane(width, height int) string {
 			assigned = WarningStyle.Render(fmt.Sprintf("→ %s", Truncate(task.AssignedTo, 10)))
 		}
 
-		line := fmt.Sprintf("%s%s %s %s %s %s", prefix, icon, id, title, workstream, assigned)
+		line := fmt.Sprintf("%s%s %s %s %s %s %s", prefix, icon, priority, id, title, workstream, assigned)
+		if task.Status == "in_progress" && task.ProgressPercent != nil {
+			line += " " + InfoStyle.Render(ProgressBar(*task.ProgressPercent))
+		}
 		if i == m.taskCursor && m.activePane == PaneTasks {
 			line = SelectedStyle.Render(line)
 		}
@@ -864,9 +1409,12 @@ func (m Model) renderTaskPane(width, height int) string {
 		// Show error preview for failed tasks
 		if task.Status == "failed" && task.ErrorPreview != "" {
 			errorStyle := lipgloss.NewStyle().
-				Foreground(ColorError).
+				Foreground(FailureCategoryColor(task.FailureCategory)).
 				Italic(true)
 			errorLine := fmt.Sprintf("    ↳ %s", task.ErrorPreview)
+			if task.FailureCategory != "" {
+				errorLine = fmt.Sprintf("    ↳ [%s] %s", task.FailureCategory, task.ErrorPreview)
+			}
 			sb.WriteString(errorStyle.Render(errorLine))
 			sb.WriteString("\n")
 		}
@@ -881,17 +1429,38 @@ func (m Model) renderLogPane(width, height int) string {
 
 	// Header
 	agentName := "none"
+	logFilePath := ""
+	notes := ""
 	if m.agentCursor < len(m.agents) {
 		agentName = m.agents[m.agentCursor].Name
+		logFilePath = m.agents[m.agentCursor].LogFilePath
+		notes = m.agents[m.agentCursor].Notes
 	}
 
 	headerParts := []string{fmt.Sprintf("Logs: %s", agentName)}
+	if notes != "" {
+		headerParts = append(headerParts, InfoStyle.Render("("+Truncate(notes, 40)+")"))
+	}
+	if logFilePath != "" {
+		headerParts = append(headerParts, MutedStyle.Render("full log: "+Truncate(logFilePath, 50)))
+	}
 	if m.logFollow {
 		headerParts = append(headerParts, SuccessStyle.Render("[follow]"))
 	}
 	if m.logPaused {
 		headerParts = append(headerParts, WarningStyle.Render("[paused]"))
 	}
+	if m.logWrap {
+		headerParts = append(headerParts, InfoStyle.Render("[wrap]"))
+	}
+	if m.logMinLevel > 0 {
+		headerParts = append(headerParts, InfoStyle.Render("[level ≥ "+logLevels[m.logMinLevel]+"]"))
+	}
+
+	logs := m.visibleLogs()
+	if search := m.renderLogSearchStatus(len(logs)); search != "" {
+		headerParts = append(headerParts, search)
+	}
 
 	header := HeaderStyle.Render(strings.Join(headerParts, " "))
 	sb.WriteString(header)
@@ -899,32 +1468,125 @@ func (m Model) renderLogPane(width, height int) string {
 	sb.WriteString(strings.Repeat("─", width))
 	sb.WriteString("\n")
 
-	if len(m.logs) == 0 {
-		sb.WriteString(MutedStyle.Render("No logs yet. Select an agent and press Enter."))
+	if len(logs) == 0 {
+		if m.logSearchRe != nil {
+			sb.WriteString(MutedStyle.Render("No lines match the search. Press Esc to clear it."))
+		} else if m.logMinLevel > 0 && len(m.logs) > 0 {
+			sb.WriteString(MutedStyle.Render("No lines at or above " + logLevels[m.logMinLevel] + ". Press L to change the level."))
+		} else {
+			sb.WriteString(MutedStyle.Render("No logs yet. Select an agent and press Enter."))
+		}
 		return sb.String()
 	}
 
-	// Calculate visible lines
+	// Calculate visible rows
 	visibleLines := height - 3
-	endIdx := min(m.logOffset+visibleLines, len(m.logs))
+	contentWidth := logContentWidth(width)
+	indent := strings.Repeat(" ", len("[15:04:05]"))
 
-	// Render visible logs
-	for i := m.logOffset; i < endIdx; i++ {
-		line := m.logs[i]
+	// Render visible logs, stopping once the pane is full
+	rows := 0
+	for i := m.logOffset; i < len(logs) && rows < visibleLines; i++ {
+		line := logs[i]
 
 		// Timestamp
 		ts := MutedStyle.Render(line.Timestamp.Format("[15:04:05]"))
 
-		// Content with level coloring
+		// Content with level coloring and search matches highlighted
 		contentStyle := lipgloss.NewStyle().Foreground(LogLevelColor(line.Level))
-		content := contentStyle.Render(Truncate(line.Content, width-12))
 
-		sb.WriteString(fmt.Sprintf("%s %s\n", ts, content))
+		// The current search match is marked in the gutter
+		sep := " "
+		if m.logSearchRe != nil && i == m.logMatch {
+			sep = WarningStyle.Render("▶")
+		}
+
+		if !m.logWrap {
+			content := highlightMatches(Truncate(line.Content, contentWidth), m.logSearchRe, contentStyle)
+			sb.WriteString(ts + sep + content + "\n")
+			rows++
+			continue
+		}
+
+		// Continuation rows are indented under the first row's content
+		for j, part := range WrapText(line.Content, contentWidth) {
+			if rows >= visibleLines {
+				break
+			}
+			prefix := ts + sep
+			if j > 0 {
+				prefix = indent + " "
+			}
+			sb.WriteString(prefix + highlightMatches(part, m.logSearchRe, contentStyle) + "\n")
+			rows++
+		}
 	}
 
 	return sb.String()
 }
 
+// renderLogSearchStatus renders the search indicator for the logs header,
+// or "" when no search is active.
+func (m Model) renderLogSearchStatus(matches int) string {
+	switch {
+	case m.logSearching:
+		style := InfoStyle
+		if m.logSearchErr {
+			style = ErrorStyle
+		}
+		return style.Render("/" + m.logSearch + "█")
+	case m.logSearchRe == nil:
+		return ""
+	case matches == 0:
+		return WarningStyle.Render(fmt.Sprintf("[/%s: no matches]", m.logSearch))
+	default:
+		return InfoStyle.Render(fmt.Sprintf("[/%s: %d/%d]", m.logSearch, m.logMatch+1, matches))
+	}
+}
+
+// renderOrchestratorLine renders the orchestrator status and progress line.
+func (m Model) renderOrchestratorLine() string {
+	info := m.orchestrator
+	if info == nil {
+		info = &OrchestratorInfo{Status: "stopped"}
+	}
+
+	var statusStyle lipgloss.Style
+	switch info.Status {
+	case "running":
+		statusStyle = SuccessStyle
+	case "starting", "stopping":
+		statusStyle = WarningStyle
+	default:
+		statusStyle = MutedStyle
+	}
+
+	parts := []string{
+		"Orchestrator: " + statusStyle.Render(fmt.Sprintf("[%s]", info.Status)),
+	}
+	if info.Total > 0 {
+		parts = append(parts, fmt.Sprintf("%d/%d complete (%.0f%%)", info.Complete, info.Total, info.Percentage))
+		parts = append(parts, fmt.Sprintf("%d in progress", info.InProgress))
+		parts = append(parts, fmt.Sprintf("%d pending", info.Pending))
+	}
+	if info.Status == "running" && info.Uptime > 0 {
+		parts = append(parts, fmt.Sprintf("up %s", info.Uptime.Truncate(time.Second)))
+	}
+	if info.Status == "stopped" && info.LastError != "" {
+		parts = append(parts, ErrorStyle.Render(Truncate(info.LastError, 50)))
+	}
+
+	hint := HelpStyle.Render("[o] start/stop")
+	line := strings.Join(parts, MutedStyle.Render(" · "))
+
+	padding := m.width - lipgloss.Width(line) - lipgloss.Width(hint)
+	if padding < 1 {
+		padding = 1
+	}
+
+	return line + strings.Repeat(" ", padding) + hint
+}
+
 // renderStatusBar renders the bottom status bar.
 func (m Model) renderStatusBar() string {
 	// Left side: status/error message
@@ -935,8 +1597,11 @@ func (m Model) renderStatusBar() string {
 		left = InfoStyle.Render(m.statusMsg)
 	}
 
-	// Right side: help hint
-	right := HelpStyle.Render("[?] help  [q] quit  [tab] switch pane")
+	// Right side: resource usage and help hint
+	right := HelpStyle.Render("[?] help  [q] quit  [tab] switch pane  [z] focus")
+	if m.resources != nil {
+		right = InfoStyle.Render(m.resources.String()) + "  " + right
+	}
 
 	// Pad to fill width
 	padding := m.width - lipgloss.Width(left) - lipgloss.Width(right)
@@ -967,6 +1632,7 @@ func (m Model) renderHelp() string {
 				"Tab / Shift+Tab  Switch pane",
 				"j/k or ↓/↑       Navigate list",
 				"Enter            Select item",
+				"z                Focus mode: show only the active pane",
 			},
 		},
 		{
@@ -976,6 +1642,7 @@ func (m Model) renderHelp() string {
 				"r                Start agent",
 				"a                Attach to agent",
 				"d                Show diff",
+				"l                Cycle group by label",
 			},
 		},
 		{
@@ -984,6 +1651,7 @@ func (m Model) renderHelp() string {
 				"Enter            Show task details",
 				"f                Cycle status filter",
 				"F                Cycle workstream filter",
+				"n / N            Next/previous failed task",
 			},
 		},
 		{
@@ -991,6 +1659,11 @@ func (m Model) renderHelp() string {
 			keys: []string{
 				"f                Toggle follow mode",
 				"p                Pause/resume",
+				"w                Toggle line wrap",
+				"L                Cycle minimum level (debug, info, warn, error)",
+				"/                Search (prefix re: for regex)",
+				"n / N            Next/previous match",
+				"Esc              Clear search",
 				"c                Clear logs",
 				"g                Go to top",
 				"G                Go to bottom",
@@ -999,6 +1672,7 @@ func (m Model) renderHelp() string {
 		{
 			title: "General",
 			keys: []string{
+				"o                Start/stop orchestrator",
 				"?                Toggle help",
 				"q / Ctrl+C       Quit",
 			},
@@ -1022,16 +1696,35 @@ func (m Model) renderHelp() string {
 // AddLogLine adds a log line to the buffer.
 func (m *Model) AddLogLine(line LogLine) {
 	m.logs = append(m.logs, line)
+	m.trimLogs()
+}
+
+// trimLogs drops the oldest lines beyond maxLogs, keeping the scroll
+// position and current search match on the same lines.
+func (m *Model) trimLogs() {
 	if len(m.logs) > m.maxLogs {
+		dropped := m.logs[:len(m.logs)-m.maxLogs]
 		m.logs = m.logs[len(m.logs)-m.maxLogs:]
-		if m.logOffset > 0 {
-			m.logOffset--
+
+		// Offsets index the visible lines, so only count dropped lines that were shown
+		for _, d := range dropped {
+			if !m.logLineShown(d) {
+				continue
+			}
+			if m.logOffset > 0 {
+				m.logOffset--
+			}
+			if m.logMatch > 0 {
+				m.logMatch--
+			}
 		}
 	}
 
 	if m.logFollow {
 		m.scrollLogsToBottom()
+		return
 	}
+	m.logOffset = min(m.logOffset, m.maxLogOffset())
 }
 
 // switchLogAgent switches the log viewer to a different agent.
@@ -1,6 +1,8 @@
 package tui
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -47,6 +49,29 @@ func (m *mockTaskProvider) ListTasks() ([]*TaskInfo, error) {
 	return m.tasks, nil
 }
 
+// mockOrchestratorProvider is a mock implementation of OrchestratorProvider for testing.
+type mockOrchestratorProvider struct {
+	info       *OrchestratorInfo
+	startErr   error
+	stopErr    error
+	startCalls int
+	stopCalls  int
+}
+
+func (m *mockOrchestratorProvider) Start() error {
+	m.startCalls++
+	return m.startErr
+}
+
+func (m *mockOrchestratorProvider) Stop() error {
+	m.stopCalls++
+	return m.stopErr
+}
+
+func (m *mockOrchestratorProvider) GetProgress() (*OrchestratorInfo, error) {
+	return m.info, nil
+}
+
 // assertModel is a test helper that asserts the tea.Model is a Model and returns it.
 func assertModel(t *testing.T, teaModel tea.Model) Model {
 	t.Helper()
@@ -232,6 +257,53 @@ func TestModelUpdate_AgentsRefreshed(t *testing.T) {
 	}
 }
 
+func TestModel_GroupAgentsByLabel(t *testing.T) {
+	model := NewModel(nil, nil)
+	model.width = 100
+	model.height = 50
+
+	agents := []*AgentInfo{
+		{Name: "plain", Status: "idle"},
+		{Name: "search-1", Status: "idle", Labels: map[string]string{"feature": "search"}},
+		{Name: "auth-2", Status: "idle", Labels: map[string]string{"feature": "auth"}},
+		{Name: "auth-1", Status: "working", Labels: map[string]string{"feature": "auth", "team": "id"}},
+	}
+	newModel, _ := model.Update(agentsRefreshedMsg{agents: agents})
+	m := assertModel(t, newModel)
+
+	// First press groups by the first label key alphabetically
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	m = assertModel(t, newModel)
+	if m.agentGroupLabel != "feature" {
+		t.Fatalf("agentGroupLabel = %q, want feature", m.agentGroupLabel)
+	}
+
+	var order []string
+	for _, a := range m.agents {
+		order = append(order, a.Name)
+	}
+	want := []string{"auth-1", "auth-2", "search-1", "plain"}
+	if strings.Join(order, ",") != strings.Join(want, ",") {
+		t.Errorf("agent order = %v, want %v", order, want)
+	}
+
+	pane := m.renderAgentPane(80, 20)
+	for _, heading := range []string{"feature=auth", "feature=search", "no feature label"} {
+		if !strings.Contains(pane, heading) {
+			t.Errorf("agents pane missing group heading %q:\n%s", heading, pane)
+		}
+	}
+
+	// Cycle through the remaining key, then back to ungrouped
+	for _, wantLabel := range []string{"team", ""} {
+		newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+		m = assertModel(t, newModel)
+		if m.agentGroupLabel != wantLabel {
+			t.Errorf("agentGroupLabel = %q, want %q", m.agentGroupLabel, wantLabel)
+		}
+	}
+}
+
 func TestModelUpdate_TasksRefreshed(t *testing.T) {
 	model := NewModel(nil, nil)
 	model.width = 100
@@ -288,6 +360,136 @@ func TestModelUpdate_PauseToggle(t *testing.T) {
 	}
 }
 
+func TestModelUpdate_WrapToggle(t *testing.T) {
+	model := NewModel(nil, nil)
+	model.activePane = PaneLogs
+
+	newModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+	m := assertModel(t, newModel)
+	if !m.logWrap {
+		t.Error("expected logWrap to be true after w")
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+	m = assertModel(t, newModel)
+	if m.logWrap {
+		t.Error("expected logWrap to be false after second w")
+	}
+
+	// Other panes ignore the key
+	m.activePane = PaneAgents
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+	m = assertModel(t, newModel)
+	if m.logWrap {
+		t.Error("expected logWrap to stay false outside the logs pane")
+	}
+}
+
+func TestModel_RenderLogPane_Wrap(t *testing.T) {
+	model := NewModel(nil, nil)
+	model.logs = []LogLine{
+		{Content: strings.Repeat("a", 40) + "TAIL", Timestamp: time.Now(), Level: "info"},
+	}
+
+	// Content width is 36-12 = 24, so the line is truncated by default
+	out := model.renderLogPane(36, 10)
+	if strings.Contains(out, "TAIL") {
+		t.Error("expected the end of the line to be truncated without wrap")
+	}
+
+	model.logWrap = true
+	out = model.renderLogPane(36, 10)
+	if !strings.Contains(out, "TAIL") {
+		t.Error("expected the end of the line to be shown with wrap")
+	}
+	if !strings.Contains(out, "[wrap]") {
+		t.Error("expected [wrap] indicator in the header")
+	}
+}
+
+func TestModel_RenderLogPane_LogFilePath(t *testing.T) {
+	model := NewModel(nil, nil)
+	model.agents = []*AgentInfo{{Name: "be-1", LogFilePath: ".tanuki/logs/task-T1.log"}}
+
+	out := model.renderLogPane(80, 10)
+	if !strings.Contains(out, "full log: .tanuki/logs/task-T1.log") {
+		t.Errorf("expected the last task's log path in the header, got:\n%s", out)
+	}
+}
+
+func TestModel_RenderLogPane_Notes(t *testing.T) {
+	model := NewModel(nil, nil)
+	model.agents = []*AgentInfo{{Name: "be-1", Notes: "working on OAuth refactor"}}
+
+	out := model.renderLogPane(100, 10)
+	if !strings.Contains(out, "(working on OAuth refactor)") {
+		t.Errorf("expected the agent's notes in the header, got:\n%s", out)
+	}
+}
+
+func TestModel_RenderLogPane_WrapFillsHeight(t *testing.T) {
+	model := NewModel(nil, nil)
+	model.logWrap = true
+	for i := 0; i < 5; i++ {
+		model.logs = append(model.logs, LogLine{Content: strings.Repeat("x", 60), Timestamp: time.Now()})
+	}
+
+	// Height 10 leaves 7 rows; each line wraps to 3 rows of 24
+	out := model.renderLogPane(36, 10)
+	rows := strings.Count(out, "\n") - 2 // header and separator
+	if rows != 7 {
+		t.Errorf("rendered %d log rows, want 7", rows)
+	}
+}
+
+func TestModel_MaxLogOffset_Wrap(t *testing.T) {
+	model := NewModel(nil, nil)
+	model.width = 40  // content width 24
+	model.height = 30 // 6 visible rows
+	for i := 0; i < 3; i++ {
+		model.logs = append(model.logs, LogLine{Content: strings.Repeat("x", 50), Timestamp: time.Now()})
+	}
+
+	if got := model.maxLogOffset(); got != 0 {
+		t.Errorf("maxLogOffset() without wrap = %d, want 0", got)
+	}
+
+	// Each line takes 3 rows, so only the last two fit
+	model.logWrap = true
+	if got := model.maxLogOffset(); got != 1 {
+		t.Errorf("maxLogOffset() with wrap = %d, want 1", got)
+	}
+
+	// A single line taller than the pane still stays on screen
+	model.logs = append(model.logs, LogLine{Content: strings.Repeat("x", 500), Timestamp: time.Now()})
+	if got := model.maxLogOffset(); got != 3 {
+		t.Errorf("maxLogOffset() with an oversized line = %d, want 3", got)
+	}
+}
+
+func TestModel_ToggleLogWrap_Follow(t *testing.T) {
+	model := NewModel(nil, nil)
+	model.width = 40
+	model.height = 30
+	model.logFollow = true
+	for i := 0; i < 10; i++ {
+		model.AddLogLine(LogLine{Content: strings.Repeat("x", 50), Timestamp: time.Now()})
+	}
+	if model.logOffset != 4 {
+		t.Fatalf("logOffset = %d, want 4", model.logOffset)
+	}
+
+	model.toggleLogWrap()
+	if model.logOffset != 8 {
+		t.Errorf("logOffset after wrap = %d, want 8", model.logOffset)
+	}
+
+	model.toggleLogWrap()
+	if model.logOffset != 4 {
+		t.Errorf("logOffset after unwrap = %d, want 4", model.logOffset)
+	}
+}
+
 func TestModelUpdate_ClearLogs(t *testing.T) {
 	model := NewModel(nil, nil)
 	model.activePane = PaneLogs
@@ -342,6 +544,53 @@ func TestModel_FilteredTasks(t *testing.T) {
 	}
 }
 
+func TestModelUpdate_JumpFailedTask(t *testing.T) {
+	model := NewModel(nil, nil)
+	model.activePane = PaneTasks
+	model.tasks = []*TaskInfo{
+		{ID: "TASK-001", Status: "failed", Workstream: "backend"},
+		{ID: "TASK-002", Status: "complete", Workstream: "backend"},
+		{ID: "TASK-003", Status: "failed", Workstream: "frontend"},
+		{ID: "TASK-004", Status: "pending", Workstream: "frontend"},
+	}
+	model.taskCursor = 1
+
+	press := func(key string) {
+		t.Helper()
+		updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		model = updated.(Model)
+	}
+
+	for _, step := range []struct {
+		key  string
+		want int
+	}{
+		{"n", 2},
+		{"n", 0}, // Wraps to the first failed task
+		{"N", 2}, // Wraps to the last
+		{"N", 0},
+	} {
+		press(step.key)
+		if model.taskCursor != step.want {
+			t.Fatalf("after %q: taskCursor = %d, want %d", step.key, model.taskCursor, step.want)
+		}
+	}
+
+	// Jumps stay within the filtered tasks
+	model.workstreamFilter = "frontend"
+	model.taskCursor = 1
+	press("n")
+	if model.taskCursor != 0 {
+		t.Errorf("taskCursor = %d, want 0 (TASK-003 in the frontend filter)", model.taskCursor)
+	}
+
+	model.statusFilter = "pending"
+	press("n")
+	if !strings.Contains(model.statusMsg, "No failed tasks") {
+		t.Errorf("statusMsg = %q, want no failed tasks", model.statusMsg)
+	}
+}
+
 func TestModel_CycleStatusFilter(t *testing.T) {
 	model := NewModel(nil, nil)
 
@@ -389,6 +638,69 @@ func TestModel_AddLogLine_MaxBuffer(t *testing.T) {
 	}
 }
 
+func TestModel_SetMaxLogs(t *testing.T) {
+	model := NewModel(nil, nil)
+	model.height = 20
+
+	for i := 0; i < 500; i++ {
+		model.AddLogLine(LogLine{Content: fmt.Sprintf("log %d", i), Timestamp: time.Now()})
+	}
+
+	// Shrinking keeps the newest lines
+	model.SetMaxLogs(200)
+	if len(model.logs) != 200 {
+		t.Fatalf("expected 200 logs after shrinking, got %d", len(model.logs))
+	}
+	if model.logs[0].Content != "log 300" || model.logs[199].Content != "log 499" {
+		t.Errorf("expected logs 300-499 to remain, got %q..%q", model.logs[0].Content, model.logs[199].Content)
+	}
+	if model.logOffset != model.maxLogOffset() {
+		t.Errorf("expected following view at bottom (%d), got offset %d", model.maxLogOffset(), model.logOffset)
+	}
+
+	// Below the minimum is raised to it
+	model.SetMaxLogs(10)
+	if model.maxLogs != MinMaxLogs {
+		t.Errorf("expected maxLogs clamped to %d, got %d", MinMaxLogs, model.maxLogs)
+	}
+	if len(model.logs) != MinMaxLogs {
+		t.Errorf("expected %d logs, got %d", MinMaxLogs, len(model.logs))
+	}
+}
+
+func TestModel_SetMaxLogs_Paused(t *testing.T) {
+	model := NewModel(nil, nil)
+	model.height = 20
+	model.logFollow = false
+
+	for i := 0; i < 500; i++ {
+		model.AddLogLine(LogLine{Content: fmt.Sprintf("log %d", i), Timestamp: time.Now()})
+	}
+	model.logOffset = 450
+
+	model.SetMaxLogs(100)
+	if model.logOffset > model.maxLogOffset() {
+		t.Errorf("expected offset within range (max %d), got %d", model.maxLogOffset(), model.logOffset)
+	}
+}
+
+func TestModel_SetRefreshInterval(t *testing.T) {
+	model := NewModel(nil, nil)
+	if model.refreshInterval != DefaultRefreshInterval {
+		t.Errorf("expected default refresh interval %v, got %v", DefaultRefreshInterval, model.refreshInterval)
+	}
+
+	model.SetRefreshInterval(5 * time.Second)
+	if model.refreshInterval != 5*time.Second {
+		t.Errorf("expected refresh interval 5s, got %v", model.refreshInterval)
+	}
+
+	model.SetRefreshInterval(time.Millisecond)
+	if model.refreshInterval != MinRefreshInterval {
+		t.Errorf("expected refresh interval clamped to %v, got %v", MinRefreshInterval, model.refreshInterval)
+	}
+}
+
 func TestModel_View_Loading(t *testing.T) {
 	model := NewModel(nil, nil)
 	// Width and height are 0
@@ -426,6 +738,45 @@ func TestModel_View_Help(t *testing.T) {
 	}
 }
 
+func TestModel_FocusMode(t *testing.T) {
+	model := NewModel(nil, nil)
+	model.width = 60
+	model.height = 30
+
+	newModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'z'}})
+	m := assertModel(t, newModel)
+	if !m.focusMode {
+		t.Fatal("expected focus mode after z")
+	}
+	view := m.View()
+	if !strings.Contains(view, "Agents [0]") || strings.Contains(view, "Tasks [0]") {
+		t.Errorf("focus mode should show only the agents pane:\n%s", view)
+	}
+
+	// Tab still switches the focused pane
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = assertModel(t, newModel)
+	view = m.View()
+	if strings.Contains(view, "Agents [0]") || !strings.Contains(view, "Tasks [0]") {
+		t.Errorf("focus mode should show only the tasks pane after tab:\n%s", view)
+	}
+
+	// The logs pane gets the full height
+	m.activePane = PaneLogs
+	if got, want := m.logPaneHeight(), 30-4-4; got != want {
+		t.Errorf("logPaneHeight() = %d, want %d", got, want)
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'z'}})
+	m = assertModel(t, newModel)
+	if m.focusMode {
+		t.Error("expected z to leave focus mode")
+	}
+	if view := m.View(); !strings.Contains(view, "Agents [0]") || !strings.Contains(view, "Tasks [0]") {
+		t.Errorf("expected all panes after leaving focus mode:\n%s", view)
+	}
+}
+
 func TestModel_GetActivePane(t *testing.T) {
 	model := NewModel(nil, nil)
 
@@ -511,7 +862,7 @@ func TestModel_CloseTaskDetails(t *testing.T) {
 	model.showTaskDetails = true
 	model.taskDetailsModal = NewTaskDetailsModal(
 		&TaskInfo{ID: "TASK-001", Title: "Test"},
-		"", 100, 40)
+		nil, "", 100, 40)
 
 	// Press Esc to close modal
 	newModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyEsc})
@@ -685,3 +1036,112 @@ func TestModel_SwitchLogAgent(t *testing.T) {
 		t.Errorf("expected logOffset to be 0, got %d", model.logOffset)
 	}
 }
+
+func TestModel_ToggleOrchestrator_Start(t *testing.T) {
+	provider := &mockOrchestratorProvider{}
+	model := NewModel(nil, nil)
+	model.SetOrchestratorProvider(provider)
+
+	newModel, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	m := assertModel(t, newModel)
+
+	if m.orchestrator == nil || m.orchestrator.Status != "starting" {
+		t.Fatalf("expected status 'starting', got %+v", m.orchestrator)
+	}
+	if cmd == nil {
+		t.Fatal("expected start command")
+	}
+	cmd()
+	if provider.startCalls != 1 {
+		t.Errorf("expected 1 start call, got %d", provider.startCalls)
+	}
+
+	// Pressing again while starting must not start twice
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	m = assertModel(t, newModel)
+	if cmd != nil {
+		t.Error("expected no command while starting")
+	}
+	if m.orchestrator.Status != "starting" {
+		t.Errorf("expected status to stay 'starting', got %q", m.orchestrator.Status)
+	}
+}
+
+func TestModel_ToggleOrchestrator_Stop(t *testing.T) {
+	provider := &mockOrchestratorProvider{}
+	model := NewModel(nil, nil)
+	model.SetOrchestratorProvider(provider)
+
+	newModel, _ := model.Update(orchestratorRefreshedMsg{info: &OrchestratorInfo{Status: "running", Total: 4, Complete: 1}})
+	m := assertModel(t, newModel)
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	m = assertModel(t, newModel)
+
+	if m.orchestrator.Status != "stopping" {
+		t.Errorf("expected status 'stopping', got %q", m.orchestrator.Status)
+	}
+	if m.orchestrator.Total != 4 {
+		t.Errorf("expected progress to be preserved, got total %d", m.orchestrator.Total)
+	}
+	if cmd == nil {
+		t.Fatal("expected stop command")
+	}
+	cmd()
+	if provider.stopCalls != 1 {
+		t.Errorf("expected 1 stop call, got %d", provider.stopCalls)
+	}
+}
+
+func TestModel_ToggleOrchestrator_NoProvider(t *testing.T) {
+	model := NewModel(nil, nil)
+
+	newModel, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	m := assertModel(t, newModel)
+
+	if cmd != nil {
+		t.Error("expected no command without provider")
+	}
+	if m.errorMsg == "" {
+		t.Error("expected error message without provider")
+	}
+}
+
+func TestModel_View_OrchestratorLine(t *testing.T) {
+	model := NewModel(nil, nil)
+	model.SetOrchestratorProvider(&mockOrchestratorProvider{})
+	model.width = 100
+	model.height = 40
+	model.orchestrator = &OrchestratorInfo{Status: "running", Total: 10, Complete: 3, Percentage: 30}
+
+	line := model.renderOrchestratorLine()
+	if !strings.Contains(line, "running") || !strings.Contains(line, "3/10") {
+		t.Errorf("expected orchestrator line to show status and progress, got %q", line)
+	}
+}
+
+func TestModel_StatusBar_ResourceUsage(t *testing.T) {
+	model := NewModel(nil, nil)
+	model.width = 120
+	model.height = 40
+
+	if strings.Contains(model.renderStatusBar(), "Mem:") {
+		t.Error("expected no resource summary before usage is known")
+	}
+
+	info := &ResourceInfo{Agents: 5, MemoryBytes: 18*1024*1024*1024 + 200*1024*1024, CPUPercent: 340.2}
+	newModel, _ := model.Update(resourcesRefreshedMsg{info: info})
+	model = newModel.(Model)
+
+	bar := model.renderStatusBar()
+	if !strings.Contains(bar, "Agents: 5, Mem: 18.2 GB, CPU: 340%") {
+		t.Errorf("expected status bar to show resource totals, got %q", bar)
+	}
+
+	// A failed refresh keeps the last totals
+	newModel, _ = model.Update(resourcesRefreshedMsg{err: fmt.Errorf("stats unavailable")})
+	model = newModel.(Model)
+	if model.resources != info {
+		t.Error("expected failed refresh to keep the last resource totals")
+	}
+}
@@ -0,0 +1,40 @@
+package tui
+
+// logLevels are the log levels from lowest to highest, in the order the
+// minimum level filter cycles through them.
+var logLevels = []string{"debug", "info", "warn", "error"}
+
+// logLevelRank returns a level's position in logLevels. Lines without a
+// recognized level count as info, the level detectLogLevel defaults to.
+func logLevelRank(level string) int {
+	for i, l := range logLevels {
+		if l == level {
+			return i
+		}
+	}
+	return 1
+}
+
+// logLineShown reports whether a buffered line passes the logs pane's
+// minimum level and search filters.
+func (m *Model) logLineShown(line LogLine) bool {
+	if logLevelRank(line.Level) < m.logMinLevel {
+		return false
+	}
+	return m.logSearchRe == nil || m.logSearchRe.MatchString(line.Content)
+}
+
+// cycleLogLevel raises the minimum level shown in the logs pane, wrapping
+// from error back to debug. Lines below it are hidden, not dropped, so
+// lowering the level again brings them back.
+func (m *Model) cycleLogLevel() {
+	m.logMinLevel = (m.logMinLevel + 1) % len(logLevels)
+
+	// Keep the current search match and offset within the remaining lines
+	m.logMatch = max(0, min(m.logMatch, len(m.visibleLogs())-1))
+	if m.logFollow {
+		m.scrollLogsToBottom()
+		return
+	}
+	m.logOffset = min(m.logOffset, m.maxLogOffset())
+}
@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func levelTestModel() Model {
+	model := NewModel(nil, nil)
+	model.activePane = PaneLogs
+	model.logs = []LogLine{
+		{Content: "debug: cache hit", Level: "debug", Timestamp: time.Now()},
+		{Content: "starting build", Level: "info", Timestamp: time.Now()},
+		{Content: "warning: slow test", Level: "warn", Timestamp: time.Now()},
+		{Content: "ERROR: missing file", Level: "error", Timestamp: time.Now()},
+		{Content: "no level", Timestamp: time.Now()},
+	}
+	return model
+}
+
+func TestModelUpdate_CycleLogLevel(t *testing.T) {
+	tests := []struct {
+		presses int
+		want    []string
+	}{
+		{0, []string{"debug: cache hit", "starting build", "warning: slow test", "ERROR: missing file", "no level"}},
+		{1, []string{"starting build", "warning: slow test", "ERROR: missing file", "no level"}},
+		{2, []string{"warning: slow test", "ERROR: missing file"}},
+		{3, []string{"ERROR: missing file"}},
+		{4, []string{"debug: cache hit", "starting build", "warning: slow test", "ERROR: missing file", "no level"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Repeat("L", tt.presses), func(t *testing.T) {
+			m := typeKeys(t, levelTestModel(), strings.Repeat("L", tt.presses))
+
+			var got []string
+			for _, line := range m.visibleLogs() {
+				got = append(got, line.Content)
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("visibleLogs() = %v, want %v", got, tt.want)
+			}
+
+			// Filtering is for display only
+			if len(m.logs) != 5 {
+				t.Errorf("buffer has %d lines, want all 5 kept", len(m.logs))
+			}
+		})
+	}
+}
+
+func TestModelUpdate_CycleLogLevel_OtherPane(t *testing.T) {
+	m := levelTestModel()
+	m.activePane = PaneAgents
+
+	m = typeKeys(t, m, "L")
+	if m.logMinLevel != 0 {
+		t.Errorf("logMinLevel = %d, want L to only apply in the logs pane", m.logMinLevel)
+	}
+}
+
+func TestModel_RenderLogPane_LogLevel(t *testing.T) {
+	m := levelTestModel()
+
+	if out := m.renderLogPane(60, 10); strings.Contains(out, "[level") {
+		t.Errorf("expected no level indicator without a filter, got:\n%s", out)
+	}
+
+	m = typeKeys(t, m, "LL")
+	out := m.renderLogPane(60, 10)
+	if !strings.Contains(out, "[level ≥ warn]") {
+		t.Errorf("expected the minimum level in the header, got:\n%s", out)
+	}
+	if strings.Contains(out, "starting build") || !strings.Contains(out, "missing file") {
+		t.Errorf("expected only warn and error lines, got:\n%s", out)
+	}
+
+	// Combined with a search, lines must pass both
+	m.setLogSearch("slow")
+	m.logs = m.logs[:2]
+	out = m.renderLogPane(60, 10)
+	if !strings.Contains(out, "No lines match the search") {
+		t.Errorf("expected the no-match message, got:\n%s", out)
+	}
+
+	m.clearLogSearch()
+	out = m.renderLogPane(60, 10)
+	if !strings.Contains(out, "No lines at or above warn") {
+		t.Errorf("expected the level message, got:\n%s", out)
+	}
+}
@@ -5,32 +5,55 @@ import (
 	"fmt"
 	"io"
 	"os/exec"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
+// defaultLogTail is how many existing lines a LogReader shows before following.
+const defaultLogTail = 100
+
 // LogReader streams logs from a Docker container.
 type LogReader struct {
+	agentName     string
 	containerName string
+	tail          int
 	outputCh      chan LogLine
 	stopCh        chan struct{}
+	doneCh        chan struct{}
+	stopOnce      sync.Once
 	cmd           *exec.Cmd
 }
 
 // NewLogReader creates a new log reader for the specified agent.
 func NewLogReader(agentName string) *LogReader {
 	return &LogReader{
+		agentName:     agentName,
 		containerName: fmt.Sprintf("tanuki-%s", agentName),
+		tail:          defaultLogTail,
 		outputCh:      make(chan LogLine, 100),
 		stopCh:        make(chan struct{}),
+		doneCh:        make(chan struct{}),
 	}
 }
 
+// SetTail sets how many existing lines to show before following. Zero or
+// less shows the whole log. Must be called before Start.
+func (r *LogReader) SetTail(n int) {
+	r.tail = n
+}
+
 // Start begins streaming logs from the container.
 func (r *LogReader) Start() error {
-	// Use docker logs with follow, tail last 100 lines
+	tail := "all"
+	if r.tail > 0 {
+		tail = strconv.Itoa(r.tail)
+	}
+
+	// Timestamps come from Docker so backlog lines keep their original times
 	// #nosec G204 - containerName is constructed internally from agentName
-	r.cmd = exec.Command("docker", "logs", "-f", "--tail", "100", r.containerName)
+	r.cmd = exec.Command("docker", "logs", "-f", "--timestamps", "--tail", tail, r.containerName)
 
 	stdout, err := r.cmd.StdoutPipe()
 	if err != nil {
@@ -46,10 +69,24 @@ func (r *LogReader) Start() error {
 		return fmt.Errorf("failed to start logs command: %w", err)
 	}
 
+	var wg sync.WaitGroup
+	wg.Add(2)
 	// Read stdout in a goroutine
-	go r.readOutput(stdout, "stdout")
+	go func() {
+		defer wg.Done()
+		r.readOutput(stdout, "stdout")
+	}()
 	// Read stderr in a goroutine (Claude Code often writes here)
-	go r.readOutput(stderr, "stderr")
+	go func() {
+		defer wg.Done()
+		r.readOutput(stderr, "stderr")
+	}()
+
+	go func() {
+		wg.Wait()
+		_ = r.cmd.Wait()
+		close(r.doneCh)
+	}()
 
 	return nil
 }
@@ -67,10 +104,10 @@ func (r *LogReader) readOutput(reader io.Reader, _ string) {
 		case <-r.stopCh:
 			return
 		default:
-			text := scanner.Text()
+			ts, text := splitDockerTimestamp(scanner.Text())
 			line := LogLine{
-				Timestamp: time.Now(),
-				Agent:     r.containerName,
+				Timestamp: ts,
+				Agent:     r.agentName,
 				Content:   text,
 				Level:     detectLogLevel(text),
 			}
@@ -87,12 +124,15 @@ func (r *LogReader) readOutput(reader io.Reader, _ string) {
 	}
 }
 
-// Stop stops the log reader and cleans up resources.
+// Stop stops the log reader and cleans up resources. It is safe to call
+// more than once.
 func (r *LogReader) Stop() {
-	close(r.stopCh)
-	if r.cmd != nil && r.cmd.Process != nil {
-		_ = r.cmd.Process.Kill()
-	}
+	r.stopOnce.Do(func() {
+		close(r.stopCh)
+		if r.cmd != nil && r.cmd.Process != nil {
+			_ = r.cmd.Process.Kill()
+		}
+	})
 }
 
 // OutputCh returns the channel for receiving log lines.
@@ -100,6 +140,25 @@ func (r *LogReader) OutputCh() <-chan LogLine {
 	return r.outputCh
 }
 
+// Done returns a channel that is closed once the log stream ends, for
+// example because the container stopped. OutputCh stays open; drain it
+// before treating the reader as finished.
+func (r *LogReader) Done() <-chan struct{} {
+	return r.doneCh
+}
+
+// splitDockerTimestamp separates the RFC 3339 timestamp that
+// "docker logs --timestamps" prefixes to each line. Lines without one are
+// stamped with the current time.
+func splitDockerTimestamp(raw string) (time.Time, string) {
+	if prefix, rest, ok := strings.Cut(raw, " "); ok {
+		if ts, err := time.Parse(time.RFC3339Nano, prefix); err == nil {
+			return ts.Local(), rest
+		}
+	}
+	return time.Now(), raw
+}
+
 // detectLogLevel attempts to detect the log level from the line content.
 func detectLogLevel(line string) string {
 	lower := strings.ToLower(line)
@@ -112,3 +112,24 @@ func TestLogReaderOutputCh(t *testing.T) {
 		// Expected - no data should be available
 	}
 }
+
+func TestSplitDockerTimestamp(t *testing.T) {
+	want := time.Date(2026, 3, 4, 5, 6, 7, 890000000, time.UTC)
+
+	ts, content := splitDockerTimestamp("2026-03-04T05:06:07.890000000Z Running tests")
+	if !ts.Equal(want) {
+		t.Errorf("splitDockerTimestamp() time = %v, want %v", ts, want)
+	}
+	if content != "Running tests" {
+		t.Errorf("splitDockerTimestamp() content = %q, want %q", content, "Running tests")
+	}
+
+	before := time.Now()
+	ts, content = splitDockerTimestamp("no timestamp here")
+	if ts.Before(before) {
+		t.Errorf("splitDockerTimestamp() time = %v, want current time for unstamped lines", ts)
+	}
+	if content != "no timestamp here" {
+		t.Errorf("splitDockerTimestamp() content = %q, want the line unchanged", content)
+	}
+}
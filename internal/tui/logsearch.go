@@ -0,0 +1,137 @@
+package tui
+
+import (
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// logSearchRegexPrefix marks a log search query as a regular expression.
+const logSearchRegexPrefix = "re:"
+
+// compileLogSearch turns a search query into a case-insensitive matcher.
+// Queries starting with "re:" are regular expressions; anything else is a
+// literal substring. An empty query matches nothing and returns nil.
+func compileLogSearch(query string) (*regexp.Regexp, error) {
+	pattern, isRegex := strings.CutPrefix(query, logSearchRegexPrefix)
+	if !isRegex {
+		pattern = regexp.QuoteMeta(query)
+	}
+	if pattern == "" {
+		return nil, nil
+	}
+	return regexp.Compile("(?i)" + pattern)
+}
+
+// visibleLogs returns the lines shown in the logs pane: every buffered line,
+// or only those at or above the minimum level and matching the active search.
+func (m *Model) visibleLogs() []LogLine {
+	if m.logSearchRe == nil && m.logMinLevel == 0 {
+		return m.logs
+	}
+
+	matches := make([]LogLine, 0, len(m.logs))
+	for _, line := range m.logs {
+		if m.logLineShown(line) {
+			matches = append(matches, line)
+		}
+	}
+	return matches
+}
+
+// updateLogSearch handles a key press while the search query is being typed.
+func (m *Model) updateLogSearch(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.clearLogSearch()
+	case tea.KeyEnter:
+		m.logSearching = false
+		if m.logSearch == "" {
+			m.clearLogSearch()
+		}
+	case tea.KeyBackspace:
+		if runes := []rune(m.logSearch); len(runes) > 0 {
+			m.setLogSearch(string(runes[:len(runes)-1]))
+		}
+	case tea.KeySpace:
+		m.setLogSearch(m.logSearch + " ")
+	case tea.KeyRunes:
+		m.setLogSearch(m.logSearch + string(msg.Runes))
+	}
+}
+
+// setLogSearch updates the query, re-filters the logs, and jumps to the most
+// recent match.
+func (m *Model) setLogSearch(query string) {
+	m.logSearch = query
+
+	re, err := compileLogSearch(query)
+	if err != nil {
+		// Keep the last valid filter while a regex is half typed
+		m.logSearchErr = true
+		return
+	}
+	m.logSearchErr = false
+	m.logSearchRe = re
+	m.logFollow = false
+
+	m.logMatch = max(0, len(m.visibleLogs())-1)
+	m.scrollToLogMatch()
+}
+
+// clearLogSearch leaves search mode and restores the full scrollback,
+// following new output again.
+func (m *Model) clearLogSearch() {
+	m.logSearching = false
+	m.logSearch = ""
+	m.logSearchRe = nil
+	m.logSearchErr = false
+	m.logMatch = 0
+	m.logFollow = true
+	m.scrollLogsToBottom()
+}
+
+// jumpLogMatch moves to the next (delta > 0) or previous (delta < 0) match,
+// wrapping around at either end.
+func (m *Model) jumpLogMatch(delta int) {
+	n := len(m.visibleLogs())
+	if n == 0 {
+		return
+	}
+	m.logMatch = ((m.logMatch+delta)%n + n) % n
+	m.logFollow = false
+	m.scrollToLogMatch()
+}
+
+// scrollToLogMatch scrolls so the current match is at the top of the pane,
+// or as close as the end of the logs allows.
+func (m *Model) scrollToLogMatch() {
+	m.logOffset = min(m.logMatch, m.maxLogOffset())
+}
+
+// highlightMatches renders s in the base style with any matches of re
+// highlighted.
+func highlightMatches(s string, re *regexp.Regexp, base lipgloss.Style) string {
+	if re == nil {
+		return base.Render(s)
+	}
+
+	var sb strings.Builder
+	last := 0
+	for _, loc := range re.FindAllStringIndex(s, -1) {
+		if loc[0] == loc[1] {
+			continue
+		}
+		if loc[0] > last {
+			sb.WriteString(base.Render(s[last:loc[0]]))
+		}
+		sb.WriteString(SearchMatchStyle.Render(s[loc[0]:loc[1]]))
+		last = loc[1]
+	}
+	if last < len(s) {
+		sb.WriteString(base.Render(s[last:]))
+	}
+	return sb.String()
+}
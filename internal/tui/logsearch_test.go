@@ -0,0 +1,216 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func typeKeys(t *testing.T, m Model, keys string) Model {
+	t.Helper()
+	for _, r := range keys {
+		newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = assertModel(t, newModel)
+	}
+	return m
+}
+
+func searchTestModel() Model {
+	model := NewModel(nil, nil)
+	model.activePane = PaneLogs
+	model.logs = []LogLine{
+		{Content: "starting build", Timestamp: time.Now()},
+		{Content: "ERROR: missing file", Timestamp: time.Now()},
+		{Content: "retrying", Timestamp: time.Now()},
+		{Content: "error: still missing", Timestamp: time.Now()},
+	}
+	return model
+}
+
+func TestCompileLogSearch(t *testing.T) {
+	tests := []struct {
+		query   string
+		line    string
+		want    bool
+		wantNil bool
+		wantErr bool
+	}{
+		{query: "", wantNil: true},
+		{query: "re:", wantNil: true},
+		{query: "error", line: "ERROR: boom", want: true},
+		{query: "a.b", line: "axb", want: false},
+		{query: "a.b", line: "a.b", want: true},
+		{query: "re:a.b", line: "axb", want: true},
+		{query: "re:^exit [1-9]", line: "Exit 2", want: true},
+		{query: "re:(", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			re, err := compileLogSearch(tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("compileLogSearch(%q) error = %v, wantErr %v", tt.query, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if (re == nil) != tt.wantNil {
+				t.Fatalf("compileLogSearch(%q) = %v, wantNil %v", tt.query, re, tt.wantNil)
+			}
+			if re != nil && re.MatchString(tt.line) != tt.want {
+				t.Errorf("compileLogSearch(%q).MatchString(%q) = %v, want %v", tt.query, tt.line, !tt.want, tt.want)
+			}
+		})
+	}
+}
+
+func TestModelUpdate_LogSearch(t *testing.T) {
+	m := typeKeys(t, searchTestModel(), "/")
+	if !m.logSearching {
+		t.Fatal("expected search mode after /")
+	}
+
+	// Keys such as n and q go into the query while typing
+	m = typeKeys(t, m, "error")
+	if m.logSearch != "error" {
+		t.Errorf("logSearch = %q, want %q", m.logSearch, "error")
+	}
+	if got := len(m.visibleLogs()); got != 2 {
+		t.Errorf("visibleLogs() has %d lines, want 2", got)
+	}
+	if m.logMatch != 1 {
+		t.Errorf("logMatch = %d, want 1 (most recent)", m.logMatch)
+	}
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = assertModel(t, newModel)
+	if m.logSearching {
+		t.Error("expected Enter to leave search input")
+	}
+	if m.logSearchRe == nil {
+		t.Error("expected the filter to stay applied after Enter")
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = assertModel(t, newModel)
+	if m.logSearchRe != nil || m.logSearch != "" {
+		t.Error("expected Esc to clear the search")
+	}
+	if got := len(m.visibleLogs()); got != 4 {
+		t.Errorf("visibleLogs() has %d lines after clearing, want 4", got)
+	}
+	if !m.logFollow {
+		t.Error("expected follow mode to resume after clearing the search")
+	}
+}
+
+func TestModelUpdate_LogSearchBackspace(t *testing.T) {
+	m := typeKeys(t, searchTestModel(), "/retx")
+	if got := len(m.visibleLogs()); got != 0 {
+		t.Errorf("visibleLogs() has %d lines, want 0", got)
+	}
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	m = assertModel(t, newModel)
+	if m.logSearch != "ret" {
+		t.Errorf("logSearch = %q, want %q", m.logSearch, "ret")
+	}
+	if got := len(m.visibleLogs()); got != 1 {
+		t.Errorf("visibleLogs() has %d lines, want 1", got)
+	}
+}
+
+func TestModelUpdate_LogSearchInvalidRegex(t *testing.T) {
+	m := typeKeys(t, searchTestModel(), "/re:miss")
+	m = typeKeys(t, m, "(")
+	if !m.logSearchErr {
+		t.Error("expected logSearchErr for an unterminated group")
+	}
+
+	// The last valid filter stays in place
+	if got := len(m.visibleLogs()); got != 2 {
+		t.Errorf("visibleLogs() has %d lines, want 2", got)
+	}
+}
+
+func TestModelUpdate_LogSearchJump(t *testing.T) {
+	m := typeKeys(t, searchTestModel(), "/error")
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = assertModel(t, newModel)
+
+	// Starts on the last match; n wraps around to the first
+	m = typeKeys(t, m, "n")
+	if m.logMatch != 0 {
+		t.Errorf("logMatch after n = %d, want 0", m.logMatch)
+	}
+	m = typeKeys(t, m, "N")
+	if m.logMatch != 1 {
+		t.Errorf("logMatch after N = %d, want 1", m.logMatch)
+	}
+	if m.logFollow {
+		t.Error("expected jumping between matches to pause follow mode")
+	}
+}
+
+func TestModel_AddLogLineWithSearch(t *testing.T) {
+	m := searchTestModel()
+	m.maxLogs = 4
+	m.setLogSearch("error")
+	m.jumpLogMatch(1) // first match
+	m.jumpLogMatch(1) // second match
+
+	// Dropping a line that isn't shown leaves the match index alone
+	m.AddLogLine(LogLine{Content: "done"})
+	if m.logMatch != 1 {
+		t.Errorf("logMatch = %d, want 1", m.logMatch)
+	}
+
+	// Dropping a shown line shifts it
+	m.AddLogLine(LogLine{Content: "done"})
+	if m.logMatch != 0 {
+		t.Errorf("logMatch = %d, want 0", m.logMatch)
+	}
+}
+
+func TestModel_RenderLogPane_Search(t *testing.T) {
+	m := searchTestModel()
+	m.setLogSearch("missing")
+
+	out := m.renderLogPane(60, 10)
+	if strings.Contains(out, "retrying") {
+		t.Error("expected non-matching lines to be hidden")
+	}
+	if !strings.Contains(out, "[/missing: 2/2]") {
+		t.Errorf("expected match count in header, got:\n%s", out)
+	}
+	if !strings.Contains(out, "▶") {
+		t.Error("expected the current match to be marked")
+	}
+
+	m.setLogSearch("nothing")
+	out = m.renderLogPane(60, 10)
+	if !strings.Contains(out, "no matches") {
+		t.Error("expected no matches indicator")
+	}
+}
+
+func TestHighlightMatches(t *testing.T) {
+	re, err := compileLogSearch("err")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	base := lipgloss.NewStyle()
+	got := highlightMatches("Error", re, base)
+	want := SearchMatchStyle.Render("Err") + base.Render("or")
+	if got != want {
+		t.Errorf("highlightMatches() = %q, want %q", got, want)
+	}
+
+	if got := highlightMatches("plain", nil, base); got != base.Render("plain") {
+		t.Errorf("highlightMatches() with nil regex = %q, want %q", got, base.Render("plain"))
+	}
+}
@@ -9,9 +9,64 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
+// DependencyState describes whether a task dependency is satisfied.
+type DependencyState string
+
+const (
+	// DependencyComplete means the dependency task is complete.
+	DependencyComplete DependencyState = "complete"
+	// DependencyIncomplete means the dependency task exists but is not complete.
+	DependencyIncomplete DependencyState = "incomplete"
+	// DependencyMissing means no task with the dependency ID is known.
+	DependencyMissing DependencyState = "missing"
+)
+
+// DependencyInfo is a task dependency resolved against the known tasks.
+type DependencyInfo struct {
+	ID     string
+	Status string // Status of the dependency task (empty if missing)
+	State  DependencyState
+}
+
+// resolveDependencies resolves a task's dependencies against all known tasks
+// and finds the tasks that depend on it.
+func resolveDependencies(task *TaskInfo, allTasks []*TaskInfo) ([]DependencyInfo, []string) {
+	byID := make(map[string]*TaskInfo, len(allTasks))
+	for _, t := range allTasks {
+		byID[t.ID] = t
+	}
+
+	deps := make([]DependencyInfo, 0, len(task.DependsOn))
+	for _, id := range task.DependsOn {
+		dep, ok := byID[id]
+		switch {
+		case !ok:
+			deps = append(deps, DependencyInfo{ID: id, State: DependencyMissing})
+		case dep.Status == "complete":
+			deps = append(deps, DependencyInfo{ID: id, Status: dep.Status, State: DependencyComplete})
+		default:
+			deps = append(deps, DependencyInfo{ID: id, Status: dep.Status, State: DependencyIncomplete})
+		}
+	}
+
+	var blocks []string
+	for _, t := range allTasks {
+		for _, id := range t.DependsOn {
+			if id == task.ID {
+				blocks = append(blocks, t.ID)
+				break
+			}
+		}
+	}
+
+	return deps, blocks
+}
+
 // TaskDetailsModal renders a modal showing task details with tabs.
 type TaskDetailsModal struct {
 	task        *TaskInfo
+	deps        []DependencyInfo
+	blocks      []string
 	tabs        *TabsModel
 	viewport    viewport.Model
 	width       int
@@ -21,7 +76,8 @@ type TaskDetailsModal struct {
 }
 
 // NewTaskDetailsModal creates a new task details modal with tabs.
-func NewTaskDetailsModal(task *TaskInfo, projectRoot string, width, height int) *TaskDetailsModal {
+// allTasks is used to resolve dependency status and find the tasks this one blocks.
+func NewTaskDetailsModal(task *TaskInfo, allTasks []*TaskInfo, projectRoot string, width, height int) *TaskDetailsModal {
 	// Calculate modal dimensions (80% of screen, min 70x25)
 	modalWidth := max(min(width*4/5, 100), 70)
 	modalHeight := max(min(height*4/5, 35), 25)
@@ -38,6 +94,7 @@ func NewTaskDetailsModal(task *TaskInfo, projectRoot string, width, height int)
 		projectRoot: projectRoot,
 		viewport:    vp,
 	}
+	m.deps, m.blocks = resolveDependencies(task, allTasks)
 
 	// Create log reader if log file path is available
 	if task.LogFilePath != "" {
@@ -173,7 +230,7 @@ func (m *TaskDetailsModal) renderDetails() string {
 
 	// Priority
 	if m.task.Priority != "" {
-		priorityStyle := lipgloss.NewStyle().Foreground(priorityColor(m.task.Priority))
+		priorityStyle := lipgloss.NewStyle().Foreground(PriorityColor(m.task.Priority))
 		sb.WriteString(fmt.Sprintf("Priority: %s\n", priorityStyle.Render(m.task.Priority)))
 	}
 
@@ -185,17 +242,16 @@ func (m *TaskDetailsModal) renderDetails() string {
 		sb.WriteString(fmt.Sprintf("Assigned: %s\n", MutedStyle.Render("(none)")))
 	}
 
+	// Blocked by hand, independent of dependencies
+	if m.task.BlockedReason != "" {
+		blockedStyle := lipgloss.NewStyle().Foreground(ColorOrange)
+		sb.WriteString(fmt.Sprintf("Blocked: %s\n", blockedStyle.Render(m.task.BlockedReason)))
+	}
+
 	sb.WriteString("\n")
 
 	// Dependencies
-	if len(m.task.DependsOn) > 0 {
-		sb.WriteString("Dependencies:\n")
-		for _, dep := range m.task.DependsOn {
-			sb.WriteString(fmt.Sprintf("  • %s\n", dep))
-		}
-	} else {
-		sb.WriteString(MutedStyle.Render("No dependencies\n"))
-	}
+	sb.WriteString(m.renderDependencies())
 
 	sb.WriteString("\n")
 
@@ -210,6 +266,43 @@ func (m *TaskDetailsModal) renderDetails() string {
 	return sb.String()
 }
 
+// renderDependencies renders dependency status and the tasks this task blocks.
+func (m *TaskDetailsModal) renderDependencies() string {
+	var sb strings.Builder
+
+	if len(m.deps) > 0 {
+		complete := 0
+		for _, dep := range m.deps {
+			if dep.State == DependencyComplete {
+				complete++
+			}
+		}
+		sb.WriteString(fmt.Sprintf("Dependencies (%d/%d complete):\n", complete, len(m.deps)))
+
+		for _, dep := range m.deps {
+			switch dep.State {
+			case DependencyMissing:
+				missingStyle := lipgloss.NewStyle().Foreground(ColorError)
+				sb.WriteString(fmt.Sprintf("  %s %s %s\n",
+					missingStyle.Render("?"), dep.ID, missingStyle.Render("(missing)")))
+			default:
+				statusStyle := lipgloss.NewStyle().Foreground(TaskStatusColor(dep.Status))
+				sb.WriteString(fmt.Sprintf("  %s %s %s\n",
+					TaskStatusIcon(dep.Status), dep.ID, statusStyle.Render(dep.Status)))
+			}
+		}
+	} else {
+		sb.WriteString(MutedStyle.Render("No dependencies\n"))
+	}
+
+	if len(m.blocks) > 0 {
+		blocksStyle := lipgloss.NewStyle().Foreground(ColorOrange)
+		sb.WriteString(fmt.Sprintf("Blocks: %s\n", blocksStyle.Render(strings.Join(m.blocks, ", "))))
+	}
+
+	return sb.String()
+}
+
 // renderErrors renders the Error Info tab content.
 func (m *TaskDetailsModal) renderErrors() string {
 	if m.task.FailureMessage == "" {
@@ -225,6 +318,11 @@ func (m *TaskDetailsModal) renderErrors() string {
 	sb.WriteString(m.task.FailureMessage)
 	sb.WriteString("\n\n")
 
+	if m.task.FailureCategory != "" {
+		categoryStyle := lipgloss.NewStyle().Foreground(FailureCategoryColor(m.task.FailureCategory))
+		sb.WriteString(fmt.Sprintf("Category: %s\n\n", categoryStyle.Render(m.task.FailureCategory)))
+	}
+
 	// Log file path
 	if m.task.LogFilePath != "" {
 		sb.WriteString(MutedStyle.Render("Full execution logs available in the 'Logs' tab\n"))
@@ -281,19 +379,3 @@ func (m *TaskDetailsModal) renderValidation() string {
 
 	return sb.String()
 }
-
-// priorityColor returns the color for a priority level.
-func priorityColor(priority string) lipgloss.Color {
-	switch priority {
-	case "critical":
-		return ColorError
-	case "high":
-		return ColorWarning
-	case "medium":
-		return ColorInfo
-	case "low":
-		return ColorSecondary
-	default:
-		return lipgloss.Color("255")
-	}
-}
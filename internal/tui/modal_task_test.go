@@ -13,7 +13,7 @@ func TestNewTaskDetailsModal(t *testing.T) {
 		Workstream: "backend",
 	}
 
-	modal := NewTaskDetailsModal(task, "", 100, 40)
+	modal := NewTaskDetailsModal(task, nil, "", 100, 40)
 
 	if modal.task != task {
 		t.Error("expected task to be set")
@@ -49,7 +49,7 @@ func TestTaskDetailsModal_View_WithTask(t *testing.T) {
 		AssignedTo: "agent-1",
 	}
 
-	modal := NewTaskDetailsModal(task, "", 100, 40)
+	modal := NewTaskDetailsModal(task, nil, "", 100, 40)
 	view := modal.View()
 
 	// Should contain task ID
@@ -96,7 +96,7 @@ func TestTaskDetailsModal_View_NoAssignment(t *testing.T) {
 		Workstream: "frontend",
 	}
 
-	modal := NewTaskDetailsModal(task, "", 100, 40)
+	modal := NewTaskDetailsModal(task, nil, "", 100, 40)
 	view := modal.View()
 
 	// Should indicate no assignment
@@ -105,22 +105,93 @@ func TestTaskDetailsModal_View_NoAssignment(t *testing.T) {
 	}
 }
 
+func TestTaskDetailsModal_View_BlockedReason(t *testing.T) {
+	task := &TaskInfo{
+		ID:            "TASK-003",
+		Title:         "Billing",
+		Status:        "blocked",
+		BlockedReason: "waiting on pricing",
+	}
+
+	view := NewTaskDetailsModal(task, nil, "", 100, 40).View()
+	if !strings.Contains(view, "Blocked:") || !strings.Contains(view, "waiting on pricing") {
+		t.Errorf("expected view to show the blocked reason:\n%s", view)
+	}
+}
+
 func TestPriorityColor(t *testing.T) {
 	tests := []struct {
 		priority string
 		expected string
 	}{
 		{"critical", string(ColorError)},
-		{"high", string(ColorWarning)},
-		{"medium", string(ColorInfo)},
-		{"low", string(ColorSecondary)},
+		{"high", string(ColorOrange)},
+		{"medium", string(ColorWarning)},
+		{"low", string(ColorMuted)},
 		{"unknown", "255"},
 	}
 
 	for _, tt := range tests {
-		color := priorityColor(tt.priority)
+		color := PriorityColor(tt.priority)
 		if string(color) != tt.expected {
-			t.Errorf("priorityColor(%s) = %s, want %s", tt.priority, color, tt.expected)
+			t.Errorf("PriorityColor(%s) = %s, want %s", tt.priority, color, tt.expected)
 		}
 	}
 }
+
+func TestResolveDependencies(t *testing.T) {
+	allTasks := []*TaskInfo{
+		{ID: "TASK-001", Status: "complete"},
+		{ID: "TASK-002", Status: "in_progress"},
+		{ID: "TASK-003", Status: "pending", DependsOn: []string{"TASK-001", "TASK-002", "TASK-404"}},
+		{ID: "TASK-004", Status: "blocked", DependsOn: []string{"TASK-003"}},
+		{ID: "TASK-005", Status: "blocked", DependsOn: []string{"TASK-002", "TASK-003"}},
+	}
+
+	deps, blocks := resolveDependencies(allTasks[2], allTasks)
+
+	want := []DependencyInfo{
+		{ID: "TASK-001", Status: "complete", State: DependencyComplete},
+		{ID: "TASK-002", Status: "in_progress", State: DependencyIncomplete},
+		{ID: "TASK-404", State: DependencyMissing},
+	}
+	if len(deps) != len(want) {
+		t.Fatalf("resolveDependencies() deps = %v, want %v", deps, want)
+	}
+	for i := range want {
+		if deps[i] != want[i] {
+			t.Errorf("deps[%d] = %+v, want %+v", i, deps[i], want[i])
+		}
+	}
+
+	if strings.Join(blocks, ",") != "TASK-004,TASK-005" {
+		t.Errorf("resolveDependencies() blocks = %v, want [TASK-004 TASK-005]", blocks)
+	}
+}
+
+func TestTaskDetailsModal_RenderDependencies(t *testing.T) {
+	allTasks := []*TaskInfo{
+		{ID: "TASK-001", Status: "complete"},
+		{ID: "TASK-002", Status: "pending", DependsOn: []string{"TASK-001", "TASK-404"}},
+		{ID: "TASK-003", Status: "blocked", DependsOn: []string{"TASK-002"}},
+	}
+
+	modal := NewTaskDetailsModal(allTasks[1], allTasks, "", 100, 40)
+	details := modal.renderDetails()
+
+	for _, want := range []string{"Dependencies (1/2 complete)", "TASK-001", "TASK-404", "(missing)", "Blocks:", "TASK-003"} {
+		if !strings.Contains(details, want) {
+			t.Errorf("renderDetails() missing %q:\n%s", want, details)
+		}
+	}
+
+	// A task with no relationships shows neither list
+	modal = NewTaskDetailsModal(&TaskInfo{ID: "TASK-009"}, allTasks, "", 100, 40)
+	details = modal.renderDetails()
+	if !strings.Contains(details, "No dependencies") {
+		t.Error("expected 'No dependencies' for task without dependencies")
+	}
+	if strings.Contains(details, "Blocks:") {
+		t.Error("did not expect 'Blocks:' for task that blocks nothing")
+	}
+}
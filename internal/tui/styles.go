@@ -2,6 +2,9 @@
 package tui
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -61,6 +64,11 @@ var (
 			Background(lipgloss.Color("62")).
 			Padding(0, 1)
 
+	// SearchMatchStyle highlights log search matches.
+	SearchMatchStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("0")).
+				Background(ColorWarning)
+
 	// StatusBarStyle is for the status bar.
 	StatusBarStyle = lipgloss.NewStyle().
 			Foreground(ColorSecondary).
@@ -86,6 +94,10 @@ func AgentStatusIcon(status string) string {
 		return lipgloss.NewStyle().
 			Foreground(ColorError).
 			Render("✗")
+	case "failed-held":
+		return lipgloss.NewStyle().
+			Foreground(ColorOrange).
+			Render("◍")
 	default:
 		return "?"
 	}
@@ -102,6 +114,8 @@ func AgentStatusColor(status string) lipgloss.Color {
 		return ColorSecondary
 	case "error":
 		return ColorError
+	case "failed-held":
+		return ColorOrange
 	default:
 		return lipgloss.Color("255")
 	}
@@ -143,6 +157,41 @@ func TaskStatusIcon(status string) string {
 	}
 }
 
+// PriorityColor returns the color for a task priority.
+func PriorityColor(priority string) lipgloss.Color {
+	switch priority {
+	case "critical":
+		return ColorError
+	case "high":
+		return ColorOrange
+	case "medium":
+		return ColorWarning
+	case "low":
+		return ColorMuted
+	default:
+		return lipgloss.Color("255")
+	}
+}
+
+// priorityLabels are the short priority badges shown in the task list.
+var priorityLabels = map[string]string{
+	"critical": "CRIT",
+	"high":     "HIGH",
+	"medium":   "MED",
+	"low":      "LOW",
+}
+
+// PriorityBadge returns a styled, fixed-width priority badge for the task
+// list. Tasks without a known priority get blank padding, so columns line up.
+func PriorityBadge(priority string) string {
+	style := lipgloss.NewStyle().Width(4)
+	label, ok := priorityLabels[priority]
+	if !ok {
+		return style.Render("")
+	}
+	return style.Foreground(PriorityColor(priority)).Bold(priority == "critical").Render(label)
+}
+
 // TaskStatusColor returns the color for a task status.
 func TaskStatusColor(status string) lipgloss.Color {
 	switch status {
@@ -165,6 +214,20 @@ func TaskStatusColor(status string) lipgloss.Color {
 	}
 }
 
+// FailureCategoryColor returns the color for a task failure category.
+func FailureCategoryColor(category string) lipgloss.Color {
+	switch category {
+	case "container", "claude_not_found":
+		return ColorOrange // Environment problems
+	case "timeout", "max_iterations", "max_turns":
+		return ColorWarning // Ran out of time or attempts
+	case "verify_failed", "no_signal", "missing_files":
+		return ColorInfo
+	default:
+		return ColorError
+	}
+}
+
 // LogLevelColor returns the color for a log level.
 func LogLevelColor(level string) lipgloss.Color {
 	switch level {
@@ -200,3 +263,45 @@ func Truncate(s string, maxLen int) string {
 	}
 	return s[:maxLen-3] + "..."
 }
+
+// ProgressBar renders a percentage as a small bar, e.g. "[██░░░]  40%".
+func ProgressBar(percent int) string {
+	const width = 5
+	percent = min(max(percent, 0), 100)
+	filled := percent * width / 100
+	return fmt.Sprintf("[%s%s] %3d%%", strings.Repeat("█", filled), strings.Repeat("░", width-filled), percent)
+}
+
+// WrapText splits s into rows of at most width runes. It always returns at
+// least one row, so an empty string yields a single empty row.
+func WrapText(s string, width int) []string {
+	width = max(1, width)
+	runes := []rune(s)
+	if len(runes) <= width {
+		return []string{s}
+	}
+
+	rows := make([]string, 0, (len(runes)+width-1)/width)
+	for len(runes) > width {
+		rows = append(rows, string(runes[:width]))
+		runes = runes[width:]
+	}
+	if len(runes) > 0 {
+		rows = append(rows, string(runes))
+	}
+	return rows
+}
+
+// FormatBytes formats a byte count as a human-readable size (e.g. "1.5 MB").
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
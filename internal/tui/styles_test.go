@@ -1,7 +1,11 @@
 package tui
 
 import (
+	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/charmbracelet/lipgloss"
 )
 
 func TestAgentStatusIcon(t *testing.T) {
@@ -13,6 +17,7 @@ func TestAgentStatusIcon(t *testing.T) {
 		{"working", "◐"},
 		{"stopped", "○"},
 		{"error", "✗"},
+		{"failed-held", "◍"},
 		{"unknown", "?"},
 	}
 
@@ -96,6 +101,54 @@ func TestTaskStatusColor(t *testing.T) {
 	}
 }
 
+func TestPriorityBadge(t *testing.T) {
+	tests := []struct {
+		priority string
+		label    string
+	}{
+		{"critical", "CRIT"},
+		{"high", "HIGH"},
+		{"medium", "MED"},
+		{"low", "LOW"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.priority, func(t *testing.T) {
+			badge := PriorityBadge(tt.priority)
+			if got := strings.TrimSpace(badge); got != tt.label {
+				t.Errorf("PriorityBadge(%q) = %q, expected %q", tt.priority, got, tt.label)
+			}
+			if width := lipgloss.Width(badge); width != 4 {
+				t.Errorf("PriorityBadge(%q) width = %d, expected 4", tt.priority, width)
+			}
+		})
+	}
+}
+
+func TestFailureCategoryColor(t *testing.T) {
+	tests := []struct {
+		category string
+		expected lipgloss.Color
+	}{
+		{"container", ColorOrange},
+		{"claude_not_found", ColorOrange},
+		{"timeout", ColorWarning},
+		{"max_iterations", ColorWarning},
+		{"verify_failed", ColorInfo},
+		{"other", ColorError},
+		{"", ColorError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.category, func(t *testing.T) {
+			if color := FailureCategoryColor(tt.category); color != tt.expected {
+				t.Errorf("FailureCategoryColor(%q) = %v, expected %v", tt.category, color, tt.expected)
+			}
+		})
+	}
+}
+
 func TestLogLevelColor(t *testing.T) {
 	tests := []struct {
 		level string
@@ -158,6 +211,69 @@ func TestTruncate(t *testing.T) {
 	}
 }
 
+func TestProgressBar(t *testing.T) {
+	tests := []struct {
+		percent  int
+		expected string
+	}{
+		{0, "[░░░░░]   0%"},
+		{42, "[██░░░]  42%"},
+		{100, "[█████] 100%"},
+		{150, "[█████] 100%"},
+	}
+
+	for _, tt := range tests {
+		if got := ProgressBar(tt.percent); got != tt.expected {
+			t.Errorf("ProgressBar(%d) = %q, expected %q", tt.percent, got, tt.expected)
+		}
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		input    string
+		width    int
+		expected []string
+	}{
+		{"hello", 10, []string{"hello"}},
+		{"", 10, []string{""}},
+		{"hello world", 5, []string{"hello", " worl", "d"}},
+		{"abcdef", 3, []string{"abc", "def"}},
+		{"héllo", 2, []string{"hé", "ll", "o"}},
+		{"abc", 0, []string{"a", "b", "c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := WrapText(tt.input, tt.width)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("WrapText(%q, %d) = %q, expected %q", tt.input, tt.width, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		input    int64
+		expected string
+	}{
+		{0, "0 B"},
+		{512, "512 B"},
+		{1024, "1.0 KB"},
+		{1536, "1.5 KB"},
+		{5 * 1024 * 1024, "5.0 MB"},
+		{3 * 1024 * 1024 * 1024, "3.0 GB"},
+	}
+
+	for _, tt := range tests {
+		result := FormatBytes(tt.input)
+		if result != tt.expected {
+			t.Errorf("FormatBytes(%d) = %q, expected %q", tt.input, result, tt.expected)
+		}
+	}
+}
+
 func TestStylesExist(t *testing.T) {
 	// Verify all style variables are defined and non-nil
 	styles := []struct {
@@ -0,0 +1,48 @@
+package tanuki_test
+
+import (
+	"context"
+	"log"
+
+	"github.com/bkonkle/tanuki/pkg/tanuki"
+)
+
+// Run a single prompt on a new agent.
+func Example() {
+	cfg, err := tanuki.LoadConfig("")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	t, err := tanuki.New(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if _, err := t.Agents().Spawn("auth", tanuki.SpawnOptions{}); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := t.Agents().Run("auth", "Add tests for the login handler", tanuki.RunOptions{Follow: true}); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// Orchestrate all pending tasks until they are complete.
+func ExampleTanuki_NewOrchestrator() {
+	t, err := tanuki.New(nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	orchCfg := tanuki.DefaultOrchestratorConfig()
+	orchCfg.StopWhenComplete = true
+
+	orch := t.NewOrchestrator(orchCfg)
+	if err := orch.Start(context.Background()); err != nil {
+		log.Fatal(err)
+	}
+
+	progress := orch.GetProgress()
+	log.Printf("%d/%d tasks complete", progress.Complete, progress.Total)
+}
@@ -0,0 +1,227 @@
+// Package tanuki is the supported API for embedding Tanuki in other Go programs.
+//
+// It exposes the agent, task, and orchestration managers behind a single
+// entry point, New, which wires the same Git, Docker, state, and executor
+// implementations the tanuki CLI uses. Like the CLI, it operates on the
+// current working directory, which must be inside a Git repository.
+//
+// The types here are aliases of Tanuki's internal types, so values returned
+// by this package can be used with any of their methods. Packages under
+// internal/ remain unsupported and may change without notice.
+package tanuki
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/bkonkle/tanuki/internal/agent"
+	"github.com/bkonkle/tanuki/internal/config"
+	"github.com/bkonkle/tanuki/internal/docker"
+	"github.com/bkonkle/tanuki/internal/executor"
+	"github.com/bkonkle/tanuki/internal/git"
+	"github.com/bkonkle/tanuki/internal/project"
+	"github.com/bkonkle/tanuki/internal/state"
+	"github.com/bkonkle/tanuki/internal/task"
+)
+
+// Configuration types.
+type (
+	// Config is the tanuki.yaml configuration.
+	Config = config.Config
+	// WorkstreamConfig configures a single workstream.
+	WorkstreamConfig = config.WorkstreamConfig
+)
+
+// Agent types.
+type (
+	// Agent is a Claude Code agent running in its own container and worktree.
+	Agent = agent.Agent
+	// AgentManager spawns, runs, and removes agents.
+	AgentManager = agent.Manager
+	// SpawnOptions configures agent creation.
+	SpawnOptions = agent.SpawnOptions
+	// RunOptions configures prompt execution on an agent.
+	RunOptions = agent.RunOptions
+	// RemoveOptions configures agent removal.
+	RemoveOptions = agent.RemoveOptions
+	// GitManager is the worktree backend used by an AgentManager.
+	GitManager = agent.GitManager
+	// DockerManager is the container backend used by an AgentManager.
+	DockerManager = agent.DockerManager
+	// StateManager persists agent state for an AgentManager.
+	StateManager = agent.StateManager
+	// ClaudeExecutor runs Claude Code inside agent containers.
+	ClaudeExecutor = agent.ClaudeExecutor
+)
+
+// Task types.
+type (
+	// Task is a unit of work defined by a markdown file with YAML front matter.
+	Task = task.Task
+	// TaskStatus is the lifecycle state of a task.
+	TaskStatus = task.Status
+	// TaskManager scans, queries, and updates task files.
+	TaskManager = task.Manager
+	// TaskManagerConfig configures a TaskManager.
+	TaskManagerConfig = task.Config
+	// TaskStore loads and saves tasks for a TaskManager. Set
+	// TaskManagerConfig.Store to source tasks from somewhere other than
+	// markdown files.
+	TaskStore = task.TaskStore
+	// TaskParseError is a task a TaskStore couldn't load.
+	TaskParseError = task.ParseError
+	// FileTaskStore is the default TaskStore, over a directory of task files.
+	FileTaskStore = task.FileStore
+	// TaskChange describes tasks added, updated, or removed.
+	TaskChange = task.TaskChange
+	// TaskEvent is a task lifecycle event emitted by an Orchestrator.
+	TaskEvent = task.Event
+)
+
+// Orchestration types.
+type (
+	// Orchestrator assigns pending tasks to idle agents until work is done.
+	Orchestrator = project.Orchestrator
+	// OrchestratorConfig configures an Orchestrator.
+	OrchestratorConfig = project.OrchestratorConfig
+	// Progress summarizes task completion for an Orchestrator.
+	Progress = project.Progress
+)
+
+// DefaultConfig returns the built-in configuration used when no tanuki.yaml exists.
+func DefaultConfig() *Config {
+	return config.DefaultConfig()
+}
+
+// LoadConfig loads configuration the same way the CLI does. An empty path
+// searches ./tanuki.yaml, ./.tanuki/config/tanuki.yaml, and the global config;
+// otherwise only the given file is loaded.
+func LoadConfig(path string) (*Config, error) {
+	loader := config.NewLoader()
+	if path != "" {
+		return loader.LoadFromPath(path)
+	}
+	return loader.Load()
+}
+
+// DefaultOrchestratorConfig returns sensible orchestrator defaults. Unlike
+// the CLI, it leaves HandleSignals off, so an embedding program keeps control
+// of SIGINT and SIGTERM; cancel the context passed to Start to shut down.
+func DefaultOrchestratorConfig() OrchestratorConfig {
+	cfg := project.DefaultOrchestratorConfig()
+	cfg.HandleSignals = false
+	return cfg
+}
+
+// NewAgentManager creates an AgentManager from explicit backends, for callers
+// that supply their own implementations. Most callers should use New.
+func NewAgentManager(cfg *Config, git GitManager, docker DockerManager, state StateManager, exec ClaudeExecutor) (*AgentManager, error) {
+	return agent.NewManager(cfg, git, docker, state, exec)
+}
+
+// NewTaskManager creates a TaskManager for the tasks under cfg.ProjectRoot.
+func NewTaskManager(cfg *TaskManagerConfig) *TaskManager {
+	return task.NewManager(cfg)
+}
+
+// NewFileTaskStore returns the default TaskStore for the tasks directory dir,
+// also reading tasks from any extra directories.
+func NewFileTaskStore(dir string, extra ...string) *FileTaskStore {
+	return task.NewFileStore(dir, extra...)
+}
+
+// NewOrchestrator creates an Orchestrator that runs tasks from taskMgr on
+// agents from agentMgr. A task isn't assigned until its depends_on tasks are
+// complete, and Start fails on a dependency cycle.
+func NewOrchestrator(taskMgr *TaskManager, agentMgr *AgentManager, cfg OrchestratorConfig) *Orchestrator {
+	orch := project.NewOrchestrator(project.NewTaskManagerAdapter(taskMgr), agentMgr, task.NewQueue(), cfg)
+	orch.SetResolver(project.NewDependencyResolver(taskMgr))
+	orch.SetRunner(project.NewAgentTaskRunnerWithOptions(taskMgr, agentMgr, project.AgentTaskRunnerOptions{
+		SkipVerify:    cfg.SkipVerify,
+		ResumeRetries: cfg.ResumeRetries,
+	}))
+	return orch
+}
+
+// Tanuki bundles the managers needed to run agents and tasks in a repository.
+type Tanuki struct {
+	config *Config
+	agents *AgentManager
+	tasks  *TaskManager
+}
+
+// New wires Tanuki's real Git, Docker, state, and executor implementations
+// for the repository containing the current working directory. A nil cfg
+// uses DefaultConfig.
+func New(cfg *Config) (*Tanuki, error) {
+	if cfg == nil {
+		cfg = DefaultConfig()
+	}
+
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("get working directory: %w", err)
+	}
+
+	gitMgr, err := git.NewManager(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("create git manager: %w", err)
+	}
+
+	dockerMgr, err := docker.NewManager(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("create docker manager: %w", err)
+	}
+
+	stateMgr, err := state.NewFileStateManager(state.DefaultStatePath(), dockerMgr)
+	if err != nil {
+		return nil, fmt.Errorf("create state manager: %w", err)
+	}
+
+	backend, err := executor.NewBackend(cfg.Executor.Backend, cfg.Executor.Command)
+	if err != nil {
+		return nil, fmt.Errorf("create executor: %w", err)
+	}
+
+	agentMgr, err := agent.NewManager(cfg, gitMgr, dockerMgr, stateMgr, executor.NewBackendExecutor(dockerMgr, backend))
+	if err != nil {
+		return nil, fmt.Errorf("create agent manager: %w", err)
+	}
+
+	return &Tanuki{
+		config: cfg,
+		agents: agentMgr,
+		tasks: task.NewManager(&task.Config{
+			ProjectRoot:          projectRoot,
+			TasksDir:             cfg.TasksDir,
+			ExtraTaskDirs:        cfg.ExtraTaskDirs,
+			MaxAssignmentHistory: cfg.MaxAssignmentHistory,
+		}),
+	}, nil
+}
+
+// Config returns the configuration Tanuki was created with.
+func (t *Tanuki) Config() *Config {
+	return t.config
+}
+
+// Agents returns the agent manager.
+func (t *Tanuki) Agents() *AgentManager {
+	return t.agents
+}
+
+// Tasks returns the task manager. Call Scan before querying tasks.
+func (t *Tanuki) Tasks() *TaskManager {
+	return t.tasks
+}
+
+// NewOrchestrator creates an Orchestrator over this instance's tasks and
+// agents, with the config's orchestration settings applied as the dashboard
+// and `tanuki project start` apply them: skip_verify,
+// keep_container_on_failure, restart_on_task_change, resume_retries,
+// group_failure, and per-workstream concurrency, task concurrency, and retry
+// limits. Limits already set in cfg take precedence.
+func (t *Tanuki) NewOrchestrator(cfg OrchestratorConfig) *Orchestrator {
+	cfg.ApplyConfig(t.config)
+	return NewOrchestrator(t.tasks, t.agents, cfg)
+}
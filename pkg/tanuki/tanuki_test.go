@@ -0,0 +1,176 @@
+package tanuki
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bkonkle/tanuki/internal/task"
+)
+
+func TestLoadConfig_Path(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tanuki.yaml")
+	if err := os.WriteFile(path, []byte("version: \"1\"\ndefaults:\n  max_turns: 12\n"), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error: %v", err)
+	}
+	if cfg.Defaults.MaxTurns != 12 {
+		t.Errorf("MaxTurns = %d, want 12", cfg.Defaults.MaxTurns)
+	}
+
+	if _, err := LoadConfig(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("LoadConfig() with a missing file should error")
+	}
+}
+
+func TestTanuki_NewOrchestrator_AppliesWorkstreamLimits(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Workstreams = map[string]*WorkstreamConfig{
+		"api": {Concurrency: 3, MaxRetries: 2},
+		"ui":  {Concurrency: 2},
+	}
+	tk := &Tanuki{config: cfg, tasks: NewTaskManager(&TaskManagerConfig{ProjectRoot: t.TempDir()})}
+
+	orchCfg := DefaultOrchestratorConfig()
+	orchCfg.WorkstreamConcurrency["ui"] = 5
+
+	orch := tk.NewOrchestrator(orchCfg)
+	if orch == nil {
+		t.Fatal("NewOrchestrator() returned nil")
+	}
+
+	if len(orchCfg.WorkstreamConcurrency) != 1 {
+		t.Errorf("caller's concurrency map was modified: %v", orchCfg.WorkstreamConcurrency)
+	}
+	sched := orch.GetWorkstreamScheduler()
+	if got := sched.GetWorkstreamConcurrency("api"); got != 3 {
+		t.Errorf("api concurrency = %d, want 3 from config", got)
+	}
+	if got := sched.GetWorkstreamConcurrency("ui"); got != 5 {
+		t.Errorf("ui concurrency = %d, want 5 from orchestrator config", got)
+	}
+}
+
+func TestDefaultOrchestratorConfig_NoSignalHandler(t *testing.T) {
+	if DefaultOrchestratorConfig().HandleSignals {
+		t.Error("HandleSignals should default to false for embedders")
+	}
+}
+
+// stubDocker supports only what NewAgentManager needs.
+type stubDocker struct{ DockerManager }
+
+func (stubDocker) EnsureNetwork(string) error { return nil }
+
+// stubState lists a fixed set of agents.
+type stubState struct {
+	StateManager
+	agents []*Agent
+}
+
+func (s stubState) ListAgents() ([]*Agent, error) { return s.agents, nil }
+
+// completingRunner completes each task it runs, noting any task started
+// before its dependencies were complete.
+type completingRunner struct {
+	taskMgr *TaskManager
+
+	mu    sync.Mutex
+	ran   []string
+	early []string
+}
+
+func (r *completingRunner) RunTask(_ context.Context, taskID, _ string) error {
+	t, err := r.taskMgr.Get(taskID)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	for _, dep := range t.DependsOn {
+		if d, err := r.taskMgr.Get(dep); err != nil || d.Status != task.StatusComplete {
+			r.early = append(r.early, taskID)
+		}
+	}
+	r.ran = append(r.ran, taskID)
+	r.mu.Unlock()
+
+	return r.taskMgr.UpdateStatus(taskID, task.StatusComplete)
+}
+
+// newDependencyTestManagers returns managers over task files with the given
+// dependencies, keyed by task ID, and two idle backend agents.
+func newDependencyTestManagers(t *testing.T, deps map[string]string) (*TaskManager, *AgentManager) {
+	t.Helper()
+
+	dir := t.TempDir()
+	tasksDir := filepath.Join(dir, "tasks")
+	if err := os.MkdirAll(tasksDir, 0750); err != nil {
+		t.Fatal(err)
+	}
+	for id, dep := range deps {
+		content := "---\nid: " + id + "\ntitle: Test\nworkstream: backend\nstatus: pending\ndepends_on: [" + dep + "]\n---\n"
+		if err := os.WriteFile(filepath.Join(tasksDir, id+".md"), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	state := stubState{agents: []*Agent{
+		{Name: "be-1", Workstream: "backend", Status: "idle"},
+		{Name: "be-2", Workstream: "backend", Status: "idle"},
+	}}
+	agentMgr, err := NewAgentManager(DefaultConfig(), struct{ GitManager }{}, stubDocker{}, state, struct{ ClaudeExecutor }{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewTaskManager(&TaskManagerConfig{ProjectRoot: dir}), agentMgr
+}
+
+func TestNewOrchestrator_DependentTaskWaits(t *testing.T) {
+	taskMgr, agentMgr := newDependencyTestManagers(t, map[string]string{"T1": "", "T2": "T1"})
+
+	cfg := DefaultOrchestratorConfig()
+	cfg.AutoSpawnAgents = false
+	cfg.StopWhenComplete = true
+	cfg.PollInterval = 10 * time.Millisecond
+	cfg.WorkstreamConcurrency["backend"] = 2
+
+	orch := NewOrchestrator(taskMgr, agentMgr, cfg)
+	runner := &completingRunner{taskMgr: taskMgr}
+	orch.SetRunner(runner)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := orch.Start(ctx); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+
+	runner.mu.Lock()
+	defer runner.mu.Unlock()
+	if len(runner.ran) != 2 || runner.ran[0] != "T1" {
+		t.Errorf("ran %v, want T1 then T2", runner.ran)
+	}
+	if len(runner.early) != 0 {
+		t.Errorf("%v started before their dependencies completed", runner.early)
+	}
+}
+
+func TestNewOrchestrator_DependencyCycle(t *testing.T) {
+	taskMgr, agentMgr := newDependencyTestManagers(t, map[string]string{"T1": "T2", "T2": "T1"})
+
+	cfg := DefaultOrchestratorConfig()
+	cfg.AutoSpawnAgents = false
+
+	err := NewOrchestrator(taskMgr, agentMgr, cfg).Start(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "dependency cycle") {
+		t.Errorf("Start() error = %v, want a dependency cycle error", err)
+	}
+}